@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nais/teams-backend/pkg/auditchain"
+	"github.com/nais/teams-backend/pkg/config"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
+)
+
+// Verify the audit log hash chain. Exits with status 1 if the chain is broken.
+func main() {
+	cfg, err := config.New()
+	if err != nil {
+		fmt.Printf("fatal: %s", err)
+		os.Exit(2)
+	}
+
+	log, err := logger.GetLogger(cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		fmt.Printf("fatal: %s", err)
+		os.Exit(2)
+	}
+
+	result, err := run(cfg)
+	if err != nil {
+		log.WithError(err).Error("fatal error in run()")
+		os.Exit(3)
+	}
+
+	if !result.Valid() {
+		link := result.BrokenLink
+		entry := log.
+			WithField("chain_position", link.ChainPosition).
+			WithField("verified_entries", result.VerifiedEntries)
+		if link.AuditLogID != nil {
+			entry = entry.WithField("audit_log_id", link.AuditLogID)
+		}
+		entry.Errorf("audit log chain is broken: %s", link.Reason)
+		os.Exit(1)
+	}
+
+	log.Infof("audit log chain is valid, %d entries verified", result.VerifiedEntries)
+}
+
+func run(cfg *config.Config) (*auditchain.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	database, err := db.New(ctx, cfg.DatabaseURL, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return auditchain.Verify(ctx, database)
+}
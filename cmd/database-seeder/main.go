@@ -14,8 +14,8 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
-	"github.com/nais/teams-backend/pkg/sqlc"
 	"golang.org/x/text/runes"
 	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
@@ -84,7 +84,7 @@ func run(cfg *seedConfig, log logger.Logger) error {
 	}
 	numLastNames := len(lastNames)
 
-	database, err := db.New(ctx, cfg.DatabaseURL)
+	database, err := db.New(ctx, cfg.DatabaseURL, false)
 	if err != nil {
 		return err
 	}
@@ -152,14 +152,14 @@ func run(cfg *seedConfig, log logger.Logger) error {
 			}
 
 			for o := 0; o < *cfg.NumOwnersPerTeam; o++ {
-				err = dbtx.SetTeamMemberRole(ctx, users[rand.Intn(usersCreated)].ID, team.Slug, sqlc.RoleNameTeamowner)
+				err = dbtx.SetTeamMemberRole(ctx, users[rand.Intn(usersCreated)].ID, team.Slug, roles.RoleNameTeamowner)
 				if err != nil {
 					return err
 				}
 			}
 
 			for o := 0; o < *cfg.NumMembersPerTeam; o++ {
-				err = dbtx.SetTeamMemberRole(ctx, users[rand.Intn(usersCreated)].ID, team.Slug, sqlc.RoleNameTeammember)
+				err = dbtx.SetTeamMemberRole(ctx, users[rand.Intn(usersCreated)].ID, team.Slug, roles.RoleNameTeammember)
 				if err != nil {
 					return err
 				}
@@ -13,11 +13,16 @@ import (
 
 	graphql_handler "github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/auditretention"
+	"github.com/nais/teams-backend/pkg/auditsink"
 	"github.com/nais/teams-backend/pkg/authn"
 	"github.com/nais/teams-backend/pkg/config"
 	"github.com/nais/teams-backend/pkg/db"
@@ -28,12 +33,15 @@ import (
 	"github.com/nais/teams-backend/pkg/graph/apierror"
 	"github.com/nais/teams-backend/pkg/graph/dataloader"
 	"github.com/nais/teams-backend/pkg/graph/generated"
+	"github.com/nais/teams-backend/pkg/leaderelection"
 	"github.com/nais/teams-backend/pkg/logger"
 	"github.com/nais/teams-backend/pkg/middleware"
+	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/nais/teams-backend/pkg/teamsync"
 	"github.com/nais/teams-backend/pkg/types"
 	"github.com/nais/teams-backend/pkg/usersync"
 	"github.com/nais/teams-backend/pkg/version"
+	"github.com/nais/teams-backend/pkg/webhooks"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -71,7 +79,7 @@ func run(cfg *config.Config, log logger.Logger) error {
 	bt, _ := version.BuildTime()
 	log.Infof("teams-backend version %s built on %s", version.Version(), bt)
 
-	database, err := db.New(ctx, cfg.DatabaseURL)
+	database, err := db.New(ctx, cfg.DatabaseURL, cfg.AuditSink.Enabled())
 	if err != nil {
 		return err
 	}
@@ -98,7 +106,9 @@ func run(cfg *config.Config, log logger.Logger) error {
 	}
 
 	wg := sync.WaitGroup{}
-	teamSync := teamsync.NewHandler(ctx, database, cfg, log)
+	webhookPublisher := webhooks.NewPublisher(database, log)
+	teamSyncProgress := teamsync.NewProgress(database, log)
+	teamSync := teamsync.NewHandler(ctx, database, cfg, webhookPublisher, teamSyncProgress, log)
 	defer func() {
 		teamSync.Close()
 		wg.Wait()
@@ -116,16 +126,57 @@ func run(cfg *config.Config, log logger.Logger) error {
 		}(ctx)
 	}
 
+	// only the leader schedules full team syncs and user syncs, but all instances process the team sync queue
+	leaderElector := leaderelection.New(database, cfg.LeaderLeaseDuration, log)
+	leaderElectionDone := make(chan struct{})
+	go func() {
+		defer close(leaderElectionDone)
+		leaderElector.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-leaderElectionDone
+	}()
+
+	// all instances deliver audit log entries from the outbox to the configured sinks
+	if cfg.AuditSink.Enabled() {
+		auditSinkDispatcher, err := auditsink.NewFromConfig(ctx, cfg, database, log)
+		if err != nil {
+			return err
+		}
+		go auditSinkDispatcher.Run(ctx)
+	} else {
+		// entries left in the outbox when the sinks were disabled would never be delivered or pruned
+		discarded, err := database.DeleteAuditLogOutboxEntries(ctx)
+		if err != nil {
+			return err
+		}
+		if discarded > 0 {
+			log.Warnf("discarded %d audit log outbox entries, as no audit log sinks are enabled", discarded)
+		}
+	}
+
+	// all instances send pending webhook deliveries to the subscribers
+	go webhooks.NewDispatcher(database, nil, log).Run(ctx)
+
+	// only the leader prunes expired audit log entries
+	go auditretention.NewFromConfig(cfg, database, log).Run(ctx, cfg.AuditLogRetention.Interval, leaderElector.IsLeader)
+
+	// only the leader deletes teams when the grace period of a confirmed deletion has passed
+	go teamSync.RunTeamDeletions(ctx, cfg.TeamDeletion.Interval, leaderElector.IsLeader)
+
 	fullTeamSyncTimer := time.NewTimer(time.Second * 1)
 	go teamSync.UpdateMetrics(ctx)
 
+	// all instances pass team sync progress events from any instance on to their subscribers
+	go teamSyncProgress.Run(ctx)
+
 	var userSyncer *usersync.UserSynchronizer
 	userSync := make(chan uuid.UUID, 1)
 	userSyncTimer := time.NewTimer(10 * time.Second)
 	userSyncTimer.Stop()
-	userSyncRuns := usersync.NewRunsHandler(cfg.UserSync.RunsToStore)
 	if cfg.UserSync.Enabled {
-		userSyncer, err = usersync.NewFromConfig(cfg, database, log, userSyncRuns)
+		userSyncer, err = usersync.NewFromConfig(cfg, database, log)
 		if err != nil {
 			return err
 		}
@@ -143,7 +194,7 @@ func run(cfg *config.Config, log logger.Logger) error {
 		log.Warnf("Deploy proxy is not configured: %v", err)
 	}
 
-	handler := setupGraphAPI(teamSync, database, deployProxy, cfg.TenantDomain, userSync, cfg.Environments, log, userSyncRuns)
+	handler := setupGraphAPI(teamSync, database, deployProxy, cfg.TenantDomain, userSync, cfg.Environments, webhookPublisher, cfg.FrontendURL, log)
 	srv := setupHTTPServer(cfg, database, handler, authHandler)
 
 	log.Infof("ready to accept requests at %s.", cfg.ListenAddress)
@@ -190,6 +241,11 @@ func run(cfg *config.Config, log logger.Logger) error {
 			log.Debugf("user sync complete")
 
 		case <-userSyncTimer.C:
+			if !leaderElector.IsLeader() {
+				userSyncTimer.Reset(cfg.LeaderLeaseDuration)
+				break
+			}
+
 			nextUserSync := time.Now().Add(userSyncInterval)
 			userSyncTimer.Reset(userSyncInterval)
 			log.Debugf("scheduled user sync triggered; next run at %s", nextUserSync)
@@ -203,6 +259,11 @@ func run(cfg *config.Config, log logger.Logger) error {
 			userSync <- correlationID
 
 		case <-fullTeamSyncTimer.C:
+			if !leaderElector.IsLeader() {
+				fullTeamSyncTimer.Reset(cfg.LeaderLeaseDuration)
+				break
+			}
+
 			log.Infof("start full team sync")
 
 			correlationID, err := uuid.NewUUID()
@@ -212,7 +273,7 @@ func run(cfg *config.Config, log logger.Logger) error {
 				break
 			}
 
-			teams, err := teamSync.ScheduleAllTeams(ctx, correlationID)
+			teams, err := teamSync.ScheduleAllTeams(ctx, correlationID, sqlc.TeamSyncTriggerSchedule)
 			if err != nil {
 				log.WithError(err).Errorf("full team sync")
 				fullTeamSyncTimer.Reset(time.Second * 1)
@@ -237,8 +298,9 @@ func setupAuthHandler(cfg *config.Config, database db.Database, log logger.Logge
 	return handler, nil
 }
 
-func setupGraphAPI(teamSync teamsync.Handler, database db.Database, deployProxy deployproxy.Proxy, domain string, userSync chan<- uuid.UUID, gcpEnvironments []string, log logger.Logger, userSyncRuns *usersync.RunsHandler) *graphql_handler.Server {
-	resolver := graph.NewResolver(teamSync, database, deployProxy, domain, userSync, auditlogger.New(database, types.ComponentNameGraphqlApi, log), gcpEnvironments, log, userSyncRuns)
+func setupGraphAPI(teamSync teamsync.Handler, database db.Database, deployProxy deployproxy.Proxy, domain string, userSync chan<- uuid.UUID, gcpEnvironments []string, webhookPublisher webhooks.Publisher, frontendURL string, log logger.Logger) *graphql_handler.Server {
+	auditLogger := webhooks.NewAuditLogger(auditlogger.New(database, types.ComponentNameGraphqlApi, log), webhookPublisher)
+	resolver := graph.NewResolver(teamSync, database, deployProxy, domain, userSync, auditLogger, gcpEnvironments, log)
 	gc := generated.Config{}
 	gc.Resolvers = resolver
 	gc.Directives.Admin = directives.Admin()
@@ -250,17 +312,48 @@ func setupGraphAPI(teamSync teamsync.Handler, database db.Database, deployProxy
 		return 10 * childComplexity
 	}
 
-	handler := graphql_handler.NewDefaultServer(
+	handler := graphql_handler.New(
 		generated.NewExecutableSchema(
 			gc,
 		),
 	)
+	handler.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: websocketOriginChecker(frontendURL),
+		},
+	})
+	handler.AddTransport(transport.Options{})
+	handler.AddTransport(transport.POST{})
+	handler.AddTransport(transport.MultipartForm{})
+	handler.SetQueryCache(lru.New(1000))
+	handler.Use(extension.Introspection{})
+	handler.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New(100),
+	})
 	handler.SetErrorPresenter(apierror.GetErrorPresenter(log))
 	handler.Use(extension.FixedComplexityLimit(1000))
 
 	return handler
 }
 
+// websocketOriginChecker Allow websocket connections from the frontend and from the same host as the API, for instance
+// the GraphQL playground. Clients that are not browsers do not send an origin, and are always allowed.
+func websocketOriginChecker(frontendURL string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || origin == frontendURL {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return u.Host == r.Host
+	}
+}
+
 func corsConfig(frontendUrl string) cors.Options {
 	return cors.Options{
 		AllowedOrigins:   []string{frontendUrl},
@@ -295,6 +388,9 @@ func setupHTTPServer(cfg *config.Config, database db.Database, graphApi *graphql
 	r.Route("/query", func(r chi.Router) {
 		r.Use(middlewares...)
 		r.Post("/", graphApi.ServeHTTP)
+		// websocket connections for subscriptions. Queries are not served over GET, as GET requests are not protected
+		// against CSRF by the cookie based authentication.
+		r.Get("/", graphApi.ServeHTTP)
 	})
 
 	r.Route("/oauth2", func(r chi.Router) {
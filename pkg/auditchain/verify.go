@@ -0,0 +1,213 @@
+package auditchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+)
+
+// verifyBatchSize The number of entries and pruned links read from the database at once
+const verifyBatchSize = 1000
+
+// Result The result of verifying the audit log hash chain
+type Result struct {
+	// VerifiedEntries The number of links in the chain that were verified before the first broken link
+	VerifiedEntries int64
+
+	// BrokenLink The first broken link in the chain, if any
+	BrokenLink *BrokenLink
+}
+
+// Valid Check if the whole chain was verified without finding any broken links
+func (r *Result) Valid() bool {
+	return r.BrokenLink == nil
+}
+
+// BrokenLink A link in the audit log hash chain that does not match the rest of the chain
+type BrokenLink struct {
+	// ChainPosition The position of the link in the chain
+	ChainPosition int64
+
+	// AuditLogID The ID of the audit log entry at the position, if it still exists
+	AuditLogID *uuid.UUID
+
+	// Reason A description of what is wrong with the link
+	Reason string
+}
+
+// link An entry in the chain, either an existing audit log entry or a link of an entry removed by the retention policy
+type link struct {
+	position     int64
+	previousHash *string
+	hash         string
+	entry        *db.AuditLog
+}
+
+// Verify Walk the audit log hash chain from the start, and check that every link contains the hash of the previous
+// link, and that the hash of every existing entry matches its content. Entries removed by the retention policy can not
+// be verified themselves, but their links are kept so that the rest of the chain can be verified.
+func Verify(ctx context.Context, database db.Database) (*Result, error) {
+	result := &Result{}
+	entries := newEntryReader(database)
+	prunedLinks := newPrunedLinkReader(database)
+
+	var previous *link
+	for {
+		entry, err := entries.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		prunedLink, err := prunedLinks.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var current *link
+		switch {
+		case entry == nil && prunedLink == nil:
+			return result, nil
+		case prunedLink == nil || (entry != nil && entry.position < prunedLink.position):
+			current = entries.next()
+		default:
+			current = prunedLinks.next()
+		}
+
+		if brokenLink := verifyLink(previous, current); brokenLink != nil {
+			result.BrokenLink = brokenLink
+			return result, nil
+		}
+
+		result.VerifiedEntries++
+		previous = current
+	}
+}
+
+func verifyLink(previous, current *link) *BrokenLink {
+	brokenLink := func(position int64, entry *db.AuditLog, reason string, args ...interface{}) *BrokenLink {
+		var auditLogID *uuid.UUID
+		if entry != nil {
+			auditLogID = &entry.ID
+		}
+
+		return &BrokenLink{
+			ChainPosition: position,
+			AuditLogID:    auditLogID,
+			Reason:        fmt.Sprintf(reason, args...),
+		}
+	}
+
+	expectedPosition := int64(1)
+	var expectedPreviousHash *string
+	if previous != nil {
+		expectedPosition = previous.position + 1
+		expectedPreviousHash = &previous.hash
+	}
+
+	if current.position > expectedPosition {
+		return brokenLink(expectedPosition, nil, "the entry has been removed from the chain")
+	}
+
+	if current.position < expectedPosition {
+		return brokenLink(current.position, current.entry, "the position is used by more than one entry")
+	}
+
+	if !equalHashes(expectedPreviousHash, current.previousHash) {
+		return brokenLink(current.position, current.entry, "the entry does not contain the hash of the previous entry")
+	}
+
+	if current.entry != nil && db.AuditLogHash(current.entry.AuditLog) != current.hash {
+		return brokenLink(current.position, current.entry, "the content of the entry has been modified")
+	}
+
+	return nil
+}
+
+func equalHashes(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// linkReader Reads links in chain order, one batch at a time
+type linkReader struct {
+	fetch  func(ctx context.Context, afterPosition int64) ([]*link, error)
+	buffer []*link
+	after  int64
+	done   bool
+}
+
+// peek Get the next link without consuming it. Returns nil when there are no more links.
+func (r *linkReader) peek(ctx context.Context) (*link, error) {
+	if len(r.buffer) == 0 && !r.done {
+		links, err := r.fetch(ctx, r.after)
+		if err != nil {
+			return nil, err
+		}
+
+		r.done = len(links) < verifyBatchSize
+		r.buffer = links
+		if len(links) > 0 {
+			r.after = links[len(links)-1].position
+		}
+	}
+
+	if len(r.buffer) == 0 {
+		return nil, nil
+	}
+
+	return r.buffer[0], nil
+}
+
+// next Consume the link returned by the last call to peek
+func (r *linkReader) next() *link {
+	current := r.buffer[0]
+	r.buffer = r.buffer[1:]
+	return current
+}
+
+func newEntryReader(database db.Database) *linkReader {
+	return &linkReader{
+		fetch: func(ctx context.Context, afterPosition int64) ([]*link, error) {
+			entries, err := database.GetAuditLogChain(ctx, afterPosition, verifyBatchSize)
+			if err != nil {
+				return nil, fmt.Errorf("get audit log chain: %w", err)
+			}
+
+			links := make([]*link, len(entries))
+			for i, entry := range entries {
+				links[i] = &link{
+					position:     *entry.ChainPosition,
+					previousHash: entry.PreviousHash,
+					hash:         *entry.Hash,
+					entry:        entry,
+				}
+			}
+			return links, nil
+		},
+	}
+}
+
+func newPrunedLinkReader(database db.Database) *linkReader {
+	return &linkReader{
+		fetch: func(ctx context.Context, afterPosition int64) ([]*link, error) {
+			prunedLinks, err := database.GetAuditLogPrunedLinks(ctx, afterPosition, verifyBatchSize)
+			if err != nil {
+				return nil, fmt.Errorf("get pruned audit log links: %w", err)
+			}
+
+			links := make([]*link, len(prunedLinks))
+			for i, prunedLink := range prunedLinks {
+				links[i] = &link{
+					position:     prunedLink.ChainPosition,
+					previousHash: prunedLink.PreviousHash,
+					hash:         prunedLink.Hash,
+				}
+			}
+			return links, nil
+		},
+	}
+}
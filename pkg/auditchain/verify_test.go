@@ -0,0 +1,153 @@
+package auditchain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditchain"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+// chain Create a valid hash chain with the given number of entries
+func chain(numEntries int) []*db.AuditLog {
+	entries := make([]*db.AuditLog, 0, numEntries)
+	var previousHash *string
+	for i := 1; i <= numEntries; i++ {
+		position := int64(i)
+		entry := &sqlc.AuditLog{
+			ID:               uuid.New(),
+			CreatedAt:        time.Date(2023, 1, 1, 0, 0, i, 0, time.UTC),
+			CorrelationID:    uuid.New(),
+			ComponentName:    "graphql-api",
+			Action:           "graphql-api:teams:update",
+			TargetType:       "team",
+			TargetIdentifier: "some-team",
+			Message:          "Team updated",
+			ChainPosition:    &position,
+			PreviousHash:     previousHash,
+		}
+		hash := db.AuditLogHash(entry)
+		entry.Hash = &hash
+		previousHash = &hash
+		entries = append(entries, &db.AuditLog{AuditLog: entry})
+	}
+	return entries
+}
+
+// prune Turn entries into pruned links
+func prune(entries ...*db.AuditLog) []*db.AuditLogPrunedLink {
+	links := make([]*db.AuditLogPrunedLink, 0, len(entries))
+	for _, entry := range entries {
+		links = append(links, &db.AuditLogPrunedLink{AuditLogPrunedLink: &sqlc.AuditLogPrunedLink{
+			ChainPosition: *entry.ChainPosition,
+			PreviousHash:  entry.PreviousHash,
+			Hash:          *entry.Hash,
+		}})
+	}
+	return links
+}
+
+func mockDatabase(t *testing.T, entries []*db.AuditLog, prunedLinks []*db.AuditLogPrunedLink) *db.MockDatabase {
+	database := db.NewMockDatabase(t)
+	database.
+		On("GetAuditLogChain", ctx, int64(0), 1000).
+		Return(entries, nil).
+		Once()
+	database.
+		On("GetAuditLogPrunedLinks", ctx, int64(0), 1000).
+		Return(prunedLinks, nil).
+		Once()
+	return database
+}
+
+var ctx = context.Background()
+
+func TestVerify(t *testing.T) {
+	t.Run("empty chain", func(t *testing.T) {
+		result, err := auditchain.Verify(ctx, mockDatabase(t, []*db.AuditLog{}, []*db.AuditLogPrunedLink{}))
+		assert.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Equal(t, int64(0), result.VerifiedEntries)
+	})
+
+	t.Run("valid chain with pruned entries", func(t *testing.T) {
+		entries := chain(5)
+		database := mockDatabase(t, []*db.AuditLog{entries[1], entries[3]}, prune(entries[0], entries[2], entries[4]))
+
+		result, err := auditchain.Verify(ctx, database)
+		assert.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Equal(t, int64(5), result.VerifiedEntries)
+	})
+
+	t.Run("modified entry", func(t *testing.T) {
+		entries := chain(3)
+		entries[1].Message = "Team deleted"
+
+		result, err := auditchain.Verify(ctx, mockDatabase(t, entries, []*db.AuditLogPrunedLink{}))
+		assert.NoError(t, err)
+		assert.False(t, result.Valid())
+		assert.Equal(t, int64(1), result.VerifiedEntries)
+		assert.Equal(t, int64(2), result.BrokenLink.ChainPosition)
+		assert.Equal(t, &entries[1].ID, result.BrokenLink.AuditLogID)
+		assert.Equal(t, "the content of the entry has been modified", result.BrokenLink.Reason)
+	})
+
+	t.Run("removed entry", func(t *testing.T) {
+		entries := chain(3)
+
+		result, err := auditchain.Verify(ctx, mockDatabase(t, []*db.AuditLog{entries[0], entries[2]}, []*db.AuditLogPrunedLink{}))
+		assert.NoError(t, err)
+		assert.False(t, result.Valid())
+		assert.Equal(t, int64(2), result.BrokenLink.ChainPosition)
+		assert.Nil(t, result.BrokenLink.AuditLogID)
+		assert.Equal(t, "the entry has been removed from the chain", result.BrokenLink.Reason)
+	})
+
+	t.Run("entry replaced with a new chain", func(t *testing.T) {
+		entries := chain(3)
+		other := chain(3)
+
+		result, err := auditchain.Verify(ctx, mockDatabase(t, []*db.AuditLog{entries[0], other[1], entries[2]}, []*db.AuditLogPrunedLink{}))
+		assert.NoError(t, err)
+		assert.False(t, result.Valid())
+		assert.Equal(t, int64(2), result.BrokenLink.ChainPosition)
+		assert.Equal(t, "the entry does not contain the hash of the previous entry", result.BrokenLink.Reason)
+	})
+
+	t.Run("position used twice", func(t *testing.T) {
+		entries := chain(2)
+
+		result, err := auditchain.Verify(ctx, mockDatabase(t, entries, prune(entries[1])))
+		assert.NoError(t, err)
+		assert.False(t, result.Valid())
+		assert.Equal(t, int64(2), result.BrokenLink.ChainPosition)
+		assert.Equal(t, "the position is used by more than one entry", result.BrokenLink.Reason)
+	})
+
+	t.Run("reads the chain in batches", func(t *testing.T) {
+		entries := chain(1001)
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetAuditLogChain", ctx, int64(0), 1000).
+			Return(entries[:1000], nil).
+			Once()
+		database.
+			On("GetAuditLogChain", ctx, int64(1000), 1000).
+			Return(entries[1000:], nil).
+			Once()
+		database.
+			On("GetAuditLogPrunedLinks", ctx, int64(0), 1000).
+			Return([]*db.AuditLogPrunedLink{}, nil).
+			Once()
+
+		result, err := auditchain.Verify(ctx, database)
+		assert.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Equal(t, int64(1001), result.VerifiedEntries)
+	})
+}
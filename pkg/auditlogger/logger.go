@@ -3,6 +3,7 @@ package auditlogger
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/nais/teams-backend/pkg/types"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/helpers"
 	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/sirupsen/logrus"
@@ -39,6 +41,41 @@ type Fields struct {
 	Action        types.AuditAction
 	Actor         *authz.Actor
 	CorrelationID uuid.UUID
+	Changes       Changes
+}
+
+// RedactedValue Used in place of secret values in structured changes
+const RedactedValue = "[REDACTED]"
+
+// Changes Structured changes stored with an audit log entry
+type Changes []*db.AuditLogChange
+
+// Add Add a change of a field to the list. The change is not added if the before and after values are equal.
+func (c Changes) Add(field string, before, after interface{}) Changes {
+	if reflect.DeepEqual(before, after) {
+		return c
+	}
+
+	return append(c, &db.AuditLogChange{
+		Field:  field,
+		Before: before,
+		After:  after,
+	})
+}
+
+// AddSecret Add a change of a secret field. Both values are redacted, and since the actual values are never compared,
+// the change is always added.
+func (c Changes) AddSecret(field string, wasSet bool) Changes {
+	var before interface{}
+	if wasSet {
+		before = RedactedValue
+	}
+
+	return append(c, &db.AuditLogChange{
+		Field:  field,
+		Before: before,
+		After:  RedactedValue,
+	})
 }
 
 type Entry struct {
@@ -109,6 +146,7 @@ func (l *auditLogger) Logf(ctx context.Context, targets []Target, fields Fields,
 			target.Identifier,
 			fields.Action,
 			message,
+			fields.Changes,
 		)
 		if err != nil {
 			l.log.WithError(err).Errorf("create audit log entry")
@@ -146,6 +184,10 @@ func UserTarget(email string) Target {
 	return Target{Type: types.AuditLogsTargetTypeUser, Identifier: email}
 }
 
+func ServiceAccountTarget(name string) Target {
+	return Target{Type: types.AuditLogsTargetTypeServiceAccount, Identifier: name}
+}
+
 func TeamTarget(slug slug.Slug) Target {
 	return Target{Type: types.AuditLogsTargetTypeTeam, Identifier: string(slug)}
 }
@@ -154,6 +196,10 @@ func ReconcilerTarget(name sqlc.ReconcilerName) Target {
 	return Target{Type: types.AuditLogsTargetTypeReconciler, Identifier: string(name)}
 }
 
+func RoleTarget(name roles.RoleName) Target {
+	return Target{Type: types.AuditLogsTargetTypeRole, Identifier: string(name)}
+}
+
 func ComponentTarget(name types.ComponentName) Target {
 	return Target{Type: types.AuditLogsTargetTypeSystem, Identifier: string(name)}
 }
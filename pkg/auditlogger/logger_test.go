@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/types"
 
 	"github.com/google/uuid"
@@ -129,23 +130,24 @@ func Test_Logf(t *testing.T) {
 				User: authenticatedUser,
 			},
 			CorrelationID: correlationID,
+			Changes:       auditlogger.Changes{}.Add("purpose", "old purpose", "new purpose"),
 		}
 
 		database := db.NewMockDatabase(t)
 		database.
-			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeUser, userEmail, action, msg).
+			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeUser, userEmail, action, msg, []*db.AuditLogChange(fields.Changes)).
 			Return(nil).
 			Once()
 		database.
-			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeTeam, string(teamSlug), action, msg).
+			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeTeam, string(teamSlug), action, msg, []*db.AuditLogChange(fields.Changes)).
 			Return(nil).
 			Once()
 		database.
-			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeReconciler, string(reconcilerName), action, msg).
+			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeReconciler, string(reconcilerName), action, msg, []*db.AuditLogChange(fields.Changes)).
 			Return(nil).
 			Once()
 		database.
-			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeSystem, string(componentName), action, msg).
+			On("CreateAuditLogEntry", ctx, correlationID, componentName, &actorIdentity, types.AuditLogsTargetTypeSystem, string(componentName), action, msg, []*db.AuditLogChange(fields.Changes)).
 			Return(nil).
 			Once()
 
@@ -160,3 +162,24 @@ func Test_Logf(t *testing.T) {
 		assert.Equal(t, msg, hook.Entries[3].Message)
 	})
 }
+
+func TestChanges_Add(t *testing.T) {
+	t.Run("equal values are not added", func(t *testing.T) {
+		changes := auditlogger.Changes{}.
+			Add("purpose", "purpose", "purpose").
+			Add("slackChannel", nil, nil).
+			Add("slackAlertsChannels", map[string]string{"dev": "#alerts"}, map[string]string{"dev": "#alerts"})
+		assert.Empty(t, changes)
+	})
+
+	t.Run("changed values are added in order", func(t *testing.T) {
+		changes := auditlogger.Changes{}.
+			Add("purpose", "old purpose", "new purpose").
+			Add("slackChannel", "#channel", "#channel").
+			Add("role", nil, roles.RoleNameTeamowner)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "purpose", Before: "old purpose", After: "new purpose"},
+			{Field: "role", Before: nil, After: roles.RoleNameTeamowner},
+		}, changes)
+	})
+}
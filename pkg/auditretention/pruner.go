@@ -0,0 +1,223 @@
+package auditretention
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditsink"
+	"github.com/nais/teams-backend/pkg/config"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/metrics"
+	"github.com/nais/teams-backend/pkg/types"
+)
+
+// pruneBatchSize The maximum number of entries archived and deleted at once
+const pruneBatchSize = 1000
+
+// rule Entries matching the filter expire after the retention
+type rule struct {
+	name      string
+	retention time.Duration
+	filter    db.ExpiredAuditLogsFilter
+}
+
+// Pruner Archives and deletes audit log entries that have expired according to the retention policy
+type Pruner struct {
+	database   db.Database
+	rules      []rule
+	archiveDir string
+	log        logger.Logger
+}
+
+// New Create a pruner for the retention policy. When archiveDir is empty, expired entries are deleted without being
+// archived.
+func New(database db.Database, policy config.AuditLogRetentionPolicy, archiveDir string, log logger.Logger) *Pruner {
+	return &Pruner{
+		database:   database,
+		rules:      rules(policy),
+		archiveDir: archiveDir,
+		log:        log.WithComponent(types.ComponentNameAuditRetention),
+	}
+}
+
+func NewFromConfig(cfg *config.Config, database db.Database, log logger.Logger) *Pruner {
+	return New(database, cfg.AuditLogRetention.Policy, cfg.AuditLogRetention.ArchiveDir, log)
+}
+
+// Run Prune expired entries every interval until the context is done. Entries are only pruned while isLeader returns
+// true, so that a single instance does the work.
+func (p *Pruner) Run(ctx context.Context, interval time.Duration, isLeader func() bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !isLeader() {
+			continue
+		}
+
+		pruned, err := p.Prune(ctx)
+		if err != nil {
+			p.log.WithError(err).Error("prune expired audit log entries")
+		}
+
+		if pruned > 0 {
+			p.log.Infof("pruned %d expired audit log entries", pruned)
+		}
+	}
+}
+
+// Prune Archive and delete all entries that have expired. Entries that have not yet been delivered to the audit sinks
+// are kept until they have been delivered. Returns the number of deleted entries.
+func (p *Pruner) Prune(ctx context.Context) (int64, error) {
+	now := time.Now()
+	total := int64(0)
+
+	for _, r := range p.rules {
+		filter := r.filter
+		filter.CreatedBefore = now.Add(-r.retention)
+
+		for {
+			entries, err := p.database.GetExpiredAuditLogs(ctx, filter, pruneBatchSize)
+			if err != nil {
+				return total, fmt.Errorf("get expired audit log entries for rule %q: %w", r.name, err)
+			}
+
+			if len(entries) == 0 {
+				break
+			}
+
+			if p.archiveDir != "" {
+				if err := p.archive(entries); err != nil {
+					return total, fmt.Errorf("archive expired audit log entries for rule %q: %w", r.name, err)
+				}
+				metrics.AddAuditLogEntriesArchived(r.name, len(entries))
+			}
+
+			ids := make([]uuid.UUID, len(entries))
+			for i, entry := range entries {
+				ids[i] = entry.ID
+			}
+
+			deleted, err := p.database.DeleteAuditLogsByIDs(ctx, ids)
+			if err != nil {
+				return total, fmt.Errorf("delete expired audit log entries for rule %q: %w", r.name, err)
+			}
+			metrics.AddAuditLogEntriesPruned(r.name, deleted)
+			total += deleted
+
+			if len(entries) < pruneBatchSize {
+				break
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// archive Write the entries to a new gzip compressed JSON lines file in the archive directory. The file is written
+// under a temporary name and renamed when complete, so a partially written archive is never left behind.
+func (p *Pruner) archive(entries []*db.AuditLog) error {
+	first := entries[0]
+	name := fmt.Sprintf("audit-logs-%s-%s.ndjson.gz", first.CreatedAt.UTC().Format("20060102T150405Z"), first.ID)
+
+	tmp, err := os.CreateTemp(p.archiveDir, ".audit-logs-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	gz := gzip.NewWriter(tmp)
+	encoder := json.NewEncoder(gz)
+	for _, entry := range entries {
+		if err := encoder.Encode(auditsink.NewEvent(entry)); err != nil {
+			return err
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(p.archiveDir, name))
+}
+
+// rules Create the rules for a retention policy. The retention of an action takes precedence over the retention of a
+// component, which takes precedence over the default retention. Entries matching a rule that keeps them forever do not
+// get a rule, but are excluded from the rules with lower precedence.
+func rules(policy config.AuditLogRetentionPolicy) []rule {
+	actions := make([]types.AuditAction, 0, len(policy.Actions))
+	for action := range policy.Actions {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+
+	components := make([]types.ComponentName, 0, len(policy.Components))
+	for component := range policy.Components {
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i] < components[j] })
+
+	ret := make([]rule, 0)
+	for _, action := range actions {
+		action := action
+		if retention := policy.Actions[action]; retention > 0 {
+			ret = append(ret, rule{
+				name:      "action:" + string(action),
+				retention: retention,
+				filter:    db.ExpiredAuditLogsFilter{Action: &action},
+			})
+		}
+	}
+
+	for _, component := range components {
+		component := component
+		if retention := policy.Components[component]; retention > 0 {
+			ret = append(ret, rule{
+				name:      "component:" + string(component),
+				retention: retention,
+				filter: db.ExpiredAuditLogsFilter{
+					ComponentName:   &component,
+					ExcludedActions: actions,
+				},
+			})
+		}
+	}
+
+	if policy.Default > 0 {
+		ret = append(ret, rule{
+			name:      "default",
+			retention: policy.Default,
+			filter: db.ExpiredAuditLogsFilter{
+				ExcludedActions:    actions,
+				ExcludedComponents: components,
+			},
+		})
+	}
+
+	return ret
+}
@@ -0,0 +1,164 @@
+package auditretention_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditretention"
+	"github.com/nais/teams-backend/pkg/auditsink"
+	"github.com/nais/teams-backend/pkg/config"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	ctx := context.Background()
+
+	policy := config.AuditLogRetentionPolicy{
+		Default: 365 * 24 * time.Hour,
+		Components: map[types.ComponentName]time.Duration{
+			types.ComponentNameUsersync: 90 * 24 * time.Hour,
+		},
+		Actions: map[types.AuditAction]time.Duration{
+			types.AuditActionGraphqlApiTeamsDelete: 0,
+			types.AuditActionUsersyncCreate:        30 * 24 * time.Hour,
+		},
+	}
+	excludedActions := []types.AuditAction{types.AuditActionGraphqlApiTeamsDelete, types.AuditActionUsersyncCreate}
+
+	newLogger := func(t *testing.T) *logger.MockLogger {
+		log := logger.NewMockLogger(t)
+		log.On("WithComponent", types.ComponentNameAuditRetention).Return(log).Once()
+		return log
+	}
+
+	filter := func(retention time.Duration, action *types.AuditAction, component *types.ComponentName, excludedActions []types.AuditAction, excludedComponents []types.ComponentName) interface{} {
+		return mock.MatchedBy(func(f db.ExpiredAuditLogsFilter) bool {
+			expectedCreatedBefore := time.Now().Add(-retention)
+			return f.CreatedBefore.Before(expectedCreatedBefore) &&
+				f.CreatedBefore.After(expectedCreatedBefore.Add(-time.Minute)) &&
+				assert.ObjectsAreEqual(action, f.Action) &&
+				assert.ObjectsAreEqual(component, f.ComponentName) &&
+				assert.ObjectsAreEqual(excludedActions, f.ExcludedActions) &&
+				assert.ObjectsAreEqual(excludedComponents, f.ExcludedComponents)
+		})
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		pruned, err := auditretention.New(database, config.AuditLogRetentionPolicy{}, "", newLogger(t)).Prune(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), pruned)
+	})
+
+	t.Run("rules in order of precedence", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		action := types.AuditActionUsersyncCreate
+		component := types.ComponentNameUsersync
+		entry1 := &db.AuditLog{AuditLog: &sqlc.AuditLog{ID: uuid.New()}}
+		entry2 := &db.AuditLog{AuditLog: &sqlc.AuditLog{ID: uuid.New()}}
+
+		database.
+			On("GetExpiredAuditLogs", ctx, filter(30*24*time.Hour, &action, nil, nil, nil), 1000).
+			Return([]*db.AuditLog{entry1}, nil).
+			Once()
+		database.
+			On("DeleteAuditLogsByIDs", ctx, []uuid.UUID{entry1.ID}).
+			Return(int64(1), nil).
+			Once()
+		database.
+			On("GetExpiredAuditLogs", ctx, filter(90*24*time.Hour, nil, &component, excludedActions, nil), 1000).
+			Return([]*db.AuditLog{entry2}, nil).
+			Once()
+		database.
+			On("DeleteAuditLogsByIDs", ctx, []uuid.UUID{entry2.ID}).
+			Return(int64(1), nil).
+			Once()
+		database.
+			On("GetExpiredAuditLogs", ctx, filter(365*24*time.Hour, nil, nil, excludedActions, []types.ComponentName{component}), 1000).
+			Return([]*db.AuditLog{}, nil).
+			Once()
+
+		pruned, err := auditretention.New(database, policy, "", newLogger(t)).Prune(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), pruned)
+	})
+
+	t.Run("archive entries before deleting them", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		archiveDir := t.TempDir()
+		entry := &db.AuditLog{AuditLog: &sqlc.AuditLog{
+			ID:               uuid.New(),
+			CreatedAt:        time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+			CorrelationID:    uuid.New(),
+			ComponentName:    string(types.ComponentNameUsersync),
+			Action:           string(types.AuditActionUsersyncCreate),
+			TargetType:       string(types.AuditLogsTargetTypeUser),
+			TargetIdentifier: "user@example.com",
+			Message:          "Local user created",
+		}}
+
+		database.
+			On("GetExpiredAuditLogs", ctx, mock.Anything, 1000).
+			Return([]*db.AuditLog{entry}, nil).
+			Once()
+		database.
+			On("GetExpiredAuditLogs", ctx, mock.Anything, 1000).
+			Return([]*db.AuditLog{}, nil).
+			Twice()
+		database.
+			On("DeleteAuditLogsByIDs", ctx, []uuid.UUID{entry.ID}).
+			Return(int64(1), nil).
+			Once()
+
+		pruned, err := auditretention.New(database, policy, archiveDir, newLogger(t)).Prune(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), pruned)
+
+		files, err := os.ReadDir(archiveDir)
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		assert.Equal(t, "audit-logs-20220102T030405Z-"+entry.ID.String()+".ndjson.gz", files[0].Name())
+
+		file, err := os.Open(filepath.Join(archiveDir, files[0].Name()))
+		assert.NoError(t, err)
+		defer file.Close()
+
+		gz, err := gzip.NewReader(file)
+		assert.NoError(t, err)
+
+		events := make([]auditsink.Event, 0)
+		scanner := bufio.NewScanner(gz)
+		for scanner.Scan() {
+			event := auditsink.Event{}
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+			events = append(events, event)
+		}
+		assert.Equal(t, []auditsink.Event{auditsink.NewEvent(entry)}, events)
+	})
+
+	t.Run("entries are kept when archival fails", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		entry := &db.AuditLog{AuditLog: &sqlc.AuditLog{ID: uuid.New()}}
+
+		database.
+			On("GetExpiredAuditLogs", ctx, mock.Anything, 1000).
+			Return([]*db.AuditLog{entry}, nil).
+			Once()
+
+		pruned, err := auditretention.New(database, policy, filepath.Join(t.TempDir(), "missing"), newLogger(t)).Prune(ctx)
+		assert.ErrorContains(t, err, "archive expired audit log entries")
+		assert.Equal(t, int64(0), pruned)
+	})
+}
@@ -0,0 +1,243 @@
+package auditsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/config"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/google_token_source"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/metrics"
+	"github.com/nais/teams-backend/pkg/types"
+	"google.golang.org/api/option"
+)
+
+const (
+	// dispatchPollInterval How long the dispatcher waits before checking the outbox again when it is empty
+	dispatchPollInterval = 5 * time.Second
+
+	// dispatchBatchSize The maximum number of entries claimed from the outbox at once
+	dispatchBatchSize = 100
+
+	// sinkTimeout The time allowed for a sink to accept a batch of entries
+	sinkTimeout = time.Minute
+
+	// staleLockTimeout Locks older than this are considered abandoned, for instance because the replica that claimed
+	// the entries was killed in the middle of a delivery. It must be longer than the time spent delivering a batch to
+	// all sinks.
+	staleLockTimeout = 10 * time.Minute
+
+	// maintenanceInterval How often to look for abandoned locks and update the number of pending entries
+	maintenanceInterval = time.Minute
+
+	// retryInitialBackoff The delay before the first retry of an entry that could not be delivered
+	retryInitialBackoff = 10 * time.Second
+
+	// retryMaxBackoff The upper limit for the delay between retries of an entry that could not be delivered
+	retryMaxBackoff = 10 * time.Minute
+)
+
+// Dispatcher Delivers audit log entries from the outbox to the configured sinks. Every audit log entry is added to the
+// outbox in the same transaction that creates it, and is only removed from the outbox when all sinks have accepted it,
+// so entries are delivered at least once even if a sink is down for a long time. Sinks that have accepted an entry
+// will not receive it again when another sink fails. All replicas can run a dispatcher at the same time.
+type Dispatcher struct {
+	database          db.Database
+	sinks             []Sink
+	workerID          string
+	log               logger.Logger
+	lastMaintenanceAt time.Time
+}
+
+func NewDispatcher(database db.Database, sinks []Sink, log logger.Logger) *Dispatcher {
+	return &Dispatcher{
+		database: database,
+		sinks:    sinks,
+		workerID: helpers.WorkerID(),
+		log:      log.WithComponent(types.ComponentNameAuditSink),
+	}
+}
+
+// NewFromConfig Create a dispatcher with the sinks enabled in the config. The dispatcher should only be started when
+// at least one sink is enabled, as entries are only added to the outbox in that case.
+func NewFromConfig(ctx context.Context, cfg *config.Config, database db.Database, log logger.Logger) (*Dispatcher, error) {
+	sinks := make([]Sink, 0)
+
+	if cfg.AuditSink.FilePath != "" {
+		sinks = append(sinks, NewFileSink(cfg.AuditSink.FilePath))
+	}
+
+	if cfg.AuditSink.WebhookURL != "" {
+		if cfg.AuditSink.WebhookSecret == "" {
+			return nil, fmt.Errorf("missing secret for the audit log webhook sink")
+		}
+		sinks = append(sinks, NewWebhookSink(cfg.AuditSink.WebhookURL, cfg.AuditSink.WebhookSecret, nil))
+	}
+
+	if cfg.AuditSink.PubSubTopic != "" {
+		builder, err := google_token_source.NewFromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenSource, err := builder.GCP(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("create token source: %w", err)
+		}
+
+		pubsubClient, err := pubsub.NewClient(ctx, cfg.GoogleManagementProjectID, option.WithTokenSource(tokenSource))
+		if err != nil {
+			return nil, fmt.Errorf("retrieve pubsub client: %w", err)
+		}
+
+		sinks = append(sinks, NewPubSubSink(pubsubClient, cfg.AuditSink.PubSubTopic))
+	}
+
+	return NewDispatcher(database, sinks, log), nil
+}
+
+// Run Deliver entries from the outbox until the context is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		d.maintenance(ctx)
+
+		claimed, err := d.DeliverBatch(ctx)
+		if err != nil {
+			d.log.WithError(err).Error("deliver audit log entries")
+		}
+
+		// keep going while the outbox is full of entries
+		if err == nil && claimed == dispatchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dispatchPollInterval):
+		}
+	}
+}
+
+// DeliverBatch Claim a batch of entries from the outbox and send them to all sinks that have not yet received them.
+// Returns the number of claimed entries.
+func (d *Dispatcher) DeliverBatch(ctx context.Context) (int, error) {
+	items, err := d.database.ClaimAuditLogOutboxEntries(ctx, d.workerID, dispatchBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim audit log outbox entries: %w", err)
+	}
+
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	itemsByAuditLogID := make(map[uuid.UUID]*db.AuditLogOutboxEntry, len(items))
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		itemsByAuditLogID[item.AuditLogID] = item
+		ids = append(ids, item.AuditLogID)
+	}
+
+	entries, err := d.database.GetAuditLogsByIDs(ctx, ids)
+	if err != nil {
+		err = fmt.Errorf("get audit log entries: %w", err)
+		for _, item := range items {
+			d.retry(ctx, item, item.DeliveredSinks, err)
+		}
+		return len(items), err
+	}
+
+	delivered := make(map[int64][]string, len(items))
+	failures := make(map[int64][]error)
+	for _, item := range items {
+		delivered[item.ID] = item.DeliveredSinks
+	}
+
+	for _, sink := range d.sinks {
+		pendingItems := make([]*db.AuditLogOutboxEntry, 0)
+		pendingEntries := make([]*db.AuditLog, 0)
+		for _, entry := range entries {
+			item := itemsByAuditLogID[entry.ID]
+			if helpers.Contains(item.DeliveredSinks, sink.Name()) {
+				continue
+			}
+			pendingItems = append(pendingItems, item)
+			pendingEntries = append(pendingEntries, entry)
+		}
+
+		if len(pendingEntries) == 0 {
+			continue
+		}
+
+		if err := d.send(ctx, sink, pendingEntries); err != nil {
+			metrics.IncAuditSinkEntries(sink.Name(), metrics.AuditSinkStateFailed, len(pendingEntries))
+			d.log.WithError(err).Errorf("send %d audit log entries to sink %q", len(pendingEntries), sink.Name())
+			for _, item := range pendingItems {
+				failures[item.ID] = append(failures[item.ID], fmt.Errorf("%s: %w", sink.Name(), err))
+			}
+			continue
+		}
+
+		metrics.IncAuditSinkEntries(sink.Name(), metrics.AuditSinkStateDelivered, len(pendingEntries))
+		for _, item := range pendingItems {
+			delivered[item.ID] = append(delivered[item.ID], sink.Name())
+		}
+	}
+
+	for _, item := range items {
+		if errs, failed := failures[item.ID]; failed {
+			d.retry(ctx, item, delivered[item.ID], errors.Join(errs...))
+			continue
+		}
+
+		// all sinks have received the entry, or the entry no longer exists
+		if err := d.database.DeleteAuditLogOutboxEntry(ctx, item.ID); err != nil {
+			d.log.WithError(err).Errorf("delete audit log outbox entry %d", item.ID)
+		}
+	}
+
+	return len(items), nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, sink Sink, entries []*db.AuditLog) error {
+	ctx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+	return sink.Send(ctx, entries)
+}
+
+// retry Release an outbox entry so that it will be claimed again after a backoff
+func (d *Dispatcher) retry(ctx context.Context, item *db.AuditLogOutboxEntry, deliveredSinks []string, deliveryErr error) {
+	nextAttemptAt := time.Now().Add(helpers.Backoff(item.Attempts, retryInitialBackoff, retryMaxBackoff))
+	if err := d.database.RetryAuditLogOutboxEntry(ctx, item.ID, deliveredSinks, deliveryErr.Error(), nextAttemptAt); err != nil {
+		d.log.WithError(err).Errorf("schedule retry of audit log outbox entry %d", item.ID)
+	}
+}
+
+// maintenance Make entries claimed by replicas that are no longer around available again, and update the number of
+// pending entries. Only runs once every maintenanceInterval.
+func (d *Dispatcher) maintenance(ctx context.Context) {
+	if time.Since(d.lastMaintenanceAt) < maintenanceInterval {
+		return
+	}
+	d.lastMaintenanceAt = time.Now()
+
+	released, err := d.database.ReleaseStaleAuditLogOutboxEntries(ctx, time.Now().Add(-staleLockTimeout))
+	if err != nil {
+		d.log.WithError(err).Error("release stale audit log outbox entries")
+	} else if released > 0 {
+		d.log.Warnf("released %d stale audit log outbox entries", released)
+	}
+
+	pending, err := d.database.GetPendingAuditLogOutboxCount(ctx)
+	if err != nil {
+		d.log.WithError(err).Error("get number of pending audit log outbox entries")
+		return
+	}
+	metrics.SetPendingAuditLogEntries(pending)
+}
@@ -0,0 +1,144 @@
+package auditsink_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditsink"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeSink struct {
+	name    string
+	err     error
+	batches [][]*db.AuditLog
+}
+
+func (s *fakeSink) Name() string {
+	return s.name
+}
+
+func (s *fakeSink) Send(_ context.Context, entries []*db.AuditLog) error {
+	s.batches = append(s.batches, entries)
+	return s.err
+}
+
+func TestDispatcher_DeliverBatch(t *testing.T) {
+	ctx := context.Background()
+
+	entry1 := &db.AuditLog{AuditLog: &sqlc.AuditLog{ID: uuid.New(), Action: "action-1"}}
+	entry2 := &db.AuditLog{AuditLog: &sqlc.AuditLog{ID: uuid.New(), Action: "action-2"}}
+	item1 := &db.AuditLogOutboxEntry{AuditLogOutbox: &sqlc.AuditLogOutbox{ID: 1, AuditLogID: entry1.ID, Attempts: 1, DeliveredSinks: []string{}}}
+	item2 := &db.AuditLogOutboxEntry{AuditLogOutbox: &sqlc.AuditLogOutbox{ID: 2, AuditLogID: entry2.ID, Attempts: 2, DeliveredSinks: []string{"healthy"}}}
+
+	newLogger := func(t *testing.T) *logger.MockLogger {
+		testLogger, _ := test.NewNullLogger()
+		log := logger.NewMockLogger(t)
+		log.On("WithComponent", types.ComponentNameAuditSink).Return(log).Once()
+		log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger}).Maybe()
+		return log
+	}
+
+	t.Run("empty outbox", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimAuditLogOutboxEntries", ctx, mock.AnythingOfType("string"), 100).
+			Return([]*db.AuditLogOutboxEntry{}, nil).
+			Once()
+
+		sink := &fakeSink{name: "healthy"}
+		claimed, err := auditsink.NewDispatcher(database, []auditsink.Sink{sink}, newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, claimed)
+		assert.Empty(t, sink.batches)
+	})
+
+	t.Run("all sinks accept the entries", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimAuditLogOutboxEntries", ctx, mock.AnythingOfType("string"), 100).
+			Return([]*db.AuditLogOutboxEntry{item1, item2}, nil).
+			Once()
+		database.
+			On("GetAuditLogsByIDs", ctx, []uuid.UUID{entry1.ID, entry2.ID}).
+			Return([]*db.AuditLog{entry1, entry2}, nil).
+			Once()
+		database.
+			On("DeleteAuditLogOutboxEntry", ctx, int64(1)).
+			Return(nil).
+			Once()
+		database.
+			On("DeleteAuditLogOutboxEntry", ctx, int64(2)).
+			Return(nil).
+			Once()
+
+		healthy := &fakeSink{name: "healthy"}
+		other := &fakeSink{name: "other"}
+		claimed, err := auditsink.NewDispatcher(database, []auditsink.Sink{healthy, other}, newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, claimed)
+
+		// entry2 has already been delivered to the healthy sink
+		assert.Equal(t, [][]*db.AuditLog{{entry1}}, healthy.batches)
+		assert.Equal(t, [][]*db.AuditLog{{entry1, entry2}}, other.batches)
+	})
+
+	t.Run("failing sink", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimAuditLogOutboxEntries", ctx, mock.AnythingOfType("string"), 100).
+			Return([]*db.AuditLogOutboxEntry{item1, item2}, nil).
+			Once()
+		database.
+			On("GetAuditLogsByIDs", ctx, []uuid.UUID{entry1.ID, entry2.ID}).
+			Return([]*db.AuditLog{entry1, entry2}, nil).
+			Once()
+		database.
+			On("RetryAuditLogOutboxEntry", ctx, int64(1), []string{"healthy"}, "broken: sink is down", mock.Anything).
+			Return(nil).
+			Once()
+		database.
+			On("RetryAuditLogOutboxEntry", ctx, int64(2), []string{"healthy"}, "broken: sink is down", mock.Anything).
+			Return(nil).
+			Once()
+
+		healthy := &fakeSink{name: "healthy"}
+		broken := &fakeSink{name: "broken", err: fmt.Errorf("sink is down")}
+		claimed, err := auditsink.NewDispatcher(database, []auditsink.Sink{healthy, broken}, newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, claimed)
+		assert.Equal(t, [][]*db.AuditLog{{entry1}}, healthy.batches)
+		assert.Equal(t, [][]*db.AuditLog{{entry1, entry2}}, broken.batches)
+	})
+
+	t.Run("audit log entry no longer exists", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimAuditLogOutboxEntries", ctx, mock.AnythingOfType("string"), 100).
+			Return([]*db.AuditLogOutboxEntry{item1}, nil).
+			Once()
+		database.
+			On("GetAuditLogsByIDs", ctx, []uuid.UUID{entry1.ID}).
+			Return([]*db.AuditLog{}, nil).
+			Once()
+		database.
+			On("DeleteAuditLogOutboxEntry", ctx, int64(1)).
+			Return(nil).
+			Once()
+
+		sink := &fakeSink{name: "healthy"}
+		claimed, err := auditsink.NewDispatcher(database, []auditsink.Sink{sink}, newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+		assert.Empty(t, sink.batches)
+	})
+}
@@ -0,0 +1,56 @@
+package auditsink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/nais/teams-backend/pkg/db"
+)
+
+const fileSinkName = "file"
+
+type fileSink struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewFileSink Create a sink that appends audit log entries as JSON lines to a file. The file is opened for each batch,
+// so it can safely be rotated by external tools.
+func NewFileSink(path string) Sink {
+	return &fileSink{
+		path: path,
+	}
+}
+
+func (s *fileSink) Name() string {
+	return fileSinkName
+}
+
+func (s *fileSink) Send(_ context.Context, entries []*db.AuditLog) error {
+	data, err := jsonLines(entries)
+	if err != nil {
+		return fmt.Errorf("encode audit log entries: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log file: %w", err)
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("write audit log file: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("sync audit log file: %w", err)
+	}
+
+	return file.Close()
+}
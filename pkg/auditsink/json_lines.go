@@ -0,0 +1,20 @@
+package auditsink
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/nais/teams-backend/pkg/db"
+)
+
+// jsonLines Encode audit log entries as JSON lines, one event per line
+func jsonLines(entries []*db.AuditLog) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(NewEvent(entry)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
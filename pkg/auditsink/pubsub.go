@@ -0,0 +1,54 @@
+package auditsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/nais/teams-backend/pkg/db"
+)
+
+const pubsubSinkName = "pubsub"
+
+type pubsubSink struct {
+	topic *pubsub.Topic
+}
+
+// NewPubSubSink Create a sink that publishes each audit log entry as a JSON message to a Pub/Sub topic
+func NewPubSubSink(client *pubsub.Client, topicID string) Sink {
+	return &pubsubSink{
+		topic: client.Topic(topicID),
+	}
+}
+
+func (s *pubsubSink) Name() string {
+	return pubsubSinkName
+}
+
+func (s *pubsubSink) Send(ctx context.Context, entries []*db.AuditLog) error {
+	results := make([]*pubsub.PublishResult, 0, len(entries))
+	for _, entry := range entries {
+		data, err := json.Marshal(NewEvent(entry))
+		if err != nil {
+			return fmt.Errorf("encode audit log entry: %w", err)
+		}
+
+		results = append(results, s.topic.Publish(ctx, &pubsub.Message{
+			Data: data,
+			Attributes: map[string]string{
+				"action":         entry.Action,
+				"component_name": entry.ComponentName,
+				"correlation_id": entry.CorrelationID.String(),
+			},
+		}))
+	}
+
+	for _, result := range results {
+		if _, err := result.Get(ctx); err != nil {
+			return fmt.Errorf("publish audit log entry: %w", err)
+		}
+	}
+
+	return nil
+}
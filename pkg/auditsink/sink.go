@@ -0,0 +1,50 @@
+package auditsink
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+)
+
+// Sink An external system that receives audit log entries
+type Sink interface {
+	// Name The name of the sink, used to keep track of which sinks have received an entry. The name must not change
+	// between restarts, as entries would then be delivered again.
+	Name() string
+
+	// Send Deliver a batch of audit log entries, oldest first. If an error is returned, the whole batch will be sent
+	// again later, so receivers must be able to handle duplicates.
+	Send(ctx context.Context, entries []*db.AuditLog) error
+}
+
+// Event The representation of an audit log entry sent to sinks
+type Event struct {
+	ID               uuid.UUID       `json:"id"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	CorrelationID    uuid.UUID       `json:"correlationID"`
+	ComponentName    string          `json:"componentName"`
+	Actor            *string         `json:"actor,omitempty"`
+	Action           string          `json:"action"`
+	TargetType       string          `json:"targetType"`
+	TargetIdentifier string          `json:"targetIdentifier"`
+	Message          string          `json:"message"`
+	Changes          json.RawMessage `json:"changes,omitempty"`
+}
+
+func NewEvent(entry *db.AuditLog) Event {
+	return Event{
+		ID:               entry.ID,
+		CreatedAt:        entry.CreatedAt,
+		CorrelationID:    entry.CorrelationID,
+		ComponentName:    entry.ComponentName,
+		Actor:            entry.Actor,
+		Action:           entry.Action,
+		TargetType:       entry.TargetType,
+		TargetIdentifier: entry.TargetIdentifier,
+		Message:          entry.Message,
+		Changes:          entry.Changes,
+	}
+}
@@ -0,0 +1,171 @@
+package auditsink_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditsink"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func testEntries() []*db.AuditLog {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []*db.AuditLog{
+		{AuditLog: &sqlc.AuditLog{
+			ID:               uuid.New(),
+			CreatedAt:        createdAt,
+			CorrelationID:    uuid.New(),
+			ComponentName:    "graphql-api",
+			Actor:            helpers.Strp("user@example.com"),
+			Action:           "graphql-api:team:create",
+			TargetType:       "team",
+			TargetIdentifier: "some-team",
+			Message:          "Team created",
+		}},
+		{AuditLog: &sqlc.AuditLog{
+			ID:               uuid.New(),
+			CreatedAt:        createdAt.Add(time.Second),
+			CorrelationID:    uuid.New(),
+			ComponentName:    "usersync",
+			Action:           "usersync:create",
+			TargetType:       "user",
+			TargetIdentifier: "user@example.com",
+			Message:          "Local user created",
+		}},
+	}
+}
+
+func readEvents(t *testing.T, r io.Reader) []auditsink.Event {
+	events := make([]auditsink.Event, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		event := auditsink.Event{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	assert.NoError(t, scanner.Err())
+	return events
+}
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	entries := testEntries()
+	sink := auditsink.NewFileSink(path)
+
+	assert.Equal(t, "file", sink.Name())
+	assert.NoError(t, sink.Send(ctx, entries[:1]))
+	assert.NoError(t, sink.Send(ctx, entries[1:]))
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	events := readEvents(t, file)
+	assert.Equal(t, []auditsink.Event{auditsink.NewEvent(entries[0]), auditsink.NewEvent(entries[1])}, events)
+}
+
+func TestWebhookSink(t *testing.T) {
+	ctx := context.Background()
+	const secret = "some-secret"
+	entries := testEntries()
+
+	t.Run("signed request", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+
+			timestamp := r.Header.Get(auditsink.WebhookTimestampHeader)
+			assert.NotEmpty(t, timestamp)
+			assert.Equal(t, auditsink.WebhookSignature([]byte(secret), timestamp, body), r.Header.Get(auditsink.WebhookSignatureHeader))
+			assert.NotEqual(t, auditsink.WebhookSignature([]byte("other-secret"), timestamp, body), r.Header.Get(auditsink.WebhookSignatureHeader))
+
+			assert.Equal(t, []auditsink.Event{auditsink.NewEvent(entries[0]), auditsink.NewEvent(entries[1])}, readEvents(t, bytes.NewReader(body)))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		sink := auditsink.NewWebhookSink(srv.URL, secret, srv.Client())
+		assert.Equal(t, "webhook", sink.Name())
+		assert.NoError(t, sink.Send(ctx, entries))
+	})
+
+	t.Run("receiver fails", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		err := auditsink.NewWebhookSink(srv.URL, secret, srv.Client()).Send(ctx, entries)
+		assert.ErrorContains(t, err, "unexpected status code from webhook: 503")
+	})
+}
+
+func TestPubSubSink(t *testing.T) {
+	ctx := context.Background()
+	const (
+		projectID = "some-project"
+		topicID   = "audit-logs"
+	)
+	entries := testEntries()
+
+	srv := pstest.NewServer()
+	defer srv.Close()
+
+	client, err := pubsub.NewClient(
+		ctx,
+		projectID,
+		option.WithEndpoint(srv.Addr),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	t.Run("missing topic", func(t *testing.T) {
+		err := auditsink.NewPubSubSink(client, "missing-topic").Send(ctx, entries)
+		assert.Error(t, err)
+	})
+
+	t.Run("publish entries", func(t *testing.T) {
+		_, err := client.CreateTopic(ctx, topicID)
+		assert.NoError(t, err)
+
+		sink := auditsink.NewPubSubSink(client, topicID)
+		assert.Equal(t, "pubsub", sink.Name())
+		assert.NoError(t, sink.Send(ctx, entries))
+
+		messages := srv.Messages()
+		assert.Len(t, messages, 2)
+
+		for i, msg := range messages {
+			event := auditsink.Event{}
+			assert.NoError(t, json.Unmarshal(msg.Data, &event))
+			assert.Equal(t, auditsink.NewEvent(entries[i]), event)
+			assert.Equal(t, entries[i].Action, msg.Attributes["action"])
+			assert.Equal(t, entries[i].CorrelationID.String(), msg.Attributes["correlation_id"])
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/metrics"
+)
+
+const (
+	webhookSinkName = "webhook"
+
+	// webhookTimeout The time allowed for the receiver to handle a single batch
+	webhookTimeout = 30 * time.Second
+
+	// WebhookTimestampHeader The header containing the Unix time of when the request was signed
+	WebhookTimestampHeader = "X-Teams-Backend-Timestamp"
+
+	// WebhookSignatureHeader The header containing the signature of the request
+	WebhookSignatureHeader = "X-Teams-Backend-Signature"
+)
+
+type webhookSink struct {
+	url        string
+	secret     []byte
+	httpClient *http.Client
+}
+
+// NewWebhookSink Create a sink that sends audit log entries as JSON lines to a URL with HTTP POST. Each request is
+// signed with the secret, see WebhookSignature.
+func NewWebhookSink(url, secret string, httpClient *http.Client) Sink {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: webhookTimeout}
+	}
+
+	return &webhookSink{
+		url:        url,
+		secret:     []byte(secret),
+		httpClient: httpClient,
+	}
+}
+
+func (s *webhookSink) Name() string {
+	return webhookSinkName
+}
+
+func (s *webhookSink) Send(ctx context.Context, entries []*db.AuditLog) error {
+	body, err := jsonLines(entries)
+	if err != nil {
+		return fmt.Errorf("encode audit log entries: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(s.secret, timestamp, body))
+
+	resp, err := s.httpClient.Do(req)
+	metrics.IncExternalHTTPCalls("audit-sink-webhook", resp, err)
+	if err != nil {
+		return fmt.Errorf("send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code from webhook: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// WebhookSignature Calculate the signature of a webhook request. The signature is the hex encoded HMAC-SHA256 of the
+// timestamp header value, a period, and the request body, prefixed with "sha256=". Receivers should compare the
+// signature in constant time, and reject requests with old timestamps to prevent replays.
+func WebhookSignature(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
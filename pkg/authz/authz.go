@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
-	"github.com/nais/teams-backend/pkg/sqlc"
 )
 
 type ContextKey string
@@ -38,7 +38,7 @@ func ContextWithActor(ctx context.Context, user db.AuthenticatedUser, roles []*d
 }
 
 // RequireRole Check if an actor has a required role
-func RequireRole(actor *Actor, requiredRoleName sqlc.RoleName) error {
+func RequireRole(actor *Actor, requiredRoleName roles.RoleName) error {
 	for _, role := range actor.Roles {
 		if role.RoleName == requiredRoleName {
 			return nil
@@ -94,6 +94,26 @@ func RequireTeamAuthorization(actor *Actor, requiredAuthzName roles.Authorizatio
 	return authorized(authorizations, requiredAuthzName)
 }
 
+// RequireServiceAccountAuthorization Require an actor to have a specific authorization through a globally assigned or a
+// correctly targeted role.
+func RequireServiceAccountAuthorization(actor *Actor, requiredAuthzName roles.Authorization, targetServiceAccountID uuid.UUID) error {
+	if !actor.Authenticated() {
+		return ErrNotAuthenticated
+	}
+
+	authorizations := make(map[roles.Authorization]struct{})
+
+	for _, role := range actor.Roles {
+		if role.IsGlobal() || role.TargetsServiceAccount(targetServiceAccountID) {
+			for _, authorization := range role.Authorizations {
+				authorizations[authorization] = struct{}{}
+			}
+		}
+	}
+
+	return authorized(authorizations, requiredAuthzName)
+}
+
 // authorized Check if one of the authorizations in the map matches the required authorization.
 func authorized(authorizations map[roles.Authorization]struct{}, requiredAuthzName roles.Authorization) error {
 	for authorization := range authorizations {
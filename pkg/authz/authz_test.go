@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/roles"
@@ -15,6 +16,8 @@ import (
 const (
 	authTeamCreateError = `required authorization: "teams:create"`
 	authTeamUpdateError = `required authorization: "teams:update"`
+
+	authServiceAccountUpdateError = `required authorization: "service_accounts:update"`
 )
 
 func TestContextWithUser(t *testing.T) {
@@ -55,7 +58,7 @@ func TestRequireGlobalAuthorization(t *testing.T) {
 	t.Run("User with insufficient roles", func(t *testing.T) {
 		userRoles := []*db.Role{
 			{
-				RoleName:       sqlc.RoleNameTeamviewer,
+				RoleName:       roles.RoleNameTeamviewer,
 				Authorizations: []roles.Authorization{},
 			},
 		}
@@ -66,7 +69,7 @@ func TestRequireGlobalAuthorization(t *testing.T) {
 	t.Run("User with sufficient role", func(t *testing.T) {
 		userRoles := []*db.Role{
 			{
-				RoleName:       sqlc.RoleNameTeamcreator,
+				RoleName:       roles.RoleNameTeamcreator,
 				Authorizations: []roles.Authorization{roles.AuthorizationTeamsCreate},
 			},
 		}
@@ -136,3 +139,50 @@ func TestRequireAuthorizationForTeamTarget(t *testing.T) {
 		assert.NoError(t, authz.RequireTeamAuthorization(contextUser, roles.AuthorizationTeamsUpdate, targetTeamSlug))
 	})
 }
+
+func TestRequireAuthorizationForServiceAccountTarget(t *testing.T) {
+	user := &db.User{
+		User: &sqlc.User{
+			Name:  "User Name",
+			Email: "mail@example.com",
+		},
+	}
+	targetServiceAccountID := uuid.New()
+
+	t.Run("Nil user", func(t *testing.T) {
+		assert.ErrorIs(t, authz.RequireServiceAccountAuthorization(nil, roles.AuthorizationServiceAccountsUpdate, targetServiceAccountID), authz.ErrNotAuthenticated)
+	})
+
+	t.Run("User with targeted role", func(t *testing.T) {
+		userRoles := []*db.Role{
+			{
+				TargetServiceAccountID: &targetServiceAccountID,
+				Authorizations:         []roles.Authorization{roles.AuthorizationServiceAccountsUpdate},
+			},
+		}
+		contextUser := authz.ActorFromContext(authz.ContextWithActor(context.Background(), user, userRoles))
+		assert.NoError(t, authz.RequireServiceAccountAuthorization(contextUser, roles.AuthorizationServiceAccountsUpdate, targetServiceAccountID))
+	})
+
+	t.Run("User with targeted role for wrong target", func(t *testing.T) {
+		wrongID := uuid.New()
+		userRoles := []*db.Role{
+			{
+				TargetServiceAccountID: &wrongID,
+				Authorizations:         []roles.Authorization{roles.AuthorizationServiceAccountsUpdate},
+			},
+		}
+		contextUser := authz.ActorFromContext(authz.ContextWithActor(context.Background(), user, userRoles))
+		assert.EqualError(t, authz.RequireServiceAccountAuthorization(contextUser, roles.AuthorizationServiceAccountsUpdate, targetServiceAccountID), authServiceAccountUpdateError)
+	})
+
+	t.Run("User with global role", func(t *testing.T) {
+		userRoles := []*db.Role{
+			{
+				Authorizations: []roles.Authorization{roles.AuthorizationServiceAccountsUpdate},
+			},
+		}
+		contextUser := authz.ActorFromContext(authz.ContextWithActor(context.Background(), user, userRoles))
+		assert.NoError(t, authz.RequireServiceAccountAuthorization(contextUser, roles.AuthorizationServiceAccountsUpdate, targetServiceAccountID))
+	})
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nais/teams-backend/pkg/types"
+)
+
+// retentionForever The value used in the retention policy to keep entries forever
+const retentionForever = "forever"
+
+// AuditLogRetentionPolicy How long audit log entries are kept. A retention of 0 means that entries are kept forever.
+// The retention of an action takes precedence over the retention of a component, which takes precedence over the
+// default retention.
+type AuditLogRetentionPolicy struct {
+	Default    time.Duration
+	Components map[types.ComponentName]time.Duration
+	Actions    map[types.AuditAction]time.Duration
+}
+
+// Decode Parse a JSON-encoded retention policy. Retentions are either Go durations, a number of days with a "d"
+// suffix, or "forever".
+//
+// Example: {"default": "365d", "components": {"usersync": "90d"}, "actions": {"graphql-api:teams:delete": "forever"}}
+func (p *AuditLogRetentionPolicy) Decode(value string) error {
+	*p = AuditLogRetentionPolicy{
+		Components: make(map[types.ComponentName]time.Duration),
+		Actions:    make(map[types.AuditAction]time.Duration),
+	}
+	if value == "" {
+		return nil
+	}
+
+	policy := struct {
+		Default    string            `json:"default"`
+		Components map[string]string `json:"components"`
+		Actions    map[string]string `json:"actions"`
+	}{}
+
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return fmt.Errorf("parse audit log retention policy: %w", err)
+	}
+
+	var err error
+	if policy.Default != "" {
+		p.Default, err = parseRetention(policy.Default)
+		if err != nil {
+			return fmt.Errorf("parse default audit log retention: %w", err)
+		}
+	}
+
+	for component, retention := range policy.Components {
+		p.Components[types.ComponentName(component)], err = parseRetention(retention)
+		if err != nil {
+			return fmt.Errorf("parse audit log retention for component %q: %w", component, err)
+		}
+	}
+
+	for action, retention := range policy.Actions {
+		p.Actions[types.AuditAction(action)], err = parseRetention(retention)
+		if err != nil {
+			return fmt.Errorf("parse audit log retention for action %q: %w", action, err)
+		}
+	}
+
+	return nil
+}
+
+func parseRetention(value string) (time.Duration, error) {
+	if value == retentionForever {
+		return 0, nil
+	}
+
+	var retention time.Duration
+	if days, found := strings.CutSuffix(value, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number of days: %q", value)
+		}
+		retention = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		retention, err = time.ParseDuration(value)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if retention <= 0 {
+		return 0, fmt.Errorf("retention must be positive, use %q to keep entries forever: %q", retentionForever, value)
+	}
+
+	return retention, nil
+}
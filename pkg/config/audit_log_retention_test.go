@@ -0,0 +1,54 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nais/teams-backend/pkg/config"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogRetentionPolicy_Decode(t *testing.T) {
+	t.Run("empty string", func(t *testing.T) {
+		policy := config.AuditLogRetentionPolicy{}
+		assert.NoError(t, policy.Decode(""))
+		assert.Equal(t, time.Duration(0), policy.Default)
+		assert.Empty(t, policy.Components)
+		assert.Empty(t, policy.Actions)
+	})
+
+	t.Run("full policy", func(t *testing.T) {
+		policy := config.AuditLogRetentionPolicy{}
+		err := policy.Decode(`{"default": "365d", "components": {"usersync": "90d", "github:team": "720h"}, "actions": {"graphql-api:teams:delete": "forever"}}`)
+		assert.NoError(t, err)
+		assert.Equal(t, 365*24*time.Hour, policy.Default)
+		assert.Equal(t, map[types.ComponentName]time.Duration{
+			types.ComponentNameUsersync:   90 * 24 * time.Hour,
+			types.ComponentNameGithubTeam: 720 * time.Hour,
+		}, policy.Components)
+		assert.Equal(t, map[types.AuditAction]time.Duration{
+			types.AuditActionGraphqlApiTeamsDelete: 0,
+		}, policy.Actions)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		policy := config.AuditLogRetentionPolicy{}
+		assert.ErrorContains(t, policy.Decode(`{"default":`), "parse audit log retention policy")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		policy := config.AuditLogRetentionPolicy{}
+		assert.ErrorContains(t, policy.Decode(`{"component": {"usersync": "90d"}}`), "unknown field")
+	})
+
+	t.Run("invalid retention", func(t *testing.T) {
+		policy := config.AuditLogRetentionPolicy{}
+		assert.ErrorContains(t, policy.Decode(`{"components": {"usersync": "ninety days"}}`), `parse audit log retention for component "usersync"`)
+	})
+
+	t.Run("zero retention", func(t *testing.T) {
+		policy := config.AuditLogRetentionPolicy{}
+		assert.ErrorContains(t, policy.Decode(`{"default": "0d"}`), "retention must be positive")
+	})
+}
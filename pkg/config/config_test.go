@@ -82,3 +82,11 @@ func TestParseEnvironments(t *testing.T) {
 	})
 
 }
+
+func TestAuditSink_Enabled(t *testing.T) {
+	assert.False(t, config.AuditSink{}.Enabled())
+	assert.False(t, config.AuditSink{WebhookSecret: "secret"}.Enabled())
+	assert.True(t, config.AuditSink{FilePath: "/var/log/audit.log"}.Enabled())
+	assert.True(t, config.AuditSink{WebhookURL: "https://example.com", WebhookSecret: "secret"}.Enabled())
+	assert.True(t, config.AuditSink{PubSubTopic: "audit-logs"}.Enabled())
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+const (
+	// apiKeyPrefixLength The number of characters of an API key that is stored in plaintext, used to look up the key
+	apiKeyPrefixLength = 8
+
+	// apiKeySaltLength The number of random bytes used to salt the hash of an API key
+	apiKeySaltLength = 16
+)
+
+// APIKeyPrefix Get the visible prefix of an API key. Keys shorter than twice the prefix length have no prefix, so that
+// no significant part of a short key is stored in plaintext.
+func APIKeyPrefix(apiKey string) string {
+	if len(apiKey) < 2*apiKeyPrefixLength {
+		return ""
+	}
+	return apiKey[:apiKeyPrefixLength]
+}
+
+// APIKeyHash Get the salted hash of an API key
+func APIKeyHash(salt []byte, apiKey string) []byte {
+	hash := sha256.Sum256(append(append([]byte{}, salt...), apiKey...))
+	return hash[:]
+}
+
+func (d *database) CreateAPIKey(ctx context.Context, apiKey string, serviceAccountID uuid.UUID, expiresAt *time.Time) (*ApiKey, error) {
+	salt := make([]byte, apiKeySaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	key, err := d.querier.CreateAPIKey(ctx, sqlc.CreateAPIKeyParams{
+		ServiceAccountID: serviceAccountID,
+		Prefix:           APIKeyPrefix(apiKey),
+		Salt:             salt,
+		Hash:             APIKeyHash(salt, apiKey),
+		ExpiresAt:        expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApiKey{ApiKey: key}, nil
+}
+
+func (d *database) GetAPIKeyByID(ctx context.Context, apiKeyID uuid.UUID) (*ApiKey, error) {
+	key, err := d.querier.GetAPIKeyByID(ctx, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApiKey{ApiKey: key}, nil
+}
+
+func (d *database) GetServiceAccountAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]*ApiKey, error) {
+	rows, err := d.querier.GetServiceAccountAPIKeys(ctx, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*ApiKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, &ApiKey{ApiKey: row})
+	}
+
+	return keys, nil
+}
+
+// GetServiceAccountByApiKey Get the service account that owns an API key. Expired keys are ignored. The last used
+// timestamp of the key is updated as part of the lookup.
+func (d *database) GetServiceAccountByApiKey(ctx context.Context, apiKey string) (*ServiceAccount, error) {
+	candidates, err := d.querier.GetActiveAPIKeysByPrefix(ctx, APIKeyPrefix(apiKey))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if subtle.ConstantTimeCompare(candidate.Hash, APIKeyHash(candidate.Salt, apiKey)) != 1 {
+			continue
+		}
+
+		if err := d.querier.SetAPIKeyLastUsed(ctx, candidate.ID); err != nil {
+			return nil, err
+		}
+
+		return d.GetServiceAccountByID(ctx, candidate.ServiceAccountID)
+	}
+
+	return nil, pgx.ErrNoRows
+}
+
+func (d *database) RevokeAPIKey(ctx context.Context, apiKeyID uuid.UUID) error {
+	return d.querier.RevokeAPIKey(ctx, apiKeyID)
+}
@@ -0,0 +1,35 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyPrefix(t *testing.T) {
+	t.Run("long key", func(t *testing.T) {
+		assert.Equal(t, "abcdefgh", db.APIKeyPrefix("abcdefghijklmnopqrstuvwxyz"))
+	})
+
+	t.Run("key of twice the prefix length", func(t *testing.T) {
+		assert.Equal(t, "abcdefgh", db.APIKeyPrefix("abcdefghijklmnop"))
+	})
+
+	t.Run("short key has no prefix", func(t *testing.T) {
+		assert.Equal(t, "", db.APIKeyPrefix("abcdefghijklmno"))
+		assert.Equal(t, "", db.APIKeyPrefix("abcdefg"))
+		assert.Equal(t, "", db.APIKeyPrefix(""))
+	})
+}
+
+func TestAPIKeyHash(t *testing.T) {
+	salt := []byte("some salt")
+	hash := db.APIKeyHash(salt, "some-key")
+
+	assert.Len(t, hash, 32)
+	assert.Equal(t, hash, db.APIKeyHash(salt, "some-key"))
+	assert.NotEqual(t, hash, db.APIKeyHash(salt, "other-key"))
+	assert.NotEqual(t, hash, db.APIKeyHash([]byte("other salt"), "some-key"))
+	assert.Equal(t, []byte("some salt"), salt, "the salt must not be modified")
+}
@@ -2,6 +2,9 @@ package db
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/nais/teams-backend/pkg/types"
 
@@ -36,15 +39,62 @@ func (d *database) GetAuditLogsForReconciler(ctx context.Context, reconcilerName
 	return entries, nil
 }
 
-func (d *database) CreateAuditLogEntry(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string) error {
-	return d.querier.CreateAuditLog(ctx, sqlc.CreateAuditLogParams{
-		CorrelationID:    correlationID,
-		Actor:            actor,
-		ComponentName:    string(componentName),
-		TargetType:       string(targetType),
-		TargetIdentifier: targetIdentifier,
-		Action:           string(action),
-		Message:          message,
+// CreateAuditLogEntry Create an audit log entry and append it to the hash chain. The entry is always created in a
+// separate transaction, as the hash chain is locked while appending to it. The entry is added to the outbox in the same
+// transaction when audit log sinks are enabled.
+func (d *database) CreateAuditLogEntry(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string, changes []*AuditLogChange) error {
+	var changesJSON []byte
+	if len(changes) > 0 {
+		var err error
+		changesJSON, err = json.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("encode audit log changes: %w", err)
+		}
+	}
+
+	return d.querier.Transaction(ctx, func(ctx context.Context, querier Querier) error {
+		entry := &sqlc.AuditLog{
+			ID: uuid.New(),
+			// Postgres stores timestamps with microsecond precision, and the hash must match the stored value
+			CreatedAt:        time.Now().UTC().Truncate(time.Microsecond),
+			CorrelationID:    correlationID,
+			ComponentName:    string(componentName),
+			Actor:            actor,
+			Action:           string(action),
+			Message:          message,
+			Changes:          changesJSON,
+			TargetType:       string(targetType),
+			TargetIdentifier: targetIdentifier,
+		}
+
+		if err := appendToAuditLogChain(ctx, querier, entry); err != nil {
+			return err
+		}
+
+		err := querier.CreateAuditLog(ctx, sqlc.CreateAuditLogParams{
+			ID:               entry.ID,
+			CreatedAt:        entry.CreatedAt,
+			CorrelationID:    entry.CorrelationID,
+			Actor:            entry.Actor,
+			ComponentName:    entry.ComponentName,
+			TargetType:       entry.TargetType,
+			TargetIdentifier: entry.TargetIdentifier,
+			Action:           entry.Action,
+			Message:          entry.Message,
+			Changes:          entry.Changes,
+			ChainPosition:    entry.ChainPosition,
+			PreviousHash:     entry.PreviousHash,
+			Hash:             entry.Hash,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !d.auditLogOutbox {
+			return nil
+		}
+
+		return querier.CreateAuditLogOutboxEntry(ctx, entry.ID)
 	})
 }
 
@@ -61,3 +111,122 @@ func (d *database) GetAuditLogsForCorrelationID(ctx context.Context, correlation
 
 	return entries, nil
 }
+
+func (d *database) GetAuditLogs(ctx context.Context, filter AuditLogFilter, after *AuditLogCursor, limit int) ([]*AuditLog, error) {
+	params := sqlc.GetAuditLogsParams{
+		Actor:            filter.Actor,
+		TargetIdentifier: filter.TargetIdentifier,
+		CorrelationID:    filter.CorrelationID,
+		CreatedAfter:     filter.CreatedAfter,
+		CreatedBefore:    filter.CreatedBefore,
+		LimitRows:        int32(limit),
+	}
+
+	if filter.Action != nil {
+		action := string(*filter.Action)
+		params.Action = &action
+	}
+
+	if filter.ComponentName != nil {
+		componentName := string(*filter.ComponentName)
+		params.ComponentName = &componentName
+	}
+
+	if filter.TargetType != nil {
+		targetType := string(*filter.TargetType)
+		params.TargetType = &targetType
+	}
+
+	if after != nil {
+		params.CursorCreatedAt = &after.CreatedAt
+		params.CursorID = &after.ID
+	}
+
+	rows, err := d.querier.GetAuditLogs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*AuditLog, len(rows))
+	for i, row := range rows {
+		entries[i] = &AuditLog{AuditLog: row}
+	}
+
+	return entries, nil
+}
+
+func (d *database) GetAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) ([]*AuditLog, error) {
+	rows, err := d.querier.GetAuditLogsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*AuditLog, len(rows))
+	for i, row := range rows {
+		entries[i] = &AuditLog{AuditLog: row}
+	}
+
+	return entries, nil
+}
+
+// GetExpiredAuditLogs Get the oldest audit log entries matching the filter. Entries that have not yet been delivered
+// to the audit sinks are never returned.
+func (d *database) GetExpiredAuditLogs(ctx context.Context, filter ExpiredAuditLogsFilter, limit int) ([]*AuditLog, error) {
+	// the excluded values must never be nil, as NULL arrays would exclude every row
+	excludedActions := make([]string, 0, len(filter.ExcludedActions))
+	for _, action := range filter.ExcludedActions {
+		excludedActions = append(excludedActions, string(action))
+	}
+
+	excludedComponents := make([]string, 0, len(filter.ExcludedComponents))
+	for _, component := range filter.ExcludedComponents {
+		excludedComponents = append(excludedComponents, string(component))
+	}
+
+	params := sqlc.GetExpiredAuditLogsParams{
+		CreatedBefore:      filter.CreatedBefore,
+		ExcludedActions:    excludedActions,
+		ExcludedComponents: excludedComponents,
+		LimitRows:          int32(limit),
+	}
+
+	if filter.Action != nil {
+		action := string(*filter.Action)
+		params.Action = &action
+	}
+
+	if filter.ComponentName != nil {
+		componentName := string(*filter.ComponentName)
+		params.ComponentName = &componentName
+	}
+
+	rows, err := d.querier.GetExpiredAuditLogs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*AuditLog, len(rows))
+	for i, row := range rows {
+		entries[i] = &AuditLog{AuditLog: row}
+	}
+
+	return entries, nil
+}
+
+func (d *database) DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	return d.querier.DeleteAuditLogsByIDs(ctx, ids)
+}
+
+// GetAuditLogChanges Decode the structured changes of an audit log entry. Entries without changes return an empty slice.
+func GetAuditLogChanges(entry *AuditLog) ([]*AuditLogChange, error) {
+	changes := make([]*AuditLogChange, 0)
+	if entry.Changes == nil {
+		return changes, nil
+	}
+
+	if err := json.Unmarshal(entry.Changes, &changes); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
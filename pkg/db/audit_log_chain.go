@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+// auditLogChainLockID The ID of the advisory lock held while appending an entry to the audit log hash chain. Every entry
+// depends on the hash of the previous one, so all writers of audit log entries are serialized on this lock. The lock is
+// held until the transaction that reads the tail of the chain and inserts the new entry ends, so the throughput of audit
+// logging is bounded by the latency of that transaction. Readers of the audit log are never blocked.
+const auditLogChainLockID = 7032412173
+
+// AuditLogHash Calculate the hash of an audit log entry in the hash chain. The hash covers the hash of the previous
+// entry, the position of the entry in the chain, and all the fields of the entry, so changing any of them, or the order
+// of the entries, breaks the chain. Structured changes are only part of the hash when the entry has any, so entries created
+// before changes were recorded keep their hash.
+func AuditLogHash(entry *sqlc.AuditLog) string {
+	var chainPosition int64
+	if entry.ChainPosition != nil {
+		chainPosition = *entry.ChainPosition
+	}
+
+	var previousHash string
+	if entry.PreviousHash != nil {
+		previousHash = *entry.PreviousHash
+	}
+
+	// encoding the fields as a JSON array makes the input unambiguous
+	fields := []interface{}{
+		previousHash,
+		chainPosition,
+		entry.ID.String(),
+		entry.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		entry.CorrelationID.String(),
+		entry.ComponentName,
+		entry.Actor,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetIdentifier,
+		entry.Message,
+	}
+
+	if entry.Changes != nil {
+		// the database normalizes JSONB values, so hash a canonical encoding of the changes instead of the raw bytes
+		var changes interface{}
+		if err := json.Unmarshal(entry.Changes, &changes); err == nil {
+			fields = append(fields, changes)
+		} else {
+			fields = append(fields, string(entry.Changes))
+		}
+	}
+
+	data, _ := json.Marshal(fields)
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (d *database) GetAuditLogChain(ctx context.Context, afterPosition int64, limit int) ([]*AuditLog, error) {
+	rows, err := d.querier.GetAuditLogChain(ctx, sqlc.GetAuditLogChainParams{
+		AfterPosition: afterPosition,
+		LimitRows:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*AuditLog, len(rows))
+	for i, row := range rows {
+		entries[i] = &AuditLog{AuditLog: row}
+	}
+
+	return entries, nil
+}
+
+func (d *database) GetAuditLogPrunedLinks(ctx context.Context, afterPosition int64, limit int) ([]*AuditLogPrunedLink, error) {
+	rows, err := d.querier.GetAuditLogPrunedLinks(ctx, sqlc.GetAuditLogPrunedLinksParams{
+		AfterPosition: afterPosition,
+		LimitRows:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*AuditLogPrunedLink, len(rows))
+	for i, row := range rows {
+		links[i] = &AuditLogPrunedLink{AuditLogPrunedLink: row}
+	}
+
+	return links, nil
+}
+
+// appendToAuditLogChain Set the chain position, previous hash and hash of a new entry. Must be called in a transaction,
+// as the chain is locked until the transaction ends.
+func appendToAuditLogChain(ctx context.Context, querier Querier, entry *sqlc.AuditLog) error {
+	if err := querier.LockAuditLogChain(ctx, auditLogChainLockID); err != nil {
+		return fmt.Errorf("lock audit log chain: %w", err)
+	}
+
+	var lastPosition int64
+	tail, err := querier.GetAuditLogChainTail(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("get audit log chain tail: %w", err)
+	} else if err == nil {
+		lastPosition = tail.ChainPosition
+		entry.PreviousHash = &tail.Hash
+	}
+
+	position := lastPosition + 1
+	entry.ChainPosition = &position
+
+	hash := AuditLogHash(entry)
+	entry.Hash = &hash
+	return nil
+}
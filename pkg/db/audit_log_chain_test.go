@@ -0,0 +1,73 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogHash(t *testing.T) {
+	position := int64(1)
+	newEntry := func() *sqlc.AuditLog {
+		return &sqlc.AuditLog{
+			ID:               uuid.MustParse("f0b7e5a4-1c8f-4b47-9a4e-5f1f4c2c6f0d"),
+			CreatedAt:        time.Date(2023, 1, 1, 12, 0, 0, 123456000, time.UTC),
+			CorrelationID:    uuid.MustParse("0d6a3d43-4d2b-4d2c-8bde-91bd2a37c1a4"),
+			ComponentName:    "graphql-api",
+			Action:           "graphql-api:teams:update",
+			TargetType:       "team",
+			TargetIdentifier: "some-team",
+			Message:          "Team configuration saved",
+			ChainPosition:    &position,
+		}
+	}
+
+	t.Run("hash changes when the content changes", func(t *testing.T) {
+		entry := newEntry()
+		hash := db.AuditLogHash(entry)
+		assert.Equal(t, hash, db.AuditLogHash(newEntry()))
+
+		entry.Message = "Team deleted"
+		assert.NotEqual(t, hash, db.AuditLogHash(entry))
+	})
+
+	t.Run("changes are part of the hash", func(t *testing.T) {
+		entry := newEntry()
+		hash := db.AuditLogHash(entry)
+
+		entry.Changes = []byte(`[{"field":"purpose","before":"old","after":"new"}]`)
+		assert.NotEqual(t, hash, db.AuditLogHash(entry))
+	})
+
+	t.Run("hash does not depend on the encoding of the changes", func(t *testing.T) {
+		entry := newEntry()
+		entry.Changes = []byte(`[{"field":"purpose","before":"old","after":"new"}]`)
+		hash := db.AuditLogHash(entry)
+
+		// JSONB values are returned with sorted keys and additional whitespace
+		entry.Changes = []byte(`[{"after": "new", "field": "purpose", "before": "old"}]`)
+		assert.Equal(t, hash, db.AuditLogHash(entry))
+	})
+}
+
+func TestGetAuditLogChanges(t *testing.T) {
+	t.Run("entry without changes", func(t *testing.T) {
+		changes, err := db.GetAuditLogChanges(&db.AuditLog{AuditLog: &sqlc.AuditLog{}})
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("entry with changes", func(t *testing.T) {
+		changes, err := db.GetAuditLogChanges(&db.AuditLog{AuditLog: &sqlc.AuditLog{
+			Changes: []byte(`[{"field": "roles", "after": ["team:owner"], "before": null}]`),
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, []*db.AuditLogChange{
+			{Field: "roles", Before: nil, After: []interface{}{"team:owner"}},
+		}, changes)
+	})
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+func (d *database) ClaimAuditLogOutboxEntries(ctx context.Context, lockedBy string, batchSize int) ([]*AuditLogOutboxEntry, error) {
+	rows, err := d.querier.ClaimAuditLogOutboxEntries(ctx, sqlc.ClaimAuditLogOutboxEntriesParams{
+		LockedBy:  lockedBy,
+		BatchSize: int32(batchSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*AuditLogOutboxEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = &AuditLogOutboxEntry{AuditLogOutbox: row}
+	}
+
+	return entries, nil
+}
+
+func (d *database) DeleteAuditLogOutboxEntry(ctx context.Context, id int64) error {
+	return d.querier.DeleteAuditLogOutboxEntry(ctx, id)
+}
+
+func (d *database) RetryAuditLogOutboxEntry(ctx context.Context, id int64, deliveredSinks []string, lastError string, nextAttemptAt time.Time) error {
+	return d.querier.RetryAuditLogOutboxEntry(ctx, sqlc.RetryAuditLogOutboxEntryParams{
+		ID:             id,
+		DeliveredSinks: deliveredSinks,
+		LastError:      lastError,
+		NextAttemptAt:  nextAttemptAt,
+	})
+}
+
+func (d *database) ReleaseStaleAuditLogOutboxEntries(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	return d.querier.ReleaseStaleAuditLogOutboxEntries(ctx, lockedBefore)
+}
+
+func (d *database) GetPendingAuditLogOutboxCount(ctx context.Context) (int64, error) {
+	return d.querier.GetPendingAuditLogOutboxCount(ctx)
+}
+
+// DeleteAuditLogOutboxEntries Remove all entries from the outbox, for instance when no audit log sinks are enabled
+func (d *database) DeleteAuditLogOutboxEntries(ctx context.Context) (int64, error) {
+	return d.querier.DeleteAuditLogOutboxEntries(ctx)
+}
@@ -17,7 +17,9 @@ import (
 
 const databaseConnectRetries = 5
 
-func New(ctx context.Context, dbUrl string) (Database, error) {
+// New Connect to the database and run the migrations. When auditLogOutbox is set, new audit log entries are added to
+// the outbox so that they can be delivered to the audit log sinks.
+func New(ctx context.Context, dbUrl string, auditLogOutbox bool) (Database, error) {
 	config, err := pgxpool.ParseConfig(dbUrl)
 	if err != nil {
 		return nil, err
@@ -42,11 +44,19 @@ func New(ctx context.Context, dbUrl string) (Database, error) {
 		return nil, err
 	}
 
+	querier := &Queries{
+		Queries:  sqlc.New(dbc),
+		connPool: dbc,
+	}
+
+	err = validateRoleAuthorizations(ctx, querier)
+	if err != nil {
+		return nil, err
+	}
+
 	return &database{
-		querier: &Queries{
-			Queries:  sqlc.New(dbc),
-			connPool: dbc,
-		},
+		querier:        querier,
+		auditLogOutbox: auditLogOutbox,
 	}, nil
 }
 
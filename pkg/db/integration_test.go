@@ -9,9 +9,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/nais/teams-backend/sqlc/schemas"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,11 +69,284 @@ func TestUsers(t *testing.T) {
 	})
 }
 
+func TestCountGlobalAdmins(t *testing.T) {
+	ctx := context.Background()
+	database, err := setupTestDatabase(ctx)
+	if err != nil {
+		t.Fatalf("Unable to setup database for integration tests: %v", err)
+	}
+
+	count, err := database.CountGlobalAdmins(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	user, err := database.CreateUser(ctx, "Admin", "admin@example.com", "external-id-admin")
+	assert.NoError(t, err)
+	assert.NoError(t, database.AssignGlobalRoleToUser(ctx, user.ID, roles.RoleNameAdmin, nil))
+	assert.NoError(t, database.AssignGlobalRoleToUser(ctx, user.ID, roles.RoleNameTeamcreator, nil))
+
+	serviceAccount, err := database.CreateServiceAccount(ctx, "ci-admin", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, database.AssignGlobalRoleToServiceAccount(ctx, serviceAccount.ID, roles.RoleNameAdmin, nil))
+
+	count, err = database.CountGlobalAdmins(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.NoError(t, database.RevokeGlobalUserRole(ctx, user.ID, roles.RoleNameAdmin))
+
+	count, err = database.CountGlobalAdmins(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	err = database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		if err := dbtx.LockGlobalAdmins(ctx); err != nil {
+			return err
+		}
+
+		count, err = dbtx.CountGlobalAdmins(ctx)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestSetTeamParent(t *testing.T) {
+	ctx := context.Background()
+	database, err := setupTestDatabase(ctx)
+	if err != nil {
+		t.Fatalf("Unable to setup database for integration tests: %v", err)
+	}
+
+	for _, teamSlug := range []slug.Slug{"parent", "child", "grandchild"} {
+		_, err := database.CreateTeam(ctx, teamSlug, "purpose", "#channel")
+		assert.NoError(t, err)
+	}
+
+	parentSlug, childSlug, grandchildSlug := slug.Slug("parent"), slug.Slug("child"), slug.Slug("grandchild")
+
+	team, err := database.SetTeamParent(ctx, childSlug, &parentSlug)
+	assert.NoError(t, err)
+	assert.Equal(t, parentSlug, *team.ParentTeamSlug)
+
+	_, err = database.SetTeamParent(ctx, grandchildSlug, &childSlug)
+	assert.NoError(t, err)
+
+	_, err = database.SetTeamParent(ctx, parentSlug, &parentSlug)
+	assert.ErrorIs(t, err, db.ErrTeamParentCycle)
+
+	_, err = database.SetTeamParent(ctx, parentSlug, &grandchildSlug)
+	assert.ErrorIs(t, err, db.ErrTeamParentCycle)
+
+	user, err := database.CreateUser(ctx, "Owner", "owner@example.com", "external-id-owner")
+	assert.NoError(t, err)
+	assert.NoError(t, database.SetTeamMemberRole(ctx, user.ID, parentSlug, roles.RoleNameTeamowner))
+	assert.NoError(t, database.SetTeamMemberRole(ctx, user.ID, childSlug, roles.RoleNameTeamowner))
+	assert.NoError(t, database.SetTeamMemberRole(ctx, user.ID, grandchildSlug, roles.RoleNameTeamowner))
+
+	userRoles, err := database.GetUserRoles(ctx, user.ID)
+	assert.NoError(t, err)
+	descendants := make(map[slug.Slug][]slug.Slug)
+	for _, role := range userRoles {
+		if role.RoleName == roles.RoleNameTeamowner {
+			descendants[*role.TargetTeamSlug] = role.DescendantTeamSlugs
+		}
+	}
+	assert.Equal(t, map[slug.Slug][]slug.Slug{
+		parentSlug:     {childSlug, grandchildSlug},
+		childSlug:      {grandchildSlug},
+		grandchildSlug: {},
+	}, descendants)
+
+	team, err = database.SetTeamParent(ctx, grandchildSlug, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, team.ParentTeamSlug)
+
+	team, err = database.SetTeamParent(ctx, parentSlug, &grandchildSlug)
+	assert.NoError(t, err)
+	assert.Equal(t, grandchildSlug, *team.ParentTeamSlug)
+}
+
+func TestRoles(t *testing.T) {
+	ctx := context.Background()
+	database, err := setupTestDatabase(ctx)
+	if err != nil {
+		t.Fatalf("Unable to setup database for integration tests: %v", err)
+	}
+
+	t.Run("Built-in roles are seeded", func(t *testing.T) {
+		expected := map[roles.RoleName][]roles.Authorization{
+			roles.RoleNameAdmin: {
+				roles.AuthorizationAuditLogsRead,
+				roles.AuthorizationServiceAccountsCreate,
+				roles.AuthorizationServiceAccountsDelete,
+				roles.AuthorizationServiceAccountsList,
+				roles.AuthorizationServiceAccountsRead,
+				roles.AuthorizationServiceAccountsUpdate,
+				roles.AuthorizationSystemStatesDelete,
+				roles.AuthorizationSystemStatesRead,
+				roles.AuthorizationSystemStatesUpdate,
+				roles.AuthorizationTeamsCreate,
+				roles.AuthorizationTeamsDelete,
+				roles.AuthorizationTeamsList,
+				roles.AuthorizationTeamsRead,
+				roles.AuthorizationTeamsUpdate,
+				roles.AuthorizationUsersList,
+				roles.AuthorizationUsersUpdate,
+				roles.AuthorizationTeamsSynchronize,
+				roles.AuthorizationUsersyncSynchronize,
+				roles.AuthorizationDeployKeyView,
+			},
+			roles.RoleNameDeploykeyviewer: {
+				roles.AuthorizationDeployKeyView,
+			},
+			roles.RoleNameServiceaccountcreator: {
+				roles.AuthorizationServiceAccountsCreate,
+			},
+			roles.RoleNameServiceaccountowner: {
+				roles.AuthorizationServiceAccountsDelete,
+				roles.AuthorizationServiceAccountsRead,
+				roles.AuthorizationServiceAccountsUpdate,
+			},
+			roles.RoleNameSynchronizer: {
+				roles.AuthorizationTeamsSynchronize,
+				roles.AuthorizationUsersyncSynchronize,
+			},
+			roles.RoleNameTeamcreator: {
+				roles.AuthorizationTeamsCreate,
+			},
+			roles.RoleNameTeammember: {
+				roles.AuthorizationAuditLogsRead,
+				roles.AuthorizationServiceAccountsRead,
+				roles.AuthorizationTeamsRead,
+				roles.AuthorizationDeployKeyView,
+				roles.AuthorizationTeamsSynchronize,
+			},
+			roles.RoleNameTeamowner: {
+				roles.AuthorizationAuditLogsRead,
+				roles.AuthorizationServiceAccountsCreate,
+				roles.AuthorizationServiceAccountsDelete,
+				roles.AuthorizationServiceAccountsRead,
+				roles.AuthorizationServiceAccountsUpdate,
+				roles.AuthorizationTeamsDelete,
+				roles.AuthorizationTeamsRead,
+				roles.AuthorizationTeamsUpdate,
+				roles.AuthorizationTeamsSynchronize,
+				roles.AuthorizationDeployKeyView,
+			},
+			roles.RoleNameTeamviewer: {
+				roles.AuthorizationAuditLogsRead,
+				roles.AuthorizationTeamsList,
+				roles.AuthorizationTeamsRead,
+			},
+			roles.RoleNameUseradmin: {
+				roles.AuthorizationUsersList,
+				roles.AuthorizationUsersUpdate,
+			},
+			roles.RoleNameUserviewer: {
+				roles.AuthorizationUsersList,
+			},
+		}
+
+		definitions, err := database.GetRoleDefinitions(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, definitions, len(expected))
+
+		for _, definition := range definitions {
+			assert.True(t, definition.BuiltIn)
+			assert.Contains(t, expected, definition.Name)
+
+			authorizations, err := database.GetRoleAuthorizations(ctx, definition.Name)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, expected[definition.Name], authorizations, "authorizations of role %q", definition.Name)
+		}
+	})
+
+	t.Run("Authorizations of several roles are loaded at once", func(t *testing.T) {
+		authorizations, err := database.GetRolesAuthorizations(ctx, []roles.RoleName{roles.RoleNameTeamviewer, roles.RoleNameDeploykeyviewer, "Unknown"})
+		assert.NoError(t, err)
+		assert.Len(t, authorizations, 2)
+		assert.ElementsMatch(t, []roles.Authorization{roles.AuthorizationDeployKeyView}, authorizations[roles.RoleNameDeploykeyviewer])
+		assert.Len(t, authorizations[roles.RoleNameTeamviewer], 3)
+	})
+
+	t.Run("Role names are unique", func(t *testing.T) {
+		_, err := database.CreateRoleDefinition(ctx, roles.RoleNameTeamviewer, "")
+		assert.ErrorIs(t, err, db.ErrRoleNameTaken)
+	})
+
+	t.Run("Assign and delete custom role", func(t *testing.T) {
+		const roleName = roles.RoleName("Team auditor")
+
+		user, err := database.CreateUser(ctx, "Auditor", "auditor@example.com", "external-id-auditor")
+		assert.NoError(t, err)
+
+		role, err := database.CreateRoleDefinition(ctx, roleName, "Read audit logs of all teams")
+		assert.NoError(t, err)
+		assert.False(t, role.BuiltIn)
+
+		err = database.SetRoleAuthorizations(ctx, roleName, []roles.Authorization{roles.AuthorizationAuditLogsRead})
+		assert.NoError(t, err)
+
+		err = database.AssignGlobalRoleToUser(ctx, user.ID, roleName, nil)
+		assert.NoError(t, err)
+
+		userRoles, err := database.GetUserRoles(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Len(t, userRoles, 1)
+		assert.Equal(t, roleName, userRoles[0].RoleName)
+		assert.Equal(t, []roles.Authorization{roles.AuthorizationAuditLogsRead}, userRoles[0].Authorizations)
+
+		err = database.DeleteRoleDefinition(ctx, roleName)
+		assert.NoError(t, err)
+
+		userRoles, err = database.GetUserRoles(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, userRoles)
+	})
+}
+
+func TestAuditLogOutbox(t *testing.T) {
+	ctx := context.Background()
+	database, err := setupTestDatabase(ctx)
+	if err != nil {
+		t.Fatalf("Unable to setup database for integration tests: %v", err)
+	}
+
+	createEntry := func(database db.Database) {
+		err := database.CreateAuditLogEntry(ctx, uuid.New(), types.ComponentNameGraphqlApi, nil, types.AuditLogsTargetTypeTeam, "team", types.AuditActionGraphqlApiTeamCreate, "Team created", nil)
+		assert.NoError(t, err)
+	}
+
+	createEntry(database)
+	pending, err := database.GetPendingAuditLogOutboxCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), pending)
+
+	databaseWithOutbox, err := db.New(ctx, connStringWithDb, true)
+	assert.NoError(t, err)
+
+	createEntry(databaseWithOutbox)
+	err = databaseWithOutbox.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		createEntry(dbtx)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	pending, err = database.GetPendingAuditLogOutboxCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), pending)
+
+	discarded, err := database.DeleteAuditLogOutboxEntries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), discarded)
+}
+
 func setupTestDatabase(ctx context.Context) (db.Database, error) {
 	if err := createEmptyTestDatabase(ctx); err != nil {
 		return nil, err
 	}
-	return db.New(ctx, connStringWithDb)
+	return db.New(ctx, connStringWithDb, false)
 }
 
 func createEmptyTestDatabase(ctx context.Context) error {
@@ -109,3 +389,60 @@ func connect(ctx context.Context, connString string, connectRetries int) (*pgx.C
 
 	return conn, nil
 }
+
+func TestAPIKeyHashesMigration(t *testing.T) {
+	const apiKeyHashesVersion = 72
+
+	ctx := context.Background()
+	if err := createEmptyTestDatabase(ctx); err != nil {
+		t.Fatalf("Unable to setup database for integration tests: %v", err)
+	}
+
+	source, err := iofs.New(schemas.FS, ".")
+	assert.NoError(t, err)
+	m, err := migrate.NewWithSourceInstance("iofs", source, connStringWithDb)
+	assert.NoError(t, err)
+	defer m.Close()
+	assert.NoError(t, m.Migrate(apiKeyHashesVersion-1))
+
+	conn, err := connect(ctx, connStringWithDb, databaseConnectRetries)
+	assert.NoError(t, err)
+	defer conn.Close(ctx)
+
+	apiKeys := []string{"short-key", "fifteen-chars-k", "sixteen-chars-ke", "some-long-api-key-with-many-characters"}
+	var serviceAccountID uuid.UUID
+	err = conn.QueryRow(ctx, "INSERT INTO service_accounts (name) VALUES ('migrated') RETURNING id").Scan(&serviceAccountID)
+	assert.NoError(t, err)
+	for _, apiKey := range apiKeys {
+		_, err = conn.Exec(ctx, "INSERT INTO api_keys (api_key, service_account_id) VALUES ($1, $2)", apiKey, serviceAccountID)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, m.Migrate(apiKeyHashesVersion))
+
+	rows, err := conn.Query(ctx, "SELECT prefix FROM api_keys")
+	assert.NoError(t, err)
+	prefixes := make([]string, 0)
+	for rows.Next() {
+		var prefix string
+		assert.NoError(t, rows.Scan(&prefix))
+		prefixes = append(prefixes, prefix)
+	}
+	assert.NoError(t, rows.Err())
+
+	expected := make([]string, 0)
+	for _, apiKey := range apiKeys {
+		expected = append(expected, db.APIKeyPrefix(apiKey))
+	}
+	assert.ElementsMatch(t, expected, prefixes)
+
+	database, err := db.New(ctx, connStringWithDb, false)
+	assert.NoError(t, err)
+	for _, apiKey := range apiKeys {
+		serviceAccount, err := database.GetServiceAccountByApiKey(ctx, apiKey)
+		assert.NoError(t, err, apiKey)
+		if serviceAccount != nil {
+			assert.Equal(t, serviceAccountID, serviceAccount.ID)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+// AcquireLeaderLease Acquire or renew a lease. pgx.ErrNoRows is returned if the lease is currently held by someone else.
+func (d *database) AcquireLeaderLease(ctx context.Context, name, holder string, duration time.Duration) (*LeaderLease, error) {
+	lease, err := d.querier.AcquireLeaderLease(ctx, sqlc.AcquireLeaderLeaseParams{
+		Name:         name,
+		Holder:       holder,
+		LeaseSeconds: int32(duration.Seconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LeaderLease{LeaderLease: lease}, nil
+}
+
+func (d *database) ReleaseLeaderLease(ctx context.Context, name, holder string) error {
+	return d.querier.ReleaseLeaderLease(ctx, sqlc.ReleaseLeaderLeaseParams{
+		Name:   name,
+		Holder: holder,
+	})
+}
@@ -5,11 +5,14 @@ package db
 import (
 	context "context"
 
+	"github.com/nais/teams-backend/pkg/roles"
 	slug "github.com/nais/teams-backend/pkg/slug"
 	mock "github.com/stretchr/testify/mock"
 
 	sqlc "github.com/nais/teams-backend/pkg/sqlc"
 
+	time "time"
+
 	types "github.com/nais/teams-backend/pkg/types"
 
 	uuid "github.com/google/uuid"
@@ -28,6 +31,63 @@ func (_m *MockDatabase) EXPECT() *MockDatabase_Expecter {
 	return &MockDatabase_Expecter{mock: &_m.Mock}
 }
 
+// AcquireLeaderLease provides a mock function with given fields: ctx, name, holder, duration
+func (_m *MockDatabase) AcquireLeaderLease(ctx context.Context, name string, holder string, duration time.Duration) (*LeaderLease, error) {
+	ret := _m.Called(ctx, name, holder, duration)
+
+	var r0 *LeaderLease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (*LeaderLease, error)); ok {
+		return rf(ctx, name, holder, duration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) *LeaderLease); ok {
+		r0 = rf(ctx, name, holder, duration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*LeaderLease)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, holder, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_AcquireLeaderLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireLeaderLease'
+type MockDatabase_AcquireLeaderLease_Call struct {
+	*mock.Call
+}
+
+// AcquireLeaderLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - holder string
+//   - duration time.Duration
+func (_e *MockDatabase_Expecter) AcquireLeaderLease(ctx interface{}, name interface{}, holder interface{}, duration interface{}) *MockDatabase_AcquireLeaderLease_Call {
+	return &MockDatabase_AcquireLeaderLease_Call{Call: _e.mock.On("AcquireLeaderLease", ctx, name, holder, duration)}
+}
+
+func (_c *MockDatabase_AcquireLeaderLease_Call) Run(run func(ctx context.Context, name string, holder string, duration time.Duration)) *MockDatabase_AcquireLeaderLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_AcquireLeaderLease_Call) Return(_a0 *LeaderLease, _a1 error) *MockDatabase_AcquireLeaderLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_AcquireLeaderLease_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) (*LeaderLease, error)) *MockDatabase_AcquireLeaderLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddReconcilerOptOut provides a mock function with given fields: ctx, userID, teamSlug, reconcilerName
 func (_m *MockDatabase) AddReconcilerOptOut(ctx context.Context, userID *uuid.UUID, teamSlug *slug.Slug, reconcilerName sqlc.ReconcilerName) error {
 	ret := _m.Called(ctx, userID, teamSlug, reconcilerName)
@@ -73,13 +133,13 @@ func (_c *MockDatabase_AddReconcilerOptOut_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
-// AssignGlobalRoleToServiceAccount provides a mock function with given fields: ctx, serviceAccountID, roleName
-func (_m *MockDatabase) AssignGlobalRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName) error {
-	ret := _m.Called(ctx, serviceAccountID, roleName)
+// AssignGlobalRoleToServiceAccount provides a mock function with given fields: ctx, serviceAccountID, roleName, grantedBy
+func (_m *MockDatabase) AssignGlobalRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, grantedBy *string) error {
+	ret := _m.Called(ctx, serviceAccountID, roleName, grantedBy)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.RoleName) error); ok {
-		r0 = rf(ctx, serviceAccountID, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, roles.RoleName, *string) error); ok {
+		r0 = rf(ctx, serviceAccountID, roleName, grantedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -95,14 +155,15 @@ type MockDatabase_AssignGlobalRoleToServiceAccount_Call struct {
 // AssignGlobalRoleToServiceAccount is a helper method to define mock.On call
 //   - ctx context.Context
 //   - serviceAccountID uuid.UUID
-//   - roleName sqlc.RoleName
-func (_e *MockDatabase_Expecter) AssignGlobalRoleToServiceAccount(ctx interface{}, serviceAccountID interface{}, roleName interface{}) *MockDatabase_AssignGlobalRoleToServiceAccount_Call {
-	return &MockDatabase_AssignGlobalRoleToServiceAccount_Call{Call: _e.mock.On("AssignGlobalRoleToServiceAccount", ctx, serviceAccountID, roleName)}
+//   - roleName roles.RoleName
+//   - grantedBy *string
+func (_e *MockDatabase_Expecter) AssignGlobalRoleToServiceAccount(ctx interface{}, serviceAccountID interface{}, roleName interface{}, grantedBy interface{}) *MockDatabase_AssignGlobalRoleToServiceAccount_Call {
+	return &MockDatabase_AssignGlobalRoleToServiceAccount_Call{Call: _e.mock.On("AssignGlobalRoleToServiceAccount", ctx, serviceAccountID, roleName, grantedBy)}
 }
 
-func (_c *MockDatabase_AssignGlobalRoleToServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName)) *MockDatabase_AssignGlobalRoleToServiceAccount_Call {
+func (_c *MockDatabase_AssignGlobalRoleToServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, grantedBy *string)) *MockDatabase_AssignGlobalRoleToServiceAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sqlc.RoleName))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(roles.RoleName), args[3].(*string))
 	})
 	return _c
 }
@@ -112,18 +173,18 @@ func (_c *MockDatabase_AssignGlobalRoleToServiceAccount_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *MockDatabase_AssignGlobalRoleToServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID, sqlc.RoleName) error) *MockDatabase_AssignGlobalRoleToServiceAccount_Call {
+func (_c *MockDatabase_AssignGlobalRoleToServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID, roles.RoleName, *string) error) *MockDatabase_AssignGlobalRoleToServiceAccount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AssignGlobalRoleToUser provides a mock function with given fields: ctx, userID, roleName
-func (_m *MockDatabase) AssignGlobalRoleToUser(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName) error {
-	ret := _m.Called(ctx, userID, roleName)
+// AssignGlobalRoleToUser provides a mock function with given fields: ctx, userID, roleName, grantedBy
+func (_m *MockDatabase) AssignGlobalRoleToUser(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, grantedBy *string) error {
+	ret := _m.Called(ctx, userID, roleName, grantedBy)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.RoleName) error); ok {
-		r0 = rf(ctx, userID, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, roles.RoleName, *string) error); ok {
+		r0 = rf(ctx, userID, roleName, grantedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -139,14 +200,15 @@ type MockDatabase_AssignGlobalRoleToUser_Call struct {
 // AssignGlobalRoleToUser is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID uuid.UUID
-//   - roleName sqlc.RoleName
-func (_e *MockDatabase_Expecter) AssignGlobalRoleToUser(ctx interface{}, userID interface{}, roleName interface{}) *MockDatabase_AssignGlobalRoleToUser_Call {
-	return &MockDatabase_AssignGlobalRoleToUser_Call{Call: _e.mock.On("AssignGlobalRoleToUser", ctx, userID, roleName)}
+//   - roleName roles.RoleName
+//   - grantedBy *string
+func (_e *MockDatabase_Expecter) AssignGlobalRoleToUser(ctx interface{}, userID interface{}, roleName interface{}, grantedBy interface{}) *MockDatabase_AssignGlobalRoleToUser_Call {
+	return &MockDatabase_AssignGlobalRoleToUser_Call{Call: _e.mock.On("AssignGlobalRoleToUser", ctx, userID, roleName, grantedBy)}
 }
 
-func (_c *MockDatabase_AssignGlobalRoleToUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName)) *MockDatabase_AssignGlobalRoleToUser_Call {
+func (_c *MockDatabase_AssignGlobalRoleToUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, grantedBy *string)) *MockDatabase_AssignGlobalRoleToUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sqlc.RoleName))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(roles.RoleName), args[3].(*string))
 	})
 	return _c
 }
@@ -156,17 +218,62 @@ func (_c *MockDatabase_AssignGlobalRoleToUser_Call) Return(_a0 error) *MockDatab
 	return _c
 }
 
-func (_c *MockDatabase_AssignGlobalRoleToUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, sqlc.RoleName) error) *MockDatabase_AssignGlobalRoleToUser_Call {
+func (_c *MockDatabase_AssignGlobalRoleToUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, roles.RoleName, *string) error) *MockDatabase_AssignGlobalRoleToUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignServiceAccountRoleToUser provides a mock function with given fields: ctx, userID, roleName, serviceAccountID
+func (_m *MockDatabase) AssignServiceAccountRoleToUser(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, serviceAccountID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, roleName, serviceAccountID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, roles.RoleName, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, roleName, serviceAccountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_AssignServiceAccountRoleToUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignServiceAccountRoleToUser'
+type MockDatabase_AssignServiceAccountRoleToUser_Call struct {
+	*mock.Call
+}
+
+// AssignServiceAccountRoleToUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - roleName roles.RoleName
+//   - serviceAccountID uuid.UUID
+func (_e *MockDatabase_Expecter) AssignServiceAccountRoleToUser(ctx interface{}, userID interface{}, roleName interface{}, serviceAccountID interface{}) *MockDatabase_AssignServiceAccountRoleToUser_Call {
+	return &MockDatabase_AssignServiceAccountRoleToUser_Call{Call: _e.mock.On("AssignServiceAccountRoleToUser", ctx, userID, roleName, serviceAccountID)}
+}
+
+func (_c *MockDatabase_AssignServiceAccountRoleToUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, serviceAccountID uuid.UUID)) *MockDatabase_AssignServiceAccountRoleToUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(roles.RoleName), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_AssignServiceAccountRoleToUser_Call) Return(_a0 error) *MockDatabase_AssignServiceAccountRoleToUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_AssignServiceAccountRoleToUser_Call) RunAndReturn(run func(context.Context, uuid.UUID, roles.RoleName, uuid.UUID) error) *MockDatabase_AssignServiceAccountRoleToUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // AssignTeamRoleToServiceAccount provides a mock function with given fields: ctx, serviceAccountID, roleName, teamSlug
-func (_m *MockDatabase) AssignTeamRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName, teamSlug slug.Slug) error {
+func (_m *MockDatabase) AssignTeamRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, teamSlug slug.Slug) error {
 	ret := _m.Called(ctx, serviceAccountID, roleName, teamSlug)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.RoleName, slug.Slug) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, roles.RoleName, slug.Slug) error); ok {
 		r0 = rf(ctx, serviceAccountID, roleName, teamSlug)
 	} else {
 		r0 = ret.Error(0)
@@ -183,15 +290,15 @@ type MockDatabase_AssignTeamRoleToServiceAccount_Call struct {
 // AssignTeamRoleToServiceAccount is a helper method to define mock.On call
 //   - ctx context.Context
 //   - serviceAccountID uuid.UUID
-//   - roleName sqlc.RoleName
+//   - roleName roles.RoleName
 //   - teamSlug slug.Slug
 func (_e *MockDatabase_Expecter) AssignTeamRoleToServiceAccount(ctx interface{}, serviceAccountID interface{}, roleName interface{}, teamSlug interface{}) *MockDatabase_AssignTeamRoleToServiceAccount_Call {
 	return &MockDatabase_AssignTeamRoleToServiceAccount_Call{Call: _e.mock.On("AssignTeamRoleToServiceAccount", ctx, serviceAccountID, roleName, teamSlug)}
 }
 
-func (_c *MockDatabase_AssignTeamRoleToServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName, teamSlug slug.Slug)) *MockDatabase_AssignTeamRoleToServiceAccount_Call {
+func (_c *MockDatabase_AssignTeamRoleToServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, teamSlug slug.Slug)) *MockDatabase_AssignTeamRoleToServiceAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sqlc.RoleName), args[3].(slug.Slug))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(roles.RoleName), args[3].(slug.Slug))
 	})
 	return _c
 }
@@ -201,7 +308,229 @@ func (_c *MockDatabase_AssignTeamRoleToServiceAccount_Call) Return(_a0 error) *M
 	return _c
 }
 
-func (_c *MockDatabase_AssignTeamRoleToServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID, sqlc.RoleName, slug.Slug) error) *MockDatabase_AssignTeamRoleToServiceAccount_Call {
+func (_c *MockDatabase_AssignTeamRoleToServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID, roles.RoleName, slug.Slug) error) *MockDatabase_AssignTeamRoleToServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelTeamDeletion provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) CancelTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*Team, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 *Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) (*Team, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) *Team); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_CancelTeamDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelTeamDeletion'
+type MockDatabase_CancelTeamDeletion_Call struct {
+	*mock.Call
+}
+
+// CancelTeamDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) CancelTeamDeletion(ctx interface{}, teamSlug interface{}) *MockDatabase_CancelTeamDeletion_Call {
+	return &MockDatabase_CancelTeamDeletion_Call{Call: _e.mock.On("CancelTeamDeletion", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_CancelTeamDeletion_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_CancelTeamDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_CancelTeamDeletion_Call) Return(_a0 *Team, _a1 error) *MockDatabase_CancelTeamDeletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_CancelTeamDeletion_Call) RunAndReturn(run func(context.Context, slug.Slug) (*Team, error)) *MockDatabase_CancelTeamDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimAuditLogOutboxEntries provides a mock function with given fields: ctx, lockedBy, batchSize
+func (_m *MockDatabase) ClaimAuditLogOutboxEntries(ctx context.Context, lockedBy string, batchSize int) ([]*AuditLogOutboxEntry, error) {
+	ret := _m.Called(ctx, lockedBy, batchSize)
+
+	var r0 []*AuditLogOutboxEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*AuditLogOutboxEntry, error)); ok {
+		return rf(ctx, lockedBy, batchSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*AuditLogOutboxEntry); ok {
+		r0 = rf(ctx, lockedBy, batchSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*AuditLogOutboxEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, lockedBy, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ClaimAuditLogOutboxEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimAuditLogOutboxEntries'
+type MockDatabase_ClaimAuditLogOutboxEntries_Call struct {
+	*mock.Call
+}
+
+// ClaimAuditLogOutboxEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lockedBy string
+//   - batchSize int
+func (_e *MockDatabase_Expecter) ClaimAuditLogOutboxEntries(ctx interface{}, lockedBy interface{}, batchSize interface{}) *MockDatabase_ClaimAuditLogOutboxEntries_Call {
+	return &MockDatabase_ClaimAuditLogOutboxEntries_Call{Call: _e.mock.On("ClaimAuditLogOutboxEntries", ctx, lockedBy, batchSize)}
+}
+
+func (_c *MockDatabase_ClaimAuditLogOutboxEntries_Call) Run(run func(ctx context.Context, lockedBy string, batchSize int)) *MockDatabase_ClaimAuditLogOutboxEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ClaimAuditLogOutboxEntries_Call) Return(_a0 []*AuditLogOutboxEntry, _a1 error) *MockDatabase_ClaimAuditLogOutboxEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ClaimAuditLogOutboxEntries_Call) RunAndReturn(run func(context.Context, string, int) ([]*AuditLogOutboxEntry, error)) *MockDatabase_ClaimAuditLogOutboxEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimTeamSync provides a mock function with given fields: ctx, lockedBy
+func (_m *MockDatabase) ClaimTeamSync(ctx context.Context, lockedBy string) (*TeamSyncQueueItem, error) {
+	ret := _m.Called(ctx, lockedBy)
+
+	var r0 *TeamSyncQueueItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*TeamSyncQueueItem, error)); ok {
+		return rf(ctx, lockedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *TeamSyncQueueItem); ok {
+		r0 = rf(ctx, lockedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TeamSyncQueueItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, lockedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ClaimTeamSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimTeamSync'
+type MockDatabase_ClaimTeamSync_Call struct {
+	*mock.Call
+}
+
+// ClaimTeamSync is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lockedBy string
+func (_e *MockDatabase_Expecter) ClaimTeamSync(ctx interface{}, lockedBy interface{}) *MockDatabase_ClaimTeamSync_Call {
+	return &MockDatabase_ClaimTeamSync_Call{Call: _e.mock.On("ClaimTeamSync", ctx, lockedBy)}
+}
+
+func (_c *MockDatabase_ClaimTeamSync_Call) Run(run func(ctx context.Context, lockedBy string)) *MockDatabase_ClaimTeamSync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ClaimTeamSync_Call) Return(_a0 *TeamSyncQueueItem, _a1 error) *MockDatabase_ClaimTeamSync_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ClaimTeamSync_Call) RunAndReturn(run func(context.Context, string) (*TeamSyncQueueItem, error)) *MockDatabase_ClaimTeamSync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimWebhookDeliveries provides a mock function with given fields: ctx, lockedBy, batchSize
+func (_m *MockDatabase) ClaimWebhookDeliveries(ctx context.Context, lockedBy string, batchSize int) ([]*WebhookDelivery, error) {
+	ret := _m.Called(ctx, lockedBy, batchSize)
+
+	var r0 []*WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*WebhookDelivery, error)); ok {
+		return rf(ctx, lockedBy, batchSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*WebhookDelivery); ok {
+		r0 = rf(ctx, lockedBy, batchSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, lockedBy, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ClaimWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimWebhookDeliveries'
+type MockDatabase_ClaimWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lockedBy string
+//   - batchSize int
+func (_e *MockDatabase_Expecter) ClaimWebhookDeliveries(ctx interface{}, lockedBy interface{}, batchSize interface{}) *MockDatabase_ClaimWebhookDeliveries_Call {
+	return &MockDatabase_ClaimWebhookDeliveries_Call{Call: _e.mock.On("ClaimWebhookDeliveries", ctx, lockedBy, batchSize)}
+}
+
+func (_c *MockDatabase_ClaimWebhookDeliveries_Call) Run(run func(ctx context.Context, lockedBy string, batchSize int)) *MockDatabase_ClaimWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ClaimWebhookDeliveries_Call) Return(_a0 []*WebhookDelivery, _a1 error) *MockDatabase_ClaimWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ClaimWebhookDeliveries_Call) RunAndReturn(run func(context.Context, string, int) ([]*WebhookDelivery, error)) *MockDatabase_ClaimWebhookDeliveries_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -338,66 +667,131 @@ func (_c *MockDatabase_ConfirmTeamDeleteKey_Call) RunAndReturn(run func(context.
 	return _c
 }
 
-// CreateAPIKey provides a mock function with given fields: ctx, apiKey, serviceAccountID
-func (_m *MockDatabase) CreateAPIKey(ctx context.Context, apiKey string, serviceAccountID uuid.UUID) error {
-	ret := _m.Called(ctx, apiKey, serviceAccountID)
+// CountGlobalAdmins provides a mock function with given fields: ctx
+func (_m *MockDatabase) CountGlobalAdmins(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) error); ok {
-		r0 = rf(ctx, apiKey, serviceAccountID)
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
-type MockDatabase_CreateAPIKey_Call struct {
+// MockDatabase_CountGlobalAdmins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountGlobalAdmins'
+type MockDatabase_CountGlobalAdmins_Call struct {
 	*mock.Call
 }
 
-// CreateAPIKey is a helper method to define mock.On call
+// CountGlobalAdmins is a helper method to define mock.On call
 //   - ctx context.Context
-//   - apiKey string
-//   - serviceAccountID uuid.UUID
-func (_e *MockDatabase_Expecter) CreateAPIKey(ctx interface{}, apiKey interface{}, serviceAccountID interface{}) *MockDatabase_CreateAPIKey_Call {
-	return &MockDatabase_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", ctx, apiKey, serviceAccountID)}
+func (_e *MockDatabase_Expecter) CountGlobalAdmins(ctx interface{}) *MockDatabase_CountGlobalAdmins_Call {
+	return &MockDatabase_CountGlobalAdmins_Call{Call: _e.mock.On("CountGlobalAdmins", ctx)}
 }
 
-func (_c *MockDatabase_CreateAPIKey_Call) Run(run func(ctx context.Context, apiKey string, serviceAccountID uuid.UUID)) *MockDatabase_CreateAPIKey_Call {
+func (_c *MockDatabase_CountGlobalAdmins_Call) Run(run func(ctx context.Context)) *MockDatabase_CountGlobalAdmins_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_CreateAPIKey_Call) Return(_a0 error) *MockDatabase_CreateAPIKey_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_CountGlobalAdmins_Call) Return(_a0 int, _a1 error) *MockDatabase_CountGlobalAdmins_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_CreateAPIKey_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) error) *MockDatabase_CreateAPIKey_Call {
+func (_c *MockDatabase_CountGlobalAdmins_Call) RunAndReturn(run func(context.Context) (int, error)) *MockDatabase_CountGlobalAdmins_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateAuditLogEntry provides a mock function with given fields: ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message
-func (_m *MockDatabase) CreateAuditLogEntry(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string) error {
-	ret := _m.Called(ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message)
+// CreateAPIKey provides a mock function with given fields: ctx, apiKey, serviceAccountID, expiresAt
+func (_m *MockDatabase) CreateAPIKey(ctx context.Context, apiKey string, serviceAccountID uuid.UUID, expiresAt *time.Time) (*ApiKey, error) {
+	ret := _m.Called(ctx, apiKey, serviceAccountID, expiresAt)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, types.ComponentName, *string, types.AuditLogsTargetType, string, types.AuditAction, string) error); ok {
-		r0 = rf(ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message)
+	var r0 *ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *time.Time) (*ApiKey, error)); ok {
+		return rf(ctx, apiKey, serviceAccountID, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID, *time.Time) *ApiKey); ok {
+		r0 = rf(ctx, apiKey, serviceAccountID, expiresAt)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ApiKey)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID, *time.Time) error); ok {
+		r1 = rf(ctx, apiKey, serviceAccountID, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_CreateAuditLogEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditLogEntry'
-type MockDatabase_CreateAuditLogEntry_Call struct {
+// MockDatabase_CreateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIKey'
+type MockDatabase_CreateAPIKey_Call struct {
+	*mock.Call
+}
+
+// CreateAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey string
+//   - serviceAccountID uuid.UUID
+//   - expiresAt *time.Time
+func (_e *MockDatabase_Expecter) CreateAPIKey(ctx interface{}, apiKey interface{}, serviceAccountID interface{}, expiresAt interface{}) *MockDatabase_CreateAPIKey_Call {
+	return &MockDatabase_CreateAPIKey_Call{Call: _e.mock.On("CreateAPIKey", ctx, apiKey, serviceAccountID, expiresAt)}
+}
+
+func (_c *MockDatabase_CreateAPIKey_Call) Run(run func(ctx context.Context, apiKey string, serviceAccountID uuid.UUID, expiresAt *time.Time)) *MockDatabase_CreateAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_CreateAPIKey_Call) Return(_a0 *ApiKey, _a1 error) *MockDatabase_CreateAPIKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_CreateAPIKey_Call) RunAndReturn(run func(context.Context, string, uuid.UUID, *time.Time) (*ApiKey, error)) *MockDatabase_CreateAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAuditLogEntry provides a mock function with given fields: ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message, changes
+func (_m *MockDatabase) CreateAuditLogEntry(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string, changes []*AuditLogChange) error {
+	ret := _m.Called(ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message, changes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, types.ComponentName, *string, types.AuditLogsTargetType, string, types.AuditAction, string, []*AuditLogChange) error); ok {
+		r0 = rf(ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_CreateAuditLogEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditLogEntry'
+type MockDatabase_CreateAuditLogEntry_Call struct {
 	*mock.Call
 }
 
@@ -410,13 +804,14 @@ type MockDatabase_CreateAuditLogEntry_Call struct {
 //   - targetIdentifier string
 //   - action types.AuditAction
 //   - message string
-func (_e *MockDatabase_Expecter) CreateAuditLogEntry(ctx interface{}, correlationID interface{}, componentName interface{}, actor interface{}, targetType interface{}, targetIdentifier interface{}, action interface{}, message interface{}) *MockDatabase_CreateAuditLogEntry_Call {
-	return &MockDatabase_CreateAuditLogEntry_Call{Call: _e.mock.On("CreateAuditLogEntry", ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message)}
+//   - changes []*AuditLogChange
+func (_e *MockDatabase_Expecter) CreateAuditLogEntry(ctx interface{}, correlationID interface{}, componentName interface{}, actor interface{}, targetType interface{}, targetIdentifier interface{}, action interface{}, message interface{}, changes interface{}) *MockDatabase_CreateAuditLogEntry_Call {
+	return &MockDatabase_CreateAuditLogEntry_Call{Call: _e.mock.On("CreateAuditLogEntry", ctx, correlationID, componentName, actor, targetType, targetIdentifier, action, message, changes)}
 }
 
-func (_c *MockDatabase_CreateAuditLogEntry_Call) Run(run func(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string)) *MockDatabase_CreateAuditLogEntry_Call {
+func (_c *MockDatabase_CreateAuditLogEntry_Call) Run(run func(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string, changes []*AuditLogChange)) *MockDatabase_CreateAuditLogEntry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(types.ComponentName), args[3].(*string), args[4].(types.AuditLogsTargetType), args[5].(string), args[6].(types.AuditAction), args[7].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(types.ComponentName), args[3].(*string), args[4].(types.AuditLogsTargetType), args[5].(string), args[6].(types.AuditAction), args[7].(string), args[8].([]*AuditLogChange))
 	})
 	return _c
 }
@@ -426,7 +821,7 @@ func (_c *MockDatabase_CreateAuditLogEntry_Call) Return(_a0 error) *MockDatabase
 	return _c
 }
 
-func (_c *MockDatabase_CreateAuditLogEntry_Call) RunAndReturn(run func(context.Context, uuid.UUID, types.ComponentName, *string, types.AuditLogsTargetType, string, types.AuditAction, string) error) *MockDatabase_CreateAuditLogEntry_Call {
+func (_c *MockDatabase_CreateAuditLogEntry_Call) RunAndReturn(run func(context.Context, uuid.UUID, types.ComponentName, *string, types.AuditLogsTargetType, string, types.AuditAction, string, []*AuditLogChange) error) *MockDatabase_CreateAuditLogEntry_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -476,25 +871,81 @@ func (_c *MockDatabase_CreateRepositoryAuthorization_Call) RunAndReturn(run func
 	return _c
 }
 
-// CreateServiceAccount provides a mock function with given fields: ctx, name
-func (_m *MockDatabase) CreateServiceAccount(ctx context.Context, name string) (*ServiceAccount, error) {
-	ret := _m.Called(ctx, name)
+// CreateRoleDefinition provides a mock function with given fields: ctx, roleName, description
+func (_m *MockDatabase) CreateRoleDefinition(ctx context.Context, roleName roles.RoleName, description string) (*RoleDefinition, error) {
+	ret := _m.Called(ctx, roleName, description)
+
+	var r0 *RoleDefinition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName, string) (*RoleDefinition, error)); ok {
+		return rf(ctx, roleName, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName, string) *RoleDefinition); ok {
+		r0 = rf(ctx, roleName, description)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*RoleDefinition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, roles.RoleName, string) error); ok {
+		r1 = rf(ctx, roleName, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_CreateRoleDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRoleDefinition'
+type MockDatabase_CreateRoleDefinition_Call struct {
+	*mock.Call
+}
+
+// CreateRoleDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName roles.RoleName
+//   - description string
+func (_e *MockDatabase_Expecter) CreateRoleDefinition(ctx interface{}, roleName interface{}, description interface{}) *MockDatabase_CreateRoleDefinition_Call {
+	return &MockDatabase_CreateRoleDefinition_Call{Call: _e.mock.On("CreateRoleDefinition", ctx, roleName, description)}
+}
+
+func (_c *MockDatabase_CreateRoleDefinition_Call) Run(run func(ctx context.Context, roleName roles.RoleName, description string)) *MockDatabase_CreateRoleDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(roles.RoleName), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_CreateRoleDefinition_Call) Return(_a0 *RoleDefinition, _a1 error) *MockDatabase_CreateRoleDefinition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_CreateRoleDefinition_Call) RunAndReturn(run func(context.Context, roles.RoleName, string) (*RoleDefinition, error)) *MockDatabase_CreateRoleDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateServiceAccount provides a mock function with given fields: ctx, name, teamSlug
+func (_m *MockDatabase) CreateServiceAccount(ctx context.Context, name string, teamSlug *slug.Slug) (*ServiceAccount, error) {
+	ret := _m.Called(ctx, name, teamSlug)
 
 	var r0 *ServiceAccount
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*ServiceAccount, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *slug.Slug) (*ServiceAccount, error)); ok {
+		return rf(ctx, name, teamSlug)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *ServiceAccount); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *slug.Slug) *ServiceAccount); ok {
+		r0 = rf(ctx, name, teamSlug)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*ServiceAccount)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *slug.Slug) error); ok {
+		r1 = rf(ctx, name, teamSlug)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -510,13 +961,14 @@ type MockDatabase_CreateServiceAccount_Call struct {
 // CreateServiceAccount is a helper method to define mock.On call
 //   - ctx context.Context
 //   - name string
-func (_e *MockDatabase_Expecter) CreateServiceAccount(ctx interface{}, name interface{}) *MockDatabase_CreateServiceAccount_Call {
-	return &MockDatabase_CreateServiceAccount_Call{Call: _e.mock.On("CreateServiceAccount", ctx, name)}
+//   - teamSlug *slug.Slug
+func (_e *MockDatabase_Expecter) CreateServiceAccount(ctx interface{}, name interface{}, teamSlug interface{}) *MockDatabase_CreateServiceAccount_Call {
+	return &MockDatabase_CreateServiceAccount_Call{Call: _e.mock.On("CreateServiceAccount", ctx, name, teamSlug)}
 }
 
-func (_c *MockDatabase_CreateServiceAccount_Call) Run(run func(ctx context.Context, name string)) *MockDatabase_CreateServiceAccount_Call {
+func (_c *MockDatabase_CreateServiceAccount_Call) Run(run func(ctx context.Context, name string, teamSlug *slug.Slug)) *MockDatabase_CreateServiceAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*slug.Slug))
 	})
 	return _c
 }
@@ -526,7 +978,7 @@ func (_c *MockDatabase_CreateServiceAccount_Call) Return(_a0 *ServiceAccount, _a
 	return _c
 }
 
-func (_c *MockDatabase_CreateServiceAccount_Call) RunAndReturn(run func(context.Context, string) (*ServiceAccount, error)) *MockDatabase_CreateServiceAccount_Call {
+func (_c *MockDatabase_CreateServiceAccount_Call) RunAndReturn(run func(context.Context, string, *slug.Slug) (*ServiceAccount, error)) *MockDatabase_CreateServiceAccount_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -699,6 +1151,64 @@ func (_c *MockDatabase_CreateTeamDeleteKey_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// CreateTeamSyncRun provides a mock function with given fields: ctx, teamSlug, correlationID, trigger, reconcilers
+func (_m *MockDatabase) CreateTeamSyncRun(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName) (*TeamSyncRun, error) {
+	ret := _m.Called(ctx, teamSlug, correlationID, trigger, reconcilers)
+
+	var r0 *TeamSyncRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, uuid.UUID, sqlc.TeamSyncTrigger, []sqlc.ReconcilerName) (*TeamSyncRun, error)); ok {
+		return rf(ctx, teamSlug, correlationID, trigger, reconcilers)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, uuid.UUID, sqlc.TeamSyncTrigger, []sqlc.ReconcilerName) *TeamSyncRun); ok {
+		r0 = rf(ctx, teamSlug, correlationID, trigger, reconcilers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TeamSyncRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, uuid.UUID, sqlc.TeamSyncTrigger, []sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, teamSlug, correlationID, trigger, reconcilers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_CreateTeamSyncRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTeamSyncRun'
+type MockDatabase_CreateTeamSyncRun_Call struct {
+	*mock.Call
+}
+
+// CreateTeamSyncRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - correlationID uuid.UUID
+//   - trigger sqlc.TeamSyncTrigger
+//   - reconcilers []sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) CreateTeamSyncRun(ctx interface{}, teamSlug interface{}, correlationID interface{}, trigger interface{}, reconcilers interface{}) *MockDatabase_CreateTeamSyncRun_Call {
+	return &MockDatabase_CreateTeamSyncRun_Call{Call: _e.mock.On("CreateTeamSyncRun", ctx, teamSlug, correlationID, trigger, reconcilers)}
+}
+
+func (_c *MockDatabase_CreateTeamSyncRun_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName)) *MockDatabase_CreateTeamSyncRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(uuid.UUID), args[3].(sqlc.TeamSyncTrigger), args[4].([]sqlc.ReconcilerName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_CreateTeamSyncRun_Call) Return(_a0 *TeamSyncRun, _a1 error) *MockDatabase_CreateTeamSyncRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_CreateTeamSyncRun_Call) RunAndReturn(run func(context.Context, slug.Slug, uuid.UUID, sqlc.TeamSyncTrigger, []sqlc.ReconcilerName) (*TeamSyncRun, error)) *MockDatabase_CreateTeamSyncRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: ctx, name, email, externalID
 func (_m *MockDatabase) CreateUser(ctx context.Context, name string, email string, externalID string) (*User, error) {
 	ret := _m.Called(ctx, name, email, externalID)
@@ -756,25 +1266,25 @@ func (_c *MockDatabase_CreateUser_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
-// DangerousGetReconcilerConfigValues provides a mock function with given fields: ctx, reconcilerName
-func (_m *MockDatabase) DangerousGetReconcilerConfigValues(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*ReconcilerConfigValues, error) {
-	ret := _m.Called(ctx, reconcilerName)
+// CreateUserSyncRun provides a mock function with given fields: ctx, correlationID
+func (_m *MockDatabase) CreateUserSyncRun(ctx context.Context, correlationID uuid.UUID) (*UserSyncRun, error) {
+	ret := _m.Called(ctx, correlationID)
 
-	var r0 *ReconcilerConfigValues
+	var r0 *UserSyncRun
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*ReconcilerConfigValues, error)); ok {
-		return rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*UserSyncRun, error)); ok {
+		return rf(ctx, correlationID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *ReconcilerConfigValues); ok {
-		r0 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *UserSyncRun); ok {
+		r0 = rf(ctx, correlationID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ReconcilerConfigValues)
+			r0 = ret.Get(0).(*UserSyncRun)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
-		r1 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, correlationID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -782,226 +1292,314 @@ func (_m *MockDatabase) DangerousGetReconcilerConfigValues(ctx context.Context,
 	return r0, r1
 }
 
-// MockDatabase_DangerousGetReconcilerConfigValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DangerousGetReconcilerConfigValues'
-type MockDatabase_DangerousGetReconcilerConfigValues_Call struct {
+// MockDatabase_CreateUserSyncRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserSyncRun'
+type MockDatabase_CreateUserSyncRun_Call struct {
 	*mock.Call
 }
 
-// DangerousGetReconcilerConfigValues is a helper method to define mock.On call
+// CreateUserSyncRun is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) DangerousGetReconcilerConfigValues(ctx interface{}, reconcilerName interface{}) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
-	return &MockDatabase_DangerousGetReconcilerConfigValues_Call{Call: _e.mock.On("DangerousGetReconcilerConfigValues", ctx, reconcilerName)}
+//   - correlationID uuid.UUID
+func (_e *MockDatabase_Expecter) CreateUserSyncRun(ctx interface{}, correlationID interface{}) *MockDatabase_CreateUserSyncRun_Call {
+	return &MockDatabase_CreateUserSyncRun_Call{Call: _e.mock.On("CreateUserSyncRun", ctx, correlationID)}
 }
 
-func (_c *MockDatabase_DangerousGetReconcilerConfigValues_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
+func (_c *MockDatabase_CreateUserSyncRun_Call) Run(run func(ctx context.Context, correlationID uuid.UUID)) *MockDatabase_CreateUserSyncRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_DangerousGetReconcilerConfigValues_Call) Return(_a0 *ReconcilerConfigValues, _a1 error) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
+func (_c *MockDatabase_CreateUserSyncRun_Call) Return(_a0 *UserSyncRun, _a1 error) *MockDatabase_CreateUserSyncRun_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_DangerousGetReconcilerConfigValues_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*ReconcilerConfigValues, error)) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
+func (_c *MockDatabase_CreateUserSyncRun_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*UserSyncRun, error)) *MockDatabase_CreateUserSyncRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteServiceAccount provides a mock function with given fields: ctx, serviceAccountID
-func (_m *MockDatabase) DeleteServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
-	ret := _m.Called(ctx, serviceAccountID)
+// CreateWebhookDeliveries provides a mock function with given fields: ctx, eventID, eventType, payload
+func (_m *MockDatabase) CreateWebhookDeliveries(ctx context.Context, eventID uuid.UUID, eventType sqlc.WebhookEventType, payload []byte) (int64, error) {
+	ret := _m.Called(ctx, eventID, eventType, payload)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, serviceAccountID)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.WebhookEventType, []byte) (int64, error)); ok {
+		return rf(ctx, eventID, eventType, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.WebhookEventType, []byte) int64); ok {
+		r0 = rf(ctx, eventID, eventType, payload)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, sqlc.WebhookEventType, []byte) error); ok {
+		r1 = rf(ctx, eventID, eventType, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_DeleteServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteServiceAccount'
-type MockDatabase_DeleteServiceAccount_Call struct {
+// MockDatabase_CreateWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookDeliveries'
+type MockDatabase_CreateWebhookDeliveries_Call struct {
 	*mock.Call
 }
 
-// DeleteServiceAccount is a helper method to define mock.On call
+// CreateWebhookDeliveries is a helper method to define mock.On call
 //   - ctx context.Context
-//   - serviceAccountID uuid.UUID
-func (_e *MockDatabase_Expecter) DeleteServiceAccount(ctx interface{}, serviceAccountID interface{}) *MockDatabase_DeleteServiceAccount_Call {
-	return &MockDatabase_DeleteServiceAccount_Call{Call: _e.mock.On("DeleteServiceAccount", ctx, serviceAccountID)}
+//   - eventID uuid.UUID
+//   - eventType sqlc.WebhookEventType
+//   - payload []byte
+func (_e *MockDatabase_Expecter) CreateWebhookDeliveries(ctx interface{}, eventID interface{}, eventType interface{}, payload interface{}) *MockDatabase_CreateWebhookDeliveries_Call {
+	return &MockDatabase_CreateWebhookDeliveries_Call{Call: _e.mock.On("CreateWebhookDeliveries", ctx, eventID, eventType, payload)}
 }
 
-func (_c *MockDatabase_DeleteServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_DeleteServiceAccount_Call {
+func (_c *MockDatabase_CreateWebhookDeliveries_Call) Run(run func(ctx context.Context, eventID uuid.UUID, eventType sqlc.WebhookEventType, payload []byte)) *MockDatabase_CreateWebhookDeliveries_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sqlc.WebhookEventType), args[3].([]byte))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_DeleteServiceAccount_Call) Return(_a0 error) *MockDatabase_DeleteServiceAccount_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_CreateWebhookDeliveries_Call) Return(_a0 int64, _a1 error) *MockDatabase_CreateWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_DeleteServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_DeleteServiceAccount_Call {
+func (_c *MockDatabase_CreateWebhookDeliveries_Call) RunAndReturn(run func(context.Context, uuid.UUID, sqlc.WebhookEventType, []byte) (int64, error)) *MockDatabase_CreateWebhookDeliveries_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteSession provides a mock function with given fields: ctx, sessionID
-func (_m *MockDatabase) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
-	ret := _m.Called(ctx, sessionID)
+// CreateWebhookSubscription provides a mock function with given fields: ctx, url, secret, eventTypes
+func (_m *MockDatabase) CreateWebhookSubscription(ctx context.Context, url string, secret string, eventTypes []sqlc.WebhookEventType) (*WebhookSubscription, error) {
+	ret := _m.Called(ctx, url, secret, eventTypes)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, sessionID)
+	var r0 *WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []sqlc.WebhookEventType) (*WebhookSubscription, error)); ok {
+		return rf(ctx, url, secret, eventTypes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []sqlc.WebhookEventType) *WebhookSubscription); ok {
+		r0 = rf(ctx, url, secret, eventTypes)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*WebhookSubscription)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []sqlc.WebhookEventType) error); ok {
+		r1 = rf(ctx, url, secret, eventTypes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
-type MockDatabase_DeleteSession_Call struct {
+// MockDatabase_CreateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookSubscription'
+type MockDatabase_CreateWebhookSubscription_Call struct {
 	*mock.Call
 }
 
-// DeleteSession is a helper method to define mock.On call
+// CreateWebhookSubscription is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sessionID uuid.UUID
-func (_e *MockDatabase_Expecter) DeleteSession(ctx interface{}, sessionID interface{}) *MockDatabase_DeleteSession_Call {
-	return &MockDatabase_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, sessionID)}
+//   - url string
+//   - secret string
+//   - eventTypes []sqlc.WebhookEventType
+func (_e *MockDatabase_Expecter) CreateWebhookSubscription(ctx interface{}, url interface{}, secret interface{}, eventTypes interface{}) *MockDatabase_CreateWebhookSubscription_Call {
+	return &MockDatabase_CreateWebhookSubscription_Call{Call: _e.mock.On("CreateWebhookSubscription", ctx, url, secret, eventTypes)}
 }
 
-func (_c *MockDatabase_DeleteSession_Call) Run(run func(ctx context.Context, sessionID uuid.UUID)) *MockDatabase_DeleteSession_Call {
+func (_c *MockDatabase_CreateWebhookSubscription_Call) Run(run func(ctx context.Context, url string, secret string, eventTypes []sqlc.WebhookEventType)) *MockDatabase_CreateWebhookSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]sqlc.WebhookEventType))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_DeleteSession_Call) Return(_a0 error) *MockDatabase_DeleteSession_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_CreateWebhookSubscription_Call) Return(_a0 *WebhookSubscription, _a1 error) *MockDatabase_CreateWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_DeleteSession_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_DeleteSession_Call {
+func (_c *MockDatabase_CreateWebhookSubscription_Call) RunAndReturn(run func(context.Context, string, string, []sqlc.WebhookEventType) (*WebhookSubscription, error)) *MockDatabase_CreateWebhookSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTeam provides a mock function with given fields: ctx, teamSlug
-func (_m *MockDatabase) DeleteTeam(ctx context.Context, teamSlug slug.Slug) error {
-	ret := _m.Called(ctx, teamSlug)
+// DangerousGetReconcilerConfigValues provides a mock function with given fields: ctx, reconcilerName
+func (_m *MockDatabase) DangerousGetReconcilerConfigValues(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*ReconcilerConfigValues, error) {
+	ret := _m.Called(ctx, reconcilerName)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) error); ok {
-		r0 = rf(ctx, teamSlug)
+	var r0 *ReconcilerConfigValues
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*ReconcilerConfigValues, error)); ok {
+		return rf(ctx, reconcilerName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *ReconcilerConfigValues); ok {
+		r0 = rf(ctx, reconcilerName)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ReconcilerConfigValues)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, reconcilerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_DeleteTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTeam'
-type MockDatabase_DeleteTeam_Call struct {
+// MockDatabase_DangerousGetReconcilerConfigValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DangerousGetReconcilerConfigValues'
+type MockDatabase_DangerousGetReconcilerConfigValues_Call struct {
 	*mock.Call
 }
 
-// DeleteTeam is a helper method to define mock.On call
+// DangerousGetReconcilerConfigValues is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-func (_e *MockDatabase_Expecter) DeleteTeam(ctx interface{}, teamSlug interface{}) *MockDatabase_DeleteTeam_Call {
-	return &MockDatabase_DeleteTeam_Call{Call: _e.mock.On("DeleteTeam", ctx, teamSlug)}
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) DangerousGetReconcilerConfigValues(ctx interface{}, reconcilerName interface{}) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
+	return &MockDatabase_DangerousGetReconcilerConfigValues_Call{Call: _e.mock.On("DangerousGetReconcilerConfigValues", ctx, reconcilerName)}
 }
 
-func (_c *MockDatabase_DeleteTeam_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_DeleteTeam_Call {
+func (_c *MockDatabase_DangerousGetReconcilerConfigValues_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug))
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_DeleteTeam_Call) Return(_a0 error) *MockDatabase_DeleteTeam_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_DangerousGetReconcilerConfigValues_Call) Return(_a0 *ReconcilerConfigValues, _a1 error) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_DeleteTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) error) *MockDatabase_DeleteTeam_Call {
+func (_c *MockDatabase_DangerousGetReconcilerConfigValues_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*ReconcilerConfigValues, error)) *MockDatabase_DangerousGetReconcilerConfigValues_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUser provides a mock function with given fields: ctx, userID
-func (_m *MockDatabase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
-	ret := _m.Called(ctx, userID)
+// DeleteAuditLogOutboxEntries provides a mock function with given fields: ctx
+func (_m *MockDatabase) DeleteAuditLogOutboxEntries(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, userID)
-	} else {
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_DeleteAuditLogOutboxEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAuditLogOutboxEntries'
+type MockDatabase_DeleteAuditLogOutboxEntries_Call struct {
+	*mock.Call
+}
+
+// DeleteAuditLogOutboxEntries is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) DeleteAuditLogOutboxEntries(ctx interface{}) *MockDatabase_DeleteAuditLogOutboxEntries_Call {
+	return &MockDatabase_DeleteAuditLogOutboxEntries_Call{Call: _e.mock.On("DeleteAuditLogOutboxEntries", ctx)}
+}
+
+func (_c *MockDatabase_DeleteAuditLogOutboxEntries_Call) Run(run func(ctx context.Context)) *MockDatabase_DeleteAuditLogOutboxEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_DeleteAuditLogOutboxEntries_Call) Return(_a0 int64, _a1 error) *MockDatabase_DeleteAuditLogOutboxEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_DeleteAuditLogOutboxEntries_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockDatabase_DeleteAuditLogOutboxEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAuditLogOutboxEntry provides a mock function with given fields: ctx, id
+func (_m *MockDatabase) DeleteAuditLogOutboxEntry(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
 		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// MockDatabase_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
-type MockDatabase_DeleteUser_Call struct {
+// MockDatabase_DeleteAuditLogOutboxEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAuditLogOutboxEntry'
+type MockDatabase_DeleteAuditLogOutboxEntry_Call struct {
 	*mock.Call
 }
 
-// DeleteUser is a helper method to define mock.On call
+// DeleteAuditLogOutboxEntry is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-func (_e *MockDatabase_Expecter) DeleteUser(ctx interface{}, userID interface{}) *MockDatabase_DeleteUser_Call {
-	return &MockDatabase_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userID)}
+//   - id int64
+func (_e *MockDatabase_Expecter) DeleteAuditLogOutboxEntry(ctx interface{}, id interface{}) *MockDatabase_DeleteAuditLogOutboxEntry_Call {
+	return &MockDatabase_DeleteAuditLogOutboxEntry_Call{Call: _e.mock.On("DeleteAuditLogOutboxEntry", ctx, id)}
 }
 
-func (_c *MockDatabase_DeleteUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockDatabase_DeleteUser_Call {
+func (_c *MockDatabase_DeleteAuditLogOutboxEntry_Call) Run(run func(ctx context.Context, id int64)) *MockDatabase_DeleteAuditLogOutboxEntry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_DeleteUser_Call) Return(_a0 error) *MockDatabase_DeleteUser_Call {
+func (_c *MockDatabase_DeleteAuditLogOutboxEntry_Call) Return(_a0 error) *MockDatabase_DeleteAuditLogOutboxEntry_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_DeleteUser_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_DeleteUser_Call {
+func (_c *MockDatabase_DeleteAuditLogOutboxEntry_Call) RunAndReturn(run func(context.Context, int64) error) *MockDatabase_DeleteAuditLogOutboxEntry_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DisableReconciler provides a mock function with given fields: ctx, reconcilerName
-func (_m *MockDatabase) DisableReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
-	ret := _m.Called(ctx, reconcilerName)
+// DeleteAuditLogsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockDatabase) DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, ids)
 
-	var r0 *Reconciler
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)); ok {
-		return rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (int64, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *Reconciler); ok {
-		r0 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) int64); ok {
+		r0 = rf(ctx, ids)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Reconciler)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
-		r1 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1009,54 +1607,52 @@ func (_m *MockDatabase) DisableReconciler(ctx context.Context, reconcilerName sq
 	return r0, r1
 }
 
-// MockDatabase_DisableReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableReconciler'
-type MockDatabase_DisableReconciler_Call struct {
+// MockDatabase_DeleteAuditLogsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAuditLogsByIDs'
+type MockDatabase_DeleteAuditLogsByIDs_Call struct {
 	*mock.Call
 }
 
-// DisableReconciler is a helper method to define mock.On call
+// DeleteAuditLogsByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) DisableReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_DisableReconciler_Call {
-	return &MockDatabase_DisableReconciler_Call{Call: _e.mock.On("DisableReconciler", ctx, reconcilerName)}
+//   - ids []uuid.UUID
+func (_e *MockDatabase_Expecter) DeleteAuditLogsByIDs(ctx interface{}, ids interface{}) *MockDatabase_DeleteAuditLogsByIDs_Call {
+	return &MockDatabase_DeleteAuditLogsByIDs_Call{Call: _e.mock.On("DeleteAuditLogsByIDs", ctx, ids)}
 }
 
-func (_c *MockDatabase_DisableReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_DisableReconciler_Call {
+func (_c *MockDatabase_DeleteAuditLogsByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockDatabase_DeleteAuditLogsByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_DisableReconciler_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_DisableReconciler_Call {
+func (_c *MockDatabase_DeleteAuditLogsByIDs_Call) Return(_a0 int64, _a1 error) *MockDatabase_DeleteAuditLogsByIDs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_DisableReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_DisableReconciler_Call {
+func (_c *MockDatabase_DeleteAuditLogsByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) (int64, error)) *MockDatabase_DeleteAuditLogsByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EnableReconciler provides a mock function with given fields: ctx, reconcilerName
-func (_m *MockDatabase) EnableReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
-	ret := _m.Called(ctx, reconcilerName)
+// DeleteOldTeamSyncRuns provides a mock function with given fields: ctx, teamSlug, runsToKeep
+func (_m *MockDatabase) DeleteOldTeamSyncRuns(ctx context.Context, teamSlug slug.Slug, runsToKeep int) (int64, error) {
+	ret := _m.Called(ctx, teamSlug, runsToKeep)
 
-	var r0 *Reconciler
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)); ok {
-		return rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, int) (int64, error)); ok {
+		return rf(ctx, teamSlug, runsToKeep)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *Reconciler); ok {
-		r0 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, int) int64); ok {
+		r0 = rf(ctx, teamSlug, runsToKeep)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Reconciler)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
-		r1 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, int) error); ok {
+		r1 = rf(ctx, teamSlug, runsToKeep)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1064,54 +1660,53 @@ func (_m *MockDatabase) EnableReconciler(ctx context.Context, reconcilerName sql
 	return r0, r1
 }
 
-// MockDatabase_EnableReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableReconciler'
-type MockDatabase_EnableReconciler_Call struct {
+// MockDatabase_DeleteOldTeamSyncRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOldTeamSyncRuns'
+type MockDatabase_DeleteOldTeamSyncRuns_Call struct {
 	*mock.Call
 }
 
-// EnableReconciler is a helper method to define mock.On call
+// DeleteOldTeamSyncRuns is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) EnableReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_EnableReconciler_Call {
-	return &MockDatabase_EnableReconciler_Call{Call: _e.mock.On("EnableReconciler", ctx, reconcilerName)}
+//   - teamSlug slug.Slug
+//   - runsToKeep int
+func (_e *MockDatabase_Expecter) DeleteOldTeamSyncRuns(ctx interface{}, teamSlug interface{}, runsToKeep interface{}) *MockDatabase_DeleteOldTeamSyncRuns_Call {
+	return &MockDatabase_DeleteOldTeamSyncRuns_Call{Call: _e.mock.On("DeleteOldTeamSyncRuns", ctx, teamSlug, runsToKeep)}
 }
 
-func (_c *MockDatabase_EnableReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_EnableReconciler_Call {
+func (_c *MockDatabase_DeleteOldTeamSyncRuns_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, runsToKeep int)) *MockDatabase_DeleteOldTeamSyncRuns_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_EnableReconciler_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_EnableReconciler_Call {
+func (_c *MockDatabase_DeleteOldTeamSyncRuns_Call) Return(_a0 int64, _a1 error) *MockDatabase_DeleteOldTeamSyncRuns_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_EnableReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_EnableReconciler_Call {
+func (_c *MockDatabase_DeleteOldTeamSyncRuns_Call) RunAndReturn(run func(context.Context, slug.Slug, int) (int64, error)) *MockDatabase_DeleteOldTeamSyncRuns_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExtendSession provides a mock function with given fields: ctx, sessionID
-func (_m *MockDatabase) ExtendSession(ctx context.Context, sessionID uuid.UUID) (*Session, error) {
-	ret := _m.Called(ctx, sessionID)
+// DeleteOldUserSyncRuns provides a mock function with given fields: ctx, runsToKeep
+func (_m *MockDatabase) DeleteOldUserSyncRuns(ctx context.Context, runsToKeep int) (int64, error) {
+	ret := _m.Called(ctx, runsToKeep)
 
-	var r0 *Session
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*Session, error)); ok {
-		return rf(ctx, sessionID)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
+		return rf(ctx, runsToKeep)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *Session); ok {
-		r0 = rf(ctx, sessionID)
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, runsToKeep)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Session)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, sessionID)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, runsToKeep)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1119,42 +1714,42 @@ func (_m *MockDatabase) ExtendSession(ctx context.Context, sessionID uuid.UUID)
 	return r0, r1
 }
 
-// MockDatabase_ExtendSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendSession'
-type MockDatabase_ExtendSession_Call struct {
+// MockDatabase_DeleteOldUserSyncRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOldUserSyncRuns'
+type MockDatabase_DeleteOldUserSyncRuns_Call struct {
 	*mock.Call
 }
 
-// ExtendSession is a helper method to define mock.On call
+// DeleteOldUserSyncRuns is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sessionID uuid.UUID
-func (_e *MockDatabase_Expecter) ExtendSession(ctx interface{}, sessionID interface{}) *MockDatabase_ExtendSession_Call {
-	return &MockDatabase_ExtendSession_Call{Call: _e.mock.On("ExtendSession", ctx, sessionID)}
+//   - runsToKeep int
+func (_e *MockDatabase_Expecter) DeleteOldUserSyncRuns(ctx interface{}, runsToKeep interface{}) *MockDatabase_DeleteOldUserSyncRuns_Call {
+	return &MockDatabase_DeleteOldUserSyncRuns_Call{Call: _e.mock.On("DeleteOldUserSyncRuns", ctx, runsToKeep)}
 }
 
-func (_c *MockDatabase_ExtendSession_Call) Run(run func(ctx context.Context, sessionID uuid.UUID)) *MockDatabase_ExtendSession_Call {
+func (_c *MockDatabase_DeleteOldUserSyncRuns_Call) Run(run func(ctx context.Context, runsToKeep int)) *MockDatabase_DeleteOldUserSyncRuns_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_ExtendSession_Call) Return(_a0 *Session, _a1 error) *MockDatabase_ExtendSession_Call {
+func (_c *MockDatabase_DeleteOldUserSyncRuns_Call) Return(_a0 int64, _a1 error) *MockDatabase_DeleteOldUserSyncRuns_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_ExtendSession_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*Session, error)) *MockDatabase_ExtendSession_Call {
+func (_c *MockDatabase_DeleteOldUserSyncRuns_Call) RunAndReturn(run func(context.Context, int) (int64, error)) *MockDatabase_DeleteOldUserSyncRuns_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FirstRunComplete provides a mock function with given fields: ctx
-func (_m *MockDatabase) FirstRunComplete(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// DeleteRoleDefinition provides a mock function with given fields: ctx, roleName
+func (_m *MockDatabase) DeleteRoleDefinition(ctx context.Context, roleName roles.RoleName) error {
+	ret := _m.Called(ctx, roleName)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName) error); ok {
+		r0 = rf(ctx, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1162,326 +1757,267 @@ func (_m *MockDatabase) FirstRunComplete(ctx context.Context) error {
 	return r0
 }
 
-// MockDatabase_FirstRunComplete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FirstRunComplete'
-type MockDatabase_FirstRunComplete_Call struct {
+// MockDatabase_DeleteRoleDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoleDefinition'
+type MockDatabase_DeleteRoleDefinition_Call struct {
 	*mock.Call
 }
 
-// FirstRunComplete is a helper method to define mock.On call
+// DeleteRoleDefinition is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) FirstRunComplete(ctx interface{}) *MockDatabase_FirstRunComplete_Call {
-	return &MockDatabase_FirstRunComplete_Call{Call: _e.mock.On("FirstRunComplete", ctx)}
+//   - roleName roles.RoleName
+func (_e *MockDatabase_Expecter) DeleteRoleDefinition(ctx interface{}, roleName interface{}) *MockDatabase_DeleteRoleDefinition_Call {
+	return &MockDatabase_DeleteRoleDefinition_Call{Call: _e.mock.On("DeleteRoleDefinition", ctx, roleName)}
 }
 
-func (_c *MockDatabase_FirstRunComplete_Call) Run(run func(ctx context.Context)) *MockDatabase_FirstRunComplete_Call {
+func (_c *MockDatabase_DeleteRoleDefinition_Call) Run(run func(ctx context.Context, roleName roles.RoleName)) *MockDatabase_DeleteRoleDefinition_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(roles.RoleName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_FirstRunComplete_Call) Return(_a0 error) *MockDatabase_FirstRunComplete_Call {
+func (_c *MockDatabase_DeleteRoleDefinition_Call) Return(_a0 error) *MockDatabase_DeleteRoleDefinition_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_FirstRunComplete_Call) RunAndReturn(run func(context.Context) error) *MockDatabase_FirstRunComplete_Call {
+func (_c *MockDatabase_DeleteRoleDefinition_Call) RunAndReturn(run func(context.Context, roles.RoleName) error) *MockDatabase_DeleteRoleDefinition_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveTeamBySlug provides a mock function with given fields: ctx, _a1
-func (_m *MockDatabase) GetActiveTeamBySlug(ctx context.Context, _a1 slug.Slug) (*Team, error) {
-	ret := _m.Called(ctx, _a1)
-
-	var r0 *Team
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) (*Team, error)); ok {
-		return rf(ctx, _a1)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) *Team); ok {
-		r0 = rf(ctx, _a1)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Team)
-		}
-	}
+// DeleteServiceAccount provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockDatabase) DeleteServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
+	ret := _m.Called(ctx, serviceAccountID)
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
-		r1 = rf(ctx, _a1)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, serviceAccountID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetActiveTeamBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveTeamBySlug'
-type MockDatabase_GetActiveTeamBySlug_Call struct {
+// MockDatabase_DeleteServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteServiceAccount'
+type MockDatabase_DeleteServiceAccount_Call struct {
 	*mock.Call
 }
 
-// GetActiveTeamBySlug is a helper method to define mock.On call
+// DeleteServiceAccount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - _a1 slug.Slug
-func (_e *MockDatabase_Expecter) GetActiveTeamBySlug(ctx interface{}, _a1 interface{}) *MockDatabase_GetActiveTeamBySlug_Call {
-	return &MockDatabase_GetActiveTeamBySlug_Call{Call: _e.mock.On("GetActiveTeamBySlug", ctx, _a1)}
+//   - serviceAccountID uuid.UUID
+func (_e *MockDatabase_Expecter) DeleteServiceAccount(ctx interface{}, serviceAccountID interface{}) *MockDatabase_DeleteServiceAccount_Call {
+	return &MockDatabase_DeleteServiceAccount_Call{Call: _e.mock.On("DeleteServiceAccount", ctx, serviceAccountID)}
 }
 
-func (_c *MockDatabase_GetActiveTeamBySlug_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetActiveTeamBySlug_Call {
+func (_c *MockDatabase_DeleteServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_DeleteServiceAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetActiveTeamBySlug_Call) Return(_a0 *Team, _a1 error) *MockDatabase_GetActiveTeamBySlug_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_DeleteServiceAccount_Call) Return(_a0 error) *MockDatabase_DeleteServiceAccount_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetActiveTeamBySlug_Call) RunAndReturn(run func(context.Context, slug.Slug) (*Team, error)) *MockDatabase_GetActiveTeamBySlug_Call {
+func (_c *MockDatabase_DeleteServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_DeleteServiceAccount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveTeams provides a mock function with given fields: ctx
-func (_m *MockDatabase) GetActiveTeams(ctx context.Context) ([]*Team, error) {
-	ret := _m.Called(ctx)
-
-	var r0 []*Team
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*Team, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*Team); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Team)
-		}
-	}
+// DeleteSession provides a mock function with given fields: ctx, sessionID
+func (_m *MockDatabase) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, sessionID)
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, sessionID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetActiveTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveTeams'
-type MockDatabase_GetActiveTeams_Call struct {
+// MockDatabase_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type MockDatabase_DeleteSession_Call struct {
 	*mock.Call
 }
 
-// GetActiveTeams is a helper method to define mock.On call
+// DeleteSession is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) GetActiveTeams(ctx interface{}) *MockDatabase_GetActiveTeams_Call {
-	return &MockDatabase_GetActiveTeams_Call{Call: _e.mock.On("GetActiveTeams", ctx)}
+//   - sessionID uuid.UUID
+func (_e *MockDatabase_Expecter) DeleteSession(ctx interface{}, sessionID interface{}) *MockDatabase_DeleteSession_Call {
+	return &MockDatabase_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, sessionID)}
 }
 
-func (_c *MockDatabase_GetActiveTeams_Call) Run(run func(ctx context.Context)) *MockDatabase_GetActiveTeams_Call {
+func (_c *MockDatabase_DeleteSession_Call) Run(run func(ctx context.Context, sessionID uuid.UUID)) *MockDatabase_DeleteSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetActiveTeams_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetActiveTeams_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_DeleteSession_Call) Return(_a0 error) *MockDatabase_DeleteSession_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetActiveTeams_Call) RunAndReturn(run func(context.Context) ([]*Team, error)) *MockDatabase_GetActiveTeams_Call {
+func (_c *MockDatabase_DeleteSession_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_DeleteSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllUserRoles provides a mock function with given fields: ctx
-func (_m *MockDatabase) GetAllUserRoles(ctx context.Context) ([]*UserRole, error) {
-	ret := _m.Called(ctx)
+// DeleteTeam provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) DeleteTeam(ctx context.Context, teamSlug slug.Slug) error {
+	ret := _m.Called(ctx, teamSlug)
 
-	var r0 []*UserRole
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*UserRole, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*UserRole); ok {
-		r0 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) error); ok {
+		r0 = rf(ctx, teamSlug)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*UserRole)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetAllUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUserRoles'
-type MockDatabase_GetAllUserRoles_Call struct {
+// MockDatabase_DeleteTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTeam'
+type MockDatabase_DeleteTeam_Call struct {
 	*mock.Call
 }
 
-// GetAllUserRoles is a helper method to define mock.On call
+// DeleteTeam is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) GetAllUserRoles(ctx interface{}) *MockDatabase_GetAllUserRoles_Call {
-	return &MockDatabase_GetAllUserRoles_Call{Call: _e.mock.On("GetAllUserRoles", ctx)}
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) DeleteTeam(ctx interface{}, teamSlug interface{}) *MockDatabase_DeleteTeam_Call {
+	return &MockDatabase_DeleteTeam_Call{Call: _e.mock.On("DeleteTeam", ctx, teamSlug)}
 }
 
-func (_c *MockDatabase_GetAllUserRoles_Call) Run(run func(ctx context.Context)) *MockDatabase_GetAllUserRoles_Call {
+func (_c *MockDatabase_DeleteTeam_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_DeleteTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(slug.Slug))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetAllUserRoles_Call) Return(_a0 []*UserRole, _a1 error) *MockDatabase_GetAllUserRoles_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_DeleteTeam_Call) Return(_a0 error) *MockDatabase_DeleteTeam_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetAllUserRoles_Call) RunAndReturn(run func(context.Context) ([]*UserRole, error)) *MockDatabase_GetAllUserRoles_Call {
+func (_c *MockDatabase_DeleteTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) error) *MockDatabase_DeleteTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAuditLogsForCorrelationID provides a mock function with given fields: ctx, correlationID
-func (_m *MockDatabase) GetAuditLogsForCorrelationID(ctx context.Context, correlationID uuid.UUID) ([]*AuditLog, error) {
-	ret := _m.Called(ctx, correlationID)
-
-	var r0 []*AuditLog
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*AuditLog, error)); ok {
-		return rf(ctx, correlationID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*AuditLog); ok {
-		r0 = rf(ctx, correlationID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*AuditLog)
-		}
-	}
+// DeleteTeamSync provides a mock function with given fields: ctx, id
+func (_m *MockDatabase) DeleteTeamSync(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, correlationID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetAuditLogsForCorrelationID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsForCorrelationID'
-type MockDatabase_GetAuditLogsForCorrelationID_Call struct {
+// MockDatabase_DeleteTeamSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTeamSync'
+type MockDatabase_DeleteTeamSync_Call struct {
 	*mock.Call
 }
 
-// GetAuditLogsForCorrelationID is a helper method to define mock.On call
+// DeleteTeamSync is a helper method to define mock.On call
 //   - ctx context.Context
-//   - correlationID uuid.UUID
-func (_e *MockDatabase_Expecter) GetAuditLogsForCorrelationID(ctx interface{}, correlationID interface{}) *MockDatabase_GetAuditLogsForCorrelationID_Call {
-	return &MockDatabase_GetAuditLogsForCorrelationID_Call{Call: _e.mock.On("GetAuditLogsForCorrelationID", ctx, correlationID)}
+//   - id int64
+func (_e *MockDatabase_Expecter) DeleteTeamSync(ctx interface{}, id interface{}) *MockDatabase_DeleteTeamSync_Call {
+	return &MockDatabase_DeleteTeamSync_Call{Call: _e.mock.On("DeleteTeamSync", ctx, id)}
 }
 
-func (_c *MockDatabase_GetAuditLogsForCorrelationID_Call) Run(run func(ctx context.Context, correlationID uuid.UUID)) *MockDatabase_GetAuditLogsForCorrelationID_Call {
+func (_c *MockDatabase_DeleteTeamSync_Call) Run(run func(ctx context.Context, id int64)) *MockDatabase_DeleteTeamSync_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetAuditLogsForCorrelationID_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogsForCorrelationID_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_DeleteTeamSync_Call) Return(_a0 error) *MockDatabase_DeleteTeamSync_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetAuditLogsForCorrelationID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*AuditLog, error)) *MockDatabase_GetAuditLogsForCorrelationID_Call {
+func (_c *MockDatabase_DeleteTeamSync_Call) RunAndReturn(run func(context.Context, int64) error) *MockDatabase_DeleteTeamSync_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAuditLogsForReconciler provides a mock function with given fields: ctx, reconcilerName
-func (_m *MockDatabase) GetAuditLogsForReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) ([]*AuditLog, error) {
-	ret := _m.Called(ctx, reconcilerName)
-
-	var r0 []*AuditLog
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) ([]*AuditLog, error)); ok {
-		return rf(ctx, reconcilerName)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) []*AuditLog); ok {
-		r0 = rf(ctx, reconcilerName)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*AuditLog)
-		}
-	}
+// DeleteUser provides a mock function with given fields: ctx, userID
+func (_m *MockDatabase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
 
-	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
-		r1 = rf(ctx, reconcilerName)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetAuditLogsForReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsForReconciler'
-type MockDatabase_GetAuditLogsForReconciler_Call struct {
+// MockDatabase_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockDatabase_DeleteUser_Call struct {
 	*mock.Call
 }
 
-// GetAuditLogsForReconciler is a helper method to define mock.On call
+// DeleteUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) GetAuditLogsForReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_GetAuditLogsForReconciler_Call {
-	return &MockDatabase_GetAuditLogsForReconciler_Call{Call: _e.mock.On("GetAuditLogsForReconciler", ctx, reconcilerName)}
+//   - userID uuid.UUID
+func (_e *MockDatabase_Expecter) DeleteUser(ctx interface{}, userID interface{}) *MockDatabase_DeleteUser_Call {
+	return &MockDatabase_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userID)}
 }
 
-func (_c *MockDatabase_GetAuditLogsForReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetAuditLogsForReconciler_Call {
+func (_c *MockDatabase_DeleteUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockDatabase_DeleteUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetAuditLogsForReconciler_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogsForReconciler_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_DeleteUser_Call) Return(_a0 error) *MockDatabase_DeleteUser_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetAuditLogsForReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) ([]*AuditLog, error)) *MockDatabase_GetAuditLogsForReconciler_Call {
+func (_c *MockDatabase_DeleteUser_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_DeleteUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAuditLogsForTeam provides a mock function with given fields: ctx, _a1
-func (_m *MockDatabase) GetAuditLogsForTeam(ctx context.Context, _a1 slug.Slug) ([]*AuditLog, error) {
-	ret := _m.Called(ctx, _a1)
+// DeleteWebhookDeliveriesBefore provides a mock function with given fields: ctx, createdBefore
+func (_m *MockDatabase) DeleteWebhookDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, createdBefore)
 
-	var r0 []*AuditLog
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*AuditLog, error)); ok {
-		return rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, createdBefore)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*AuditLog); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, createdBefore)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*AuditLog)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, createdBefore)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1489,91 +2025,80 @@ func (_m *MockDatabase) GetAuditLogsForTeam(ctx context.Context, _a1 slug.Slug)
 	return r0, r1
 }
 
-// MockDatabase_GetAuditLogsForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsForTeam'
-type MockDatabase_GetAuditLogsForTeam_Call struct {
+// MockDatabase_DeleteWebhookDeliveriesBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookDeliveriesBefore'
+type MockDatabase_DeleteWebhookDeliveriesBefore_Call struct {
 	*mock.Call
 }
 
-// GetAuditLogsForTeam is a helper method to define mock.On call
+// DeleteWebhookDeliveriesBefore is a helper method to define mock.On call
 //   - ctx context.Context
-//   - _a1 slug.Slug
-func (_e *MockDatabase_Expecter) GetAuditLogsForTeam(ctx interface{}, _a1 interface{}) *MockDatabase_GetAuditLogsForTeam_Call {
-	return &MockDatabase_GetAuditLogsForTeam_Call{Call: _e.mock.On("GetAuditLogsForTeam", ctx, _a1)}
+//   - createdBefore time.Time
+func (_e *MockDatabase_Expecter) DeleteWebhookDeliveriesBefore(ctx interface{}, createdBefore interface{}) *MockDatabase_DeleteWebhookDeliveriesBefore_Call {
+	return &MockDatabase_DeleteWebhookDeliveriesBefore_Call{Call: _e.mock.On("DeleteWebhookDeliveriesBefore", ctx, createdBefore)}
 }
 
-func (_c *MockDatabase_GetAuditLogsForTeam_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetAuditLogsForTeam_Call {
+func (_c *MockDatabase_DeleteWebhookDeliveriesBefore_Call) Run(run func(ctx context.Context, createdBefore time.Time)) *MockDatabase_DeleteWebhookDeliveriesBefore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug))
+		run(args[0].(context.Context), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetAuditLogsForTeam_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogsForTeam_Call {
+func (_c *MockDatabase_DeleteWebhookDeliveriesBefore_Call) Return(_a0 int64, _a1 error) *MockDatabase_DeleteWebhookDeliveriesBefore_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetAuditLogsForTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*AuditLog, error)) *MockDatabase_GetAuditLogsForTeam_Call {
+func (_c *MockDatabase_DeleteWebhookDeliveriesBefore_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockDatabase_DeleteWebhookDeliveriesBefore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEnabledReconcilers provides a mock function with given fields: ctx
-func (_m *MockDatabase) GetEnabledReconcilers(ctx context.Context) ([]*Reconciler, error) {
-	ret := _m.Called(ctx)
-
-	var r0 []*Reconciler
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*Reconciler, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*Reconciler); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Reconciler)
-		}
-	}
+// DeleteWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *MockDatabase) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetEnabledReconcilers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnabledReconcilers'
-type MockDatabase_GetEnabledReconcilers_Call struct {
+// MockDatabase_DeleteWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookSubscription'
+type MockDatabase_DeleteWebhookSubscription_Call struct {
 	*mock.Call
 }
 
-// GetEnabledReconcilers is a helper method to define mock.On call
+// DeleteWebhookSubscription is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) GetEnabledReconcilers(ctx interface{}) *MockDatabase_GetEnabledReconcilers_Call {
-	return &MockDatabase_GetEnabledReconcilers_Call{Call: _e.mock.On("GetEnabledReconcilers", ctx)}
+//   - id uuid.UUID
+func (_e *MockDatabase_Expecter) DeleteWebhookSubscription(ctx interface{}, id interface{}) *MockDatabase_DeleteWebhookSubscription_Call {
+	return &MockDatabase_DeleteWebhookSubscription_Call{Call: _e.mock.On("DeleteWebhookSubscription", ctx, id)}
 }
 
-func (_c *MockDatabase_GetEnabledReconcilers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetEnabledReconcilers_Call {
+func (_c *MockDatabase_DeleteWebhookSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockDatabase_DeleteWebhookSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetEnabledReconcilers_Call) Return(_a0 []*Reconciler, _a1 error) *MockDatabase_GetEnabledReconcilers_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_DeleteWebhookSubscription_Call) Return(_a0 error) *MockDatabase_DeleteWebhookSubscription_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetEnabledReconcilers_Call) RunAndReturn(run func(context.Context) ([]*Reconciler, error)) *MockDatabase_GetEnabledReconcilers_Call {
+func (_c *MockDatabase_DeleteWebhookSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_DeleteWebhookSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReconciler provides a mock function with given fields: ctx, reconcilerName
-func (_m *MockDatabase) GetReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
+// DisableReconciler provides a mock function with given fields: ctx, reconcilerName
+func (_m *MockDatabase) DisableReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
 	ret := _m.Called(ctx, reconcilerName)
 
 	var r0 *Reconciler
@@ -1598,54 +2123,54 @@ func (_m *MockDatabase) GetReconciler(ctx context.Context, reconcilerName sqlc.R
 	return r0, r1
 }
 
-// MockDatabase_GetReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconciler'
-type MockDatabase_GetReconciler_Call struct {
+// MockDatabase_DisableReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableReconciler'
+type MockDatabase_DisableReconciler_Call struct {
 	*mock.Call
 }
 
-// GetReconciler is a helper method to define mock.On call
+// DisableReconciler is a helper method to define mock.On call
 //   - ctx context.Context
 //   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) GetReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_GetReconciler_Call {
-	return &MockDatabase_GetReconciler_Call{Call: _e.mock.On("GetReconciler", ctx, reconcilerName)}
+func (_e *MockDatabase_Expecter) DisableReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_DisableReconciler_Call {
+	return &MockDatabase_DisableReconciler_Call{Call: _e.mock.On("DisableReconciler", ctx, reconcilerName)}
 }
 
-func (_c *MockDatabase_GetReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetReconciler_Call {
+func (_c *MockDatabase_DisableReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_DisableReconciler_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetReconciler_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_GetReconciler_Call {
+func (_c *MockDatabase_DisableReconciler_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_DisableReconciler_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_GetReconciler_Call {
+func (_c *MockDatabase_DisableReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_DisableReconciler_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReconcilerConfig provides a mock function with given fields: ctx, reconcilerName
-func (_m *MockDatabase) GetReconcilerConfig(ctx context.Context, reconcilerName sqlc.ReconcilerName) ([]*ReconcilerConfig, error) {
-	ret := _m.Called(ctx, reconcilerName)
+// DisableTeam provides a mock function with given fields: ctx, teamSlug, suspendResources
+func (_m *MockDatabase) DisableTeam(ctx context.Context, teamSlug slug.Slug, suspendResources bool) (*Team, error) {
+	ret := _m.Called(ctx, teamSlug, suspendResources)
 
-	var r0 []*ReconcilerConfig
+	var r0 *Team
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) ([]*ReconcilerConfig, error)); ok {
-		return rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, bool) (*Team, error)); ok {
+		return rf(ctx, teamSlug, suspendResources)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) []*ReconcilerConfig); ok {
-		r0 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, bool) *Team); ok {
+		r0 = rf(ctx, teamSlug, suspendResources)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*ReconcilerConfig)
+			r0 = ret.Get(0).(*Team)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
-		r1 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, bool) error); ok {
+		r1 = rf(ctx, teamSlug, suspendResources)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1653,54 +2178,55 @@ func (_m *MockDatabase) GetReconcilerConfig(ctx context.Context, reconcilerName
 	return r0, r1
 }
 
-// MockDatabase_GetReconcilerConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconcilerConfig'
-type MockDatabase_GetReconcilerConfig_Call struct {
+// MockDatabase_DisableTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTeam'
+type MockDatabase_DisableTeam_Call struct {
 	*mock.Call
 }
 
-// GetReconcilerConfig is a helper method to define mock.On call
+// DisableTeam is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) GetReconcilerConfig(ctx interface{}, reconcilerName interface{}) *MockDatabase_GetReconcilerConfig_Call {
-	return &MockDatabase_GetReconcilerConfig_Call{Call: _e.mock.On("GetReconcilerConfig", ctx, reconcilerName)}
+//   - teamSlug slug.Slug
+//   - suspendResources bool
+func (_e *MockDatabase_Expecter) DisableTeam(ctx interface{}, teamSlug interface{}, suspendResources interface{}) *MockDatabase_DisableTeam_Call {
+	return &MockDatabase_DisableTeam_Call{Call: _e.mock.On("DisableTeam", ctx, teamSlug, suspendResources)}
 }
 
-func (_c *MockDatabase_GetReconcilerConfig_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetReconcilerConfig_Call {
+func (_c *MockDatabase_DisableTeam_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, suspendResources bool)) *MockDatabase_DisableTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetReconcilerConfig_Call) Return(_a0 []*ReconcilerConfig, _a1 error) *MockDatabase_GetReconcilerConfig_Call {
+func (_c *MockDatabase_DisableTeam_Call) Return(_a0 *Team, _a1 error) *MockDatabase_DisableTeam_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetReconcilerConfig_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) ([]*ReconcilerConfig, error)) *MockDatabase_GetReconcilerConfig_Call {
+func (_c *MockDatabase_DisableTeam_Call) RunAndReturn(run func(context.Context, slug.Slug, bool) (*Team, error)) *MockDatabase_DisableTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReconcilers provides a mock function with given fields: ctx
-func (_m *MockDatabase) GetReconcilers(ctx context.Context) ([]*Reconciler, error) {
-	ret := _m.Called(ctx)
+// EnableReconciler provides a mock function with given fields: ctx, reconcilerName
+func (_m *MockDatabase) EnableReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
+	ret := _m.Called(ctx, reconcilerName)
 
-	var r0 []*Reconciler
+	var r0 *Reconciler
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*Reconciler, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)); ok {
+		return rf(ctx, reconcilerName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*Reconciler); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *Reconciler); ok {
+		r0 = rf(ctx, reconcilerName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Reconciler)
+			r0 = ret.Get(0).(*Reconciler)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, reconcilerName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1708,53 +2234,54 @@ func (_m *MockDatabase) GetReconcilers(ctx context.Context) ([]*Reconciler, erro
 	return r0, r1
 }
 
-// MockDatabase_GetReconcilers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconcilers'
-type MockDatabase_GetReconcilers_Call struct {
+// MockDatabase_EnableReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableReconciler'
+type MockDatabase_EnableReconciler_Call struct {
 	*mock.Call
 }
 
-// GetReconcilers is a helper method to define mock.On call
+// EnableReconciler is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) GetReconcilers(ctx interface{}) *MockDatabase_GetReconcilers_Call {
-	return &MockDatabase_GetReconcilers_Call{Call: _e.mock.On("GetReconcilers", ctx)}
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) EnableReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_EnableReconciler_Call {
+	return &MockDatabase_EnableReconciler_Call{Call: _e.mock.On("EnableReconciler", ctx, reconcilerName)}
 }
 
-func (_c *MockDatabase_GetReconcilers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetReconcilers_Call {
+func (_c *MockDatabase_EnableReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_EnableReconciler_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetReconcilers_Call) Return(_a0 []*Reconciler, _a1 error) *MockDatabase_GetReconcilers_Call {
+func (_c *MockDatabase_EnableReconciler_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_EnableReconciler_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetReconcilers_Call) RunAndReturn(run func(context.Context) ([]*Reconciler, error)) *MockDatabase_GetReconcilers_Call {
+func (_c *MockDatabase_EnableReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_EnableReconciler_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRepositoryAuthorizations provides a mock function with given fields: ctx, teamSlug, repo
-func (_m *MockDatabase) GetRepositoryAuthorizations(ctx context.Context, teamSlug slug.Slug, repo string) ([]sqlc.RepositoryAuthorizationEnum, error) {
-	ret := _m.Called(ctx, teamSlug, repo)
+// EnableTeam provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) EnableTeam(ctx context.Context, teamSlug slug.Slug) (*Team, error) {
+	ret := _m.Called(ctx, teamSlug)
 
-	var r0 []sqlc.RepositoryAuthorizationEnum
+	var r0 *Team
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string) ([]sqlc.RepositoryAuthorizationEnum, error)); ok {
-		return rf(ctx, teamSlug, repo)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) (*Team, error)); ok {
+		return rf(ctx, teamSlug)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string) []sqlc.RepositoryAuthorizationEnum); ok {
-		r0 = rf(ctx, teamSlug, repo)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) *Team); ok {
+		r0 = rf(ctx, teamSlug)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]sqlc.RepositoryAuthorizationEnum)
+			r0 = ret.Get(0).(*Team)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, string) error); ok {
-		r1 = rf(ctx, teamSlug, repo)
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1762,110 +2289,101 @@ func (_m *MockDatabase) GetRepositoryAuthorizations(ctx context.Context, teamSlu
 	return r0, r1
 }
 
-// MockDatabase_GetRepositoryAuthorizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepositoryAuthorizations'
-type MockDatabase_GetRepositoryAuthorizations_Call struct {
+// MockDatabase_EnableTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableTeam'
+type MockDatabase_EnableTeam_Call struct {
 	*mock.Call
 }
 
-// GetRepositoryAuthorizations is a helper method to define mock.On call
+// EnableTeam is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamSlug slug.Slug
-//   - repo string
-func (_e *MockDatabase_Expecter) GetRepositoryAuthorizations(ctx interface{}, teamSlug interface{}, repo interface{}) *MockDatabase_GetRepositoryAuthorizations_Call {
-	return &MockDatabase_GetRepositoryAuthorizations_Call{Call: _e.mock.On("GetRepositoryAuthorizations", ctx, teamSlug, repo)}
+func (_e *MockDatabase_Expecter) EnableTeam(ctx interface{}, teamSlug interface{}) *MockDatabase_EnableTeam_Call {
+	return &MockDatabase_EnableTeam_Call{Call: _e.mock.On("EnableTeam", ctx, teamSlug)}
 }
 
-func (_c *MockDatabase_GetRepositoryAuthorizations_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, repo string)) *MockDatabase_GetRepositoryAuthorizations_Call {
+func (_c *MockDatabase_EnableTeam_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_EnableTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string))
+		run(args[0].(context.Context), args[1].(slug.Slug))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetRepositoryAuthorizations_Call) Return(_a0 []sqlc.RepositoryAuthorizationEnum, _a1 error) *MockDatabase_GetRepositoryAuthorizations_Call {
+func (_c *MockDatabase_EnableTeam_Call) Return(_a0 *Team, _a1 error) *MockDatabase_EnableTeam_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetRepositoryAuthorizations_Call) RunAndReturn(run func(context.Context, slug.Slug, string) ([]sqlc.RepositoryAuthorizationEnum, error)) *MockDatabase_GetRepositoryAuthorizations_Call {
+func (_c *MockDatabase_EnableTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) (*Team, error)) *MockDatabase_EnableTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetServiceAccountByApiKey provides a mock function with given fields: ctx, APIKey
-func (_m *MockDatabase) GetServiceAccountByApiKey(ctx context.Context, APIKey string) (*ServiceAccount, error) {
-	ret := _m.Called(ctx, APIKey)
-
-	var r0 *ServiceAccount
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*ServiceAccount, error)); ok {
-		return rf(ctx, APIKey)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *ServiceAccount); ok {
-		r0 = rf(ctx, APIKey)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ServiceAccount)
-		}
-	}
+// EnqueueTeamSync provides a mock function with given fields: ctx, teamSlug, correlationID, trigger, reconcilers, runAt
+func (_m *MockDatabase) EnqueueTeamSync(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName, runAt time.Time) error {
+	ret := _m.Called(ctx, teamSlug, correlationID, trigger, reconcilers, runAt)
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, APIKey)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, uuid.UUID, sqlc.TeamSyncTrigger, []sqlc.ReconcilerName, time.Time) error); ok {
+		r0 = rf(ctx, teamSlug, correlationID, trigger, reconcilers, runAt)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetServiceAccountByApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountByApiKey'
-type MockDatabase_GetServiceAccountByApiKey_Call struct {
+// MockDatabase_EnqueueTeamSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueTeamSync'
+type MockDatabase_EnqueueTeamSync_Call struct {
 	*mock.Call
 }
 
-// GetServiceAccountByApiKey is a helper method to define mock.On call
+// EnqueueTeamSync is a helper method to define mock.On call
 //   - ctx context.Context
-//   - APIKey string
-func (_e *MockDatabase_Expecter) GetServiceAccountByApiKey(ctx interface{}, APIKey interface{}) *MockDatabase_GetServiceAccountByApiKey_Call {
-	return &MockDatabase_GetServiceAccountByApiKey_Call{Call: _e.mock.On("GetServiceAccountByApiKey", ctx, APIKey)}
+//   - teamSlug slug.Slug
+//   - correlationID uuid.UUID
+//   - trigger sqlc.TeamSyncTrigger
+//   - reconcilers []sqlc.ReconcilerName
+//   - runAt time.Time
+func (_e *MockDatabase_Expecter) EnqueueTeamSync(ctx interface{}, teamSlug interface{}, correlationID interface{}, trigger interface{}, reconcilers interface{}, runAt interface{}) *MockDatabase_EnqueueTeamSync_Call {
+	return &MockDatabase_EnqueueTeamSync_Call{Call: _e.mock.On("EnqueueTeamSync", ctx, teamSlug, correlationID, trigger, reconcilers, runAt)}
 }
 
-func (_c *MockDatabase_GetServiceAccountByApiKey_Call) Run(run func(ctx context.Context, APIKey string)) *MockDatabase_GetServiceAccountByApiKey_Call {
+func (_c *MockDatabase_EnqueueTeamSync_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName, runAt time.Time)) *MockDatabase_EnqueueTeamSync_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(uuid.UUID), args[3].(sqlc.TeamSyncTrigger), args[4].([]sqlc.ReconcilerName), args[5].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccountByApiKey_Call) Return(_a0 *ServiceAccount, _a1 error) *MockDatabase_GetServiceAccountByApiKey_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_EnqueueTeamSync_Call) Return(_a0 error) *MockDatabase_EnqueueTeamSync_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccountByApiKey_Call) RunAndReturn(run func(context.Context, string) (*ServiceAccount, error)) *MockDatabase_GetServiceAccountByApiKey_Call {
+func (_c *MockDatabase_EnqueueTeamSync_Call) RunAndReturn(run func(context.Context, slug.Slug, uuid.UUID, sqlc.TeamSyncTrigger, []sqlc.ReconcilerName, time.Time) error) *MockDatabase_EnqueueTeamSync_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetServiceAccountByName provides a mock function with given fields: ctx, name
-func (_m *MockDatabase) GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error) {
-	ret := _m.Called(ctx, name)
+// ExtendSession provides a mock function with given fields: ctx, sessionID
+func (_m *MockDatabase) ExtendSession(ctx context.Context, sessionID uuid.UUID) (*Session, error) {
+	ret := _m.Called(ctx, sessionID)
 
-	var r0 *ServiceAccount
+	var r0 *Session
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*ServiceAccount, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*Session, error)); ok {
+		return rf(ctx, sessionID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *ServiceAccount); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *Session); ok {
+		r0 = rf(ctx, sessionID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ServiceAccount)
+			r0 = ret.Get(0).(*Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, sessionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1873,54 +2391,54 @@ func (_m *MockDatabase) GetServiceAccountByName(ctx context.Context, name string
 	return r0, r1
 }
 
-// MockDatabase_GetServiceAccountByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountByName'
-type MockDatabase_GetServiceAccountByName_Call struct {
+// MockDatabase_ExtendSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendSession'
+type MockDatabase_ExtendSession_Call struct {
 	*mock.Call
 }
 
-// GetServiceAccountByName is a helper method to define mock.On call
+// ExtendSession is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-func (_e *MockDatabase_Expecter) GetServiceAccountByName(ctx interface{}, name interface{}) *MockDatabase_GetServiceAccountByName_Call {
-	return &MockDatabase_GetServiceAccountByName_Call{Call: _e.mock.On("GetServiceAccountByName", ctx, name)}
+//   - sessionID uuid.UUID
+func (_e *MockDatabase_Expecter) ExtendSession(ctx interface{}, sessionID interface{}) *MockDatabase_ExtendSession_Call {
+	return &MockDatabase_ExtendSession_Call{Call: _e.mock.On("ExtendSession", ctx, sessionID)}
 }
 
-func (_c *MockDatabase_GetServiceAccountByName_Call) Run(run func(ctx context.Context, name string)) *MockDatabase_GetServiceAccountByName_Call {
+func (_c *MockDatabase_ExtendSession_Call) Run(run func(ctx context.Context, sessionID uuid.UUID)) *MockDatabase_ExtendSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccountByName_Call) Return(_a0 *ServiceAccount, _a1 error) *MockDatabase_GetServiceAccountByName_Call {
+func (_c *MockDatabase_ExtendSession_Call) Return(_a0 *Session, _a1 error) *MockDatabase_ExtendSession_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccountByName_Call) RunAndReturn(run func(context.Context, string) (*ServiceAccount, error)) *MockDatabase_GetServiceAccountByName_Call {
+func (_c *MockDatabase_ExtendSession_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*Session, error)) *MockDatabase_ExtendSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetServiceAccountRoles provides a mock function with given fields: ctx, serviceAccountID
-func (_m *MockDatabase) GetServiceAccountRoles(ctx context.Context, serviceAccountID uuid.UUID) ([]*Role, error) {
-	ret := _m.Called(ctx, serviceAccountID)
+// FinishTeamSyncRun provides a mock function with given fields: ctx, id, status, errorMessage, results
+func (_m *MockDatabase) FinishTeamSyncRun(ctx context.Context, id int64, status sqlc.TeamSyncRunStatus, errorMessage *string, results []*TeamSyncReconcilerResult) (*TeamSyncRun, error) {
+	ret := _m.Called(ctx, id, status, errorMessage, results)
 
-	var r0 []*Role
+	var r0 *TeamSyncRun
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*Role, error)); ok {
-		return rf(ctx, serviceAccountID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, sqlc.TeamSyncRunStatus, *string, []*TeamSyncReconcilerResult) (*TeamSyncRun, error)); ok {
+		return rf(ctx, id, status, errorMessage, results)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*Role); ok {
-		r0 = rf(ctx, serviceAccountID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, sqlc.TeamSyncRunStatus, *string, []*TeamSyncReconcilerResult) *TeamSyncRun); ok {
+		r0 = rf(ctx, id, status, errorMessage, results)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Role)
+			r0 = ret.Get(0).(*TeamSyncRun)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, serviceAccountID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, sqlc.TeamSyncRunStatus, *string, []*TeamSyncReconcilerResult) error); ok {
+		r1 = rf(ctx, id, status, errorMessage, results)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1928,54 +2446,57 @@ func (_m *MockDatabase) GetServiceAccountRoles(ctx context.Context, serviceAccou
 	return r0, r1
 }
 
-// MockDatabase_GetServiceAccountRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountRoles'
-type MockDatabase_GetServiceAccountRoles_Call struct {
+// MockDatabase_FinishTeamSyncRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishTeamSyncRun'
+type MockDatabase_FinishTeamSyncRun_Call struct {
 	*mock.Call
 }
 
-// GetServiceAccountRoles is a helper method to define mock.On call
+// FinishTeamSyncRun is a helper method to define mock.On call
 //   - ctx context.Context
-//   - serviceAccountID uuid.UUID
-func (_e *MockDatabase_Expecter) GetServiceAccountRoles(ctx interface{}, serviceAccountID interface{}) *MockDatabase_GetServiceAccountRoles_Call {
-	return &MockDatabase_GetServiceAccountRoles_Call{Call: _e.mock.On("GetServiceAccountRoles", ctx, serviceAccountID)}
+//   - id int64
+//   - status sqlc.TeamSyncRunStatus
+//   - errorMessage *string
+//   - results []*TeamSyncReconcilerResult
+func (_e *MockDatabase_Expecter) FinishTeamSyncRun(ctx interface{}, id interface{}, status interface{}, errorMessage interface{}, results interface{}) *MockDatabase_FinishTeamSyncRun_Call {
+	return &MockDatabase_FinishTeamSyncRun_Call{Call: _e.mock.On("FinishTeamSyncRun", ctx, id, status, errorMessage, results)}
 }
 
-func (_c *MockDatabase_GetServiceAccountRoles_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_GetServiceAccountRoles_Call {
+func (_c *MockDatabase_FinishTeamSyncRun_Call) Run(run func(ctx context.Context, id int64, status sqlc.TeamSyncRunStatus, errorMessage *string, results []*TeamSyncReconcilerResult)) *MockDatabase_FinishTeamSyncRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(int64), args[2].(sqlc.TeamSyncRunStatus), args[3].(*string), args[4].([]*TeamSyncReconcilerResult))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccountRoles_Call) Return(_a0 []*Role, _a1 error) *MockDatabase_GetServiceAccountRoles_Call {
+func (_c *MockDatabase_FinishTeamSyncRun_Call) Return(_a0 *TeamSyncRun, _a1 error) *MockDatabase_FinishTeamSyncRun_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccountRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*Role, error)) *MockDatabase_GetServiceAccountRoles_Call {
+func (_c *MockDatabase_FinishTeamSyncRun_Call) RunAndReturn(run func(context.Context, int64, sqlc.TeamSyncRunStatus, *string, []*TeamSyncReconcilerResult) (*TeamSyncRun, error)) *MockDatabase_FinishTeamSyncRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetServiceAccounts provides a mock function with given fields: ctx
-func (_m *MockDatabase) GetServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
-	ret := _m.Called(ctx)
+// FinishUserSyncRun provides a mock function with given fields: ctx, id, status, errorMessage, createdUsers, updatedUsers, deletedUsers
+func (_m *MockDatabase) FinishUserSyncRun(ctx context.Context, id int64, status sqlc.UserSyncRunStatus, errorMessage *string, createdUsers int, updatedUsers int, deletedUsers int) (*UserSyncRun, error) {
+	ret := _m.Called(ctx, id, status, errorMessage, createdUsers, updatedUsers, deletedUsers)
 
-	var r0 []*ServiceAccount
+	var r0 *UserSyncRun
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*ServiceAccount, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, sqlc.UserSyncRunStatus, *string, int, int, int) (*UserSyncRun, error)); ok {
+		return rf(ctx, id, status, errorMessage, createdUsers, updatedUsers, deletedUsers)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*ServiceAccount); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, sqlc.UserSyncRunStatus, *string, int, int, int) *UserSyncRun); ok {
+		r0 = rf(ctx, id, status, errorMessage, createdUsers, updatedUsers, deletedUsers)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*ServiceAccount)
+			r0 = ret.Get(0).(*UserSyncRun)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, sqlc.UserSyncRunStatus, *string, int, int, int) error); ok {
+		r1 = rf(ctx, id, status, errorMessage, createdUsers, updatedUsers, deletedUsers)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1983,108 +2504,101 @@ func (_m *MockDatabase) GetServiceAccounts(ctx context.Context) ([]*ServiceAccou
 	return r0, r1
 }
 
-// MockDatabase_GetServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccounts'
-type MockDatabase_GetServiceAccounts_Call struct {
+// MockDatabase_FinishUserSyncRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishUserSyncRun'
+type MockDatabase_FinishUserSyncRun_Call struct {
 	*mock.Call
 }
 
-// GetServiceAccounts is a helper method to define mock.On call
+// FinishUserSyncRun is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) GetServiceAccounts(ctx interface{}) *MockDatabase_GetServiceAccounts_Call {
-	return &MockDatabase_GetServiceAccounts_Call{Call: _e.mock.On("GetServiceAccounts", ctx)}
+//   - id int64
+//   - status sqlc.UserSyncRunStatus
+//   - errorMessage *string
+//   - createdUsers int
+//   - updatedUsers int
+//   - deletedUsers int
+func (_e *MockDatabase_Expecter) FinishUserSyncRun(ctx interface{}, id interface{}, status interface{}, errorMessage interface{}, createdUsers interface{}, updatedUsers interface{}, deletedUsers interface{}) *MockDatabase_FinishUserSyncRun_Call {
+	return &MockDatabase_FinishUserSyncRun_Call{Call: _e.mock.On("FinishUserSyncRun", ctx, id, status, errorMessage, createdUsers, updatedUsers, deletedUsers)}
 }
 
-func (_c *MockDatabase_GetServiceAccounts_Call) Run(run func(ctx context.Context)) *MockDatabase_GetServiceAccounts_Call {
+func (_c *MockDatabase_FinishUserSyncRun_Call) Run(run func(ctx context.Context, id int64, status sqlc.UserSyncRunStatus, errorMessage *string, createdUsers int, updatedUsers int, deletedUsers int)) *MockDatabase_FinishUserSyncRun_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(int64), args[2].(sqlc.UserSyncRunStatus), args[3].(*string), args[4].(int), args[5].(int), args[6].(int))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccounts_Call) Return(_a0 []*ServiceAccount, _a1 error) *MockDatabase_GetServiceAccounts_Call {
+func (_c *MockDatabase_FinishUserSyncRun_Call) Return(_a0 *UserSyncRun, _a1 error) *MockDatabase_FinishUserSyncRun_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetServiceAccounts_Call) RunAndReturn(run func(context.Context) ([]*ServiceAccount, error)) *MockDatabase_GetServiceAccounts_Call {
+func (_c *MockDatabase_FinishUserSyncRun_Call) RunAndReturn(run func(context.Context, int64, sqlc.UserSyncRunStatus, *string, int, int, int) (*UserSyncRun, error)) *MockDatabase_FinishUserSyncRun_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSessionByID provides a mock function with given fields: ctx, sessionID
-func (_m *MockDatabase) GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*Session, error) {
-	ret := _m.Called(ctx, sessionID)
-
-	var r0 *Session
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*Session, error)); ok {
-		return rf(ctx, sessionID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *Session); ok {
-		r0 = rf(ctx, sessionID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Session)
-		}
-	}
+// FirstRunComplete provides a mock function with given fields: ctx
+func (_m *MockDatabase) FirstRunComplete(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, sessionID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockDatabase_GetSessionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionByID'
-type MockDatabase_GetSessionByID_Call struct {
+// MockDatabase_FirstRunComplete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FirstRunComplete'
+type MockDatabase_FirstRunComplete_Call struct {
 	*mock.Call
 }
 
-// GetSessionByID is a helper method to define mock.On call
+// FirstRunComplete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sessionID uuid.UUID
-func (_e *MockDatabase_Expecter) GetSessionByID(ctx interface{}, sessionID interface{}) *MockDatabase_GetSessionByID_Call {
-	return &MockDatabase_GetSessionByID_Call{Call: _e.mock.On("GetSessionByID", ctx, sessionID)}
+func (_e *MockDatabase_Expecter) FirstRunComplete(ctx interface{}) *MockDatabase_FirstRunComplete_Call {
+	return &MockDatabase_FirstRunComplete_Call{Call: _e.mock.On("FirstRunComplete", ctx)}
 }
 
-func (_c *MockDatabase_GetSessionByID_Call) Run(run func(ctx context.Context, sessionID uuid.UUID)) *MockDatabase_GetSessionByID_Call {
+func (_c *MockDatabase_FirstRunComplete_Call) Run(run func(ctx context.Context)) *MockDatabase_FirstRunComplete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetSessionByID_Call) Return(_a0 *Session, _a1 error) *MockDatabase_GetSessionByID_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_FirstRunComplete_Call) Return(_a0 error) *MockDatabase_FirstRunComplete_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_GetSessionByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*Session, error)) *MockDatabase_GetSessionByID_Call {
+func (_c *MockDatabase_FirstRunComplete_Call) RunAndReturn(run func(context.Context) error) *MockDatabase_FirstRunComplete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSlackAlertsChannels provides a mock function with given fields: ctx, teamSlug
-func (_m *MockDatabase) GetSlackAlertsChannels(ctx context.Context, teamSlug slug.Slug) (map[string]string, error) {
-	ret := _m.Called(ctx, teamSlug)
+// GetAPIKeyByID provides a mock function with given fields: ctx, apiKeyID
+func (_m *MockDatabase) GetAPIKeyByID(ctx context.Context, apiKeyID uuid.UUID) (*ApiKey, error) {
+	ret := _m.Called(ctx, apiKeyID)
 
-	var r0 map[string]string
+	var r0 *ApiKey
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) (map[string]string, error)); ok {
-		return rf(ctx, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*ApiKey, error)); ok {
+		return rf(ctx, apiKeyID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) map[string]string); ok {
-		r0 = rf(ctx, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *ApiKey); ok {
+		r0 = rf(ctx, apiKeyID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]string)
+			r0 = ret.Get(0).(*ApiKey)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
-		r1 = rf(ctx, teamSlug)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, apiKeyID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2092,37 +2606,37 @@ func (_m *MockDatabase) GetSlackAlertsChannels(ctx context.Context, teamSlug slu
 	return r0, r1
 }
 
-// MockDatabase_GetSlackAlertsChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSlackAlertsChannels'
-type MockDatabase_GetSlackAlertsChannels_Call struct {
+// MockDatabase_GetAPIKeyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKeyByID'
+type MockDatabase_GetAPIKeyByID_Call struct {
 	*mock.Call
 }
 
-// GetSlackAlertsChannels is a helper method to define mock.On call
+// GetAPIKeyByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-func (_e *MockDatabase_Expecter) GetSlackAlertsChannels(ctx interface{}, teamSlug interface{}) *MockDatabase_GetSlackAlertsChannels_Call {
-	return &MockDatabase_GetSlackAlertsChannels_Call{Call: _e.mock.On("GetSlackAlertsChannels", ctx, teamSlug)}
+//   - apiKeyID uuid.UUID
+func (_e *MockDatabase_Expecter) GetAPIKeyByID(ctx interface{}, apiKeyID interface{}) *MockDatabase_GetAPIKeyByID_Call {
+	return &MockDatabase_GetAPIKeyByID_Call{Call: _e.mock.On("GetAPIKeyByID", ctx, apiKeyID)}
 }
 
-func (_c *MockDatabase_GetSlackAlertsChannels_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetSlackAlertsChannels_Call {
+func (_c *MockDatabase_GetAPIKeyByID_Call) Run(run func(ctx context.Context, apiKeyID uuid.UUID)) *MockDatabase_GetAPIKeyByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetSlackAlertsChannels_Call) Return(_a0 map[string]string, _a1 error) *MockDatabase_GetSlackAlertsChannels_Call {
+func (_c *MockDatabase_GetAPIKeyByID_Call) Return(_a0 *ApiKey, _a1 error) *MockDatabase_GetAPIKeyByID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetSlackAlertsChannels_Call) RunAndReturn(run func(context.Context, slug.Slug) (map[string]string, error)) *MockDatabase_GetSlackAlertsChannels_Call {
+func (_c *MockDatabase_GetAPIKeyByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*ApiKey, error)) *MockDatabase_GetAPIKeyByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamBySlug provides a mock function with given fields: ctx, _a1
-func (_m *MockDatabase) GetTeamBySlug(ctx context.Context, _a1 slug.Slug) (*Team, error) {
+// GetActiveTeamBySlug provides a mock function with given fields: ctx, _a1
+func (_m *MockDatabase) GetActiveTeamBySlug(ctx context.Context, _a1 slug.Slug) (*Team, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 *Team
@@ -2147,54 +2661,54 @@ func (_m *MockDatabase) GetTeamBySlug(ctx context.Context, _a1 slug.Slug) (*Team
 	return r0, r1
 }
 
-// MockDatabase_GetTeamBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamBySlug'
-type MockDatabase_GetTeamBySlug_Call struct {
+// MockDatabase_GetActiveTeamBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveTeamBySlug'
+type MockDatabase_GetActiveTeamBySlug_Call struct {
 	*mock.Call
 }
 
-// GetTeamBySlug is a helper method to define mock.On call
+// GetActiveTeamBySlug is a helper method to define mock.On call
 //   - ctx context.Context
 //   - _a1 slug.Slug
-func (_e *MockDatabase_Expecter) GetTeamBySlug(ctx interface{}, _a1 interface{}) *MockDatabase_GetTeamBySlug_Call {
-	return &MockDatabase_GetTeamBySlug_Call{Call: _e.mock.On("GetTeamBySlug", ctx, _a1)}
+func (_e *MockDatabase_Expecter) GetActiveTeamBySlug(ctx interface{}, _a1 interface{}) *MockDatabase_GetActiveTeamBySlug_Call {
+	return &MockDatabase_GetActiveTeamBySlug_Call{Call: _e.mock.On("GetActiveTeamBySlug", ctx, _a1)}
 }
 
-func (_c *MockDatabase_GetTeamBySlug_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetTeamBySlug_Call {
+func (_c *MockDatabase_GetActiveTeamBySlug_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetActiveTeamBySlug_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(slug.Slug))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamBySlug_Call) Return(_a0 *Team, _a1 error) *MockDatabase_GetTeamBySlug_Call {
+func (_c *MockDatabase_GetActiveTeamBySlug_Call) Return(_a0 *Team, _a1 error) *MockDatabase_GetActiveTeamBySlug_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamBySlug_Call) RunAndReturn(run func(context.Context, slug.Slug) (*Team, error)) *MockDatabase_GetTeamBySlug_Call {
+func (_c *MockDatabase_GetActiveTeamBySlug_Call) RunAndReturn(run func(context.Context, slug.Slug) (*Team, error)) *MockDatabase_GetActiveTeamBySlug_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamDeleteKey provides a mock function with given fields: ctx, key
-func (_m *MockDatabase) GetTeamDeleteKey(ctx context.Context, key uuid.UUID) (*TeamDeleteKey, error) {
-	ret := _m.Called(ctx, key)
+// GetActiveTeams provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetActiveTeams(ctx context.Context) ([]*Team, error) {
+	ret := _m.Called(ctx)
 
-	var r0 *TeamDeleteKey
+	var r0 []*Team
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*TeamDeleteKey, error)); ok {
-		return rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*Team, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *TeamDeleteKey); ok {
-		r0 = rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context) []*Team); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*TeamDeleteKey)
+			r0 = ret.Get(0).([]*Team)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, key)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2202,54 +2716,53 @@ func (_m *MockDatabase) GetTeamDeleteKey(ctx context.Context, key uuid.UUID) (*T
 	return r0, r1
 }
 
-// MockDatabase_GetTeamDeleteKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamDeleteKey'
-type MockDatabase_GetTeamDeleteKey_Call struct {
+// MockDatabase_GetActiveTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveTeams'
+type MockDatabase_GetActiveTeams_Call struct {
 	*mock.Call
 }
 
-// GetTeamDeleteKey is a helper method to define mock.On call
+// GetActiveTeams is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key uuid.UUID
-func (_e *MockDatabase_Expecter) GetTeamDeleteKey(ctx interface{}, key interface{}) *MockDatabase_GetTeamDeleteKey_Call {
-	return &MockDatabase_GetTeamDeleteKey_Call{Call: _e.mock.On("GetTeamDeleteKey", ctx, key)}
+func (_e *MockDatabase_Expecter) GetActiveTeams(ctx interface{}) *MockDatabase_GetActiveTeams_Call {
+	return &MockDatabase_GetActiveTeams_Call{Call: _e.mock.On("GetActiveTeams", ctx)}
 }
 
-func (_c *MockDatabase_GetTeamDeleteKey_Call) Run(run func(ctx context.Context, key uuid.UUID)) *MockDatabase_GetTeamDeleteKey_Call {
+func (_c *MockDatabase_GetActiveTeams_Call) Run(run func(ctx context.Context)) *MockDatabase_GetActiveTeams_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamDeleteKey_Call) Return(_a0 *TeamDeleteKey, _a1 error) *MockDatabase_GetTeamDeleteKey_Call {
+func (_c *MockDatabase_GetActiveTeams_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetActiveTeams_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamDeleteKey_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*TeamDeleteKey, error)) *MockDatabase_GetTeamDeleteKey_Call {
+func (_c *MockDatabase_GetActiveTeams_Call) RunAndReturn(run func(context.Context) ([]*Team, error)) *MockDatabase_GetActiveTeams_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamMember provides a mock function with given fields: ctx, teamSlug, userID
-func (_m *MockDatabase) GetTeamMember(ctx context.Context, teamSlug slug.Slug, userID uuid.UUID) (*User, error) {
-	ret := _m.Called(ctx, teamSlug, userID)
+// GetAllUserRoles provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetAllUserRoles(ctx context.Context) ([]*UserRole, error) {
+	ret := _m.Called(ctx)
 
-	var r0 *User
+	var r0 []*UserRole
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, uuid.UUID) (*User, error)); ok {
-		return rf(ctx, teamSlug, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*UserRole, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, uuid.UUID) *User); ok {
-		r0 = rf(ctx, teamSlug, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) []*UserRole); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).([]*UserRole)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, uuid.UUID) error); ok {
-		r1 = rf(ctx, teamSlug, userID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2257,55 +2770,53 @@ func (_m *MockDatabase) GetTeamMember(ctx context.Context, teamSlug slug.Slug, u
 	return r0, r1
 }
 
-// MockDatabase_GetTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMember'
-type MockDatabase_GetTeamMember_Call struct {
+// MockDatabase_GetAllUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUserRoles'
+type MockDatabase_GetAllUserRoles_Call struct {
 	*mock.Call
 }
 
-// GetTeamMember is a helper method to define mock.On call
+// GetAllUserRoles is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-//   - userID uuid.UUID
-func (_e *MockDatabase_Expecter) GetTeamMember(ctx interface{}, teamSlug interface{}, userID interface{}) *MockDatabase_GetTeamMember_Call {
-	return &MockDatabase_GetTeamMember_Call{Call: _e.mock.On("GetTeamMember", ctx, teamSlug, userID)}
+func (_e *MockDatabase_Expecter) GetAllUserRoles(ctx interface{}) *MockDatabase_GetAllUserRoles_Call {
+	return &MockDatabase_GetAllUserRoles_Call{Call: _e.mock.On("GetAllUserRoles", ctx)}
 }
 
-func (_c *MockDatabase_GetTeamMember_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, userID uuid.UUID)) *MockDatabase_GetTeamMember_Call {
+func (_c *MockDatabase_GetAllUserRoles_Call) Run(run func(ctx context.Context)) *MockDatabase_GetAllUserRoles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMember_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetTeamMember_Call {
+func (_c *MockDatabase_GetAllUserRoles_Call) Return(_a0 []*UserRole, _a1 error) *MockDatabase_GetAllUserRoles_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMember_Call) RunAndReturn(run func(context.Context, slug.Slug, uuid.UUID) (*User, error)) *MockDatabase_GetTeamMember_Call {
+func (_c *MockDatabase_GetAllUserRoles_Call) RunAndReturn(run func(context.Context) ([]*UserRole, error)) *MockDatabase_GetAllUserRoles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamMemberOptOuts provides a mock function with given fields: ctx, userID, teamSlug
-func (_m *MockDatabase) GetTeamMemberOptOuts(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) ([]*sqlc.GetTeamMemberOptOutsRow, error) {
-	ret := _m.Called(ctx, userID, teamSlug)
+// GetAuditLogChain provides a mock function with given fields: ctx, afterPosition, limit
+func (_m *MockDatabase) GetAuditLogChain(ctx context.Context, afterPosition int64, limit int) ([]*AuditLog, error) {
+	ret := _m.Called(ctx, afterPosition, limit)
 
-	var r0 []*sqlc.GetTeamMemberOptOutsRow
+	var r0 []*AuditLog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug) ([]*sqlc.GetTeamMemberOptOutsRow, error)); ok {
-		return rf(ctx, userID, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]*AuditLog, error)); ok {
+		return rf(ctx, afterPosition, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug) []*sqlc.GetTeamMemberOptOutsRow); ok {
-		r0 = rf(ctx, userID, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []*AuditLog); ok {
+		r0 = rf(ctx, afterPosition, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*sqlc.GetTeamMemberOptOutsRow)
+			r0 = ret.Get(0).([]*AuditLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, slug.Slug) error); ok {
-		r1 = rf(ctx, userID, teamSlug)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterPosition, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2313,55 +2824,55 @@ func (_m *MockDatabase) GetTeamMemberOptOuts(ctx context.Context, userID uuid.UU
 	return r0, r1
 }
 
-// MockDatabase_GetTeamMemberOptOuts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMemberOptOuts'
-type MockDatabase_GetTeamMemberOptOuts_Call struct {
+// MockDatabase_GetAuditLogChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogChain'
+type MockDatabase_GetAuditLogChain_Call struct {
 	*mock.Call
 }
 
-// GetTeamMemberOptOuts is a helper method to define mock.On call
+// GetAuditLogChain is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-//   - teamSlug slug.Slug
-func (_e *MockDatabase_Expecter) GetTeamMemberOptOuts(ctx interface{}, userID interface{}, teamSlug interface{}) *MockDatabase_GetTeamMemberOptOuts_Call {
-	return &MockDatabase_GetTeamMemberOptOuts_Call{Call: _e.mock.On("GetTeamMemberOptOuts", ctx, userID, teamSlug)}
+//   - afterPosition int64
+//   - limit int
+func (_e *MockDatabase_Expecter) GetAuditLogChain(ctx interface{}, afterPosition interface{}, limit interface{}) *MockDatabase_GetAuditLogChain_Call {
+	return &MockDatabase_GetAuditLogChain_Call{Call: _e.mock.On("GetAuditLogChain", ctx, afterPosition, limit)}
 }
 
-func (_c *MockDatabase_GetTeamMemberOptOuts_Call) Run(run func(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug)) *MockDatabase_GetTeamMemberOptOuts_Call {
+func (_c *MockDatabase_GetAuditLogChain_Call) Run(run func(ctx context.Context, afterPosition int64, limit int)) *MockDatabase_GetAuditLogChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMemberOptOuts_Call) Return(_a0 []*sqlc.GetTeamMemberOptOutsRow, _a1 error) *MockDatabase_GetTeamMemberOptOuts_Call {
+func (_c *MockDatabase_GetAuditLogChain_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogChain_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMemberOptOuts_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug) ([]*sqlc.GetTeamMemberOptOutsRow, error)) *MockDatabase_GetTeamMemberOptOuts_Call {
+func (_c *MockDatabase_GetAuditLogChain_Call) RunAndReturn(run func(context.Context, int64, int) ([]*AuditLog, error)) *MockDatabase_GetAuditLogChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamMembers provides a mock function with given fields: ctx, teamSlug
-func (_m *MockDatabase) GetTeamMembers(ctx context.Context, teamSlug slug.Slug) ([]*User, error) {
-	ret := _m.Called(ctx, teamSlug)
+// GetAuditLogPrunedLinks provides a mock function with given fields: ctx, afterPosition, limit
+func (_m *MockDatabase) GetAuditLogPrunedLinks(ctx context.Context, afterPosition int64, limit int) ([]*AuditLogPrunedLink, error) {
+	ret := _m.Called(ctx, afterPosition, limit)
 
-	var r0 []*User
+	var r0 []*AuditLogPrunedLink
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*User, error)); ok {
-		return rf(ctx, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]*AuditLogPrunedLink, error)); ok {
+		return rf(ctx, afterPosition, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*User); ok {
-		r0 = rf(ctx, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []*AuditLogPrunedLink); ok {
+		r0 = rf(ctx, afterPosition, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*User)
+			r0 = ret.Get(0).([]*AuditLogPrunedLink)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
-		r1 = rf(ctx, teamSlug)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterPosition, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2369,54 +2880,55 @@ func (_m *MockDatabase) GetTeamMembers(ctx context.Context, teamSlug slug.Slug)
 	return r0, r1
 }
 
-// MockDatabase_GetTeamMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMembers'
-type MockDatabase_GetTeamMembers_Call struct {
+// MockDatabase_GetAuditLogPrunedLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogPrunedLinks'
+type MockDatabase_GetAuditLogPrunedLinks_Call struct {
 	*mock.Call
 }
 
-// GetTeamMembers is a helper method to define mock.On call
+// GetAuditLogPrunedLinks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-func (_e *MockDatabase_Expecter) GetTeamMembers(ctx interface{}, teamSlug interface{}) *MockDatabase_GetTeamMembers_Call {
-	return &MockDatabase_GetTeamMembers_Call{Call: _e.mock.On("GetTeamMembers", ctx, teamSlug)}
+//   - afterPosition int64
+//   - limit int
+func (_e *MockDatabase_Expecter) GetAuditLogPrunedLinks(ctx interface{}, afterPosition interface{}, limit interface{}) *MockDatabase_GetAuditLogPrunedLinks_Call {
+	return &MockDatabase_GetAuditLogPrunedLinks_Call{Call: _e.mock.On("GetAuditLogPrunedLinks", ctx, afterPosition, limit)}
 }
 
-func (_c *MockDatabase_GetTeamMembers_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetTeamMembers_Call {
+func (_c *MockDatabase_GetAuditLogPrunedLinks_Call) Run(run func(ctx context.Context, afterPosition int64, limit int)) *MockDatabase_GetAuditLogPrunedLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMembers_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetTeamMembers_Call {
+func (_c *MockDatabase_GetAuditLogPrunedLinks_Call) Return(_a0 []*AuditLogPrunedLink, _a1 error) *MockDatabase_GetAuditLogPrunedLinks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMembers_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*User, error)) *MockDatabase_GetTeamMembers_Call {
+func (_c *MockDatabase_GetAuditLogPrunedLinks_Call) RunAndReturn(run func(context.Context, int64, int) ([]*AuditLogPrunedLink, error)) *MockDatabase_GetAuditLogPrunedLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamMembersForReconciler provides a mock function with given fields: ctx, teamSlug, reconcilerName
-func (_m *MockDatabase) GetTeamMembersForReconciler(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName) ([]*User, error) {
-	ret := _m.Called(ctx, teamSlug, reconcilerName)
+// GetAuditLogs provides a mock function with given fields: ctx, filter, after, limit
+func (_m *MockDatabase) GetAuditLogs(ctx context.Context, filter AuditLogFilter, after *AuditLogCursor, limit int) ([]*AuditLog, error) {
+	ret := _m.Called(ctx, filter, after, limit)
 
-	var r0 []*User
+	var r0 []*AuditLog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, sqlc.ReconcilerName) ([]*User, error)); ok {
-		return rf(ctx, teamSlug, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, AuditLogFilter, *AuditLogCursor, int) ([]*AuditLog, error)); ok {
+		return rf(ctx, filter, after, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, sqlc.ReconcilerName) []*User); ok {
-		r0 = rf(ctx, teamSlug, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, AuditLogFilter, *AuditLogCursor, int) []*AuditLog); ok {
+		r0 = rf(ctx, filter, after, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*User)
+			r0 = ret.Get(0).([]*AuditLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, sqlc.ReconcilerName) error); ok {
-		r1 = rf(ctx, teamSlug, reconcilerName)
+	if rf, ok := ret.Get(1).(func(context.Context, AuditLogFilter, *AuditLogCursor, int) error); ok {
+		r1 = rf(ctx, filter, after, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2424,55 +2936,56 @@ func (_m *MockDatabase) GetTeamMembersForReconciler(ctx context.Context, teamSlu
 	return r0, r1
 }
 
-// MockDatabase_GetTeamMembersForReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMembersForReconciler'
-type MockDatabase_GetTeamMembersForReconciler_Call struct {
+// MockDatabase_GetAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogs'
+type MockDatabase_GetAuditLogs_Call struct {
 	*mock.Call
 }
 
-// GetTeamMembersForReconciler is a helper method to define mock.On call
+// GetAuditLogs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-//   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) GetTeamMembersForReconciler(ctx interface{}, teamSlug interface{}, reconcilerName interface{}) *MockDatabase_GetTeamMembersForReconciler_Call {
-	return &MockDatabase_GetTeamMembersForReconciler_Call{Call: _e.mock.On("GetTeamMembersForReconciler", ctx, teamSlug, reconcilerName)}
+//   - filter AuditLogFilter
+//   - after *AuditLogCursor
+//   - limit int
+func (_e *MockDatabase_Expecter) GetAuditLogs(ctx interface{}, filter interface{}, after interface{}, limit interface{}) *MockDatabase_GetAuditLogs_Call {
+	return &MockDatabase_GetAuditLogs_Call{Call: _e.mock.On("GetAuditLogs", ctx, filter, after, limit)}
 }
 
-func (_c *MockDatabase_GetTeamMembersForReconciler_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetTeamMembersForReconciler_Call {
+func (_c *MockDatabase_GetAuditLogs_Call) Run(run func(ctx context.Context, filter AuditLogFilter, after *AuditLogCursor, limit int)) *MockDatabase_GetAuditLogs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].(AuditLogFilter), args[2].(*AuditLogCursor), args[3].(int))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMembersForReconciler_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetTeamMembersForReconciler_Call {
+func (_c *MockDatabase_GetAuditLogs_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamMembersForReconciler_Call) RunAndReturn(run func(context.Context, slug.Slug, sqlc.ReconcilerName) ([]*User, error)) *MockDatabase_GetTeamMembersForReconciler_Call {
+func (_c *MockDatabase_GetAuditLogs_Call) RunAndReturn(run func(context.Context, AuditLogFilter, *AuditLogCursor, int) ([]*AuditLog, error)) *MockDatabase_GetAuditLogs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamReconcilerErrors provides a mock function with given fields: ctx, _a1
-func (_m *MockDatabase) GetTeamReconcilerErrors(ctx context.Context, _a1 slug.Slug) ([]*ReconcilerError, error) {
-	ret := _m.Called(ctx, _a1)
+// GetAuditLogsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockDatabase) GetAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) ([]*AuditLog, error) {
+	ret := _m.Called(ctx, ids)
 
-	var r0 []*ReconcilerError
+	var r0 []*AuditLog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*ReconcilerError, error)); ok {
-		return rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*AuditLog, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*ReconcilerError); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*AuditLog); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*ReconcilerError)
+			r0 = ret.Get(0).([]*AuditLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2480,54 +2993,54 @@ func (_m *MockDatabase) GetTeamReconcilerErrors(ctx context.Context, _a1 slug.Sl
 	return r0, r1
 }
 
-// MockDatabase_GetTeamReconcilerErrors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamReconcilerErrors'
-type MockDatabase_GetTeamReconcilerErrors_Call struct {
+// MockDatabase_GetAuditLogsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsByIDs'
+type MockDatabase_GetAuditLogsByIDs_Call struct {
 	*mock.Call
 }
 
-// GetTeamReconcilerErrors is a helper method to define mock.On call
+// GetAuditLogsByIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - _a1 slug.Slug
-func (_e *MockDatabase_Expecter) GetTeamReconcilerErrors(ctx interface{}, _a1 interface{}) *MockDatabase_GetTeamReconcilerErrors_Call {
-	return &MockDatabase_GetTeamReconcilerErrors_Call{Call: _e.mock.On("GetTeamReconcilerErrors", ctx, _a1)}
+//   - ids []uuid.UUID
+func (_e *MockDatabase_Expecter) GetAuditLogsByIDs(ctx interface{}, ids interface{}) *MockDatabase_GetAuditLogsByIDs_Call {
+	return &MockDatabase_GetAuditLogsByIDs_Call{Call: _e.mock.On("GetAuditLogsByIDs", ctx, ids)}
 }
 
-func (_c *MockDatabase_GetTeamReconcilerErrors_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetTeamReconcilerErrors_Call {
+func (_c *MockDatabase_GetAuditLogsByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockDatabase_GetAuditLogsByIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug))
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamReconcilerErrors_Call) Return(_a0 []*ReconcilerError, _a1 error) *MockDatabase_GetTeamReconcilerErrors_Call {
+func (_c *MockDatabase_GetAuditLogsByIDs_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogsByIDs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamReconcilerErrors_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*ReconcilerError, error)) *MockDatabase_GetTeamReconcilerErrors_Call {
+func (_c *MockDatabase_GetAuditLogsByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]*AuditLog, error)) *MockDatabase_GetAuditLogsByIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeams provides a mock function with given fields: ctx
-func (_m *MockDatabase) GetTeams(ctx context.Context) ([]*Team, error) {
-	ret := _m.Called(ctx)
+// GetAuditLogsForCorrelationID provides a mock function with given fields: ctx, correlationID
+func (_m *MockDatabase) GetAuditLogsForCorrelationID(ctx context.Context, correlationID uuid.UUID) ([]*AuditLog, error) {
+	ret := _m.Called(ctx, correlationID)
 
-	var r0 []*Team
+	var r0 []*AuditLog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*Team, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*AuditLog, error)); ok {
+		return rf(ctx, correlationID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*Team); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*AuditLog); ok {
+		r0 = rf(ctx, correlationID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Team)
+			r0 = ret.Get(0).([]*AuditLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, correlationID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2535,53 +3048,54 @@ func (_m *MockDatabase) GetTeams(ctx context.Context) ([]*Team, error) {
 	return r0, r1
 }
 
-// MockDatabase_GetTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeams'
-type MockDatabase_GetTeams_Call struct {
+// MockDatabase_GetAuditLogsForCorrelationID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsForCorrelationID'
+type MockDatabase_GetAuditLogsForCorrelationID_Call struct {
 	*mock.Call
 }
 
-// GetTeams is a helper method to define mock.On call
+// GetAuditLogsForCorrelationID is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) GetTeams(ctx interface{}) *MockDatabase_GetTeams_Call {
-	return &MockDatabase_GetTeams_Call{Call: _e.mock.On("GetTeams", ctx)}
+//   - correlationID uuid.UUID
+func (_e *MockDatabase_Expecter) GetAuditLogsForCorrelationID(ctx interface{}, correlationID interface{}) *MockDatabase_GetAuditLogsForCorrelationID_Call {
+	return &MockDatabase_GetAuditLogsForCorrelationID_Call{Call: _e.mock.On("GetAuditLogsForCorrelationID", ctx, correlationID)}
 }
 
-func (_c *MockDatabase_GetTeams_Call) Run(run func(ctx context.Context)) *MockDatabase_GetTeams_Call {
+func (_c *MockDatabase_GetAuditLogsForCorrelationID_Call) Run(run func(ctx context.Context, correlationID uuid.UUID)) *MockDatabase_GetAuditLogsForCorrelationID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeams_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetTeams_Call {
+func (_c *MockDatabase_GetAuditLogsForCorrelationID_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogsForCorrelationID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeams_Call) RunAndReturn(run func(context.Context) ([]*Team, error)) *MockDatabase_GetTeams_Call {
+func (_c *MockDatabase_GetAuditLogsForCorrelationID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*AuditLog, error)) *MockDatabase_GetAuditLogsForCorrelationID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamsWithPermissionInGitHubRepo provides a mock function with given fields: ctx, repoName, permission
-func (_m *MockDatabase) GetTeamsWithPermissionInGitHubRepo(ctx context.Context, repoName string, permission string) ([]*Team, error) {
-	ret := _m.Called(ctx, repoName, permission)
+// GetAuditLogsForReconciler provides a mock function with given fields: ctx, reconcilerName
+func (_m *MockDatabase) GetAuditLogsForReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) ([]*AuditLog, error) {
+	ret := _m.Called(ctx, reconcilerName)
 
-	var r0 []*Team
+	var r0 []*AuditLog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*Team, error)); ok {
-		return rf(ctx, repoName, permission)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) ([]*AuditLog, error)); ok {
+		return rf(ctx, reconcilerName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*Team); ok {
-		r0 = rf(ctx, repoName, permission)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) []*AuditLog); ok {
+		r0 = rf(ctx, reconcilerName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Team)
+			r0 = ret.Get(0).([]*AuditLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, repoName, permission)
+	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, reconcilerName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2589,55 +3103,54 @@ func (_m *MockDatabase) GetTeamsWithPermissionInGitHubRepo(ctx context.Context,
 	return r0, r1
 }
 
-// MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamsWithPermissionInGitHubRepo'
-type MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call struct {
+// MockDatabase_GetAuditLogsForReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsForReconciler'
+type MockDatabase_GetAuditLogsForReconciler_Call struct {
 	*mock.Call
 }
 
-// GetTeamsWithPermissionInGitHubRepo is a helper method to define mock.On call
+// GetAuditLogsForReconciler is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repoName string
-//   - permission string
-func (_e *MockDatabase_Expecter) GetTeamsWithPermissionInGitHubRepo(ctx interface{}, repoName interface{}, permission interface{}) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
-	return &MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call{Call: _e.mock.On("GetTeamsWithPermissionInGitHubRepo", ctx, repoName, permission)}
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) GetAuditLogsForReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_GetAuditLogsForReconciler_Call {
+	return &MockDatabase_GetAuditLogsForReconciler_Call{Call: _e.mock.On("GetAuditLogsForReconciler", ctx, reconcilerName)}
 }
 
-func (_c *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call) Run(run func(ctx context.Context, repoName string, permission string)) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
+func (_c *MockDatabase_GetAuditLogsForReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetAuditLogsForReconciler_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
+func (_c *MockDatabase_GetAuditLogsForReconciler_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogsForReconciler_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call) RunAndReturn(run func(context.Context, string, string) ([]*Team, error)) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
+func (_c *MockDatabase_GetAuditLogsForReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) ([]*AuditLog, error)) *MockDatabase_GetAuditLogsForReconciler_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByEmail provides a mock function with given fields: ctx, email
-func (_m *MockDatabase) GetUserByEmail(ctx context.Context, email string) (*User, error) {
-	ret := _m.Called(ctx, email)
+// GetAuditLogsForTeam provides a mock function with given fields: ctx, _a1
+func (_m *MockDatabase) GetAuditLogsForTeam(ctx context.Context, _a1 slug.Slug) ([]*AuditLog, error) {
+	ret := _m.Called(ctx, _a1)
 
-	var r0 *User
+	var r0 []*AuditLog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*User, error)); ok {
-		return rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*AuditLog, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *User); ok {
-		r0 = rf(ctx, email)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*AuditLog); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).([]*AuditLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, email)
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2645,54 +3158,54 @@ func (_m *MockDatabase) GetUserByEmail(ctx context.Context, email string) (*User
 	return r0, r1
 }
 
-// MockDatabase_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
-type MockDatabase_GetUserByEmail_Call struct {
+// MockDatabase_GetAuditLogsForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogsForTeam'
+type MockDatabase_GetAuditLogsForTeam_Call struct {
 	*mock.Call
 }
 
-// GetUserByEmail is a helper method to define mock.On call
+// GetAuditLogsForTeam is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-func (_e *MockDatabase_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *MockDatabase_GetUserByEmail_Call {
-	return &MockDatabase_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+//   - _a1 slug.Slug
+func (_e *MockDatabase_Expecter) GetAuditLogsForTeam(ctx interface{}, _a1 interface{}) *MockDatabase_GetAuditLogsForTeam_Call {
+	return &MockDatabase_GetAuditLogsForTeam_Call{Call: _e.mock.On("GetAuditLogsForTeam", ctx, _a1)}
 }
 
-func (_c *MockDatabase_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockDatabase_GetUserByEmail_Call {
+func (_c *MockDatabase_GetAuditLogsForTeam_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetAuditLogsForTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(slug.Slug))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetUserByEmail_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetUserByEmail_Call {
+func (_c *MockDatabase_GetAuditLogsForTeam_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetAuditLogsForTeam_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetUserByEmail_Call) RunAndReturn(run func(context.Context, string) (*User, error)) *MockDatabase_GetUserByEmail_Call {
+func (_c *MockDatabase_GetAuditLogsForTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*AuditLog, error)) *MockDatabase_GetAuditLogsForTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByExternalID provides a mock function with given fields: ctx, externalID
-func (_m *MockDatabase) GetUserByExternalID(ctx context.Context, externalID string) (*User, error) {
-	ret := _m.Called(ctx, externalID)
+// GetEnabledReconcilers provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetEnabledReconcilers(ctx context.Context) ([]*Reconciler, error) {
+	ret := _m.Called(ctx)
 
-	var r0 *User
+	var r0 []*Reconciler
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*User, error)); ok {
-		return rf(ctx, externalID)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*Reconciler, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *User); ok {
-		r0 = rf(ctx, externalID)
+	if rf, ok := ret.Get(0).(func(context.Context) []*Reconciler); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).([]*Reconciler)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, externalID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2700,54 +3213,53 @@ func (_m *MockDatabase) GetUserByExternalID(ctx context.Context, externalID stri
 	return r0, r1
 }
 
-// MockDatabase_GetUserByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByExternalID'
-type MockDatabase_GetUserByExternalID_Call struct {
+// MockDatabase_GetEnabledReconcilers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnabledReconcilers'
+type MockDatabase_GetEnabledReconcilers_Call struct {
 	*mock.Call
 }
 
-// GetUserByExternalID is a helper method to define mock.On call
+// GetEnabledReconcilers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - externalID string
-func (_e *MockDatabase_Expecter) GetUserByExternalID(ctx interface{}, externalID interface{}) *MockDatabase_GetUserByExternalID_Call {
-	return &MockDatabase_GetUserByExternalID_Call{Call: _e.mock.On("GetUserByExternalID", ctx, externalID)}
+func (_e *MockDatabase_Expecter) GetEnabledReconcilers(ctx interface{}) *MockDatabase_GetEnabledReconcilers_Call {
+	return &MockDatabase_GetEnabledReconcilers_Call{Call: _e.mock.On("GetEnabledReconcilers", ctx)}
 }
 
-func (_c *MockDatabase_GetUserByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockDatabase_GetUserByExternalID_Call {
+func (_c *MockDatabase_GetEnabledReconcilers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetEnabledReconcilers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetUserByExternalID_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetUserByExternalID_Call {
+func (_c *MockDatabase_GetEnabledReconcilers_Call) Return(_a0 []*Reconciler, _a1 error) *MockDatabase_GetEnabledReconcilers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetUserByExternalID_Call) RunAndReturn(run func(context.Context, string) (*User, error)) *MockDatabase_GetUserByExternalID_Call {
+func (_c *MockDatabase_GetEnabledReconcilers_Call) RunAndReturn(run func(context.Context) ([]*Reconciler, error)) *MockDatabase_GetEnabledReconcilers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByID provides a mock function with given fields: ctx, ID
-func (_m *MockDatabase) GetUserByID(ctx context.Context, ID uuid.UUID) (*User, error) {
-	ret := _m.Called(ctx, ID)
+// GetExpiredAuditLogs provides a mock function with given fields: ctx, filter, limit
+func (_m *MockDatabase) GetExpiredAuditLogs(ctx context.Context, filter ExpiredAuditLogsFilter, limit int) ([]*AuditLog, error) {
+	ret := _m.Called(ctx, filter, limit)
 
-	var r0 *User
+	var r0 []*AuditLog
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*User, error)); ok {
-		return rf(ctx, ID)
+	if rf, ok := ret.Get(0).(func(context.Context, ExpiredAuditLogsFilter, int) ([]*AuditLog, error)); ok {
+		return rf(ctx, filter, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *User); ok {
-		r0 = rf(ctx, ID)
+	if rf, ok := ret.Get(0).(func(context.Context, ExpiredAuditLogsFilter, int) []*AuditLog); ok {
+		r0 = rf(ctx, filter, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*User)
+			r0 = ret.Get(0).([]*AuditLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, ID)
+	if rf, ok := ret.Get(1).(func(context.Context, ExpiredAuditLogsFilter, int) error); ok {
+		r1 = rf(ctx, filter, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2755,54 +3267,53 @@ func (_m *MockDatabase) GetUserByID(ctx context.Context, ID uuid.UUID) (*User, e
 	return r0, r1
 }
 
-// MockDatabase_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
-type MockDatabase_GetUserByID_Call struct {
+// MockDatabase_GetExpiredAuditLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredAuditLogs'
+type MockDatabase_GetExpiredAuditLogs_Call struct {
 	*mock.Call
 }
 
-// GetUserByID is a helper method to define mock.On call
+// GetExpiredAuditLogs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ID uuid.UUID
-func (_e *MockDatabase_Expecter) GetUserByID(ctx interface{}, ID interface{}) *MockDatabase_GetUserByID_Call {
-	return &MockDatabase_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, ID)}
+//   - filter ExpiredAuditLogsFilter
+//   - limit int
+func (_e *MockDatabase_Expecter) GetExpiredAuditLogs(ctx interface{}, filter interface{}, limit interface{}) *MockDatabase_GetExpiredAuditLogs_Call {
+	return &MockDatabase_GetExpiredAuditLogs_Call{Call: _e.mock.On("GetExpiredAuditLogs", ctx, filter, limit)}
 }
 
-func (_c *MockDatabase_GetUserByID_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *MockDatabase_GetUserByID_Call {
+func (_c *MockDatabase_GetExpiredAuditLogs_Call) Run(run func(ctx context.Context, filter ExpiredAuditLogsFilter, limit int)) *MockDatabase_GetExpiredAuditLogs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(ExpiredAuditLogsFilter), args[2].(int))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetUserByID_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetUserByID_Call {
+func (_c *MockDatabase_GetExpiredAuditLogs_Call) Return(_a0 []*AuditLog, _a1 error) *MockDatabase_GetExpiredAuditLogs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetUserByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*User, error)) *MockDatabase_GetUserByID_Call {
+func (_c *MockDatabase_GetExpiredAuditLogs_Call) RunAndReturn(run func(context.Context, ExpiredAuditLogsFilter, int) ([]*AuditLog, error)) *MockDatabase_GetExpiredAuditLogs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserRoles provides a mock function with given fields: ctx, userID
-func (_m *MockDatabase) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*Role, error) {
-	ret := _m.Called(ctx, userID)
+// GetPendingAuditLogOutboxCount provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetPendingAuditLogOutboxCount(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []*Role
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*Role, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*Role); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Role)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2810,54 +3321,51 @@ func (_m *MockDatabase) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*
 	return r0, r1
 }
 
-// MockDatabase_GetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRoles'
-type MockDatabase_GetUserRoles_Call struct {
+// MockDatabase_GetPendingAuditLogOutboxCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingAuditLogOutboxCount'
+type MockDatabase_GetPendingAuditLogOutboxCount_Call struct {
 	*mock.Call
 }
 
-// GetUserRoles is a helper method to define mock.On call
+// GetPendingAuditLogOutboxCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-func (_e *MockDatabase_Expecter) GetUserRoles(ctx interface{}, userID interface{}) *MockDatabase_GetUserRoles_Call {
-	return &MockDatabase_GetUserRoles_Call{Call: _e.mock.On("GetUserRoles", ctx, userID)}
+func (_e *MockDatabase_Expecter) GetPendingAuditLogOutboxCount(ctx interface{}) *MockDatabase_GetPendingAuditLogOutboxCount_Call {
+	return &MockDatabase_GetPendingAuditLogOutboxCount_Call{Call: _e.mock.On("GetPendingAuditLogOutboxCount", ctx)}
 }
 
-func (_c *MockDatabase_GetUserRoles_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockDatabase_GetUserRoles_Call {
+func (_c *MockDatabase_GetPendingAuditLogOutboxCount_Call) Run(run func(ctx context.Context)) *MockDatabase_GetPendingAuditLogOutboxCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetUserRoles_Call) Return(_a0 []*Role, _a1 error) *MockDatabase_GetUserRoles_Call {
+func (_c *MockDatabase_GetPendingAuditLogOutboxCount_Call) Return(_a0 int64, _a1 error) *MockDatabase_GetPendingAuditLogOutboxCount_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetUserRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*Role, error)) *MockDatabase_GetUserRoles_Call {
+func (_c *MockDatabase_GetPendingAuditLogOutboxCount_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockDatabase_GetPendingAuditLogOutboxCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserTeams provides a mock function with given fields: ctx, userID
-func (_m *MockDatabase) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*Team, error) {
-	ret := _m.Called(ctx, userID)
+// GetPendingTeamSyncCount provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetPendingTeamSyncCount(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []*Team
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*Team, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*Team); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*Team)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2865,50 +3373,47 @@ func (_m *MockDatabase) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*
 	return r0, r1
 }
 
-// MockDatabase_GetUserTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserTeams'
-type MockDatabase_GetUserTeams_Call struct {
+// MockDatabase_GetPendingTeamSyncCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTeamSyncCount'
+type MockDatabase_GetPendingTeamSyncCount_Call struct {
 	*mock.Call
 }
 
-// GetUserTeams is a helper method to define mock.On call
+// GetPendingTeamSyncCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-func (_e *MockDatabase_Expecter) GetUserTeams(ctx interface{}, userID interface{}) *MockDatabase_GetUserTeams_Call {
-	return &MockDatabase_GetUserTeams_Call{Call: _e.mock.On("GetUserTeams", ctx, userID)}
+func (_e *MockDatabase_Expecter) GetPendingTeamSyncCount(ctx interface{}) *MockDatabase_GetPendingTeamSyncCount_Call {
+	return &MockDatabase_GetPendingTeamSyncCount_Call{Call: _e.mock.On("GetPendingTeamSyncCount", ctx)}
 }
 
-func (_c *MockDatabase_GetUserTeams_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockDatabase_GetUserTeams_Call {
+func (_c *MockDatabase_GetPendingTeamSyncCount_Call) Run(run func(ctx context.Context)) *MockDatabase_GetPendingTeamSyncCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetUserTeams_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetUserTeams_Call {
+func (_c *MockDatabase_GetPendingTeamSyncCount_Call) Return(_a0 int64, _a1 error) *MockDatabase_GetPendingTeamSyncCount_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetUserTeams_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*Team, error)) *MockDatabase_GetUserTeams_Call {
+func (_c *MockDatabase_GetPendingTeamSyncCount_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockDatabase_GetPendingTeamSyncCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsers provides a mock function with given fields: ctx
-func (_m *MockDatabase) GetUsers(ctx context.Context) ([]*User, error) {
+// GetPendingWebhookDeliveryCount provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetPendingWebhookDeliveryCount(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
 
-	var r0 []*User
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*User, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*User)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -2920,53 +3425,53 @@ func (_m *MockDatabase) GetUsers(ctx context.Context) ([]*User, error) {
 	return r0, r1
 }
 
-// MockDatabase_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
-type MockDatabase_GetUsers_Call struct {
+// MockDatabase_GetPendingWebhookDeliveryCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingWebhookDeliveryCount'
+type MockDatabase_GetPendingWebhookDeliveryCount_Call struct {
 	*mock.Call
 }
 
-// GetUsers is a helper method to define mock.On call
+// GetPendingWebhookDeliveryCount is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) GetUsers(ctx interface{}) *MockDatabase_GetUsers_Call {
-	return &MockDatabase_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
+func (_e *MockDatabase_Expecter) GetPendingWebhookDeliveryCount(ctx interface{}) *MockDatabase_GetPendingWebhookDeliveryCount_Call {
+	return &MockDatabase_GetPendingWebhookDeliveryCount_Call{Call: _e.mock.On("GetPendingWebhookDeliveryCount", ctx)}
 }
 
-func (_c *MockDatabase_GetUsers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetUsers_Call {
+func (_c *MockDatabase_GetPendingWebhookDeliveryCount_Call) Run(run func(ctx context.Context)) *MockDatabase_GetPendingWebhookDeliveryCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetUsers_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetUsers_Call {
+func (_c *MockDatabase_GetPendingWebhookDeliveryCount_Call) Return(_a0 int64, _a1 error) *MockDatabase_GetPendingWebhookDeliveryCount_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetUsers_Call) RunAndReturn(run func(context.Context) ([]*User, error)) *MockDatabase_GetUsers_Call {
+func (_c *MockDatabase_GetPendingWebhookDeliveryCount_Call) RunAndReturn(run func(context.Context) (int64, error)) *MockDatabase_GetPendingWebhookDeliveryCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsersWithGloballyAssignedRole provides a mock function with given fields: ctx, roleName
-func (_m *MockDatabase) GetUsersWithGloballyAssignedRole(ctx context.Context, roleName sqlc.RoleName) ([]*User, error) {
-	ret := _m.Called(ctx, roleName)
+// GetReconciler provides a mock function with given fields: ctx, reconcilerName
+func (_m *MockDatabase) GetReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
+	ret := _m.Called(ctx, reconcilerName)
 
-	var r0 []*User
+	var r0 *Reconciler
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.RoleName) ([]*User, error)); ok {
-		return rf(ctx, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)); ok {
+		return rf(ctx, reconcilerName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.RoleName) []*User); ok {
-		r0 = rf(ctx, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *Reconciler); ok {
+		r0 = rf(ctx, reconcilerName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*User)
+			r0 = ret.Get(0).(*Reconciler)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, sqlc.RoleName) error); ok {
-		r1 = rf(ctx, roleName)
+	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, reconcilerName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2974,52 +3479,54 @@ func (_m *MockDatabase) GetUsersWithGloballyAssignedRole(ctx context.Context, ro
 	return r0, r1
 }
 
-// MockDatabase_GetUsersWithGloballyAssignedRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersWithGloballyAssignedRole'
-type MockDatabase_GetUsersWithGloballyAssignedRole_Call struct {
+// MockDatabase_GetReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconciler'
+type MockDatabase_GetReconciler_Call struct {
 	*mock.Call
 }
 
-// GetUsersWithGloballyAssignedRole is a helper method to define mock.On call
+// GetReconciler is a helper method to define mock.On call
 //   - ctx context.Context
-//   - roleName sqlc.RoleName
-func (_e *MockDatabase_Expecter) GetUsersWithGloballyAssignedRole(ctx interface{}, roleName interface{}) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
-	return &MockDatabase_GetUsersWithGloballyAssignedRole_Call{Call: _e.mock.On("GetUsersWithGloballyAssignedRole", ctx, roleName)}
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) GetReconciler(ctx interface{}, reconcilerName interface{}) *MockDatabase_GetReconciler_Call {
+	return &MockDatabase_GetReconciler_Call{Call: _e.mock.On("GetReconciler", ctx, reconcilerName)}
 }
 
-func (_c *MockDatabase_GetUsersWithGloballyAssignedRole_Call) Run(run func(ctx context.Context, roleName sqlc.RoleName)) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
+func (_c *MockDatabase_GetReconciler_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetReconciler_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.RoleName))
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_GetUsersWithGloballyAssignedRole_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
+func (_c *MockDatabase_GetReconciler_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_GetReconciler_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_GetUsersWithGloballyAssignedRole_Call) RunAndReturn(run func(context.Context, sqlc.RoleName) ([]*User, error)) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
+func (_c *MockDatabase_GetReconciler_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_GetReconciler_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IsFirstRun provides a mock function with given fields: ctx
-func (_m *MockDatabase) IsFirstRun(ctx context.Context) (bool, error) {
-	ret := _m.Called(ctx)
+// GetReconcilerConfig provides a mock function with given fields: ctx, reconcilerName
+func (_m *MockDatabase) GetReconcilerConfig(ctx context.Context, reconcilerName sqlc.ReconcilerName) ([]*ReconcilerConfig, error) {
+	ret := _m.Called(ctx, reconcilerName)
 
-	var r0 bool
+	var r0 []*ReconcilerConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) ([]*ReconcilerConfig, error)); ok {
+		return rf(ctx, reconcilerName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) []*ReconcilerConfig); ok {
+		r0 = rf(ctx, reconcilerName)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ReconcilerConfig)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, reconcilerName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3027,41 +3534,3376 @@ func (_m *MockDatabase) IsFirstRun(ctx context.Context) (bool, error) {
 	return r0, r1
 }
 
-// MockDatabase_IsFirstRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsFirstRun'
-type MockDatabase_IsFirstRun_Call struct {
+// MockDatabase_GetReconcilerConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconcilerConfig'
+type MockDatabase_GetReconcilerConfig_Call struct {
 	*mock.Call
 }
 
-// IsFirstRun is a helper method to define mock.On call
+// GetReconcilerConfig is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockDatabase_Expecter) IsFirstRun(ctx interface{}) *MockDatabase_IsFirstRun_Call {
-	return &MockDatabase_IsFirstRun_Call{Call: _e.mock.On("IsFirstRun", ctx)}
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) GetReconcilerConfig(ctx interface{}, reconcilerName interface{}) *MockDatabase_GetReconcilerConfig_Call {
+	return &MockDatabase_GetReconcilerConfig_Call{Call: _e.mock.On("GetReconcilerConfig", ctx, reconcilerName)}
 }
 
-func (_c *MockDatabase_IsFirstRun_Call) Run(run func(ctx context.Context)) *MockDatabase_IsFirstRun_Call {
+func (_c *MockDatabase_GetReconcilerConfig_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetReconcilerConfig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_IsFirstRun_Call) Return(_a0 bool, _a1 error) *MockDatabase_IsFirstRun_Call {
+func (_c *MockDatabase_GetReconcilerConfig_Call) Return(_a0 []*ReconcilerConfig, _a1 error) *MockDatabase_GetReconcilerConfig_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_IsFirstRun_Call) RunAndReturn(run func(context.Context) (bool, error)) *MockDatabase_IsFirstRun_Call {
+func (_c *MockDatabase_GetReconcilerConfig_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) ([]*ReconcilerConfig, error)) *MockDatabase_GetReconcilerConfig_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LoadReconcilerStateForTeam provides a mock function with given fields: ctx, reconcilerName, _a2, state
-func (_m *MockDatabase) LoadReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{}) error {
-	ret := _m.Called(ctx, reconcilerName, _a2, state)
+// GetReconcilers provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetReconcilers(ctx context.Context) ([]*Reconciler, error) {
+	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error); ok {
-		r0 = rf(ctx, reconcilerName, _a2, state)
+	var r0 []*Reconciler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*Reconciler, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*Reconciler); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Reconciler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetReconcilers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReconcilers'
+type MockDatabase_GetReconcilers_Call struct {
+	*mock.Call
+}
+
+// GetReconcilers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetReconcilers(ctx interface{}) *MockDatabase_GetReconcilers_Call {
+	return &MockDatabase_GetReconcilers_Call{Call: _e.mock.On("GetReconcilers", ctx)}
+}
+
+func (_c *MockDatabase_GetReconcilers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetReconcilers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetReconcilers_Call) Return(_a0 []*Reconciler, _a1 error) *MockDatabase_GetReconcilers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetReconcilers_Call) RunAndReturn(run func(context.Context) ([]*Reconciler, error)) *MockDatabase_GetReconcilers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRepositoryAuthorizations provides a mock function with given fields: ctx, teamSlug, repo
+func (_m *MockDatabase) GetRepositoryAuthorizations(ctx context.Context, teamSlug slug.Slug, repo string) ([]sqlc.RepositoryAuthorizationEnum, error) {
+	ret := _m.Called(ctx, teamSlug, repo)
+
+	var r0 []sqlc.RepositoryAuthorizationEnum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string) ([]sqlc.RepositoryAuthorizationEnum, error)); ok {
+		return rf(ctx, teamSlug, repo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string) []sqlc.RepositoryAuthorizationEnum); ok {
+		r0 = rf(ctx, teamSlug, repo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqlc.RepositoryAuthorizationEnum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, string) error); ok {
+		r1 = rf(ctx, teamSlug, repo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetRepositoryAuthorizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRepositoryAuthorizations'
+type MockDatabase_GetRepositoryAuthorizations_Call struct {
+	*mock.Call
+}
+
+// GetRepositoryAuthorizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - repo string
+func (_e *MockDatabase_Expecter) GetRepositoryAuthorizations(ctx interface{}, teamSlug interface{}, repo interface{}) *MockDatabase_GetRepositoryAuthorizations_Call {
+	return &MockDatabase_GetRepositoryAuthorizations_Call{Call: _e.mock.On("GetRepositoryAuthorizations", ctx, teamSlug, repo)}
+}
+
+func (_c *MockDatabase_GetRepositoryAuthorizations_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, repo string)) *MockDatabase_GetRepositoryAuthorizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetRepositoryAuthorizations_Call) Return(_a0 []sqlc.RepositoryAuthorizationEnum, _a1 error) *MockDatabase_GetRepositoryAuthorizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetRepositoryAuthorizations_Call) RunAndReturn(run func(context.Context, slug.Slug, string) ([]sqlc.RepositoryAuthorizationEnum, error)) *MockDatabase_GetRepositoryAuthorizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoleAuthorizations provides a mock function with given fields: ctx, roleName
+func (_m *MockDatabase) GetRoleAuthorizations(ctx context.Context, roleName roles.RoleName) ([]roles.Authorization, error) {
+	ret := _m.Called(ctx, roleName)
+
+	var r0 []roles.Authorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName) ([]roles.Authorization, error)); ok {
+		return rf(ctx, roleName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName) []roles.Authorization); ok {
+		r0 = rf(ctx, roleName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]roles.Authorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, roles.RoleName) error); ok {
+		r1 = rf(ctx, roleName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetRoleAuthorizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoleAuthorizations'
+type MockDatabase_GetRoleAuthorizations_Call struct {
+	*mock.Call
+}
+
+// GetRoleAuthorizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName roles.RoleName
+func (_e *MockDatabase_Expecter) GetRoleAuthorizations(ctx interface{}, roleName interface{}) *MockDatabase_GetRoleAuthorizations_Call {
+	return &MockDatabase_GetRoleAuthorizations_Call{Call: _e.mock.On("GetRoleAuthorizations", ctx, roleName)}
+}
+
+func (_c *MockDatabase_GetRoleAuthorizations_Call) Run(run func(ctx context.Context, roleName roles.RoleName)) *MockDatabase_GetRoleAuthorizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(roles.RoleName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetRoleAuthorizations_Call) Return(_a0 []roles.Authorization, _a1 error) *MockDatabase_GetRoleAuthorizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetRoleAuthorizations_Call) RunAndReturn(run func(context.Context, roles.RoleName) ([]roles.Authorization, error)) *MockDatabase_GetRoleAuthorizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoleDefinition provides a mock function with given fields: ctx, roleName
+func (_m *MockDatabase) GetRoleDefinition(ctx context.Context, roleName roles.RoleName) (*RoleDefinition, error) {
+	ret := _m.Called(ctx, roleName)
+
+	var r0 *RoleDefinition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName) (*RoleDefinition, error)); ok {
+		return rf(ctx, roleName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName) *RoleDefinition); ok {
+		r0 = rf(ctx, roleName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*RoleDefinition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, roles.RoleName) error); ok {
+		r1 = rf(ctx, roleName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetRoleDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoleDefinition'
+type MockDatabase_GetRoleDefinition_Call struct {
+	*mock.Call
+}
+
+// GetRoleDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName roles.RoleName
+func (_e *MockDatabase_Expecter) GetRoleDefinition(ctx interface{}, roleName interface{}) *MockDatabase_GetRoleDefinition_Call {
+	return &MockDatabase_GetRoleDefinition_Call{Call: _e.mock.On("GetRoleDefinition", ctx, roleName)}
+}
+
+func (_c *MockDatabase_GetRoleDefinition_Call) Run(run func(ctx context.Context, roleName roles.RoleName)) *MockDatabase_GetRoleDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(roles.RoleName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetRoleDefinition_Call) Return(_a0 *RoleDefinition, _a1 error) *MockDatabase_GetRoleDefinition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetRoleDefinition_Call) RunAndReturn(run func(context.Context, roles.RoleName) (*RoleDefinition, error)) *MockDatabase_GetRoleDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoleDefinitions provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetRoleDefinitions(ctx context.Context) ([]*RoleDefinition, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*RoleDefinition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*RoleDefinition, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*RoleDefinition); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*RoleDefinition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetRoleDefinitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoleDefinitions'
+type MockDatabase_GetRoleDefinitions_Call struct {
+	*mock.Call
+}
+
+// GetRoleDefinitions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetRoleDefinitions(ctx interface{}) *MockDatabase_GetRoleDefinitions_Call {
+	return &MockDatabase_GetRoleDefinitions_Call{Call: _e.mock.On("GetRoleDefinitions", ctx)}
+}
+
+func (_c *MockDatabase_GetRoleDefinitions_Call) Run(run func(ctx context.Context)) *MockDatabase_GetRoleDefinitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetRoleDefinitions_Call) Return(_a0 []*RoleDefinition, _a1 error) *MockDatabase_GetRoleDefinitions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetRoleDefinitions_Call) RunAndReturn(run func(context.Context) ([]*RoleDefinition, error)) *MockDatabase_GetRoleDefinitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRolesAuthorizations provides a mock function with given fields: ctx, roleNames
+func (_m *MockDatabase) GetRolesAuthorizations(ctx context.Context, roleNames []roles.RoleName) (map[roles.RoleName][]roles.Authorization, error) {
+	ret := _m.Called(ctx, roleNames)
+
+	var r0 map[roles.RoleName][]roles.Authorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []roles.RoleName) (map[roles.RoleName][]roles.Authorization, error)); ok {
+		return rf(ctx, roleNames)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []roles.RoleName) map[roles.RoleName][]roles.Authorization); ok {
+		r0 = rf(ctx, roleNames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[roles.RoleName][]roles.Authorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []roles.RoleName) error); ok {
+		r1 = rf(ctx, roleNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetRolesAuthorizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRolesAuthorizations'
+type MockDatabase_GetRolesAuthorizations_Call struct {
+	*mock.Call
+}
+
+// GetRolesAuthorizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleNames []roles.RoleName
+func (_e *MockDatabase_Expecter) GetRolesAuthorizations(ctx interface{}, roleNames interface{}) *MockDatabase_GetRolesAuthorizations_Call {
+	return &MockDatabase_GetRolesAuthorizations_Call{Call: _e.mock.On("GetRolesAuthorizations", ctx, roleNames)}
+}
+
+func (_c *MockDatabase_GetRolesAuthorizations_Call) Run(run func(ctx context.Context, roleNames []roles.RoleName)) *MockDatabase_GetRolesAuthorizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]roles.RoleName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetRolesAuthorizations_Call) Return(_a0 map[roles.RoleName][]roles.Authorization, _a1 error) *MockDatabase_GetRolesAuthorizations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetRolesAuthorizations_Call) RunAndReturn(run func(context.Context, []roles.RoleName) (map[roles.RoleName][]roles.Authorization, error)) *MockDatabase_GetRolesAuthorizations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccountAPIKeys provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockDatabase) GetServiceAccountAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]*ApiKey, error) {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	var r0 []*ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*ApiKey, error)); ok {
+		return rf(ctx, serviceAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*ApiKey); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, serviceAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetServiceAccountAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountAPIKeys'
+type MockDatabase_GetServiceAccountAPIKeys_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccountAPIKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID uuid.UUID
+func (_e *MockDatabase_Expecter) GetServiceAccountAPIKeys(ctx interface{}, serviceAccountID interface{}) *MockDatabase_GetServiceAccountAPIKeys_Call {
+	return &MockDatabase_GetServiceAccountAPIKeys_Call{Call: _e.mock.On("GetServiceAccountAPIKeys", ctx, serviceAccountID)}
+}
+
+func (_c *MockDatabase_GetServiceAccountAPIKeys_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_GetServiceAccountAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountAPIKeys_Call) Return(_a0 []*ApiKey, _a1 error) *MockDatabase_GetServiceAccountAPIKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountAPIKeys_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*ApiKey, error)) *MockDatabase_GetServiceAccountAPIKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccountByApiKey provides a mock function with given fields: ctx, APIKey
+func (_m *MockDatabase) GetServiceAccountByApiKey(ctx context.Context, APIKey string) (*ServiceAccount, error) {
+	ret := _m.Called(ctx, APIKey)
+
+	var r0 *ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ServiceAccount, error)); ok {
+		return rf(ctx, APIKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ServiceAccount); ok {
+		r0 = rf(ctx, APIKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, APIKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetServiceAccountByApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountByApiKey'
+type MockDatabase_GetServiceAccountByApiKey_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccountByApiKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - APIKey string
+func (_e *MockDatabase_Expecter) GetServiceAccountByApiKey(ctx interface{}, APIKey interface{}) *MockDatabase_GetServiceAccountByApiKey_Call {
+	return &MockDatabase_GetServiceAccountByApiKey_Call{Call: _e.mock.On("GetServiceAccountByApiKey", ctx, APIKey)}
+}
+
+func (_c *MockDatabase_GetServiceAccountByApiKey_Call) Run(run func(ctx context.Context, APIKey string)) *MockDatabase_GetServiceAccountByApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountByApiKey_Call) Return(_a0 *ServiceAccount, _a1 error) *MockDatabase_GetServiceAccountByApiKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountByApiKey_Call) RunAndReturn(run func(context.Context, string) (*ServiceAccount, error)) *MockDatabase_GetServiceAccountByApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccountByID provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockDatabase) GetServiceAccountByID(ctx context.Context, serviceAccountID uuid.UUID) (*ServiceAccount, error) {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	var r0 *ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*ServiceAccount, error)); ok {
+		return rf(ctx, serviceAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *ServiceAccount); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, serviceAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetServiceAccountByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountByID'
+type MockDatabase_GetServiceAccountByID_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccountByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID uuid.UUID
+func (_e *MockDatabase_Expecter) GetServiceAccountByID(ctx interface{}, serviceAccountID interface{}) *MockDatabase_GetServiceAccountByID_Call {
+	return &MockDatabase_GetServiceAccountByID_Call{Call: _e.mock.On("GetServiceAccountByID", ctx, serviceAccountID)}
+}
+
+func (_c *MockDatabase_GetServiceAccountByID_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_GetServiceAccountByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountByID_Call) Return(_a0 *ServiceAccount, _a1 error) *MockDatabase_GetServiceAccountByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*ServiceAccount, error)) *MockDatabase_GetServiceAccountByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccountByName provides a mock function with given fields: ctx, name
+func (_m *MockDatabase) GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ServiceAccount, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ServiceAccount); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetServiceAccountByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountByName'
+type MockDatabase_GetServiceAccountByName_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccountByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockDatabase_Expecter) GetServiceAccountByName(ctx interface{}, name interface{}) *MockDatabase_GetServiceAccountByName_Call {
+	return &MockDatabase_GetServiceAccountByName_Call{Call: _e.mock.On("GetServiceAccountByName", ctx, name)}
+}
+
+func (_c *MockDatabase_GetServiceAccountByName_Call) Run(run func(ctx context.Context, name string)) *MockDatabase_GetServiceAccountByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountByName_Call) Return(_a0 *ServiceAccount, _a1 error) *MockDatabase_GetServiceAccountByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountByName_Call) RunAndReturn(run func(context.Context, string) (*ServiceAccount, error)) *MockDatabase_GetServiceAccountByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccountRoles provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockDatabase) GetServiceAccountRoles(ctx context.Context, serviceAccountID uuid.UUID) ([]*Role, error) {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	var r0 []*Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*Role, error)); ok {
+		return rf(ctx, serviceAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*Role); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, serviceAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetServiceAccountRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccountRoles'
+type MockDatabase_GetServiceAccountRoles_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccountRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID uuid.UUID
+func (_e *MockDatabase_Expecter) GetServiceAccountRoles(ctx interface{}, serviceAccountID interface{}) *MockDatabase_GetServiceAccountRoles_Call {
+	return &MockDatabase_GetServiceAccountRoles_Call{Call: _e.mock.On("GetServiceAccountRoles", ctx, serviceAccountID)}
+}
+
+func (_c *MockDatabase_GetServiceAccountRoles_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_GetServiceAccountRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountRoles_Call) Return(_a0 []*Role, _a1 error) *MockDatabase_GetServiceAccountRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccountRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*Role, error)) *MockDatabase_GetServiceAccountRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServiceAccounts provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*ServiceAccount, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*ServiceAccount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServiceAccounts'
+type MockDatabase_GetServiceAccounts_Call struct {
+	*mock.Call
+}
+
+// GetServiceAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetServiceAccounts(ctx interface{}) *MockDatabase_GetServiceAccounts_Call {
+	return &MockDatabase_GetServiceAccounts_Call{Call: _e.mock.On("GetServiceAccounts", ctx)}
+}
+
+func (_c *MockDatabase_GetServiceAccounts_Call) Run(run func(ctx context.Context)) *MockDatabase_GetServiceAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccounts_Call) Return(_a0 []*ServiceAccount, _a1 error) *MockDatabase_GetServiceAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetServiceAccounts_Call) RunAndReturn(run func(context.Context) ([]*ServiceAccount, error)) *MockDatabase_GetServiceAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessionByID provides a mock function with given fields: ctx, sessionID
+func (_m *MockDatabase) GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*Session, error) {
+	ret := _m.Called(ctx, sessionID)
+
+	var r0 *Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*Session, error)); ok {
+		return rf(ctx, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *Session); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetSessionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionByID'
+type MockDatabase_GetSessionByID_Call struct {
+	*mock.Call
+}
+
+// GetSessionByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID uuid.UUID
+func (_e *MockDatabase_Expecter) GetSessionByID(ctx interface{}, sessionID interface{}) *MockDatabase_GetSessionByID_Call {
+	return &MockDatabase_GetSessionByID_Call{Call: _e.mock.On("GetSessionByID", ctx, sessionID)}
+}
+
+func (_c *MockDatabase_GetSessionByID_Call) Run(run func(ctx context.Context, sessionID uuid.UUID)) *MockDatabase_GetSessionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetSessionByID_Call) Return(_a0 *Session, _a1 error) *MockDatabase_GetSessionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetSessionByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*Session, error)) *MockDatabase_GetSessionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSlackAlertsChannels provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) GetSlackAlertsChannels(ctx context.Context, teamSlug slug.Slug) (map[string]string, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) (map[string]string, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) map[string]string); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetSlackAlertsChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSlackAlertsChannels'
+type MockDatabase_GetSlackAlertsChannels_Call struct {
+	*mock.Call
+}
+
+// GetSlackAlertsChannels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetSlackAlertsChannels(ctx interface{}, teamSlug interface{}) *MockDatabase_GetSlackAlertsChannels_Call {
+	return &MockDatabase_GetSlackAlertsChannels_Call{Call: _e.mock.On("GetSlackAlertsChannels", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_GetSlackAlertsChannels_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetSlackAlertsChannels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetSlackAlertsChannels_Call) Return(_a0 map[string]string, _a1 error) *MockDatabase_GetSlackAlertsChannels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetSlackAlertsChannels_Call) RunAndReturn(run func(context.Context, slug.Slug) (map[string]string, error)) *MockDatabase_GetSlackAlertsChannels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamBySlug provides a mock function with given fields: ctx, _a1
+func (_m *MockDatabase) GetTeamBySlug(ctx context.Context, _a1 slug.Slug) (*Team, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 *Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) (*Team, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) *Team); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamBySlug'
+type MockDatabase_GetTeamBySlug_Call struct {
+	*mock.Call
+}
+
+// GetTeamBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamBySlug(ctx interface{}, _a1 interface{}) *MockDatabase_GetTeamBySlug_Call {
+	return &MockDatabase_GetTeamBySlug_Call{Call: _e.mock.On("GetTeamBySlug", ctx, _a1)}
+}
+
+func (_c *MockDatabase_GetTeamBySlug_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetTeamBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamBySlug_Call) Return(_a0 *Team, _a1 error) *MockDatabase_GetTeamBySlug_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamBySlug_Call) RunAndReturn(run func(context.Context, slug.Slug) (*Team, error)) *MockDatabase_GetTeamBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamChildren provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) GetTeamChildren(ctx context.Context, teamSlug slug.Slug) ([]*Team, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 []*Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*Team, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*Team); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamChildren'
+type MockDatabase_GetTeamChildren_Call struct {
+	*mock.Call
+}
+
+// GetTeamChildren is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamChildren(ctx interface{}, teamSlug interface{}) *MockDatabase_GetTeamChildren_Call {
+	return &MockDatabase_GetTeamChildren_Call{Call: _e.mock.On("GetTeamChildren", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_GetTeamChildren_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetTeamChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamChildren_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetTeamChildren_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamChildren_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*Team, error)) *MockDatabase_GetTeamChildren_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamDeleteKey provides a mock function with given fields: ctx, key
+func (_m *MockDatabase) GetTeamDeleteKey(ctx context.Context, key uuid.UUID) (*TeamDeleteKey, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *TeamDeleteKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*TeamDeleteKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *TeamDeleteKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TeamDeleteKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamDeleteKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamDeleteKey'
+type MockDatabase_GetTeamDeleteKey_Call struct {
+	*mock.Call
+}
+
+// GetTeamDeleteKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key uuid.UUID
+func (_e *MockDatabase_Expecter) GetTeamDeleteKey(ctx interface{}, key interface{}) *MockDatabase_GetTeamDeleteKey_Call {
+	return &MockDatabase_GetTeamDeleteKey_Call{Call: _e.mock.On("GetTeamDeleteKey", ctx, key)}
+}
+
+func (_c *MockDatabase_GetTeamDeleteKey_Call) Run(run func(ctx context.Context, key uuid.UUID)) *MockDatabase_GetTeamDeleteKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDeleteKey_Call) Return(_a0 *TeamDeleteKey, _a1 error) *MockDatabase_GetTeamDeleteKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDeleteKey_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*TeamDeleteKey, error)) *MockDatabase_GetTeamDeleteKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamDeletion provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) GetTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*TeamDeletion, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 *TeamDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) (*TeamDeletion, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) *TeamDeletion); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TeamDeletion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamDeletion'
+type MockDatabase_GetTeamDeletion_Call struct {
+	*mock.Call
+}
+
+// GetTeamDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamDeletion(ctx interface{}, teamSlug interface{}) *MockDatabase_GetTeamDeletion_Call {
+	return &MockDatabase_GetTeamDeletion_Call{Call: _e.mock.On("GetTeamDeletion", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_GetTeamDeletion_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetTeamDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDeletion_Call) Return(_a0 *TeamDeletion, _a1 error) *MockDatabase_GetTeamDeletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDeletion_Call) RunAndReturn(run func(context.Context, slug.Slug) (*TeamDeletion, error)) *MockDatabase_GetTeamDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamDeletionReconcilers provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) GetTeamDeletionReconcilers(ctx context.Context, teamSlug slug.Slug) ([]*TeamDeletionReconciler, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 []*TeamDeletionReconciler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*TeamDeletionReconciler, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*TeamDeletionReconciler); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TeamDeletionReconciler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamDeletionReconcilers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamDeletionReconcilers'
+type MockDatabase_GetTeamDeletionReconcilers_Call struct {
+	*mock.Call
+}
+
+// GetTeamDeletionReconcilers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamDeletionReconcilers(ctx interface{}, teamSlug interface{}) *MockDatabase_GetTeamDeletionReconcilers_Call {
+	return &MockDatabase_GetTeamDeletionReconcilers_Call{Call: _e.mock.On("GetTeamDeletionReconcilers", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_GetTeamDeletionReconcilers_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetTeamDeletionReconcilers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDeletionReconcilers_Call) Return(_a0 []*TeamDeletionReconciler, _a1 error) *MockDatabase_GetTeamDeletionReconcilers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDeletionReconcilers_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*TeamDeletionReconciler, error)) *MockDatabase_GetTeamDeletionReconcilers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamDescendantSlugs provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) GetTeamDescendantSlugs(ctx context.Context, teamSlug slug.Slug) ([]slug.Slug, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 []slug.Slug
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]slug.Slug, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []slug.Slug); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slug.Slug)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamDescendantSlugs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamDescendantSlugs'
+type MockDatabase_GetTeamDescendantSlugs_Call struct {
+	*mock.Call
+}
+
+// GetTeamDescendantSlugs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamDescendantSlugs(ctx interface{}, teamSlug interface{}) *MockDatabase_GetTeamDescendantSlugs_Call {
+	return &MockDatabase_GetTeamDescendantSlugs_Call{Call: _e.mock.On("GetTeamDescendantSlugs", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_GetTeamDescendantSlugs_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetTeamDescendantSlugs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDescendantSlugs_Call) Return(_a0 []slug.Slug, _a1 error) *MockDatabase_GetTeamDescendantSlugs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamDescendantSlugs_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]slug.Slug, error)) *MockDatabase_GetTeamDescendantSlugs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamMember provides a mock function with given fields: ctx, teamSlug, userID
+func (_m *MockDatabase) GetTeamMember(ctx context.Context, teamSlug slug.Slug, userID uuid.UUID) (*User, error) {
+	ret := _m.Called(ctx, teamSlug, userID)
+
+	var r0 *User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, uuid.UUID) (*User, error)); ok {
+		return rf(ctx, teamSlug, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, uuid.UUID) *User); ok {
+		r0 = rf(ctx, teamSlug, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, uuid.UUID) error); ok {
+		r1 = rf(ctx, teamSlug, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMember'
+type MockDatabase_GetTeamMember_Call struct {
+	*mock.Call
+}
+
+// GetTeamMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - userID uuid.UUID
+func (_e *MockDatabase_Expecter) GetTeamMember(ctx interface{}, teamSlug interface{}, userID interface{}) *MockDatabase_GetTeamMember_Call {
+	return &MockDatabase_GetTeamMember_Call{Call: _e.mock.On("GetTeamMember", ctx, teamSlug, userID)}
+}
+
+func (_c *MockDatabase_GetTeamMember_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, userID uuid.UUID)) *MockDatabase_GetTeamMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMember_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetTeamMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMember_Call) RunAndReturn(run func(context.Context, slug.Slug, uuid.UUID) (*User, error)) *MockDatabase_GetTeamMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamMemberOptOuts provides a mock function with given fields: ctx, userID, teamSlug
+func (_m *MockDatabase) GetTeamMemberOptOuts(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) ([]*sqlc.GetTeamMemberOptOutsRow, error) {
+	ret := _m.Called(ctx, userID, teamSlug)
+
+	var r0 []*sqlc.GetTeamMemberOptOutsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug) ([]*sqlc.GetTeamMemberOptOutsRow, error)); ok {
+		return rf(ctx, userID, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug) []*sqlc.GetTeamMemberOptOutsRow); ok {
+		r0 = rf(ctx, userID, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*sqlc.GetTeamMemberOptOutsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, slug.Slug) error); ok {
+		r1 = rf(ctx, userID, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamMemberOptOuts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMemberOptOuts'
+type MockDatabase_GetTeamMemberOptOuts_Call struct {
+	*mock.Call
+}
+
+// GetTeamMemberOptOuts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamMemberOptOuts(ctx interface{}, userID interface{}, teamSlug interface{}) *MockDatabase_GetTeamMemberOptOuts_Call {
+	return &MockDatabase_GetTeamMemberOptOuts_Call{Call: _e.mock.On("GetTeamMemberOptOuts", ctx, userID, teamSlug)}
+}
+
+func (_c *MockDatabase_GetTeamMemberOptOuts_Call) Run(run func(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug)) *MockDatabase_GetTeamMemberOptOuts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMemberOptOuts_Call) Return(_a0 []*sqlc.GetTeamMemberOptOutsRow, _a1 error) *MockDatabase_GetTeamMemberOptOuts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMemberOptOuts_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug) ([]*sqlc.GetTeamMemberOptOutsRow, error)) *MockDatabase_GetTeamMemberOptOuts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamMembers provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) GetTeamMembers(ctx context.Context, teamSlug slug.Slug) ([]*User, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 []*User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*User, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*User); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMembers'
+type MockDatabase_GetTeamMembers_Call struct {
+	*mock.Call
+}
+
+// GetTeamMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamMembers(ctx interface{}, teamSlug interface{}) *MockDatabase_GetTeamMembers_Call {
+	return &MockDatabase_GetTeamMembers_Call{Call: _e.mock.On("GetTeamMembers", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_GetTeamMembers_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetTeamMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMembers_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetTeamMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMembers_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*User, error)) *MockDatabase_GetTeamMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamMembersForReconciler provides a mock function with given fields: ctx, teamSlug, reconcilerName
+func (_m *MockDatabase) GetTeamMembersForReconciler(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName) ([]*User, error) {
+	ret := _m.Called(ctx, teamSlug, reconcilerName)
+
+	var r0 []*User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, sqlc.ReconcilerName) ([]*User, error)); ok {
+		return rf(ctx, teamSlug, reconcilerName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, sqlc.ReconcilerName) []*User); ok {
+		r0 = rf(ctx, teamSlug, reconcilerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, teamSlug, reconcilerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamMembersForReconciler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMembersForReconciler'
+type MockDatabase_GetTeamMembersForReconciler_Call struct {
+	*mock.Call
+}
+
+// GetTeamMembersForReconciler is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) GetTeamMembersForReconciler(ctx interface{}, teamSlug interface{}, reconcilerName interface{}) *MockDatabase_GetTeamMembersForReconciler_Call {
+	return &MockDatabase_GetTeamMembersForReconciler_Call{Call: _e.mock.On("GetTeamMembersForReconciler", ctx, teamSlug, reconcilerName)}
+}
+
+func (_c *MockDatabase_GetTeamMembersForReconciler_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName)) *MockDatabase_GetTeamMembersForReconciler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(sqlc.ReconcilerName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMembersForReconciler_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetTeamMembersForReconciler_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamMembersForReconciler_Call) RunAndReturn(run func(context.Context, slug.Slug, sqlc.ReconcilerName) ([]*User, error)) *MockDatabase_GetTeamMembersForReconciler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamReconcilerErrors provides a mock function with given fields: ctx, _a1
+func (_m *MockDatabase) GetTeamReconcilerErrors(ctx context.Context, _a1 slug.Slug) ([]*ReconcilerError, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 []*ReconcilerError
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*ReconcilerError, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*ReconcilerError); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ReconcilerError)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamReconcilerErrors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamReconcilerErrors'
+type MockDatabase_GetTeamReconcilerErrors_Call struct {
+	*mock.Call
+}
+
+// GetTeamReconcilerErrors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamReconcilerErrors(ctx interface{}, _a1 interface{}) *MockDatabase_GetTeamReconcilerErrors_Call {
+	return &MockDatabase_GetTeamReconcilerErrors_Call{Call: _e.mock.On("GetTeamReconcilerErrors", ctx, _a1)}
+}
+
+func (_c *MockDatabase_GetTeamReconcilerErrors_Call) Run(run func(ctx context.Context, _a1 slug.Slug)) *MockDatabase_GetTeamReconcilerErrors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamReconcilerErrors_Call) Return(_a0 []*ReconcilerError, _a1 error) *MockDatabase_GetTeamReconcilerErrors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamReconcilerErrors_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*ReconcilerError, error)) *MockDatabase_GetTeamReconcilerErrors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamServiceAccounts provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) GetTeamServiceAccounts(ctx context.Context, teamSlug slug.Slug) ([]*ServiceAccount, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 []*ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*ServiceAccount, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*ServiceAccount); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamServiceAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamServiceAccounts'
+type MockDatabase_GetTeamServiceAccounts_Call struct {
+	*mock.Call
+}
+
+// GetTeamServiceAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) GetTeamServiceAccounts(ctx interface{}, teamSlug interface{}) *MockDatabase_GetTeamServiceAccounts_Call {
+	return &MockDatabase_GetTeamServiceAccounts_Call{Call: _e.mock.On("GetTeamServiceAccounts", ctx, teamSlug)}
+}
+
+func (_c *MockDatabase_GetTeamServiceAccounts_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_GetTeamServiceAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamServiceAccounts_Call) Return(_a0 []*ServiceAccount, _a1 error) *MockDatabase_GetTeamServiceAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamServiceAccounts_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*ServiceAccount, error)) *MockDatabase_GetTeamServiceAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamSyncRunReconcilers provides a mock function with given fields: ctx, runID
+func (_m *MockDatabase) GetTeamSyncRunReconcilers(ctx context.Context, runID int64) ([]*TeamSyncRunReconciler, error) {
+	ret := _m.Called(ctx, runID)
+
+	var r0 []*TeamSyncRunReconciler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*TeamSyncRunReconciler, error)); ok {
+		return rf(ctx, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*TeamSyncRunReconciler); ok {
+		r0 = rf(ctx, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TeamSyncRunReconciler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamSyncRunReconcilers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamSyncRunReconcilers'
+type MockDatabase_GetTeamSyncRunReconcilers_Call struct {
+	*mock.Call
+}
+
+// GetTeamSyncRunReconcilers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID int64
+func (_e *MockDatabase_Expecter) GetTeamSyncRunReconcilers(ctx interface{}, runID interface{}) *MockDatabase_GetTeamSyncRunReconcilers_Call {
+	return &MockDatabase_GetTeamSyncRunReconcilers_Call{Call: _e.mock.On("GetTeamSyncRunReconcilers", ctx, runID)}
+}
+
+func (_c *MockDatabase_GetTeamSyncRunReconcilers_Call) Run(run func(ctx context.Context, runID int64)) *MockDatabase_GetTeamSyncRunReconcilers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamSyncRunReconcilers_Call) Return(_a0 []*TeamSyncRunReconciler, _a1 error) *MockDatabase_GetTeamSyncRunReconcilers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamSyncRunReconcilers_Call) RunAndReturn(run func(context.Context, int64) ([]*TeamSyncRunReconciler, error)) *MockDatabase_GetTeamSyncRunReconcilers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamSyncRuns provides a mock function with given fields: ctx, teamSlug, after, limit
+func (_m *MockDatabase) GetTeamSyncRuns(ctx context.Context, teamSlug slug.Slug, after *TeamSyncRunCursor, limit int) ([]*TeamSyncRun, error) {
+	ret := _m.Called(ctx, teamSlug, after, limit)
+
+	var r0 []*TeamSyncRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, *TeamSyncRunCursor, int) ([]*TeamSyncRun, error)); ok {
+		return rf(ctx, teamSlug, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, *TeamSyncRunCursor, int) []*TeamSyncRun); ok {
+		r0 = rf(ctx, teamSlug, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TeamSyncRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, *TeamSyncRunCursor, int) error); ok {
+		r1 = rf(ctx, teamSlug, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamSyncRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamSyncRuns'
+type MockDatabase_GetTeamSyncRuns_Call struct {
+	*mock.Call
+}
+
+// GetTeamSyncRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - after *TeamSyncRunCursor
+//   - limit int
+func (_e *MockDatabase_Expecter) GetTeamSyncRuns(ctx interface{}, teamSlug interface{}, after interface{}, limit interface{}) *MockDatabase_GetTeamSyncRuns_Call {
+	return &MockDatabase_GetTeamSyncRuns_Call{Call: _e.mock.On("GetTeamSyncRuns", ctx, teamSlug, after, limit)}
+}
+
+func (_c *MockDatabase_GetTeamSyncRuns_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, after *TeamSyncRunCursor, limit int)) *MockDatabase_GetTeamSyncRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(*TeamSyncRunCursor), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamSyncRuns_Call) Return(_a0 []*TeamSyncRun, _a1 error) *MockDatabase_GetTeamSyncRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamSyncRuns_Call) RunAndReturn(run func(context.Context, slug.Slug, *TeamSyncRunCursor, int) ([]*TeamSyncRun, error)) *MockDatabase_GetTeamSyncRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeams provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetTeams(ctx context.Context) ([]*Team, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*Team, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*Team); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeams'
+type MockDatabase_GetTeams_Call struct {
+	*mock.Call
+}
+
+// GetTeams is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetTeams(ctx interface{}) *MockDatabase_GetTeams_Call {
+	return &MockDatabase_GetTeams_Call{Call: _e.mock.On("GetTeams", ctx)}
+}
+
+func (_c *MockDatabase_GetTeams_Call) Run(run func(ctx context.Context)) *MockDatabase_GetTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeams_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetTeams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeams_Call) RunAndReturn(run func(context.Context) ([]*Team, error)) *MockDatabase_GetTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamsWithPermissionInGitHubRepo provides a mock function with given fields: ctx, repoName, permission
+func (_m *MockDatabase) GetTeamsWithPermissionInGitHubRepo(ctx context.Context, repoName string, permission string) ([]*Team, error) {
+	ret := _m.Called(ctx, repoName, permission)
+
+	var r0 []*Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*Team, error)); ok {
+		return rf(ctx, repoName, permission)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*Team); ok {
+		r0 = rf(ctx, repoName, permission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, repoName, permission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamsWithPermissionInGitHubRepo'
+type MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call struct {
+	*mock.Call
+}
+
+// GetTeamsWithPermissionInGitHubRepo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repoName string
+//   - permission string
+func (_e *MockDatabase_Expecter) GetTeamsWithPermissionInGitHubRepo(ctx interface{}, repoName interface{}, permission interface{}) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
+	return &MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call{Call: _e.mock.On("GetTeamsWithPermissionInGitHubRepo", ctx, repoName, permission)}
+}
+
+func (_c *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call) Run(run func(ctx context.Context, repoName string, permission string)) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call) RunAndReturn(run func(context.Context, string, string) ([]*Team, error)) *MockDatabase_GetTeamsWithPermissionInGitHubRepo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *MockDatabase) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 *User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type MockDatabase_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockDatabase_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *MockDatabase_GetUserByEmail_Call {
+	return &MockDatabase_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *MockDatabase_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockDatabase_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUserByEmail_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetUserByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUserByEmail_Call) RunAndReturn(run func(context.Context, string) (*User, error)) *MockDatabase_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByExternalID provides a mock function with given fields: ctx, externalID
+func (_m *MockDatabase) GetUserByExternalID(ctx context.Context, externalID string) (*User, error) {
+	ret := _m.Called(ctx, externalID)
+
+	var r0 *User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*User, error)); ok {
+		return rf(ctx, externalID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *User); ok {
+		r0 = rf(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUserByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByExternalID'
+type MockDatabase_GetUserByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetUserByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockDatabase_Expecter) GetUserByExternalID(ctx interface{}, externalID interface{}) *MockDatabase_GetUserByExternalID_Call {
+	return &MockDatabase_GetUserByExternalID_Call{Call: _e.mock.On("GetUserByExternalID", ctx, externalID)}
+}
+
+func (_c *MockDatabase_GetUserByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockDatabase_GetUserByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUserByExternalID_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetUserByExternalID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUserByExternalID_Call) RunAndReturn(run func(context.Context, string) (*User, error)) *MockDatabase_GetUserByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function with given fields: ctx, ID
+func (_m *MockDatabase) GetUserByID(ctx context.Context, ID uuid.UUID) (*User, error) {
+	ret := _m.Called(ctx, ID)
+
+	var r0 *User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*User, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *User); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockDatabase_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *MockDatabase_Expecter) GetUserByID(ctx interface{}, ID interface{}) *MockDatabase_GetUserByID_Call {
+	return &MockDatabase_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, ID)}
+}
+
+func (_c *MockDatabase_GetUserByID_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *MockDatabase_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUserByID_Call) Return(_a0 *User, _a1 error) *MockDatabase_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUserByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*User, error)) *MockDatabase_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserRoles provides a mock function with given fields: ctx, userID
+func (_m *MockDatabase) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*Role, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*Role, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*Role); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUserRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserRoles'
+type MockDatabase_GetUserRoles_Call struct {
+	*mock.Call
+}
+
+// GetUserRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockDatabase_Expecter) GetUserRoles(ctx interface{}, userID interface{}) *MockDatabase_GetUserRoles_Call {
+	return &MockDatabase_GetUserRoles_Call{Call: _e.mock.On("GetUserRoles", ctx, userID)}
+}
+
+func (_c *MockDatabase_GetUserRoles_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockDatabase_GetUserRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUserRoles_Call) Return(_a0 []*Role, _a1 error) *MockDatabase_GetUserRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUserRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*Role, error)) *MockDatabase_GetUserRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserSyncRuns provides a mock function with given fields: ctx, offset, limit
+func (_m *MockDatabase) GetUserSyncRuns(ctx context.Context, offset int, limit int) ([]*UserSyncRun, error) {
+	ret := _m.Called(ctx, offset, limit)
+
+	var r0 []*UserSyncRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*UserSyncRun, error)); ok {
+		return rf(ctx, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*UserSyncRun); ok {
+		r0 = rf(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*UserSyncRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUserSyncRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSyncRuns'
+type MockDatabase_GetUserSyncRuns_Call struct {
+	*mock.Call
+}
+
+// GetUserSyncRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - offset int
+//   - limit int
+func (_e *MockDatabase_Expecter) GetUserSyncRuns(ctx interface{}, offset interface{}, limit interface{}) *MockDatabase_GetUserSyncRuns_Call {
+	return &MockDatabase_GetUserSyncRuns_Call{Call: _e.mock.On("GetUserSyncRuns", ctx, offset, limit)}
+}
+
+func (_c *MockDatabase_GetUserSyncRuns_Call) Run(run func(ctx context.Context, offset int, limit int)) *MockDatabase_GetUserSyncRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUserSyncRuns_Call) Return(_a0 []*UserSyncRun, _a1 error) *MockDatabase_GetUserSyncRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUserSyncRuns_Call) RunAndReturn(run func(context.Context, int, int) ([]*UserSyncRun, error)) *MockDatabase_GetUserSyncRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserTeams provides a mock function with given fields: ctx, userID
+func (_m *MockDatabase) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*Team, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*Team, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*Team); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUserTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserTeams'
+type MockDatabase_GetUserTeams_Call struct {
+	*mock.Call
+}
+
+// GetUserTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockDatabase_Expecter) GetUserTeams(ctx interface{}, userID interface{}) *MockDatabase_GetUserTeams_Call {
+	return &MockDatabase_GetUserTeams_Call{Call: _e.mock.On("GetUserTeams", ctx, userID)}
+}
+
+func (_c *MockDatabase_GetUserTeams_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockDatabase_GetUserTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUserTeams_Call) Return(_a0 []*Team, _a1 error) *MockDatabase_GetUserTeams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUserTeams_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*Team, error)) *MockDatabase_GetUserTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetUsers(ctx context.Context) ([]*User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockDatabase_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetUsers(ctx interface{}) *MockDatabase_GetUsers_Call {
+	return &MockDatabase_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
+}
+
+func (_c *MockDatabase_GetUsers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUsers_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUsers_Call) RunAndReturn(run func(context.Context) ([]*User, error)) *MockDatabase_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersWithGloballyAssignedRole provides a mock function with given fields: ctx, roleName
+func (_m *MockDatabase) GetUsersWithGloballyAssignedRole(ctx context.Context, roleName roles.RoleName) ([]*User, error) {
+	ret := _m.Called(ctx, roleName)
+
+	var r0 []*User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName) ([]*User, error)); ok {
+		return rf(ctx, roleName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName) []*User); ok {
+		r0 = rf(ctx, roleName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, roles.RoleName) error); ok {
+		r1 = rf(ctx, roleName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetUsersWithGloballyAssignedRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersWithGloballyAssignedRole'
+type MockDatabase_GetUsersWithGloballyAssignedRole_Call struct {
+	*mock.Call
+}
+
+// GetUsersWithGloballyAssignedRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName roles.RoleName
+func (_e *MockDatabase_Expecter) GetUsersWithGloballyAssignedRole(ctx interface{}, roleName interface{}) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
+	return &MockDatabase_GetUsersWithGloballyAssignedRole_Call{Call: _e.mock.On("GetUsersWithGloballyAssignedRole", ctx, roleName)}
+}
+
+func (_c *MockDatabase_GetUsersWithGloballyAssignedRole_Call) Run(run func(ctx context.Context, roleName roles.RoleName)) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(roles.RoleName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetUsersWithGloballyAssignedRole_Call) Return(_a0 []*User, _a1 error) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetUsersWithGloballyAssignedRole_Call) RunAndReturn(run func(context.Context, roles.RoleName) ([]*User, error)) *MockDatabase_GetUsersWithGloballyAssignedRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDeliveries provides a mock function with given fields: ctx, subscriptionID, offset, limit
+func (_m *MockDatabase) GetWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, offset int, limit int) ([]*WebhookDelivery, error) {
+	ret := _m.Called(ctx, subscriptionID, offset, limit)
+
+	var r0 []*WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) ([]*WebhookDelivery, error)); ok {
+		return rf(ctx, subscriptionID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, int) []*WebhookDelivery); ok {
+		r0 = rf(ctx, subscriptionID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, int) error); ok {
+		r1 = rf(ctx, subscriptionID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveries'
+type MockDatabase_GetWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID uuid.UUID
+//   - offset int
+//   - limit int
+func (_e *MockDatabase_Expecter) GetWebhookDeliveries(ctx interface{}, subscriptionID interface{}, offset interface{}, limit interface{}) *MockDatabase_GetWebhookDeliveries_Call {
+	return &MockDatabase_GetWebhookDeliveries_Call{Call: _e.mock.On("GetWebhookDeliveries", ctx, subscriptionID, offset, limit)}
+}
+
+func (_c *MockDatabase_GetWebhookDeliveries_Call) Run(run func(ctx context.Context, subscriptionID uuid.UUID, offset int, limit int)) *MockDatabase_GetWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookDeliveries_Call) Return(_a0 []*WebhookDelivery, _a1 error) *MockDatabase_GetWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookDeliveries_Call) RunAndReturn(run func(context.Context, uuid.UUID, int, int) ([]*WebhookDelivery, error)) *MockDatabase_GetWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *MockDatabase) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*WebhookSubscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *WebhookSubscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscription'
+type MockDatabase_GetWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockDatabase_Expecter) GetWebhookSubscription(ctx interface{}, id interface{}) *MockDatabase_GetWebhookSubscription_Call {
+	return &MockDatabase_GetWebhookSubscription_Call{Call: _e.mock.On("GetWebhookSubscription", ctx, id)}
+}
+
+func (_c *MockDatabase_GetWebhookSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockDatabase_GetWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookSubscription_Call) Return(_a0 *WebhookSubscription, _a1 error) *MockDatabase_GetWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*WebhookSubscription, error)) *MockDatabase_GetWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookSubscriptions provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetWebhookSubscriptions(ctx context.Context) ([]*WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetWebhookSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscriptions'
+type MockDatabase_GetWebhookSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetWebhookSubscriptions(ctx interface{}) *MockDatabase_GetWebhookSubscriptions_Call {
+	return &MockDatabase_GetWebhookSubscriptions_Call{Call: _e.mock.On("GetWebhookSubscriptions", ctx)}
+}
+
+func (_c *MockDatabase_GetWebhookSubscriptions_Call) Run(run func(ctx context.Context)) *MockDatabase_GetWebhookSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookSubscriptions_Call) Return(_a0 []*WebhookSubscription, _a1 error) *MockDatabase_GetWebhookSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookSubscriptions_Call) RunAndReturn(run func(context.Context) ([]*WebhookSubscription, error)) *MockDatabase_GetWebhookSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookSubscriptionsByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockDatabase) GetWebhookSubscriptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]*WebhookSubscription, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]*WebhookSubscription, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*WebhookSubscription); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetWebhookSubscriptionsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscriptionsByIDs'
+type MockDatabase_GetWebhookSubscriptionsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscriptionsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+func (_e *MockDatabase_Expecter) GetWebhookSubscriptionsByIDs(ctx interface{}, ids interface{}) *MockDatabase_GetWebhookSubscriptionsByIDs_Call {
+	return &MockDatabase_GetWebhookSubscriptionsByIDs_Call{Call: _e.mock.On("GetWebhookSubscriptionsByIDs", ctx, ids)}
+}
+
+func (_c *MockDatabase_GetWebhookSubscriptionsByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID)) *MockDatabase_GetWebhookSubscriptionsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookSubscriptionsByIDs_Call) Return(_a0 []*WebhookSubscription, _a1 error) *MockDatabase_GetWebhookSubscriptionsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetWebhookSubscriptionsByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]*WebhookSubscription, error)) *MockDatabase_GetWebhookSubscriptionsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsFirstRun provides a mock function with given fields: ctx
+func (_m *MockDatabase) IsFirstRun(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_IsFirstRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsFirstRun'
+type MockDatabase_IsFirstRun_Call struct {
+	*mock.Call
+}
+
+// IsFirstRun is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) IsFirstRun(ctx interface{}) *MockDatabase_IsFirstRun_Call {
+	return &MockDatabase_IsFirstRun_Call{Call: _e.mock.On("IsFirstRun", ctx)}
+}
+
+func (_c *MockDatabase_IsFirstRun_Call) Run(run func(ctx context.Context)) *MockDatabase_IsFirstRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_IsFirstRun_Call) Return(_a0 bool, _a1 error) *MockDatabase_IsFirstRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_IsFirstRun_Call) RunAndReturn(run func(context.Context) (bool, error)) *MockDatabase_IsFirstRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListenTeamSyncProgress provides a mock function with given fields: ctx, fn
+func (_m *MockDatabase) ListenTeamSyncProgress(ctx context.Context, fn func([]byte)) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func([]byte)) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_ListenTeamSyncProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListenTeamSyncProgress'
+type MockDatabase_ListenTeamSyncProgress_Call struct {
+	*mock.Call
+}
+
+// ListenTeamSyncProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func([]byte)
+func (_e *MockDatabase_Expecter) ListenTeamSyncProgress(ctx interface{}, fn interface{}) *MockDatabase_ListenTeamSyncProgress_Call {
+	return &MockDatabase_ListenTeamSyncProgress_Call{Call: _e.mock.On("ListenTeamSyncProgress", ctx, fn)}
+}
+
+func (_c *MockDatabase_ListenTeamSyncProgress_Call) Run(run func(ctx context.Context, fn func([]byte))) *MockDatabase_ListenTeamSyncProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func([]byte)))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ListenTeamSyncProgress_Call) Return(_a0 error) *MockDatabase_ListenTeamSyncProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_ListenTeamSyncProgress_Call) RunAndReturn(run func(context.Context, func([]byte)) error) *MockDatabase_ListenTeamSyncProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadReconcilerStateForTeam provides a mock function with given fields: ctx, reconcilerName, _a2, state
+func (_m *MockDatabase) LoadReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{}) error {
+	ret := _m.Called(ctx, reconcilerName, _a2, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error); ok {
+		r0 = rf(ctx, reconcilerName, _a2, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_LoadReconcilerStateForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadReconcilerStateForTeam'
+type MockDatabase_LoadReconcilerStateForTeam_Call struct {
+	*mock.Call
+}
+
+// LoadReconcilerStateForTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reconcilerName sqlc.ReconcilerName
+//   - _a2 slug.Slug
+//   - state interface{}
+func (_e *MockDatabase_Expecter) LoadReconcilerStateForTeam(ctx interface{}, reconcilerName interface{}, _a2 interface{}, state interface{}) *MockDatabase_LoadReconcilerStateForTeam_Call {
+	return &MockDatabase_LoadReconcilerStateForTeam_Call{Call: _e.mock.On("LoadReconcilerStateForTeam", ctx, reconcilerName, _a2, state)}
+}
+
+func (_c *MockDatabase_LoadReconcilerStateForTeam_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{})) *MockDatabase_LoadReconcilerStateForTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName), args[2].(slug.Slug), args[3].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_LoadReconcilerStateForTeam_Call) Return(_a0 error) *MockDatabase_LoadReconcilerStateForTeam_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_LoadReconcilerStateForTeam_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error) *MockDatabase_LoadReconcilerStateForTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockGlobalAdmins provides a mock function with given fields: ctx
+func (_m *MockDatabase) LockGlobalAdmins(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_LockGlobalAdmins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockGlobalAdmins'
+type MockDatabase_LockGlobalAdmins_Call struct {
+	*mock.Call
+}
+
+// LockGlobalAdmins is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) LockGlobalAdmins(ctx interface{}) *MockDatabase_LockGlobalAdmins_Call {
+	return &MockDatabase_LockGlobalAdmins_Call{Call: _e.mock.On("LockGlobalAdmins", ctx)}
+}
+
+func (_c *MockDatabase_LockGlobalAdmins_Call) Run(run func(ctx context.Context)) *MockDatabase_LockGlobalAdmins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_LockGlobalAdmins_Call) Return(_a0 error) *MockDatabase_LockGlobalAdmins_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_LockGlobalAdmins_Call) RunAndReturn(run func(context.Context) error) *MockDatabase_LockGlobalAdmins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyTeamSyncProgress provides a mock function with given fields: ctx, payload
+func (_m *MockDatabase) NotifyTeamSyncProgress(ctx context.Context, payload []byte) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_NotifyTeamSyncProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyTeamSyncProgress'
+type MockDatabase_NotifyTeamSyncProgress_Call struct {
+	*mock.Call
+}
+
+// NotifyTeamSyncProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload []byte
+func (_e *MockDatabase_Expecter) NotifyTeamSyncProgress(ctx interface{}, payload interface{}) *MockDatabase_NotifyTeamSyncProgress_Call {
+	return &MockDatabase_NotifyTeamSyncProgress_Call{Call: _e.mock.On("NotifyTeamSyncProgress", ctx, payload)}
+}
+
+func (_c *MockDatabase_NotifyTeamSyncProgress_Call) Run(run func(ctx context.Context, payload []byte)) *MockDatabase_NotifyTeamSyncProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_NotifyTeamSyncProgress_Call) Return(_a0 error) *MockDatabase_NotifyTeamSyncProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_NotifyTeamSyncProgress_Call) RunAndReturn(run func(context.Context, []byte) error) *MockDatabase_NotifyTeamSyncProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseLeaderLease provides a mock function with given fields: ctx, name, holder
+func (_m *MockDatabase) ReleaseLeaderLease(ctx context.Context, name string, holder string) error {
+	ret := _m.Called(ctx, name, holder)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, holder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_ReleaseLeaderLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseLeaderLease'
+type MockDatabase_ReleaseLeaderLease_Call struct {
+	*mock.Call
+}
+
+// ReleaseLeaderLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - holder string
+func (_e *MockDatabase_Expecter) ReleaseLeaderLease(ctx interface{}, name interface{}, holder interface{}) *MockDatabase_ReleaseLeaderLease_Call {
+	return &MockDatabase_ReleaseLeaderLease_Call{Call: _e.mock.On("ReleaseLeaderLease", ctx, name, holder)}
+}
+
+func (_c *MockDatabase_ReleaseLeaderLease_Call) Run(run func(ctx context.Context, name string, holder string)) *MockDatabase_ReleaseLeaderLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseLeaderLease_Call) Return(_a0 error) *MockDatabase_ReleaseLeaderLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseLeaderLease_Call) RunAndReturn(run func(context.Context, string, string) error) *MockDatabase_ReleaseLeaderLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStaleAuditLogOutboxEntries provides a mock function with given fields: ctx, lockedBefore
+func (_m *MockDatabase) ReleaseStaleAuditLogOutboxEntries(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, lockedBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, lockedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, lockedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, lockedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStaleAuditLogOutboxEntries'
+type MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call struct {
+	*mock.Call
+}
+
+// ReleaseStaleAuditLogOutboxEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lockedBefore time.Time
+func (_e *MockDatabase_Expecter) ReleaseStaleAuditLogOutboxEntries(ctx interface{}, lockedBefore interface{}) *MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call {
+	return &MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call{Call: _e.mock.On("ReleaseStaleAuditLogOutboxEntries", ctx, lockedBefore)}
+}
+
+func (_c *MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call) Run(run func(ctx context.Context, lockedBefore time.Time)) *MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call) Return(_a0 int64, _a1 error) *MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockDatabase_ReleaseStaleAuditLogOutboxEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStaleTeamDeletions provides a mock function with given fields: ctx, startedBefore
+func (_m *MockDatabase) ReleaseStaleTeamDeletions(ctx context.Context, startedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, startedBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, startedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, startedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, startedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ReleaseStaleTeamDeletions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStaleTeamDeletions'
+type MockDatabase_ReleaseStaleTeamDeletions_Call struct {
+	*mock.Call
+}
+
+// ReleaseStaleTeamDeletions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - startedBefore time.Time
+func (_e *MockDatabase_Expecter) ReleaseStaleTeamDeletions(ctx interface{}, startedBefore interface{}) *MockDatabase_ReleaseStaleTeamDeletions_Call {
+	return &MockDatabase_ReleaseStaleTeamDeletions_Call{Call: _e.mock.On("ReleaseStaleTeamDeletions", ctx, startedBefore)}
+}
+
+func (_c *MockDatabase_ReleaseStaleTeamDeletions_Call) Run(run func(ctx context.Context, startedBefore time.Time)) *MockDatabase_ReleaseStaleTeamDeletions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleTeamDeletions_Call) Return(_a0 int64, _a1 error) *MockDatabase_ReleaseStaleTeamDeletions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleTeamDeletions_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockDatabase_ReleaseStaleTeamDeletions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStaleTeamSyncs provides a mock function with given fields: ctx, lockedBefore
+func (_m *MockDatabase) ReleaseStaleTeamSyncs(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, lockedBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, lockedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, lockedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, lockedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ReleaseStaleTeamSyncs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStaleTeamSyncs'
+type MockDatabase_ReleaseStaleTeamSyncs_Call struct {
+	*mock.Call
+}
+
+// ReleaseStaleTeamSyncs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lockedBefore time.Time
+func (_e *MockDatabase_Expecter) ReleaseStaleTeamSyncs(ctx interface{}, lockedBefore interface{}) *MockDatabase_ReleaseStaleTeamSyncs_Call {
+	return &MockDatabase_ReleaseStaleTeamSyncs_Call{Call: _e.mock.On("ReleaseStaleTeamSyncs", ctx, lockedBefore)}
+}
+
+func (_c *MockDatabase_ReleaseStaleTeamSyncs_Call) Run(run func(ctx context.Context, lockedBefore time.Time)) *MockDatabase_ReleaseStaleTeamSyncs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleTeamSyncs_Call) Return(_a0 int64, _a1 error) *MockDatabase_ReleaseStaleTeamSyncs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleTeamSyncs_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockDatabase_ReleaseStaleTeamSyncs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStaleWebhookDeliveries provides a mock function with given fields: ctx, lockedBefore
+func (_m *MockDatabase) ReleaseStaleWebhookDeliveries(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, lockedBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, lockedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, lockedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, lockedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ReleaseStaleWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStaleWebhookDeliveries'
+type MockDatabase_ReleaseStaleWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ReleaseStaleWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lockedBefore time.Time
+func (_e *MockDatabase_Expecter) ReleaseStaleWebhookDeliveries(ctx interface{}, lockedBefore interface{}) *MockDatabase_ReleaseStaleWebhookDeliveries_Call {
+	return &MockDatabase_ReleaseStaleWebhookDeliveries_Call{Call: _e.mock.On("ReleaseStaleWebhookDeliveries", ctx, lockedBefore)}
+}
+
+func (_c *MockDatabase_ReleaseStaleWebhookDeliveries_Call) Run(run func(ctx context.Context, lockedBefore time.Time)) *MockDatabase_ReleaseStaleWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleWebhookDeliveries_Call) Return(_a0 int64, _a1 error) *MockDatabase_ReleaseStaleWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseStaleWebhookDeliveries_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockDatabase_ReleaseStaleWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseTeamSync provides a mock function with given fields: ctx, id
+func (_m *MockDatabase) ReleaseTeamSync(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_ReleaseTeamSync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseTeamSync'
+type MockDatabase_ReleaseTeamSync_Call struct {
+	*mock.Call
+}
+
+// ReleaseTeamSync is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockDatabase_Expecter) ReleaseTeamSync(ctx interface{}, id interface{}) *MockDatabase_ReleaseTeamSync_Call {
+	return &MockDatabase_ReleaseTeamSync_Call{Call: _e.mock.On("ReleaseTeamSync", ctx, id)}
+}
+
+func (_c *MockDatabase_ReleaseTeamSync_Call) Run(run func(ctx context.Context, id int64)) *MockDatabase_ReleaseTeamSync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseTeamSync_Call) Return(_a0 error) *MockDatabase_ReleaseTeamSync_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_ReleaseTeamSync_Call) RunAndReturn(run func(context.Context, int64) error) *MockDatabase_ReleaseTeamSync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveAllServiceAccountRoles provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockDatabase) RemoveAllServiceAccountRoles(ctx context.Context, serviceAccountID uuid.UUID) error {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RemoveAllServiceAccountRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAllServiceAccountRoles'
+type MockDatabase_RemoveAllServiceAccountRoles_Call struct {
+	*mock.Call
+}
+
+// RemoveAllServiceAccountRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID uuid.UUID
+func (_e *MockDatabase_Expecter) RemoveAllServiceAccountRoles(ctx interface{}, serviceAccountID interface{}) *MockDatabase_RemoveAllServiceAccountRoles_Call {
+	return &MockDatabase_RemoveAllServiceAccountRoles_Call{Call: _e.mock.On("RemoveAllServiceAccountRoles", ctx, serviceAccountID)}
+}
+
+func (_c *MockDatabase_RemoveAllServiceAccountRoles_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_RemoveAllServiceAccountRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RemoveAllServiceAccountRoles_Call) Return(_a0 error) *MockDatabase_RemoveAllServiceAccountRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RemoveAllServiceAccountRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_RemoveAllServiceAccountRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveApiKeysFromServiceAccount provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockDatabase) RemoveApiKeysFromServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RemoveApiKeysFromServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveApiKeysFromServiceAccount'
+type MockDatabase_RemoveApiKeysFromServiceAccount_Call struct {
+	*mock.Call
+}
+
+// RemoveApiKeysFromServiceAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID uuid.UUID
+func (_e *MockDatabase_Expecter) RemoveApiKeysFromServiceAccount(ctx interface{}, serviceAccountID interface{}) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
+	return &MockDatabase_RemoveApiKeysFromServiceAccount_Call{Call: _e.mock.On("RemoveApiKeysFromServiceAccount", ctx, serviceAccountID)}
+}
+
+func (_c *MockDatabase_RemoveApiKeysFromServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RemoveApiKeysFromServiceAccount_Call) Return(_a0 error) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RemoveApiKeysFromServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReconcilerOptOut provides a mock function with given fields: ctx, userID, teamSlug, reconcilerName
+func (_m *MockDatabase) RemoveReconcilerOptOut(ctx context.Context, userID *uuid.UUID, teamSlug *slug.Slug, reconcilerName sqlc.ReconcilerName) error {
+	ret := _m.Called(ctx, userID, teamSlug, reconcilerName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *slug.Slug, sqlc.ReconcilerName) error); ok {
+		r0 = rf(ctx, userID, teamSlug, reconcilerName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RemoveReconcilerOptOut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReconcilerOptOut'
+type MockDatabase_RemoveReconcilerOptOut_Call struct {
+	*mock.Call
+}
+
+// RemoveReconcilerOptOut is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID *uuid.UUID
+//   - teamSlug *slug.Slug
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) RemoveReconcilerOptOut(ctx interface{}, userID interface{}, teamSlug interface{}, reconcilerName interface{}) *MockDatabase_RemoveReconcilerOptOut_Call {
+	return &MockDatabase_RemoveReconcilerOptOut_Call{Call: _e.mock.On("RemoveReconcilerOptOut", ctx, userID, teamSlug, reconcilerName)}
+}
+
+func (_c *MockDatabase_RemoveReconcilerOptOut_Call) Run(run func(ctx context.Context, userID *uuid.UUID, teamSlug *slug.Slug, reconcilerName sqlc.ReconcilerName)) *MockDatabase_RemoveReconcilerOptOut_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*uuid.UUID), args[2].(*slug.Slug), args[3].(sqlc.ReconcilerName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RemoveReconcilerOptOut_Call) Return(_a0 error) *MockDatabase_RemoveReconcilerOptOut_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RemoveReconcilerOptOut_Call) RunAndReturn(run func(context.Context, *uuid.UUID, *slug.Slug, sqlc.ReconcilerName) error) *MockDatabase_RemoveReconcilerOptOut_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveReconcilerStateForTeam provides a mock function with given fields: ctx, reconcilerName, _a2
+func (_m *MockDatabase) RemoveReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug) error {
+	ret := _m.Called(ctx, reconcilerName, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, slug.Slug) error); ok {
+		r0 = rf(ctx, reconcilerName, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RemoveReconcilerStateForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReconcilerStateForTeam'
+type MockDatabase_RemoveReconcilerStateForTeam_Call struct {
+	*mock.Call
+}
+
+// RemoveReconcilerStateForTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reconcilerName sqlc.ReconcilerName
+//   - _a2 slug.Slug
+func (_e *MockDatabase_Expecter) RemoveReconcilerStateForTeam(ctx interface{}, reconcilerName interface{}, _a2 interface{}) *MockDatabase_RemoveReconcilerStateForTeam_Call {
+	return &MockDatabase_RemoveReconcilerStateForTeam_Call{Call: _e.mock.On("RemoveReconcilerStateForTeam", ctx, reconcilerName, _a2)}
+}
+
+func (_c *MockDatabase_RemoveReconcilerStateForTeam_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug)) *MockDatabase_RemoveReconcilerStateForTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName), args[2].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RemoveReconcilerStateForTeam_Call) Return(_a0 error) *MockDatabase_RemoveReconcilerStateForTeam_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RemoveReconcilerStateForTeam_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName, slug.Slug) error) *MockDatabase_RemoveReconcilerStateForTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveRepositoryAuthorization provides a mock function with given fields: ctx, teamSlug, repoName, authorization
+func (_m *MockDatabase) RemoveRepositoryAuthorization(ctx context.Context, teamSlug slug.Slug, repoName string, authorization sqlc.RepositoryAuthorizationEnum) error {
+	ret := _m.Called(ctx, teamSlug, repoName, authorization)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string, sqlc.RepositoryAuthorizationEnum) error); ok {
+		r0 = rf(ctx, teamSlug, repoName, authorization)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RemoveRepositoryAuthorization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRepositoryAuthorization'
+type MockDatabase_RemoveRepositoryAuthorization_Call struct {
+	*mock.Call
+}
+
+// RemoveRepositoryAuthorization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - repoName string
+//   - authorization sqlc.RepositoryAuthorizationEnum
+func (_e *MockDatabase_Expecter) RemoveRepositoryAuthorization(ctx interface{}, teamSlug interface{}, repoName interface{}, authorization interface{}) *MockDatabase_RemoveRepositoryAuthorization_Call {
+	return &MockDatabase_RemoveRepositoryAuthorization_Call{Call: _e.mock.On("RemoveRepositoryAuthorization", ctx, teamSlug, repoName, authorization)}
+}
+
+func (_c *MockDatabase_RemoveRepositoryAuthorization_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, repoName string, authorization sqlc.RepositoryAuthorizationEnum)) *MockDatabase_RemoveRepositoryAuthorization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string), args[3].(sqlc.RepositoryAuthorizationEnum))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RemoveRepositoryAuthorization_Call) Return(_a0 error) *MockDatabase_RemoveRepositoryAuthorization_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RemoveRepositoryAuthorization_Call) RunAndReturn(run func(context.Context, slug.Slug, string, sqlc.RepositoryAuthorizationEnum) error) *MockDatabase_RemoveRepositoryAuthorization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveSlackAlertsChannel provides a mock function with given fields: ctx, teamSlug, environment
+func (_m *MockDatabase) RemoveSlackAlertsChannel(ctx context.Context, teamSlug slug.Slug, environment string) error {
+	ret := _m.Called(ctx, teamSlug, environment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string) error); ok {
+		r0 = rf(ctx, teamSlug, environment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RemoveSlackAlertsChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveSlackAlertsChannel'
+type MockDatabase_RemoveSlackAlertsChannel_Call struct {
+	*mock.Call
+}
+
+// RemoveSlackAlertsChannel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - environment string
+func (_e *MockDatabase_Expecter) RemoveSlackAlertsChannel(ctx interface{}, teamSlug interface{}, environment interface{}) *MockDatabase_RemoveSlackAlertsChannel_Call {
+	return &MockDatabase_RemoveSlackAlertsChannel_Call{Call: _e.mock.On("RemoveSlackAlertsChannel", ctx, teamSlug, environment)}
+}
+
+func (_c *MockDatabase_RemoveSlackAlertsChannel_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, environment string)) *MockDatabase_RemoveSlackAlertsChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RemoveSlackAlertsChannel_Call) Return(_a0 error) *MockDatabase_RemoveSlackAlertsChannel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RemoveSlackAlertsChannel_Call) RunAndReturn(run func(context.Context, slug.Slug, string) error) *MockDatabase_RemoveSlackAlertsChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveUserFromTeam provides a mock function with given fields: ctx, userID, teamSlug
+func (_m *MockDatabase) RemoveUserFromTeam(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) error {
+	ret := _m.Called(ctx, userID, teamSlug)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug) error); ok {
+		r0 = rf(ctx, userID, teamSlug)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RemoveUserFromTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUserFromTeam'
+type MockDatabase_RemoveUserFromTeam_Call struct {
+	*mock.Call
+}
+
+// RemoveUserFromTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) RemoveUserFromTeam(ctx interface{}, userID interface{}, teamSlug interface{}) *MockDatabase_RemoveUserFromTeam_Call {
+	return &MockDatabase_RemoveUserFromTeam_Call{Call: _e.mock.On("RemoveUserFromTeam", ctx, userID, teamSlug)}
+}
+
+func (_c *MockDatabase_RemoveUserFromTeam_Call) Run(run func(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug)) *MockDatabase_RemoveUserFromTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RemoveUserFromTeam_Call) Return(_a0 error) *MockDatabase_RemoveUserFromTeam_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RemoveUserFromTeam_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug) error) *MockDatabase_RemoveUserFromTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetReconcilerConfig provides a mock function with given fields: ctx, reconcilerName
+func (_m *MockDatabase) ResetReconcilerConfig(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
+	ret := _m.Called(ctx, reconcilerName)
+
+	var r0 *Reconciler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)); ok {
+		return rf(ctx, reconcilerName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *Reconciler); ok {
+		r0 = rf(ctx, reconcilerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Reconciler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
+		r1 = rf(ctx, reconcilerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ResetReconcilerConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetReconcilerConfig'
+type MockDatabase_ResetReconcilerConfig_Call struct {
+	*mock.Call
+}
+
+// ResetReconcilerConfig is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reconcilerName sqlc.ReconcilerName
+func (_e *MockDatabase_Expecter) ResetReconcilerConfig(ctx interface{}, reconcilerName interface{}) *MockDatabase_ResetReconcilerConfig_Call {
+	return &MockDatabase_ResetReconcilerConfig_Call{Call: _e.mock.On("ResetReconcilerConfig", ctx, reconcilerName)}
+}
+
+func (_c *MockDatabase_ResetReconcilerConfig_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_ResetReconcilerConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ResetReconcilerConfig_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_ResetReconcilerConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ResetReconcilerConfig_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_ResetReconcilerConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetTeamDeletion provides a mock function with given fields: ctx, teamSlug, deleteAt
+func (_m *MockDatabase) ResetTeamDeletion(ctx context.Context, teamSlug slug.Slug, deleteAt time.Time) error {
+	ret := _m.Called(ctx, teamSlug, deleteAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, time.Time) error); ok {
+		r0 = rf(ctx, teamSlug, deleteAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_ResetTeamDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetTeamDeletion'
+type MockDatabase_ResetTeamDeletion_Call struct {
+	*mock.Call
+}
+
+// ResetTeamDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+//   - deleteAt time.Time
+func (_e *MockDatabase_Expecter) ResetTeamDeletion(ctx interface{}, teamSlug interface{}, deleteAt interface{}) *MockDatabase_ResetTeamDeletion_Call {
+	return &MockDatabase_ResetTeamDeletion_Call{Call: _e.mock.On("ResetTeamDeletion", ctx, teamSlug, deleteAt)}
+}
+
+func (_c *MockDatabase_ResetTeamDeletion_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, deleteAt time.Time)) *MockDatabase_ResetTeamDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ResetTeamDeletion_Call) Return(_a0 error) *MockDatabase_ResetTeamDeletion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_ResetTeamDeletion_Call) RunAndReturn(run func(context.Context, slug.Slug, time.Time) error) *MockDatabase_ResetTeamDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryAuditLogOutboxEntry provides a mock function with given fields: ctx, id, deliveredSinks, lastError, nextAttemptAt
+func (_m *MockDatabase) RetryAuditLogOutboxEntry(ctx context.Context, id int64, deliveredSinks []string, lastError string, nextAttemptAt time.Time) error {
+	ret := _m.Called(ctx, id, deliveredSinks, lastError, nextAttemptAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, deliveredSinks, lastError, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RetryAuditLogOutboxEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryAuditLogOutboxEntry'
+type MockDatabase_RetryAuditLogOutboxEntry_Call struct {
+	*mock.Call
+}
+
+// RetryAuditLogOutboxEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - deliveredSinks []string
+//   - lastError string
+//   - nextAttemptAt time.Time
+func (_e *MockDatabase_Expecter) RetryAuditLogOutboxEntry(ctx interface{}, id interface{}, deliveredSinks interface{}, lastError interface{}, nextAttemptAt interface{}) *MockDatabase_RetryAuditLogOutboxEntry_Call {
+	return &MockDatabase_RetryAuditLogOutboxEntry_Call{Call: _e.mock.On("RetryAuditLogOutboxEntry", ctx, id, deliveredSinks, lastError, nextAttemptAt)}
+}
+
+func (_c *MockDatabase_RetryAuditLogOutboxEntry_Call) Run(run func(ctx context.Context, id int64, deliveredSinks []string, lastError string, nextAttemptAt time.Time)) *MockDatabase_RetryAuditLogOutboxEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RetryAuditLogOutboxEntry_Call) Return(_a0 error) *MockDatabase_RetryAuditLogOutboxEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RetryAuditLogOutboxEntry_Call) RunAndReturn(run func(context.Context, int64, []string, string, time.Time) error) *MockDatabase_RetryAuditLogOutboxEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAPIKey provides a mock function with given fields: ctx, apiKeyID
+func (_m *MockDatabase) RevokeAPIKey(ctx context.Context, apiKeyID uuid.UUID) error {
+	ret := _m.Called(ctx, apiKeyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, apiKeyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type MockDatabase_RevokeAPIKey_Call struct {
+	*mock.Call
+}
+
+// RevokeAPIKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKeyID uuid.UUID
+func (_e *MockDatabase_Expecter) RevokeAPIKey(ctx interface{}, apiKeyID interface{}) *MockDatabase_RevokeAPIKey_Call {
+	return &MockDatabase_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", ctx, apiKeyID)}
+}
+
+func (_c *MockDatabase_RevokeAPIKey_Call) Run(run func(ctx context.Context, apiKeyID uuid.UUID)) *MockDatabase_RevokeAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_RevokeAPIKey_Call) Return(_a0 error) *MockDatabase_RevokeAPIKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_RevokeAPIKey_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_RevokeAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeGlobalServiceAccountRole provides a mock function with given fields: ctx, serviceAccountID, roleName
+func (_m *MockDatabase) RevokeGlobalServiceAccountRole(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName) error {
+	ret := _m.Called(ctx, serviceAccountID, roleName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, roles.RoleName) error); ok {
+		r0 = rf(ctx, serviceAccountID, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3069,44 +6911,43 @@ func (_m *MockDatabase) LoadReconcilerStateForTeam(ctx context.Context, reconcil
 	return r0
 }
 
-// MockDatabase_LoadReconcilerStateForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadReconcilerStateForTeam'
-type MockDatabase_LoadReconcilerStateForTeam_Call struct {
+// MockDatabase_RevokeGlobalServiceAccountRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeGlobalServiceAccountRole'
+type MockDatabase_RevokeGlobalServiceAccountRole_Call struct {
 	*mock.Call
 }
 
-// LoadReconcilerStateForTeam is a helper method to define mock.On call
+// RevokeGlobalServiceAccountRole is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
-//   - _a2 slug.Slug
-//   - state interface{}
-func (_e *MockDatabase_Expecter) LoadReconcilerStateForTeam(ctx interface{}, reconcilerName interface{}, _a2 interface{}, state interface{}) *MockDatabase_LoadReconcilerStateForTeam_Call {
-	return &MockDatabase_LoadReconcilerStateForTeam_Call{Call: _e.mock.On("LoadReconcilerStateForTeam", ctx, reconcilerName, _a2, state)}
+//   - serviceAccountID uuid.UUID
+//   - roleName roles.RoleName
+func (_e *MockDatabase_Expecter) RevokeGlobalServiceAccountRole(ctx interface{}, serviceAccountID interface{}, roleName interface{}) *MockDatabase_RevokeGlobalServiceAccountRole_Call {
+	return &MockDatabase_RevokeGlobalServiceAccountRole_Call{Call: _e.mock.On("RevokeGlobalServiceAccountRole", ctx, serviceAccountID, roleName)}
 }
 
-func (_c *MockDatabase_LoadReconcilerStateForTeam_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{})) *MockDatabase_LoadReconcilerStateForTeam_Call {
+func (_c *MockDatabase_RevokeGlobalServiceAccountRole_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName)) *MockDatabase_RevokeGlobalServiceAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName), args[2].(slug.Slug), args[3].(interface{}))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(roles.RoleName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_LoadReconcilerStateForTeam_Call) Return(_a0 error) *MockDatabase_LoadReconcilerStateForTeam_Call {
+func (_c *MockDatabase_RevokeGlobalServiceAccountRole_Call) Return(_a0 error) *MockDatabase_RevokeGlobalServiceAccountRole_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_LoadReconcilerStateForTeam_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error) *MockDatabase_LoadReconcilerStateForTeam_Call {
+func (_c *MockDatabase_RevokeGlobalServiceAccountRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, roles.RoleName) error) *MockDatabase_RevokeGlobalServiceAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveAllServiceAccountRoles provides a mock function with given fields: ctx, serviceAccountID
-func (_m *MockDatabase) RemoveAllServiceAccountRoles(ctx context.Context, serviceAccountID uuid.UUID) error {
-	ret := _m.Called(ctx, serviceAccountID)
+// RevokeGlobalUserRole provides a mock function with given fields: ctx, userID, roleName
+func (_m *MockDatabase) RevokeGlobalUserRole(ctx context.Context, userID uuid.UUID, roleName roles.RoleName) error {
+	ret := _m.Called(ctx, userID, roleName)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, serviceAccountID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, roles.RoleName) error); ok {
+		r0 = rf(ctx, userID, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3114,85 +6955,100 @@ func (_m *MockDatabase) RemoveAllServiceAccountRoles(ctx context.Context, servic
 	return r0
 }
 
-// MockDatabase_RemoveAllServiceAccountRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAllServiceAccountRoles'
-type MockDatabase_RemoveAllServiceAccountRoles_Call struct {
+// MockDatabase_RevokeGlobalUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeGlobalUserRole'
+type MockDatabase_RevokeGlobalUserRole_Call struct {
 	*mock.Call
 }
 
-// RemoveAllServiceAccountRoles is a helper method to define mock.On call
+// RevokeGlobalUserRole is a helper method to define mock.On call
 //   - ctx context.Context
-//   - serviceAccountID uuid.UUID
-func (_e *MockDatabase_Expecter) RemoveAllServiceAccountRoles(ctx interface{}, serviceAccountID interface{}) *MockDatabase_RemoveAllServiceAccountRoles_Call {
-	return &MockDatabase_RemoveAllServiceAccountRoles_Call{Call: _e.mock.On("RemoveAllServiceAccountRoles", ctx, serviceAccountID)}
+//   - userID uuid.UUID
+//   - roleName roles.RoleName
+func (_e *MockDatabase_Expecter) RevokeGlobalUserRole(ctx interface{}, userID interface{}, roleName interface{}) *MockDatabase_RevokeGlobalUserRole_Call {
+	return &MockDatabase_RevokeGlobalUserRole_Call{Call: _e.mock.On("RevokeGlobalUserRole", ctx, userID, roleName)}
 }
 
-func (_c *MockDatabase_RemoveAllServiceAccountRoles_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_RemoveAllServiceAccountRoles_Call {
+func (_c *MockDatabase_RevokeGlobalUserRole_Call) Run(run func(ctx context.Context, userID uuid.UUID, roleName roles.RoleName)) *MockDatabase_RevokeGlobalUserRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(roles.RoleName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RemoveAllServiceAccountRoles_Call) Return(_a0 error) *MockDatabase_RemoveAllServiceAccountRoles_Call {
+func (_c *MockDatabase_RevokeGlobalUserRole_Call) Return(_a0 error) *MockDatabase_RevokeGlobalUserRole_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_RemoveAllServiceAccountRoles_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_RemoveAllServiceAccountRoles_Call {
+func (_c *MockDatabase_RevokeGlobalUserRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, roles.RoleName) error) *MockDatabase_RevokeGlobalUserRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveApiKeysFromServiceAccount provides a mock function with given fields: ctx, serviceAccountID
-func (_m *MockDatabase) RemoveApiKeysFromServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
-	ret := _m.Called(ctx, serviceAccountID)
+// ScheduleTeamDeletion provides a mock function with given fields: ctx, deleteKey, correlationID, deleteAt
+func (_m *MockDatabase) ScheduleTeamDeletion(ctx context.Context, deleteKey *TeamDeleteKey, correlationID uuid.UUID, deleteAt time.Time) (*TeamDeletion, error) {
+	ret := _m.Called(ctx, deleteKey, correlationID, deleteAt)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, serviceAccountID)
+	var r0 *TeamDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TeamDeleteKey, uuid.UUID, time.Time) (*TeamDeletion, error)); ok {
+		return rf(ctx, deleteKey, correlationID, deleteAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TeamDeleteKey, uuid.UUID, time.Time) *TeamDeletion); ok {
+		r0 = rf(ctx, deleteKey, correlationID, deleteAt)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TeamDeletion)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *TeamDeleteKey, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, deleteKey, correlationID, deleteAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_RemoveApiKeysFromServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveApiKeysFromServiceAccount'
-type MockDatabase_RemoveApiKeysFromServiceAccount_Call struct {
+// MockDatabase_ScheduleTeamDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleTeamDeletion'
+type MockDatabase_ScheduleTeamDeletion_Call struct {
 	*mock.Call
 }
 
-// RemoveApiKeysFromServiceAccount is a helper method to define mock.On call
+// ScheduleTeamDeletion is a helper method to define mock.On call
 //   - ctx context.Context
-//   - serviceAccountID uuid.UUID
-func (_e *MockDatabase_Expecter) RemoveApiKeysFromServiceAccount(ctx interface{}, serviceAccountID interface{}) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
-	return &MockDatabase_RemoveApiKeysFromServiceAccount_Call{Call: _e.mock.On("RemoveApiKeysFromServiceAccount", ctx, serviceAccountID)}
+//   - deleteKey *TeamDeleteKey
+//   - correlationID uuid.UUID
+//   - deleteAt time.Time
+func (_e *MockDatabase_Expecter) ScheduleTeamDeletion(ctx interface{}, deleteKey interface{}, correlationID interface{}, deleteAt interface{}) *MockDatabase_ScheduleTeamDeletion_Call {
+	return &MockDatabase_ScheduleTeamDeletion_Call{Call: _e.mock.On("ScheduleTeamDeletion", ctx, deleteKey, correlationID, deleteAt)}
 }
 
-func (_c *MockDatabase_RemoveApiKeysFromServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID)) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
+func (_c *MockDatabase_ScheduleTeamDeletion_Call) Run(run func(ctx context.Context, deleteKey *TeamDeleteKey, correlationID uuid.UUID, deleteAt time.Time)) *MockDatabase_ScheduleTeamDeletion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(*TeamDeleteKey), args[2].(uuid.UUID), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RemoveApiKeysFromServiceAccount_Call) Return(_a0 error) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_ScheduleTeamDeletion_Call) Return(_a0 *TeamDeletion, _a1 error) *MockDatabase_ScheduleTeamDeletion_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_RemoveApiKeysFromServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockDatabase_RemoveApiKeysFromServiceAccount_Call {
+func (_c *MockDatabase_ScheduleTeamDeletion_Call) RunAndReturn(run func(context.Context, *TeamDeleteKey, uuid.UUID, time.Time) (*TeamDeletion, error)) *MockDatabase_ScheduleTeamDeletion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveReconcilerOptOut provides a mock function with given fields: ctx, userID, teamSlug, reconcilerName
-func (_m *MockDatabase) RemoveReconcilerOptOut(ctx context.Context, userID *uuid.UUID, teamSlug *slug.Slug, reconcilerName sqlc.ReconcilerName) error {
-	ret := _m.Called(ctx, userID, teamSlug, reconcilerName)
+// SetLastSuccessfulSyncForTeam provides a mock function with given fields: ctx, teamSlug
+func (_m *MockDatabase) SetLastSuccessfulSyncForTeam(ctx context.Context, teamSlug slug.Slug) error {
+	ret := _m.Called(ctx, teamSlug)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *slug.Slug, sqlc.ReconcilerName) error); ok {
-		r0 = rf(ctx, userID, teamSlug, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) error); ok {
+		r0 = rf(ctx, teamSlug)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3200,88 +7056,101 @@ func (_m *MockDatabase) RemoveReconcilerOptOut(ctx context.Context, userID *uuid
 	return r0
 }
 
-// MockDatabase_RemoveReconcilerOptOut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReconcilerOptOut'
-type MockDatabase_RemoveReconcilerOptOut_Call struct {
+// MockDatabase_SetLastSuccessfulSyncForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLastSuccessfulSyncForTeam'
+type MockDatabase_SetLastSuccessfulSyncForTeam_Call struct {
 	*mock.Call
 }
 
-// RemoveReconcilerOptOut is a helper method to define mock.On call
+// SetLastSuccessfulSyncForTeam is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID *uuid.UUID
-//   - teamSlug *slug.Slug
-//   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) RemoveReconcilerOptOut(ctx interface{}, userID interface{}, teamSlug interface{}, reconcilerName interface{}) *MockDatabase_RemoveReconcilerOptOut_Call {
-	return &MockDatabase_RemoveReconcilerOptOut_Call{Call: _e.mock.On("RemoveReconcilerOptOut", ctx, userID, teamSlug, reconcilerName)}
+//   - teamSlug slug.Slug
+func (_e *MockDatabase_Expecter) SetLastSuccessfulSyncForTeam(ctx interface{}, teamSlug interface{}) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
+	return &MockDatabase_SetLastSuccessfulSyncForTeam_Call{Call: _e.mock.On("SetLastSuccessfulSyncForTeam", ctx, teamSlug)}
 }
 
-func (_c *MockDatabase_RemoveReconcilerOptOut_Call) Run(run func(ctx context.Context, userID *uuid.UUID, teamSlug *slug.Slug, reconcilerName sqlc.ReconcilerName)) *MockDatabase_RemoveReconcilerOptOut_Call {
+func (_c *MockDatabase_SetLastSuccessfulSyncForTeam_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*uuid.UUID), args[2].(*slug.Slug), args[3].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].(slug.Slug))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RemoveReconcilerOptOut_Call) Return(_a0 error) *MockDatabase_RemoveReconcilerOptOut_Call {
+func (_c *MockDatabase_SetLastSuccessfulSyncForTeam_Call) Return(_a0 error) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_RemoveReconcilerOptOut_Call) RunAndReturn(run func(context.Context, *uuid.UUID, *slug.Slug, sqlc.ReconcilerName) error) *MockDatabase_RemoveReconcilerOptOut_Call {
+func (_c *MockDatabase_SetLastSuccessfulSyncForTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) error) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveReconcilerStateForTeam provides a mock function with given fields: ctx, reconcilerName, _a2
-func (_m *MockDatabase) RemoveReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug) error {
-	ret := _m.Called(ctx, reconcilerName, _a2)
+// SetReconcilerErrorForTeam provides a mock function with given fields: ctx, correlationID, _a2, reconcilerName, err, kind
+func (_m *MockDatabase) SetReconcilerErrorForTeam(ctx context.Context, correlationID uuid.UUID, _a2 slug.Slug, reconcilerName sqlc.ReconcilerName, err error, kind sqlc.ReconcilerErrorKind) (*ReconcilerError, error) {
+	ret := _m.Called(ctx, correlationID, _a2, reconcilerName, err, kind)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, slug.Slug) error); ok {
-		r0 = rf(ctx, reconcilerName, _a2)
+	var r0 *ReconcilerError
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error, sqlc.ReconcilerErrorKind) (*ReconcilerError, error)); ok {
+		return rf(ctx, correlationID, _a2, reconcilerName, err, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error, sqlc.ReconcilerErrorKind) *ReconcilerError); ok {
+		r0 = rf(ctx, correlationID, _a2, reconcilerName, err, kind)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ReconcilerError)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error, sqlc.ReconcilerErrorKind) error); ok {
+		r1 = rf(ctx, correlationID, _a2, reconcilerName, err, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_RemoveReconcilerStateForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReconcilerStateForTeam'
-type MockDatabase_RemoveReconcilerStateForTeam_Call struct {
+// MockDatabase_SetReconcilerErrorForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReconcilerErrorForTeam'
+type MockDatabase_SetReconcilerErrorForTeam_Call struct {
 	*mock.Call
 }
 
-// RemoveReconcilerStateForTeam is a helper method to define mock.On call
+// SetReconcilerErrorForTeam is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
+//   - correlationID uuid.UUID
 //   - _a2 slug.Slug
-func (_e *MockDatabase_Expecter) RemoveReconcilerStateForTeam(ctx interface{}, reconcilerName interface{}, _a2 interface{}) *MockDatabase_RemoveReconcilerStateForTeam_Call {
-	return &MockDatabase_RemoveReconcilerStateForTeam_Call{Call: _e.mock.On("RemoveReconcilerStateForTeam", ctx, reconcilerName, _a2)}
+//   - reconcilerName sqlc.ReconcilerName
+//   - err error
+//   - kind sqlc.ReconcilerErrorKind
+func (_e *MockDatabase_Expecter) SetReconcilerErrorForTeam(ctx interface{}, correlationID interface{}, _a2 interface{}, reconcilerName interface{}, err interface{}, kind interface{}) *MockDatabase_SetReconcilerErrorForTeam_Call {
+	return &MockDatabase_SetReconcilerErrorForTeam_Call{Call: _e.mock.On("SetReconcilerErrorForTeam", ctx, correlationID, _a2, reconcilerName, err, kind)}
 }
 
-func (_c *MockDatabase_RemoveReconcilerStateForTeam_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug)) *MockDatabase_RemoveReconcilerStateForTeam_Call {
+func (_c *MockDatabase_SetReconcilerErrorForTeam_Call) Run(run func(ctx context.Context, correlationID uuid.UUID, _a2 slug.Slug, reconcilerName sqlc.ReconcilerName, err error, kind sqlc.ReconcilerErrorKind)) *MockDatabase_SetReconcilerErrorForTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName), args[2].(slug.Slug))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug), args[3].(sqlc.ReconcilerName), args[4].(error), args[5].(sqlc.ReconcilerErrorKind))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RemoveReconcilerStateForTeam_Call) Return(_a0 error) *MockDatabase_RemoveReconcilerStateForTeam_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_SetReconcilerErrorForTeam_Call) Return(_a0 *ReconcilerError, _a1 error) *MockDatabase_SetReconcilerErrorForTeam_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_RemoveReconcilerStateForTeam_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName, slug.Slug) error) *MockDatabase_RemoveReconcilerStateForTeam_Call {
+func (_c *MockDatabase_SetReconcilerErrorForTeam_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error, sqlc.ReconcilerErrorKind) (*ReconcilerError, error)) *MockDatabase_SetReconcilerErrorForTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveRepositoryAuthorization provides a mock function with given fields: ctx, teamSlug, repoName, authorization
-func (_m *MockDatabase) RemoveRepositoryAuthorization(ctx context.Context, teamSlug slug.Slug, repoName string, authorization sqlc.RepositoryAuthorizationEnum) error {
-	ret := _m.Called(ctx, teamSlug, repoName, authorization)
+// SetReconcilerErrorNextRetry provides a mock function with given fields: ctx, _a1, reconcilerName, nextRetryAt
+func (_m *MockDatabase) SetReconcilerErrorNextRetry(ctx context.Context, _a1 slug.Slug, reconcilerName sqlc.ReconcilerName, nextRetryAt time.Time) error {
+	ret := _m.Called(ctx, _a1, reconcilerName, nextRetryAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string, sqlc.RepositoryAuthorizationEnum) error); ok {
-		r0 = rf(ctx, teamSlug, repoName, authorization)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, sqlc.ReconcilerName, time.Time) error); ok {
+		r0 = rf(ctx, _a1, reconcilerName, nextRetryAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3289,44 +7158,44 @@ func (_m *MockDatabase) RemoveRepositoryAuthorization(ctx context.Context, teamS
 	return r0
 }
 
-// MockDatabase_RemoveRepositoryAuthorization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveRepositoryAuthorization'
-type MockDatabase_RemoveRepositoryAuthorization_Call struct {
+// MockDatabase_SetReconcilerErrorNextRetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReconcilerErrorNextRetry'
+type MockDatabase_SetReconcilerErrorNextRetry_Call struct {
 	*mock.Call
 }
 
-// RemoveRepositoryAuthorization is a helper method to define mock.On call
+// SetReconcilerErrorNextRetry is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-//   - repoName string
-//   - authorization sqlc.RepositoryAuthorizationEnum
-func (_e *MockDatabase_Expecter) RemoveRepositoryAuthorization(ctx interface{}, teamSlug interface{}, repoName interface{}, authorization interface{}) *MockDatabase_RemoveRepositoryAuthorization_Call {
-	return &MockDatabase_RemoveRepositoryAuthorization_Call{Call: _e.mock.On("RemoveRepositoryAuthorization", ctx, teamSlug, repoName, authorization)}
+//   - _a1 slug.Slug
+//   - reconcilerName sqlc.ReconcilerName
+//   - nextRetryAt time.Time
+func (_e *MockDatabase_Expecter) SetReconcilerErrorNextRetry(ctx interface{}, _a1 interface{}, reconcilerName interface{}, nextRetryAt interface{}) *MockDatabase_SetReconcilerErrorNextRetry_Call {
+	return &MockDatabase_SetReconcilerErrorNextRetry_Call{Call: _e.mock.On("SetReconcilerErrorNextRetry", ctx, _a1, reconcilerName, nextRetryAt)}
 }
 
-func (_c *MockDatabase_RemoveRepositoryAuthorization_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, repoName string, authorization sqlc.RepositoryAuthorizationEnum)) *MockDatabase_RemoveRepositoryAuthorization_Call {
+func (_c *MockDatabase_SetReconcilerErrorNextRetry_Call) Run(run func(ctx context.Context, _a1 slug.Slug, reconcilerName sqlc.ReconcilerName, nextRetryAt time.Time)) *MockDatabase_SetReconcilerErrorNextRetry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string), args[3].(sqlc.RepositoryAuthorizationEnum))
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(sqlc.ReconcilerName), args[3].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RemoveRepositoryAuthorization_Call) Return(_a0 error) *MockDatabase_RemoveRepositoryAuthorization_Call {
+func (_c *MockDatabase_SetReconcilerErrorNextRetry_Call) Return(_a0 error) *MockDatabase_SetReconcilerErrorNextRetry_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_RemoveRepositoryAuthorization_Call) RunAndReturn(run func(context.Context, slug.Slug, string, sqlc.RepositoryAuthorizationEnum) error) *MockDatabase_RemoveRepositoryAuthorization_Call {
+func (_c *MockDatabase_SetReconcilerErrorNextRetry_Call) RunAndReturn(run func(context.Context, slug.Slug, sqlc.ReconcilerName, time.Time) error) *MockDatabase_SetReconcilerErrorNextRetry_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveSlackAlertsChannel provides a mock function with given fields: ctx, teamSlug, environment
-func (_m *MockDatabase) RemoveSlackAlertsChannel(ctx context.Context, teamSlug slug.Slug, environment string) error {
-	ret := _m.Called(ctx, teamSlug, environment)
+// SetReconcilerSkippedForTeam provides a mock function with given fields: ctx, correlationID, _a2, reconcilerName, err
+func (_m *MockDatabase) SetReconcilerSkippedForTeam(ctx context.Context, correlationID uuid.UUID, _a2 slug.Slug, reconcilerName sqlc.ReconcilerName, err error) error {
+	ret := _m.Called(ctx, correlationID, _a2, reconcilerName, err)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string) error); ok {
-		r0 = rf(ctx, teamSlug, environment)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error) error); ok {
+		r0 = rf(ctx, correlationID, _a2, reconcilerName, err)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3334,43 +7203,45 @@ func (_m *MockDatabase) RemoveSlackAlertsChannel(ctx context.Context, teamSlug s
 	return r0
 }
 
-// MockDatabase_RemoveSlackAlertsChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveSlackAlertsChannel'
-type MockDatabase_RemoveSlackAlertsChannel_Call struct {
+// MockDatabase_SetReconcilerSkippedForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReconcilerSkippedForTeam'
+type MockDatabase_SetReconcilerSkippedForTeam_Call struct {
 	*mock.Call
 }
 
-// RemoveSlackAlertsChannel is a helper method to define mock.On call
+// SetReconcilerSkippedForTeam is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-//   - environment string
-func (_e *MockDatabase_Expecter) RemoveSlackAlertsChannel(ctx interface{}, teamSlug interface{}, environment interface{}) *MockDatabase_RemoveSlackAlertsChannel_Call {
-	return &MockDatabase_RemoveSlackAlertsChannel_Call{Call: _e.mock.On("RemoveSlackAlertsChannel", ctx, teamSlug, environment)}
+//   - correlationID uuid.UUID
+//   - _a2 slug.Slug
+//   - reconcilerName sqlc.ReconcilerName
+//   - err error
+func (_e *MockDatabase_Expecter) SetReconcilerSkippedForTeam(ctx interface{}, correlationID interface{}, _a2 interface{}, reconcilerName interface{}, err interface{}) *MockDatabase_SetReconcilerSkippedForTeam_Call {
+	return &MockDatabase_SetReconcilerSkippedForTeam_Call{Call: _e.mock.On("SetReconcilerSkippedForTeam", ctx, correlationID, _a2, reconcilerName, err)}
 }
 
-func (_c *MockDatabase_RemoveSlackAlertsChannel_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, environment string)) *MockDatabase_RemoveSlackAlertsChannel_Call {
+func (_c *MockDatabase_SetReconcilerSkippedForTeam_Call) Run(run func(ctx context.Context, correlationID uuid.UUID, _a2 slug.Slug, reconcilerName sqlc.ReconcilerName, err error)) *MockDatabase_SetReconcilerSkippedForTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug), args[3].(sqlc.ReconcilerName), args[4].(error))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RemoveSlackAlertsChannel_Call) Return(_a0 error) *MockDatabase_RemoveSlackAlertsChannel_Call {
+func (_c *MockDatabase_SetReconcilerSkippedForTeam_Call) Return(_a0 error) *MockDatabase_SetReconcilerSkippedForTeam_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_RemoveSlackAlertsChannel_Call) RunAndReturn(run func(context.Context, slug.Slug, string) error) *MockDatabase_RemoveSlackAlertsChannel_Call {
+func (_c *MockDatabase_SetReconcilerSkippedForTeam_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error) error) *MockDatabase_SetReconcilerSkippedForTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveUserFromTeam provides a mock function with given fields: ctx, userID, teamSlug
-func (_m *MockDatabase) RemoveUserFromTeam(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) error {
-	ret := _m.Called(ctx, userID, teamSlug)
+// SetReconcilerStateForTeam provides a mock function with given fields: ctx, reconcilerName, _a2, state
+func (_m *MockDatabase) SetReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{}) error {
+	ret := _m.Called(ctx, reconcilerName, _a2, state)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug) error); ok {
-		r0 = rf(ctx, userID, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error); ok {
+		r0 = rf(ctx, reconcilerName, _a2, state)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3378,55 +7249,56 @@ func (_m *MockDatabase) RemoveUserFromTeam(ctx context.Context, userID uuid.UUID
 	return r0
 }
 
-// MockDatabase_RemoveUserFromTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUserFromTeam'
-type MockDatabase_RemoveUserFromTeam_Call struct {
+// MockDatabase_SetReconcilerStateForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReconcilerStateForTeam'
+type MockDatabase_SetReconcilerStateForTeam_Call struct {
 	*mock.Call
 }
 
-// RemoveUserFromTeam is a helper method to define mock.On call
+// SetReconcilerStateForTeam is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-//   - teamSlug slug.Slug
-func (_e *MockDatabase_Expecter) RemoveUserFromTeam(ctx interface{}, userID interface{}, teamSlug interface{}) *MockDatabase_RemoveUserFromTeam_Call {
-	return &MockDatabase_RemoveUserFromTeam_Call{Call: _e.mock.On("RemoveUserFromTeam", ctx, userID, teamSlug)}
+//   - reconcilerName sqlc.ReconcilerName
+//   - _a2 slug.Slug
+//   - state interface{}
+func (_e *MockDatabase_Expecter) SetReconcilerStateForTeam(ctx interface{}, reconcilerName interface{}, _a2 interface{}, state interface{}) *MockDatabase_SetReconcilerStateForTeam_Call {
+	return &MockDatabase_SetReconcilerStateForTeam_Call{Call: _e.mock.On("SetReconcilerStateForTeam", ctx, reconcilerName, _a2, state)}
 }
 
-func (_c *MockDatabase_RemoveUserFromTeam_Call) Run(run func(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug)) *MockDatabase_RemoveUserFromTeam_Call {
+func (_c *MockDatabase_SetReconcilerStateForTeam_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{})) *MockDatabase_SetReconcilerStateForTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug))
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName), args[2].(slug.Slug), args[3].(interface{}))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RemoveUserFromTeam_Call) Return(_a0 error) *MockDatabase_RemoveUserFromTeam_Call {
+func (_c *MockDatabase_SetReconcilerStateForTeam_Call) Return(_a0 error) *MockDatabase_SetReconcilerStateForTeam_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_RemoveUserFromTeam_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug) error) *MockDatabase_RemoveUserFromTeam_Call {
+func (_c *MockDatabase_SetReconcilerStateForTeam_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error) *MockDatabase_SetReconcilerStateForTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResetReconcilerConfig provides a mock function with given fields: ctx, reconcilerName
-func (_m *MockDatabase) ResetReconcilerConfig(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error) {
-	ret := _m.Called(ctx, reconcilerName)
+// SetReconcilerTimeout provides a mock function with given fields: ctx, reconcilerName, timeoutSeconds
+func (_m *MockDatabase) SetReconcilerTimeout(ctx context.Context, reconcilerName sqlc.ReconcilerName, timeoutSeconds *int32) (*Reconciler, error) {
+	ret := _m.Called(ctx, reconcilerName, timeoutSeconds)
 
 	var r0 *Reconciler
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)); ok {
-		return rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, *int32) (*Reconciler, error)); ok {
+		return rf(ctx, reconcilerName, timeoutSeconds)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName) *Reconciler); ok {
-		r0 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, *int32) *Reconciler); ok {
+		r0 = rf(ctx, reconcilerName, timeoutSeconds)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*Reconciler)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName) error); ok {
-		r1 = rf(ctx, reconcilerName)
+	if rf, ok := ret.Get(1).(func(context.Context, sqlc.ReconcilerName, *int32) error); ok {
+		r1 = rf(ctx, reconcilerName, timeoutSeconds)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3434,42 +7306,87 @@ func (_m *MockDatabase) ResetReconcilerConfig(ctx context.Context, reconcilerNam
 	return r0, r1
 }
 
-// MockDatabase_ResetReconcilerConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetReconcilerConfig'
-type MockDatabase_ResetReconcilerConfig_Call struct {
+// MockDatabase_SetReconcilerTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReconcilerTimeout'
+type MockDatabase_SetReconcilerTimeout_Call struct {
 	*mock.Call
 }
 
-// ResetReconcilerConfig is a helper method to define mock.On call
+// SetReconcilerTimeout is a helper method to define mock.On call
 //   - ctx context.Context
 //   - reconcilerName sqlc.ReconcilerName
-func (_e *MockDatabase_Expecter) ResetReconcilerConfig(ctx interface{}, reconcilerName interface{}) *MockDatabase_ResetReconcilerConfig_Call {
-	return &MockDatabase_ResetReconcilerConfig_Call{Call: _e.mock.On("ResetReconcilerConfig", ctx, reconcilerName)}
+//   - timeoutSeconds *int32
+func (_e *MockDatabase_Expecter) SetReconcilerTimeout(ctx interface{}, reconcilerName interface{}, timeoutSeconds interface{}) *MockDatabase_SetReconcilerTimeout_Call {
+	return &MockDatabase_SetReconcilerTimeout_Call{Call: _e.mock.On("SetReconcilerTimeout", ctx, reconcilerName, timeoutSeconds)}
 }
 
-func (_c *MockDatabase_ResetReconcilerConfig_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName)) *MockDatabase_ResetReconcilerConfig_Call {
+func (_c *MockDatabase_SetReconcilerTimeout_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName, timeoutSeconds *int32)) *MockDatabase_SetReconcilerTimeout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName))
+		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName), args[2].(*int32))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetReconcilerTimeout_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_SetReconcilerTimeout_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetReconcilerTimeout_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName, *int32) (*Reconciler, error)) *MockDatabase_SetReconcilerTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRoleAuthorizations provides a mock function with given fields: ctx, roleName, authorizations
+func (_m *MockDatabase) SetRoleAuthorizations(ctx context.Context, roleName roles.RoleName, authorizations []roles.Authorization) error {
+	ret := _m.Called(ctx, roleName, authorizations)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName, []roles.Authorization) error); ok {
+		r0 = rf(ctx, roleName, authorizations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_SetRoleAuthorizations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRoleAuthorizations'
+type MockDatabase_SetRoleAuthorizations_Call struct {
+	*mock.Call
+}
+
+// SetRoleAuthorizations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName roles.RoleName
+//   - authorizations []roles.Authorization
+func (_e *MockDatabase_Expecter) SetRoleAuthorizations(ctx interface{}, roleName interface{}, authorizations interface{}) *MockDatabase_SetRoleAuthorizations_Call {
+	return &MockDatabase_SetRoleAuthorizations_Call{Call: _e.mock.On("SetRoleAuthorizations", ctx, roleName, authorizations)}
+}
+
+func (_c *MockDatabase_SetRoleAuthorizations_Call) Run(run func(ctx context.Context, roleName roles.RoleName, authorizations []roles.Authorization)) *MockDatabase_SetRoleAuthorizations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(roles.RoleName), args[2].([]roles.Authorization))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_ResetReconcilerConfig_Call) Return(_a0 *Reconciler, _a1 error) *MockDatabase_ResetReconcilerConfig_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockDatabase_SetRoleAuthorizations_Call) Return(_a0 error) *MockDatabase_SetRoleAuthorizations_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_ResetReconcilerConfig_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName) (*Reconciler, error)) *MockDatabase_ResetReconcilerConfig_Call {
+func (_c *MockDatabase_SetRoleAuthorizations_Call) RunAndReturn(run func(context.Context, roles.RoleName, []roles.Authorization) error) *MockDatabase_SetRoleAuthorizations_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RevokeGlobalUserRole provides a mock function with given fields: ctx, userID, roleName
-func (_m *MockDatabase) RevokeGlobalUserRole(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName) error {
-	ret := _m.Called(ctx, userID, roleName)
+// SetSlackAlertsChannel provides a mock function with given fields: ctx, teamSlug, environment, channelName
+func (_m *MockDatabase) SetSlackAlertsChannel(ctx context.Context, teamSlug slug.Slug, environment string, channelName string) error {
+	ret := _m.Called(ctx, teamSlug, environment, channelName)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.RoleName) error); ok {
-		r0 = rf(ctx, userID, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string, string) error); ok {
+		r0 = rf(ctx, teamSlug, environment, channelName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3477,43 +7394,44 @@ func (_m *MockDatabase) RevokeGlobalUserRole(ctx context.Context, userID uuid.UU
 	return r0
 }
 
-// MockDatabase_RevokeGlobalUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeGlobalUserRole'
-type MockDatabase_RevokeGlobalUserRole_Call struct {
+// MockDatabase_SetSlackAlertsChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSlackAlertsChannel'
+type MockDatabase_SetSlackAlertsChannel_Call struct {
 	*mock.Call
 }
 
-// RevokeGlobalUserRole is a helper method to define mock.On call
+// SetSlackAlertsChannel is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-//   - roleName sqlc.RoleName
-func (_e *MockDatabase_Expecter) RevokeGlobalUserRole(ctx interface{}, userID interface{}, roleName interface{}) *MockDatabase_RevokeGlobalUserRole_Call {
-	return &MockDatabase_RevokeGlobalUserRole_Call{Call: _e.mock.On("RevokeGlobalUserRole", ctx, userID, roleName)}
+//   - teamSlug slug.Slug
+//   - environment string
+//   - channelName string
+func (_e *MockDatabase_Expecter) SetSlackAlertsChannel(ctx interface{}, teamSlug interface{}, environment interface{}, channelName interface{}) *MockDatabase_SetSlackAlertsChannel_Call {
+	return &MockDatabase_SetSlackAlertsChannel_Call{Call: _e.mock.On("SetSlackAlertsChannel", ctx, teamSlug, environment, channelName)}
 }
 
-func (_c *MockDatabase_RevokeGlobalUserRole_Call) Run(run func(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName)) *MockDatabase_RevokeGlobalUserRole_Call {
+func (_c *MockDatabase_SetSlackAlertsChannel_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, environment string, channelName string)) *MockDatabase_SetSlackAlertsChannel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sqlc.RoleName))
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_RevokeGlobalUserRole_Call) Return(_a0 error) *MockDatabase_RevokeGlobalUserRole_Call {
+func (_c *MockDatabase_SetSlackAlertsChannel_Call) Return(_a0 error) *MockDatabase_SetSlackAlertsChannel_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_RevokeGlobalUserRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, sqlc.RoleName) error) *MockDatabase_RevokeGlobalUserRole_Call {
+func (_c *MockDatabase_SetSlackAlertsChannel_Call) RunAndReturn(run func(context.Context, slug.Slug, string, string) error) *MockDatabase_SetSlackAlertsChannel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetLastSuccessfulSyncForTeam provides a mock function with given fields: ctx, teamSlug
-func (_m *MockDatabase) SetLastSuccessfulSyncForTeam(ctx context.Context, teamSlug slug.Slug) error {
-	ret := _m.Called(ctx, teamSlug)
+// SetTeamDeletionReconcilerResult provides a mock function with given fields: ctx, teamSlug, reconcilerName, status, errorMessage
+func (_m *MockDatabase) SetTeamDeletionReconcilerResult(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName, status sqlc.TeamDeletionReconcilerStatus, errorMessage *string) error {
+	ret := _m.Called(ctx, teamSlug, reconcilerName, status, errorMessage)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) error); ok {
-		r0 = rf(ctx, teamSlug)
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, sqlc.ReconcilerName, sqlc.TeamDeletionReconcilerStatus, *string) error); ok {
+		r0 = rf(ctx, teamSlug, reconcilerName, status, errorMessage)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3521,42 +7439,45 @@ func (_m *MockDatabase) SetLastSuccessfulSyncForTeam(ctx context.Context, teamSl
 	return r0
 }
 
-// MockDatabase_SetLastSuccessfulSyncForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLastSuccessfulSyncForTeam'
-type MockDatabase_SetLastSuccessfulSyncForTeam_Call struct {
+// MockDatabase_SetTeamDeletionReconcilerResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamDeletionReconcilerResult'
+type MockDatabase_SetTeamDeletionReconcilerResult_Call struct {
 	*mock.Call
 }
 
-// SetLastSuccessfulSyncForTeam is a helper method to define mock.On call
+// SetTeamDeletionReconcilerResult is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamSlug slug.Slug
-func (_e *MockDatabase_Expecter) SetLastSuccessfulSyncForTeam(ctx interface{}, teamSlug interface{}) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
-	return &MockDatabase_SetLastSuccessfulSyncForTeam_Call{Call: _e.mock.On("SetLastSuccessfulSyncForTeam", ctx, teamSlug)}
+//   - reconcilerName sqlc.ReconcilerName
+//   - status sqlc.TeamDeletionReconcilerStatus
+//   - errorMessage *string
+func (_e *MockDatabase_Expecter) SetTeamDeletionReconcilerResult(ctx interface{}, teamSlug interface{}, reconcilerName interface{}, status interface{}, errorMessage interface{}) *MockDatabase_SetTeamDeletionReconcilerResult_Call {
+	return &MockDatabase_SetTeamDeletionReconcilerResult_Call{Call: _e.mock.On("SetTeamDeletionReconcilerResult", ctx, teamSlug, reconcilerName, status, errorMessage)}
 }
 
-func (_c *MockDatabase_SetLastSuccessfulSyncForTeam_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
+func (_c *MockDatabase_SetTeamDeletionReconcilerResult_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName, status sqlc.TeamDeletionReconcilerStatus, errorMessage *string)) *MockDatabase_SetTeamDeletionReconcilerResult_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug))
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(sqlc.ReconcilerName), args[3].(sqlc.TeamDeletionReconcilerStatus), args[4].(*string))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_SetLastSuccessfulSyncForTeam_Call) Return(_a0 error) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
+func (_c *MockDatabase_SetTeamDeletionReconcilerResult_Call) Return(_a0 error) *MockDatabase_SetTeamDeletionReconcilerResult_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_SetLastSuccessfulSyncForTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) error) *MockDatabase_SetLastSuccessfulSyncForTeam_Call {
+func (_c *MockDatabase_SetTeamDeletionReconcilerResult_Call) RunAndReturn(run func(context.Context, slug.Slug, sqlc.ReconcilerName, sqlc.TeamDeletionReconcilerStatus, *string) error) *MockDatabase_SetTeamDeletionReconcilerResult_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetReconcilerErrorForTeam provides a mock function with given fields: ctx, correlationID, _a2, reconcilerName, err
-func (_m *MockDatabase) SetReconcilerErrorForTeam(ctx context.Context, correlationID uuid.UUID, _a2 slug.Slug, reconcilerName sqlc.ReconcilerName, err error) error {
-	ret := _m.Called(ctx, correlationID, _a2, reconcilerName, err)
+// SetTeamMemberRole provides a mock function with given fields: ctx, userID, teamSlug, role
+func (_m *MockDatabase) SetTeamMemberRole(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role roles.RoleName) error {
+	ret := _m.Called(ctx, userID, teamSlug, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error) error); ok {
-		r0 = rf(ctx, correlationID, _a2, reconcilerName, err)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug, roles.RoleName) error); ok {
+		r0 = rf(ctx, userID, teamSlug, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3564,90 +7485,100 @@ func (_m *MockDatabase) SetReconcilerErrorForTeam(ctx context.Context, correlati
 	return r0
 }
 
-// MockDatabase_SetReconcilerErrorForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReconcilerErrorForTeam'
-type MockDatabase_SetReconcilerErrorForTeam_Call struct {
+// MockDatabase_SetTeamMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamMemberRole'
+type MockDatabase_SetTeamMemberRole_Call struct {
 	*mock.Call
 }
 
-// SetReconcilerErrorForTeam is a helper method to define mock.On call
+// SetTeamMemberRole is a helper method to define mock.On call
 //   - ctx context.Context
-//   - correlationID uuid.UUID
-//   - _a2 slug.Slug
-//   - reconcilerName sqlc.ReconcilerName
-//   - err error
-func (_e *MockDatabase_Expecter) SetReconcilerErrorForTeam(ctx interface{}, correlationID interface{}, _a2 interface{}, reconcilerName interface{}, err interface{}) *MockDatabase_SetReconcilerErrorForTeam_Call {
-	return &MockDatabase_SetReconcilerErrorForTeam_Call{Call: _e.mock.On("SetReconcilerErrorForTeam", ctx, correlationID, _a2, reconcilerName, err)}
+//   - userID uuid.UUID
+//   - teamSlug slug.Slug
+//   - role roles.RoleName
+func (_e *MockDatabase_Expecter) SetTeamMemberRole(ctx interface{}, userID interface{}, teamSlug interface{}, role interface{}) *MockDatabase_SetTeamMemberRole_Call {
+	return &MockDatabase_SetTeamMemberRole_Call{Call: _e.mock.On("SetTeamMemberRole", ctx, userID, teamSlug, role)}
 }
 
-func (_c *MockDatabase_SetReconcilerErrorForTeam_Call) Run(run func(ctx context.Context, correlationID uuid.UUID, _a2 slug.Slug, reconcilerName sqlc.ReconcilerName, err error)) *MockDatabase_SetReconcilerErrorForTeam_Call {
+func (_c *MockDatabase_SetTeamMemberRole_Call) Run(run func(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role roles.RoleName)) *MockDatabase_SetTeamMemberRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug), args[3].(sqlc.ReconcilerName), args[4].(error))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug), args[3].(roles.RoleName))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_SetReconcilerErrorForTeam_Call) Return(_a0 error) *MockDatabase_SetReconcilerErrorForTeam_Call {
+func (_c *MockDatabase_SetTeamMemberRole_Call) Return(_a0 error) *MockDatabase_SetTeamMemberRole_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_SetReconcilerErrorForTeam_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug, sqlc.ReconcilerName, error) error) *MockDatabase_SetReconcilerErrorForTeam_Call {
+func (_c *MockDatabase_SetTeamMemberRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug, roles.RoleName) error) *MockDatabase_SetTeamMemberRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetReconcilerStateForTeam provides a mock function with given fields: ctx, reconcilerName, _a2, state
-func (_m *MockDatabase) SetReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{}) error {
-	ret := _m.Called(ctx, reconcilerName, _a2, state)
+// SetTeamParent provides a mock function with given fields: ctx, teamSlug, parentTeamSlug
+func (_m *MockDatabase) SetTeamParent(ctx context.Context, teamSlug slug.Slug, parentTeamSlug *slug.Slug) (*Team, error) {
+	ret := _m.Called(ctx, teamSlug, parentTeamSlug)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error); ok {
-		r0 = rf(ctx, reconcilerName, _a2, state)
+	var r0 *Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, *slug.Slug) (*Team, error)); ok {
+		return rf(ctx, teamSlug, parentTeamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, *slug.Slug) *Team); ok {
+		r0 = rf(ctx, teamSlug, parentTeamSlug)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Team)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug, *slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug, parentTeamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_SetReconcilerStateForTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReconcilerStateForTeam'
-type MockDatabase_SetReconcilerStateForTeam_Call struct {
+// MockDatabase_SetTeamParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamParent'
+type MockDatabase_SetTeamParent_Call struct {
 	*mock.Call
 }
 
-// SetReconcilerStateForTeam is a helper method to define mock.On call
+// SetTeamParent is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reconcilerName sqlc.ReconcilerName
-//   - _a2 slug.Slug
-//   - state interface{}
-func (_e *MockDatabase_Expecter) SetReconcilerStateForTeam(ctx interface{}, reconcilerName interface{}, _a2 interface{}, state interface{}) *MockDatabase_SetReconcilerStateForTeam_Call {
-	return &MockDatabase_SetReconcilerStateForTeam_Call{Call: _e.mock.On("SetReconcilerStateForTeam", ctx, reconcilerName, _a2, state)}
+//   - teamSlug slug.Slug
+//   - parentTeamSlug *slug.Slug
+func (_e *MockDatabase_Expecter) SetTeamParent(ctx interface{}, teamSlug interface{}, parentTeamSlug interface{}) *MockDatabase_SetTeamParent_Call {
+	return &MockDatabase_SetTeamParent_Call{Call: _e.mock.On("SetTeamParent", ctx, teamSlug, parentTeamSlug)}
 }
 
-func (_c *MockDatabase_SetReconcilerStateForTeam_Call) Run(run func(ctx context.Context, reconcilerName sqlc.ReconcilerName, _a2 slug.Slug, state interface{})) *MockDatabase_SetReconcilerStateForTeam_Call {
+func (_c *MockDatabase_SetTeamParent_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, parentTeamSlug *slug.Slug)) *MockDatabase_SetTeamParent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(sqlc.ReconcilerName), args[2].(slug.Slug), args[3].(interface{}))
+		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(*slug.Slug))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_SetReconcilerStateForTeam_Call) Return(_a0 error) *MockDatabase_SetReconcilerStateForTeam_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_SetTeamParent_Call) Return(_a0 *Team, _a1 error) *MockDatabase_SetTeamParent_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_SetReconcilerStateForTeam_Call) RunAndReturn(run func(context.Context, sqlc.ReconcilerName, slug.Slug, interface{}) error) *MockDatabase_SetReconcilerStateForTeam_Call {
+func (_c *MockDatabase_SetTeamParent_Call) RunAndReturn(run func(context.Context, slug.Slug, *slug.Slug) (*Team, error)) *MockDatabase_SetTeamParent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetSlackAlertsChannel provides a mock function with given fields: ctx, teamSlug, environment, channelName
-func (_m *MockDatabase) SetSlackAlertsChannel(ctx context.Context, teamSlug slug.Slug, environment string, channelName string) error {
-	ret := _m.Called(ctx, teamSlug, environment, channelName)
+// SetWebhookDeliveryResult provides a mock function with given fields: ctx, id, status, statusCode, lastError, nextAttemptAt
+func (_m *MockDatabase) SetWebhookDeliveryResult(ctx context.Context, id uuid.UUID, status sqlc.WebhookDeliveryStatus, statusCode *int32, lastError *string, nextAttemptAt time.Time) error {
+	ret := _m.Called(ctx, id, status, statusCode, lastError, nextAttemptAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug, string, string) error); ok {
-		r0 = rf(ctx, teamSlug, environment, channelName)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.WebhookDeliveryStatus, *int32, *string, time.Time) error); ok {
+		r0 = rf(ctx, id, status, statusCode, lastError, nextAttemptAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3655,78 +7586,89 @@ func (_m *MockDatabase) SetSlackAlertsChannel(ctx context.Context, teamSlug slug
 	return r0
 }
 
-// MockDatabase_SetSlackAlertsChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSlackAlertsChannel'
-type MockDatabase_SetSlackAlertsChannel_Call struct {
+// MockDatabase_SetWebhookDeliveryResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWebhookDeliveryResult'
+type MockDatabase_SetWebhookDeliveryResult_Call struct {
 	*mock.Call
 }
 
-// SetSlackAlertsChannel is a helper method to define mock.On call
+// SetWebhookDeliveryResult is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamSlug slug.Slug
-//   - environment string
-//   - channelName string
-func (_e *MockDatabase_Expecter) SetSlackAlertsChannel(ctx interface{}, teamSlug interface{}, environment interface{}, channelName interface{}) *MockDatabase_SetSlackAlertsChannel_Call {
-	return &MockDatabase_SetSlackAlertsChannel_Call{Call: _e.mock.On("SetSlackAlertsChannel", ctx, teamSlug, environment, channelName)}
+//   - id uuid.UUID
+//   - status sqlc.WebhookDeliveryStatus
+//   - statusCode *int32
+//   - lastError *string
+//   - nextAttemptAt time.Time
+func (_e *MockDatabase_Expecter) SetWebhookDeliveryResult(ctx interface{}, id interface{}, status interface{}, statusCode interface{}, lastError interface{}, nextAttemptAt interface{}) *MockDatabase_SetWebhookDeliveryResult_Call {
+	return &MockDatabase_SetWebhookDeliveryResult_Call{Call: _e.mock.On("SetWebhookDeliveryResult", ctx, id, status, statusCode, lastError, nextAttemptAt)}
 }
 
-func (_c *MockDatabase_SetSlackAlertsChannel_Call) Run(run func(ctx context.Context, teamSlug slug.Slug, environment string, channelName string)) *MockDatabase_SetSlackAlertsChannel_Call {
+func (_c *MockDatabase_SetWebhookDeliveryResult_Call) Run(run func(ctx context.Context, id uuid.UUID, status sqlc.WebhookDeliveryStatus, statusCode *int32, lastError *string, nextAttemptAt time.Time)) *MockDatabase_SetWebhookDeliveryResult_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slug.Slug), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sqlc.WebhookDeliveryStatus), args[3].(*int32), args[4].(*string), args[5].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_SetSlackAlertsChannel_Call) Return(_a0 error) *MockDatabase_SetSlackAlertsChannel_Call {
+func (_c *MockDatabase_SetWebhookDeliveryResult_Call) Return(_a0 error) *MockDatabase_SetWebhookDeliveryResult_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockDatabase_SetSlackAlertsChannel_Call) RunAndReturn(run func(context.Context, slug.Slug, string, string) error) *MockDatabase_SetSlackAlertsChannel_Call {
+func (_c *MockDatabase_SetWebhookDeliveryResult_Call) RunAndReturn(run func(context.Context, uuid.UUID, sqlc.WebhookDeliveryStatus, *int32, *string, time.Time) error) *MockDatabase_SetWebhookDeliveryResult_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetTeamMemberRole provides a mock function with given fields: ctx, userID, teamSlug, role
-func (_m *MockDatabase) SetTeamMemberRole(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role sqlc.RoleName) error {
-	ret := _m.Called(ctx, userID, teamSlug, role)
+// StartDueTeamDeletions provides a mock function with given fields: ctx
+func (_m *MockDatabase) StartDueTeamDeletions(ctx context.Context) ([]*TeamDeletion, error) {
+	ret := _m.Called(ctx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, slug.Slug, sqlc.RoleName) error); ok {
-		r0 = rf(ctx, userID, teamSlug, role)
+	var r0 []*TeamDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*TeamDeletion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*TeamDeletion); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TeamDeletion)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockDatabase_SetTeamMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamMemberRole'
-type MockDatabase_SetTeamMemberRole_Call struct {
+// MockDatabase_StartDueTeamDeletions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartDueTeamDeletions'
+type MockDatabase_StartDueTeamDeletions_Call struct {
 	*mock.Call
 }
 
-// SetTeamMemberRole is a helper method to define mock.On call
+// StartDueTeamDeletions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-//   - teamSlug slug.Slug
-//   - role sqlc.RoleName
-func (_e *MockDatabase_Expecter) SetTeamMemberRole(ctx interface{}, userID interface{}, teamSlug interface{}, role interface{}) *MockDatabase_SetTeamMemberRole_Call {
-	return &MockDatabase_SetTeamMemberRole_Call{Call: _e.mock.On("SetTeamMemberRole", ctx, userID, teamSlug, role)}
+func (_e *MockDatabase_Expecter) StartDueTeamDeletions(ctx interface{}) *MockDatabase_StartDueTeamDeletions_Call {
+	return &MockDatabase_StartDueTeamDeletions_Call{Call: _e.mock.On("StartDueTeamDeletions", ctx)}
 }
 
-func (_c *MockDatabase_SetTeamMemberRole_Call) Run(run func(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role sqlc.RoleName)) *MockDatabase_SetTeamMemberRole_Call {
+func (_c *MockDatabase_StartDueTeamDeletions_Call) Run(run func(ctx context.Context)) *MockDatabase_StartDueTeamDeletions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(slug.Slug), args[3].(sqlc.RoleName))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_SetTeamMemberRole_Call) Return(_a0 error) *MockDatabase_SetTeamMemberRole_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDatabase_StartDueTeamDeletions_Call) Return(_a0 []*TeamDeletion, _a1 error) *MockDatabase_StartDueTeamDeletions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_SetTeamMemberRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, slug.Slug, sqlc.RoleName) error) *MockDatabase_SetTeamMemberRole_Call {
+func (_c *MockDatabase_StartDueTeamDeletions_Call) RunAndReturn(run func(context.Context) ([]*TeamDeletion, error)) *MockDatabase_StartDueTeamDeletions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3774,6 +7716,118 @@ func (_c *MockDatabase_Transaction_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpdateRoleDefinition provides a mock function with given fields: ctx, roleName, description
+func (_m *MockDatabase) UpdateRoleDefinition(ctx context.Context, roleName roles.RoleName, description string) (*RoleDefinition, error) {
+	ret := _m.Called(ctx, roleName, description)
+
+	var r0 *RoleDefinition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName, string) (*RoleDefinition, error)); ok {
+		return rf(ctx, roleName, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, roles.RoleName, string) *RoleDefinition); ok {
+		r0 = rf(ctx, roleName, description)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*RoleDefinition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, roles.RoleName, string) error); ok {
+		r1 = rf(ctx, roleName, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_UpdateRoleDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRoleDefinition'
+type MockDatabase_UpdateRoleDefinition_Call struct {
+	*mock.Call
+}
+
+// UpdateRoleDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - roleName roles.RoleName
+//   - description string
+func (_e *MockDatabase_Expecter) UpdateRoleDefinition(ctx interface{}, roleName interface{}, description interface{}) *MockDatabase_UpdateRoleDefinition_Call {
+	return &MockDatabase_UpdateRoleDefinition_Call{Call: _e.mock.On("UpdateRoleDefinition", ctx, roleName, description)}
+}
+
+func (_c *MockDatabase_UpdateRoleDefinition_Call) Run(run func(ctx context.Context, roleName roles.RoleName, description string)) *MockDatabase_UpdateRoleDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(roles.RoleName), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_UpdateRoleDefinition_Call) Return(_a0 *RoleDefinition, _a1 error) *MockDatabase_UpdateRoleDefinition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_UpdateRoleDefinition_Call) RunAndReturn(run func(context.Context, roles.RoleName, string) (*RoleDefinition, error)) *MockDatabase_UpdateRoleDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateServiceAccount provides a mock function with given fields: ctx, serviceAccountID, name
+func (_m *MockDatabase) UpdateServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, name string) (*ServiceAccount, error) {
+	ret := _m.Called(ctx, serviceAccountID, name)
+
+	var r0 *ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*ServiceAccount, error)); ok {
+		return rf(ctx, serviceAccountID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *ServiceAccount); ok {
+		r0 = rf(ctx, serviceAccountID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, serviceAccountID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_UpdateServiceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateServiceAccount'
+type MockDatabase_UpdateServiceAccount_Call struct {
+	*mock.Call
+}
+
+// UpdateServiceAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID uuid.UUID
+//   - name string
+func (_e *MockDatabase_Expecter) UpdateServiceAccount(ctx interface{}, serviceAccountID interface{}, name interface{}) *MockDatabase_UpdateServiceAccount_Call {
+	return &MockDatabase_UpdateServiceAccount_Call{Call: _e.mock.On("UpdateServiceAccount", ctx, serviceAccountID, name)}
+}
+
+func (_c *MockDatabase_UpdateServiceAccount_Call) Run(run func(ctx context.Context, serviceAccountID uuid.UUID, name string)) *MockDatabase_UpdateServiceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_UpdateServiceAccount_Call) Return(_a0 *ServiceAccount, _a1 error) *MockDatabase_UpdateServiceAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_UpdateServiceAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*ServiceAccount, error)) *MockDatabase_UpdateServiceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTeam provides a mock function with given fields: ctx, teamSlug, purpose, slackChannel
 func (_m *MockDatabase) UpdateTeam(ctx context.Context, teamSlug slug.Slug, purpose *string, slackChannel *string) (*Team, error) {
 	ret := _m.Called(ctx, teamSlug, purpose, slackChannel)
@@ -3889,6 +7943,64 @@ func (_c *MockDatabase_UpdateUser_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
+// UpdateWebhookSubscription provides a mock function with given fields: ctx, id, url, eventTypes, enabled
+func (_m *MockDatabase) UpdateWebhookSubscription(ctx context.Context, id uuid.UUID, url *string, eventTypes []sqlc.WebhookEventType, enabled *bool) (*WebhookSubscription, error) {
+	ret := _m.Called(ctx, id, url, eventTypes, enabled)
+
+	var r0 *WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string, []sqlc.WebhookEventType, *bool) (*WebhookSubscription, error)); ok {
+		return rf(ctx, id, url, eventTypes, enabled)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *string, []sqlc.WebhookEventType, *bool) *WebhookSubscription); ok {
+		r0 = rf(ctx, id, url, eventTypes, enabled)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *string, []sqlc.WebhookEventType, *bool) error); ok {
+		r1 = rf(ctx, id, url, eventTypes, enabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_UpdateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookSubscription'
+type MockDatabase_UpdateWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - url *string
+//   - eventTypes []sqlc.WebhookEventType
+//   - enabled *bool
+func (_e *MockDatabase_Expecter) UpdateWebhookSubscription(ctx interface{}, id interface{}, url interface{}, eventTypes interface{}, enabled interface{}) *MockDatabase_UpdateWebhookSubscription_Call {
+	return &MockDatabase_UpdateWebhookSubscription_Call{Call: _e.mock.On("UpdateWebhookSubscription", ctx, id, url, eventTypes, enabled)}
+}
+
+func (_c *MockDatabase_UpdateWebhookSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID, url *string, eventTypes []sqlc.WebhookEventType, enabled *bool)) *MockDatabase_UpdateWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*string), args[3].([]sqlc.WebhookEventType), args[4].(*bool))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_UpdateWebhookSubscription_Call) Return(_a0 *WebhookSubscription, _a1 error) *MockDatabase_UpdateWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_UpdateWebhookSubscription_Call) RunAndReturn(run func(context.Context, uuid.UUID, *string, []sqlc.WebhookEventType, *bool) (*WebhookSubscription, error)) *MockDatabase_UpdateWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserIsTeamOwner provides a mock function with given fields: ctx, userID, teamSlug
 func (_m *MockDatabase) UserIsTeamOwner(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) (bool, error) {
 	ret := _m.Called(ctx, userID, teamSlug)
@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v4"
 )
 
 func (q *Queries) Transaction(ctx context.Context, callback QuerierTransactionFunc) error {
@@ -23,3 +25,29 @@ func (q *Queries) Transaction(ctx context.Context, callback QuerierTransactionFu
 
 	return tx.Commit(ctx)
 }
+
+// Listen Call fn with the payload of every notification sent to the channel until the context is done or the
+// connection fails. A connection is taken from the pool for as long as the function is listening.
+func (q *Queries) Listen(ctx context.Context, channel string, fn func(payload string)) error {
+	conn, err := q.connPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the connection is closed by the pool if it can not be reset
+		_, _ = conn.Exec(context.Background(), "UNLISTEN *")
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		fn(notification.Payload)
+	}
+}
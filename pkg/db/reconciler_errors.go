@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/nais/teams-backend/pkg/slug"
 
@@ -9,12 +10,37 @@ import (
 	"github.com/nais/teams-backend/pkg/sqlc"
 )
 
-func (d *database) SetReconcilerErrorForTeam(ctx context.Context, correlationID uuid.UUID, slug slug.Slug, reconcilerName sqlc.ReconcilerName, err error) error {
-	return d.querier.SetReconcilerErrorForTeam(ctx, sqlc.SetReconcilerErrorForTeamParams{
+func (d *database) SetReconcilerErrorForTeam(ctx context.Context, correlationID uuid.UUID, slug slug.Slug, reconcilerName sqlc.ReconcilerName, err error, kind sqlc.ReconcilerErrorKind) (*ReconcilerError, error) {
+	row, err := d.querier.SetReconcilerErrorForTeam(ctx, sqlc.SetReconcilerErrorForTeamParams{
 		CorrelationID: correlationID,
 		TeamSlug:      slug,
 		Reconciler:    reconcilerName,
 		ErrorMessage:  err.Error(),
+		Kind:          kind,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReconcilerError{ReconcilerError: row}, nil
+}
+
+// SetReconcilerSkippedForTeam Store the reason a reconciler was skipped for a team. The number of attempts and the
+// next retry of an existing error are left as they are, as the reconciler did not run.
+func (d *database) SetReconcilerSkippedForTeam(ctx context.Context, correlationID uuid.UUID, slug slug.Slug, reconcilerName sqlc.ReconcilerName, err error) error {
+	return d.querier.SetReconcilerSkippedForTeam(ctx, sqlc.SetReconcilerSkippedForTeamParams{
+		CorrelationID: correlationID,
+		TeamSlug:      slug,
+		Reconciler:    reconcilerName,
+		ErrorMessage:  err.Error(),
+	})
+}
+
+func (d *database) SetReconcilerErrorNextRetry(ctx context.Context, slug slug.Slug, reconcilerName sqlc.ReconcilerName, nextRetryAt time.Time) error {
+	return d.querier.SetReconcilerErrorNextRetry(ctx, sqlc.SetReconcilerErrorNextRetryParams{
+		TeamSlug:    slug,
+		Reconciler:  reconcilerName,
+		NextRetryAt: nextRetryAt,
 	})
 }
 
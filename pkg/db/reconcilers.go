@@ -89,6 +89,18 @@ func (d *database) DisableReconciler(ctx context.Context, reconcilerName sqlc.Re
 	return &Reconciler{Reconciler: reconciler}, nil
 }
 
+func (d *database) SetReconcilerTimeout(ctx context.Context, reconcilerName sqlc.ReconcilerName, timeoutSeconds *int32) (*Reconciler, error) {
+	reconciler, err := d.querier.SetReconcilerTimeout(ctx, sqlc.SetReconcilerTimeoutParams{
+		Name:           reconcilerName,
+		TimeoutSeconds: timeoutSeconds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reconciler{Reconciler: reconciler}, nil
+}
+
 func (d *database) DangerousGetReconcilerConfigValues(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*ReconcilerConfigValues, error) {
 	rows, err := d.querier.DangerousGetReconcilerConfigValues(ctx, reconcilerName)
 	if err != nil {
@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/nais/teams-backend/pkg/roles"
 
@@ -11,21 +13,38 @@ import (
 	"github.com/nais/teams-backend/pkg/sqlc"
 )
 
-func (d *database) AssignGlobalRoleToUser(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName) error {
+// AssignGlobalRoleToUser Assign a global role to a user. The grantedBy argument is the identity of the actor granting
+// the role, or nil when the role is granted by the system.
+func (d *database) AssignGlobalRoleToUser(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, grantedBy *string) error {
 	return d.querier.AssignGlobalRoleToUser(ctx, sqlc.AssignGlobalRoleToUserParams{
-		UserID:   userID,
-		RoleName: roleName,
+		UserID:    userID,
+		RoleName:  roleName,
+		GrantedBy: grantedBy,
 	})
 }
 
-func (d *database) AssignGlobalRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName) error {
+// ErrTeamServiceAccountRole The role does not target the team owning the service account
+var ErrTeamServiceAccountRole = errors.New("team service accounts can only be assigned roles targeting the team owning the service account")
+
+// AssignGlobalRoleToServiceAccount Assign a global role to a service account. The grantedBy argument is the identity of
+// the actor granting the role, or nil when the role is granted by the system.
+func (d *database) AssignGlobalRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, grantedBy *string) error {
+	if err := d.requireServiceAccountRoleTarget(ctx, serviceAccountID, nil); err != nil {
+		return err
+	}
+
 	return d.querier.AssignGlobalRoleToServiceAccount(ctx, sqlc.AssignGlobalRoleToServiceAccountParams{
 		ServiceAccountID: serviceAccountID,
 		RoleName:         roleName,
+		GrantedBy:        grantedBy,
 	})
 }
 
-func (d *database) AssignTeamRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName, teamSlug slug.Slug) error {
+func (d *database) AssignTeamRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, teamSlug slug.Slug) error {
+	if err := d.requireServiceAccountRoleTarget(ctx, serviceAccountID, &teamSlug); err != nil {
+		return err
+	}
+
 	return d.querier.AssignTeamRoleToServiceAccount(ctx, sqlc.AssignTeamRoleToServiceAccountParams{
 		ServiceAccountID: serviceAccountID,
 		RoleName:         roleName,
@@ -33,14 +52,56 @@ func (d *database) AssignTeamRoleToServiceAccount(ctx context.Context, serviceAc
 	})
 }
 
+func (d *database) AssignServiceAccountRoleToUser(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, serviceAccountID uuid.UUID) error {
+	return d.querier.AssignServiceAccountRoleToUser(ctx, sqlc.AssignServiceAccountRoleToUserParams{
+		UserID:                 userID,
+		RoleName:               roleName,
+		TargetServiceAccountID: &serviceAccountID,
+	})
+}
+
+// requireServiceAccountRoleTarget Make sure that the roles of a team service account only target the team owning the
+// service account
+func (d *database) requireServiceAccountRoleTarget(ctx context.Context, serviceAccountID uuid.UUID, teamSlug *slug.Slug) error {
+	serviceAccount, err := d.querier.GetServiceAccountByID(ctx, serviceAccountID)
+	if err != nil {
+		return err
+	}
+
+	if serviceAccount.TeamSlug == nil {
+		return nil
+	}
+
+	if teamSlug == nil || *teamSlug != *serviceAccount.TeamSlug {
+		return ErrTeamServiceAccountRole
+	}
+
+	return nil
+}
+
 // IsGlobal Check if the role is globally assigned or not
 func (r Role) IsGlobal() bool {
 	return r.TargetServiceAccountID == nil && r.TargetTeamSlug == nil
 }
 
-// TargetsTeam Check if the role targets a specific team
+// TargetsTeam Check if the role targets a specific team, either directly or through one of the sub-teams of the
+// target team
 func (r Role) TargetsTeam(targetsTeamSlug slug.Slug) bool {
-	return r.TargetTeamSlug != nil && *r.TargetTeamSlug == targetsTeamSlug
+	if r.TargetTeamSlug == nil {
+		return false
+	}
+
+	if *r.TargetTeamSlug == targetsTeamSlug {
+		return true
+	}
+
+	for _, descendant := range r.DescendantTeamSlugs {
+		if descendant == targetsTeamSlug {
+			return true
+		}
+	}
+
+	return false
 }
 
 // TargetsServiceAccount Check if the role targets a specific service account
@@ -49,13 +110,13 @@ func (r Role) TargetsServiceAccount(targetServiceAccountID uuid.UUID) bool {
 }
 
 func (d *database) UserIsTeamOwner(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) (bool, error) {
-	roles, err := d.querier.GetUserRoles(ctx, userID)
+	userRoles, err := d.querier.GetUserRoles(ctx, userID)
 	if err != nil {
 		return false, err
 	}
 
-	for _, role := range roles {
-		if role.RoleName == sqlc.RoleNameTeamowner && role.TargetTeamSlug != nil && *role.TargetTeamSlug == teamSlug {
+	for _, role := range userRoles {
+		if role.RoleName == roles.RoleNameTeamowner && role.TargetTeamSlug != nil && *role.TargetTeamSlug == teamSlug {
 			return true, nil
 		}
 	}
@@ -63,7 +124,7 @@ func (d *database) UserIsTeamOwner(ctx context.Context, userID uuid.UUID, teamSl
 	return false, nil
 }
 
-func (d *database) SetTeamMemberRole(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role sqlc.RoleName) error {
+func (d *database) SetTeamMemberRole(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role roles.RoleName) error {
 	return d.querier.AssignTeamRoleToUser(ctx, sqlc.AssignTeamRoleToUserParams{
 		UserID:         userID,
 		TargetTeamSlug: &teamSlug,
@@ -71,14 +132,40 @@ func (d *database) SetTeamMemberRole(ctx context.Context, userID uuid.UUID, team
 	})
 }
 
-func (d *database) RevokeGlobalUserRole(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName) error {
+func (d *database) RevokeGlobalUserRole(ctx context.Context, userID uuid.UUID, roleName roles.RoleName) error {
 	return d.querier.RevokeGlobalUserRole(ctx, sqlc.RevokeGlobalUserRoleParams{
 		RoleName: roleName,
 		UserID:   userID,
 	})
 }
 
-func (d *database) GetUsersWithGloballyAssignedRole(ctx context.Context, roleName sqlc.RoleName) ([]*User, error) {
+func (d *database) RevokeGlobalServiceAccountRole(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName) error {
+	return d.querier.RevokeGlobalServiceAccountRole(ctx, sqlc.RevokeGlobalServiceAccountRoleParams{
+		RoleName:         roleName,
+		ServiceAccountID: serviceAccountID,
+	})
+}
+
+// globalAdminsLockID The ID of the advisory lock held while the global admin role is revoked
+const globalAdminsLockID = 7032412174
+
+// LockGlobalAdmins Serialize changes to the global admin role bindings, so that concurrent revocations can not remove
+// the last admin. Must be called in a transaction, as the lock is held until the transaction ends.
+func (d *database) LockGlobalAdmins(ctx context.Context) error {
+	return d.querier.LockGlobalAdmins(ctx, globalAdminsLockID)
+}
+
+// CountGlobalAdmins Count the users and service accounts with a globally assigned admin role
+func (d *database) CountGlobalAdmins(ctx context.Context) (int, error) {
+	count, err := d.querier.CountGlobalAdmins(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (d *database) GetUsersWithGloballyAssignedRole(ctx context.Context, roleName roles.RoleName) ([]*User, error) {
 	users, err := d.querier.GetUsersWithGloballyAssignedRole(ctx, roleName)
 	if err != nil {
 		return nil, err
@@ -101,16 +188,52 @@ func (d *database) GetAllUserRoles(ctx context.Context) ([]*UserRole, error) {
 	return roles, nil
 }
 
-func (d *database) roleFromRoleBinding(_ context.Context, roleName sqlc.RoleName, targetServiceAccountID *uuid.UUID, targetTeamSlug *slug.Slug) (*Role, error) {
-	authorizations, err := roles.Authorizations(roleName)
+// withDescendantTeams Let team owner roles apply to all sub-teams of the target team, as owners of a team are allowed
+// to manage its sub-teams. The sub-teams of all target teams are fetched at once.
+func (d *database) withDescendantTeams(ctx context.Context, roleBindings []*Role) error {
+	teamSlugs := make([]string, 0)
+	for _, role := range roleBindings {
+		if role.RoleName == roles.RoleNameTeamowner && role.TargetTeamSlug != nil {
+			teamSlugs = append(teamSlugs, string(*role.TargetTeamSlug))
+		}
+	}
+
+	if len(teamSlugs) == 0 {
+		return nil
+	}
+
+	rows, err := d.querier.GetDescendantSlugsForTeams(ctx, teamSlugs)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	descendants := make(map[slug.Slug][]slug.Slug)
+	for _, row := range rows {
+		ancestor := slug.Slug(row.AncestorSlug)
+		descendants[ancestor] = append(descendants[ancestor], slug.Slug(row.Slug))
+	}
+
+	for _, role := range roleBindings {
+		if role.RoleName != roles.RoleNameTeamowner || role.TargetTeamSlug == nil {
+			continue
+		}
+
+		role.DescendantTeamSlugs = descendants[*role.TargetTeamSlug]
+		if role.DescendantTeamSlugs == nil {
+			role.DescendantTeamSlugs = make([]slug.Slug, 0)
+		}
 	}
 
+	return nil
+}
+
+func roleFromRoleBinding(authorizations map[roles.RoleName][]roles.Authorization, roleName roles.RoleName, targetServiceAccountID *uuid.UUID, targetTeamSlug *slug.Slug, grantedBy *string, grantedAt *time.Time) *Role {
 	return &Role{
-		Authorizations:         authorizations,
+		Authorizations:         authorizations[roleName],
 		RoleName:               roleName,
 		TargetServiceAccountID: targetServiceAccountID,
 		TargetTeamSlug:         targetTeamSlug,
-	}, nil
+		GrantedBy:              grantedBy,
+		GrantedAt:              grantedAt,
+	}
 }
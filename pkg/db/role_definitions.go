@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+func (d *database) GetRoleDefinitions(ctx context.Context) ([]*RoleDefinition, error) {
+	rows, err := d.querier.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]*RoleDefinition, 0, len(rows))
+	for _, row := range rows {
+		definitions = append(definitions, &RoleDefinition{Role: row})
+	}
+
+	return definitions, nil
+}
+
+func (d *database) GetRoleDefinition(ctx context.Context, roleName roles.RoleName) (*RoleDefinition, error) {
+	role, err := d.querier.GetRoleByName(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoleDefinition{Role: role}, nil
+}
+
+// GetRoleAuthorizations Get the authorizations granted by a role
+func (d *database) GetRoleAuthorizations(ctx context.Context, roleName roles.RoleName) ([]roles.Authorization, error) {
+	return d.querier.GetRoleAuthorizations(ctx, roleName)
+}
+
+// GetRolesAuthorizations Get the authorizations granted by each of the given roles, keyed by role name
+func (d *database) GetRolesAuthorizations(ctx context.Context, roleNames []roles.RoleName) (map[roles.RoleName][]roles.Authorization, error) {
+	names := make([]string, 0, len(roleNames))
+	for _, roleName := range roleNames {
+		names = append(names, string(roleName))
+	}
+
+	rows, err := d.querier.GetAuthorizationsForRoles(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizations := make(map[roles.RoleName][]roles.Authorization)
+	for _, row := range rows {
+		authorizations[row.RoleName] = append(authorizations[row.RoleName], row.AuthorizationName)
+	}
+
+	return authorizations, nil
+}
+
+// pgUniqueViolation Postgres error code returned when a unique constraint is violated
+const pgUniqueViolation = "23505"
+
+// ErrRoleNameTaken A role with the given name already exists
+var ErrRoleNameTaken = errors.New("a role with the given name already exists")
+
+// CreateRoleDefinition Create a custom role. The authorizations of the role are set with SetRoleAuthorizations.
+// Returns ErrRoleNameTaken if a role with the same name already exists.
+func (d *database) CreateRoleDefinition(ctx context.Context, roleName roles.RoleName, description string) (*RoleDefinition, error) {
+	role, err := d.querier.CreateRole(ctx, sqlc.CreateRoleParams{
+		Name:        roleName,
+		Description: description,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, ErrRoleNameTaken
+		}
+		return nil, err
+	}
+
+	return &RoleDefinition{Role: role}, nil
+}
+
+// UpdateRoleDefinition Update the description of a custom role. Built-in roles can not be updated.
+func (d *database) UpdateRoleDefinition(ctx context.Context, roleName roles.RoleName, description string) (*RoleDefinition, error) {
+	role, err := d.querier.UpdateRole(ctx, sqlc.UpdateRoleParams{
+		Name:        roleName,
+		Description: description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoleDefinition{Role: role}, nil
+}
+
+// DeleteRoleDefinition Delete a custom role, including all assignments of the role. Built-in roles can not be deleted.
+func (d *database) DeleteRoleDefinition(ctx context.Context, roleName roles.RoleName) error {
+	return d.querier.DeleteRole(ctx, roleName)
+}
+
+// SetRoleAuthorizations Replace the authorizations granted by a role. This method should be run in a transaction.
+func (d *database) SetRoleAuthorizations(ctx context.Context, roleName roles.RoleName, authorizations []roles.Authorization) error {
+	if err := d.querier.RemoveRoleAuthorizations(ctx, roleName); err != nil {
+		return err
+	}
+
+	for _, authorization := range authorizations {
+		err := d.querier.AddRoleAuthorization(ctx, sqlc.AddRoleAuthorizationParams{
+			RoleName:          roleName,
+			AuthorizationName: authorization,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRoleAuthorizations Make sure that all authorizations granted by the roles stored in the database are known to
+// the application, so that the seeded built-in roles and the authorizations in the code can not drift apart unnoticed
+func validateRoleAuthorizations(ctx context.Context, querier Querier) error {
+	authorizations, err := querier.GetAuthorizationNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, authorization := range authorizations {
+		if !authorization.Valid() {
+			return fmt.Errorf("the database contains a role with the unknown authorization %q", authorization)
+		}
+	}
+
+	return nil
+}
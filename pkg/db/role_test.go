@@ -32,3 +32,14 @@ func TestRole_Targets(t *testing.T) {
 	assert.True(t, r2.TargetsTeam(slug2))
 	assert.False(t, r3.TargetsTeam(slug2))
 }
+
+func TestRole_TargetsDescendantTeams(t *testing.T) {
+	parent := slug.Slug("parent")
+	child := slug.Slug("child")
+	other := slug.Slug("other")
+	r := db.Role{TargetTeamSlug: &parent, DescendantTeamSlugs: []slug.Slug{child}}
+
+	assert.True(t, r.TargetsTeam(parent))
+	assert.True(t, r.TargetsTeam(child))
+	assert.False(t, r.TargetsTeam(other))
+}
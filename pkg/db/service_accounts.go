@@ -3,13 +3,20 @@ package db
 import (
 	"context"
 
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
 
 	"github.com/google/uuid"
 )
 
-func (d *database) CreateServiceAccount(ctx context.Context, name string) (*ServiceAccount, error) {
-	serviceAccount, err := d.querier.CreateServiceAccount(ctx, name)
+// CreateServiceAccount Create a service account. When a team slug is given the service account is owned by the team,
+// and is deleted along with the team.
+func (d *database) CreateServiceAccount(ctx context.Context, name string, teamSlug *slug.Slug) (*ServiceAccount, error) {
+	serviceAccount, err := d.querier.CreateServiceAccount(ctx, sqlc.CreateServiceAccountParams{
+		Name:     name,
+		TeamSlug: teamSlug,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +33,34 @@ func (d *database) GetServiceAccountByName(ctx context.Context, name string) (*S
 	return &ServiceAccount{ServiceAccount: serviceAccount}, nil
 }
 
-func (d *database) GetServiceAccountByApiKey(ctx context.Context, apiKey string) (*ServiceAccount, error) {
-	serviceAccount, err := d.querier.GetServiceAccountByApiKey(ctx, apiKey)
+func (d *database) GetTeamServiceAccounts(ctx context.Context, teamSlug slug.Slug) ([]*ServiceAccount, error) {
+	rows, err := d.querier.GetTeamServiceAccounts(ctx, &teamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts := make([]*ServiceAccount, 0, len(rows))
+	for _, row := range rows {
+		serviceAccounts = append(serviceAccounts, &ServiceAccount{ServiceAccount: row})
+	}
+
+	return serviceAccounts, nil
+}
+
+func (d *database) GetServiceAccountByID(ctx context.Context, serviceAccountID uuid.UUID) (*ServiceAccount, error) {
+	serviceAccount, err := d.querier.GetServiceAccountByID(ctx, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceAccount{ServiceAccount: serviceAccount}, nil
+}
+
+func (d *database) UpdateServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, name string) (*ServiceAccount, error) {
+	serviceAccount, err := d.querier.UpdateServiceAccount(ctx, sqlc.UpdateServiceAccountParams{
+		ID:   serviceAccountID,
+		Name: name,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -63,23 +96,26 @@ func (d *database) GetServiceAccountRoles(ctx context.Context, serviceAccountID
 		return nil, err
 	}
 
-	roles := make([]*Role, 0, len(serviceAccountRoles))
+	roleNames := make([]roles.RoleName, 0, len(serviceAccountRoles))
 	for _, serviceAccountRole := range serviceAccountRoles {
-		role, err := d.roleFromRoleBinding(ctx, serviceAccountRole.RoleName, serviceAccountRole.TargetServiceAccountID, serviceAccountRole.TargetTeamSlug)
-		if err != nil {
-			return nil, err
-		}
-		roles = append(roles, role)
+		roleNames = append(roleNames, serviceAccountRole.RoleName)
 	}
 
-	return roles, nil
-}
+	authorizations, err := d.GetRolesAuthorizations(ctx, roleNames)
+	if err != nil {
+		return nil, err
+	}
 
-func (d *database) CreateAPIKey(ctx context.Context, apiKey string, serviceAccountID uuid.UUID) error {
-	return d.querier.CreateAPIKey(ctx, sqlc.CreateAPIKeyParams{
-		ApiKey:           apiKey,
-		ServiceAccountID: serviceAccountID,
-	})
+	roleBindings := make([]*Role, 0, len(serviceAccountRoles))
+	for _, serviceAccountRole := range serviceAccountRoles {
+		roleBindings = append(roleBindings, roleFromRoleBinding(authorizations, serviceAccountRole.RoleName, serviceAccountRole.TargetServiceAccountID, serviceAccountRole.TargetTeamSlug, serviceAccountRole.GrantedBy, serviceAccountRole.GrantedAt))
+	}
+
+	if err := d.withDescendantTeams(ctx, roleBindings); err != nil {
+		return nil, err
+	}
+
+	return roleBindings, nil
 }
 
 func (d *database) RemoveApiKeysFromServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error {
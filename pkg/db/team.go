@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
@@ -88,6 +89,106 @@ func (d *database) GetActiveTeams(ctx context.Context) ([]*Team, error) {
 	return collection, nil
 }
 
+// ErrTeamParentCycle The team can not be a sub-team of itself or of one of its sub-teams
+var ErrTeamParentCycle = errors.New("team can not be a sub-team of itself or of one of its sub-teams")
+
+// SetTeamParent Set the parent of a team. The team will no longer have a parent when parentTeamSlug is nil. The team and
+// all ancestors of the new parent are locked while checking for cycles, so concurrent changes can not create one.
+// Returns ErrTeamParentCycle if the parent is the team itself or one of its sub-teams.
+func (d *database) SetTeamParent(ctx context.Context, teamSlug slug.Slug, parentTeamSlug *slug.Slug) (*Team, error) {
+	var team *sqlc.Team
+	err := d.querier.Transaction(ctx, func(ctx context.Context, querier Querier) error {
+		if parentTeamSlug != nil {
+			if *parentTeamSlug == teamSlug {
+				return ErrTeamParentCycle
+			}
+
+			err := querier.LockTeamAndParentAncestors(ctx, sqlc.LockTeamAndParentAncestorsParams{
+				Slug:       teamSlug,
+				ParentSlug: *parentTeamSlug,
+			})
+			if err != nil {
+				return err
+			}
+
+			descendants, err := querier.GetTeamDescendantSlugs(ctx, string(teamSlug))
+			if err != nil {
+				return err
+			}
+
+			for _, descendant := range descendants {
+				if descendant == string(*parentTeamSlug) {
+					return ErrTeamParentCycle
+				}
+			}
+		}
+
+		var err error
+		team, err = querier.SetTeamParent(ctx, sqlc.SetTeamParentParams{
+			Slug:           teamSlug,
+			ParentTeamSlug: parentTeamSlug,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Team{Team: team}, nil
+}
+
+func (d *database) GetTeamChildren(ctx context.Context, teamSlug slug.Slug) ([]*Team, error) {
+	rows, err := d.querier.GetTeamChildren(ctx, &teamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*Team, 0)
+	for _, team := range rows {
+		teams = append(teams, &Team{Team: team})
+	}
+
+	return teams, nil
+}
+
+// GetTeamDescendantSlugs Get the slugs of all sub-teams of a team, including sub-teams of sub-teams
+func (d *database) GetTeamDescendantSlugs(ctx context.Context, teamSlug slug.Slug) ([]slug.Slug, error) {
+	rows, err := d.querier.GetTeamDescendantSlugs(ctx, string(teamSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	slugs := make([]slug.Slug, 0, len(rows))
+	for _, row := range rows {
+		slugs = append(slugs, slug.Slug(row))
+	}
+
+	return slugs, nil
+}
+
+// DisableTeam Disable a team. The members of the team will be removed from external resources when suspendResources is
+// set.
+func (d *database) DisableTeam(ctx context.Context, teamSlug slug.Slug, suspendResources bool) (*Team, error) {
+	team, err := d.querier.DisableTeam(ctx, sqlc.DisableTeamParams{
+		Slug:               teamSlug,
+		ResourcesSuspended: suspendResources,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Team{Team: team}, nil
+}
+
+func (d *database) EnableTeam(ctx context.Context, teamSlug slug.Slug) (*Team, error) {
+	team, err := d.querier.EnableTeam(ctx, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Team{Team: team}, nil
+}
+
 func (d *database) GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*Team, error) {
 	rows, err := d.querier.GetUserTeams(ctx, userID)
 	if err != nil {
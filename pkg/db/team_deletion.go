@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+// ScheduleTeamDeletion Confirm a team delete key, disable the team and schedule it for deletion at the given time. The
+// key is only confirmed if the deletion is scheduled.
+func (d *database) ScheduleTeamDeletion(ctx context.Context, deleteKey *TeamDeleteKey, correlationID uuid.UUID, deleteAt time.Time) (*TeamDeletion, error) {
+	var deletion *sqlc.TeamDeletion
+	err := d.querier.Transaction(ctx, func(ctx context.Context, querier Querier) error {
+		err := querier.ConfirmTeamDeleteKey(ctx, deleteKey.Key)
+		if err != nil {
+			return err
+		}
+
+		_, err = querier.DisableTeam(ctx, sqlc.DisableTeamParams{
+			Slug: deleteKey.TeamSlug,
+		})
+		if err != nil {
+			return err
+		}
+
+		deletion, err = querier.CreateTeamDeletion(ctx, sqlc.CreateTeamDeletionParams{
+			TeamSlug:      deleteKey.TeamSlug,
+			CorrelationID: correlationID,
+			DeleteAt:      deleteAt,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamDeletion{TeamDeletion: deletion}, nil
+}
+
+// ErrTeamDeletionStarted The deletion of the team has started, and can no longer be cancelled
+var ErrTeamDeletionStarted = errors.New("team deletion has already started")
+
+// CancelTeamDeletion Cancel a scheduled team deletion. The delete keys of the team are removed, and the team is enabled
+// again. Returns ErrTeamDeletionStarted if the deletion is no longer scheduled, for instance because it has started.
+func (d *database) CancelTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*Team, error) {
+	var team *sqlc.Team
+	err := d.querier.Transaction(ctx, func(ctx context.Context, querier Querier) error {
+		deleted, err := querier.DeleteTeamDeletion(ctx, teamSlug)
+		if err != nil {
+			return err
+		}
+
+		if deleted == 0 {
+			return ErrTeamDeletionStarted
+		}
+
+		err = querier.DeleteTeamDeleteKeys(ctx, teamSlug)
+		if err != nil {
+			return err
+		}
+
+		team, err = querier.EnableTeam(ctx, teamSlug)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Team{Team: team}, nil
+}
+
+func (d *database) GetTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*TeamDeletion, error) {
+	deletion, err := d.querier.GetTeamDeletion(ctx, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamDeletion{TeamDeletion: deletion}, nil
+}
+
+// StartDueTeamDeletions Mark all team deletions that are due as started, and return them
+func (d *database) StartDueTeamDeletions(ctx context.Context) ([]*TeamDeletion, error) {
+	rows, err := d.querier.StartDueTeamDeletions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deletions := make([]*TeamDeletion, 0, len(rows))
+	for _, row := range rows {
+		deletions = append(deletions, &TeamDeletion{TeamDeletion: row})
+	}
+
+	return deletions, nil
+}
+
+// ResetTeamDeletion Mark a started team deletion as not started, so that it will be attempted again at deleteAt
+func (d *database) ResetTeamDeletion(ctx context.Context, teamSlug slug.Slug, deleteAt time.Time) error {
+	return d.querier.ResetTeamDeletion(ctx, sqlc.ResetTeamDeletionParams{
+		TeamSlug: teamSlug,
+		DeleteAt: deleteAt,
+	})
+}
+
+// ReleaseStaleTeamDeletions Mark team deletions that were started before startedBefore as not started, so that they
+// will be attempted again. Returns the number of released deletions.
+func (d *database) ReleaseStaleTeamDeletions(ctx context.Context, startedBefore time.Time) (int64, error) {
+	return d.querier.ReleaseStaleTeamDeletions(ctx, startedBefore)
+}
+
+func (d *database) SetTeamDeletionReconcilerResult(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName, status sqlc.TeamDeletionReconcilerStatus, errorMessage *string) error {
+	return d.querier.SetTeamDeletionReconcilerResult(ctx, sqlc.SetTeamDeletionReconcilerResultParams{
+		TeamSlug:     teamSlug,
+		Reconciler:   reconcilerName,
+		Status:       status,
+		ErrorMessage: errorMessage,
+	})
+}
+
+func (d *database) GetTeamDeletionReconcilers(ctx context.Context, teamSlug slug.Slug) ([]*TeamDeletionReconciler, error) {
+	rows, err := d.querier.GetTeamDeletionReconcilers(ctx, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	reconcilers := make([]*TeamDeletionReconciler, 0, len(rows))
+	for _, row := range rows {
+		reconcilers = append(reconcilers, &TeamDeletionReconciler{TeamDeletionReconciler: row})
+	}
+
+	return reconcilers, nil
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+// teamSyncProgressChannel The notification channel used to broadcast the progress of team syncs to all replicas
+const teamSyncProgressChannel = "team_sync_progress"
+
+func (d *database) EnqueueTeamSync(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName, runAt time.Time) error {
+	names := make([]string, 0, len(reconcilers))
+	for _, reconciler := range reconcilers {
+		names = append(names, string(reconciler))
+	}
+
+	return d.querier.EnqueueTeamSync(ctx, sqlc.EnqueueTeamSyncParams{
+		TeamSlug:      teamSlug,
+		CorrelationID: correlationID,
+		TriggeredBy:   trigger,
+		Reconcilers:   names,
+		NextRunAt:     runAt,
+	})
+}
+
+func (d *database) ClaimTeamSync(ctx context.Context, lockedBy string) (*TeamSyncQueueItem, error) {
+	item, err := d.querier.ClaimTeamSync(ctx, lockedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamSyncQueueItem{TeamSyncQueue: item}, nil
+}
+
+func (d *database) DeleteTeamSync(ctx context.Context, id int64) error {
+	return d.querier.DeleteTeamSync(ctx, id)
+}
+
+func (d *database) ReleaseTeamSync(ctx context.Context, id int64) error {
+	return d.querier.ReleaseTeamSync(ctx, id)
+}
+
+func (d *database) ReleaseStaleTeamSyncs(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	return d.querier.ReleaseStaleTeamSyncs(ctx, lockedBefore)
+}
+
+func (d *database) GetPendingTeamSyncCount(ctx context.Context) (int64, error) {
+	return d.querier.GetPendingTeamSyncCount(ctx)
+}
+
+func (d *database) NotifyTeamSyncProgress(ctx context.Context, payload []byte) error {
+	return d.querier.Notify(ctx, sqlc.NotifyParams{
+		Channel: teamSyncProgressChannel,
+		Payload: string(payload),
+	})
+}
+
+func (d *database) ListenTeamSyncProgress(ctx context.Context, fn func(payload []byte)) error {
+	return d.querier.Listen(ctx, teamSyncProgressChannel, func(payload string) {
+		fn([]byte(payload))
+	})
+}
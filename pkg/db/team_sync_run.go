@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+func (d *database) CreateTeamSyncRun(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName) (*TeamSyncRun, error) {
+	names := make([]string, 0, len(reconcilers))
+	for _, reconciler := range reconcilers {
+		names = append(names, string(reconciler))
+	}
+
+	run, err := d.querier.CreateTeamSyncRun(ctx, sqlc.CreateTeamSyncRunParams{
+		TeamSlug:      teamSlug,
+		CorrelationID: correlationID,
+		TriggeredBy:   trigger,
+		Reconcilers:   names,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamSyncRun{TeamSyncRun: run}, nil
+}
+
+// FinishTeamSyncRun Store the status of a team sync run along with the results of the reconcilers that were part of
+// the run
+func (d *database) FinishTeamSyncRun(ctx context.Context, id int64, status sqlc.TeamSyncRunStatus, errorMessage *string, results []*TeamSyncReconcilerResult) (*TeamSyncRun, error) {
+	var run *sqlc.TeamSyncRun
+	err := d.querier.Transaction(ctx, func(ctx context.Context, querier Querier) error {
+		for _, result := range results {
+			err := querier.CreateTeamSyncRunReconciler(ctx, sqlc.CreateTeamSyncRunReconcilerParams{
+				RunID:        id,
+				Reconciler:   result.Reconciler,
+				Status:       result.Status,
+				StartedAt:    result.StartedAt,
+				FinishedAt:   result.FinishedAt,
+				ErrorMessage: result.ErrorMessage,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		run, err = querier.FinishTeamSyncRun(ctx, sqlc.FinishTeamSyncRunParams{
+			ID:           id,
+			Status:       status,
+			ErrorMessage: errorMessage,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamSyncRun{TeamSyncRun: run}, nil
+}
+
+func (d *database) GetTeamSyncRuns(ctx context.Context, teamSlug slug.Slug, after *TeamSyncRunCursor, limit int) ([]*TeamSyncRun, error) {
+	params := sqlc.GetTeamSyncRunsParams{
+		TeamSlug:  teamSlug,
+		LimitRows: int32(limit),
+	}
+
+	if after != nil {
+		params.CursorStartedAt = &after.StartedAt
+		params.CursorID = &after.ID
+	}
+
+	rows, err := d.querier.GetTeamSyncRuns(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*TeamSyncRun, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, &TeamSyncRun{TeamSyncRun: row})
+	}
+
+	return runs, nil
+}
+
+func (d *database) GetTeamSyncRunReconcilers(ctx context.Context, runID int64) ([]*TeamSyncRunReconciler, error) {
+	rows, err := d.querier.GetTeamSyncRunReconcilers(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	reconcilers := make([]*TeamSyncRunReconciler, 0, len(rows))
+	for _, row := range rows {
+		reconcilers = append(reconcilers, &TeamSyncRunReconciler{TeamSyncRunReconciler: row})
+	}
+
+	return reconcilers, nil
+}
+
+func (d *database) DeleteOldTeamSyncRuns(ctx context.Context, teamSlug slug.Slug, runsToKeep int) (int64, error) {
+	return d.querier.DeleteOldTeamSyncRuns(ctx, sqlc.DeleteOldTeamSyncRunsParams{
+		TeamSlug:   teamSlug,
+		RunsToKeep: int32(runsToKeep),
+	})
+}
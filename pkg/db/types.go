@@ -31,6 +31,62 @@ type AuditLog struct {
 	*sqlc.AuditLog
 }
 
+// AuditLogChange A structured change of a single field, stored with an audit log entry. The values are encoded as JSON.
+type AuditLogChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditLogFilter Filters used when listing audit log entries. Fields that are nil are not used for filtering.
+type AuditLogFilter struct {
+	Action           *types.AuditAction
+	ComponentName    *types.ComponentName
+	Actor            *string
+	TargetType       *types.AuditLogsTargetType
+	TargetIdentifier *string
+	CorrelationID    *uuid.UUID
+	CreatedAfter     *time.Time
+	CreatedBefore    *time.Time
+}
+
+// ExpiredAuditLogsFilter Selects audit log entries that have expired according to a retention rule
+type ExpiredAuditLogsFilter struct {
+	CreatedBefore      time.Time
+	Action             *types.AuditAction
+	ComponentName      *types.ComponentName
+	ExcludedActions    []types.AuditAction
+	ExcludedComponents []types.ComponentName
+}
+
+type AuditLogPrunedLink struct {
+	*sqlc.AuditLogPrunedLink
+}
+
+type AuditLogOutboxEntry struct {
+	*sqlc.AuditLogOutbox
+}
+
+type WebhookSubscription struct {
+	*sqlc.WebhookSubscription
+}
+
+type WebhookDelivery struct {
+	*sqlc.WebhookDelivery
+}
+
+// AuditLogCursor The position of an audit log entry when listing entries, newest first
+type AuditLogCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// TeamSyncRunCursor The position of a team sync run when listing runs, newest first
+type TeamSyncRunCursor struct {
+	StartedAt time.Time
+	ID        int64
+}
+
 type Reconciler struct {
 	*sqlc.Reconciler
 }
@@ -39,6 +95,10 @@ type TeamDeleteKey struct {
 	*sqlc.TeamDeleteKey
 }
 
+type LeaderLease struct {
+	*sqlc.LeaderLease
+}
+
 type ReconcilerConfig struct {
 	*sqlc.GetReconcilerConfigRow
 }
@@ -64,15 +124,30 @@ type UserRole struct {
 
 type Role struct {
 	Authorizations         []roles.Authorization
-	RoleName               sqlc.RoleName
+	RoleName               roles.RoleName
 	TargetServiceAccountID *uuid.UUID
 	TargetTeamSlug         *slug.Slug
+	GrantedBy              *string
+	GrantedAt              *time.Time
+
+	// DescendantTeamSlugs Sub-teams of the target team that the role also applies to. Only set for team owner roles.
+	DescendantTeamSlugs []slug.Slug
+}
+
+// RoleDefinition A role and its description. Built-in roles are created by the database migrations and can not be
+// changed.
+type RoleDefinition struct {
+	*sqlc.Role
 }
 
 type ServiceAccount struct {
 	*sqlc.ServiceAccount
 }
 
+type ApiKey struct {
+	*sqlc.ApiKey
+}
+
 type Session struct {
 	*sqlc.Session
 }
@@ -81,13 +156,47 @@ type Team struct {
 	*sqlc.Team
 }
 
+type TeamDeletion struct {
+	*sqlc.TeamDeletion
+}
+
+type TeamDeletionReconciler struct {
+	*sqlc.TeamDeletionReconciler
+}
+
+type TeamSyncQueueItem struct {
+	*sqlc.TeamSyncQueue
+}
+
+type TeamSyncRun struct {
+	*sqlc.TeamSyncRun
+}
+
+type TeamSyncRunReconciler struct {
+	*sqlc.TeamSyncRunReconciler
+}
+
+// TeamSyncReconcilerResult The outcome of a single reconciler in a team sync run
+type TeamSyncReconcilerResult struct {
+	Reconciler   sqlc.ReconcilerName
+	Status       sqlc.TeamSyncReconcilerStatus
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	ErrorMessage *string
+}
+
 type User struct {
 	*sqlc.User
 }
 
+type UserSyncRun struct {
+	*sqlc.UserSyncRun
+}
+
 type Querier interface {
 	sqlc.Querier
 	Transaction(ctx context.Context, callback QuerierTransactionFunc) error
+	Listen(ctx context.Context, channel string, fn func(payload string)) error
 }
 
 type Queries struct {
@@ -97,15 +206,21 @@ type Queries struct {
 
 type database struct {
 	querier Querier
+
+	// auditLogOutbox Add new audit log entries to the outbox, so that they are delivered to the audit log sinks
+	auditLogOutbox bool
 }
 
 type Database interface {
 	CreateRepositoryAuthorization(ctx context.Context, teamSlug slug.Slug, repoName string, authorization sqlc.RepositoryAuthorizationEnum) error
 	RemoveRepositoryAuthorization(ctx context.Context, teamSlug slug.Slug, repoName string, authorization sqlc.RepositoryAuthorizationEnum) error
-	CreateAuditLogEntry(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string) error
+	CreateAuditLogEntry(ctx context.Context, correlationID uuid.UUID, componentName types.ComponentName, actor *string, targetType types.AuditLogsTargetType, targetIdentifier string, action types.AuditAction, message string, changes []*AuditLogChange) error
 	CreateUser(ctx context.Context, name, email, externalID string) (*User, error)
-	CreateServiceAccount(ctx context.Context, name string) (*ServiceAccount, error)
+	CreateServiceAccount(ctx context.Context, name string, teamSlug *slug.Slug) (*ServiceAccount, error)
+	GetTeamServiceAccounts(ctx context.Context, teamSlug slug.Slug) ([]*ServiceAccount, error)
 	GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error)
+	GetServiceAccountByID(ctx context.Context, serviceAccountID uuid.UUID) (*ServiceAccount, error)
+	UpdateServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, name string) (*ServiceAccount, error)
 	GetUserByID(ctx context.Context, ID uuid.UUID) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetServiceAccountByApiKey(ctx context.Context, APIKey string) (*ServiceAccount, error)
@@ -118,16 +233,25 @@ type Database interface {
 	GetTeamBySlug(ctx context.Context, slug slug.Slug) (*Team, error)
 	GetActiveTeams(ctx context.Context) ([]*Team, error)
 	GetTeams(ctx context.Context) ([]*Team, error)
+	SetTeamParent(ctx context.Context, teamSlug slug.Slug, parentTeamSlug *slug.Slug) (*Team, error)
+	GetTeamChildren(ctx context.Context, teamSlug slug.Slug) ([]*Team, error)
+	GetTeamDescendantSlugs(ctx context.Context, teamSlug slug.Slug) ([]slug.Slug, error)
+	DisableTeam(ctx context.Context, teamSlug slug.Slug, suspendResources bool) (*Team, error)
+	EnableTeam(ctx context.Context, teamSlug slug.Slug) (*Team, error)
 	GetTeamMembers(ctx context.Context, teamSlug slug.Slug) ([]*User, error)
 	GetTeamMember(ctx context.Context, teamSlug slug.Slug, userID uuid.UUID) (*User, error)
 	UserIsTeamOwner(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) (bool, error)
-	SetTeamMemberRole(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role sqlc.RoleName) error
+	SetTeamMemberRole(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug, role roles.RoleName) error
 	GetAuditLogsForTeam(ctx context.Context, slug slug.Slug) ([]*AuditLog, error)
-	AssignGlobalRoleToUser(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName) error
-	AssignGlobalRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName) error
-	AssignTeamRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName sqlc.RoleName, teamSlug slug.Slug) error
+	AssignGlobalRoleToUser(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, grantedBy *string) error
+	AssignGlobalRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, grantedBy *string) error
+	AssignTeamRoleToServiceAccount(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName, teamSlug slug.Slug) error
+	AssignServiceAccountRoleToUser(ctx context.Context, userID uuid.UUID, roleName roles.RoleName, serviceAccountID uuid.UUID) error
 	RemoveUserFromTeam(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) error
-	CreateAPIKey(ctx context.Context, apiKey string, serviceAccountID uuid.UUID) error
+	CreateAPIKey(ctx context.Context, apiKey string, serviceAccountID uuid.UUID, expiresAt *time.Time) (*ApiKey, error)
+	GetAPIKeyByID(ctx context.Context, apiKeyID uuid.UUID) (*ApiKey, error)
+	GetServiceAccountAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]*ApiKey, error)
+	RevokeAPIKey(ctx context.Context, apiKeyID uuid.UUID) error
 	RemoveAllServiceAccountRoles(ctx context.Context, serviceAccountID uuid.UUID) error
 	RemoveApiKeysFromServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*Role, error)
@@ -138,7 +262,9 @@ type Database interface {
 	SetReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, slug slug.Slug, state interface{}) error
 	RemoveReconcilerStateForTeam(ctx context.Context, reconcilerName sqlc.ReconcilerName, slug slug.Slug) error
 	UpdateUser(ctx context.Context, userID uuid.UUID, name, email, externalID string) (*User, error)
-	SetReconcilerErrorForTeam(ctx context.Context, correlationID uuid.UUID, slug slug.Slug, reconcilerName sqlc.ReconcilerName, err error) error
+	SetReconcilerErrorForTeam(ctx context.Context, correlationID uuid.UUID, slug slug.Slug, reconcilerName sqlc.ReconcilerName, err error, kind sqlc.ReconcilerErrorKind) (*ReconcilerError, error)
+	SetReconcilerSkippedForTeam(ctx context.Context, correlationID uuid.UUID, slug slug.Slug, reconcilerName sqlc.ReconcilerName, err error) error
+	SetReconcilerErrorNextRetry(ctx context.Context, slug slug.Slug, reconcilerName sqlc.ReconcilerName, nextRetryAt time.Time) error
 	GetTeamReconcilerErrors(ctx context.Context, slug slug.Slug) ([]*ReconcilerError, error)
 	ClearReconcilerErrorsForTeam(ctx context.Context, slug slug.Slug, reconcilerName sqlc.ReconcilerName) error
 	GetServiceAccounts(ctx context.Context) ([]*ServiceAccount, error)
@@ -156,11 +282,23 @@ type Database interface {
 	ResetReconcilerConfig(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error)
 	EnableReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error)
 	DisableReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*Reconciler, error)
+	SetReconcilerTimeout(ctx context.Context, reconcilerName sqlc.ReconcilerName, timeoutSeconds *int32) (*Reconciler, error)
 	DangerousGetReconcilerConfigValues(ctx context.Context, reconcilerName sqlc.ReconcilerName) (*ReconcilerConfigValues, error)
 	GetAuditLogsForReconciler(ctx context.Context, reconcilerName sqlc.ReconcilerName) ([]*AuditLog, error)
 	SetLastSuccessfulSyncForTeam(ctx context.Context, teamSlug slug.Slug) error
-	RevokeGlobalUserRole(ctx context.Context, userID uuid.UUID, roleName sqlc.RoleName) error
-	GetUsersWithGloballyAssignedRole(ctx context.Context, roleName sqlc.RoleName) ([]*User, error)
+	RevokeGlobalUserRole(ctx context.Context, userID uuid.UUID, roleName roles.RoleName) error
+	RevokeGlobalServiceAccountRole(ctx context.Context, serviceAccountID uuid.UUID, roleName roles.RoleName) error
+	LockGlobalAdmins(ctx context.Context) error
+	CountGlobalAdmins(ctx context.Context) (int, error)
+	GetUsersWithGloballyAssignedRole(ctx context.Context, roleName roles.RoleName) ([]*User, error)
+	GetRoleDefinitions(ctx context.Context) ([]*RoleDefinition, error)
+	GetRoleDefinition(ctx context.Context, roleName roles.RoleName) (*RoleDefinition, error)
+	GetRoleAuthorizations(ctx context.Context, roleName roles.RoleName) ([]roles.Authorization, error)
+	GetRolesAuthorizations(ctx context.Context, roleNames []roles.RoleName) (map[roles.RoleName][]roles.Authorization, error)
+	CreateRoleDefinition(ctx context.Context, roleName roles.RoleName, description string) (*RoleDefinition, error)
+	UpdateRoleDefinition(ctx context.Context, roleName roles.RoleName, description string) (*RoleDefinition, error)
+	DeleteRoleDefinition(ctx context.Context, roleName roles.RoleName) error
+	SetRoleAuthorizations(ctx context.Context, roleName roles.RoleName, authorizations []roles.Authorization) error
 	IsFirstRun(ctx context.Context) (bool, error)
 	FirstRunComplete(ctx context.Context) error
 	GetSlackAlertsChannels(ctx context.Context, teamSlug slug.Slug) (map[string]string, error)
@@ -171,12 +309,64 @@ type Database interface {
 	ConfirmTeamDeleteKey(ctx context.Context, key uuid.UUID) error
 	DeleteTeam(ctx context.Context, teamSlug slug.Slug) error
 	GetAuditLogsForCorrelationID(ctx context.Context, correlationID uuid.UUID) ([]*AuditLog, error)
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter, after *AuditLogCursor, limit int) ([]*AuditLog, error)
+	GetAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) ([]*AuditLog, error)
+	GetExpiredAuditLogs(ctx context.Context, filter ExpiredAuditLogsFilter, limit int) ([]*AuditLog, error)
+	DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) (int64, error)
+	GetAuditLogChain(ctx context.Context, afterPosition int64, limit int) ([]*AuditLog, error)
+	GetAuditLogPrunedLinks(ctx context.Context, afterPosition int64, limit int) ([]*AuditLogPrunedLink, error)
+	ClaimAuditLogOutboxEntries(ctx context.Context, lockedBy string, batchSize int) ([]*AuditLogOutboxEntry, error)
+	DeleteAuditLogOutboxEntry(ctx context.Context, id int64) error
+	RetryAuditLogOutboxEntry(ctx context.Context, id int64, deliveredSinks []string, lastError string, nextAttemptAt time.Time) error
+	ReleaseStaleAuditLogOutboxEntries(ctx context.Context, lockedBefore time.Time) (int64, error)
+	GetPendingAuditLogOutboxCount(ctx context.Context) (int64, error)
+	DeleteAuditLogOutboxEntries(ctx context.Context) (int64, error)
 	AddReconcilerOptOut(ctx context.Context, userID *uuid.UUID, teamSlug *slug.Slug, reconcilerName sqlc.ReconcilerName) error
 	RemoveReconcilerOptOut(ctx context.Context, userID *uuid.UUID, teamSlug *slug.Slug, reconcilerName sqlc.ReconcilerName) error
 	GetTeamMembersForReconciler(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName) ([]*User, error)
 	GetTeamMemberOptOuts(ctx context.Context, userID uuid.UUID, teamSlug slug.Slug) ([]*sqlc.GetTeamMemberOptOutsRow, error)
 	GetTeamsWithPermissionInGitHubRepo(ctx context.Context, repoName, permission string) ([]*Team, error)
 	GetRepositoryAuthorizations(ctx context.Context, teamSlug slug.Slug, repo string) ([]sqlc.RepositoryAuthorizationEnum, error)
+	EnqueueTeamSync(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName, runAt time.Time) error
+	ClaimTeamSync(ctx context.Context, lockedBy string) (*TeamSyncQueueItem, error)
+	DeleteTeamSync(ctx context.Context, id int64) error
+	ReleaseTeamSync(ctx context.Context, id int64) error
+	ReleaseStaleTeamSyncs(ctx context.Context, lockedBefore time.Time) (int64, error)
+	GetPendingTeamSyncCount(ctx context.Context) (int64, error)
+	NotifyTeamSyncProgress(ctx context.Context, payload []byte) error
+	ListenTeamSyncProgress(ctx context.Context, fn func(payload []byte)) error
+	CreateTeamSyncRun(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger, reconcilers []sqlc.ReconcilerName) (*TeamSyncRun, error)
+	FinishTeamSyncRun(ctx context.Context, id int64, status sqlc.TeamSyncRunStatus, errorMessage *string, results []*TeamSyncReconcilerResult) (*TeamSyncRun, error)
+	GetTeamSyncRuns(ctx context.Context, teamSlug slug.Slug, after *TeamSyncRunCursor, limit int) ([]*TeamSyncRun, error)
+	GetTeamSyncRunReconcilers(ctx context.Context, runID int64) ([]*TeamSyncRunReconciler, error)
+	DeleteOldTeamSyncRuns(ctx context.Context, teamSlug slug.Slug, runsToKeep int) (int64, error)
+	ScheduleTeamDeletion(ctx context.Context, deleteKey *TeamDeleteKey, correlationID uuid.UUID, deleteAt time.Time) (*TeamDeletion, error)
+	CancelTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*Team, error)
+	GetTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*TeamDeletion, error)
+	StartDueTeamDeletions(ctx context.Context) ([]*TeamDeletion, error)
+	ResetTeamDeletion(ctx context.Context, teamSlug slug.Slug, deleteAt time.Time) error
+	ReleaseStaleTeamDeletions(ctx context.Context, startedBefore time.Time) (int64, error)
+	SetTeamDeletionReconcilerResult(ctx context.Context, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName, status sqlc.TeamDeletionReconcilerStatus, errorMessage *string) error
+	GetTeamDeletionReconcilers(ctx context.Context, teamSlug slug.Slug) ([]*TeamDeletionReconciler, error)
+	AcquireLeaderLease(ctx context.Context, name, holder string, duration time.Duration) (*LeaderLease, error)
+	ReleaseLeaderLease(ctx context.Context, name, holder string) error
+	CreateUserSyncRun(ctx context.Context, correlationID uuid.UUID) (*UserSyncRun, error)
+	FinishUserSyncRun(ctx context.Context, id int64, status sqlc.UserSyncRunStatus, errorMessage *string, createdUsers, updatedUsers, deletedUsers int) (*UserSyncRun, error)
+	GetUserSyncRuns(ctx context.Context, offset, limit int) ([]*UserSyncRun, error)
+	DeleteOldUserSyncRuns(ctx context.Context, runsToKeep int) (int64, error)
+	CreateWebhookSubscription(ctx context.Context, url, secret string, eventTypes []sqlc.WebhookEventType) (*WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, id uuid.UUID, url *string, eventTypes []sqlc.WebhookEventType, enabled *bool) (*WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error)
+	GetWebhookSubscriptions(ctx context.Context) ([]*WebhookSubscription, error)
+	GetWebhookSubscriptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]*WebhookSubscription, error)
+	CreateWebhookDeliveries(ctx context.Context, eventID uuid.UUID, eventType sqlc.WebhookEventType, payload []byte) (int64, error)
+	ClaimWebhookDeliveries(ctx context.Context, lockedBy string, batchSize int) ([]*WebhookDelivery, error)
+	SetWebhookDeliveryResult(ctx context.Context, id uuid.UUID, status sqlc.WebhookDeliveryStatus, statusCode *int32, lastError *string, nextAttemptAt time.Time) error
+	ReleaseStaleWebhookDeliveries(ctx context.Context, lockedBefore time.Time) (int64, error)
+	DeleteWebhookDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error)
+	GetWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, offset, limit int) ([]*WebhookDelivery, error)
+	GetPendingWebhookDeliveryCount(ctx context.Context) (int64, error)
 }
 
 func (u User) GetID() uuid.UUID {
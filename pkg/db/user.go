@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/sqlc"
 )
 
@@ -93,14 +94,24 @@ func (d *database) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*Role,
 		return nil, err
 	}
 
-	roles := make([]*Role, 0, len(userRoles))
+	roleNames := make([]roles.RoleName, 0, len(userRoles))
 	for _, userRole := range userRoles {
-		role, err := d.roleFromRoleBinding(ctx, userRole.RoleName, userRole.TargetServiceAccountID, userRole.TargetTeamSlug)
-		if err != nil {
-			return nil, err
-		}
-		roles = append(roles, role)
+		roleNames = append(roleNames, userRole.RoleName)
 	}
 
-	return roles, nil
+	authorizations, err := d.GetRolesAuthorizations(ctx, roleNames)
+	if err != nil {
+		return nil, err
+	}
+
+	roleBindings := make([]*Role, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		roleBindings = append(roleBindings, roleFromRoleBinding(authorizations, userRole.RoleName, userRole.TargetServiceAccountID, userRole.TargetTeamSlug, userRole.GrantedBy, userRole.GrantedAt))
+	}
+
+	if err := d.withDescendantTeams(ctx, roleBindings); err != nil {
+		return nil, err
+	}
+
+	return roleBindings, nil
 }
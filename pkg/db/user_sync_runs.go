@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+func (d *database) CreateUserSyncRun(ctx context.Context, correlationID uuid.UUID) (*UserSyncRun, error) {
+	run, err := d.querier.CreateUserSyncRun(ctx, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserSyncRun{UserSyncRun: run}, nil
+}
+
+func (d *database) FinishUserSyncRun(ctx context.Context, id int64, status sqlc.UserSyncRunStatus, errorMessage *string, createdUsers, updatedUsers, deletedUsers int) (*UserSyncRun, error) {
+	run, err := d.querier.FinishUserSyncRun(ctx, sqlc.FinishUserSyncRunParams{
+		ID:           id,
+		Status:       status,
+		ErrorMessage: errorMessage,
+		CreatedUsers: int32(createdUsers),
+		UpdatedUsers: int32(updatedUsers),
+		DeletedUsers: int32(deletedUsers),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserSyncRun{UserSyncRun: run}, nil
+}
+
+func (d *database) GetUserSyncRuns(ctx context.Context, offset, limit int) ([]*UserSyncRun, error) {
+	rows, err := d.querier.GetUserSyncRuns(ctx, sqlc.GetUserSyncRunsParams{
+		OffsetRows: int32(offset),
+		LimitRows:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*UserSyncRun, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, &UserSyncRun{UserSyncRun: row})
+	}
+
+	return runs, nil
+}
+
+func (d *database) DeleteOldUserSyncRuns(ctx context.Context, runsToKeep int) (int64, error) {
+	return d.querier.DeleteOldUserSyncRuns(ctx, int32(runsToKeep))
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+func (d *database) CreateWebhookSubscription(ctx context.Context, url, secret string, eventTypes []sqlc.WebhookEventType) (*WebhookSubscription, error) {
+	subscription, err := d.querier.CreateWebhookSubscription(ctx, sqlc.CreateWebhookSubscriptionParams{
+		Url:        url,
+		Secret:     secret,
+		EventTypes: webhookEventTypesToStrings(eventTypes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookSubscription{WebhookSubscription: subscription}, nil
+}
+
+func (d *database) UpdateWebhookSubscription(ctx context.Context, id uuid.UUID, url *string, eventTypes []sqlc.WebhookEventType, enabled *bool) (*WebhookSubscription, error) {
+	params := sqlc.UpdateWebhookSubscriptionParams{
+		ID:      id,
+		Url:     url,
+		Enabled: enabled,
+	}
+
+	// a nil slice is sent as NULL, which keeps the existing event types
+	if eventTypes != nil {
+		params.EventTypes = webhookEventTypesToStrings(eventTypes)
+	}
+
+	subscription, err := d.querier.UpdateWebhookSubscription(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookSubscription{WebhookSubscription: subscription}, nil
+}
+
+func (d *database) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	return d.querier.DeleteWebhookSubscription(ctx, id)
+}
+
+func (d *database) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error) {
+	subscription, err := d.querier.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookSubscription{WebhookSubscription: subscription}, nil
+}
+
+func (d *database) GetWebhookSubscriptions(ctx context.Context) ([]*WebhookSubscription, error) {
+	rows, err := d.querier.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookSubscriptionsFromRows(rows), nil
+}
+
+func (d *database) GetWebhookSubscriptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]*WebhookSubscription, error) {
+	rows, err := d.querier.GetWebhookSubscriptionsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookSubscriptionsFromRows(rows), nil
+}
+
+func (d *database) CreateWebhookDeliveries(ctx context.Context, eventID uuid.UUID, eventType sqlc.WebhookEventType, payload []byte) (int64, error) {
+	return d.querier.CreateWebhookDeliveries(ctx, sqlc.CreateWebhookDeliveriesParams{
+		EventID:   eventID,
+		EventType: eventType,
+		Payload:   payload,
+	})
+}
+
+func (d *database) ClaimWebhookDeliveries(ctx context.Context, lockedBy string, batchSize int) ([]*WebhookDelivery, error) {
+	rows, err := d.querier.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+		LockedBy:  lockedBy,
+		BatchSize: int32(batchSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookDeliveriesFromRows(rows), nil
+}
+
+func (d *database) SetWebhookDeliveryResult(ctx context.Context, id uuid.UUID, status sqlc.WebhookDeliveryStatus, statusCode *int32, lastError *string, nextAttemptAt time.Time) error {
+	return d.querier.SetWebhookDeliveryResult(ctx, sqlc.SetWebhookDeliveryResultParams{
+		ID:             id,
+		Status:         status,
+		LastStatusCode: statusCode,
+		LastError:      lastError,
+		NextAttemptAt:  nextAttemptAt,
+	})
+}
+
+func (d *database) ReleaseStaleWebhookDeliveries(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	return d.querier.ReleaseStaleWebhookDeliveries(ctx, lockedBefore)
+}
+
+func (d *database) DeleteWebhookDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	return d.querier.DeleteWebhookDeliveriesBefore(ctx, createdBefore)
+}
+
+func (d *database) GetWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, offset, limit int) ([]*WebhookDelivery, error) {
+	rows, err := d.querier.GetWebhookDeliveries(ctx, sqlc.GetWebhookDeliveriesParams{
+		SubscriptionID: subscriptionID,
+		OffsetRows:     int32(offset),
+		LimitRows:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookDeliveriesFromRows(rows), nil
+}
+
+func (d *database) GetPendingWebhookDeliveryCount(ctx context.Context) (int64, error) {
+	return d.querier.GetPendingWebhookDeliveryCount(ctx)
+}
+
+// GetEventTypes Get the event types of the subscription
+func (s *WebhookSubscription) GetEventTypes() []sqlc.WebhookEventType {
+	eventTypes := make([]sqlc.WebhookEventType, len(s.EventTypes))
+	for i, eventType := range s.EventTypes {
+		eventTypes[i] = sqlc.WebhookEventType(eventType)
+	}
+	return eventTypes
+}
+
+func webhookEventTypesToStrings(eventTypes []sqlc.WebhookEventType) []string {
+	ret := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		ret[i] = string(eventType)
+	}
+	return ret
+}
+
+func webhookSubscriptionsFromRows(rows []*sqlc.WebhookSubscription) []*WebhookSubscription {
+	subscriptions := make([]*WebhookSubscription, len(rows))
+	for i, row := range rows {
+		subscriptions[i] = &WebhookSubscription{WebhookSubscription: row}
+	}
+	return subscriptions
+}
+
+func webhookDeliveriesFromRows(rows []*sqlc.WebhookDelivery) []*WebhookDelivery {
+	deliveries := make([]*WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = &WebhookDelivery{WebhookDelivery: row}
+	}
+	return deliveries
+}
@@ -3,10 +3,9 @@ package directives
 import (
 	"context"
 
-	"github.com/nais/teams-backend/pkg/sqlc"
-
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/nais/teams-backend/pkg/authz"
+	"github.com/nais/teams-backend/pkg/roles"
 )
 
 // Admin Require a user with the admin role to allow the request
@@ -17,7 +16,7 @@ func Admin() DirectiveFunc {
 			return nil, authz.ErrNotAuthenticated
 		}
 
-		err := authz.RequireRole(actor, sqlc.RoleNameAdmin)
+		err := authz.RequireRole(actor, roles.RoleNameAdmin)
 		if err != nil {
 			return nil, err
 		}
@@ -4,10 +4,9 @@ import (
 	"context"
 	"testing"
 
-	"github.com/nais/teams-backend/pkg/sqlc"
-
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/roles"
 
 	"github.com/nais/teams-backend/pkg/directives"
 	"github.com/stretchr/testify/assert"
@@ -30,7 +29,7 @@ func TestAdmin(t *testing.T) {
 			panic("Should not be executed")
 		}
 		user := &db.User{}
-		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{{RoleName: sqlc.RoleNameTeamcreator}})
+		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{{RoleName: roles.RoleNameTeamcreator}})
 		_, err := directives.Admin()(ctx, obj, nextHandler)
 		assert.EqualError(t, err, "required role: \"Admin\"")
 	})
@@ -40,7 +39,7 @@ func TestAdmin(t *testing.T) {
 			return "executed", nil
 		}
 		user := &db.User{}
-		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{{RoleName: sqlc.RoleNameAdmin}})
+		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{{RoleName: roles.RoleNameAdmin}})
 		result, err := directives.Admin()(ctx, obj, nextHandler)
 		assert.NoError(t, err)
 		assert.Equal(t, "executed", result)
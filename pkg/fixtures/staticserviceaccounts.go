@@ -3,11 +3,13 @@ package fixtures
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/db"
-	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/roles"
 )
 
 type ServiceAccount struct {
@@ -17,7 +19,7 @@ type ServiceAccount struct {
 }
 
 type ServiceAccountRole struct {
-	Name sqlc.RoleName `json:"name"`
+	Name roles.RoleName `json:"name"`
 }
 
 const NaisServiceAccountPrefix = "nais-"
@@ -47,12 +49,6 @@ func (s *ServiceAccounts) Decode(value string) error {
 		if serviceAccount.APIKey == "" {
 			return fmt.Errorf("service account is missing an API key: %q", serviceAccount.Name)
 		}
-
-		for _, role := range serviceAccount.Roles {
-			if !role.Name.Valid() {
-				return fmt.Errorf("invalid role name: %q for service account %q", role.Name, serviceAccount.Name)
-			}
-		}
 	}
 
 	*s = serviceAccounts
@@ -67,7 +63,7 @@ func SetupStaticServiceAccounts(ctx context.Context, database db.Database, servi
 			serviceAccountNames[serviceAccountFromInput.Name] = struct{}{}
 			serviceAccount, err := dbtx.GetServiceAccountByName(ctx, serviceAccountFromInput.Name)
 			if err != nil {
-				serviceAccount, err = dbtx.CreateServiceAccount(ctx, serviceAccountFromInput.Name)
+				serviceAccount, err = dbtx.CreateServiceAccount(ctx, serviceAccountFromInput.Name, nil)
 				if err != nil {
 					return err
 				}
@@ -88,13 +84,21 @@ func SetupStaticServiceAccounts(ctx context.Context, database db.Database, servi
 					continue
 				}
 
-				err = dbtx.AssignGlobalRoleToServiceAccount(ctx, serviceAccount.ID, role.Name)
+				// roles are stored in the database, so role names can only be validated once connected
+				_, err = dbtx.GetRoleDefinition(ctx, role.Name)
+				if errors.Is(err, pgx.ErrNoRows) {
+					return fmt.Errorf("invalid role name: %q for service account %q", role.Name, serviceAccountFromInput.Name)
+				} else if err != nil {
+					return fmt.Errorf("get role %q for service account %q: %w", role.Name, serviceAccountFromInput.Name, err)
+				}
+
+				err = dbtx.AssignGlobalRoleToServiceAccount(ctx, serviceAccount.ID, role.Name, nil)
 				if err != nil {
 					return err
 				}
 			}
 
-			err = dbtx.CreateAPIKey(ctx, serviceAccountFromInput.APIKey, serviceAccount.ID)
+			_, err = dbtx.CreateAPIKey(ctx, serviceAccountFromInput.APIKey, serviceAccount.ID, nil)
 			if err != nil {
 				return err
 			}
@@ -124,7 +128,7 @@ func SetupStaticServiceAccounts(ctx context.Context, database db.Database, servi
 	})
 }
 
-func hasGlobalRoleRole(roleName sqlc.RoleName, existingRoles []*db.Role) bool {
+func hasGlobalRoleRole(roleName roles.RoleName, existingRoles []*db.Role) bool {
 	for _, r := range existingRoles {
 		if r.TargetTeamSlug != nil {
 			continue
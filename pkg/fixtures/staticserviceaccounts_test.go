@@ -3,11 +3,16 @@ package fixtures_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/fixtures"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -58,13 +63,87 @@ func TestSetupStaticServiceAccounts(t *testing.T) {
 	})
 
 	t.Run("service account with invalid role", func(t *testing.T) {
-		err := serviceAccounts.Decode(`[{
+		ctx := context.Background()
+		txCtx := context.Background()
+		database := db.NewMockDatabase(t)
+		dbtx := db.NewMockDatabase(t)
+		sa := serviceAccountWithName("nais-service-account")
+
+		database.
+			On("Transaction", ctx, mock.AnythingOfType("db.DatabaseTransactionFunc")).
+			Return(func(_ context.Context, fn db.DatabaseTransactionFunc) error {
+				return fn(txCtx, dbtx)
+			}).
+			Once()
+		dbtx.
+			On("GetServiceAccountByName", txCtx, sa.Name).
+			Return(sa, nil).
+			Once()
+		dbtx.
+			On("RemoveApiKeysFromServiceAccount", txCtx, sa.ID).
+			Return(nil).
+			Once()
+		dbtx.
+			On("GetServiceAccountRoles", txCtx, sa.ID).
+			Return(nil, nil).
+			Once()
+		dbtx.
+			On("GetRoleDefinition", txCtx, roles.RoleName("role")).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		invalid := make(fixtures.ServiceAccounts, 0)
+		err := invalid.Decode(`[{
 			"name": "nais-service-account",
 			"apiKey": "some key",
 			"roles": [{"name":"role"}]
 		}]`)
+		assert.NoError(t, err)
+
+		err = fixtures.SetupStaticServiceAccounts(ctx, database, invalid)
 		assert.EqualError(t, err, `invalid role name: "role" for service account "nais-service-account"`)
-		assert.Len(t, serviceAccounts, 0)
+	})
+
+	t.Run("unable to get role", func(t *testing.T) {
+		ctx := context.Background()
+		txCtx := context.Background()
+		database := db.NewMockDatabase(t)
+		dbtx := db.NewMockDatabase(t)
+		sa := serviceAccountWithName("nais-service-account")
+
+		database.
+			On("Transaction", ctx, mock.AnythingOfType("db.DatabaseTransactionFunc")).
+			Return(func(_ context.Context, fn db.DatabaseTransactionFunc) error {
+				return fn(txCtx, dbtx)
+			}).
+			Once()
+		dbtx.
+			On("GetServiceAccountByName", txCtx, sa.Name).
+			Return(sa, nil).
+			Once()
+		dbtx.
+			On("RemoveApiKeysFromServiceAccount", txCtx, sa.ID).
+			Return(nil).
+			Once()
+		dbtx.
+			On("GetServiceAccountRoles", txCtx, sa.ID).
+			Return(nil, nil).
+			Once()
+		dbtx.
+			On("GetRoleDefinition", txCtx, roles.RoleName("role")).
+			Return(nil, fmt.Errorf("some error")).
+			Once()
+
+		invalid := make(fixtures.ServiceAccounts, 0)
+		err := invalid.Decode(`[{
+			"name": "nais-service-account",
+			"apiKey": "some key",
+			"roles": [{"name":"role"}]
+		}]`)
+		assert.NoError(t, err)
+
+		err = fixtures.SetupStaticServiceAccounts(ctx, database, invalid)
+		assert.EqualError(t, err, `get role "role" for service account "nais-service-account": some error`)
 	})
 
 	t.Run("create multiple service accounts and delete old one", func(t *testing.T) {
@@ -92,7 +171,7 @@ func TestSetupStaticServiceAccounts(t *testing.T) {
 			Return(nil, errors.New("service account not found")).
 			Once()
 		dbtx.
-			On("CreateServiceAccount", txCtx, "nais-service-account-1").
+			On("CreateServiceAccount", txCtx, "nais-service-account-1", (*slug.Slug)(nil)).
 			Return(sa1, nil).
 			Once()
 		dbtx.
@@ -104,17 +183,25 @@ func TestSetupStaticServiceAccounts(t *testing.T) {
 			Return(nil, nil).
 			Once()
 		dbtx.
-			On("AssignGlobalRoleToServiceAccount", txCtx, sa1.ID, sqlc.RoleNameTeamcreator).
-			Return(nil).
+			On("GetRoleDefinition", txCtx, roles.RoleNameTeamcreator).
+			Return(&db.RoleDefinition{}, nil).
 			Once()
 		dbtx.
-			On("AssignGlobalRoleToServiceAccount", txCtx, sa1.ID, sqlc.RoleNameTeamviewer).
+			On("AssignGlobalRoleToServiceAccount", txCtx, sa1.ID, roles.RoleNameTeamcreator, (*string)(nil)).
 			Return(nil).
 			Once()
 		dbtx.
-			On("CreateAPIKey", txCtx, "key-1", sa1.ID).
+			On("GetRoleDefinition", txCtx, roles.RoleNameTeamviewer).
+			Return(&db.RoleDefinition{}, nil).
+			Once()
+		dbtx.
+			On("AssignGlobalRoleToServiceAccount", txCtx, sa1.ID, roles.RoleNameTeamviewer, (*string)(nil)).
 			Return(nil).
 			Once()
+		dbtx.
+			On("CreateAPIKey", txCtx, "key-1", sa1.ID, (*time.Time)(nil)).
+			Return(&db.ApiKey{}, nil).
+			Once()
 
 		// Second service account, already has the role requested
 		dbtx.
@@ -127,11 +214,11 @@ func TestSetupStaticServiceAccounts(t *testing.T) {
 			Once()
 		dbtx.
 			On("GetServiceAccountRoles", txCtx, sa2.ID).
-			Return([]*db.Role{{RoleName: sqlc.RoleNameAdmin}}, nil).
+			Return([]*db.Role{{RoleName: roles.RoleNameAdmin}}, nil).
 			Once()
 		dbtx.
-			On("CreateAPIKey", txCtx, "key-2", sa2.ID).
-			Return(nil).
+			On("CreateAPIKey", txCtx, "key-2", sa2.ID, (*time.Time)(nil)).
+			Return(&db.ApiKey{}, nil).
 			Once()
 
 		// Delete old service account
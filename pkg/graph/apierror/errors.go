@@ -16,15 +16,32 @@ import (
 )
 
 var (
-	ErrUserNotExists       = Errorf("The user does not exist.")
-	ErrTeamSlug            = Errorf("Your team identifier does not fit our requirements. Team identifiers must contain only lowercase alphanumeric characters or hyphens, contain at least 3 characters and at most 30 characters, start with an alphabetic character, end with an alphanumeric character, and not contain two hyphens in a row.")
-	ErrInternal            = Errorf("The server errored out while processing your request, and we didn't write a suitable error message. You might consider that a bug on our side. Please try again, and if the error persists, contact the NAIS team.")
-	ErrDatabase            = Errorf("The database system encountered an error while processing your request. This is probably a transient error, please try again. If the error persists, contact the NAIS team.")
-	ErrTeamPurpose         = Errorf("You must specify the purpose for your team. This is a human-readable string which is used in external systems, and is important because other people might need to to understand what your team is all about.")
-	ErrTeamNotExist        = Errorf("The team you are referring to does not exist in our database.")
-	ErrTeamPrefixRedundant = Errorf("The name prefix 'team' is redundant. When you create a team, it is by definition a team. Try again with a different name, perhaps just removing the prefix?")
-	ErrTeamSlugReserved    = Errorf("The specified slug is reserved by the platform.")
-	ErrUserIsNotTeamMember = Errorf("The user is not a member of the team.")
+	ErrUserNotExists               = Errorf("The user does not exist.")
+	ErrTeamSlug                    = Errorf("Your team identifier does not fit our requirements. Team identifiers must contain only lowercase alphanumeric characters or hyphens, contain at least 3 characters and at most 30 characters, start with an alphabetic character, end with an alphanumeric character, and not contain two hyphens in a row.")
+	ErrInternal                    = Errorf("The server errored out while processing your request, and we didn't write a suitable error message. You might consider that a bug on our side. Please try again, and if the error persists, contact the NAIS team.")
+	ErrDatabase                    = Errorf("The database system encountered an error while processing your request. This is probably a transient error, please try again. If the error persists, contact the NAIS team.")
+	ErrTeamPurpose                 = Errorf("You must specify the purpose for your team. This is a human-readable string which is used in external systems, and is important because other people might need to to understand what your team is all about.")
+	ErrLastAdmin                   = Errorf("The admin role can not be revoked from the last admin.")
+	ErrRoleBuiltIn                 = Errorf("The role is built-in and can not be changed.")
+	ErrRoleName                    = Errorf("Your role name does not fit our requirements. Role names must contain at least 3 characters and at most 60 characters, and can not start or end with whitespace.")
+	ErrRoleNameTaken               = Errorf("A role with the specified name already exists.")
+	ErrRoleNotExist                = Errorf("The role you are referring to does not exist.")
+	ErrAPIKeyNotExist              = Errorf("The API key you are referring to does not exist.")
+	ErrServiceAccountName          = Errorf("Your service account name does not fit our requirements. Service account names must contain only lowercase alphanumeric characters or hyphens, contain at least 3 characters and at most 40 characters, start with an alphabetic character, end with an alphanumeric character, and not contain two hyphens in a row.")
+	ErrServiceAccountNameReserved  = Errorf("Service account names starting with 'nais-' are reserved by the platform.")
+	ErrServiceAccountNameTaken     = Errorf("A service account with the specified name already exists.")
+	ErrServiceAccountNotExist      = Errorf("The service account you are referring to does not exist.")
+	ErrServiceAccountStatic        = Errorf("The service account is managed by the platform and can not be changed through the API.")
+	ErrTeamDeletionNotScheduled    = Errorf("The team is not scheduled for deletion.")
+	ErrTeamDeletionScheduled       = Errorf("The team is scheduled for deletion. Cancel the deletion with the cancelTeamDeletion mutation first.")
+	ErrTeamDeletionStarted         = Errorf("The deletion of the team has already started, and can no longer be cancelled.")
+	ErrTeamDisabled                = Errorf("The team is disabled and can not be synchronized. Enable the team before trying again.")
+	ErrTeamNotExist                = Errorf("The team you are referring to does not exist in our database.")
+	ErrTeamParentCycle             = Errorf("A team can not be the parent of itself or of one of its parent teams.")
+	ErrTeamPrefixRedundant         = Errorf("The name prefix 'team' is redundant. When you create a team, it is by definition a team. Try again with a different name, perhaps just removing the prefix?")
+	ErrTeamSlugReserved            = Errorf("The specified slug is reserved by the platform.")
+	ErrUserIsNotTeamMember         = Errorf("The user is not a member of the team.")
+	ErrWebhookSubscriptionNotExist = Errorf("The webhook subscription you are referring to does not exist.")
 )
 
 type Error struct {
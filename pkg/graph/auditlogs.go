@@ -7,8 +7,13 @@ package graph
 import (
 	"context"
 
+	"github.com/nais/teams-backend/pkg/auditchain"
+	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/graph/apierror"
 	"github.com/nais/teams-backend/pkg/graph/generated"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/types"
 )
 
@@ -27,6 +32,92 @@ func (r *auditLogResolver) TargetType(ctx context.Context, obj *db.AuditLog) (ty
 	return types.AuditLogsTargetType(obj.TargetType), nil
 }
 
+// Changes is the resolver for the changes field.
+func (r *auditLogResolver) Changes(ctx context.Context, obj *db.AuditLog) ([]*db.AuditLogChange, error) {
+	changes, err := db.GetAuditLogChanges(obj)
+	if err != nil {
+		r.log.WithError(err).Errorf("decode changes of audit log entry %q", obj.ID)
+		return nil, apierror.Errorf("Unable to get the changes of the audit log entry.")
+	}
+
+	return changes, nil
+}
+
+// AuditLogs is the resolver for the auditLogs field.
+func (r *queryResolver) AuditLogs(ctx context.Context, first *int, after *string, filter *db.AuditLogFilter) (*model.AuditLogConnection, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireGlobalAuthorization(actor, roles.AuthorizationAuditLogsRead)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := defaultAuditLogsLimit
+	if first != nil {
+		limit = *first
+	}
+
+	if limit < 1 || limit > maxAuditLogsLimit {
+		return nil, apierror.Errorf("The number of entries must be between 1 and %d.", maxAuditLogsLimit)
+	}
+
+	var cursor *db.AuditLogCursor
+	if after != nil {
+		cursor, err = decodeAuditLogCursor(*after)
+		if err != nil {
+			return nil, apierror.Errorf("Invalid cursor: %q.", *after)
+		}
+	}
+
+	if filter == nil {
+		filter = &db.AuditLogFilter{}
+	}
+
+	// fetch one extra entry to find out if there is a next page
+	entries, err := r.database.GetAuditLogs(ctx, *filter, cursor, limit+1)
+	if err != nil {
+		r.log.WithError(err).Errorf("get audit logs")
+		return nil, apierror.Errorf("Unable to get audit logs.")
+	}
+
+	hasNextPage := len(entries) > limit
+	if hasNextPage {
+		entries = entries[:limit]
+	}
+
+	edges := make([]*model.AuditLogEdge, len(entries))
+	for i, entry := range entries {
+		edges[i] = &model.AuditLogEdge{
+			Cursor: encodeAuditLogCursor(entry),
+			Node:   entry,
+		}
+	}
+
+	pageInfo := &model.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: cursor != nil,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &model.AuditLogConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+// VerifyAuditLogChain is the resolver for the verifyAuditLogChain field.
+func (r *queryResolver) VerifyAuditLogChain(ctx context.Context) (*auditchain.Result, error) {
+	result, err := auditchain.Verify(ctx, r.database)
+	if err != nil {
+		r.log.WithError(err).Errorf("verify audit log chain")
+		return nil, apierror.Errorf("Unable to verify the audit log chain.")
+	}
+
+	return result, nil
+}
+
 // AuditLog returns generated.AuditLogResolver implementation.
 func (r *Resolver) AuditLog() generated.AuditLogResolver { return &auditLogResolver{r} }
 
@@ -0,0 +1,125 @@
+package graph_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/authz"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/deployproxy"
+	"github.com/nais/teams-backend/pkg/graph"
+	"github.com/nais/teams-backend/pkg/graph/generated"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryResolver_AuditLogs(t *testing.T) {
+	ctx := context.Background()
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+
+	user := &db.User{
+		User: &sqlc.User{
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	authorizedCtx := authz.ContextWithActor(ctx, user, []*db.Role{
+		{
+			Authorizations: []roles.Authorization{roles.AuthorizationAuditLogsRead},
+		},
+	})
+
+	newResolver := func(database db.Database) generated.QueryResolver {
+		return graph.
+			NewResolver(nil, database, deployproxy.NewMockProxy(t), "example.com", make(chan<- uuid.UUID), auditlogger.NewMockAuditLogger(t), []string{"env"}, log).
+			Query()
+	}
+
+	t.Run("unauthenticated user", func(t *testing.T) {
+		conn, err := newResolver(db.NewMockDatabase(t)).AuditLogs(ctx, nil, nil, nil)
+		assert.Nil(t, conn)
+		assert.ErrorIs(t, err, authz.ErrNotAuthenticated)
+	})
+
+	t.Run("team role does not give access", func(t *testing.T) {
+		teamSlug := slug.Slug("my-team")
+		ctx := authz.ContextWithActor(ctx, user, []*db.Role{
+			{
+				Authorizations: []roles.Authorization{roles.AuthorizationAuditLogsRead},
+				RoleName:       roles.RoleNameTeamowner,
+				TargetTeamSlug: &teamSlug,
+			},
+		})
+		conn, err := newResolver(db.NewMockDatabase(t)).AuditLogs(ctx, nil, nil, nil)
+		assert.Nil(t, conn)
+		assert.ErrorAs(t, err, &authz.ErrMissingAuthorization{})
+	})
+
+	t.Run("invalid number of entries", func(t *testing.T) {
+		first := 0
+		conn, err := newResolver(db.NewMockDatabase(t)).AuditLogs(authorizedCtx, &first, nil, nil)
+		assert.Nil(t, conn)
+		assert.ErrorContains(t, err, "The number of entries must be between 1 and 100.")
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		after := "not a cursor"
+		conn, err := newResolver(db.NewMockDatabase(t)).AuditLogs(authorizedCtx, nil, &after, nil)
+		assert.Nil(t, conn)
+		assert.ErrorContains(t, err, "Invalid cursor")
+	})
+
+	t.Run("paginate with filter", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		resolver := newResolver(database)
+
+		now := time.Now()
+		entry := func(n int) *db.AuditLog {
+			return &db.AuditLog{AuditLog: &sqlc.AuditLog{
+				ID:        uuid.New(),
+				CreatedAt: now.Add(-time.Duration(n) * time.Minute),
+				Action:    string(types.AuditActionGraphqlApiTeamCreate),
+			}}
+		}
+		entry1, entry2, entry3 := entry(1), entry(2), entry(3)
+
+		action := types.AuditActionGraphqlApiTeamCreate
+		filter := &db.AuditLogFilter{Action: &action}
+
+		first := 2
+		database.
+			On("GetAuditLogs", authorizedCtx, *filter, (*db.AuditLogCursor)(nil), 3).
+			Return([]*db.AuditLog{entry1, entry2, entry3}, nil).
+			Once()
+
+		conn, err := resolver.AuditLogs(authorizedCtx, &first, nil, filter)
+		assert.NoError(t, err)
+		assert.Len(t, conn.Edges, 2)
+		assert.Equal(t, entry1, conn.Edges[0].Node)
+		assert.Equal(t, entry2, conn.Edges[1].Node)
+		assert.True(t, conn.PageInfo.HasNextPage)
+		assert.False(t, conn.PageInfo.HasPreviousPage)
+		assert.Equal(t, conn.Edges[0].Cursor, *conn.PageInfo.StartCursor)
+		assert.Equal(t, conn.Edges[1].Cursor, *conn.PageInfo.EndCursor)
+
+		database.
+			On("GetAuditLogs", authorizedCtx, *filter, &db.AuditLogCursor{CreatedAt: entry2.CreatedAt.UTC(), ID: entry2.ID}, 3).
+			Return([]*db.AuditLog{entry3}, nil).
+			Once()
+
+		conn, err = resolver.AuditLogs(authorizedCtx, &first, conn.PageInfo.EndCursor, filter)
+		assert.NoError(t, err)
+		assert.Len(t, conn.Edges, 1)
+		assert.Equal(t, entry3, conn.Edges[0].Node)
+		assert.False(t, conn.PageInfo.HasNextPage)
+		assert.True(t, conn.PageInfo.HasPreviousPage)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/graph-gophers/dataloader/v7"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/metrics"
+	"github.com/nais/teams-backend/pkg/roles"
 )
 
 type ctxKey string
@@ -18,6 +19,8 @@ type Loaders struct {
 	UsersLoader     *dataloader.Loader[string, *db.User]
 	TeamsLoader     *dataloader.Loader[string, *db.Team]
 	UserRolesLoader *dataloader.Loader[string, []*db.UserRole]
+
+	RoleAuthorizationsLoader *dataloader.Loader[roles.RoleName, []roles.Authorization]
 }
 
 // NewLoaders instantiates data loaders for the middleware
@@ -26,6 +29,7 @@ func NewLoaders(database db.Database) *Loaders {
 	usersReader := &UserReader{db: database}
 	teamsReader := &TeamReader{db: database}
 	userRolesReader := &UserRoleReader{db: database}
+	roleAuthorizationsReader := &RoleAuthorizationReader{db: database}
 
 	loaders := &Loaders{
 		UsersLoader: dataloader.NewBatchedLoader(usersReader.load,
@@ -40,6 +44,10 @@ func NewLoaders(database db.Database) *Loaders {
 			dataloader.WithCache(userRolesReader.newCache()),
 			dataloader.WithInputCapacity[string, []*db.UserRole](5000),
 		),
+		RoleAuthorizationsLoader: dataloader.NewBatchedLoader(roleAuthorizationsReader.load,
+			dataloader.WithCache(roleAuthorizationsReader.newCache()),
+			dataloader.WithInputCapacity[roles.RoleName, []roles.Authorization](500),
+		),
 	}
 
 	return loaders
@@ -60,6 +68,8 @@ func Middleware(loaders *Loaders) func(http.Handler) http.Handler {
 			metrics.IncDataloaderCacheClears(LoaderNameTeams)
 			loaders.UserRolesLoader.ClearAll()
 			metrics.IncDataloaderCacheClears(LoaderNameUserRoles)
+			loaders.RoleAuthorizationsLoader.ClearAll()
+			metrics.IncDataloaderCacheClears(LoaderNameRoleAuthorizations)
 		})
 	}
 }
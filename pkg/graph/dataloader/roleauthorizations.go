@@ -0,0 +1,47 @@
+package dataloader
+
+import (
+	"context"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/metrics"
+	"github.com/nais/teams-backend/pkg/roles"
+)
+
+type RoleAuthorizationReader struct {
+	db db.Database
+}
+
+const LoaderNameRoleAuthorizations = "role_authorizations"
+
+func (r *RoleAuthorizationReader) load(ctx context.Context, keys []roles.RoleName) []*dataloader.Result[[]roles.Authorization] {
+	output := make([]*dataloader.Result[[]roles.Authorization], len(keys))
+
+	authorizations, err := r.db.GetRolesAuthorizations(ctx, keys)
+	for index, roleName := range keys {
+		if err != nil {
+			output[index] = &dataloader.Result[[]roles.Authorization]{Data: nil, Error: err}
+			continue
+		}
+		output[index] = &dataloader.Result[[]roles.Authorization]{Data: authorizations[roleName], Error: nil}
+	}
+
+	metrics.IncDataloaderLoads(LoaderNameRoleAuthorizations)
+	return output
+}
+
+func (r *RoleAuthorizationReader) newCache() dataloader.Cache[roles.RoleName, []roles.Authorization] {
+	return dataloader.NewCache[roles.RoleName, []roles.Authorization]()
+}
+
+func GetRoleAuthorizations(ctx context.Context, roleName roles.RoleName) ([]roles.Authorization, error) {
+	metrics.IncDataloaderCalls(LoaderNameRoleAuthorizations)
+	loaders := For(ctx)
+	thunk := loaders.RoleAuthorizationsLoader.Load(ctx, roleName)
+	result, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -10,10 +10,45 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
 )
 
+// A page of audit log entries.
+type AuditLogConnection struct {
+	// The audit log entries in the page.
+	Edges []*AuditLogEdge `json:"edges"`
+	// Information about the page.
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+// An audit log entry in a page.
+type AuditLogEdge struct {
+	// The cursor of the audit log entry.
+	Cursor string `json:"cursor"`
+	// The audit log entry.
+	Node *db.AuditLog `json:"node"`
+}
+
+// Input for creating a new role.
+type CreateRoleInput struct {
+	// The name of the role. Must be unique.
+	Name roles.RoleName `json:"name"`
+	// Description of the role.
+	Description *string `json:"description,omitempty"`
+	// The authorizations granted by the role.
+	Authorizations []roles.Authorization `json:"authorizations"`
+}
+
+// Input for creating a new service account.
+type CreateServiceAccountInput struct {
+	// The name of the service account. Must be unique, and can not start with the reserved nais- prefix.
+	Name string `json:"name"`
+	// Optional slug of the team owning the service account.
+	TeamSlug *slug.Slug `json:"teamSlug,omitempty"`
+}
+
 // Input for creating a new team.
 type CreateTeamInput struct {
 	// Team slug. After creation, this value can not be changed.
@@ -24,6 +59,38 @@ type CreateTeamInput struct {
 	SlackChannel string `json:"slackChannel"`
 }
 
+// Input for creating a new webhook subscription.
+type CreateWebhookSubscriptionInput struct {
+	// The URL to send the events to. Must be an absolute http or https URL.
+	URL string `json:"url"`
+	// The types of events to send to the URL. At least one type must be set.
+	EventTypes []sqlc.WebhookEventType `json:"eventTypes"`
+}
+
+// A newly created API key.
+type CreatedAPIKey struct {
+	// The created API key.
+	APIKey *db.ApiKey `json:"apiKey"`
+	// The secret of the API key. Used as a bearer token when authenticating as the service account.
+	Secret string `json:"secret"`
+}
+
+// A newly created service account.
+type CreatedServiceAccount struct {
+	// The created service account.
+	ServiceAccount *db.ServiceAccount `json:"serviceAccount"`
+	// The API key of the service account. Used as a bearer token when authenticating as the service account.
+	APIKey string `json:"apiKey"`
+}
+
+// A newly created webhook subscription.
+type CreatedWebhookSubscription struct {
+	// The webhook subscription.
+	Subscription *db.WebhookSubscription `json:"subscription"`
+	// The secret used to sign the deliveries. It is not possible to retrieve the secret later.
+	Secret string `json:"secret"`
+}
+
 // GCP project type.
 type GcpProject struct {
 	// The environment for the project.
@@ -42,6 +109,28 @@ type NaisNamespace struct {
 	Namespace *slug.Slug `json:"namespace"`
 }
 
+// Information about a page in a paginated list, as defined by the Relay cursor connections specification.
+type PageInfo struct {
+	// Whether or not there are more items after the last item in the page.
+	HasNextPage bool `json:"hasNextPage"`
+	// Whether or not there are items before the first item in the page.
+	HasPreviousPage bool `json:"hasPreviousPage"`
+	// The cursor of the first item in the page. Not set when the page is empty.
+	StartCursor *string `json:"startCursor,omitempty"`
+	// The cursor of the last item in the page. Not set when the page is empty. Use this value as the after argument to get the next page.
+	EndCursor *string `json:"endCursor,omitempty"`
+}
+
+// A change a reconciler would make in an external system.
+type PlannedChange struct {
+	// The type of change.
+	Action PlannedChangeAction `json:"action"`
+	// The affected resource in the external system, for instance the name of a group.
+	Resource string `json:"resource"`
+	// Description of the change.
+	Details string `json:"details"`
+}
+
 // Reconciler configuration input.
 type ReconcilerConfigInput struct {
 	// Configuration key.
@@ -50,6 +139,16 @@ type ReconcilerConfigInput struct {
 	Value string `json:"value"`
 }
 
+// The changes a reconciler would make for a team.
+type ReconcilerPlan struct {
+	// The name of the reconciler.
+	Reconciler sqlc.ReconcilerName `json:"reconciler"`
+	// The changes the reconciler would make.
+	Changes []*PlannedChange `json:"changes"`
+	// Error message if the reconciler was unable to plan the changes.
+	Error *string `json:"error,omitempty"`
+}
+
 // Reconciler state type.
 type ReconcilerState struct {
 	// The GitHub team slug.
@@ -92,6 +191,12 @@ type SyncError struct {
 	Reconciler sqlc.ReconcilerName `json:"reconciler"`
 	// Error message.
 	Error string `json:"error"`
+	// The number of consecutive failed attempts for the reconciler.
+	Attempts int `json:"attempts"`
+	// Timestamp of the next scheduled retry of the reconciler. Null when no retry is scheduled.
+	NextRetryAt *time.Time `json:"nextRetryAt,omitempty"`
+	// The kind of failure.
+	Kind ReconcilerErrorKind `json:"kind"`
 }
 
 // Team member.
@@ -122,6 +227,52 @@ type TeamSync struct {
 	CorrelationID *uuid.UUID `json:"correlationID"`
 }
 
+// The progress of a reconciler for a team during a team synchronization.
+type TeamSyncProgressEvent struct {
+	// The correlation ID of the synchronization.
+	CorrelationID *uuid.UUID `json:"correlationID"`
+	// The slug of the synchronized team.
+	TeamSlug *slug.Slug `json:"teamSlug"`
+	// The name of the reconciler.
+	Reconciler sqlc.ReconcilerName `json:"reconciler"`
+	// The state of the reconciler.
+	State TeamSyncProgressState `json:"state"`
+	// The error from the reconciler. Only set when the reconciler failed.
+	Error *string `json:"error,omitempty"`
+	// The time of the event.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// A page of synchronization runs of a team.
+type TeamSyncRunConnection struct {
+	// The synchronization runs in the page.
+	Edges []*TeamSyncRunEdge `json:"edges"`
+	// Information about the page.
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+// A synchronization run in a page.
+type TeamSyncRunEdge struct {
+	// The cursor of the synchronization run.
+	Cursor string `json:"cursor"`
+	// The synchronization run.
+	Node *db.TeamSyncRun `json:"node"`
+}
+
+// Input for updating a role.
+type UpdateRoleInput struct {
+	// Description of the role.
+	Description *string `json:"description,omitempty"`
+	// The authorizations granted by the role. Replaces the current authorizations of the role.
+	Authorizations []roles.Authorization `json:"authorizations,omitempty"`
+}
+
+// Input for updating a service account.
+type UpdateServiceAccountInput struct {
+	// The new name of the service account.
+	Name *string `json:"name,omitempty"`
+}
+
 // Input for updating an existing team.
 type UpdateTeamInput struct {
 	// Specify team purpose to update the existing value.
@@ -132,6 +283,116 @@ type UpdateTeamInput struct {
 	SlackAlertsChannels []*SlackAlertsChannelInput `json:"slackAlertsChannels,omitempty"`
 }
 
+// Input for updating a webhook subscription.
+type UpdateWebhookSubscriptionInput struct {
+	// The URL to send the events to. Must be an absolute http or https URL.
+	URL *string `json:"url,omitempty"`
+	// The types of events to send to the URL. At least one type must be set.
+	EventTypes []sqlc.WebhookEventType `json:"eventTypes,omitempty"`
+	// Whether or not to send events to the URL.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// Types of planned changes.
+type PlannedChangeAction string
+
+const (
+	// The resource will be created.
+	PlannedChangeActionCreate PlannedChangeAction = "CREATE"
+	// The resource will be updated.
+	PlannedChangeActionUpdate PlannedChangeAction = "UPDATE"
+	// The resource will be deleted.
+	PlannedChangeActionDelete PlannedChangeAction = "DELETE"
+	// A member will be added to the resource.
+	PlannedChangeActionAddMember PlannedChangeAction = "ADD_MEMBER"
+	// A member will be removed from the resource.
+	PlannedChangeActionRemoveMember PlannedChangeAction = "REMOVE_MEMBER"
+	// The billing information of the resource will be changed.
+	PlannedChangeActionSetBilling PlannedChangeAction = "SET_BILLING"
+)
+
+var AllPlannedChangeAction = []PlannedChangeAction{
+	PlannedChangeActionCreate,
+	PlannedChangeActionUpdate,
+	PlannedChangeActionDelete,
+	PlannedChangeActionAddMember,
+	PlannedChangeActionRemoveMember,
+	PlannedChangeActionSetBilling,
+}
+
+func (e PlannedChangeAction) IsValid() bool {
+	switch e {
+	case PlannedChangeActionCreate, PlannedChangeActionUpdate, PlannedChangeActionDelete, PlannedChangeActionAddMember, PlannedChangeActionRemoveMember, PlannedChangeActionSetBilling:
+		return true
+	}
+	return false
+}
+
+func (e PlannedChangeAction) String() string {
+	return string(e)
+}
+
+func (e *PlannedChangeAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PlannedChangeAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PlannedChangeAction", str)
+	}
+	return nil
+}
+
+func (e PlannedChangeAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// The kind of failure for a reconciler.
+type ReconcilerErrorKind string
+
+const (
+	// The reconciler returned an error.
+	ReconcilerErrorKindError ReconcilerErrorKind = "ERROR"
+	// The reconciler did not finish within its timeout.
+	ReconcilerErrorKindTimeout ReconcilerErrorKind = "TIMEOUT"
+)
+
+var AllReconcilerErrorKind = []ReconcilerErrorKind{
+	ReconcilerErrorKindError,
+	ReconcilerErrorKindTimeout,
+}
+
+func (e ReconcilerErrorKind) IsValid() bool {
+	switch e {
+	case ReconcilerErrorKindError, ReconcilerErrorKindTimeout:
+		return true
+	}
+	return false
+}
+
+func (e ReconcilerErrorKind) String() string {
+	return string(e)
+}
+
+func (e *ReconcilerErrorKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReconcilerErrorKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReconcilerErrorKind", str)
+	}
+	return nil
+}
+
+func (e ReconcilerErrorKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Repository authorizations.
 type RepositoryAuthorization string
 
@@ -173,6 +434,50 @@ func (e RepositoryAuthorization) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// The status of a reconciler in a team deletion.
+type TeamDeletionReconcilerStatus string
+
+const (
+	// The reconciler deleted the resources of the team.
+	TeamDeletionReconcilerStatusSuccess TeamDeletionReconcilerStatus = "SUCCESS"
+	// The reconciler failed to delete the resources of the team.
+	TeamDeletionReconcilerStatusFailure TeamDeletionReconcilerStatus = "FAILURE"
+)
+
+var AllTeamDeletionReconcilerStatus = []TeamDeletionReconcilerStatus{
+	TeamDeletionReconcilerStatusSuccess,
+	TeamDeletionReconcilerStatusFailure,
+}
+
+func (e TeamDeletionReconcilerStatus) IsValid() bool {
+	switch e {
+	case TeamDeletionReconcilerStatusSuccess, TeamDeletionReconcilerStatusFailure:
+		return true
+	}
+	return false
+}
+
+func (e TeamDeletionReconcilerStatus) String() string {
+	return string(e)
+}
+
+func (e *TeamDeletionReconcilerStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TeamDeletionReconcilerStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TeamDeletionReconcilerStatus", str)
+	}
+	return nil
+}
+
+func (e TeamDeletionReconcilerStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Available team roles.
 type TeamRole string
 
@@ -217,6 +522,206 @@ func (e TeamRole) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// The state of a reconciler during a team synchronization.
+type TeamSyncProgressState string
+
+const (
+	// The reconciler has started.
+	TeamSyncProgressStateStarted TeamSyncProgressState = "STARTED"
+	// The reconciler has finished successfully.
+	TeamSyncProgressStateSucceeded TeamSyncProgressState = "SUCCEEDED"
+	// The reconciler failed, or was skipped because one of its prerequisites failed.
+	TeamSyncProgressStateFailed TeamSyncProgressState = "FAILED"
+)
+
+var AllTeamSyncProgressState = []TeamSyncProgressState{
+	TeamSyncProgressStateStarted,
+	TeamSyncProgressStateSucceeded,
+	TeamSyncProgressStateFailed,
+}
+
+func (e TeamSyncProgressState) IsValid() bool {
+	switch e {
+	case TeamSyncProgressStateStarted, TeamSyncProgressStateSucceeded, TeamSyncProgressStateFailed:
+		return true
+	}
+	return false
+}
+
+func (e TeamSyncProgressState) String() string {
+	return string(e)
+}
+
+func (e *TeamSyncProgressState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TeamSyncProgressState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TeamSyncProgressState", str)
+	}
+	return nil
+}
+
+func (e TeamSyncProgressState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// The status of a reconciler in a team sync run.
+type TeamSyncReconcilerStatus string
+
+const (
+	// The reconciler finished successfully.
+	TeamSyncReconcilerStatusSuccess TeamSyncReconcilerStatus = "SUCCESS"
+	// The reconciler failed.
+	TeamSyncReconcilerStatusFailure TeamSyncReconcilerStatus = "FAILURE"
+	// The reconciler did not finish within its timeout.
+	TeamSyncReconcilerStatusTimeout TeamSyncReconcilerStatus = "TIMEOUT"
+	// The reconciler was skipped because one of its prerequisites did not succeed.
+	TeamSyncReconcilerStatusSkipped TeamSyncReconcilerStatus = "SKIPPED"
+	// The reconciler was interrupted.
+	TeamSyncReconcilerStatusCanceled TeamSyncReconcilerStatus = "CANCELED"
+)
+
+var AllTeamSyncReconcilerStatus = []TeamSyncReconcilerStatus{
+	TeamSyncReconcilerStatusSuccess,
+	TeamSyncReconcilerStatusFailure,
+	TeamSyncReconcilerStatusTimeout,
+	TeamSyncReconcilerStatusSkipped,
+	TeamSyncReconcilerStatusCanceled,
+}
+
+func (e TeamSyncReconcilerStatus) IsValid() bool {
+	switch e {
+	case TeamSyncReconcilerStatusSuccess, TeamSyncReconcilerStatusFailure, TeamSyncReconcilerStatusTimeout, TeamSyncReconcilerStatusSkipped, TeamSyncReconcilerStatusCanceled:
+		return true
+	}
+	return false
+}
+
+func (e TeamSyncReconcilerStatus) String() string {
+	return string(e)
+}
+
+func (e *TeamSyncReconcilerStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TeamSyncReconcilerStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TeamSyncReconcilerStatus", str)
+	}
+	return nil
+}
+
+func (e TeamSyncReconcilerStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Team sync run status.
+type TeamSyncRunStatus string
+
+const (
+	// Team sync run in progress.
+	TeamSyncRunStatusInProgress TeamSyncRunStatus = "IN_PROGRESS"
+	// All reconcilers finished successfully.
+	TeamSyncRunStatusSuccess TeamSyncRunStatus = "SUCCESS"
+	// One or more reconcilers did not succeed.
+	TeamSyncRunStatusFailure TeamSyncRunStatus = "FAILURE"
+	// The run was interrupted before all reconcilers had finished.
+	TeamSyncRunStatusCanceled TeamSyncRunStatus = "CANCELED"
+)
+
+var AllTeamSyncRunStatus = []TeamSyncRunStatus{
+	TeamSyncRunStatusInProgress,
+	TeamSyncRunStatusSuccess,
+	TeamSyncRunStatusFailure,
+	TeamSyncRunStatusCanceled,
+}
+
+func (e TeamSyncRunStatus) IsValid() bool {
+	switch e {
+	case TeamSyncRunStatusInProgress, TeamSyncRunStatusSuccess, TeamSyncRunStatusFailure, TeamSyncRunStatusCanceled:
+		return true
+	}
+	return false
+}
+
+func (e TeamSyncRunStatus) String() string {
+	return string(e)
+}
+
+func (e *TeamSyncRunStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TeamSyncRunStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TeamSyncRunStatus", str)
+	}
+	return nil
+}
+
+func (e TeamSyncRunStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// What caused a synchronization of a team.
+type TeamSyncTrigger string
+
+const (
+	// The team, or the configuration of the reconcilers, was changed.
+	TeamSyncTriggerChange TeamSyncTrigger = "CHANGE"
+	// The synchronization was requested by a user.
+	TeamSyncTriggerManual TeamSyncTrigger = "MANUAL"
+	// The periodic synchronization of all teams.
+	TeamSyncTriggerSchedule TeamSyncTrigger = "SCHEDULE"
+	// A retry of reconcilers that failed in an earlier synchronization.
+	TeamSyncTriggerRetry TeamSyncTrigger = "RETRY"
+)
+
+var AllTeamSyncTrigger = []TeamSyncTrigger{
+	TeamSyncTriggerChange,
+	TeamSyncTriggerManual,
+	TeamSyncTriggerSchedule,
+	TeamSyncTriggerRetry,
+}
+
+func (e TeamSyncTrigger) IsValid() bool {
+	switch e {
+	case TeamSyncTriggerChange, TeamSyncTriggerManual, TeamSyncTriggerSchedule, TeamSyncTriggerRetry:
+		return true
+	}
+	return false
+}
+
+func (e TeamSyncTrigger) String() string {
+	return string(e)
+}
+
+func (e *TeamSyncTrigger) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TeamSyncTrigger(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TeamSyncTrigger", str)
+	}
+	return nil
+}
+
+func (e TeamSyncTrigger) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // User sync run status.
 type UserSyncRunStatus string
 
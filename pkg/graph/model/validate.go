@@ -1,16 +1,23 @@
 package model
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 
+	"github.com/nais/teams-backend/pkg/fixtures"
 	"github.com/nais/teams-backend/pkg/graph/apierror"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/sqlc"
 )
 
 var (
 	// Slightly modified from database schema because Golang doesn't like Perl-flavored regexes.
 	teamSlugRegex = regexp.MustCompile("^[a-z](-?[a-z0-9]+)+$")
 
+	// Service account names follow the same rules as team slugs
+	serviceAccountNameRegex = teamSlugRegex
+
 	// Rules can be found here: https://api.slack.com/methods/conversations.create#naming
 	slackChannelNameRegex = regexp.MustCompile("^#[a-z0-9æøå_-]{2,80}$")
 
@@ -89,6 +96,102 @@ func (input UpdateTeamInput) Validate(validEnvironments []string) error {
 	return nil
 }
 
+func (input CreateServiceAccountInput) Validate() error {
+	return validateServiceAccountName(input.Name)
+}
+
+func (input UpdateServiceAccountInput) Validate() error {
+	if input.Name != nil {
+		return validateServiceAccountName(*input.Name)
+	}
+
+	return nil
+}
+
+func validateServiceAccountName(name string) error {
+	if !serviceAccountNameRegex.MatchString(name) || len(name) < 3 || len(name) > 40 {
+		return apierror.ErrServiceAccountName
+	}
+
+	if strings.HasPrefix(name, fixtures.NaisServiceAccountPrefix) {
+		return apierror.ErrServiceAccountNameReserved
+	}
+
+	return nil
+}
+
+func (input CreateRoleInput) Validate() error {
+	name := string(input.Name)
+	if len(name) < 3 || len(name) > 60 || strings.TrimSpace(name) != name {
+		return apierror.ErrRoleName
+	}
+
+	return validateAuthorizations(input.Authorizations)
+}
+
+func (input UpdateRoleInput) Validate() error {
+	if input.Authorizations != nil {
+		return validateAuthorizations(input.Authorizations)
+	}
+
+	return nil
+}
+
+func validateAuthorizations(authorizations []roles.Authorization) error {
+	for _, authorization := range authorizations {
+		if !authorization.Valid() {
+			return apierror.Errorf("The specified authorization is not valid: %q.", authorization)
+		}
+	}
+
+	return nil
+}
+
+func (input CreateWebhookSubscriptionInput) Validate() error {
+	if err := validateWebhookURL(input.URL); err != nil {
+		return err
+	}
+
+	return validateWebhookEventTypes(input.EventTypes)
+}
+
+func (input UpdateWebhookSubscriptionInput) Validate() error {
+	if input.URL != nil {
+		if err := validateWebhookURL(*input.URL); err != nil {
+			return err
+		}
+	}
+
+	if input.EventTypes != nil {
+		return validateWebhookEventTypes(input.EventTypes)
+	}
+
+	return nil
+}
+
+func validateWebhookURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apierror.Errorf("The webhook URL is not valid: %q. The URL must be an absolute http or https URL.", value)
+	}
+
+	return nil
+}
+
+func validateWebhookEventTypes(eventTypes []sqlc.WebhookEventType) error {
+	if len(eventTypes) == 0 {
+		return apierror.Errorf("You must specify at least one event type for the webhook subscription.")
+	}
+
+	for _, eventType := range eventTypes {
+		if !eventType.Valid() {
+			return apierror.Errorf("The specified event type is not valid: %q.", eventType)
+		}
+	}
+
+	return nil
+}
+
 func slackChannelError(channel string) apierror.Error {
 	return apierror.Errorf("The Slack channel does not fit the requirements: %q. The name must contain at least 2 characters and at most 80 characters. The name must consist of lowercase letters, numbers, hyphens and underscores, and it must be prefixed with a hash symbol.", channel)
 }
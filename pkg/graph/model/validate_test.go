@@ -6,6 +6,7 @@ import (
 	"github.com/nais/teams-backend/pkg/graph/apierror"
 
 	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/stretchr/testify/assert"
 )
@@ -156,3 +157,69 @@ func TestUpdateTeamInput_Validate(t *testing.T) {
 		assert.ErrorContains(t, input.Validate([]string{"prod"}), "The Slack channel does not fit the requirements")
 	})
 }
+
+func TestCreateServiceAccountInput_Validate(t *testing.T) {
+	validNames := []string{
+		"foo",
+		"ci-deployer",
+		"github-actions-1",
+		"some-long-string-with-less-than-41-chars",
+	}
+
+	invalidNames := []string{
+		"ab",
+		"-foo",
+		"foo-",
+		"foo--bar",
+		"4chan",
+		"Uppercase",
+		"some-long-string-with-more-than-40-characters",
+	}
+
+	for _, name := range validNames {
+		input := model.CreateServiceAccountInput{Name: name}
+		assert.NoError(t, input.Validate(), "Name %q should pass validation, but didn't", name)
+	}
+
+	for _, name := range invalidNames {
+		input := model.CreateServiceAccountInput{Name: name}
+		assert.ErrorIs(t, input.Validate(), apierror.ErrServiceAccountName, "Name %q passed validation even if it should not", name)
+	}
+
+	t.Run("reserved prefix", func(t *testing.T) {
+		input := model.CreateServiceAccountInput{Name: "nais-deploy"}
+		assert.ErrorIs(t, input.Validate(), apierror.ErrServiceAccountNameReserved)
+	})
+}
+
+func TestCreateRoleInput_Validate(t *testing.T) {
+	validNames := []roles.RoleName{
+		"Team auditor",
+		"abc",
+	}
+
+	invalidNames := []roles.RoleName{
+		"ab",
+		" Team auditor",
+		"Team auditor ",
+		"A role name that is longer than the sixty characters allowed for roles",
+	}
+
+	for _, name := range validNames {
+		input := model.CreateRoleInput{Name: name}
+		assert.NoError(t, input.Validate(), "Name %q should pass validation, but didn't", name)
+	}
+
+	for _, name := range invalidNames {
+		input := model.CreateRoleInput{Name: name}
+		assert.ErrorIs(t, input.Validate(), apierror.ErrRoleName, "Name %q passed validation even if it should not", name)
+	}
+
+	t.Run("unknown authorization", func(t *testing.T) {
+		input := model.CreateRoleInput{
+			Name:           "Team auditor",
+			Authorizations: []roles.Authorization{roles.AuthorizationAuditLogsRead, "audit_logs:write"},
+		}
+		assert.ErrorContains(t, input.Validate(), `"audit_logs:write"`)
+	})
+}
@@ -85,7 +85,7 @@ func (r *mutationResolver) EnableReconciler(ctx context.Context, name sqlc.Recon
 	}
 	r.auditLogger.Logf(ctx, targets, fields, "Enable reconciler: %q", name)
 
-	_, err = r.teamSyncHandler.ScheduleAllTeams(ctx, correlationID)
+	_, err = r.teamSyncHandler.ScheduleAllTeams(ctx, correlationID, sqlc.TeamSyncTriggerChange)
 	if err != nil {
 		r.log.WithError(err).Errorf("reconcile all teams")
 	}
@@ -143,6 +143,7 @@ func (r *mutationResolver) ConfigureReconciler(ctx context.Context, name sqlc.Re
 		reconcilerConfig[entry.Key] = entry.Value
 	}
 
+	changes := auditlogger.Changes{}
 	err := r.database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
 		rows, err := dbtx.GetReconcilerConfig(ctx, name)
 		if err != nil {
@@ -154,7 +155,7 @@ func (r *mutationResolver) ConfigureReconciler(ctx context.Context, name sqlc.Re
 			validOptions[row.Key] = struct{}{}
 		}
 
-		for key, value := range reconcilerConfig {
+		for key := range reconcilerConfig {
 			if _, exists := validOptions[key]; !exists {
 				keys := make([]string, 0, len(validOptions))
 				for key := range validOptions {
@@ -162,11 +163,24 @@ func (r *mutationResolver) ConfigureReconciler(ctx context.Context, name sqlc.Re
 				}
 				return fmt.Errorf("unknown configuration option %q for reconciler %q. Valid options: %s", key, name, strings.Join(keys, ", "))
 			}
+		}
 
-			err = dbtx.ConfigureReconciler(ctx, name, key, value)
+		for _, row := range rows {
+			value, exists := reconcilerConfig[row.Key]
+			if !exists {
+				continue
+			}
+
+			err = dbtx.ConfigureReconciler(ctx, name, row.Key, value)
 			if err != nil {
 				return err
 			}
+
+			if row.Secret {
+				changes = changes.AddSecret(string(row.Key), row.Configured)
+			} else {
+				changes = changes.Add(string(row.Key), row.Value, &value)
+			}
 		}
 
 		return nil
@@ -192,14 +206,60 @@ func (r *mutationResolver) ConfigureReconciler(ctx context.Context, name sqlc.Re
 		auditlogger.ReconcilerTarget(name),
 	}
 	fields := auditlogger.Fields{
-		Action: types.AuditActionGraphqlApiReconcilersConfigure,
-		Actor:  actor,
+		Action:  types.AuditActionGraphqlApiReconcilersConfigure,
+		Actor:   actor,
+		Changes: changes,
 	}
 	r.auditLogger.Logf(ctx, targets, fields, "Configure reconciler: %q", name)
 
 	return reconciler, nil
 }
 
+// SetReconcilerTimeout is the resolver for the setReconcilerTimeout field.
+func (r *mutationResolver) SetReconcilerTimeout(ctx context.Context, name sqlc.ReconcilerName, timeoutSeconds *int) (*db.Reconciler, error) {
+	if !name.Valid() {
+		return nil, apierror.Errorf("%q is not a valid name", name)
+	}
+
+	var timeout *int32
+	if timeoutSeconds != nil {
+		if *timeoutSeconds < 1 {
+			return nil, apierror.Errorf("The timeout must be at least 1 second.")
+		}
+		t := int32(*timeoutSeconds)
+		timeout = &t
+	}
+
+	reconciler, err := r.database.SetReconcilerTimeout(ctx, name, timeout)
+	if err != nil {
+		r.log.WithError(err).Errorf("unable to set reconciler timeout")
+		return nil, apierror.Errorf("Unable to set timeout for reconciler: %q", name)
+	}
+
+	if reconciler.Enabled {
+		err = r.teamSyncHandler.UseReconciler(*reconciler)
+		if err != nil {
+			r.log.WithError(err).Errorf("use reconciler: %q", reconciler.Name)
+		}
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	targets := []auditlogger.Target{
+		auditlogger.ReconcilerTarget(name),
+	}
+	fields := auditlogger.Fields{
+		Action: types.AuditActionGraphqlApiReconcilersSetTimeout,
+		Actor:  actor,
+	}
+	if timeout == nil {
+		r.auditLogger.Logf(ctx, targets, fields, "Reset timeout for reconciler: %q", name)
+	} else {
+		r.auditLogger.Logf(ctx, targets, fields, "Set timeout for reconciler %q to %d second(s)", name, *timeout)
+	}
+
+	return reconciler, nil
+}
+
 // ResetReconciler is the resolver for the resetReconciler field.
 func (r *mutationResolver) ResetReconciler(ctx context.Context, name sqlc.ReconcilerName) (*db.Reconciler, error) {
 	if !name.Valid() {
@@ -0,0 +1,127 @@
+package graph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/authz"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/deployproxy"
+	"github.com/nais/teams-backend/pkg/graph"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/teamsync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMutationResolver_ConfigureReconciler(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{RoleName: roles.RoleNameAdmin},
+	})
+
+	teamSyncHandler := teamsync.NewMockHandler(t)
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	const tenantDomain = "example.com"
+	reconcilerName := sqlc.ReconcilerNameAzureGroup
+
+	t.Run("unknown configuration option", func(t *testing.T) {
+		txCtx := context.Background()
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("GetReconcilerConfig", txCtx, reconcilerName).
+			Return([]*db.ReconcilerConfig{
+				{GetReconcilerConfigRow: &sqlc.GetReconcilerConfigRow{Key: sqlc.ReconcilerConfigKeyAzureClientID}},
+			}, nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Return(func(_ context.Context, fn db.DatabaseTransactionFunc) error {
+				return fn(txCtx, dbtx)
+			}).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		_, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			ConfigureReconciler(ctx, reconcilerName, []*model.ReconcilerConfigInput{
+				{Key: "azure:unknown", Value: "value"},
+			})
+		assert.ErrorContains(t, err, `unknown configuration option "azure:unknown"`)
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("secret values are redacted in the changes", func(t *testing.T) {
+		oldClientID := "old-client-id"
+		newClientID := "new-client-id"
+		tenantID := "tenant-id"
+
+		txCtx := context.Background()
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("GetReconcilerConfig", txCtx, reconcilerName).
+			Return([]*db.ReconcilerConfig{
+				{GetReconcilerConfigRow: &sqlc.GetReconcilerConfigRow{Key: sqlc.ReconcilerConfigKeyAzureClientID, Configured: true, Value: &oldClientID}},
+				{GetReconcilerConfigRow: &sqlc.GetReconcilerConfigRow{Key: sqlc.ReconcilerConfigKeyAzureClientSecret, Configured: true, Secret: true}},
+				{GetReconcilerConfigRow: &sqlc.GetReconcilerConfigRow{Key: sqlc.ReconcilerConfigKeyAzureTenantID, Configured: true, Value: &tenantID}},
+			}, nil).
+			Once()
+		dbtx.
+			On("ConfigureReconciler", txCtx, reconcilerName, sqlc.ReconcilerConfigKeyAzureClientID, newClientID).
+			Return(nil).
+			Once()
+		dbtx.
+			On("ConfigureReconciler", txCtx, reconcilerName, sqlc.ReconcilerConfigKeyAzureClientSecret, "new secret").
+			Return(nil).
+			Once()
+		dbtx.
+			On("ConfigureReconciler", txCtx, reconcilerName, sqlc.ReconcilerConfigKeyAzureTenantID, tenantID).
+			Return(nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Return(func(_ context.Context, fn db.DatabaseTransactionFunc) error {
+				return fn(txCtx, dbtx)
+			}).
+			Once()
+		database.
+			On("GetReconciler", ctx, reconcilerName).
+			Return(&db.Reconciler{Reconciler: &sqlc.Reconciler{Name: reconcilerName}}, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		_, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			ConfigureReconciler(ctx, reconcilerName, []*model.ReconcilerConfigInput{
+				{Key: sqlc.ReconcilerConfigKeyAzureTenantID, Value: tenantID},
+				{Key: sqlc.ReconcilerConfigKeyAzureClientSecret, Value: "new secret"},
+				{Key: sqlc.ReconcilerConfigKeyAzureClientID, Value: newClientID},
+			})
+		assert.NoError(t, err)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "azure:client_id", Before: &oldClientID, After: &newClientID},
+			{Field: "azure:client_secret", Before: auditlogger.RedactedValue, After: auditlogger.RedactedValue},
+		}, auditLogger.Entries()[0].Fields.Changes)
+	})
+}
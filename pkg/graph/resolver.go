@@ -2,27 +2,66 @@ package graph
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/deployproxy"
+	"github.com/nais/teams-backend/pkg/fixtures"
 	"github.com/nais/teams-backend/pkg/graph/apierror"
 	"github.com/nais/teams-backend/pkg/graph/model"
 	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/nais/teams-backend/pkg/teamsync"
 	"github.com/nais/teams-backend/pkg/types"
-	"github.com/nais/teams-backend/pkg/usersync"
 )
 
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
+const (
+	// defaultUserSyncRunsLimit The number of user sync runs returned when no limit is given
+	defaultUserSyncRunsLimit = 20
+
+	// maxUserSyncRunsLimit The maximum number of user sync runs that can be returned at once
+	maxUserSyncRunsLimit = 100
+
+	// defaultAuditLogsLimit The number of audit log entries returned when no limit is given
+	defaultAuditLogsLimit = 50
+
+	// maxAuditLogsLimit The maximum number of audit log entries that can be returned at once
+	maxAuditLogsLimit = 100
+
+	// defaultWebhookDeliveriesLimit The number of webhook deliveries returned when no limit is given
+	defaultWebhookDeliveriesLimit = 20
+
+	// maxWebhookDeliveriesLimit The maximum number of webhook deliveries that can be returned at once
+	maxWebhookDeliveriesLimit = 100
+
+	// defaultTeamSyncRunsLimit The number of team sync runs returned when no limit is given
+	defaultTeamSyncRunsLimit = 20
+
+	// maxTeamSyncRunsLimit The maximum number of team sync runs that can be returned at once
+	maxTeamSyncRunsLimit = 100
+
+	// apiKeyLength The number of random bytes in a generated service account API key
+	apiKeyLength = 32
+)
+
 type Resolver struct {
 	teamSyncHandler teamsync.Handler
 	database        db.Database
@@ -33,10 +72,9 @@ type Resolver struct {
 	auditLogger     auditlogger.AuditLogger
 	gcpEnvironments []string
 	log             logger.Logger
-	userSyncRuns    *usersync.RunsHandler
 }
 
-func NewResolver(teamSyncHandler teamsync.Handler, database db.Database, deployProxy deployproxy.Proxy, tenantDomain string, userSync chan<- uuid.UUID, auditLogger auditlogger.AuditLogger, gcpEnvironments []string, log logger.Logger, userSyncRuns *usersync.RunsHandler) *Resolver {
+func NewResolver(teamSyncHandler teamsync.Handler, database db.Database, deployProxy deployproxy.Proxy, tenantDomain string, userSync chan<- uuid.UUID, auditLogger auditlogger.AuditLogger, gcpEnvironments []string, log logger.Logger) *Resolver {
 	return &Resolver{
 		teamSyncHandler: teamSyncHandler,
 		database:        database,
@@ -47,7 +85,6 @@ func NewResolver(teamSyncHandler teamsync.Handler, database db.Database, deployP
 		gcpEnvironments: gcpEnvironments,
 		log:             log.WithComponent(types.ComponentNameGraphqlApi),
 		userSync:        userSync,
-		userSyncRuns:    userSyncRuns,
 	}
 }
 
@@ -61,23 +98,27 @@ func GetQueriedFields(ctx context.Context) map[string]bool {
 }
 
 // addTeamToReconcilerQueue add a team (enclosed in an input) to the reconciler queue
-func (r *Resolver) addTeamToReconcilerQueue(input teamsync.Input) error {
-	err := r.teamSyncHandler.Schedule(input)
+func (r *Resolver) addTeamToReconcilerQueue(ctx context.Context, input teamsync.Input) error {
+	err := r.teamSyncHandler.Schedule(ctx, input)
 	if err != nil {
 		r.log.WithTeamSlug(string(input.TeamSlug)).WithError(err).Errorf("add team to reconciler queue")
-		return apierror.Errorf("teams-backend is about to restart, unable to reconcile team: %q", input.TeamSlug)
+		if errors.Is(err, teamsync.ErrQueueClosed) {
+			return apierror.Errorf("teams-backend is about to restart, unable to reconcile team: %q", input.TeamSlug)
+		}
+		return apierror.Errorf("Unable to schedule synchronization of team: %q", input.TeamSlug)
 	}
 	return nil
 }
 
 // reconcileTeam Trigger team reconcilers for a given team
-func (r *Resolver) reconcileTeam(_ context.Context, correlationID uuid.UUID, slug slug.Slug) error {
+func (r *Resolver) reconcileTeam(ctx context.Context, correlationID uuid.UUID, slug slug.Slug) error {
 	input := teamsync.Input{
 		TeamSlug:      slug,
 		CorrelationID: correlationID,
+		Trigger:       sqlc.TeamSyncTriggerChange,
 	}
 
-	return r.addTeamToReconcilerQueue(input)
+	return r.addTeamToReconcilerQueue(ctx, input)
 }
 
 func (r *Resolver) getTeamBySlug(ctx context.Context, slug slug.Slug) (*db.Team, error) {
@@ -89,13 +130,236 @@ func (r *Resolver) getTeamBySlug(ctx context.Context, slug slug.Slug) (*db.Team,
 	return team, nil
 }
 
-func sqlcRoleFromTeamRole(teamRole model.TeamRole) (sqlc.RoleName, error) {
+// getServiceAccount Get a service account that can be managed through the API
+func (r *Resolver) getServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) (*db.ServiceAccount, error) {
+	serviceAccount, err := r.database.GetServiceAccountByID(ctx, serviceAccountID)
+	if err != nil {
+		return nil, apierror.ErrServiceAccountNotExist
+	}
+
+	if strings.HasPrefix(serviceAccount.Name, fixtures.NaisServiceAccountPrefix) {
+		return nil, apierror.ErrServiceAccountStatic
+	}
+
+	return serviceAccount, nil
+}
+
+// requireServiceAccountAuthorization Require an actor to have a specific authorization for a service account, either
+// through a role targeting the service account, or through a role for the team owning the service account
+func requireServiceAccountAuthorization(actor *authz.Actor, requiredAuthzName roles.Authorization, serviceAccount *db.ServiceAccount) error {
+	err := authz.RequireServiceAccountAuthorization(actor, requiredAuthzName, serviceAccount.ID)
+	if err != nil && serviceAccount.TeamSlug != nil {
+		return authz.RequireTeamAuthorization(actor, requiredAuthzName, *serviceAccount.TeamSlug)
+	}
+
+	return err
+}
+
+// requireNoTeamDeletion Make sure that the team is not scheduled for deletion
+func (r *Resolver) requireNoTeamDeletion(ctx context.Context, teamSlug slug.Slug) error {
+	_, err := r.database.GetTeamDeletion(ctx, teamSlug)
+	if err == nil {
+		return apierror.ErrTeamDeletionScheduled
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		r.log.WithError(err).Errorf("get team deletion")
+		return apierror.ErrDatabase
+	}
+
+	return nil
+}
+
+// getRoleDefinition Get a role definition, or an API error if the role does not exist
+func (r *Resolver) getRoleDefinition(ctx context.Context, roleName roles.RoleName) (*db.RoleDefinition, error) {
+	role, err := r.database.GetRoleDefinition(ctx, roleName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apierror.ErrRoleNotExist
+	} else if err != nil {
+		r.log.WithError(err).Errorf("get role %q", roleName)
+		return nil, apierror.ErrDatabase
+	}
+
+	return role, nil
+}
+
+// getCustomRoleDefinition Get a role that can be changed through the API
+func (r *Resolver) getCustomRoleDefinition(ctx context.Context, roleName roles.RoleName) (*db.RoleDefinition, error) {
+	role, err := r.getRoleDefinition(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.BuiltIn {
+		return nil, apierror.ErrRoleBuiltIn
+	}
+
+	return role, nil
+}
+
+// getGlobalRoleSubject Get the audit log target and the roles of the user or service account that a global role is
+// assigned to or revoked from. Exactly one of the IDs must be set.
+func (r *Resolver) getGlobalRoleSubject(ctx context.Context, userID, serviceAccountID *uuid.UUID) (auditlogger.Target, []*db.Role, error) {
+	if (userID == nil) == (serviceAccountID == nil) {
+		return auditlogger.Target{}, nil, apierror.Errorf("You must specify exactly one of userID and serviceAccountID.")
+	}
+
+	if userID != nil {
+		user, err := r.database.GetUserByID(ctx, *userID)
+		if err != nil {
+			return auditlogger.Target{}, nil, apierror.ErrUserNotExists
+		}
+
+		userRoles, err := r.database.GetUserRoles(ctx, user.ID)
+		if err != nil {
+			return auditlogger.Target{}, nil, err
+		}
+
+		return auditlogger.UserTarget(user.Email), userRoles, nil
+	}
+
+	serviceAccount, err := r.getServiceAccount(ctx, *serviceAccountID)
+	if err != nil {
+		return auditlogger.Target{}, nil, err
+	}
+
+	if serviceAccount.TeamSlug != nil {
+		return auditlogger.Target{}, nil, apierror.Errorf("Service accounts owned by a team can not be assigned global roles.")
+	}
+
+	serviceAccountRoles, err := r.database.GetServiceAccountRoles(ctx, serviceAccount.ID)
+	if err != nil {
+		return auditlogger.Target{}, nil, err
+	}
+
+	return auditlogger.ServiceAccountTarget(serviceAccount.Name), serviceAccountRoles, nil
+}
+
+// hasGlobalRole Check if a list of roles contains a globally assigned role
+func hasGlobalRole(roles []*db.Role, roleName roles.RoleName) bool {
+	for _, role := range roles {
+		if role.IsGlobal() && role.RoleName == roleName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateAPIKey Generate a random API key for a service account
+func generateAPIKey() (string, error) {
+	key := make([]byte, apiKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+func (r *Resolver) getWebhookSubscription(ctx context.Context, id uuid.UUID) (*db.WebhookSubscription, error) {
+	subscription, err := r.database.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, apierror.ErrWebhookSubscriptionNotExist
+	}
+
+	return subscription, nil
+}
+
+// teamRolesOfUser Get the names of the roles a user has in a team, sorted by name
+func teamRolesOfUser(ctx context.Context, database db.Database, userID uuid.UUID, teamSlug slug.Slug) ([]roles.RoleName, error) {
+	userRoles, err := database.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := make([]roles.RoleName, 0)
+	for _, role := range userRoles {
+		if role.TargetTeamSlug != nil && *role.TargetTeamSlug == teamSlug {
+			roleNames = append(roleNames, role.RoleName)
+		}
+	}
+
+	sort.Slice(roleNames, func(i, j int) bool {
+		return roleNames[i] < roleNames[j]
+	})
+
+	return roleNames, nil
+}
+
+func sqlcRoleFromTeamRole(teamRole model.TeamRole) (roles.RoleName, error) {
 	switch teamRole {
 	case model.TeamRoleMember:
-		return sqlc.RoleNameTeammember, nil
+		return roles.RoleNameTeammember, nil
 	case model.TeamRoleOwner:
-		return sqlc.RoleNameTeamowner, nil
+		return roles.RoleNameTeamowner, nil
 	}
 
 	return "", fmt.Errorf("invalid team role: %v", teamRole)
 }
+
+// encodeAuditLogCursor Create an opaque cursor for an audit log entry
+func encodeAuditLogCursor(entry *db.AuditLog) string {
+	value := entry.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + entry.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeAuditLogCursor Get the position of an audit log entry from a cursor created by encodeAuditLogCursor
+func decodeAuditLogCursor(cursor string) (*db.AuditLogCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, id, found := strings.Cut(string(value), ",")
+	if !found {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &db.AuditLogCursor{
+		CreatedAt: t,
+		ID:        uid,
+	}, nil
+}
+
+// encodeTeamSyncRunCursor Create an opaque cursor for a team sync run
+func encodeTeamSyncRunCursor(run *db.TeamSyncRun) string {
+	value := run.StartedAt.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatInt(run.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeTeamSyncRunCursor Get the position of a team sync run from a cursor created by encodeTeamSyncRunCursor
+func decodeTeamSyncRunCursor(cursor string) (*db.TeamSyncRunCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt, id, found := strings.Cut(string(value), ",")
+	if !found {
+		return nil, fmt.Errorf("invalid cursor format")
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	runID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &db.TeamSyncRunCursor{
+		StartedAt: t,
+		ID:        runID,
+	}, nil
+}
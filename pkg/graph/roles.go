@@ -6,23 +6,310 @@ package graph
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/graph/apierror"
+	"github.com/nais/teams-backend/pkg/graph/dataloader"
 	"github.com/nais/teams-backend/pkg/graph/generated"
-	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/types"
 )
 
+// CreateRole is the resolver for the createRole field.
+func (r *mutationResolver) CreateRole(ctx context.Context, input model.CreateRoleInput) (*db.RoleDefinition, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	description := ""
+	if input.Description != nil {
+		description = *input.Description
+	}
+
+	var role *db.RoleDefinition
+	err = r.database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		role, err = dbtx.CreateRoleDefinition(ctx, input.Name, description)
+		if err != nil {
+			return err
+		}
+
+		return dbtx.SetRoleAuthorizations(ctx, role.Name, input.Authorizations)
+	})
+	if errors.Is(err, db.ErrRoleNameTaken) {
+		return nil, apierror.ErrRoleNameTaken
+	} else if err != nil {
+		r.log.WithError(err).Errorf("create role")
+		return nil, apierror.Errorf("Unable to create role.")
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiRolesCreate,
+		Actor:         actor,
+		CorrelationID: correlationID,
+		Changes: auditlogger.Changes{}.
+			Add("description", nil, role.Description).
+			Add("authorizations", nil, input.Authorizations),
+	}
+	r.auditLogger.Logf(ctx, []auditlogger.Target{auditlogger.RoleTarget(role.Name)}, fields, "Create role %q", role.Name)
+
+	return role, nil
+}
+
+// UpdateRole is the resolver for the updateRole field.
+func (r *mutationResolver) UpdateRole(ctx context.Context, name roles.RoleName, input model.UpdateRoleInput) (*db.RoleDefinition, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := r.getCustomRoleDefinition(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	existingAuthorizations, err := r.database.GetRoleAuthorizations(ctx, existing.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	description := existing.Description
+	if input.Description != nil {
+		description = *input.Description
+	}
+
+	authorizations := existingAuthorizations
+	if input.Authorizations != nil {
+		authorizations = input.Authorizations
+	}
+
+	var role *db.RoleDefinition
+	err = r.database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		role, err = dbtx.UpdateRoleDefinition(ctx, existing.Name, description)
+		if err != nil {
+			return err
+		}
+
+		if input.Authorizations == nil {
+			return nil
+		}
+
+		return dbtx.SetRoleAuthorizations(ctx, existing.Name, input.Authorizations)
+	})
+	if err != nil {
+		r.log.WithError(err).Errorf("update role")
+		return nil, apierror.Errorf("Unable to update role.")
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiRolesUpdate,
+		Actor:         actor,
+		CorrelationID: correlationID,
+		Changes: auditlogger.Changes{}.
+			Add("description", existing.Description, role.Description).
+			Add("authorizations", existingAuthorizations, authorizations),
+	}
+	r.auditLogger.Logf(ctx, []auditlogger.Target{auditlogger.RoleTarget(role.Name)}, fields, "Update role %q", role.Name)
+
+	return role, nil
+}
+
+// DeleteRole is the resolver for the deleteRole field.
+func (r *mutationResolver) DeleteRole(ctx context.Context, name roles.RoleName) (bool, error) {
+	role, err := r.getCustomRoleDefinition(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return false, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	if err := r.database.DeleteRoleDefinition(ctx, role.Name); err != nil {
+		r.log.WithError(err).Errorf("delete role")
+		return false, apierror.Errorf("Unable to delete role.")
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiRolesDelete,
+		Actor:         actor,
+		CorrelationID: correlationID,
+	}
+	r.auditLogger.Logf(ctx, []auditlogger.Target{auditlogger.RoleTarget(role.Name)}, fields, "Delete role %q", role.Name)
+
+	return true, nil
+}
+
+// AssignGlobalRole is the resolver for the assignGlobalRole field.
+func (r *mutationResolver) AssignGlobalRole(ctx context.Context, role roles.RoleName, userID *uuid.UUID, serviceAccountID *uuid.UUID) (bool, error) {
+	target, existingRoles, err := r.getGlobalRoleSubject(ctx, userID, serviceAccountID)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := r.getRoleDefinition(ctx, role); err != nil {
+		return false, err
+	}
+
+	if hasGlobalRole(existingRoles, role) {
+		return false, apierror.Errorf("The role %q is already assigned.", role)
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return false, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	grantedBy := actor.User.Identity()
+	if userID != nil {
+		err = r.database.AssignGlobalRoleToUser(ctx, *userID, role, &grantedBy)
+	} else {
+		err = r.database.AssignGlobalRoleToServiceAccount(ctx, *serviceAccountID, role, &grantedBy)
+	}
+	if err != nil {
+		r.log.WithError(err).Errorf("assign global role")
+		return false, apierror.Errorf("Unable to assign the global role.")
+	}
+
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiRolesAssignGlobalRole,
+		Actor:         actor,
+		CorrelationID: correlationID,
+	}
+	r.auditLogger.Logf(ctx, []auditlogger.Target{target}, fields, "Assign global role %q to %q", role, target.Identifier)
+
+	return true, nil
+}
+
+// RevokeGlobalRole is the resolver for the revokeGlobalRole field.
+func (r *mutationResolver) RevokeGlobalRole(ctx context.Context, role roles.RoleName, userID *uuid.UUID, serviceAccountID *uuid.UUID) (bool, error) {
+	target, existingRoles, err := r.getGlobalRoleSubject(ctx, userID, serviceAccountID)
+	if err != nil {
+		return false, err
+	}
+
+	if !hasGlobalRole(existingRoles, role) {
+		return false, apierror.Errorf("The role %q is not assigned.", role)
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return false, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	err = r.database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		if role == roles.RoleNameAdmin {
+			// concurrent revocations could otherwise each see another admin and remove the last two together
+			if err := dbtx.LockGlobalAdmins(ctx); err != nil {
+				return err
+			}
+		}
+
+		if userID != nil {
+			err = dbtx.RevokeGlobalUserRole(ctx, *userID, role)
+		} else {
+			err = dbtx.RevokeGlobalServiceAccountRole(ctx, *serviceAccountID, role)
+		}
+		if err != nil {
+			return err
+		}
+
+		if role != roles.RoleNameAdmin {
+			return nil
+		}
+
+		admins, err := dbtx.CountGlobalAdmins(ctx)
+		if err != nil {
+			return err
+		}
+
+		if admins == 0 {
+			return apierror.ErrLastAdmin
+		}
+
+		return nil
+	})
+	if errors.Is(err, apierror.ErrLastAdmin) {
+		return false, err
+	} else if err != nil {
+		r.log.WithError(err).Errorf("revoke global role")
+		return false, apierror.Errorf("Unable to revoke the global role.")
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiRolesRevokeGlobalRole,
+		Actor:         actor,
+		CorrelationID: correlationID,
+	}
+	r.auditLogger.Logf(ctx, []auditlogger.Target{target}, fields, "Revoke global role %q from %q", role, target.Identifier)
+
+	return true, nil
+}
+
 // Roles is the resolver for the roles field.
-func (r *queryResolver) Roles(ctx context.Context) ([]sqlc.RoleName, error) {
-	return sqlc.AllRoleNameValues(), nil
+func (r *queryResolver) Roles(ctx context.Context) ([]roles.RoleName, error) {
+	definitions, err := r.database.GetRoleDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := make([]roles.RoleName, 0, len(definitions))
+	for _, definition := range definitions {
+		roleNames = append(roleNames, definition.Name)
+	}
+
+	return roleNames, nil
+}
+
+// RoleDefinitions is the resolver for the roleDefinitions field.
+func (r *queryResolver) RoleDefinitions(ctx context.Context) ([]*db.RoleDefinition, error) {
+	return r.database.GetRoleDefinitions(ctx)
+}
+
+// Authorizations is the resolver for the authorizations field.
+func (r *queryResolver) Authorizations(ctx context.Context) ([]roles.Authorization, error) {
+	return roles.AllAuthorizations(), nil
 }
 
 // Name is the resolver for the name field.
-func (r *roleResolver) Name(ctx context.Context, obj *db.Role) (sqlc.RoleName, error) {
+func (r *roleResolver) Name(ctx context.Context, obj *db.Role) (roles.RoleName, error) {
 	return obj.RoleName, nil
 }
 
+// Authorizations is the resolver for the authorizations field.
+func (r *roleDefinitionResolver) Authorizations(ctx context.Context, obj *db.RoleDefinition) ([]roles.Authorization, error) {
+	return dataloader.GetRoleAuthorizations(ctx, obj.Name)
+}
+
 // Role returns generated.RoleResolver implementation.
 func (r *Resolver) Role() generated.RoleResolver { return &roleResolver{r} }
 
-type roleResolver struct{ *Resolver }
+// RoleDefinition returns generated.RoleDefinitionResolver implementation.
+func (r *Resolver) RoleDefinition() generated.RoleDefinitionResolver {
+	return &roleDefinitionResolver{r}
+}
+
+type (
+	roleResolver           struct{ *Resolver }
+	roleDefinitionResolver struct{ *Resolver }
+)
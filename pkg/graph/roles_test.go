@@ -2,19 +2,26 @@ package graph_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/auditlogger"
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/deployproxy"
 	"github.com/nais/teams-backend/pkg/graph"
+	"github.com/nais/teams-backend/pkg/graph/apierror"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/helpers"
 	"github.com/nais/teams-backend/pkg/logger"
 	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
-	"github.com/nais/teams-backend/pkg/usersync"
+	"github.com/nais/teams-backend/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMutationResolver_Role(t *testing.T) {
@@ -26,14 +33,13 @@ func TestMutationResolver_Role(t *testing.T) {
 	}
 	ctx := authz.ContextWithActor(context.Background(), serviceAccount, []*db.Role{
 		{
-			RoleName: sqlc.RoleNameAdmin,
+			RoleName: roles.RoleNameAdmin,
 			Authorizations: []roles.Authorization{
 				roles.AuthorizationTeamsCreate,
 			},
 		},
 	})
 
-	userSyncRuns := usersync.NewRunsHandler(5)
 	auditLogger := auditlogger.NewMockAuditLogger(t)
 	database := db.NewMockDatabase(t)
 	deployProxy := deployproxy.NewMockProxy(t)
@@ -41,19 +47,510 @@ func TestMutationResolver_Role(t *testing.T) {
 	assert.NoError(t, err)
 	userSync := make(chan<- uuid.UUID)
 	resolver := graph.
-		NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{"env"}, log, userSyncRuns).
+		NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{"env"}, log).
 		Role()
 
 	t.Run("get role name", func(t *testing.T) {
 		role := &db.Role{
 			Authorizations:         nil,
-			RoleName:               sqlc.RoleNameAdmin,
+			RoleName:               roles.RoleNameAdmin,
 			TargetServiceAccountID: nil,
 			TargetTeamSlug:         nil,
 		}
 
 		roleName, err := resolver.Name(ctx, role)
 		assert.NoError(t, err)
-		assert.Equal(t, sqlc.RoleNameAdmin, roleName)
+		assert.Equal(t, roles.RoleNameAdmin, roleName)
+	})
+}
+
+func TestMutationResolver_AssignGlobalRole(t *testing.T) {
+	admin := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "admin@example.com", Name: "Admin"}}
+	ctx := authz.ContextWithActor(context.Background(), admin, []*db.Role{{RoleName: roles.RoleNameAdmin}})
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("user or service account is required", func(t *testing.T) {
+		_, err := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			AssignGlobalRole(ctx, roles.RoleNameAdmin, nil, nil)
+		assert.ErrorContains(t, err, "exactly one of userID and serviceAccountID")
+	})
+
+	t.Run("role does not exist", func(t *testing.T) {
+		user := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "user@example.com"}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetUserByID", ctx, user.ID).
+			Return(user, nil).
+			Once()
+		database.
+			On("GetUserRoles", ctx, user.ID).
+			Return([]*db.Role{}, nil).
+			Once()
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleName("Unknown")).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			AssignGlobalRole(ctx, "Unknown", &user.ID, nil)
+		assert.ErrorIs(t, err, apierror.ErrRoleNotExist)
+	})
+
+	t.Run("unable to get role", func(t *testing.T) {
+		user := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "user@example.com"}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetUserByID", ctx, user.ID).
+			Return(user, nil).
+			Once()
+		database.
+			On("GetUserRoles", ctx, user.ID).
+			Return([]*db.Role{}, nil).
+			Once()
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleNameTeamcreator).
+			Return(nil, fmt.Errorf("some error")).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			AssignGlobalRole(ctx, roles.RoleNameTeamcreator, &user.ID, nil)
+		assert.ErrorIs(t, err, apierror.ErrDatabase)
+	})
+
+	t.Run("role already assigned", func(t *testing.T) {
+		user := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "user@example.com"}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetUserByID", ctx, user.ID).
+			Return(user, nil).
+			Once()
+		database.
+			On("GetUserRoles", ctx, user.ID).
+			Return([]*db.Role{{RoleName: roles.RoleNameAdmin}}, nil).
+			Once()
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleNameAdmin).
+			Return(&db.RoleDefinition{Role: &sqlc.Role{Name: roles.RoleNameAdmin, BuiltIn: true}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			AssignGlobalRole(ctx, roles.RoleNameAdmin, &user.ID, nil)
+		assert.ErrorContains(t, err, "already assigned")
+	})
+
+	t.Run("assign role to user", func(t *testing.T) {
+		user := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "user@example.com"}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetUserByID", ctx, user.ID).
+			Return(user, nil).
+			Once()
+		database.
+			On("GetUserRoles", ctx, user.ID).
+			Return([]*db.Role{}, nil).
+			Once()
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleNameAdmin).
+			Return(&db.RoleDefinition{Role: &sqlc.Role{Name: roles.RoleNameAdmin, BuiltIn: true}}, nil).
+			Once()
+		database.
+			On("AssignGlobalRoleToUser", ctx, user.ID, roles.RoleNameAdmin, helpers.Strp("admin@example.com")).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		assigned, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			AssignGlobalRole(ctx, roles.RoleNameAdmin, &user.ID, nil)
+		assert.NoError(t, err)
+		assert.True(t, assigned)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiRolesAssignGlobalRole, entry.Fields.Action)
+		assert.Equal(t, []auditlogger.Target{auditlogger.UserTarget("user@example.com")}, entry.Targets)
+	})
+
+	t.Run("assign role to service account", func(t *testing.T) {
+		serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+		database.
+			On("GetServiceAccountRoles", ctx, serviceAccount.ID).
+			Return([]*db.Role{}, nil).
+			Once()
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleName("Team auditor")).
+			Return(&db.RoleDefinition{Role: &sqlc.Role{Name: "Team auditor"}}, nil).
+			Once()
+		database.
+			On("AssignGlobalRoleToServiceAccount", ctx, serviceAccount.ID, roles.RoleName("Team auditor"), helpers.Strp("admin@example.com")).
+			Return(nil).
+			Once()
+
+		assigned, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			AssignGlobalRole(ctx, "Team auditor", nil, &serviceAccount.ID)
+		assert.NoError(t, err)
+		assert.True(t, assigned)
+	})
+
+	t.Run("team owned service account can not get global roles", func(t *testing.T) {
+		teamSlug := slug.Slug("some-team")
+		serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer", TeamSlug: &teamSlug}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			AssignGlobalRole(ctx, roles.RoleNameTeamcreator, nil, &serviceAccount.ID)
+		assert.ErrorContains(t, err, "owned by a team")
+	})
+}
+
+func TestMutationResolver_RevokeGlobalRole(t *testing.T) {
+	admin := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "admin@example.com", Name: "Admin"}}
+	ctx := authz.ContextWithActor(context.Background(), admin, []*db.Role{{RoleName: roles.RoleNameAdmin}})
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("role not assigned", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetUserByID", ctx, admin.ID).
+			Return(admin, nil).
+			Once()
+		database.
+			On("GetUserRoles", ctx, admin.ID).
+			Return([]*db.Role{{RoleName: roles.RoleNameAdmin}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			RevokeGlobalRole(ctx, roles.RoleNameTeamcreator, &admin.ID, nil)
+		assert.ErrorContains(t, err, "not assigned")
+	})
+
+	t.Run("can not revoke the last admin", func(t *testing.T) {
+		txCtx := context.Background()
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("LockGlobalAdmins", txCtx).
+			Return(nil).
+			Once()
+		dbtx.
+			On("RevokeGlobalUserRole", txCtx, admin.ID, roles.RoleNameAdmin).
+			Return(nil).
+			Once()
+		dbtx.
+			On("CountGlobalAdmins", txCtx).
+			Return(0, nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetUserByID", ctx, admin.ID).
+			Return(admin, nil).
+			Once()
+		database.
+			On("GetUserRoles", ctx, admin.ID).
+			Return([]*db.Role{{RoleName: roles.RoleNameAdmin}}, nil).
+			Once()
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn db.DatabaseTransactionFunc) error {
+				return fn(txCtx, dbtx)
+			}).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			RevokeGlobalRole(ctx, roles.RoleNameAdmin, &admin.ID, nil)
+		assert.ErrorIs(t, err, apierror.ErrLastAdmin)
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("revoke admin role when other admins remain", func(t *testing.T) {
+		serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}
+		txCtx := context.Background()
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("LockGlobalAdmins", txCtx).
+			Return(nil).
+			Once()
+		dbtx.
+			On("RevokeGlobalServiceAccountRole", txCtx, serviceAccount.ID, roles.RoleNameAdmin).
+			Return(nil).
+			Once()
+		dbtx.
+			On("CountGlobalAdmins", txCtx).
+			Return(1, nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+		database.
+			On("GetServiceAccountRoles", ctx, serviceAccount.ID).
+			Return([]*db.Role{{RoleName: roles.RoleNameAdmin}}, nil).
+			Once()
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				assert.NoError(t, fn(txCtx, dbtx))
+			}).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		revoked, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			RevokeGlobalRole(ctx, roles.RoleNameAdmin, nil, &serviceAccount.ID)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiRolesRevokeGlobalRole, entry.Fields.Action)
+		assert.Equal(t, []auditlogger.Target{auditlogger.ServiceAccountTarget("ci-deployer")}, entry.Targets)
+	})
+}
+
+func TestMutationResolver_CreateRole(t *testing.T) {
+	admin := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "admin@example.com", Name: "Admin"}}
+	ctx := authz.ContextWithActor(context.Background(), admin, []*db.Role{{RoleName: roles.RoleNameAdmin}})
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	authorizations := []roles.Authorization{roles.AuthorizationAuditLogsRead, roles.AuthorizationTeamsList}
+
+	t.Run("invalid authorization", func(t *testing.T) {
+		_, err := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateRole(ctx, model.CreateRoleInput{Name: "Team auditor", Authorizations: []roles.Authorization{"teams:destroy"}})
+		assert.ErrorContains(t, err, `The specified authorization is not valid: "teams:destroy".`)
+	})
+
+	t.Run("name already taken", func(t *testing.T) {
+		txCtx := context.Background()
+
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("CreateRoleDefinition", txCtx, roles.RoleNameTeamviewer, "").
+			Return(nil, db.ErrRoleNameTaken).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Return(func(ctx context.Context, fn db.DatabaseTransactionFunc) error {
+				return fn(txCtx, dbtx)
+			}).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateRole(ctx, model.CreateRoleInput{Name: roles.RoleNameTeamviewer, Authorizations: authorizations})
+		assert.ErrorIs(t, err, apierror.ErrRoleNameTaken)
+	})
+
+	t.Run("create role", func(t *testing.T) {
+		role := &db.RoleDefinition{Role: &sqlc.Role{Name: "Team auditor", Description: "Read audit logs"}}
+		txCtx := context.Background()
+
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("CreateRoleDefinition", txCtx, roles.RoleName("Team auditor"), "Read audit logs").
+			Return(role, nil).
+			Once()
+		dbtx.
+			On("SetRoleAuthorizations", txCtx, roles.RoleName("Team auditor"), authorizations).
+			Return(nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				assert.NoError(t, fn(txCtx, dbtx))
+			}).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		created, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			CreateRole(ctx, model.CreateRoleInput{Name: "Team auditor", Description: helpers.Strp("Read audit logs"), Authorizations: authorizations})
+		assert.NoError(t, err)
+		assert.Equal(t, role, created)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiRolesCreate, entry.Fields.Action)
+		assert.Equal(t, []auditlogger.Target{auditlogger.RoleTarget("Team auditor")}, entry.Targets)
+	})
+}
+
+func TestMutationResolver_UpdateRole(t *testing.T) {
+	admin := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "admin@example.com", Name: "Admin"}}
+	ctx := authz.ContextWithActor(context.Background(), admin, []*db.Role{{RoleName: roles.RoleNameAdmin}})
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("built-in roles can not be updated", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleNameTeamowner).
+			Return(&db.RoleDefinition{Role: &sqlc.Role{Name: roles.RoleNameTeamowner, BuiltIn: true}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			UpdateRole(ctx, roles.RoleNameTeamowner, model.UpdateRoleInput{Authorizations: []roles.Authorization{}})
+		assert.ErrorIs(t, err, apierror.ErrRoleBuiltIn)
+	})
+
+	t.Run("update authorizations", func(t *testing.T) {
+		existing := &db.RoleDefinition{Role: &sqlc.Role{Name: "Team auditor", Description: "Read audit logs"}}
+		authorizations := []roles.Authorization{roles.AuthorizationAuditLogsRead, roles.AuthorizationTeamsRead}
+		txCtx := context.Background()
+
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("UpdateRoleDefinition", txCtx, roles.RoleName("Team auditor"), "Read audit logs").
+			Return(existing, nil).
+			Once()
+		dbtx.
+			On("SetRoleAuthorizations", txCtx, roles.RoleName("Team auditor"), authorizations).
+			Return(nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleName("Team auditor")).
+			Return(existing, nil).
+			Once()
+		database.
+			On("GetRoleAuthorizations", ctx, roles.RoleName("Team auditor")).
+			Return([]roles.Authorization{roles.AuthorizationAuditLogsRead}, nil).
+			Once()
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				assert.NoError(t, fn(txCtx, dbtx))
+			}).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		updated, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			UpdateRole(ctx, "Team auditor", model.UpdateRoleInput{Authorizations: authorizations})
+		assert.NoError(t, err)
+		assert.Equal(t, existing, updated)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, types.AuditActionGraphqlApiRolesUpdate, auditLogger.Entries()[0].Fields.Action)
+	})
+}
+
+func TestMutationResolver_DeleteRole(t *testing.T) {
+	admin := &db.User{User: &sqlc.User{ID: uuid.New(), Email: "admin@example.com", Name: "Admin"}}
+	ctx := authz.ContextWithActor(context.Background(), admin, []*db.Role{{RoleName: roles.RoleNameAdmin}})
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("role does not exist", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleName("Team auditor")).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			DeleteRole(ctx, "Team auditor")
+		assert.ErrorIs(t, err, apierror.ErrRoleNotExist)
+	})
+
+	t.Run("built-in roles can not be deleted", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleNameAdmin).
+			Return(&db.RoleDefinition{Role: &sqlc.Role{Name: roles.RoleNameAdmin, BuiltIn: true}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			DeleteRole(ctx, roles.RoleNameAdmin)
+		assert.ErrorIs(t, err, apierror.ErrRoleBuiltIn)
+	})
+
+	t.Run("delete role", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetRoleDefinition", ctx, roles.RoleName("Team auditor")).
+			Return(&db.RoleDefinition{Role: &sqlc.Role{Name: "Team auditor"}}, nil).
+			Once()
+		database.
+			On("DeleteRoleDefinition", ctx, roles.RoleName("Team auditor")).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		deleted, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			DeleteRole(ctx, "Team auditor")
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiRolesDelete, entry.Fields.Action)
+		assert.Equal(t, []auditlogger.Target{auditlogger.RoleTarget("Team auditor")}, entry.Targets)
 	})
 }
@@ -6,17 +6,329 @@ package graph
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/nais/teams-backend/pkg/auditlogger"
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/graph/apierror"
 	"github.com/nais/teams-backend/pkg/graph/generated"
-	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/types"
 )
 
+// CreateServiceAccount is the resolver for the createServiceAccount field.
+func (r *mutationResolver) CreateServiceAccount(ctx context.Context, input model.CreateServiceAccountInput) (*model.CreatedServiceAccount, error) {
+	actor := authz.ActorFromContext(ctx)
+	if actor.User.IsServiceAccount() {
+		return nil, apierror.Errorf("Service accounts are not allowed to create service accounts.")
+	}
+
+	var err error
+	if input.TeamSlug != nil {
+		err = authz.RequireTeamAuthorization(actor, roles.AuthorizationServiceAccountsCreate, *input.TeamSlug)
+	} else {
+		err = authz.RequireGlobalAuthorization(actor, roles.AuthorizationServiceAccountsCreate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	_, err = r.database.GetServiceAccountByName(ctx, input.Name)
+	if err == nil {
+		return nil, apierror.ErrServiceAccountNameTaken
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		r.log.WithError(err).Errorf("get service account by name")
+		return nil, apierror.ErrDatabase
+	}
+
+	apiKey, err := generateAPIKey()
+	if err != nil {
+		r.log.WithError(err).Errorf("generate service account API key")
+		return nil, apierror.ErrInternal
+	}
+
+	var serviceAccount *db.ServiceAccount
+	err = r.database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		serviceAccount, err = dbtx.CreateServiceAccount(ctx, input.Name, input.TeamSlug)
+		if err != nil {
+			return err
+		}
+
+		if input.TeamSlug != nil {
+			err = dbtx.AssignTeamRoleToServiceAccount(ctx, serviceAccount.ID, roles.RoleNameTeammember, *input.TeamSlug)
+		} else {
+			err = dbtx.AssignServiceAccountRoleToUser(ctx, actor.User.GetID(), roles.RoleNameServiceaccountowner, serviceAccount.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = dbtx.CreateAPIKey(ctx, apiKey, serviceAccount.ID, nil)
+		return err
+	})
+	if err != nil {
+		r.log.WithError(err).Errorf("create service account")
+		return nil, apierror.Errorf("Unable to create service account.")
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.ServiceAccountTarget(serviceAccount.Name),
+	}
+	if input.TeamSlug != nil {
+		targets = append(targets, auditlogger.TeamTarget(*input.TeamSlug))
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiServiceAccountCreate,
+		Actor:         actor,
+		CorrelationID: correlationID,
+		Changes: auditlogger.Changes{}.
+			Add("name", nil, serviceAccount.Name).
+			AddSecret("apiKey", false),
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Create service account %q", serviceAccount.Name)
+
+	return &model.CreatedServiceAccount{
+		ServiceAccount: serviceAccount,
+		APIKey:         apiKey,
+	}, nil
+}
+
+// UpdateServiceAccount is the resolver for the updateServiceAccount field.
+func (r *mutationResolver) UpdateServiceAccount(ctx context.Context, serviceAccountID *uuid.UUID, input model.UpdateServiceAccountInput) (*db.ServiceAccount, error) {
+	existing, err := r.getServiceAccount(ctx, *serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	err = requireServiceAccountAuthorization(actor, roles.AuthorizationServiceAccountsUpdate, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	if input.Name == nil || *input.Name == existing.Name {
+		return existing, nil
+	}
+
+	_, err = r.database.GetServiceAccountByName(ctx, *input.Name)
+	if err == nil {
+		return nil, apierror.ErrServiceAccountNameTaken
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		r.log.WithError(err).Errorf("get service account by name")
+		return nil, apierror.ErrDatabase
+	}
+
+	serviceAccount, err := r.database.UpdateServiceAccount(ctx, existing.ID, *input.Name)
+	if err != nil {
+		r.log.WithError(err).Errorf("update service account")
+		return nil, apierror.Errorf("Unable to update service account.")
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.ServiceAccountTarget(serviceAccount.Name),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiServiceAccountUpdate,
+		Actor:         actor,
+		CorrelationID: correlationID,
+		Changes: auditlogger.Changes{}.
+			Add("name", existing.Name, serviceAccount.Name),
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Rename service account %q to %q", existing.Name, serviceAccount.Name)
+
+	return serviceAccount, nil
+}
+
+// CreateAPIKey is the resolver for the createApiKey field.
+func (r *mutationResolver) CreateAPIKey(ctx context.Context, serviceAccountID *uuid.UUID, expiresAt *time.Time) (*model.CreatedAPIKey, error) {
+	serviceAccount, err := r.getServiceAccount(ctx, *serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	err = requireServiceAccountAuthorization(actor, roles.AuthorizationServiceAccountsUpdate, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, apierror.Errorf("The expiry time of the API key must be in the future.")
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		r.log.WithError(err).Errorf("generate service account API key")
+		return nil, apierror.ErrInternal
+	}
+
+	apiKey, err := r.database.CreateAPIKey(ctx, secret, serviceAccount.ID, expiresAt)
+	if err != nil {
+		r.log.WithError(err).Errorf("create API key")
+		return nil, apierror.Errorf("Unable to create API key.")
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.ServiceAccountTarget(serviceAccount.Name),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiServiceAccountCreateApiKey,
+		Actor:         actor,
+		CorrelationID: correlationID,
+		Changes: auditlogger.Changes{}.
+			Add("prefix", nil, apiKey.Prefix).
+			Add("expiresAt", nil, apiKey.ExpiresAt).
+			AddSecret("secret", false),
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Create API key %q for service account %q", apiKey.Prefix, serviceAccount.Name)
+
+	return &model.CreatedAPIKey{
+		APIKey: apiKey,
+		Secret: secret,
+	}, nil
+}
+
+// RevokeAPIKey is the resolver for the revokeApiKey field.
+func (r *mutationResolver) RevokeAPIKey(ctx context.Context, apiKeyID *uuid.UUID) (bool, error) {
+	apiKey, err := r.database.GetAPIKeyByID(ctx, *apiKeyID)
+	if err != nil {
+		return false, apierror.ErrAPIKeyNotExist
+	}
+
+	serviceAccount, err := r.getServiceAccount(ctx, apiKey.ServiceAccountID)
+	if err != nil {
+		return false, err
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	err = requireServiceAccountAuthorization(actor, roles.AuthorizationServiceAccountsUpdate, serviceAccount)
+	if err != nil {
+		return false, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return false, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	if err := r.database.RevokeAPIKey(ctx, apiKey.ID); err != nil {
+		r.log.WithError(err).Errorf("revoke API key")
+		return false, apierror.Errorf("Unable to revoke API key.")
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.ServiceAccountTarget(serviceAccount.Name),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiServiceAccountRevokeApiKey,
+		Actor:         actor,
+		CorrelationID: correlationID,
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Revoke API key %q of service account %q", apiKey.Prefix, serviceAccount.Name)
+
+	return true, nil
+}
+
+// DeleteServiceAccount is the resolver for the deleteServiceAccount field.
+func (r *mutationResolver) DeleteServiceAccount(ctx context.Context, serviceAccountID *uuid.UUID) (bool, error) {
+	serviceAccount, err := r.getServiceAccount(ctx, *serviceAccountID)
+	if err != nil {
+		return false, err
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	err = requireServiceAccountAuthorization(actor, roles.AuthorizationServiceAccountsDelete, serviceAccount)
+	if err != nil {
+		return false, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return false, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	if err := r.database.DeleteServiceAccount(ctx, serviceAccount.ID); err != nil {
+		r.log.WithError(err).Errorf("delete service account")
+		return false, apierror.Errorf("Unable to delete service account.")
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.ServiceAccountTarget(serviceAccount.Name),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiServiceAccountDelete,
+		Actor:         actor,
+		CorrelationID: correlationID,
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Delete service account %q", serviceAccount.Name)
+
+	return true, nil
+}
+
+// ServiceAccounts is the resolver for the serviceAccounts field.
+func (r *queryResolver) ServiceAccounts(ctx context.Context) ([]*db.ServiceAccount, error) {
+	serviceAccounts, err := r.database.GetServiceAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	if authz.RequireGlobalAuthorization(actor, roles.AuthorizationServiceAccountsList) == nil {
+		return serviceAccounts, nil
+	}
+
+	owned := make([]*db.ServiceAccount, 0)
+	for _, serviceAccount := range serviceAccounts {
+		if requireServiceAccountAuthorization(actor, roles.AuthorizationServiceAccountsRead, serviceAccount) == nil {
+			owned = append(owned, serviceAccount)
+		}
+	}
+
+	return owned, nil
+}
+
+// Team is the resolver for the team field.
+func (r *serviceAccountResolver) Team(ctx context.Context, obj *db.ServiceAccount) (*db.Team, error) {
+	if obj.TeamSlug == nil {
+		return nil, nil
+	}
+
+	return r.database.GetTeamBySlug(ctx, *obj.TeamSlug)
+}
+
 // Roles is the resolver for the roles field.
 func (r *serviceAccountResolver) Roles(ctx context.Context, obj *db.ServiceAccount) ([]*db.Role, error) {
 	actor := authz.ActorFromContext(ctx)
-	err := authz.RequireRole(actor, sqlc.RoleNameAdmin)
+	err := requireServiceAccountAuthorization(actor, roles.AuthorizationServiceAccountsRead, obj)
 	if err != nil && actor.User.GetID() != obj.ID {
 		return nil, err
 	}
@@ -24,6 +336,17 @@ func (r *serviceAccountResolver) Roles(ctx context.Context, obj *db.ServiceAccou
 	return r.database.GetServiceAccountRoles(ctx, obj.ID)
 }
 
+// APIKeys is the resolver for the apiKeys field.
+func (r *serviceAccountResolver) APIKeys(ctx context.Context, obj *db.ServiceAccount) ([]*db.ApiKey, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := requireServiceAccountAuthorization(actor, roles.AuthorizationServiceAccountsRead, obj)
+	if err != nil && actor.User.GetID() != obj.ID {
+		return nil, err
+	}
+
+	return r.database.GetServiceAccountAPIKeys(ctx, obj.ID)
+}
+
 // ServiceAccount returns generated.ServiceAccountResolver implementation.
 func (r *Resolver) ServiceAccount() generated.ServiceAccountResolver {
 	return &serviceAccountResolver{r}
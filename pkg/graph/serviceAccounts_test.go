@@ -3,18 +3,25 @@ package graph_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/auditlogger"
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/deployproxy"
 	"github.com/nais/teams-backend/pkg/graph"
+	"github.com/nais/teams-backend/pkg/graph/apierror"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/helpers"
 	"github.com/nais/teams-backend/pkg/logger"
 	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
-	"github.com/nais/teams-backend/pkg/usersync"
+	"github.com/nais/teams-backend/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMutationResolver_Roles(t *testing.T) {
@@ -26,14 +33,13 @@ func TestMutationResolver_Roles(t *testing.T) {
 	}
 	ctx := authz.ContextWithActor(context.Background(), serviceAccount, []*db.Role{
 		{
-			RoleName: sqlc.RoleNameAdmin,
+			RoleName: roles.RoleNameAdmin,
 			Authorizations: []roles.Authorization{
 				roles.AuthorizationTeamsCreate,
 			},
 		},
 	})
 
-	userSyncRuns := usersync.NewRunsHandler(5)
 	auditLogger := auditlogger.NewMockAuditLogger(t)
 	database := db.NewMockDatabase(t)
 	deployProxy := deployproxy.NewMockProxy(t)
@@ -41,7 +47,7 @@ func TestMutationResolver_Roles(t *testing.T) {
 	assert.NoError(t, err)
 	userSync := make(chan<- uuid.UUID)
 	resolver := graph.
-		NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{"env"}, log, userSyncRuns).
+		NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{"env"}, log).
 		ServiceAccount()
 
 	t.Run("get roles for serviceAccount", func(t *testing.T) {
@@ -60,3 +66,586 @@ func TestMutationResolver_Roles(t *testing.T) {
 		assert.Equal(t, roles[0], role)
 	})
 }
+
+func TestMutationResolver_CreateServiceAccount(t *testing.T) {
+	teamSlug := slug.Slug("my-team")
+
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	teamOwnerCtx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:       roles.RoleNameTeamowner,
+			TargetTeamSlug: &teamSlug,
+			Authorizations: []roles.Authorization{roles.AuthorizationServiceAccountsCreate},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("service accounts can not create service accounts", func(t *testing.T) {
+		serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "service-account"}}
+		ctx := authz.ContextWithActor(context.Background(), serviceAccount, []*db.Role{})
+		_, err := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateServiceAccount(ctx, model.CreateServiceAccountInput{Name: "ci-deployer"})
+		assert.ErrorContains(t, err, "Service accounts are not allowed to create service accounts.")
+	})
+
+	t.Run("team owner can not create service account without a team", func(t *testing.T) {
+		_, err := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateServiceAccount(teamOwnerCtx, model.CreateServiceAccountInput{Name: "ci-deployer"})
+		assert.ErrorContains(t, err, `required authorization: "service_accounts:create"`)
+	})
+
+	t.Run("reserved name", func(t *testing.T) {
+		_, err := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateServiceAccount(teamOwnerCtx, model.CreateServiceAccountInput{Name: "nais-deployer", TeamSlug: &teamSlug})
+		assert.ErrorIs(t, err, apierror.ErrServiceAccountNameReserved)
+	})
+
+	t.Run("name already taken", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByName", teamOwnerCtx, "ci-deployer").
+			Return(&db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateServiceAccount(teamOwnerCtx, model.CreateServiceAccountInput{Name: "ci-deployer", TeamSlug: &teamSlug})
+		assert.ErrorIs(t, err, apierror.ErrServiceAccountNameTaken)
+	})
+
+	t.Run("creator owns service account without a team", func(t *testing.T) {
+		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+			{
+				RoleName:       roles.RoleNameServiceaccountcreator,
+				Authorizations: []roles.Authorization{roles.AuthorizationServiceAccountsCreate},
+			},
+		})
+		serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}
+		txCtx := context.Background()
+
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("CreateServiceAccount", txCtx, "ci-deployer", (*slug.Slug)(nil)).
+			Return(serviceAccount, nil).
+			Once()
+		dbtx.
+			On("AssignServiceAccountRoleToUser", txCtx, user.ID, roles.RoleNameServiceaccountowner, serviceAccount.ID).
+			Return(nil).
+			Once()
+		dbtx.
+			On("CreateAPIKey", txCtx, mock.AnythingOfType("string"), serviceAccount.ID, (*time.Time)(nil)).
+			Return(&db.ApiKey{}, nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByName", ctx, "ci-deployer").
+			Return(nil, pgx.ErrNoRows).
+			Once()
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				assert.NoError(t, fn(txCtx, dbtx))
+			}).
+			Return(nil).
+			Once()
+
+		created, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateServiceAccount(ctx, model.CreateServiceAccountInput{Name: "ci-deployer"})
+		assert.NoError(t, err)
+		assert.Equal(t, serviceAccount, created.ServiceAccount)
+	})
+
+	t.Run("create service account for team", func(t *testing.T) {
+		var apiKey string
+		serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer", TeamSlug: &teamSlug}}
+		txCtx := context.Background()
+
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("CreateServiceAccount", txCtx, "ci-deployer", &teamSlug).
+			Return(serviceAccount, nil).
+			Once()
+		dbtx.
+			On("AssignTeamRoleToServiceAccount", txCtx, serviceAccount.ID, roles.RoleNameTeammember, teamSlug).
+			Return(nil).
+			Once()
+		dbtx.
+			On("CreateAPIKey", txCtx, mock.AnythingOfType("string"), serviceAccount.ID, (*time.Time)(nil)).
+			Run(func(args mock.Arguments) {
+				apiKey = args.String(1)
+			}).
+			Return(&db.ApiKey{}, nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByName", teamOwnerCtx, "ci-deployer").
+			Return(nil, pgx.ErrNoRows).
+			Once()
+		database.
+			On("Transaction", teamOwnerCtx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				assert.NoError(t, fn(txCtx, dbtx))
+			}).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		created, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			CreateServiceAccount(teamOwnerCtx, model.CreateServiceAccountInput{Name: "ci-deployer", TeamSlug: &teamSlug})
+		assert.NoError(t, err)
+		assert.Equal(t, serviceAccount, created.ServiceAccount)
+		assert.NotEmpty(t, created.APIKey)
+		assert.Equal(t, apiKey, created.APIKey)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiServiceAccountCreate, entry.Fields.Action)
+		assert.Equal(t, []auditlogger.Target{
+			auditlogger.ServiceAccountTarget("ci-deployer"),
+			auditlogger.TeamTarget(teamSlug),
+		}, entry.Targets)
+	})
+}
+
+func TestMutationResolver_UpdateServiceAccount(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:               roles.RoleNameServiceaccountowner,
+			TargetServiceAccountID: &serviceAccount.ID,
+			Authorizations:         []roles.Authorization{roles.AuthorizationServiceAccountsUpdate},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("not owner of service account", func(t *testing.T) {
+		other := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "other"}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, other.ID).
+			Return(other, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			UpdateServiceAccount(ctx, &other.ID, model.UpdateServiceAccountInput{Name: helpers.Strp("new-name")})
+		assert.ErrorContains(t, err, `required authorization: "service_accounts:update"`)
+	})
+
+	t.Run("owner of team owning the service account", func(t *testing.T) {
+		teamSlug := slug.Slug("my-team")
+		teamServiceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "team-deployer", TeamSlug: &teamSlug}}
+		renamed := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: teamServiceAccount.ID, Name: "new-name", TeamSlug: &teamSlug}}
+		teamOwnerCtx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+			{
+				RoleName:       roles.RoleNameTeamowner,
+				TargetTeamSlug: &teamSlug,
+				Authorizations: []roles.Authorization{roles.AuthorizationServiceAccountsUpdate},
+			},
+		})
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", teamOwnerCtx, teamServiceAccount.ID).
+			Return(teamServiceAccount, nil).
+			Once()
+		database.
+			On("GetServiceAccountByName", teamOwnerCtx, "new-name").
+			Return(nil, pgx.ErrNoRows).
+			Once()
+		database.
+			On("UpdateServiceAccount", teamOwnerCtx, teamServiceAccount.ID, "new-name").
+			Return(renamed, nil).
+			Once()
+
+		updated, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			UpdateServiceAccount(teamOwnerCtx, &teamServiceAccount.ID, model.UpdateServiceAccountInput{Name: helpers.Strp("new-name")})
+		assert.NoError(t, err)
+		assert.Equal(t, renamed, updated)
+	})
+
+	t.Run("static service account", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(&db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: serviceAccount.ID, Name: "nais-deploy"}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			UpdateServiceAccount(ctx, &serviceAccount.ID, model.UpdateServiceAccountInput{Name: helpers.Strp("new-name")})
+		assert.ErrorIs(t, err, apierror.ErrServiceAccountStatic)
+	})
+
+	t.Run("rename service account", func(t *testing.T) {
+		renamed := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: serviceAccount.ID, Name: "new-name"}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+		database.
+			On("GetServiceAccountByName", ctx, "new-name").
+			Return(nil, pgx.ErrNoRows).
+			Once()
+		database.
+			On("UpdateServiceAccount", ctx, serviceAccount.ID, "new-name").
+			Return(renamed, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		updated, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			UpdateServiceAccount(ctx, &serviceAccount.ID, model.UpdateServiceAccountInput{Name: helpers.Strp("new-name")})
+		assert.NoError(t, err)
+		assert.Equal(t, renamed, updated)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiServiceAccountUpdate, entry.Fields.Action)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "name", Before: "ci-deployer", After: "new-name"},
+		}, entry.Fields.Changes)
+	})
+}
+
+func TestMutationResolver_DeleteServiceAccount(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:               roles.RoleNameServiceaccountowner,
+			TargetServiceAccountID: &serviceAccount.ID,
+			Authorizations:         []roles.Authorization{roles.AuthorizationServiceAccountsDelete},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("service account does not exist", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			DeleteServiceAccount(ctx, &serviceAccount.ID)
+		assert.ErrorIs(t, err, apierror.ErrServiceAccountNotExist)
+	})
+
+	t.Run("delete service account", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+		database.
+			On("DeleteServiceAccount", ctx, serviceAccount.ID).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		deleted, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			DeleteServiceAccount(ctx, &serviceAccount.ID)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, types.AuditActionGraphqlApiServiceAccountDelete, auditLogger.Entries()[0].Fields.Action)
+	})
+}
+
+func TestQueryResolver_ServiceAccounts(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	owned := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "owned"}}
+	other := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "other"}}
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("list all service accounts", func(t *testing.T) {
+		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+			{
+				RoleName:       roles.RoleNameAdmin,
+				Authorizations: []roles.Authorization{roles.AuthorizationServiceAccountsList},
+			},
+		})
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccounts", ctx).
+			Return([]*db.ServiceAccount{owned, other}, nil).
+			Once()
+
+		serviceAccounts, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Query().
+			ServiceAccounts(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []*db.ServiceAccount{owned, other}, serviceAccounts)
+	})
+
+	t.Run("list owned service accounts", func(t *testing.T) {
+		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+			{
+				RoleName:               roles.RoleNameServiceaccountowner,
+				TargetServiceAccountID: &owned.ID,
+				Authorizations:         []roles.Authorization{roles.AuthorizationServiceAccountsRead},
+			},
+		})
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccounts", ctx).
+			Return([]*db.ServiceAccount{owned, other}, nil).
+			Once()
+
+		serviceAccounts, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Query().
+			ServiceAccounts(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []*db.ServiceAccount{owned}, serviceAccounts)
+	})
+
+	t.Run("list service accounts owned by team", func(t *testing.T) {
+		teamSlug := slug.Slug("my-team")
+		teamServiceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "team", TeamSlug: &teamSlug}}
+		ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+			{
+				RoleName:       roles.RoleNameTeammember,
+				TargetTeamSlug: &teamSlug,
+				Authorizations: []roles.Authorization{roles.AuthorizationServiceAccountsRead},
+			},
+		})
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccounts", ctx).
+			Return([]*db.ServiceAccount{owned, other, teamServiceAccount}, nil).
+			Once()
+
+		serviceAccounts, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Query().
+			ServiceAccounts(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []*db.ServiceAccount{teamServiceAccount}, serviceAccounts)
+	})
+}
+
+func TestMutationResolver_CreateAPIKey(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:               roles.RoleNameServiceaccountowner,
+			TargetServiceAccountID: &serviceAccount.ID,
+			Authorizations:         []roles.Authorization{roles.AuthorizationServiceAccountsUpdate},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			CreateAPIKey(ctx, &serviceAccount.ID, &expiresAt)
+		assert.ErrorContains(t, err, "The expiry time of the API key must be in the future.")
+	})
+
+	t.Run("create API key with expiry", func(t *testing.T) {
+		var secret string
+		expiresAt := time.Now().Add(time.Hour * 24 * 30)
+		apiKey := &db.ApiKey{ApiKey: &sqlc.ApiKey{
+			ID:               uuid.New(),
+			ServiceAccountID: serviceAccount.ID,
+			Prefix:           "abcdefgh",
+			ExpiresAt:        &expiresAt,
+		}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+		database.
+			On("CreateAPIKey", ctx, mock.AnythingOfType("string"), serviceAccount.ID, &expiresAt).
+			Run(func(args mock.Arguments) {
+				secret = args.String(1)
+			}).
+			Return(apiKey, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		created, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			CreateAPIKey(ctx, &serviceAccount.ID, &expiresAt)
+		assert.NoError(t, err)
+		assert.Equal(t, apiKey, created.APIKey)
+		assert.NotEmpty(t, created.Secret)
+		assert.Equal(t, secret, created.Secret)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiServiceAccountCreateApiKey, entry.Fields.Action)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "prefix", Before: nil, After: "abcdefgh"},
+			{Field: "expiresAt", Before: nil, After: &expiresAt},
+			{Field: "secret", Before: nil, After: auditlogger.RedactedValue},
+		}, entry.Fields.Changes)
+	})
+}
+
+func TestMutationResolver_RevokeAPIKey(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	serviceAccount := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "ci-deployer"}}
+	apiKey := &db.ApiKey{ApiKey: &sqlc.ApiKey{
+		ID:               uuid.New(),
+		ServiceAccountID: serviceAccount.ID,
+		Prefix:           "abcdefgh",
+	}}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:               roles.RoleNameServiceaccountowner,
+			TargetServiceAccountID: &serviceAccount.ID,
+			Authorizations:         []roles.Authorization{roles.AuthorizationServiceAccountsUpdate},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+
+	t.Run("API key of other service account", func(t *testing.T) {
+		other := &db.ServiceAccount{ServiceAccount: &sqlc.ServiceAccount{ID: uuid.New(), Name: "other"}}
+		otherKey := &db.ApiKey{ApiKey: &sqlc.ApiKey{ID: uuid.New(), ServiceAccountID: other.ID}}
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetAPIKeyByID", ctx, otherKey.ID).
+			Return(otherKey, nil).
+			Once()
+		database.
+			On("GetServiceAccountByID", ctx, other.ID).
+			Return(other, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditlogger.NewAuditLoggerForTesting(), []string{}, log).
+			Mutation().
+			RevokeAPIKey(ctx, &otherKey.ID)
+		assert.ErrorContains(t, err, `required authorization: "service_accounts:update"`)
+	})
+
+	t.Run("revoke API key", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetAPIKeyByID", ctx, apiKey.ID).
+			Return(apiKey, nil).
+			Once()
+		database.
+			On("GetServiceAccountByID", ctx, serviceAccount.ID).
+			Return(serviceAccount, nil).
+			Once()
+		database.
+			On("RevokeAPIKey", ctx, apiKey.ID).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		revoked, err := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Mutation().
+			RevokeAPIKey(ctx, &apiKey.ID)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, types.AuditActionGraphqlApiServiceAccountRevokeApiKey, auditLogger.Entries()[0].Fields.Action)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	pgx "github.com/jackc/pgx/v4"
@@ -23,6 +24,7 @@ import (
 	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/teamsync"
 	"github.com/nais/teams-backend/pkg/types"
 )
 
@@ -75,10 +77,10 @@ func (r *mutationResolver) CreateTeam(ctx context.Context, input model.CreateTea
 		}
 
 		if actor.User.IsServiceAccount() {
-			return dbtx.AssignTeamRoleToServiceAccount(ctx, actor.User.GetID(), sqlc.RoleNameTeamowner, *input.Slug)
+			return dbtx.AssignTeamRoleToServiceAccount(ctx, actor.User.GetID(), roles.RoleNameTeamowner, *input.Slug)
 		}
 
-		return dbtx.SetTeamMemberRole(ctx, actor.User.GetID(), team.Slug, sqlc.RoleNameTeamowner)
+		return dbtx.SetTeamMemberRole(ctx, actor.User.GetID(), team.Slug, roles.RoleNameTeamowner)
 	})
 	if err != nil {
 		return nil, err
@@ -125,12 +127,22 @@ func (r *mutationResolver) UpdateTeam(ctx context.Context, slug *slug.Slug, inpu
 
 	auditLogEntries := make([]auditlogger.Entry, 0)
 	err = r.database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		purposeBefore, slackChannelBefore := team.Purpose, team.SlackChannel
 		team, err = dbtx.UpdateTeam(ctx, team.Slug, input.Purpose, input.SlackChannel)
 		if err != nil {
 			return err
 		}
 
+		changes := auditlogger.Changes{}.
+			Add("purpose", purposeBefore, team.Purpose).
+			Add("slackChannel", slackChannelBefore, team.SlackChannel)
+
 		if len(input.SlackAlertsChannels) > 0 {
+			slackAlertsChannelsBefore, err := dbtx.GetSlackAlertsChannels(ctx, team.Slug)
+			if err != nil {
+				return err
+			}
+
 			for _, slackAlertsChannel := range input.SlackAlertsChannels {
 				var err error
 				if slackAlertsChannel.ChannelName == nil {
@@ -142,6 +154,12 @@ func (r *mutationResolver) UpdateTeam(ctx context.Context, slug *slug.Slug, inpu
 					return err
 				}
 			}
+
+			slackAlertsChannelsAfter, err := dbtx.GetSlackAlertsChannels(ctx, team.Slug)
+			if err != nil {
+				return err
+			}
+			changes = changes.Add("slackAlertsChannels", slackAlertsChannelsBefore, slackAlertsChannelsAfter)
 		}
 
 		targets := []auditlogger.Target{
@@ -151,6 +169,7 @@ func (r *mutationResolver) UpdateTeam(ctx context.Context, slug *slug.Slug, inpu
 			Action:        types.AuditActionGraphqlApiTeamUpdate,
 			CorrelationID: correlationID,
 			Actor:         actor,
+			Changes:       changes,
 		}
 		auditLogEntries = append(auditLogEntries, auditlogger.Entry{
 			Targets: targets,
@@ -172,6 +191,157 @@ func (r *mutationResolver) UpdateTeam(ctx context.Context, slug *slug.Slug, inpu
 	return team, nil
 }
 
+// SetTeamParent is the resolver for the setTeamParent field.
+func (r *mutationResolver) SetTeamParent(ctx context.Context, slug *slug.Slug, parentTeamSlug *slug.Slug) (*db.Team, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsUpdate, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := r.getTeamBySlug(ctx, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentTeamSlug != nil {
+		err = authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsUpdate, *parentTeamSlug)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = r.getTeamBySlug(ctx, *parentTeamSlug)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	parentBefore := team.ParentTeamSlug
+	team, err = r.database.SetTeamParent(ctx, team.Slug, parentTeamSlug)
+	if errors.Is(err, db.ErrTeamParentCycle) {
+		return nil, apierror.ErrTeamParentCycle
+	} else if err != nil {
+		return nil, err
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.TeamTarget(team.Slug),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiTeamSetParent,
+		CorrelationID: correlationID,
+		Actor:         actor,
+		Changes:       auditlogger.Changes{}.Add("parent", parentBefore, team.ParentTeamSlug),
+	}
+	if parentTeamSlug == nil {
+		r.auditLogger.Logf(ctx, targets, fields, "Removed team from parent team")
+	} else {
+		r.auditLogger.Logf(ctx, targets, fields, "Set parent team to %q", *parentTeamSlug)
+	}
+
+	// the parents are synchronized as well, as reconcilers can include sub-teams in the resources of the parent
+	r.reconcileTeam(ctx, correlationID, team.Slug)
+	if parentBefore != nil {
+		r.reconcileTeam(ctx, correlationID, *parentBefore)
+	}
+	if team.ParentTeamSlug != nil && (parentBefore == nil || *parentBefore != *team.ParentTeamSlug) {
+		r.reconcileTeam(ctx, correlationID, *team.ParentTeamSlug)
+	}
+
+	return team, nil
+}
+
+// DisableTeam is the resolver for the disableTeam field.
+func (r *mutationResolver) DisableTeam(ctx context.Context, slug *slug.Slug, suspendResources *bool) (*db.Team, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsUpdate, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := r.getTeamBySlug(ctx, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	suspend := suspendResources != nil && *suspendResources
+	team, err = r.database.DisableTeam(ctx, team.Slug, suspend)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.TeamTarget(team.Slug),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiTeamDisable,
+		CorrelationID: correlationID,
+		Actor:         actor,
+	}
+	if suspend {
+		r.auditLogger.Logf(ctx, targets, fields, "Disabled team and suspended resources")
+
+		// the members of the team are removed from the external resources by the reconcilers
+		r.reconcileTeam(ctx, correlationID, team.Slug)
+	} else {
+		r.auditLogger.Logf(ctx, targets, fields, "Disabled team")
+	}
+
+	return team, nil
+}
+
+// EnableTeam is the resolver for the enableTeam field.
+func (r *mutationResolver) EnableTeam(ctx context.Context, slug *slug.Slug) (*db.Team, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsUpdate, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := r.getTeamBySlug(ctx, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.requireNoTeamDeletion(ctx, team.Slug); err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	team, err = r.database.EnableTeam(ctx, team.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.TeamTarget(team.Slug),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiTeamEnable,
+		CorrelationID: correlationID,
+		Actor:         actor,
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Enabled team")
+
+	r.reconcileTeam(ctx, correlationID, team.Slug)
+
+	return team, nil
+}
+
 // RemoveUsersFromTeam is the resolver for the removeUsersFromTeam field.
 func (r *mutationResolver) RemoveUsersFromTeam(ctx context.Context, slug *slug.Slug, userIds []*uuid.UUID) (*db.Team, error) {
 	actor := authz.ActorFromContext(ctx)
@@ -212,11 +382,21 @@ func (r *mutationResolver) RemoveUsersFromTeam(ctx context.Context, slug *slug.S
 				return apierror.Errorf("The user %q is not a member of team %q.", *userID, *slug)
 			}
 
+			rolesBefore, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+			if err != nil {
+				return err
+			}
+
 			err = dbtx.RemoveUserFromTeam(ctx, *userID, team.Slug)
 			if err != nil {
 				return err
 			}
 
+			rolesAfter, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+			if err != nil {
+				return err
+			}
+
 			targets := []auditlogger.Target{
 				auditlogger.TeamTarget(team.Slug),
 				auditlogger.UserTarget(member.Email),
@@ -225,6 +405,7 @@ func (r *mutationResolver) RemoveUsersFromTeam(ctx context.Context, slug *slug.S
 				Action:        types.AuditActionGraphqlApiTeamRemoveMember,
 				CorrelationID: correlationID,
 				Actor:         actor,
+				Changes:       auditlogger.Changes{}.Add("roles", rolesBefore, rolesAfter),
 			}
 			auditLogEntries = append(auditLogEntries, auditlogger.Entry{
 				Targets: targets,
@@ -286,11 +467,21 @@ func (r *mutationResolver) RemoveUserFromTeam(ctx context.Context, slug *slug.Sl
 			return apierror.Errorf("The user %q is not a member of team %q.", *userID, *slug)
 		}
 
+		rolesBefore, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+		if err != nil {
+			return err
+		}
+
 		err = dbtx.RemoveUserFromTeam(ctx, *userID, team.Slug)
 		if err != nil {
 			return err
 		}
 
+		rolesAfter, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+		if err != nil {
+			return err
+		}
+
 		targets := []auditlogger.Target{
 			auditlogger.TeamTarget(team.Slug),
 			auditlogger.UserTarget(member.Email),
@@ -299,6 +490,7 @@ func (r *mutationResolver) RemoveUserFromTeam(ctx context.Context, slug *slug.Sl
 			Action:        types.AuditActionGraphqlApiTeamRemoveMember,
 			CorrelationID: correlationID,
 			Actor:         actor,
+			Changes:       auditlogger.Changes{}.Add("roles", rolesBefore, rolesAfter),
 		}
 		auditLogEntries = append(auditLogEntries, auditlogger.Entry{
 			Targets: targets,
@@ -321,18 +513,28 @@ func (r *mutationResolver) RemoveUserFromTeam(ctx context.Context, slug *slug.Sl
 }
 
 // SynchronizeTeam is the resolver for the synchronizeTeam field.
-func (r *mutationResolver) SynchronizeTeam(ctx context.Context, slug *slug.Slug) (*model.TeamSync, error) {
+func (r *mutationResolver) SynchronizeTeam(ctx context.Context, slug *slug.Slug, reconcilers []sqlc.ReconcilerName) (*model.TeamSync, error) {
 	actor := authz.ActorFromContext(ctx)
 	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsSynchronize, *slug)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, name := range reconcilers {
+		if !name.Valid() {
+			return nil, apierror.Errorf("%q is not a valid name", name)
+		}
+	}
+
 	team, err := r.getTeamBySlug(ctx, *slug)
 	if err != nil {
 		return nil, err
 	}
 
+	if !team.Enabled {
+		return nil, apierror.ErrTeamDisabled
+	}
+
 	correlationID, err := uuid.NewUUID()
 	if err != nil {
 		return nil, fmt.Errorf("create log correlation ID: %w", err)
@@ -346,9 +548,26 @@ func (r *mutationResolver) SynchronizeTeam(ctx context.Context, slug *slug.Slug)
 		CorrelationID: correlationID,
 		Actor:         actor,
 	}
-	r.auditLogger.Logf(ctx, targets, fields, "Manually scheduled for synchronization")
 
-	r.reconcileTeam(ctx, correlationID, team.Slug)
+	if len(reconcilers) > 0 {
+		names := make([]string, 0, len(reconcilers))
+		for _, name := range reconcilers {
+			names = append(names, string(name))
+		}
+		r.auditLogger.Logf(ctx, targets, fields, "Manually scheduled for synchronization with reconcilers: %s", strings.Join(names, ", "))
+	} else {
+		r.auditLogger.Logf(ctx, targets, fields, "Manually scheduled for synchronization")
+	}
+
+	err = r.addTeamToReconcilerQueue(ctx, teamsync.Input{
+		TeamSlug:      team.Slug,
+		CorrelationID: correlationID,
+		Trigger:       sqlc.TeamSyncTriggerManual,
+		Reconcilers:   reconcilers,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &model.TeamSync{
 		CorrelationID: &correlationID,
@@ -368,7 +587,7 @@ func (r *mutationResolver) SynchronizeAllTeams(ctx context.Context) (*model.Team
 		return nil, fmt.Errorf("create log correlation ID: %w", err)
 	}
 
-	teams, err := r.teamSyncHandler.ScheduleAllTeams(ctx, correlationID)
+	teams, err := r.teamSyncHandler.ScheduleAllTeams(ctx, correlationID, sqlc.TeamSyncTriggerManual)
 	if err != nil {
 		return nil, err
 	}
@@ -415,7 +634,17 @@ func (r *mutationResolver) AddTeamMembers(ctx context.Context, slug *slug.Slug,
 				return err
 			}
 
-			err = dbtx.SetTeamMemberRole(ctx, *userID, team.Slug, sqlc.RoleNameTeammember)
+			rolesBefore, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+			if err != nil {
+				return err
+			}
+
+			err = dbtx.SetTeamMemberRole(ctx, *userID, team.Slug, roles.RoleNameTeammember)
+			if err != nil {
+				return err
+			}
+
+			rolesAfter, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
 			if err != nil {
 				return err
 			}
@@ -428,6 +657,7 @@ func (r *mutationResolver) AddTeamMembers(ctx context.Context, slug *slug.Slug,
 				Action:        types.AuditActionGraphqlApiTeamAddMember,
 				CorrelationID: correlationID,
 				Actor:         actor,
+				Changes:       auditlogger.Changes{}.Add("roles", rolesBefore, rolesAfter),
 			}
 			auditLogEntries = append(auditLogEntries, auditlogger.Entry{
 				Targets: targets,
@@ -476,7 +706,17 @@ func (r *mutationResolver) AddTeamOwners(ctx context.Context, slug *slug.Slug, u
 				return err
 			}
 
-			err = dbtx.SetTeamMemberRole(ctx, *userID, team.Slug, sqlc.RoleNameTeamowner)
+			rolesBefore, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+			if err != nil {
+				return err
+			}
+
+			err = dbtx.SetTeamMemberRole(ctx, *userID, team.Slug, roles.RoleNameTeamowner)
+			if err != nil {
+				return err
+			}
+
+			rolesAfter, err := teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
 			if err != nil {
 				return err
 			}
@@ -489,6 +729,7 @@ func (r *mutationResolver) AddTeamOwners(ctx context.Context, slug *slug.Slug, u
 				Action:        types.AuditActionGraphqlApiTeamAddOwner,
 				CorrelationID: correlationID,
 				Actor:         actor,
+				Changes:       auditlogger.Changes{}.Add("roles", rolesBefore, rolesAfter),
 			}
 			auditLogEntries = append(auditLogEntries, auditlogger.Entry{
 				Targets: targets,
@@ -546,11 +787,21 @@ func (r *mutationResolver) AddTeamMember(ctx context.Context, slug *slug.Slug, m
 			return err
 		}
 
+		rolesBefore, err := teamRolesOfUser(ctx, dbtx, *member.UserID, team.Slug)
+		if err != nil {
+			return err
+		}
+
 		err = dbtx.SetTeamMemberRole(ctx, *member.UserID, team.Slug, role)
 		if err != nil {
 			return err
 		}
 
+		rolesAfter, err := teamRolesOfUser(ctx, dbtx, *member.UserID, team.Slug)
+		if err != nil {
+			return err
+		}
+
 		for _, reconcilerName := range member.ReconcilerOptOuts {
 			err = dbtx.AddReconcilerOptOut(ctx, member.UserID, &team.Slug, reconcilerName)
 			if err != nil {
@@ -566,10 +817,10 @@ func (r *mutationResolver) AddTeamMember(ctx context.Context, slug *slug.Slug, m
 		var action types.AuditAction
 		var msg string
 
-		if role == sqlc.RoleNameTeamowner {
+		if role == roles.RoleNameTeamowner {
 			action = types.AuditActionGraphqlApiTeamAddOwner
 			msg = fmt.Sprintf("Add team owner: %q", user.Email)
-		} else if role == sqlc.RoleNameTeammember {
+		} else if role == roles.RoleNameTeammember {
 			action = types.AuditActionGraphqlApiTeamAddMember
 			msg = fmt.Sprintf("Add team member: %q", user.Email)
 		} else {
@@ -580,6 +831,7 @@ func (r *mutationResolver) AddTeamMember(ctx context.Context, slug *slug.Slug, m
 			Action:        action,
 			CorrelationID: correlationID,
 			Actor:         actor,
+			Changes:       auditlogger.Changes{}.Add("roles", rolesBefore, rolesAfter),
 		}
 		auditLogEntries = append(auditLogEntries, auditlogger.Entry{
 			Targets: targets,
@@ -640,13 +892,25 @@ func (r *mutationResolver) SetTeamMemberRole(ctx context.Context, slug *slug.Slu
 		return nil, err
 	}
 
+	var rolesBefore, rolesAfter []roles.RoleName
 	err = r.database.Transaction(ctx, func(ctx context.Context, dbtx db.Database) error {
+		rolesBefore, err = teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+		if err != nil {
+			return err
+		}
+
 		err = dbtx.RemoveUserFromTeam(ctx, *userID, team.Slug)
 		if err != nil {
 			return err
 		}
 
-		return dbtx.SetTeamMemberRole(ctx, *userID, team.Slug, desiredRole)
+		err = dbtx.SetTeamMemberRole(ctx, *userID, team.Slug, desiredRole)
+		if err != nil {
+			return err
+		}
+
+		rolesAfter, err = teamRolesOfUser(ctx, dbtx, *userID, team.Slug)
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -660,6 +924,7 @@ func (r *mutationResolver) SetTeamMemberRole(ctx context.Context, slug *slug.Slu
 		Action:        types.AuditActionGraphqlApiTeamSetMemberRole,
 		CorrelationID: correlationID,
 		Actor:         actor,
+		Changes:       auditlogger.Changes{}.Add("roles", rolesBefore, rolesAfter),
 	}
 
 	r.auditLogger.Logf(ctx, targets, fields, "Assign %q to %s", desiredRole, member.Email)
@@ -686,6 +951,10 @@ func (r *mutationResolver) RequestTeamDeletion(ctx context.Context, slug *slug.S
 		return nil, err
 	}
 
+	if err := r.requireNoTeamDeletion(ctx, team.Slug); err != nil {
+		return nil, err
+	}
+
 	correlationID, err := uuid.NewUUID()
 	if err != nil {
 		return nil, fmt.Errorf("create log correlation ID: %w", err)
@@ -742,12 +1011,14 @@ func (r *mutationResolver) ConfirmTeamDeletion(ctx context.Context, key *uuid.UU
 		return nil, fmt.Errorf("create log correlation ID: %w", err)
 	}
 
-	err = r.database.ConfirmTeamDeleteKey(ctx, *key)
-	if err != nil {
-		return nil, fmt.Errorf("confirm team delete key: %w", err)
+	if err := r.requireNoTeamDeletion(ctx, deleteKey.TeamSlug); err != nil {
+		return nil, err
 	}
 
-	go r.teamSyncHandler.DeleteTeam(deleteKey.TeamSlug, correlationID)
+	deletion, err := r.teamSyncHandler.ScheduleTeamDeletion(ctx, deleteKey, correlationID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule team deletion: %w", err)
+	}
 
 	targets := []auditlogger.Target{
 		auditlogger.TeamTarget(deleteKey.TeamSlug),
@@ -757,11 +1028,59 @@ func (r *mutationResolver) ConfirmTeamDeletion(ctx context.Context, key *uuid.UU
 		Actor:         actor,
 		CorrelationID: correlationID,
 	}
-	r.auditLogger.Logf(ctx, targets, fields, "Delete team")
+	r.auditLogger.Logf(ctx, targets, fields, "Scheduled team for deletion at %s", deletion.DeleteAt.Format(time.RFC3339))
 
 	return &correlationID, nil
 }
 
+// CancelTeamDeletion is the resolver for the cancelTeamDeletion field.
+func (r *mutationResolver) CancelTeamDeletion(ctx context.Context, slug *slug.Slug) (*db.Team, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsUpdate, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := r.getTeamBySlug(ctx, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion, err := r.database.GetTeamDeletion(ctx, team.Slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apierror.ErrTeamDeletionNotScheduled
+		}
+		return nil, err
+	}
+
+	if deletion.StartedAt != nil {
+		return nil, apierror.ErrTeamDeletionStarted
+	}
+
+	team, err = r.database.CancelTeamDeletion(ctx, team.Slug)
+	if errors.Is(err, db.ErrTeamDeletionStarted) {
+		// the deletion was started after it was fetched
+		return nil, apierror.ErrTeamDeletionStarted
+	} else if err != nil {
+		return nil, err
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.TeamTarget(team.Slug),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiTeamCancelDeletion,
+		Actor:         actor,
+		CorrelationID: deletion.CorrelationID,
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Cancelled team deletion")
+
+	r.reconcileTeam(ctx, deletion.CorrelationID, team.Slug)
+
+	return team, nil
+}
+
 // AuthorizeRepository is the resolver for the authorizeRepository field.
 func (r *mutationResolver) AuthorizeRepository(ctx context.Context, authorization model.RepositoryAuthorization, teamSlug *slug.Slug, repoName string) (*db.Team, error) {
 	actor := authz.ActorFromContext(ctx)
@@ -922,6 +1241,98 @@ func (r *queryResolver) IsRepositoryAuthorized(ctx context.Context, repoName str
 	return false, nil
 }
 
+// PlanTeamSync is the resolver for the planTeamSync field.
+func (r *queryResolver) PlanTeamSync(ctx context.Context, slug *slug.Slug) ([]*model.ReconcilerPlan, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsSynchronize, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := r.getTeamBySlug(ctx, *slug)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := r.teamSyncHandler.PlanTeam(ctx, team.Slug)
+	if err != nil {
+		r.log.WithTeamSlug(string(team.Slug)).WithError(err).Errorf("plan team sync")
+		return nil, apierror.Errorf("Unable to plan synchronization of team: %q", team.Slug)
+	}
+
+	ret := make([]*model.ReconcilerPlan, 0, len(plans))
+	for _, plan := range plans {
+		reconcilerPlan := &model.ReconcilerPlan{
+			Reconciler: plan.Reconciler,
+			Changes:    make([]*model.PlannedChange, 0, len(plan.Changes)),
+		}
+
+		if plan.Error != nil {
+			errorMessage := plan.Error.Error()
+			reconcilerPlan.Error = &errorMessage
+		}
+
+		for _, change := range plan.Changes {
+			reconcilerPlan.Changes = append(reconcilerPlan.Changes, &model.PlannedChange{
+				Action:   model.PlannedChangeAction(change.Action),
+				Resource: change.Resource,
+				Details:  change.Details,
+			})
+		}
+
+		ret = append(ret, reconcilerPlan)
+	}
+
+	return ret, nil
+}
+
+// TeamSyncProgress is the resolver for the teamSyncProgress field.
+func (r *subscriptionResolver) TeamSyncProgress(ctx context.Context, correlationID *uuid.UUID) (<-chan *model.TeamSyncProgressEvent, error) {
+	actor := authz.ActorFromContext(ctx)
+	events := r.teamSyncHandler.SubscribeProgress(ctx, *correlationID)
+
+	ch := make(chan *model.TeamSyncProgressEvent)
+	go func() {
+		defer close(ch)
+		for event := range events {
+			if authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsRead, event.TeamSlug) != nil {
+				continue
+			}
+
+			correlationID := event.CorrelationID
+			teamSlug := event.TeamSlug
+			select {
+			case ch <- &model.TeamSyncProgressEvent{
+				CorrelationID: &correlationID,
+				TeamSlug:      &teamSlug,
+				Reconciler:    event.Reconciler,
+				State:         model.TeamSyncProgressState(event.State),
+				Error:         event.Error,
+				CreatedAt:     event.CreatedAt,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// Parent is the resolver for the parent field.
+func (r *teamResolver) Parent(ctx context.Context, obj *db.Team) (*db.Team, error) {
+	if obj.ParentTeamSlug == nil {
+		return nil, nil
+	}
+
+	return r.database.GetTeamBySlug(ctx, *obj.ParentTeamSlug)
+}
+
+// Children is the resolver for the children field.
+func (r *teamResolver) Children(ctx context.Context, obj *db.Team) ([]*db.Team, error) {
+	return r.database.GetTeamChildren(ctx, obj.Slug)
+}
+
 // AuditLogs is the resolver for the auditLogs field.
 func (r *teamResolver) AuditLogs(ctx context.Context, obj *db.Team) ([]*db.AuditLog, error) {
 	actor := authz.ActorFromContext(ctx)
@@ -973,6 +1384,17 @@ func (r *teamResolver) Members(ctx context.Context, obj *db.Team) ([]*model.Team
 	return members, nil
 }
 
+// ServiceAccounts is the resolver for the serviceAccounts field.
+func (r *teamResolver) ServiceAccounts(ctx context.Context, obj *db.Team) ([]*db.ServiceAccount, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationServiceAccountsRead, obj.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.database.GetTeamServiceAccounts(ctx, obj.Slug)
+}
+
 // SyncErrors is the resolver for the syncErrors field.
 func (r *teamResolver) SyncErrors(ctx context.Context, obj *db.Team) ([]*model.SyncError, error) {
 	actor := authz.ActorFromContext(ctx)
@@ -989,15 +1411,78 @@ func (r *teamResolver) SyncErrors(ctx context.Context, obj *db.Team) ([]*model.S
 	syncErrors := make([]*model.SyncError, 0)
 	for _, row := range rows {
 		syncErrors = append(syncErrors, &model.SyncError{
-			CreatedAt:  row.CreatedAt,
-			Reconciler: row.Reconciler,
-			Error:      row.ErrorMessage,
+			CreatedAt:   row.CreatedAt,
+			Reconciler:  row.Reconciler,
+			Error:       row.ErrorMessage,
+			Attempts:    int(row.Attempts),
+			NextRetryAt: row.NextRetryAt,
+			Kind:        model.ReconcilerErrorKind(strings.ToUpper(string(row.Kind))),
 		})
 	}
 
 	return syncErrors, nil
 }
 
+// SyncHistory is the resolver for the syncHistory field.
+func (r *teamResolver) SyncHistory(ctx context.Context, obj *db.Team, first *int, after *string) (*model.TeamSyncRunConnection, error) {
+	actor := authz.ActorFromContext(ctx)
+	err := authz.RequireTeamAuthorization(actor, roles.AuthorizationTeamsRead, obj.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := defaultTeamSyncRunsLimit
+	if first != nil {
+		limit = *first
+	}
+
+	if limit < 1 || limit > maxTeamSyncRunsLimit {
+		return nil, apierror.Errorf("The number of runs must be between 1 and %d.", maxTeamSyncRunsLimit)
+	}
+
+	var cursor *db.TeamSyncRunCursor
+	if after != nil {
+		cursor, err = decodeTeamSyncRunCursor(*after)
+		if err != nil {
+			return nil, apierror.Errorf("Invalid cursor: %q.", *after)
+		}
+	}
+
+	// fetch one extra run to find out if there is a next page
+	runs, err := r.database.GetTeamSyncRuns(ctx, obj.Slug, cursor, limit+1)
+	if err != nil {
+		r.log.WithError(err).Errorf("get team sync runs")
+		return nil, apierror.Errorf("Unable to get the synchronization history of the team.")
+	}
+
+	hasNextPage := len(runs) > limit
+	if hasNextPage {
+		runs = runs[:limit]
+	}
+
+	edges := make([]*model.TeamSyncRunEdge, len(runs))
+	for i, run := range runs {
+		edges[i] = &model.TeamSyncRunEdge{
+			Cursor: encodeTeamSyncRunCursor(run),
+			Node:   run,
+		}
+	}
+
+	pageInfo := &model.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: cursor != nil,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &model.TeamSyncRunConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}, nil
+}
+
 // ReconcilerState is the resolver for the reconcilerState field.
 func (r *teamResolver) ReconcilerState(ctx context.Context, obj *db.Team) (*model.ReconcilerState, error) {
 	gitHubState := &reconcilers.GitHubState{}
@@ -1148,6 +1633,31 @@ func (r *teamResolver) DeletionInProgress(ctx context.Context, obj *db.Team) (bo
 	return false, err
 }
 
+// Deletion is the resolver for the deletion field.
+func (r *teamResolver) Deletion(ctx context.Context, obj *db.Team) (*db.TeamDeletion, error) {
+	deletion, err := r.database.GetTeamDeletion(ctx, obj.Slug)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return deletion, err
+}
+
+// Reconcilers is the resolver for the reconcilers field.
+func (r *teamDeletionResolver) Reconcilers(ctx context.Context, obj *db.TeamDeletion) ([]*db.TeamDeletionReconciler, error) {
+	return r.database.GetTeamDeletionReconcilers(ctx, obj.TeamSlug)
+}
+
+// Status is the resolver for the status field.
+func (r *teamDeletionReconcilerResolver) Status(ctx context.Context, obj *db.TeamDeletionReconciler) (model.TeamDeletionReconcilerStatus, error) {
+	return model.TeamDeletionReconcilerStatus(strings.ToUpper(string(obj.Status))), nil
+}
+
+// Error is the resolver for the error field.
+func (r *teamDeletionReconcilerResolver) Error(ctx context.Context, obj *db.TeamDeletionReconciler) (*string, error) {
+	return obj.ErrorMessage, nil
+}
+
 // CreatedBy is the resolver for the createdBy field.
 func (r *teamDeleteKeyResolver) CreatedBy(ctx context.Context, obj *db.TeamDeleteKey) (*db.User, error) {
 	return r.database.GetUserByID(ctx, obj.CreatedBy)
@@ -1168,6 +1678,50 @@ func (r *teamMemberReconcilerResolver) Reconciler(ctx context.Context, obj *sqlc
 	return reconciler, nil
 }
 
+// Trigger is the resolver for the trigger field.
+func (r *teamSyncRunResolver) Trigger(ctx context.Context, obj *db.TeamSyncRun) (model.TeamSyncTrigger, error) {
+	return model.TeamSyncTrigger(strings.ToUpper(string(obj.TriggeredBy))), nil
+}
+
+// RequestedReconcilers is the resolver for the requestedReconcilers field.
+func (r *teamSyncRunResolver) RequestedReconcilers(ctx context.Context, obj *db.TeamSyncRun) ([]sqlc.ReconcilerName, error) {
+	reconcilerNames := make([]sqlc.ReconcilerName, 0, len(obj.Reconcilers))
+	for _, name := range obj.Reconcilers {
+		reconcilerNames = append(reconcilerNames, sqlc.ReconcilerName(name))
+	}
+	return reconcilerNames, nil
+}
+
+// Status is the resolver for the status field.
+func (r *teamSyncRunResolver) Status(ctx context.Context, obj *db.TeamSyncRun) (model.TeamSyncRunStatus, error) {
+	return model.TeamSyncRunStatus(strings.ToUpper(string(obj.Status))), nil
+}
+
+// Error is the resolver for the error field.
+func (r *teamSyncRunResolver) Error(ctx context.Context, obj *db.TeamSyncRun) (*string, error) {
+	return obj.ErrorMessage, nil
+}
+
+// Reconcilers is the resolver for the reconcilers field.
+func (r *teamSyncRunResolver) Reconcilers(ctx context.Context, obj *db.TeamSyncRun) ([]*db.TeamSyncRunReconciler, error) {
+	return r.database.GetTeamSyncRunReconcilers(ctx, obj.ID)
+}
+
+// Status is the resolver for the status field.
+func (r *teamSyncRunReconcilerResolver) Status(ctx context.Context, obj *db.TeamSyncRunReconciler) (model.TeamSyncReconcilerStatus, error) {
+	return model.TeamSyncReconcilerStatus(strings.ToUpper(string(obj.Status))), nil
+}
+
+// DurationMs is the resolver for the durationMs field.
+func (r *teamSyncRunReconcilerResolver) DurationMs(ctx context.Context, obj *db.TeamSyncRunReconciler) (int, error) {
+	return int(obj.FinishedAt.Sub(obj.StartedAt).Milliseconds()), nil
+}
+
+// Error is the resolver for the error field.
+func (r *teamSyncRunReconcilerResolver) Error(ctx context.Context, obj *db.TeamSyncRunReconciler) (*string, error) {
+	return obj.ErrorMessage, nil
+}
+
 // GitHubRepository returns generated.GitHubRepositoryResolver implementation.
 func (r *Resolver) GitHubRepository() generated.GitHubRepositoryResolver {
 	return &gitHubRepositoryResolver{r}
@@ -1176,6 +1730,14 @@ func (r *Resolver) GitHubRepository() generated.GitHubRepositoryResolver {
 // Team returns generated.TeamResolver implementation.
 func (r *Resolver) Team() generated.TeamResolver { return &teamResolver{r} }
 
+// TeamDeletion returns generated.TeamDeletionResolver implementation.
+func (r *Resolver) TeamDeletion() generated.TeamDeletionResolver { return &teamDeletionResolver{r} }
+
+// TeamDeletionReconciler returns generated.TeamDeletionReconcilerResolver implementation.
+func (r *Resolver) TeamDeletionReconciler() generated.TeamDeletionReconcilerResolver {
+	return &teamDeletionReconcilerResolver{r}
+}
+
 // TeamDeleteKey returns generated.TeamDeleteKeyResolver implementation.
 func (r *Resolver) TeamDeleteKey() generated.TeamDeleteKeyResolver { return &teamDeleteKeyResolver{r} }
 
@@ -1184,9 +1746,21 @@ func (r *Resolver) TeamMemberReconciler() generated.TeamMemberReconcilerResolver
 	return &teamMemberReconcilerResolver{r}
 }
 
+// TeamSyncRun returns generated.TeamSyncRunResolver implementation.
+func (r *Resolver) TeamSyncRun() generated.TeamSyncRunResolver { return &teamSyncRunResolver{r} }
+
+// TeamSyncRunReconciler returns generated.TeamSyncRunReconcilerResolver implementation.
+func (r *Resolver) TeamSyncRunReconciler() generated.TeamSyncRunReconcilerResolver {
+	return &teamSyncRunReconcilerResolver{r}
+}
+
 type (
-	gitHubRepositoryResolver     struct{ *Resolver }
-	teamResolver                 struct{ *Resolver }
-	teamDeleteKeyResolver        struct{ *Resolver }
-	teamMemberReconcilerResolver struct{ *Resolver }
+	gitHubRepositoryResolver       struct{ *Resolver }
+	teamResolver                   struct{ *Resolver }
+	teamDeleteKeyResolver          struct{ *Resolver }
+	teamDeletionResolver           struct{ *Resolver }
+	teamDeletionReconcilerResolver struct{ *Resolver }
+	teamMemberReconcilerResolver   struct{ *Resolver }
+	teamSyncRunResolver            struct{ *Resolver }
+	teamSyncRunReconcilerResolver  struct{ *Resolver }
 )
@@ -2,11 +2,13 @@ package graph_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/auditlogger"
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
@@ -14,13 +16,14 @@ import (
 	"github.com/nais/teams-backend/pkg/graph"
 	"github.com/nais/teams-backend/pkg/graph/apierror"
 	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/helpers"
 	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/reconcilers"
 	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/nais/teams-backend/pkg/teamsync"
 	"github.com/nais/teams-backend/pkg/types"
-	"github.com/nais/teams-backend/pkg/usersync"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -36,7 +39,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 
 	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
 		{
-			RoleName: sqlc.RoleNameAdmin,
+			RoleName: roles.RoleNameAdmin,
 			Authorizations: []roles.Authorization{
 				roles.AuthorizationTeamsCreate,
 			},
@@ -52,14 +55,13 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 
 	saCtx := authz.ContextWithActor(context.Background(), serviceAccount, []*db.Role{
 		{
-			RoleName: sqlc.RoleNameAdmin,
+			RoleName: roles.RoleNameAdmin,
 			Authorizations: []roles.Authorization{
 				roles.AuthorizationTeamsCreate,
 			},
 		},
 	})
 
-	userSyncRuns := usersync.NewRunsHandler(5)
 	database := db.NewMockDatabase(t)
 	teamSyncHandler := teamsync.NewMockHandler(t)
 
@@ -74,7 +76,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 
 	t.Run("create team with empty purpose", func(t *testing.T) {
 		_, err := graph.
-			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log, userSyncRuns).
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
 			Mutation().
 			CreateTeam(ctx, model.CreateTeamInput{
 				Slug:         &teamSlug,
@@ -96,7 +98,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 			Return(createdTeam, nil).
 			Once()
 		dbtx.
-			On("SetTeamMemberRole", txCtx, user.ID, createdTeam.Slug, sqlc.RoleNameTeamowner).
+			On("SetTeamMemberRole", txCtx, user.ID, createdTeam.Slug, roles.RoleNameTeamowner).
 			Return(nil).
 			Once()
 
@@ -110,7 +112,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 			Once()
 
 		teamSyncHandler.
-			On("Schedule", mock.MatchedBy(func(input teamsync.Input) bool {
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
 				return input.TeamSlug == createdTeam.Slug
 			})).
 			Return(nil).
@@ -118,7 +120,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 
 		auditLogger := auditlogger.NewAuditLoggerForTesting()
 		returnedTeam, err := graph.
-			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log, userSyncRuns).
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
 			Mutation().
 			CreateTeam(ctx, model.CreateTeamInput{
 				Slug:         &teamSlug,
@@ -148,7 +150,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 			Once()
 
 		dbtx.
-			On("AssignTeamRoleToServiceAccount", txCtx, serviceAccount.GetID(), sqlc.RoleNameTeamowner, teamSlug).
+			On("AssignTeamRoleToServiceAccount", txCtx, serviceAccount.GetID(), roles.RoleNameTeamowner, teamSlug).
 			Return(nil).
 			Once()
 
@@ -162,7 +164,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 			Once()
 
 		teamSyncHandler.
-			On("Schedule", mock.MatchedBy(func(input teamsync.Input) bool {
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
 				return input.TeamSlug == createdTeam.Slug
 			})).
 			Return(nil).
@@ -170,7 +172,7 @@ func TestMutationResolver_CreateTeam(t *testing.T) {
 
 		auditLogger := auditlogger.NewAuditLoggerForTesting()
 		returnedTeam, err := graph.
-			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log, userSyncRuns).
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
 			Mutation().CreateTeam(saCtx, model.CreateTeamInput{
 			Slug:         &teamSlug,
 			Purpose:      " some purpose ",
@@ -201,11 +203,10 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 	gcpEnvironments := []string{"env"}
 	ctx := context.Background()
 	teamSlug := slug.Slug("my-team")
-	userSyncRuns := usersync.NewRunsHandler(5)
 
 	t.Run("service accounts can not create delete keys", func(t *testing.T) {
 		resolver := graph.
-			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log, userSyncRuns).
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
 			Mutation()
 
 		serviceAccount := db.ServiceAccount{
@@ -223,7 +224,7 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 
 	t.Run("missing authz", func(t *testing.T) {
 		resolver := graph.
-			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log, userSyncRuns).
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
 			Mutation()
 
 		user := db.User{
@@ -250,7 +251,7 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 		}
 		ctx := authz.ContextWithActor(ctx, user, []*db.Role{
 			{
-				RoleName: sqlc.RoleNameTeamowner,
+				RoleName: roles.RoleNameTeamowner,
 				Authorizations: []roles.Authorization{
 					roles.AuthorizationTeamsUpdate,
 				},
@@ -264,7 +265,7 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 			Once()
 
 		resolver := graph.
-			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log, userSyncRuns).
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
 			Mutation()
 
 		key, err := resolver.RequestTeamDeletion(ctx, &teamSlug)
@@ -289,7 +290,7 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 		}
 		ctx := authz.ContextWithActor(ctx, user, []*db.Role{
 			{
-				RoleName: sqlc.RoleNameTeamowner,
+				RoleName: roles.RoleNameTeamowner,
 				Authorizations: []roles.Authorization{
 					roles.AuthorizationTeamsUpdate,
 				},
@@ -311,6 +312,10 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 			On("GetTeamBySlug", ctx, teamSlug).
 			Return(team, nil).
 			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(nil, pgx.ErrNoRows).
+			Once()
 		database.
 			On("CreateTeamDeleteKey", ctx, teamSlug, userID).
 			Return(key, nil).
@@ -318,7 +323,7 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 
 		auditLogger := auditlogger.NewAuditLoggerForTesting()
 		resolver := graph.
-			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log, userSyncRuns).
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
 			Mutation()
 
 		returnedKey, err := resolver.RequestTeamDeletion(ctx, &teamSlug)
@@ -335,3 +340,1079 @@ func TestMutationResolver_RequestTeamDeletion(t *testing.T) {
 		assert.Equal(t, "Request team deletion", entry.Message)
 	})
 }
+
+func TestMutationResolver_ConfirmTeamDeletion(t *testing.T) {
+	const tenantDomain = "example.com"
+	deployProxy := deployproxy.NewMockProxy(t)
+	log := logger.NewMockLogger(t)
+	log.
+		On("WithComponent", types.ComponentNameGraphqlApi).
+		Return(log)
+	userSync := make(chan<- uuid.UUID)
+	gcpEnvironments := []string{"env"}
+	teamSlug := slug.Slug("my-team")
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:       roles.RoleNameTeamowner,
+			TargetTeamSlug: &teamSlug,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsUpdate,
+			},
+		},
+	})
+	key := &db.TeamDeleteKey{
+		TeamDeleteKey: &sqlc.TeamDeleteKey{
+			Key:       uuid.New(),
+			TeamSlug:  teamSlug,
+			CreatedAt: time.Now(),
+			CreatedBy: uuid.New(),
+		},
+	}
+
+	t.Run("team already scheduled for deletion", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamDeleteKey", ctx, key.Key).
+			Return(key, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(&db.TeamDeletion{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: teamSlug}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
+			Mutation().
+			ConfirmTeamDeletion(ctx, &key.Key)
+		assert.ErrorIs(t, err, apierror.ErrTeamDeletionScheduled)
+	})
+
+	t.Run("confirm key and schedule deletion", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamDeleteKey", ctx, key.Key).
+			Return(key, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("ScheduleTeamDeletion", ctx, key, mock.AnythingOfType("uuid.UUID")).
+			Return(&db.TeamDeletion{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: teamSlug, DeleteAt: time.Now()}}, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		correlationID, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			ConfirmTeamDeletion(ctx, &key.Key)
+		assert.NoError(t, err)
+		assert.NotNil(t, correlationID)
+		assert.Len(t, auditLogger.Entries(), 1)
+	})
+}
+
+func TestMutationResolver_CancelTeamDeletion(t *testing.T) {
+	const tenantDomain = "example.com"
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	gcpEnvironments := []string{"env"}
+	teamSlug := slug.Slug("my-team")
+	correlationID := uuid.New()
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:       roles.RoleNameTeamowner,
+			TargetTeamSlug: &teamSlug,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsUpdate,
+			},
+		},
+	})
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug: teamSlug,
+		},
+	}
+
+	t.Run("no scheduled deletion", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		returnedTeam, err := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
+			Mutation().
+			CancelTeamDeletion(ctx, &teamSlug)
+		assert.Nil(t, returnedTeam)
+		assert.ErrorIs(t, err, apierror.ErrTeamDeletionNotScheduled)
+	})
+
+	t.Run("deletion has started", func(t *testing.T) {
+		startedAt := time.Now()
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(&db.TeamDeletion{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: teamSlug, StartedAt: &startedAt}}, nil).
+			Once()
+
+		returnedTeam, err := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
+			Mutation().
+			CancelTeamDeletion(ctx, &teamSlug)
+		assert.Nil(t, returnedTeam)
+		assert.ErrorIs(t, err, apierror.ErrTeamDeletionStarted)
+	})
+
+	t.Run("deletion starts after it has been fetched", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(&db.TeamDeletion{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: teamSlug, CorrelationID: correlationID}}, nil).
+			Once()
+		database.
+			On("CancelTeamDeletion", ctx, teamSlug).
+			Return(nil, db.ErrTeamDeletionStarted).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		returnedTeam, err := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			CancelTeamDeletion(ctx, &teamSlug)
+		assert.Nil(t, returnedTeam)
+		assert.ErrorIs(t, err, apierror.ErrTeamDeletionStarted)
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("cancel deletion", func(t *testing.T) {
+		enabledTeam := &db.Team{Team: &sqlc.Team{Slug: teamSlug, Enabled: true}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(&db.TeamDeletion{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: teamSlug, CorrelationID: correlationID}}, nil).
+			Once()
+		database.
+			On("CancelTeamDeletion", ctx, teamSlug).
+			Return(enabledTeam, nil).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("Schedule", mock.Anything, teamsync.Input{TeamSlug: teamSlug, CorrelationID: correlationID, Trigger: sqlc.TeamSyncTriggerChange}).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		returnedTeam, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			CancelTeamDeletion(ctx, &teamSlug)
+		assert.NoError(t, err)
+		assert.Equal(t, enabledTeam, returnedTeam)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, types.AuditActionGraphqlApiTeamCancelDeletion, auditLogger.Entries()[0].Fields.Action)
+		assert.Equal(t, correlationID, auditLogger.Entries()[0].Fields.CorrelationID)
+	})
+}
+
+func TestMutationResolver_SynchronizeTeam(t *testing.T) {
+	const tenantDomain = "example.com"
+	database := db.NewMockDatabase(t)
+	deployProxy := deployproxy.NewMockProxy(t)
+	log := logger.NewMockLogger(t)
+	log.
+		On("WithComponent", types.ComponentNameGraphqlApi).
+		Return(log)
+	userSync := make(chan<- uuid.UUID)
+	gcpEnvironments := []string{"env"}
+	teamSlug := slug.Slug("my-team")
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName: roles.RoleNameTeamowner,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsSynchronize,
+			},
+		},
+	})
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Enabled: true,
+		},
+	}
+
+	t.Run("invalid reconciler name", func(t *testing.T) {
+		resolver := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
+			Mutation()
+
+		sync, err := resolver.SynchronizeTeam(ctx, &teamSlug, []sqlc.ReconcilerName{"invalid"})
+		assert.Nil(t, sync)
+		assert.ErrorContains(t, err, `"invalid" is not a valid name`)
+	})
+
+	t.Run("synchronize selected reconcilers", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+
+		selected := []sqlc.ReconcilerName{sqlc.ReconcilerNameGithubTeam}
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("Schedule", ctx, mock.MatchedBy(func(input teamsync.Input) bool {
+				return input.TeamSlug == teamSlug && assert.Equal(t, selected, input.Reconcilers)
+			})).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		resolver := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation()
+
+		sync, err := resolver.SynchronizeTeam(ctx, &teamSlug, selected)
+		assert.NoError(t, err)
+		assert.NotNil(t, sync.CorrelationID)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, "Manually scheduled for synchronization with reconcilers: github:team", auditLogger.Entries()[0].Message)
+	})
+
+	t.Run("disabled team", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(&db.Team{Team: &sqlc.Team{Slug: teamSlug}}, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		resolver := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation()
+
+		sync, err := resolver.SynchronizeTeam(ctx, &teamSlug, nil)
+		assert.Nil(t, sync)
+		assert.ErrorIs(t, err, apierror.ErrTeamDisabled)
+		assert.Empty(t, auditLogger.Entries())
+	})
+}
+
+func TestMutationResolver_DisableTeam(t *testing.T) {
+	const tenantDomain = "example.com"
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	gcpEnvironments := []string{"env"}
+	teamSlug := slug.Slug("my-team")
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:       roles.RoleNameTeamowner,
+			TargetTeamSlug: &teamSlug,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsUpdate,
+			},
+		},
+	})
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Enabled: true,
+		},
+	}
+
+	t.Run("disable team", func(t *testing.T) {
+		disabledTeam := &db.Team{Team: &sqlc.Team{Slug: teamSlug}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("DisableTeam", ctx, teamSlug, false).
+			Return(disabledTeam, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		returnedTeam, err := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			DisableTeam(ctx, &teamSlug, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, disabledTeam, returnedTeam)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, types.AuditActionGraphqlApiTeamDisable, auditLogger.Entries()[0].Fields.Action)
+		assert.Equal(t, "Disabled team", auditLogger.Entries()[0].Message)
+	})
+
+	t.Run("disable team and suspend resources", func(t *testing.T) {
+		disabledTeam := &db.Team{Team: &sqlc.Team{Slug: teamSlug, ResourcesSuspended: true}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("DisableTeam", ctx, teamSlug, true).
+			Return(disabledTeam, nil).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
+				return input.TeamSlug == teamSlug
+			})).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		returnedTeam, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			DisableTeam(ctx, &teamSlug, helpers.Boolp(true))
+		assert.NoError(t, err)
+		assert.Equal(t, disabledTeam, returnedTeam)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, "Disabled team and suspended resources", auditLogger.Entries()[0].Message)
+	})
+
+	t.Run("enable team", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(&db.Team{Team: &sqlc.Team{Slug: teamSlug, ResourcesSuspended: true}}, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+		database.
+			On("EnableTeam", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
+				return input.TeamSlug == teamSlug
+			})).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		returnedTeam, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			EnableTeam(ctx, &teamSlug)
+		assert.NoError(t, err)
+		assert.Equal(t, team, returnedTeam)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, types.AuditActionGraphqlApiTeamEnable, auditLogger.Entries()[0].Fields.Action)
+	})
+
+	t.Run("team scheduled for deletion can not be enabled", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(&db.Team{Team: &sqlc.Team{Slug: teamSlug}}, nil).
+			Once()
+		database.
+			On("GetTeamDeletion", ctx, teamSlug).
+			Return(&db.TeamDeletion{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: teamSlug}}, nil).
+			Once()
+
+		_, err := graph.
+			NewResolver(teamsync.NewMockHandler(t), database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
+			Mutation().
+			EnableTeam(ctx, &teamSlug)
+		assert.ErrorIs(t, err, apierror.ErrTeamDeletionScheduled)
+	})
+}
+
+func TestQueryResolver_PlanTeamSync(t *testing.T) {
+	const tenantDomain = "example.com"
+	deployProxy := deployproxy.NewMockProxy(t)
+	log := logger.NewMockLogger(t)
+	log.
+		On("WithComponent", types.ComponentNameGraphqlApi).
+		Return(log)
+	userSync := make(chan<- uuid.UUID)
+	gcpEnvironments := []string{"env"}
+	teamSlug := slug.Slug("my-team")
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName: roles.RoleNameTeamowner,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsSynchronize,
+			},
+			TargetTeamSlug: &teamSlug,
+		},
+	})
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug: teamSlug,
+		},
+	}
+
+	t.Run("plans are converted", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("PlanTeam", ctx, teamSlug).
+			Return([]*teamsync.ReconcilerPlan{
+				{
+					Reconciler: sqlc.ReconcilerNameGithubTeam,
+					Changes: []reconcilers.PlannedChange{
+						{
+							Action:   reconcilers.PlannedChangeActionAddMember,
+							Resource: "my-team",
+							Details:  `Add member "user" to GitHub team "my-team"`,
+						},
+					},
+				},
+				{
+					Reconciler: sqlc.ReconcilerNameAzureGroup,
+					Error:      errors.New("some error"),
+				},
+			}, nil).
+			Once()
+
+		plans, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditlogger.NewAuditLoggerForTesting(), gcpEnvironments, log).
+			Query().
+			PlanTeamSync(ctx, &teamSlug)
+		assert.NoError(t, err)
+		assert.Len(t, plans, 2)
+		assert.Equal(t, sqlc.ReconcilerNameGithubTeam, plans[0].Reconciler)
+		assert.Nil(t, plans[0].Error)
+		assert.Len(t, plans[0].Changes, 1)
+		assert.Equal(t, model.PlannedChangeActionAddMember, plans[0].Changes[0].Action)
+		assert.Equal(t, "my-team", plans[0].Changes[0].Resource)
+		assert.Equal(t, sqlc.ReconcilerNameAzureGroup, plans[1].Reconciler)
+		assert.Equal(t, "some error", *plans[1].Error)
+		assert.Empty(t, plans[1].Changes)
+	})
+}
+
+func TestMutationResolver_UpdateTeam(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+
+	teamSlug := slug.Slug("some-slug")
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:       roles.RoleNameTeamowner,
+			TargetTeamSlug: &teamSlug,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsUpdate,
+			},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	gcpEnvironments := []string{"dev", "prod"}
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	const tenantDomain = "example.com"
+
+	t.Run("changes are added to the audit log entry", func(t *testing.T) {
+		team := &db.Team{
+			Team: &sqlc.Team{Slug: teamSlug, Purpose: "old purpose", SlackChannel: "#channel"},
+		}
+		updatedTeam := &db.Team{
+			Team: &sqlc.Team{Slug: teamSlug, Purpose: "new purpose", SlackChannel: "#channel"},
+		}
+		purpose := "new purpose"
+		devChannel := "#dev-alerts"
+
+		txCtx := context.Background()
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("UpdateTeam", txCtx, teamSlug, &purpose, (*string)(nil)).
+			Return(updatedTeam, nil).
+			Once()
+		dbtx.
+			On("GetSlackAlertsChannels", txCtx, teamSlug).
+			Return(map[string]string{"prod": "#prod-alerts"}, nil).
+			Once()
+		dbtx.
+			On("SetSlackAlertsChannel", txCtx, teamSlug, "dev", devChannel).
+			Return(nil).
+			Once()
+		dbtx.
+			On("RemoveSlackAlertsChannel", txCtx, teamSlug, "prod").
+			Return(nil).
+			Once()
+		dbtx.
+			On("GetSlackAlertsChannels", txCtx, teamSlug).
+			Return(map[string]string{"dev": devChannel}, nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				assert.NoError(t, fn(txCtx, dbtx))
+			}).
+			Return(nil).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
+				return input.TeamSlug == teamSlug
+			})).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		returnedTeam, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			UpdateTeam(ctx, &teamSlug, model.UpdateTeamInput{
+				Purpose: &purpose,
+				SlackAlertsChannels: []*model.SlackAlertsChannelInput{
+					{Environment: "dev", ChannelName: &devChannel},
+					{Environment: "prod"},
+				},
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, updatedTeam, returnedTeam)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "purpose", Before: "old purpose", After: "new purpose"},
+			{Field: "slackAlertsChannels", Before: map[string]string{"prod": "#prod-alerts"}, After: map[string]string{"dev": devChannel}},
+		}, auditLogger.Entries()[0].Fields.Changes)
+	})
+}
+
+func TestMutationResolver_SetTeamParent(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+
+	parentSlug := slug.Slug("parent")
+	childSlug := slug.Slug("child")
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:            roles.RoleNameTeamowner,
+			TargetTeamSlug:      &parentSlug,
+			DescendantTeamSlugs: []slug.Slug{childSlug},
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsUpdate,
+			},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	gcpEnvironments := []string{"dev", "prod"}
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	const tenantDomain = "example.com"
+
+	parent := &db.Team{Team: &sqlc.Team{Slug: parentSlug}}
+	child := &db.Team{Team: &sqlc.Team{Slug: childSlug}}
+
+	t.Run("team can not be its own parent", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, parentSlug).
+			Return(parent, nil).
+			Twice()
+		database.
+			On("SetTeamParent", ctx, parentSlug, &parentSlug).
+			Return(nil, db.ErrTeamParentCycle).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		team, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			SetTeamParent(ctx, &parentSlug, &parentSlug)
+		assert.Nil(t, team)
+		assert.ErrorIs(t, err, apierror.ErrTeamParentCycle)
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("sub-team can not be the parent of its parent", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, parentSlug).
+			Return(parent, nil).
+			Once()
+		database.
+			On("GetTeamBySlug", ctx, childSlug).
+			Return(child, nil).
+			Once()
+		database.
+			On("SetTeamParent", ctx, parentSlug, &childSlug).
+			Return(nil, db.ErrTeamParentCycle).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		team, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			SetTeamParent(ctx, &parentSlug, &childSlug)
+		assert.Nil(t, team)
+		assert.ErrorIs(t, err, apierror.ErrTeamParentCycle)
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("set parent of team", func(t *testing.T) {
+		updatedChild := &db.Team{Team: &sqlc.Team{Slug: childSlug, ParentTeamSlug: &parentSlug}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, childSlug).
+			Return(child, nil).
+			Once()
+		database.
+			On("GetTeamBySlug", ctx, parentSlug).
+			Return(parent, nil).
+			Once()
+		database.
+			On("SetTeamParent", ctx, childSlug, &parentSlug).
+			Return(updatedChild, nil).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
+				return input.TeamSlug == childSlug
+			})).
+			Return(nil).
+			Once()
+		teamSyncHandler.
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
+				return input.TeamSlug == parentSlug
+			})).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		team, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, gcpEnvironments, log).
+			Mutation().
+			SetTeamParent(ctx, &childSlug, &parentSlug)
+		assert.NoError(t, err)
+		assert.Equal(t, updatedChild, team)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, types.AuditActionGraphqlApiTeamSetParent, auditLogger.Entries()[0].Fields.Action)
+	})
+}
+
+func TestMutationResolver_SetTeamMemberRole(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	member := &db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "member@example.com",
+			Name:  "Member Name",
+		},
+	}
+
+	teamSlug := slug.Slug("some-slug")
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName:       roles.RoleNameTeamowner,
+			TargetTeamSlug: &teamSlug,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsUpdate,
+			},
+		},
+	})
+
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	const tenantDomain = "example.com"
+
+	t.Run("role change is added to the audit log entry", func(t *testing.T) {
+		team := &db.Team{
+			Team: &sqlc.Team{Slug: teamSlug},
+		}
+		otherTeamSlug := slug.Slug("other-team")
+
+		txCtx := context.Background()
+		dbtx := db.NewMockDatabase(t)
+		dbtx.
+			On("GetUserRoles", txCtx, member.ID).
+			Return([]*db.Role{
+				{RoleName: roles.RoleNameTeammember, TargetTeamSlug: &teamSlug},
+				{RoleName: roles.RoleNameTeamowner, TargetTeamSlug: &otherTeamSlug},
+			}, nil).
+			Once()
+		dbtx.
+			On("RemoveUserFromTeam", txCtx, member.ID, teamSlug).
+			Return(nil).
+			Once()
+		dbtx.
+			On("SetTeamMemberRole", txCtx, member.ID, teamSlug, roles.RoleNameTeamowner).
+			Return(nil).
+			Once()
+		dbtx.
+			On("GetUserRoles", txCtx, member.ID).
+			Return([]*db.Role{
+				{RoleName: roles.RoleNameTeamowner, TargetTeamSlug: &teamSlug},
+				{RoleName: roles.RoleNameTeamowner, TargetTeamSlug: &otherTeamSlug},
+			}, nil).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembers", ctx, teamSlug).
+			Return([]*db.User{member}, nil).
+			Once()
+		database.
+			On("Transaction", ctx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				assert.NoError(t, fn(txCtx, dbtx))
+			}).
+			Return(nil).
+			Once()
+
+		teamSyncHandler := teamsync.NewMockHandler(t)
+		teamSyncHandler.
+			On("Schedule", mock.Anything, mock.MatchedBy(func(input teamsync.Input) bool {
+				return input.TeamSlug == teamSlug
+			})).
+			Return(nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		_, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			SetTeamMemberRole(ctx, &teamSlug, &member.ID, model.TeamRoleOwner)
+		assert.NoError(t, err)
+		assert.Len(t, auditLogger.Entries(), 1)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "roles", Before: []roles.RoleName{roles.RoleNameTeammember}, After: []roles.RoleName{roles.RoleNameTeamowner}},
+		}, auditLogger.Entries()[0].Fields.Changes)
+	})
+}
+
+func TestSubscriptionResolver_TeamSyncProgress(t *testing.T) {
+	deployProxy := deployproxy.NewMockProxy(t)
+	database := db.NewMockDatabase(t)
+	auditLogger := auditlogger.NewAuditLoggerForTesting()
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	correlationID := uuid.New()
+	teamSlug := slug.Slug("my-team")
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx, cancel := context.WithCancel(authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName: roles.RoleNameTeammember,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsRead,
+			},
+			TargetTeamSlug: &teamSlug,
+		},
+	}))
+	defer cancel()
+
+	progress := make(chan *teamsync.ProgressEvent, 2)
+	progress <- &teamsync.ProgressEvent{
+		CorrelationID: correlationID,
+		TeamSlug:      "other-team",
+		Reconciler:    sqlc.ReconcilerNameGithubTeam,
+		State:         teamsync.ProgressStateStarted,
+	}
+	progress <- &teamsync.ProgressEvent{
+		CorrelationID: correlationID,
+		TeamSlug:      teamSlug,
+		Reconciler:    sqlc.ReconcilerNameGithubTeam,
+		State:         teamsync.ProgressStateFailed,
+		Error:         helpers.Strp("some error"),
+	}
+	close(progress)
+
+	teamSyncHandler := teamsync.NewMockHandler(t)
+	teamSyncHandler.
+		On("SubscribeProgress", ctx, correlationID).
+		Return((<-chan *teamsync.ProgressEvent)(progress)).
+		Once()
+
+	events, err := graph.
+		NewResolver(teamSyncHandler, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+		Subscription().
+		TeamSyncProgress(ctx, &correlationID)
+	assert.NoError(t, err)
+
+	received := make([]*model.TeamSyncProgressEvent, 0)
+	for event := range events {
+		received = append(received, event)
+	}
+
+	// events for teams the user can not read are not sent
+	assert.Len(t, received, 1)
+	assert.Equal(t, &teamSlug, received[0].TeamSlug)
+	assert.Equal(t, &correlationID, received[0].CorrelationID)
+	assert.Equal(t, model.TeamSyncProgressStateFailed, received[0].State)
+	assert.Equal(t, helpers.Strp("some error"), received[0].Error)
+}
+
+func TestTeamResolver_SyncHistory(t *testing.T) {
+	deployProxy := deployproxy.NewMockProxy(t)
+	auditLogger := auditlogger.NewAuditLoggerForTesting()
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	teamSlug := slug.Slug("my-team")
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{
+			RoleName: roles.RoleNameTeammember,
+			Authorizations: []roles.Authorization{
+				roles.AuthorizationTeamsRead,
+			},
+			TargetTeamSlug: &teamSlug,
+		},
+	})
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug: teamSlug,
+		},
+	}
+
+	t.Run("team not readable by the user", func(t *testing.T) {
+		resolver := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Team()
+
+		otherTeam := &db.Team{Team: &sqlc.Team{Slug: "other-team"}}
+		conn, err := resolver.SyncHistory(ctx, otherTeam, nil, nil)
+		assert.Nil(t, conn)
+		assert.ErrorContains(t, err, `required authorization: "teams:read"`)
+	})
+
+	t.Run("invalid number of runs", func(t *testing.T) {
+		resolver := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Team()
+
+		first := 0
+		conn, err := resolver.SyncHistory(ctx, team, &first, nil)
+		assert.Nil(t, conn)
+		assert.ErrorContains(t, err, "The number of runs must be between 1 and 100.")
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		resolver := graph.
+			NewResolver(nil, db.NewMockDatabase(t), deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Team()
+
+		after := "invalid"
+		conn, err := resolver.SyncHistory(ctx, team, nil, &after)
+		assert.Nil(t, conn)
+		assert.ErrorContains(t, err, "Invalid cursor")
+	})
+
+	t.Run("paginate runs", func(t *testing.T) {
+		startedAt := time.Date(2023, 9, 1, 12, 0, 0, 123456000, time.UTC)
+		run1 := &db.TeamSyncRun{TeamSyncRun: &sqlc.TeamSyncRun{ID: 3, TeamSlug: teamSlug, StartedAt: startedAt}}
+		run2 := &db.TeamSyncRun{TeamSyncRun: &sqlc.TeamSyncRun{ID: 2, TeamSlug: teamSlug, StartedAt: startedAt.Add(-time.Minute)}}
+		run3 := &db.TeamSyncRun{TeamSyncRun: &sqlc.TeamSyncRun{ID: 1, TeamSlug: teamSlug, StartedAt: startedAt.Add(-2 * time.Minute)}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamSyncRuns", ctx, teamSlug, (*db.TeamSyncRunCursor)(nil), 3).
+			Return([]*db.TeamSyncRun{run1, run2, run3}, nil).
+			Once()
+		database.
+			On("GetTeamSyncRuns", ctx, teamSlug, &db.TeamSyncRunCursor{StartedAt: run2.StartedAt, ID: run2.ID}, 3).
+			Return([]*db.TeamSyncRun{run3}, nil).
+			Once()
+
+		resolver := graph.
+			NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log).
+			Team()
+
+		first := 2
+		conn, err := resolver.SyncHistory(ctx, team, &first, nil)
+		assert.NoError(t, err)
+		assert.Len(t, conn.Edges, 2)
+		assert.Equal(t, run1, conn.Edges[0].Node)
+		assert.Equal(t, run2, conn.Edges[1].Node)
+		assert.True(t, conn.PageInfo.HasNextPage)
+		assert.False(t, conn.PageInfo.HasPreviousPage)
+		assert.Equal(t, conn.Edges[0].Cursor, *conn.PageInfo.StartCursor)
+		assert.Equal(t, conn.Edges[1].Cursor, *conn.PageInfo.EndCursor)
+
+		conn, err = resolver.SyncHistory(ctx, team, &first, conn.PageInfo.EndCursor)
+		assert.NoError(t, err)
+		assert.Len(t, conn.Edges, 1)
+		assert.Equal(t, run3, conn.Edges[0].Node)
+		assert.False(t, conn.PageInfo.HasNextPage)
+		assert.True(t, conn.PageInfo.HasPreviousPage)
+	})
+
+	t.Run("runs with reconciler results", func(t *testing.T) {
+		startedAt := time.Now()
+		run := &db.TeamSyncRun{
+			TeamSyncRun: &sqlc.TeamSyncRun{
+				ID:          1,
+				TeamSlug:    teamSlug,
+				TriggeredBy: sqlc.TeamSyncTriggerRetry,
+				Reconcilers: []string{string(sqlc.ReconcilerNameGithubTeam)},
+				StartedAt:   startedAt,
+				Status:      sqlc.TeamSyncRunStatusFailure,
+			},
+		}
+		runReconciler := &db.TeamSyncRunReconciler{
+			TeamSyncRunReconciler: &sqlc.TeamSyncRunReconciler{
+				RunID:        run.ID,
+				Reconciler:   sqlc.ReconcilerNameGithubTeam,
+				Status:       sqlc.TeamSyncReconcilerStatusTimeout,
+				StartedAt:    startedAt,
+				FinishedAt:   startedAt.Add(1500 * time.Millisecond),
+				ErrorMessage: helpers.Strp("reconciler timed out"),
+			},
+		}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetTeamSyncRuns", ctx, teamSlug, (*db.TeamSyncRunCursor)(nil), 21).
+			Return([]*db.TeamSyncRun{run}, nil).
+			Once()
+		database.
+			On("GetTeamSyncRunReconcilers", ctx, run.ID).
+			Return([]*db.TeamSyncRunReconciler{runReconciler}, nil).
+			Once()
+
+		resolver := graph.NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, []string{}, log)
+		conn, err := resolver.Team().SyncHistory(ctx, team, nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, conn.Edges, 1)
+		assert.False(t, conn.PageInfo.HasNextPage)
+		syncRun := conn.Edges[0].Node
+
+		trigger, _ := resolver.TeamSyncRun().Trigger(ctx, syncRun)
+		assert.Equal(t, model.TeamSyncTriggerRetry, trigger)
+		status, _ := resolver.TeamSyncRun().Status(ctx, syncRun)
+		assert.Equal(t, model.TeamSyncRunStatusFailure, status)
+		requested, _ := resolver.TeamSyncRun().RequestedReconcilers(ctx, syncRun)
+		assert.Equal(t, []sqlc.ReconcilerName{sqlc.ReconcilerNameGithubTeam}, requested)
+
+		runReconcilers, err := resolver.TeamSyncRun().Reconcilers(ctx, syncRun)
+		assert.NoError(t, err)
+		assert.Len(t, runReconcilers, 1)
+
+		reconcilerStatus, _ := resolver.TeamSyncRunReconciler().Status(ctx, runReconcilers[0])
+		assert.Equal(t, model.TeamSyncReconcilerStatusTimeout, reconcilerStatus)
+		duration, _ := resolver.TeamSyncRunReconciler().DurationMs(ctx, runReconcilers[0])
+		assert.Equal(t, 1500, duration)
+	})
+}
@@ -12,13 +12,13 @@ import (
 	"github.com/nais/teams-backend/pkg/auditlogger"
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/graph/apierror"
 	"github.com/nais/teams-backend/pkg/graph/dataloader"
 	"github.com/nais/teams-backend/pkg/graph/generated"
 	"github.com/nais/teams-backend/pkg/graph/model"
 	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/nais/teams-backend/pkg/types"
-	"github.com/nais/teams-backend/pkg/usersync"
 )
 
 // SynchronizeUsers is the resolver for the synchronizeUsers field.
@@ -82,14 +82,38 @@ func (r *queryResolver) UserByEmail(ctx context.Context, email string) (*db.User
 }
 
 // UserSync is the resolver for the userSync field.
-func (r *queryResolver) UserSync(ctx context.Context) ([]*usersync.Run, error) {
+func (r *queryResolver) UserSync(ctx context.Context, offset *int, limit *int) ([]*db.UserSyncRun, error) {
 	actor := authz.ActorFromContext(ctx)
 	err := authz.RequireGlobalAuthorization(actor, roles.AuthorizationUsersyncSynchronize)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.userSyncRuns.GetRuns(), nil
+	runsOffset := 0
+	if offset != nil {
+		runsOffset = *offset
+	}
+
+	runsLimit := defaultUserSyncRunsLimit
+	if limit != nil {
+		runsLimit = *limit
+	}
+
+	if runsOffset < 0 {
+		return nil, apierror.Errorf("The offset can not be negative.")
+	}
+
+	if runsLimit < 1 || runsLimit > maxUserSyncRunsLimit {
+		return nil, apierror.Errorf("The limit must be between 1 and %d.", maxUserSyncRunsLimit)
+	}
+
+	runs, err := r.database.GetUserSyncRuns(ctx, runsOffset, runsLimit)
+	if err != nil {
+		r.log.WithError(err).Errorf("get user sync runs")
+		return nil, apierror.Errorf("Unable to get user sync runs.")
+	}
+
+	return runs, nil
 }
 
 // Teams is the resolver for the teams field.
@@ -113,9 +137,9 @@ func (r *userResolver) Teams(ctx context.Context, obj *db.User) ([]*model.TeamMe
 
 		var teamRole model.TeamRole
 		switch role.RoleName {
-		case sqlc.RoleNameTeammember:
+		case roles.RoleNameTeammember:
 			teamRole = model.TeamRoleMember
-		case sqlc.RoleNameTeamowner:
+		case roles.RoleNameTeamowner:
 			teamRole = model.TeamRoleOwner
 		default:
 			continue
@@ -146,7 +170,7 @@ func (r *userResolver) Teams(ctx context.Context, obj *db.User) ([]*model.TeamMe
 // Roles is the resolver for the roles field.
 func (r *userResolver) Roles(ctx context.Context, obj *db.User) ([]*db.Role, error) {
 	actor := authz.ActorFromContext(ctx)
-	err := authz.RequireRole(actor, sqlc.RoleNameAdmin)
+	err := authz.RequireRole(actor, roles.RoleNameAdmin)
 	if err != nil && actor.User.GetID() != obj.ID {
 		return nil, err
 	}
@@ -157,16 +181,18 @@ func (r *userResolver) Roles(ctx context.Context, obj *db.User) ([]*db.Role, err
 	}
 	ret := make([]*db.Role, 0)
 	for _, ur := range userRoles {
-		authz, err := roles.Authorizations(ur.RoleName)
+		authorizations, err := dataloader.GetRoleAuthorizations(ctx, ur.RoleName)
 		if err != nil {
 			return nil, err
 		}
 
 		ret = append(ret, &db.Role{
-			Authorizations:         authz,
+			Authorizations:         authorizations,
 			RoleName:               ur.RoleName,
 			TargetServiceAccountID: ur.TargetServiceAccountID,
 			TargetTeamSlug:         ur.TargetTeamSlug,
+			GrantedBy:              ur.GrantedBy,
+			GrantedAt:              ur.GrantedAt,
 		})
 	}
 
@@ -174,16 +200,16 @@ func (r *userResolver) Roles(ctx context.Context, obj *db.User) ([]*db.Role, err
 }
 
 // LogEntries is the resolver for the logEntries field.
-func (r *userSyncRunResolver) LogEntries(ctx context.Context, obj *usersync.Run) ([]*db.AuditLog, error) {
-	return r.database.GetAuditLogsForCorrelationID(ctx, obj.CorrelationID())
+func (r *userSyncRunResolver) LogEntries(ctx context.Context, obj *db.UserSyncRun) ([]*db.AuditLog, error) {
+	return r.database.GetAuditLogsForCorrelationID(ctx, obj.CorrelationID)
 }
 
 // Status is the resolver for the status field.
-func (r *userSyncRunResolver) Status(ctx context.Context, obj *usersync.Run) (model.UserSyncRunStatus, error) {
-	switch obj.Status() {
-	case usersync.RunSuccess:
+func (r *userSyncRunResolver) Status(ctx context.Context, obj *db.UserSyncRun) (model.UserSyncRunStatus, error) {
+	switch obj.Status {
+	case sqlc.UserSyncRunStatusSuccess:
 		return model.UserSyncRunStatusSuccess, nil
-	case usersync.RunFailure:
+	case sqlc.UserSyncRunStatusFailure:
 		return model.UserSyncRunStatusFailure, nil
 	default:
 		return model.UserSyncRunStatusInProgress, nil
@@ -191,14 +217,8 @@ func (r *userSyncRunResolver) Status(ctx context.Context, obj *usersync.Run) (mo
 }
 
 // Error is the resolver for the error field.
-func (r *userSyncRunResolver) Error(ctx context.Context, obj *usersync.Run) (*string, error) {
-	err := obj.Error()
-	if err != nil {
-		msg := err.Error()
-		return &msg, nil
-	}
-
-	return nil, nil
+func (r *userSyncRunResolver) Error(ctx context.Context, obj *db.UserSyncRun) (*string, error) {
+	return obj.ErrorMessage, nil
 }
 
 // User returns generated.UserResolver implementation.
@@ -13,7 +13,6 @@ import (
 	"github.com/nais/teams-backend/pkg/logger"
 	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/sqlc"
-	"github.com/nais/teams-backend/pkg/usersync"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,9 +25,8 @@ func TestQueryResolver_Users(t *testing.T) {
 	log, err := logger.GetLogger("text", "info")
 	assert.NoError(t, err)
 	userSync := make(chan<- uuid.UUID)
-	userSyncRuns := usersync.NewRunsHandler(5)
 	resolver := graph.
-		NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, gcpEnvironments, log, userSyncRuns).
+		NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, gcpEnvironments, log).
 		Query()
 
 	t.Run("unauthenticated user", func(t *testing.T) {
@@ -60,3 +58,75 @@ func TestQueryResolver_Users(t *testing.T) {
 		assert.Len(t, users, 2)
 	})
 }
+
+func TestQueryResolver_UserSync(t *testing.T) {
+	ctx := context.Background()
+	database := db.NewMockDatabase(t)
+	deployProxy := deployproxy.NewMockProxy(t)
+	auditLogger := auditlogger.NewMockAuditLogger(t)
+	gcpEnvironments := []string{"env"}
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	resolver := graph.
+		NewResolver(nil, database, deployProxy, "example.com", userSync, auditLogger, gcpEnvironments, log).
+		Query()
+
+	user := &db.User{
+		User: &sqlc.User{
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	authorizedCtx := authz.ContextWithActor(ctx, user, []*db.Role{
+		{
+			Authorizations: []roles.Authorization{roles.AuthorizationUsersyncSynchronize},
+		},
+	})
+
+	t.Run("unauthenticated user", func(t *testing.T) {
+		runs, err := resolver.UserSync(ctx, nil, nil)
+		assert.Nil(t, runs)
+		assert.ErrorIs(t, err, authz.ErrNotAuthenticated)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		limit := 101
+		runs, err := resolver.UserSync(authorizedCtx, nil, &limit)
+		assert.Nil(t, runs)
+		assert.ErrorContains(t, err, "The limit must be between 1 and 100.")
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		offset := -1
+		runs, err := resolver.UserSync(authorizedCtx, &offset, nil)
+		assert.Nil(t, runs)
+		assert.ErrorContains(t, err, "The offset can not be negative.")
+	})
+
+	t.Run("default pagination", func(t *testing.T) {
+		database.
+			On("GetUserSyncRuns", authorizedCtx, 0, 20).
+			Return([]*db.UserSyncRun{
+				{UserSyncRun: &sqlc.UserSyncRun{ID: 2, Status: sqlc.UserSyncRunStatusInProgress}},
+				{UserSyncRun: &sqlc.UserSyncRun{ID: 1, Status: sqlc.UserSyncRunStatusSuccess}},
+			}, nil).
+			Once()
+
+		runs, err := resolver.UserSync(authorizedCtx, nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, runs, 2)
+	})
+
+	t.Run("custom pagination", func(t *testing.T) {
+		offset, limit := 10, 5
+		database.
+			On("GetUserSyncRuns", authorizedCtx, offset, limit).
+			Return([]*db.UserSyncRun{}, nil).
+			Once()
+
+		runs, err := resolver.UserSync(authorizedCtx, &offset, &limit)
+		assert.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+}
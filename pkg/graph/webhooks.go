@@ -0,0 +1,195 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.36
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/authz"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/graph/apierror"
+	"github.com/nais/teams-backend/pkg/graph/generated"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/nais/teams-backend/pkg/webhooks"
+)
+
+// CreateWebhookSubscription is the resolver for the createWebhookSubscription field.
+func (r *mutationResolver) CreateWebhookSubscription(ctx context.Context, input model.CreateWebhookSubscriptionInput) (*model.CreatedWebhookSubscription, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	secret, err := webhooks.GenerateSecret()
+	if err != nil {
+		r.log.WithError(err).Errorf("generate webhook secret")
+		return nil, apierror.ErrInternal
+	}
+
+	subscription, err := r.database.CreateWebhookSubscription(ctx, input.URL, secret, input.EventTypes)
+	if err != nil {
+		r.log.WithError(err).Errorf("create webhook subscription")
+		return nil, apierror.Errorf("Unable to create webhook subscription.")
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	targets := []auditlogger.Target{
+		auditlogger.ComponentTarget(types.ComponentNameWebhooks),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiWebhooksCreate,
+		Actor:         actor,
+		CorrelationID: correlationID,
+		Changes: auditlogger.Changes{}.
+			Add("url", nil, subscription.Url).
+			Add("eventTypes", nil, subscription.GetEventTypes()).
+			AddSecret("secret", false),
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Create webhook subscription %q for %q", subscription.ID, subscription.Url)
+
+	return &model.CreatedWebhookSubscription{
+		Subscription: subscription,
+		Secret:       secret,
+	}, nil
+}
+
+// UpdateWebhookSubscription is the resolver for the updateWebhookSubscription field.
+func (r *mutationResolver) UpdateWebhookSubscription(ctx context.Context, id *uuid.UUID, input model.UpdateWebhookSubscriptionInput) (*db.WebhookSubscription, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	existing, err := r.getWebhookSubscription(ctx, *id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := r.database.UpdateWebhookSubscription(ctx, existing.ID, input.URL, input.EventTypes, input.Enabled)
+	if err != nil {
+		r.log.WithError(err).Errorf("update webhook subscription")
+		return nil, apierror.Errorf("Unable to update webhook subscription.")
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	targets := []auditlogger.Target{
+		auditlogger.ComponentTarget(types.ComponentNameWebhooks),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiWebhooksUpdate,
+		Actor:         actor,
+		CorrelationID: correlationID,
+		Changes: auditlogger.Changes{}.
+			Add("url", existing.Url, subscription.Url).
+			Add("eventTypes", existing.GetEventTypes(), subscription.GetEventTypes()).
+			Add("enabled", existing.Enabled, subscription.Enabled),
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Update webhook subscription %q", subscription.ID)
+
+	return subscription, nil
+}
+
+// DeleteWebhookSubscription is the resolver for the deleteWebhookSubscription field.
+func (r *mutationResolver) DeleteWebhookSubscription(ctx context.Context, id *uuid.UUID) (bool, error) {
+	correlationID, err := uuid.NewUUID()
+	if err != nil {
+		return false, fmt.Errorf("create log correlation ID: %w", err)
+	}
+
+	subscription, err := r.getWebhookSubscription(ctx, *id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.database.DeleteWebhookSubscription(ctx, subscription.ID); err != nil {
+		r.log.WithError(err).Errorf("delete webhook subscription")
+		return false, apierror.Errorf("Unable to delete webhook subscription.")
+	}
+
+	actor := authz.ActorFromContext(ctx)
+	targets := []auditlogger.Target{
+		auditlogger.ComponentTarget(types.ComponentNameWebhooks),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGraphqlApiWebhooksDelete,
+		Actor:         actor,
+		CorrelationID: correlationID,
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Delete webhook subscription %q for %q", subscription.ID, subscription.Url)
+
+	return true, nil
+}
+
+// WebhookSubscriptions is the resolver for the webhookSubscriptions field.
+func (r *queryResolver) WebhookSubscriptions(ctx context.Context) ([]*db.WebhookSubscription, error) {
+	return r.database.GetWebhookSubscriptions(ctx)
+}
+
+// Payload is the resolver for the payload field.
+func (r *webhookDeliveryResolver) Payload(ctx context.Context, obj *db.WebhookDelivery) (string, error) {
+	return string(obj.Payload), nil
+}
+
+// EventTypes is the resolver for the eventTypes field.
+func (r *webhookSubscriptionResolver) EventTypes(ctx context.Context, obj *db.WebhookSubscription) ([]sqlc.WebhookEventType, error) {
+	return obj.GetEventTypes(), nil
+}
+
+// Deliveries is the resolver for the deliveries field.
+func (r *webhookSubscriptionResolver) Deliveries(ctx context.Context, obj *db.WebhookSubscription, offset *int, limit *int) ([]*db.WebhookDelivery, error) {
+	deliveriesOffset := 0
+	if offset != nil {
+		deliveriesOffset = *offset
+	}
+
+	deliveriesLimit := defaultWebhookDeliveriesLimit
+	if limit != nil {
+		deliveriesLimit = *limit
+	}
+
+	if deliveriesOffset < 0 {
+		return nil, apierror.Errorf("The offset can not be negative.")
+	}
+
+	if deliveriesLimit < 1 || deliveriesLimit > maxWebhookDeliveriesLimit {
+		return nil, apierror.Errorf("The limit must be between 1 and %d.", maxWebhookDeliveriesLimit)
+	}
+
+	deliveries, err := r.database.GetWebhookDeliveries(ctx, obj.ID, deliveriesOffset, deliveriesLimit)
+	if err != nil {
+		r.log.WithError(err).Errorf("get webhook deliveries")
+		return nil, apierror.Errorf("Unable to get webhook deliveries.")
+	}
+
+	return deliveries, nil
+}
+
+// WebhookDelivery returns generated.WebhookDeliveryResolver implementation.
+func (r *Resolver) WebhookDelivery() generated.WebhookDeliveryResolver {
+	return &webhookDeliveryResolver{r}
+}
+
+// WebhookSubscription returns generated.WebhookSubscriptionResolver implementation.
+func (r *Resolver) WebhookSubscription() generated.WebhookSubscriptionResolver {
+	return &webhookSubscriptionResolver{r}
+}
+
+type (
+	webhookDeliveryResolver     struct{ *Resolver }
+	webhookSubscriptionResolver struct{ *Resolver }
+)
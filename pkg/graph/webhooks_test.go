@@ -0,0 +1,194 @@
+package graph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/authz"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/deployproxy"
+	"github.com/nais/teams-backend/pkg/graph"
+	"github.com/nais/teams-backend/pkg/graph/model"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/roles"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/teamsync"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMutationResolver_CreateWebhookSubscription(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{RoleName: roles.RoleNameAdmin},
+	})
+
+	teamSyncHandler := teamsync.NewMockHandler(t)
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	const tenantDomain = "example.com"
+	eventTypes := []sqlc.WebhookEventType{sqlc.WebhookEventTypeTeamcreated, sqlc.WebhookEventTypeSyncfailed}
+
+	t.Run("invalid URL", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		_, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			CreateWebhookSubscription(ctx, model.CreateWebhookSubscriptionInput{
+				URL:        "ftp://example.com/hook",
+				EventTypes: eventTypes,
+			})
+		assert.ErrorContains(t, err, "The webhook URL is not valid")
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("invalid event type", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		_, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			CreateWebhookSubscription(ctx, model.CreateWebhookSubscriptionInput{
+				URL:        "https://example.com/hook",
+				EventTypes: []sqlc.WebhookEventType{"team.renamed"},
+			})
+		assert.ErrorContains(t, err, `The specified event type is not valid: "team.renamed"`)
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("create subscription with generated secret", func(t *testing.T) {
+		var secret string
+		subscription := &db.WebhookSubscription{WebhookSubscription: &sqlc.WebhookSubscription{
+			ID:         uuid.New(),
+			Url:        "https://example.com/hook",
+			EventTypes: []string{"team.created", "sync.failed"},
+			Enabled:    true,
+		}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("CreateWebhookSubscription", ctx, "https://example.com/hook", mock.AnythingOfType("string"), eventTypes).
+			Run(func(args mock.Arguments) {
+				secret = args.String(2)
+			}).
+			Return(subscription, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		created, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			CreateWebhookSubscription(ctx, model.CreateWebhookSubscriptionInput{
+				URL:        "https://example.com/hook",
+				EventTypes: eventTypes,
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, subscription, created.Subscription)
+		assert.NotEmpty(t, created.Secret)
+		assert.Equal(t, secret, created.Secret)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiWebhooksCreate, entry.Fields.Action)
+		assert.Equal(t, []auditlogger.Target{auditlogger.ComponentTarget(types.ComponentNameWebhooks)}, entry.Targets)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "url", Before: nil, After: "https://example.com/hook"},
+			{Field: "eventTypes", Before: nil, After: eventTypes},
+			{Field: "secret", Before: nil, After: auditlogger.RedactedValue},
+		}, entry.Fields.Changes)
+	})
+}
+
+func TestMutationResolver_UpdateWebhookSubscription(t *testing.T) {
+	user := db.User{
+		User: &sqlc.User{
+			ID:    uuid.New(),
+			Email: "user@example.com",
+			Name:  "User Name",
+		},
+	}
+	ctx := authz.ContextWithActor(context.Background(), user, []*db.Role{
+		{RoleName: roles.RoleNameAdmin},
+	})
+
+	teamSyncHandler := teamsync.NewMockHandler(t)
+	deployProxy := deployproxy.NewMockProxy(t)
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	userSync := make(chan<- uuid.UUID)
+	const tenantDomain = "example.com"
+	id := uuid.New()
+
+	t.Run("subscription does not exist", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetWebhookSubscription", ctx, id).
+			Return(nil, assert.AnError).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		_, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			UpdateWebhookSubscription(ctx, &id, model.UpdateWebhookSubscriptionInput{
+				Enabled: helpers.Boolp(false),
+			})
+		assert.ErrorContains(t, err, "The webhook subscription you are referring to does not exist.")
+		assert.Empty(t, auditLogger.Entries())
+	})
+
+	t.Run("disable subscription", func(t *testing.T) {
+		existing := &db.WebhookSubscription{WebhookSubscription: &sqlc.WebhookSubscription{
+			ID:         id,
+			Url:        "https://example.com/hook",
+			EventTypes: []string{"team.created"},
+			Enabled:    true,
+		}}
+		updated := &db.WebhookSubscription{WebhookSubscription: &sqlc.WebhookSubscription{
+			ID:         id,
+			Url:        "https://example.com/hook",
+			EventTypes: []string{"team.created"},
+			Enabled:    false,
+		}}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetWebhookSubscription", ctx, id).
+			Return(existing, nil).
+			Once()
+		database.
+			On("UpdateWebhookSubscription", ctx, id, (*string)(nil), []sqlc.WebhookEventType(nil), helpers.Boolp(false)).
+			Return(updated, nil).
+			Once()
+
+		auditLogger := auditlogger.NewAuditLoggerForTesting()
+		subscription, err := graph.
+			NewResolver(teamSyncHandler, database, deployProxy, tenantDomain, userSync, auditLogger, []string{}, log).
+			Mutation().
+			UpdateWebhookSubscription(ctx, &id, model.UpdateWebhookSubscriptionInput{
+				Enabled: helpers.Boolp(false),
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, updated, subscription)
+
+		assert.Len(t, auditLogger.Entries(), 1)
+		entry := auditLogger.Entries()[0]
+		assert.Equal(t, types.AuditActionGraphqlApiWebhooksUpdate, entry.Fields.Action)
+		assert.Equal(t, auditlogger.Changes{
+			{Field: "enabled", Before: true, After: false},
+		}, entry.Fields.Changes)
+	})
+}
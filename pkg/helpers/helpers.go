@@ -3,8 +3,13 @@ package helpers
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/nais/teams-backend/pkg/slug"
 )
 
@@ -41,6 +46,32 @@ func Contains(strings []string, contains string) bool {
 	return false
 }
 
+// Backoff Calculate the delay before the next attempt of a failed operation. The delay is doubled for each attempt,
+// capped at maxBackoff, and half of it is randomized to avoid retrying a lot of operations at the same time.
+func Backoff(attempts int32, initialBackoff, maxBackoff time.Duration) time.Duration {
+	backoff := initialBackoff
+	for i := int32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// WorkerID Create a unique identifier for a worker in this process, made up of the hostname and a random UUID. Used to
+// tell which replica claimed a lease or an item from a queue.
+func WorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "teams-backend"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.New())
+}
+
 func SlugHashPrefixTruncate(slug slug.Slug, prefix string, maxLength int) string {
 	hasher := sha256.New()
 	hasher.Write([]byte(slug))
@@ -1,7 +1,10 @@
 package helpers_test
 
 import (
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nais/teams-backend/pkg/helpers"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +57,32 @@ func TestContains(t *testing.T) {
 		assert.False(t, helpers.Contains([]string{"foo", "bar"}, "Bar"))
 	})
 }
+
+func TestBackoff(t *testing.T) {
+	t.Run("first attempt", func(t *testing.T) {
+		backoff := helpers.Backoff(1, time.Minute, time.Hour)
+		assert.GreaterOrEqual(t, backoff, 30*time.Second)
+		assert.LessOrEqual(t, backoff, time.Minute)
+	})
+
+	t.Run("doubled for each attempt", func(t *testing.T) {
+		backoff := helpers.Backoff(3, time.Minute, time.Hour)
+		assert.GreaterOrEqual(t, backoff, 2*time.Minute)
+		assert.LessOrEqual(t, backoff, 4*time.Minute)
+	})
+
+	t.Run("capped at max backoff", func(t *testing.T) {
+		backoff := helpers.Backoff(100, time.Minute, time.Hour)
+		assert.GreaterOrEqual(t, backoff, 30*time.Minute)
+		assert.LessOrEqual(t, backoff, time.Hour)
+	})
+}
+
+func TestWorkerID(t *testing.T) {
+	hostname, err := os.Hostname()
+	assert.NoError(t, err)
+
+	first, second := helpers.WorkerID(), helpers.WorkerID()
+	assert.True(t, strings.HasPrefix(first, hostname+"-"))
+	assert.NotEqual(t, first, second)
+}
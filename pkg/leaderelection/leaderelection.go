@@ -0,0 +1,113 @@
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/metrics"
+	"github.com/nais/teams-backend/pkg/types"
+)
+
+// leaseName The name of the lease held by the instance responsible for scheduling periodic jobs
+const leaseName = "teams-backend"
+
+// releaseTimeout Time allowed for releasing the lease during shutdown
+const releaseTimeout = time.Second * 5
+
+// Elector Keeps track of whether or not this instance is the leader. The leader holds a lease in the database, which it
+// renews periodically. If the leader stops renewing the lease, another instance will take over when the lease expires.
+type Elector struct {
+	database      db.Database
+	holder        string
+	leaseDuration time.Duration
+	log           logger.Logger
+
+	lock        sync.RWMutex
+	leaderUntil time.Time
+}
+
+func New(database db.Database, leaseDuration time.Duration, log logger.Logger) *Elector {
+	return &Elector{
+		database:      database,
+		holder:        helpers.WorkerID(),
+		leaseDuration: leaseDuration,
+		log:           log.WithComponent(types.ComponentNameLeaderElection),
+	}
+}
+
+// IsLeader Check if this instance currently holds the lease
+func (e *Elector) IsLeader() bool {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return time.Now().Before(e.leaderUntil)
+}
+
+// Run Acquire or renew the lease until the context is done. The lease is released when Run returns, so that another
+// instance can take over right away.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		e.renew(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.release()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) renew(ctx context.Context) {
+	wasLeader := e.IsLeader()
+
+	// measure the lease from before the request, so the lease never ends later locally than in the database
+	leaderUntil := time.Now().Add(e.leaseDuration)
+	_, err := e.database.AcquireLeaderLease(ctx, leaseName, e.holder, e.leaseDuration)
+	if err != nil {
+		leaderUntil = time.Time{}
+		if !errors.Is(err, pgx.ErrNoRows) && ctx.Err() == nil {
+			e.log.WithError(err).Error("acquire leader lease")
+		}
+	}
+
+	e.setLeaderUntil(leaderUntil)
+	isLeader := !leaderUntil.IsZero()
+	metrics.SetLeader(isLeader)
+
+	if isLeader && !wasLeader {
+		e.log.Infof("elected as leader")
+	} else if !isLeader && wasLeader {
+		e.log.Warnf("no longer the leader")
+	}
+}
+
+func (e *Elector) release() {
+	if !e.IsLeader() {
+		return
+	}
+
+	e.setLeaderUntil(time.Time{})
+	metrics.SetLeader(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if err := e.database.ReleaseLeaderLease(ctx, leaseName, e.holder); err != nil {
+		e.log.WithError(err).Error("release leader lease")
+	}
+}
+
+func (e *Elector) setLeaderUntil(leaderUntil time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.leaderUntil = leaderUntil
+}
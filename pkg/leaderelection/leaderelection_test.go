@@ -0,0 +1,109 @@
+package leaderelection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/leaderelection"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestElector(t *testing.T) {
+	const leaseDuration = time.Minute
+
+	newLogger := func(t *testing.T) *logger.MockLogger {
+		log := logger.NewMockLogger(t)
+		log.On("WithComponent", types.ComponentNameLeaderElection).Return(log).Once()
+		return log
+	}
+
+	t.Run("acquire and release lease", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var holder string
+		database := db.NewMockDatabase(t)
+		database.
+			On("AcquireLeaderLease", ctx, "teams-backend", mock.AnythingOfType("string"), leaseDuration).
+			Run(func(args mock.Arguments) {
+				holder = args.String(2)
+			}).
+			Return(&db.LeaderLease{LeaderLease: &sqlc.LeaderLease{}}, nil).
+			Once()
+		database.
+			On("ReleaseLeaderLease", mock.Anything, "teams-backend", mock.MatchedBy(func(h string) bool {
+				return h == holder
+			})).
+			Return(nil).
+			Once()
+
+		log := newLogger(t)
+		log.On("Infof", "elected as leader").Return().Once()
+
+		elector := leaderelection.New(database, leaseDuration, log)
+		assert.False(t, elector.IsLeader())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			elector.Run(ctx)
+		}()
+
+		assert.Eventually(t, elector.IsLeader, time.Second, time.Millisecond)
+		cancel()
+		<-done
+		assert.False(t, elector.IsLeader())
+	})
+
+	t.Run("lease held by another instance", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("AcquireLeaderLease", ctx, "teams-backend", mock.AnythingOfType("string"), leaseDuration).
+			Run(func(mock.Arguments) {
+				cancel()
+			}).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		elector := leaderelection.New(database, leaseDuration, newLogger(t))
+		elector.Run(ctx)
+		assert.False(t, elector.IsLeader())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("AcquireLeaderLease", ctx, "teams-backend", mock.AnythingOfType("string"), leaseDuration).
+			Return(nil, errors.New("some error")).
+			Once()
+
+		testLogger, hook := test.NewNullLogger()
+		log := newLogger(t)
+		log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger}).Once()
+
+		elector := leaderelection.New(database, leaseDuration, log)
+		go func() {
+			assert.Eventually(t, func() bool { return len(hook.AllEntries()) > 0 }, time.Second, time.Millisecond)
+			cancel()
+		}()
+		elector.Run(ctx)
+		assert.False(t, elector.IsLeader())
+		assert.Equal(t, "acquire leader lease", hook.LastEntry().Message)
+	})
+}
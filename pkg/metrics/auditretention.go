@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const labelRule = "rule"
+
+var (
+	auditLogEntriesPruned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "audit_log_entries_pruned",
+		Help:      "Number of expired audit log entries deleted, labeled with the retention rule that expired them",
+	}, []string{labelRule})
+
+	auditLogEntriesArchived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "audit_log_entries_archived",
+		Help:      "Number of expired audit log entries archived before they were deleted, labeled with the retention rule that expired them",
+	}, []string{labelRule})
+)
+
+func AddAuditLogEntriesPruned(rule string, numEntries int64) {
+	auditLogEntriesPruned.With(prometheus.Labels{labelRule: rule}).Add(float64(numEntries))
+}
+
+func AddAuditLogEntriesArchived(rule string, numEntries int) {
+	auditLogEntriesArchived.With(prometheus.Labels{labelRule: rule}).Add(float64(numEntries))
+}
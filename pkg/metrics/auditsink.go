@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type AuditSinkState string
+
+const (
+	AuditSinkStateDelivered AuditSinkState = "delivered"
+	AuditSinkStateFailed    AuditSinkState = "failed"
+)
+
+const labelSink = "sink"
+
+var (
+	auditSinkEntries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "audit_sink_entries",
+		Help:      "Number of audit log entries sent to external sinks, labeled with sink name and delivery state",
+	}, []string{labelSink, labelState})
+
+	pendingAuditLogEntries = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "audit_sink_pending_entries",
+		Help:      "How many audit log entries are waiting to be delivered to external sinks",
+	})
+)
+
+func IncAuditSinkEntries(sink string, state AuditSinkState, numEntries int) {
+	labels := prometheus.Labels{
+		labelSink:  sink,
+		labelState: string(state),
+	}
+	auditSinkEntries.With(labels).Add(float64(numEntries))
+}
+
+func SetPendingAuditLogEntries(numEntries int64) {
+	pendingAuditLogEntries.Set(float64(numEntries))
+}
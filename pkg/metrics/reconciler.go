@@ -20,6 +20,9 @@ const (
 	ReconcilerStateStarted    ReconcilerState = "started"
 	ReconcilerStateFailed     ReconcilerState = "failed"
 	ReconcilerStateSuccessful ReconcilerState = "successful"
+	ReconcilerStateSkipped    ReconcilerState = "skipped"
+	ReconcilerStateTimeout    ReconcilerState = "timeout"
+	ReconcilerStateCanceled   ReconcilerState = "canceled"
 )
 
 const (
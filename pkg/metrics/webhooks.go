@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type WebhookDeliveryState string
+
+const (
+	WebhookDeliveryStateDelivered WebhookDeliveryState = "delivered"
+	WebhookDeliveryStateRetry     WebhookDeliveryState = "retry"
+	WebhookDeliveryStateFailed    WebhookDeliveryState = "failed"
+)
+
+const labelEventType = "event_type"
+
+var (
+	webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "webhook_deliveries",
+		Help:      "Number of webhook delivery attempts, labeled with event type and delivery state",
+	}, []string{labelEventType, labelState})
+
+	pendingWebhookDeliveries = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "webhook_pending_deliveries",
+		Help:      "How many webhook deliveries are waiting to be delivered",
+	})
+)
+
+func IncWebhookDeliveries(eventType string, state WebhookDeliveryState) {
+	labels := prometheus.Labels{
+		labelEventType: eventType,
+		labelState:     string(state),
+	}
+	webhookDeliveries.With(labels).Inc()
+}
+
+func SetPendingWebhookDeliveries(numDeliveries int64) {
+	pendingWebhookDeliveries.Set(float64(numDeliveries))
+}
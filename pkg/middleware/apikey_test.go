@@ -11,6 +11,7 @@ import (
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/middleware"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -54,7 +55,7 @@ func TestApiKeyAuthentication(t *testing.T) {
 			},
 		}
 		roles := []*db.Role{
-			{RoleName: sqlc.RoleNameAdmin},
+			{RoleName: roles.RoleNameAdmin},
 		}
 
 		database := db.NewMockDatabase(t)
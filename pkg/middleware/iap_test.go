@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -35,7 +36,7 @@ func TestIapAuthentication(t *testing.T) {
 			},
 		}
 		roles := []*db.Role{
-			{RoleName: sqlc.RoleNameAdmin},
+			{RoleName: roles.RoleNameAdmin},
 		}
 
 		responseWriter := httptest.NewRecorder()
@@ -13,6 +13,7 @@ import (
 	"github.com/nais/teams-backend/pkg/authz"
 	"github.com/nais/teams-backend/pkg/db"
 	"github.com/nais/teams-backend/pkg/middleware"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/stretchr/testify/assert"
 )
@@ -85,7 +86,7 @@ func TestOauth2Authentication(t *testing.T) {
 			},
 		}
 		roles := []*db.Role{
-			{RoleName: sqlc.RoleNameAdmin},
+			{RoleName: roles.RoleNameAdmin},
 		}
 		session := &db.Session{Session: &sqlc.Session{
 			ID:      sessionID,
@@ -3,6 +3,7 @@ package azure_group_reconciler
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/nais/teams-backend/pkg/types"
@@ -121,6 +122,63 @@ func (r *azureGroupReconciler) Reconcile(ctx context.Context, input reconcilers.
 	return nil
 }
 
+func (r *azureGroupReconciler) Plan(ctx context.Context, input reconcilers.Input) ([]reconcilers.PlannedChange, error) {
+	state := &reconcilers.AzureState{}
+	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), input.Team.Slug, state)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load system state for team %q in system %q: %w", input.Team.Slug, r.Name(), err)
+	}
+
+	changes := make([]reconcilers.PlannedChange, 0)
+	prefixedName := teamNameWithPrefix(input.Team.Slug)
+	members := make([]*azureclient.Member, 0)
+
+	if state.GroupID == nil {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionCreate,
+			Resource: prefixedName,
+			Details:  fmt.Sprintf("Create Azure AD group %q", prefixedName),
+		})
+	} else {
+		grp, err := r.client.GetGroupById(ctx, *state.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		prefixedName = grp.MailNickname
+
+		members, err = r.client.ListGroupMembers(ctx, grp)
+		if err != nil {
+			return nil, fmt.Errorf("list existing members in Azure group %q: %s", grp.MailNickname, err)
+		}
+	}
+
+	membersToRemove := remoteOnlyMembers(members, input.TeamMembers)
+	sort.Slice(membersToRemove, func(i, j int) bool {
+		return membersToRemove[i].Mail < membersToRemove[j].Mail
+	})
+	for _, member := range membersToRemove {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionRemoveMember,
+			Resource: prefixedName,
+			Details:  fmt.Sprintf("Remove member %q from Azure group %q", strings.ToLower(member.Mail), prefixedName),
+		})
+	}
+
+	membersToAdd := localOnlyMembers(members, input.TeamMembers)
+	sort.Slice(membersToAdd, func(i, j int) bool {
+		return membersToAdd[i].Email < membersToAdd[j].Email
+	})
+	for _, user := range membersToAdd {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionAddMember,
+			Resource: prefixedName,
+			Details:  fmt.Sprintf("Add member %q to Azure group %q", user.Email, prefixedName),
+		})
+	}
+
+	return changes, nil
+}
+
 func (r *azureGroupReconciler) Delete(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID) error {
 	state := &reconcilers.AzureState{}
 	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), teamSlug, state)
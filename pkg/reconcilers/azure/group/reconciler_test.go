@@ -320,6 +320,85 @@ func TestAzureReconciler_Reconcile(t *testing.T) {
 	})
 }
 
+func TestAzureReconciler_Plan(t *testing.T) {
+	const domain = "example.com"
+
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	group := &azureclient.Group{
+		ID:           "some-group-id",
+		MailNickname: "nais-team-slug",
+	}
+	input := reconcilers.Input{
+		CorrelationID: uuid.New(),
+		Team: db.Team{
+			Team: &sqlc.Team{
+				Slug:    "slug",
+				Purpose: "My purpose",
+			},
+		},
+		TeamMembers: []*db.User{
+			{User: &sqlc.User{Email: "keeper@example.com"}},
+			{User: &sqlc.User{Email: "add@example.com"}},
+		},
+	}
+
+	t.Run("group does not exist", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, azure_group_reconciler.Name, input.Team.Slug, mock.Anything).
+			Return(nil).
+			Once()
+
+		changes, err := azure_group_reconciler.
+			New(database, auditlogger.NewMockAuditLogger(t), azureclient.NewMockClient(t), domain, log).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionCreate, Resource: "nais-team-slug", Details: `Create Azure AD group "nais-team-slug"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: "nais-team-slug", Details: `Add member "add@example.com" to Azure group "nais-team-slug"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: "nais-team-slug", Details: `Add member "keeper@example.com" to Azure group "nais-team-slug"`},
+		}, changes)
+	})
+
+	t.Run("existing group with outdated members", func(t *testing.T) {
+		grpID := uuid.New()
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, azure_group_reconciler.Name, input.Team.Slug, mock.Anything).
+			Run(func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.AzureState)
+				state.GroupID = &grpID
+			}).
+			Return(nil).
+			Once()
+
+		mockClient := azureclient.NewMockClient(t)
+		mockClient.
+			On("GetGroupById", ctx, grpID).
+			Return(group, nil).
+			Once()
+		mockClient.
+			On("ListGroupMembers", ctx, group).
+			Return([]*azureclient.Member{
+				{ID: "keeper", Mail: "keeper@example.com"},
+				{ID: "remove", Mail: "Remove@example.com"},
+			}, nil).
+			Once()
+
+		changes, err := azure_group_reconciler.
+			New(database, auditlogger.NewMockAuditLogger(t), mockClient, domain, log).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionRemoveMember, Resource: "nais-team-slug", Details: `Remove member "remove@example.com" from Azure group "nais-team-slug"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: "nais-team-slug", Details: `Add member "add@example.com" to Azure group "nais-team-slug"`},
+		}, changes)
+	})
+}
+
 func TestAzureReconciler_Delete(t *testing.T) {
 	const tenantDomain = "example.com"
 
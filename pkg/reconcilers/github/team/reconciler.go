@@ -108,6 +108,77 @@ func (r *githubTeamReconciler) Reconcile(ctx context.Context, input reconcilers.
 	return r.connectUsers(ctx, githubTeam, input)
 }
 
+func (r *githubTeamReconciler) Plan(ctx context.Context, input reconcilers.Input) ([]reconcilers.PlannedChange, error) {
+	state := &reconcilers.GitHubState{}
+	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), input.Team.Slug, state)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load system state for team %q in system %q: %w", input.Team.Slug, r.Name(), err)
+	}
+
+	githubTeam, err := r.getTeam(ctx, *state)
+	if err != nil {
+		return nil, err
+	}
+
+	teamsBackendUserWithGitHubUser, err := r.mapSSOUsers(ctx, input.TeamMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]reconcilers.PlannedChange, 0)
+	membersAccordingToGitHub := make([]*github.User, 0)
+
+	if githubTeam == nil {
+		slug := input.Team.Slug.String()
+		if state.Slug != nil {
+			slug = state.Slug.String()
+		}
+		githubTeam = &github.Team{Slug: &slug}
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionCreate,
+			Resource: *githubTeam.Slug,
+			Details:  fmt.Sprintf("Create GitHub team %q", *githubTeam.Slug),
+		})
+	} else {
+		if !gitHubTeamIsUpToDate(input.Team, *githubTeam) {
+			changes = append(changes, reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionUpdate,
+				Resource: *githubTeam.Slug,
+				Details:  fmt.Sprintf("Update description and privacy of GitHub team %q", *githubTeam.Slug),
+			})
+		}
+
+		membersAccordingToGitHub, err = r.getTeamMembers(ctx, *githubTeam.Slug)
+		if err != nil {
+			return nil, fmt.Errorf("list existing members in GitHub team %q: %w", *githubTeam.Slug, err)
+		}
+	}
+
+	for _, gitHubUser := range remoteOnlyMembers(membersAccordingToGitHub, teamsBackendUserWithGitHubUser) {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionRemoveMember,
+			Resource: *githubTeam.Slug,
+			Details:  fmt.Sprintf("Remove member %q from GitHub team %q", gitHubUser.GetLogin(), *githubTeam.Slug),
+		})
+	}
+
+	membersToAdd := localOnlyMembers(teamsBackendUserWithGitHubUser, membersAccordingToGitHub)
+	usernames := make([]string, 0, len(membersToAdd))
+	for username := range membersToAdd {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	for _, username := range usernames {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionAddMember,
+			Resource: *githubTeam.Slug,
+			Details:  fmt.Sprintf("Add member %q to GitHub team %q", username, *githubTeam.Slug),
+		})
+	}
+
+	return changes, nil
+}
+
 func (r *githubTeamReconciler) Delete(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID) error {
 	state := &reconcilers.GitHubState{}
 	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), teamSlug, state)
@@ -202,27 +273,42 @@ func (r *githubTeamReconciler) removeTeamIDPSync(ctx context.Context, slug strin
 	return nil
 }
 
+// getTeam Get the GitHub team referenced in the state. Returns nil if the team does not exist.
+func (r *githubTeamReconciler) getTeam(ctx context.Context, state reconcilers.GitHubState) (*github.Team, error) {
+	if state.Slug == nil {
+		return nil, nil
+	}
+
+	existingTeam, resp, err := r.teamsService.GetTeamBySlug(ctx, r.org, string(*state.Slug))
+	metrics.IncExternalHTTPCalls(metricsSystemName, unwrapResponse(resp), err)
+	if resp == nil && err != nil {
+		return nil, fmt.Errorf("unable to fetch GitHub team %q: %w", *state.Slug, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, nil
+	case http.StatusOK:
+		return existingTeam, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("server error from GitHub: %s: %s", resp.Status, string(body))
+	}
+}
+
 func (r *githubTeamReconciler) getOrCreateTeam(ctx context.Context, state reconcilers.GitHubState, correlationID uuid.UUID, team db.Team) (*github.Team, error) {
-	slug := team.Slug.String()
+	existingTeam, err := r.getTeam(ctx, state)
+	if err != nil {
+		return nil, err
+	}
 
+	if existingTeam != nil {
+		return existingTeam, nil
+	}
+
+	slug := team.Slug.String()
 	if state.Slug != nil {
 		slug = state.Slug.String()
-
-		existingTeam, resp, err := r.teamsService.GetTeamBySlug(ctx, r.org, string(*state.Slug))
-		metrics.IncExternalHTTPCalls(metricsSystemName, unwrapResponse(resp), err)
-		if resp == nil && err != nil {
-			return nil, fmt.Errorf("unable to fetch GitHub team %q: %w", *state.Slug, err)
-		}
-
-		switch resp.StatusCode {
-		case http.StatusNotFound:
-			break
-		case http.StatusOK:
-			return existingTeam, nil
-		default:
-			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("server error from GitHub: %s: %s", resp.Status, string(body))
-		}
 	}
 
 	githubTeam, resp, err := r.teamsService.CreateTeam(ctx, r.org, github.NewTeam{
@@ -537,6 +537,102 @@ func TestGitHubReconciler_Reconcile(t *testing.T) {
 	})
 }
 
+func TestGitHubReconciler_Plan(t *testing.T) {
+	const (
+		domain   = "example.com"
+		org      = "my-organization"
+		teamName = "myteam"
+	)
+
+	ctx := context.Background()
+	teamSlug := slug.Slug(teamName)
+	componentName := github_team_reconciler.Name
+
+	createLogin := "should-create"
+	createEmail := "should-create@example.com"
+	keepLogin := "should-keep"
+	keepEmail := "should-keep@example.com"
+	removeLogin := "should-remove"
+
+	input := reconcilers.Input{
+		CorrelationID: uuid.New(),
+		Team:          db.Team{Team: &sqlc.Team{Slug: teamSlug, Purpose: "some purpose"}},
+		TeamMembers: []*db.User{
+			{User: &sqlc.User{Email: createEmail}},
+			{User: &sqlc.User{Email: keepEmail}},
+		},
+	}
+
+	newLogger := func(t *testing.T) *logger.MockLogger {
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithComponent", types.ComponentNameGithubTeam).
+			Return(log).
+			Once()
+		return log
+	}
+
+	t.Run("team does not exist", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, componentName, teamSlug, mock.Anything).
+			Return(nil).
+			Once()
+
+		teamsService := github_team_reconciler.NewMockTeamsService(t)
+		graphClient := github_team_reconciler.NewMockGraphClient(t)
+		configureRegisterLoginEmail(graphClient, org, createEmail, createLogin)
+		configureRegisterLoginEmail(graphClient, org, keepEmail, keepLogin)
+
+		changes, err := github_team_reconciler.
+			New(database, auditlogger.NewMockAuditLogger(t), org, domain, teamsService, graphClient, newLogger(t)).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionCreate, Resource: teamName, Details: `Create GitHub team "myteam"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: teamName, Details: `Add member "should-create" to GitHub team "myteam"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: teamName, Details: `Add member "should-keep" to GitHub team "myteam"`},
+		}, changes)
+	})
+
+	t.Run("existing team is out of date", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, componentName, teamSlug, mock.Anything).
+			Run(func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.GitHubState)
+				state.Slug = &teamSlug
+			}).
+			Return(nil).
+			Once()
+
+		teamsService := github_team_reconciler.NewMockTeamsService(t)
+		teamsService.
+			On("GetTeamBySlug", ctx, org, teamName).
+			Return(
+				&github.Team{Slug: helpers.Strp(teamName), Description: helpers.Strp("old purpose"), Privacy: helpers.Strp("closed")},
+				&github.Response{Response: &http.Response{StatusCode: http.StatusOK}},
+				nil,
+			).
+			Once()
+		configureListTeamMembersBySlug(teamsService, org, teamName, keepLogin, removeLogin)
+
+		graphClient := github_team_reconciler.NewMockGraphClient(t)
+		configureRegisterLoginEmail(graphClient, org, createEmail, createLogin)
+		configureRegisterLoginEmail(graphClient, org, keepEmail, keepLogin)
+
+		changes, err := github_team_reconciler.
+			New(database, auditlogger.NewMockAuditLogger(t), org, domain, teamsService, graphClient, newLogger(t)).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionUpdate, Resource: teamName, Details: `Update description and privacy of GitHub team "myteam"`},
+			{Action: reconcilers.PlannedChangeActionRemoveMember, Resource: teamName, Details: `Remove member "should-remove" from GitHub team "myteam"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: teamName, Details: `Add member "should-create" to GitHub team "myteam"`},
+		}, changes)
+	})
+}
+
 func TestGitHubReconciler_Delete(t *testing.T) {
 	const domain = "example.com"
 	const org = "my-organization"
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/nais/teams-backend/pkg/types"
 
@@ -84,6 +86,10 @@ func (r *garReconciler) Name() sqlc.ReconcilerName {
 	return Name
 }
 
+func (r *garReconciler) Prerequisites() []sqlc.ReconcilerName {
+	return []sqlc.ReconcilerName{google_workspace_admin_reconciler.Name}
+}
+
 func (r *garReconciler) Reconcile(ctx context.Context, input reconcilers.Input) error {
 	log := r.log.WithTeamSlug(string(input.Team.Slug))
 	serviceAccount, err := r.getOrCreateServiceAccount(ctx, input)
@@ -122,6 +128,119 @@ func (r *garReconciler) Reconcile(ctx context.Context, input reconcilers.Input)
 	return nil
 }
 
+func (r *garReconciler) Plan(ctx context.Context, input reconcilers.Input) ([]reconcilers.PlannedChange, error) {
+	changes := make([]reconcilers.PlannedChange, 0)
+	serviceAccountName, accountID := serviceAccountNameAndAccountID(input.Team.Slug, r.managementProjectID)
+	serviceAccountEmail := fmt.Sprintf("%s@%s.iam.gserviceaccount.com", accountID, r.managementProjectID)
+
+	members, err := r.getServiceAccountPolicyMembers(ctx, input.Team.Slug)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(members)
+
+	_, err = r.iamService.Projects.ServiceAccounts.Get(serviceAccountName).Context(ctx).Do()
+	if err != nil {
+		googleError, ok := err.(*googleapi.Error)
+		if !ok || googleError.Code != http.StatusNotFound {
+			return nil, fmt.Errorf("get service account %q: %w", serviceAccountName, err)
+		}
+
+		changes = append(changes,
+			reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionCreate,
+				Resource: serviceAccountEmail,
+				Details:  fmt.Sprintf("Create GAR service account %q", serviceAccountEmail),
+			},
+			reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionUpdate,
+				Resource: serviceAccountEmail,
+				Details:  fmt.Sprintf("Allow %d GitHub repositories to impersonate GAR service account %q", len(members), serviceAccountEmail),
+			},
+		)
+	} else {
+		policy, err := r.iamService.Projects.ServiceAccounts.GetIamPolicy(serviceAccountName).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("get IAM policy for service account %q: %w", serviceAccountName, err)
+		}
+
+		existingMembers := make([]string, 0)
+		for _, binding := range policy.Bindings {
+			if binding.Role == "roles/iam.workloadIdentityUser" {
+				existingMembers = append(existingMembers, binding.Members...)
+			}
+		}
+		sort.Strings(existingMembers)
+
+		if strings.Join(existingMembers, ",") != strings.Join(members, ",") {
+			changes = append(changes, reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionUpdate,
+				Resource: serviceAccountEmail,
+				Details:  fmt.Sprintf("Allow %d GitHub repositories to impersonate GAR service account %q", len(members), serviceAccountEmail),
+			})
+		}
+	}
+
+	googleWorkspaceState := &reconcilers.GoogleWorkspaceState{}
+	err = r.database.LoadReconcilerStateForTeam(ctx, google_workspace_admin_reconciler.Name, input.Team.Slug, googleWorkspaceState)
+	if err != nil {
+		return nil, fmt.Errorf("load system state for team %q in system %q: %w", input.Team.Slug, google_workspace_admin_reconciler.Name, err)
+	}
+
+	name := fmt.Sprintf("projects/%s/locations/europe-north1/repositories/%s", r.managementProjectID, input.Team.Slug)
+	description := fmt.Sprintf("Docker repository for team %q. Managed by teams-backend.", input.Team.Slug)
+	existing, err := r.artifactRegistry.GetRepository(ctx, &artifactregistrypb.GetRepositoryRequest{
+		Name: name,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	if existing == nil {
+		return append(changes,
+			reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionCreate,
+				Resource: name,
+				Details:  fmt.Sprintf("Create GAR repository %q", name),
+			},
+			reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionUpdate,
+				Resource: name,
+				Details:  fmt.Sprintf("Set IAM policy for GAR repository %q", name),
+			},
+		), nil
+	}
+
+	if existing.Format != artifactregistrypb.Repository_DOCKER {
+		return nil, fmt.Errorf("existing repo has invalid format: %q %q", name, existing.Format)
+	}
+
+	if fields := garRepositoryChanges(existing, input.Team.Slug, description); len(fields) > 0 {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionUpdate,
+			Resource: name,
+			Details:  fmt.Sprintf("Update %s of GAR repository %q", strings.Join(fields, ", "), name),
+		})
+	}
+
+	policy, err := r.artifactRegistry.GetIamPolicy(ctx, &iampb.GetIamPolicyRequest{
+		Resource: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get IAM policy for GAR repository %q: %w", name, err)
+	}
+
+	if !garRepositoryPolicyIsUpToDate(policy.Bindings, garRepositoryPolicyBindings(serviceAccountEmail, googleWorkspaceState.GroupEmail)) {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionUpdate,
+			Resource: name,
+			Details:  fmt.Sprintf("Set IAM policy for GAR repository %q", name),
+		})
+	}
+
+	return changes, nil
+}
+
 func (r *garReconciler) Delete(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID) error {
 	state := &reconcilers.GoogleGarState{}
 	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), teamSlug, state)
@@ -283,24 +402,31 @@ func (r *garReconciler) getServiceAccountPolicyMembers(ctx context.Context, team
 	return members, nil
 }
 
-func (r *garReconciler) updateGarRepository(ctx context.Context, repository *artifactregistrypb.Repository, slug slug.Slug, description string, log logger.Logger) (*artifactregistrypb.Repository, error) {
+// garRepositoryChanges Get the paths of the repository fields that are not up to date
+func garRepositoryChanges(repository *artifactregistrypb.Repository, slug slug.Slug, description string) []string {
 	var changes []string
 	if repository.Labels["team"] != string(slug) {
-		repository.Labels["team"] = string(slug)
 		changes = append(changes, "labels.team")
 	}
 
 	if repository.Labels[reconcilers.ManagedByLabelName] != reconcilers.ManagedByLabelValue {
-		repository.Labels[reconcilers.ManagedByLabelName] = reconcilers.ManagedByLabelValue
 		changes = append(changes, "labels.managed-by")
 	}
 
 	if repository.Description != description {
-		repository.Description = fmt.Sprintf("Docker repository for team %q. Managed by teams-backend.", slug)
 		changes = append(changes, "description")
 	}
 
+	return changes
+}
+
+func (r *garReconciler) updateGarRepository(ctx context.Context, repository *artifactregistrypb.Repository, slug slug.Slug, description string, log logger.Logger) (*artifactregistrypb.Repository, error) {
+	changes := garRepositoryChanges(repository, slug, description)
 	if len(changes) > 0 {
+		repository.Labels["team"] = string(slug)
+		repository.Labels[reconcilers.ManagedByLabelName] = reconcilers.ManagedByLabelValue
+		repository.Description = description
+
 		updateRequest := &artifactregistrypb.UpdateRepositoryRequest{
 			Repository: repository,
 			UpdateMask: &fieldmaskpb.FieldMask{
@@ -316,10 +442,20 @@ func (r *garReconciler) updateGarRepository(ctx context.Context, repository *art
 }
 
 func (r *garReconciler) setGarRepositoryPolicy(ctx context.Context, repository *artifactregistrypb.Repository, serviceAccount *iam.ServiceAccount, groupEmail *string) error {
+	_, err := r.artifactRegistry.SetIamPolicy(ctx, &iampb.SetIamPolicyRequest{
+		Resource: repository.Name,
+		Policy: &iampb.Policy{
+			Bindings: garRepositoryPolicyBindings(serviceAccount.Email, groupEmail),
+		},
+	})
+	return err
+}
+
+func garRepositoryPolicyBindings(serviceAccountEmail string, groupEmail *string) []*iampb.Binding {
 	bindings := []*iampb.Binding{
 		{
 			Role:    "roles/artifactregistry.writer",
-			Members: []string{"serviceAccount:" + serviceAccount.Email},
+			Members: []string{"serviceAccount:" + serviceAccountEmail},
 		},
 	}
 
@@ -330,13 +466,34 @@ func (r *garReconciler) setGarRepositoryPolicy(ctx context.Context, repository *
 		})
 	}
 
-	_, err := r.artifactRegistry.SetIamPolicy(ctx, &iampb.SetIamPolicyRequest{
-		Resource: repository.Name,
-		Policy: &iampb.Policy{
-			Bindings: bindings,
-		},
-	})
-	return err
+	return bindings
+}
+
+// garRepositoryPolicyIsUpToDate Check if the existing bindings grants exactly the same members the same roles as the
+// desired bindings
+func garRepositoryPolicyIsUpToDate(existing, desired []*iampb.Binding) bool {
+	toMap := func(bindings []*iampb.Binding) map[string]string {
+		m := make(map[string]string)
+		for _, binding := range bindings {
+			members := append([]string{}, binding.Members...)
+			sort.Strings(members)
+			m[binding.Role] = strings.Join(members, ",")
+		}
+		return m
+	}
+
+	existingMap, desiredMap := toMap(existing), toMap(desired)
+	if len(existingMap) != len(desiredMap) {
+		return false
+	}
+
+	for role, members := range desiredMap {
+		if existingMap[role] != members {
+			return false
+		}
+	}
+
+	return true
 }
 
 func serviceAccountNameAndAccountID(teamSlug slug.Slug, projectID string) (serviceAccountName, accountID string) {
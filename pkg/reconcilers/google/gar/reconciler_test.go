@@ -57,6 +57,9 @@ type fakeArtifactRegistry struct {
 	setIamPolicy        func(context.Context, *iampb.SetIamPolicyRequest) (*iampb.Policy, error)
 	setIamPolicyCounter int
 
+	getIamPolicy        func(context.Context, *iampb.GetIamPolicyRequest) (*iampb.Policy, error)
+	getIamPolicyCounter int
+
 	artifactregistrypb.UnimplementedArtifactRegistryServer
 }
 
@@ -90,6 +93,11 @@ func (f *fakeArtifactRegistry) SetIamPolicy(ctx context.Context, r *iampb.SetIam
 	return f.setIamPolicy(ctx, r)
 }
 
+func (f *fakeArtifactRegistry) GetIamPolicy(ctx context.Context, r *iampb.GetIamPolicyRequest) (*iampb.Policy, error) {
+	f.getIamPolicyCounter++
+	return f.getIamPolicy(ctx, r)
+}
+
 func (f *fakeArtifactRegistry) assert(t *testing.T) {
 	if f.create != nil {
 		assert.Equal(t, f.createCounter, 1, "mock expected 1 call to create")
@@ -106,6 +114,9 @@ func (f *fakeArtifactRegistry) assert(t *testing.T) {
 	if f.setIamPolicy != nil {
 		assert.Equal(t, f.setIamPolicyCounter, 1, "mock expected 1 call to setIamPolicy")
 	}
+	if f.getIamPolicy != nil {
+		assert.Equal(t, f.getIamPolicyCounter, 1, "mock expected 1 call to getIamPolicy")
+	}
 }
 
 func (m *mocks) start(t *testing.T, ctx context.Context) (*artifactregistry.Client, *iam.Service) {
@@ -692,3 +703,152 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestPlan(t *testing.T) {
+	const (
+		managementProjectID      = "management-project-123"
+		workloadIdentityPoolName = "projects/123456789/locations/global/workloadIdentityPools/some-identity-pool"
+	)
+
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	groupEmail := "team@example.com"
+	team := db.Team{Team: &sqlc.Team{Slug: slug.Slug("team")}}
+	input := reconcilers.Input{
+		CorrelationID: uuid.New(),
+		Team:          team,
+	}
+
+	email := "gar-team-ca8b@management-project-123.iam.gserviceaccount.com"
+	repositoryName := fmt.Sprintf("projects/%s/locations/europe-north1/repositories/%s", managementProjectID, team.Slug)
+	member := "principalSet://iam.googleapis.com/" + workloadIdentityPoolName + "/attribute.repository/test/repository"
+
+	mockGitHubState := func(database *db.MockDatabase) {
+		database.
+			On("LoadReconcilerStateForTeam", ctx, sqlc.ReconcilerNameGithubTeam, team.Slug, mock.Anything).
+			Run(func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.GitHubState)
+				state.Repositories = []*reconcilers.GitHubRepository{
+					{
+						Name: "test/repository",
+						Permissions: []*reconcilers.GitHubRepositoryPermission{
+							{Name: "push", Granted: true},
+						},
+					},
+				}
+			}).
+			Return(nil).
+			Once()
+	}
+
+	mockGoogleWorkspaceState := func(database *db.MockDatabase) {
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, team.Slug, mock.Anything).
+			Run(func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.GoogleWorkspaceState)
+				state.GroupEmail = &groupEmail
+			}).
+			Return(nil).
+			Once()
+	}
+
+	t.Run("nothing exists", func(t *testing.T) {
+		mocks := mocks{
+			iam: test.HttpServerWithHandlers(t, []http.HandlerFunc{
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				},
+			}),
+			artifactRegistry: &fakeArtifactRegistry{
+				get: func(ctx context.Context, r *artifactregistrypb.GetRepositoryRequest) (*artifactregistrypb.Repository, error) {
+					return nil, status.Error(codes.NotFound, "not found")
+				},
+			},
+		}
+		garClient, iamService := mocks.start(t, ctx)
+		database := db.NewMockDatabase(t)
+		mockGitHubState(database)
+		mockGoogleWorkspaceState(database)
+
+		changes, err := google_gar.
+			New(auditlogger.NewMockAuditLogger(t), database, managementProjectID, workloadIdentityPoolName, garClient, iamService, log).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionCreate, Resource: email, Details: fmt.Sprintf("Create GAR service account %q", email)},
+			{Action: reconcilers.PlannedChangeActionUpdate, Resource: email, Details: fmt.Sprintf("Allow 1 GitHub repositories to impersonate GAR service account %q", email)},
+			{Action: reconcilers.PlannedChangeActionCreate, Resource: repositoryName, Details: fmt.Sprintf("Create GAR repository %q", repositoryName)},
+			{Action: reconcilers.PlannedChangeActionUpdate, Resource: repositoryName, Details: fmt.Sprintf("Set IAM policy for GAR repository %q", repositoryName)},
+		}, changes)
+	})
+
+	t.Run("everything is up to date", func(t *testing.T) {
+		mocks := mocks{
+			iam: test.HttpServerWithHandlers(t, []http.HandlerFunc{
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.NoError(t, json.NewEncoder(w).Encode(&iam.ServiceAccount{Email: email}))
+				},
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.NoError(t, json.NewEncoder(w).Encode(&iam.Policy{
+						Bindings: []*iam.Binding{
+							{Role: "roles/iam.workloadIdentityUser", Members: []string{member}},
+						},
+					}))
+				},
+			}),
+			artifactRegistry: &fakeArtifactRegistry{
+				get: func(ctx context.Context, r *artifactregistrypb.GetRepositoryRequest) (*artifactregistrypb.Repository, error) {
+					return &artifactregistrypb.Repository{
+						Name:        repositoryName,
+						Format:      artifactregistrypb.Repository_DOCKER,
+						Description: fmt.Sprintf("Docker repository for team %q. Managed by teams-backend.", team.Slug),
+						Labels: map[string]string{
+							"team":                         string(team.Slug),
+							reconcilers.ManagedByLabelName: reconcilers.ManagedByLabelValue,
+						},
+					}, nil
+				},
+				getIamPolicy: func(ctx context.Context, r *iampb.GetIamPolicyRequest) (*iampb.Policy, error) {
+					assert.Equal(t, repositoryName, r.Resource)
+					return &iampb.Policy{
+						Bindings: []*iampb.Binding{
+							{Role: "roles/artifactregistry.writer", Members: []string{"serviceAccount:" + email}},
+							{Role: "roles/artifactregistry.repoAdmin", Members: []string{"group:" + groupEmail}},
+						},
+					}, nil
+				},
+			},
+		}
+		garClient, iamService := mocks.start(t, ctx)
+		database := db.NewMockDatabase(t)
+		mockGitHubState(database)
+		mockGoogleWorkspaceState(database)
+
+		changes, err := google_gar.
+			New(auditlogger.NewMockAuditLogger(t), database, managementProjectID, workloadIdentityPoolName, garClient, iamService, log).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("unable to get service account", func(t *testing.T) {
+		mocks := mocks{
+			iam: test.HttpServerWithHandlers(t, []http.HandlerFunc{
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusForbidden)
+				},
+			}),
+		}
+		_, iamService := mocks.start(t, ctx)
+		database := db.NewMockDatabase(t)
+		mockGitHubState(database)
+
+		changes, err := google_gar.
+			New(auditlogger.NewMockAuditLogger(t), database, managementProjectID, workloadIdentityPoolName, nil, iamService, log).
+			Plan(ctx, input)
+		assert.ErrorContains(t, err, "get service account")
+		assert.Nil(t, changes)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -67,6 +68,10 @@ func (r *googleGcpReconciler) Name() sqlc.ReconcilerName {
 	return Name
 }
 
+func (r *googleGcpReconciler) Prerequisites() []sqlc.ReconcilerName {
+	return []sqlc.ReconcilerName{google_workspace_admin_reconciler.Name}
+}
+
 func (r *googleGcpReconciler) Reconcile(ctx context.Context, input reconcilers.Input) error {
 	state := &reconcilers.GoogleGcpProjectState{
 		Projects: make(map[string]reconcilers.GoogleGcpEnvironmentProject),
@@ -107,12 +112,7 @@ func (r *googleGcpReconciler) Reconcile(ctx context.Context, input reconcilers.I
 			r.log.WithError(err).Error("persist system state")
 		}
 
-		err = r.ensureProjectHasLabels(ctx, teamProject, map[string]string{
-			"team":                         string(input.Team.Slug),
-			"environment":                  environment,
-			"tenant":                       r.tenantName,
-			reconcilers.ManagedByLabelName: reconcilers.ManagedByLabelValue,
-		})
+		err = r.ensureProjectHasLabels(ctx, teamProject, r.projectLabels(input.Team.Slug, environment))
 		if err != nil {
 			return fmt.Errorf("set project labels: %w", err)
 		}
@@ -146,6 +146,189 @@ func (r *googleGcpReconciler) Reconcile(ctx context.Context, input reconcilers.I
 	return nil
 }
 
+func (r *googleGcpReconciler) Plan(ctx context.Context, input reconcilers.Input) ([]reconcilers.PlannedChange, error) {
+	state := &reconcilers.GoogleGcpProjectState{
+		Projects: make(map[string]reconcilers.GoogleGcpEnvironmentProject),
+	}
+	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), input.Team.Slug, state)
+	if err != nil {
+		return nil, fmt.Errorf("load system state for team %q in system %q: %w", input.Team.Slug, r.Name(), err)
+	}
+
+	googleWorkspaceState := &reconcilers.GoogleWorkspaceState{}
+	err = r.database.LoadReconcilerStateForTeam(ctx, google_workspace_admin_reconciler.Name, input.Team.Slug, googleWorkspaceState)
+	if err != nil {
+		return nil, fmt.Errorf("load system state for team %q in system %q: %w", input.Team.Slug, google_workspace_admin_reconciler.Name, err)
+	}
+
+	environments := make([]string, 0, len(r.clusters))
+	for environment := range r.clusters {
+		environments = append(environments, environment)
+	}
+	sort.Strings(environments)
+
+	changes := make([]reconcilers.PlannedChange, 0)
+	for _, environment := range environments {
+		projectID := GenerateProjectID(r.domain, environment, input.Team.Slug)
+		if projectFromState, exists := state.Projects[environment]; exists {
+			projectID = projectFromState.ProjectID
+		}
+
+		response, err := r.gcpServices.CloudResourceManagerProjectsService.Search().Query("id:" + projectID).Do()
+		if err != nil {
+			metrics.IncExternalCallsByError(metricsSystemName, err)
+			return nil, err
+		}
+		metrics.IncExternalCalls(metricsSystemName, response.HTTPStatusCode)
+
+		if len(response.Projects) > 1 {
+			return nil, fmt.Errorf("multiple projects with id: %q found, unable to continue", projectID)
+		}
+
+		if len(response.Projects) == 0 {
+			changes = append(changes,
+				reconcilers.PlannedChange{
+					Action:   reconcilers.PlannedChangeActionCreate,
+					Resource: projectID,
+					Details:  fmt.Sprintf("Create GCP project %q for team %q in environment %q", projectID, input.Team.Slug, environment),
+				},
+				reconcilers.PlannedChange{
+					Action:   reconcilers.PlannedChangeActionSetBilling,
+					Resource: projectID,
+					Details:  fmt.Sprintf("Set billing account %q for GCP project %q", r.billingAccount, projectID),
+				},
+				reconcilers.PlannedChange{
+					Action:   reconcilers.PlannedChangeActionCreate,
+					Resource: projectID,
+					Details:  fmt.Sprintf("Create CNRM service account in GCP project %q", projectID),
+				},
+				reconcilers.PlannedChange{
+					Action:   reconcilers.PlannedChangeActionUpdate,
+					Resource: projectID,
+					Details:  fmt.Sprintf("Assign IAM permissions in GCP project %q", projectID),
+				},
+			)
+			continue
+		}
+
+		projectChanges, err := r.planExistingProject(ctx, response.Projects[0], input, googleWorkspaceState.GroupEmail, environment)
+		if err != nil {
+			return nil, fmt.Errorf("plan changes for GCP project %q for team %q in environment %q: %w", projectID, input.Team.Slug, environment, err)
+		}
+		changes = append(changes, projectChanges...)
+	}
+
+	return changes, nil
+}
+
+// planExistingProject Get the changes a reconcile would make to an existing team project
+func (r *googleGcpReconciler) planExistingProject(ctx context.Context, project *cloudresourcemanager.Project, input reconcilers.Input, groupEmail *string, environment string) ([]reconcilers.PlannedChange, error) {
+	changes := make([]reconcilers.PlannedChange, 0)
+
+	for key, value := range r.projectLabels(input.Team.Slug, environment) {
+		if project.Labels[key] != value {
+			changes = append(changes, reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionUpdate,
+				Resource: project.ProjectId,
+				Details:  fmt.Sprintf("Set labels on GCP project %q", project.ProjectId),
+			})
+			break
+		}
+	}
+
+	info, err := r.gcpServices.CloudBillingProjectsService.GetBillingInfo(project.Name).Do()
+	if err != nil {
+		metrics.IncExternalCallsByError(metricsSystemName, err)
+		return nil, err
+	}
+	metrics.IncExternalCalls(metricsSystemName, info.HTTPStatusCode)
+
+	if info.BillingAccountName != r.billingAccount {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionSetBilling,
+			Resource: project.ProjectId,
+			Details:  fmt.Sprintf("Change billing account for GCP project %q from %q to %q", project.ProjectId, info.BillingAccountName, r.billingAccount),
+		})
+	}
+
+	cnrmEmail := fmt.Sprintf("%s@%s.iam.gserviceaccount.com", reconcilers.CnrmServiceAccountAccountID, project.ProjectId)
+	serviceAccount, err := r.gcpServices.IamProjectsServiceAccountsService.Get("projects/-/serviceAccounts/" + cnrmEmail).Do()
+	if err != nil {
+		googleError, ok := err.(*googleapi.Error)
+		if !ok {
+			metrics.IncExternalCallsByError(metricsSystemName, err)
+			return nil, fmt.Errorf("retrieve CNRM service account: %w", err)
+		}
+
+		metrics.IncExternalCalls(metricsSystemName, googleError.Code)
+		if googleError.Code != http.StatusNotFound {
+			return nil, fmt.Errorf("retrieve CNRM service account: %w", err)
+		}
+
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionCreate,
+			Resource: project.ProjectId,
+			Details:  fmt.Sprintf("Create CNRM service account in GCP project %q", project.ProjectId),
+		})
+	} else {
+		metrics.IncExternalCalls(metricsSystemName, serviceAccount.HTTPStatusCode)
+	}
+
+	policy, err := r.gcpServices.CloudResourceManagerProjectsService.GetIamPolicy(project.Name, &cloudresourcemanager.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		metrics.IncExternalCallsByError(metricsSystemName, err)
+		return nil, fmt.Errorf("retrieve existing GCP project IAM policy: %w", err)
+	}
+	metrics.IncExternalCalls(metricsSystemName, policy.HTTPStatusCode)
+
+	requiredRoleBindings := map[string]string{
+		r.cnrmRoleName: "serviceAccount:" + cnrmEmail,
+	}
+	if groupEmail != nil {
+		requiredRoleBindings["roles/owner"] = "group:" + *groupEmail
+	}
+	if _, updated := calculateRoleBindings(policy.Bindings, requiredRoleBindings); updated {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionUpdate,
+			Resource: project.ProjectId,
+			Details:  fmt.Sprintf("Assign IAM permissions in GCP project %q", project.ProjectId),
+		})
+	}
+
+	servicesToEnable, err := r.getGoogleApisToEnable(project)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range servicesToEnable {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionUpdate,
+			Resource: project.ProjectId,
+			Details:  fmt.Sprintf("Enable Google API %q for %q", service, project.ProjectId),
+		})
+	}
+
+	rules, err := r.gcpServices.FirewallService.List(project.ProjectId).Context(ctx).Do()
+	if err != nil {
+		metrics.IncExternalCallsByError(metricsSystemName, err)
+		return nil, err
+	}
+	metrics.IncExternalCalls(metricsSystemName, rules.HTTPStatusCode)
+
+	for _, rule := range rules.Items {
+		for _, deleteTemplate := range defaultVPCNetworkRulesToDelete {
+			if rule.Name == deleteTemplate.name && rule.Priority == deleteTemplate.priority {
+				changes = append(changes, reconcilers.PlannedChange{
+					Action:   reconcilers.PlannedChangeActionDelete,
+					Resource: project.ProjectId,
+					Details:  fmt.Sprintf("Delete default firewall rule %q in GCP project %q", rule.Name, project.ProjectId),
+				})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
 func (r *googleGcpReconciler) Delete(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID) error {
 	log := r.log.WithTeamSlug(string(teamSlug))
 	state := &reconcilers.GoogleGcpProjectState{
@@ -211,46 +394,15 @@ func (r *googleGcpReconciler) Delete(ctx context.Context, teamSlug slug.Slug, co
 }
 
 func (r *googleGcpReconciler) ensureProjectHasAccessToGoogleApis(ctx context.Context, project *cloudresourcemanager.Project, input reconcilers.Input) error {
-	desiredServiceIDs := map[string]struct{}{
-		"compute.googleapis.com":              {},
-		"cloudbilling.googleapis.com":         {},
-		"storage-component.googleapis.com":    {},
-		"storage-api.googleapis.com":          {},
-		"sqladmin.googleapis.com":             {},
-		"sql-component.googleapis.com":        {},
-		"cloudresourcemanager.googleapis.com": {},
-		"secretmanager.googleapis.com":        {},
-		"pubsub.googleapis.com":               {},
-		"logging.googleapis.com":              {},
-		"bigquery.googleapis.com":             {},
-		"cloudtrace.googleapis.com":           {},
-	}
-
-	response, err := r.gcpServices.ServiceUsageService.List(project.Name).Filter("state:ENABLED").Do()
+	servicesToEnable, err := r.getGoogleApisToEnable(project)
 	if err != nil {
-		metrics.IncExternalCallsByError(metricsSystemName, err)
 		return err
 	}
-	metrics.IncExternalCalls(metricsSystemName, response.HTTPStatusCode)
-
-	if response.HTTPStatusCode != http.StatusOK {
-		return fmt.Errorf("non OK http status: %v", response.HTTPStatusCode)
-	}
 
-	// Take already enabled services out of the list of services we want to enable
-	for _, enabledService := range response.Services {
-		delete(desiredServiceIDs, enabledService.Config.Name)
-	}
-
-	if len(desiredServiceIDs) == 0 {
+	if len(servicesToEnable) == 0 {
 		return nil
 	}
 
-	servicesToEnable := make([]string, 0, len(desiredServiceIDs))
-	for key := range desiredServiceIDs {
-		servicesToEnable = append(servicesToEnable, key)
-	}
-
 	req := &serviceusage.BatchEnableServicesRequest{
 		ServiceIds: servicesToEnable,
 	}
@@ -290,6 +442,48 @@ func (r *googleGcpReconciler) ensureProjectHasAccessToGoogleApis(ctx context.Con
 	return nil
 }
 
+// getGoogleApisToEnable Get the Google APIs that are not yet enabled in the project
+func (r *googleGcpReconciler) getGoogleApisToEnable(project *cloudresourcemanager.Project) ([]string, error) {
+	desiredServiceIDs := map[string]struct{}{
+		"compute.googleapis.com":              {},
+		"cloudbilling.googleapis.com":         {},
+		"storage-component.googleapis.com":    {},
+		"storage-api.googleapis.com":          {},
+		"sqladmin.googleapis.com":             {},
+		"sql-component.googleapis.com":        {},
+		"cloudresourcemanager.googleapis.com": {},
+		"secretmanager.googleapis.com":        {},
+		"pubsub.googleapis.com":               {},
+		"logging.googleapis.com":              {},
+		"bigquery.googleapis.com":             {},
+		"cloudtrace.googleapis.com":           {},
+	}
+
+	response, err := r.gcpServices.ServiceUsageService.List(project.Name).Filter("state:ENABLED").Do()
+	if err != nil {
+		metrics.IncExternalCallsByError(metricsSystemName, err)
+		return nil, err
+	}
+	metrics.IncExternalCalls(metricsSystemName, response.HTTPStatusCode)
+
+	if response.HTTPStatusCode != http.StatusOK {
+		return nil, fmt.Errorf("non OK http status: %v", response.HTTPStatusCode)
+	}
+
+	// Take already enabled services out of the list of services we want to enable
+	for _, enabledService := range response.Services {
+		delete(desiredServiceIDs, enabledService.Config.Name)
+	}
+
+	servicesToEnable := make([]string, 0, len(desiredServiceIDs))
+	for key := range desiredServiceIDs {
+		servicesToEnable = append(servicesToEnable, key)
+	}
+	sort.Strings(servicesToEnable)
+
+	return servicesToEnable, nil
+}
+
 func (r *googleGcpReconciler) getOrCreateProject(ctx context.Context, projectID string, state *reconcilers.GoogleGcpProjectState, environment string, parentFolderID int64, input reconcilers.Input) (*cloudresourcemanager.Project, error) {
 	if projectFromState, exists := state.Projects[environment]; exists {
 		response, err := r.gcpServices.CloudResourceManagerProjectsService.Search().Query("id:" + projectFromState.ProjectID).Do()
@@ -515,6 +709,16 @@ func (r *googleGcpReconciler) getOperationResponse(operation *cloudresourcemanag
 	return operation.Response, nil
 }
 
+// projectLabels Get the labels that should be set on the project for a team in a given environment
+func (r *googleGcpReconciler) projectLabels(teamSlug slug.Slug, environment string) map[string]string {
+	return map[string]string{
+		"team":                         string(teamSlug),
+		"environment":                  environment,
+		"tenant":                       r.tenantName,
+		reconcilers.ManagedByLabelName: reconcilers.ManagedByLabelValue,
+	}
+}
+
 func (r *googleGcpReconciler) ensureProjectHasLabels(_ context.Context, project *cloudresourcemanager.Project, labels map[string]string) error {
 	operation, err := r.gcpServices.CloudResourceManagerProjectsService.Patch(project.Name, &cloudresourcemanager.Project{
 		Labels: labels,
@@ -578,16 +782,17 @@ func createGcpServices(ctx context.Context, cfg *config.Config) (*GcpServices, e
 	}, nil
 }
 
-func (r *googleGcpReconciler) deleteDefaultVPCNetworkRules(ctx context.Context, project *cloudresourcemanager.Project) error {
-	rulesToDelete := []struct {
-		name     string
-		priority int64
-	}{
-		{name: "default-allow-icmp", priority: 65534},
-		{name: "default-allow-rdp", priority: 65534},
-		{name: "default-allow-ssh", priority: 65534},
-	}
+// defaultVPCNetworkRulesToDelete Default firewall rules that are removed from all team projects
+var defaultVPCNetworkRulesToDelete = []struct {
+	name     string
+	priority int64
+}{
+	{name: "default-allow-icmp", priority: 65534},
+	{name: "default-allow-rdp", priority: 65534},
+	{name: "default-allow-ssh", priority: 65534},
+}
 
+func (r *googleGcpReconciler) deleteDefaultVPCNetworkRules(ctx context.Context, project *cloudresourcemanager.Project) error {
 	rules, err := r.gcpServices.FirewallService.List(project.ProjectId).Context(ctx).Do()
 	if err != nil {
 		metrics.IncExternalCallsByError(metricsSystemName, err)
@@ -596,7 +801,7 @@ func (r *googleGcpReconciler) deleteDefaultVPCNetworkRules(ctx context.Context,
 	metrics.IncExternalCalls(metricsSystemName, rules.HTTPStatusCode)
 
 	for _, rule := range rules.Items {
-		for _, deleteTemplate := range rulesToDelete {
+		for _, deleteTemplate := range defaultVPCNetworkRulesToDelete {
 			if rule.Name == deleteTemplate.name && rule.Priority == deleteTemplate.priority {
 				operation, err := r.gcpServices.FirewallService.Delete(project.ProjectId, rule.Name).Context(ctx).Do()
 				if err != nil {
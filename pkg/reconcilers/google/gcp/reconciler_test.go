@@ -510,3 +510,163 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestPlan(t *testing.T) {
+	const expectedTeamProjectID = "slug-prod-ea99"
+
+	log, err := logger.GetLogger("text", "info")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	groupEmail := "mail@example.com"
+
+	newDatabase := func(t *testing.T) *db.MockDatabase {
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_gcp_reconciler.Name, team.Slug, mock.Anything).
+			Return(nil).
+			Once()
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, team.Slug, mock.Anything).
+			Run(func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.GoogleWorkspaceState)
+				state.GroupEmail = &groupEmail
+			}).
+			Return(nil).
+			Once()
+		return database
+	}
+
+	newGcpServices := func(url string) *google_gcp_reconciler.GcpServices {
+		cloudBillingService, _ := cloudbilling.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(url))
+		cloudResourceManagerService, _ := cloudresourcemanager.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(url))
+		iamService, _ := iam.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(url))
+		serviceUsageService, _ := serviceusage.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(url))
+		computeService, _ := compute.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(url))
+
+		return &google_gcp_reconciler.GcpServices{
+			CloudBillingProjectsService:         cloudBillingService.Projects,
+			CloudResourceManagerProjectsService: cloudResourceManagerService.Projects,
+			IamProjectsServiceAccountsService:   iamService.Projects.ServiceAccounts,
+			ServiceUsageService:                 serviceUsageService.Services,
+			FirewallService:                     computeService.Firewalls,
+		}
+	}
+
+	searchProjects := func(projects ...*cloudresourcemanager.Project) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "id:"+expectedTeamProjectID, r.URL.Query().Get("query"))
+			response := cloudresourcemanager.SearchProjectsResponse{Projects: projects}
+			resp, _ := response.MarshalJSON()
+			w.Write(resp)
+		}
+	}
+
+	existingProject := &cloudresourcemanager.Project{
+		Name:      "projects/123",
+		ProjectId: expectedTeamProjectID,
+		Labels: map[string]string{
+			"team":                         string(teamSlug),
+			"environment":                  env,
+			"tenant":                       tenantName,
+			reconcilers.ManagedByLabelName: reconcilers.ManagedByLabelValue,
+		},
+	}
+
+	getBillingInfo := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		info := cloudbilling.ProjectBillingInfo{BillingAccountName: billingAccount}
+		resp, _ := info.MarshalJSON()
+		w.Write(resp)
+	}
+
+	t.Run("project does not exist", func(t *testing.T) {
+		srv := test.HttpServerWithHandlers(t, []http.HandlerFunc{
+			searchProjects(),
+		})
+		defer srv.Close()
+
+		changes, err := google_gcp_reconciler.
+			New(newDatabase(t), auditlogger.NewMockAuditLogger(t), clusters, newGcpServices(srv.URL), tenantName, tenantDomain, cnrmRoleName, billingAccount, log).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionCreate, Resource: expectedTeamProjectID, Details: `Create GCP project "slug-prod-ea99" for team "slug" in environment "prod"`},
+			{Action: reconcilers.PlannedChangeActionSetBilling, Resource: expectedTeamProjectID, Details: `Set billing account "billingAccounts/123" for GCP project "slug-prod-ea99"`},
+			{Action: reconcilers.PlannedChangeActionCreate, Resource: expectedTeamProjectID, Details: `Create CNRM service account in GCP project "slug-prod-ea99"`},
+			{Action: reconcilers.PlannedChangeActionUpdate, Resource: expectedTeamProjectID, Details: `Assign IAM permissions in GCP project "slug-prod-ea99"`},
+		}, changes)
+	})
+
+	t.Run("existing project with missing resources", func(t *testing.T) {
+		srv := test.HttpServerWithHandlers(t, []http.HandlerFunc{
+			searchProjects(existingProject),
+			getBillingInfo,
+
+			// get existing CNRM service account
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(http.StatusNotFound)
+			},
+
+			// get existing IAM policy for the team project
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				policy := cloudresourcemanager.Policy{}
+				resp, _ := policy.MarshalJSON()
+				w.Write(resp)
+			},
+
+			// list enabled Google APIs for the team project
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				services := serviceusage.ListServicesResponse{}
+				resp, _ := services.MarshalJSON()
+				w.Write(resp)
+			},
+
+			// list firewall rules for project
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				list := compute.FirewallList{
+					Items: []*compute.Firewall{
+						{Name: "default-allow-ssh", Priority: 65534},
+					},
+				}
+				resp, _ := list.MarshalJSON()
+				w.Write(resp)
+			},
+		})
+		defer srv.Close()
+
+		changes, err := google_gcp_reconciler.
+			New(newDatabase(t), auditlogger.NewMockAuditLogger(t), clusters, newGcpServices(srv.URL), tenantName, tenantDomain, cnrmRoleName, billingAccount, log).
+			Plan(ctx, input)
+		assert.NoError(t, err)
+		assert.Len(t, changes, numberOfAPIs+3)
+		assert.Contains(t, changes, reconcilers.PlannedChange{Action: reconcilers.PlannedChangeActionCreate, Resource: expectedTeamProjectID, Details: `Create CNRM service account in GCP project "slug-prod-ea99"`})
+		assert.Contains(t, changes, reconcilers.PlannedChange{Action: reconcilers.PlannedChangeActionUpdate, Resource: expectedTeamProjectID, Details: `Assign IAM permissions in GCP project "slug-prod-ea99"`})
+		assert.Contains(t, changes, reconcilers.PlannedChange{Action: reconcilers.PlannedChangeActionDelete, Resource: expectedTeamProjectID, Details: `Delete default firewall rule "default-allow-ssh" in GCP project "slug-prod-ea99"`})
+	})
+
+	t.Run("unable to get CNRM service account", func(t *testing.T) {
+		srv := test.HttpServerWithHandlers(t, []http.HandlerFunc{
+			searchProjects(existingProject),
+			getBillingInfo,
+
+			// get existing CNRM service account
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(http.StatusForbidden)
+			},
+		})
+		defer srv.Close()
+
+		changes, err := google_gcp_reconciler.
+			New(newDatabase(t), auditlogger.NewMockAuditLogger(t), clusters, newGcpServices(srv.URL), tenantName, tenantDomain, cnrmRoleName, billingAccount, log).
+			Plan(ctx, input)
+		assert.ErrorContains(t, err, "retrieve CNRM service account")
+		assert.Nil(t, changes)
+	})
+}
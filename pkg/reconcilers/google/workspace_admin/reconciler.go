@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/nais/teams-backend/pkg/types"
@@ -25,25 +26,29 @@ import (
 )
 
 type googleWorkspaceAdminReconciler struct {
-	database     db.Database
-	auditLogger  auditlogger.AuditLogger
-	domain       string
-	adminService *admin_directory_v1.Service
-	log          logger.Logger
+	database        db.Database
+	auditLogger     auditlogger.AuditLogger
+	domain          string
+	adminService    *admin_directory_v1.Service
+	nestChildGroups bool
+	log             logger.Logger
 }
 
 const (
 	Name              = sqlc.ReconcilerNameGoogleWorkspaceAdmin
 	metricsSystemName = "google-admin"
+
+	gkeSecurityGroupPrefix = "gke-security-groups@"
 )
 
-func New(database db.Database, auditLogger auditlogger.AuditLogger, domain string, adminService *admin_directory_v1.Service, log logger.Logger) *googleWorkspaceAdminReconciler {
+func New(database db.Database, auditLogger auditlogger.AuditLogger, domain string, adminService *admin_directory_v1.Service, nestChildGroups bool, log logger.Logger) *googleWorkspaceAdminReconciler {
 	return &googleWorkspaceAdminReconciler{
-		database:     database,
-		auditLogger:  auditLogger,
-		domain:       domain,
-		adminService: adminService,
-		log:          log.WithComponent(types.ComponentNameGoogleWorkspaceAdmin),
+		database:        database,
+		auditLogger:     auditLogger,
+		domain:          domain,
+		adminService:    adminService,
+		nestChildGroups: nestChildGroups,
+		log:             log.WithComponent(types.ComponentNameGoogleWorkspaceAdmin),
 	}
 }
 
@@ -63,7 +68,7 @@ func NewFromConfig(ctx context.Context, database db.Database, cfg *config.Config
 		return nil, fmt.Errorf("retrieve directory client: %w", err)
 	}
 
-	return New(database, auditlogger.New(database, types.ComponentNameGoogleWorkspaceAdmin, log), cfg.TenantDomain, srv, log), nil
+	return New(database, auditlogger.New(database, types.ComponentNameGoogleWorkspaceAdmin, log), cfg.TenantDomain, srv, cfg.GoogleWorkspace.NestChildGroups, log), nil
 }
 
 func (r *googleWorkspaceAdminReconciler) Name() sqlc.ReconcilerName {
@@ -92,14 +97,118 @@ func (r *googleWorkspaceAdminReconciler) Reconcile(ctx context.Context, input re
 		r.log.WithError(err).Error("persiste system state")
 	}
 
-	err = r.connectUsers(ctx, grp, input)
+	childGroups, err := r.childGroupEmails(ctx, input.Team.Slug)
+	if err != nil {
+		return err
+	}
+
+	err = r.connectUsers(ctx, grp, input, childGroups)
 	if err != nil {
 		return fmt.Errorf("add members to group: %w", err)
 	}
 
+	err = r.addToParentGroup(ctx, grp, input)
+	if err != nil {
+		return err
+	}
+
 	return r.addToGKESecurityGroup(ctx, grp, input)
 }
 
+func (r *googleWorkspaceAdminReconciler) Plan(ctx context.Context, input reconcilers.Input) ([]reconcilers.PlannedChange, error) {
+	state := &reconcilers.GoogleWorkspaceState{}
+	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), input.Team.Slug, state)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load system state for team %q in system %q: %w", input.Team.Slug, r.Name(), err)
+	}
+
+	changes := make([]reconcilers.PlannedChange, 0)
+	gkeSecurityGroupKey := gkeSecurityGroupPrefix + r.domain
+
+	if state.GroupEmail == nil {
+		email := fmt.Sprintf("%s%s@%s", reconcilers.TeamNamePrefix, input.Team.Slug, r.domain)
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionCreate,
+			Resource: email,
+			Details:  fmt.Sprintf("Create Google Directory group %q", email),
+		})
+		for _, user := range sortedUsers(input.TeamMembers) {
+			changes = append(changes, reconcilers.PlannedChange{
+				Action:   reconcilers.PlannedChangeActionAddMember,
+				Resource: email,
+				Details:  fmt.Sprintf("Add member %q to Google Directory group %q", user.Email, email),
+			})
+		}
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionAddMember,
+			Resource: gkeSecurityGroupKey,
+			Details:  fmt.Sprintf("Add group %q to GKE security group %q", email, gkeSecurityGroupKey),
+		})
+		return changes, nil
+	}
+
+	grp, err := r.adminService.Groups.Get(*state.GroupEmail).Do()
+	if err != nil {
+		metrics.IncExternalCallsByError(metricsSystemName, err)
+		return nil, err
+	}
+	metrics.IncExternalCalls(metricsSystemName, grp.HTTPStatusCode)
+
+	if input.Team.Purpose != grp.Description {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionUpdate,
+			Resource: grp.Email,
+			Details:  fmt.Sprintf("Update description of Google Directory group %q", grp.Email),
+		})
+	}
+
+	membersAccordingToGoogle, err := getGoogleGroupMembers(ctx, r.adminService.Members, grp.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	childGroups, err := r.childGroupEmails(ctx, input.Team.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	membersToRemove := withoutGroups(remoteOnlyMembers(membersAccordingToGoogle, input.TeamMembers), childGroups)
+	sort.Slice(membersToRemove, func(i, j int) bool {
+		return membersToRemove[i].Email < membersToRemove[j].Email
+	})
+	for _, member := range membersToRemove {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionRemoveMember,
+			Resource: grp.Email,
+			Details:  fmt.Sprintf("Remove member %q from Google Directory group %q", member.Email, grp.Email),
+		})
+	}
+
+	for _, user := range sortedUsers(localOnlyMembers(membersAccordingToGoogle, input.TeamMembers)) {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionAddMember,
+			Resource: grp.Email,
+			Details:  fmt.Sprintf("Add member %q to Google Directory group %q", user.Email, grp.Email),
+		})
+	}
+
+	isMember, err := r.adminService.Members.HasMember(gkeSecurityGroupKey, grp.Email).Context(ctx).Do()
+	metrics.IncExternalCallsByError(metricsSystemName, err)
+	if err != nil {
+		return nil, fmt.Errorf("check membership of group %q in GKE security group %q: %w", grp.Email, gkeSecurityGroupKey, err)
+	}
+
+	if !isMember.IsMember {
+		changes = append(changes, reconcilers.PlannedChange{
+			Action:   reconcilers.PlannedChangeActionAddMember,
+			Resource: gkeSecurityGroupKey,
+			Details:  fmt.Sprintf("Add group %q to GKE security group %q", grp.Email, gkeSecurityGroupKey),
+		})
+	}
+
+	return changes, nil
+}
+
 func (r *googleWorkspaceAdminReconciler) Delete(ctx context.Context, teamSlug slug.Slug, correlationID uuid.UUID) error {
 	state := &reconcilers.GoogleWorkspaceState{}
 	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), teamSlug, state)
@@ -187,14 +296,16 @@ func getGoogleGroupMembers(ctx context.Context, service *admin_directory_v1.Memb
 	return members, nil
 }
 
-func (r *googleWorkspaceAdminReconciler) connectUsers(ctx context.Context, grp *admin_directory_v1.Group, input reconcilers.Input) error {
+// connectUsers Make sure that the members of the team, and no one else, are members of the group. Groups of sub-teams
+// are kept as members of the group.
+func (r *googleWorkspaceAdminReconciler) connectUsers(ctx context.Context, grp *admin_directory_v1.Group, input reconcilers.Input, childGroups map[string]struct{}) error {
 	membersAccordingToGoogle, err := getGoogleGroupMembers(ctx, r.adminService.Members, grp.Id)
 	if err != nil {
 		return fmt.Errorf("list existing members in Google Directory group: %w", err)
 	}
 
 	teamsBackendUserMap := make(map[string]*db.User)
-	membersToRemove := remoteOnlyMembers(membersAccordingToGoogle, input.TeamMembers)
+	membersToRemove := withoutGroups(remoteOnlyMembers(membersAccordingToGoogle, input.TeamMembers), childGroups)
 	for _, member := range membersToRemove {
 		remoteMemberEmail := strings.ToLower(member.Email)
 		err = r.adminService.Members.Delete(grp.Id, member.Id).Do()
@@ -256,8 +367,7 @@ func (r *googleWorkspaceAdminReconciler) connectUsers(ctx context.Context, grp *
 }
 
 func (r *googleWorkspaceAdminReconciler) addToGKESecurityGroup(ctx context.Context, grp *admin_directory_v1.Group, input reconcilers.Input) error {
-	const groupPrefix = "gke-security-groups@"
-	groupKey := groupPrefix + r.domain
+	groupKey := gkeSecurityGroupPrefix + r.domain
 
 	member := &admin_directory_v1.Member{
 		Email: grp.Email,
@@ -285,6 +395,78 @@ func (r *googleWorkspaceAdminReconciler) addToGKESecurityGroup(ctx context.Conte
 	return nil
 }
 
+// addToParentGroup Add the group to the group of the parent team. Nothing is done when nesting of groups is disabled,
+// or when the parent team does not have a group yet.
+func (r *googleWorkspaceAdminReconciler) addToParentGroup(ctx context.Context, grp *admin_directory_v1.Group, input reconcilers.Input) error {
+	if !r.nestChildGroups || input.Team.ParentTeamSlug == nil {
+		return nil
+	}
+
+	parentState := &reconcilers.GoogleWorkspaceState{}
+	err := r.database.LoadReconcilerStateForTeam(ctx, r.Name(), *input.Team.ParentTeamSlug, parentState)
+	if err != nil {
+		return fmt.Errorf("load reconciler state for parent team %q: %w", *input.Team.ParentTeamSlug, err)
+	}
+
+	if parentState.GroupEmail == nil {
+		return nil
+	}
+
+	member := &admin_directory_v1.Member{
+		Email: grp.Email,
+	}
+
+	_, err = r.adminService.Members.Insert(*parentState.GroupEmail, member).Context(ctx).Do()
+	if err != nil {
+		googleError, ok := err.(*googleapi.Error)
+		metrics.IncExternalCallsByError(metricsSystemName, err)
+		if ok && googleError.Code == http.StatusConflict {
+			return nil
+		}
+		return fmt.Errorf("add group %q to group %q of parent team: %w", member.Email, *parentState.GroupEmail, err)
+	}
+
+	targets := []auditlogger.Target{
+		auditlogger.TeamTarget(input.Team.Slug),
+		auditlogger.TeamTarget(*input.Team.ParentTeamSlug),
+	}
+	fields := auditlogger.Fields{
+		Action:        types.AuditActionGoogleWorkspaceAdminAddToParentGroup,
+		CorrelationID: input.CorrelationID,
+	}
+	r.auditLogger.Logf(ctx, targets, fields, "Added group %q to group %q of parent team", member.Email, *parentState.GroupEmail)
+
+	return nil
+}
+
+// childGroupEmails Get the lower case email addresses of the groups of all sub-teams of a team. The map is empty when
+// nesting of groups is disabled.
+func (r *googleWorkspaceAdminReconciler) childGroupEmails(ctx context.Context, teamSlug slug.Slug) (map[string]struct{}, error) {
+	emails := make(map[string]struct{})
+	if !r.nestChildGroups {
+		return emails, nil
+	}
+
+	children, err := r.database.GetTeamChildren(ctx, teamSlug)
+	if err != nil {
+		return nil, fmt.Errorf("get sub-teams of team %q: %w", teamSlug, err)
+	}
+
+	for _, child := range children {
+		state := &reconcilers.GoogleWorkspaceState{}
+		err = r.database.LoadReconcilerStateForTeam(ctx, r.Name(), child.Slug, state)
+		if err != nil {
+			return nil, fmt.Errorf("load reconciler state for sub-team %q: %w", child.Slug, err)
+		}
+
+		if state.GroupEmail != nil {
+			emails[strings.ToLower(*state.GroupEmail)] = struct{}{}
+		}
+	}
+
+	return emails, nil
+}
+
 func (r *googleWorkspaceAdminReconciler) syncGroupInfo(ctx context.Context, team db.Team, group *admin_directory_v1.Group) error {
 	if team.Purpose == group.Description {
 		return nil
@@ -317,6 +499,17 @@ func remoteOnlyMembers(googleGroupMembers []*admin_directory_v1.Member, teamsBac
 	return googleGroupMembers
 }
 
+// withoutGroups Return the members that are not one of the given groups
+func withoutGroups(members []*admin_directory_v1.Member, groupEmails map[string]struct{}) []*admin_directory_v1.Member {
+	filtered := make([]*admin_directory_v1.Member, 0, len(members))
+	for _, member := range members {
+		if _, isGroup := groupEmails[strings.ToLower(member.Email)]; !isGroup {
+			filtered = append(filtered, member)
+		}
+	}
+	return filtered
+}
+
 // localOnlyMembers Given a list of Google group members and a list of users, return users not present in members
 // directory.
 func localOnlyMembers(googleGroupMembers []*admin_directory_v1.Member, teamsBackendUsers []*db.User) []*db.User {
@@ -333,3 +526,13 @@ func localOnlyMembers(googleGroupMembers []*admin_directory_v1.Member, teamsBack
 	}
 	return teamsBackendUsers
 }
+
+// sortedUsers Return a copy of the users, sorted by email address
+func sortedUsers(users []*db.User) []*db.User {
+	sorted := make([]*db.User, len(users))
+	copy(sorted, users)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Email < sorted[j].Email
+	})
+	return sorted
+}
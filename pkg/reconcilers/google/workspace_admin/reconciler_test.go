@@ -66,7 +66,7 @@ func TestReconcile(t *testing.T) {
 		service, _ := admin_directory_v1.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(ts.URL))
 
 		err := google_workspace_admin_reconciler.
-			New(database, auditLog, domain, service, log).
+			New(database, auditLog, domain, service, false, log).
 			Reconcile(ctx, input)
 		assert.ErrorContains(t, err, "unable to load system state")
 	})
@@ -239,10 +239,281 @@ func TestReconcile(t *testing.T) {
 			Once()
 
 		err := google_workspace_admin_reconciler.
-			New(database, auditLog, domain, service, log).
+			New(database, auditLog, domain, service, false, log).
 			Reconcile(ctx, input)
 		assert.NoError(t, err)
 	})
+
+	t.Run("nest child groups", func(t *testing.T) {
+		ctx := context.Background()
+
+		teamsBackendUser := teamsBackendUserWithEmail("user1@example.com")
+		teamSlug := slug.Slug("my-team")
+		parentSlug := slug.Slug("parent-team")
+		childSlug := slug.Slug("child-team")
+		groupEmail := "nais-team-my-team@example.com"
+		parentGroupEmail := "nais-team-parent-team@example.com"
+		childGroupEmail := "nais-team-child-team@example.com"
+		googleGroupId := uuid.New().String()
+
+		input := reconcilers.Input{
+			CorrelationID: correlationID,
+			Team: db.Team{
+				Team: &sqlc.Team{
+					Slug:           teamSlug,
+					Purpose:        "some purpose",
+					ParentTeamSlug: &parentSlug,
+				},
+			},
+			TeamMembers: []*db.User{teamsBackendUser},
+		}
+
+		ts := test.HttpServerWithHandlers(t, []http.HandlerFunc{
+			// get existing group
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				googleGroup := admin_directory_v1.Group{Id: googleGroupId, Email: groupEmail, Description: "some purpose"}
+				rsp, err := googleGroup.MarshalJSON()
+				assert.NoError(t, err)
+				w.Write(rsp)
+			},
+
+			// list existing members, the group of the sub-team is kept
+			func(w http.ResponseWriter, r *http.Request) {
+				members := admin_directory_v1.Members{
+					Members: []*admin_directory_v1.Member{
+						{Id: uuid.New().String(), Email: "user1@example.com"},
+						{Id: uuid.New().String(), Email: childGroupEmail},
+					},
+				}
+				rsp, err := members.MarshalJSON()
+				assert.NoError(t, err)
+				w.Write(rsp)
+			},
+
+			// add to group of parent team
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Contains(t, r.URL.Path, "/groups/"+parentGroupEmail+"/members")
+
+				addedMember := admin_directory_v1.Member{}
+				err := json.NewDecoder(r.Body).Decode(&addedMember)
+				assert.NoError(t, err)
+				assert.Equal(t, groupEmail, addedMember.Email)
+
+				rsp, err := addedMember.MarshalJSON()
+				assert.NoError(t, err)
+				w.Write(rsp)
+			},
+
+			// add to GKE security group, already a member
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Contains(t, r.URL.Path, "/groups/"+gkeSecurityGroup+"/members")
+				w.WriteHeader(http.StatusConflict)
+			},
+		})
+		defer ts.Close()
+
+		service, _ := admin_directory_v1.NewService(ctx, option.WithoutAuthentication(), option.WithEndpoint(ts.URL))
+
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithComponent", types.ComponentNameGoogleWorkspaceAdmin).
+			Return(log).
+			Once()
+
+		loadState := func(email string) func(mock.Arguments) {
+			return func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.GoogleWorkspaceState)
+				state.GroupEmail = &email
+			}
+		}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, teamSlug, mock.Anything).
+			Run(loadState(groupEmail)).
+			Return(nil).
+			Once()
+		database.
+			On("SetReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, teamSlug, mock.Anything).
+			Return(nil).
+			Once()
+		database.
+			On("GetTeamChildren", ctx, teamSlug).
+			Return([]*db.Team{{Team: &sqlc.Team{Slug: childSlug, ParentTeamSlug: &teamSlug}}}, nil).
+			Once()
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, childSlug, mock.Anything).
+			Run(loadState(childGroupEmail)).
+			Return(nil).
+			Once()
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, parentSlug, mock.Anything).
+			Run(loadState(parentGroupEmail)).
+			Return(nil).
+			Once()
+
+		auditLog := auditlogger.NewMockAuditLogger(t)
+		auditLog.EXPECT().
+			Logf(ctx, mock.MatchedBy(func(targets []auditlogger.Target) bool {
+				return targets[0].Identifier == string(teamSlug) && targets[1].Identifier == string(parentSlug)
+			}), mock.MatchedBy(func(fields auditlogger.Fields) bool {
+				return fields.CorrelationID == correlationID && fields.Action == types.AuditActionGoogleWorkspaceAdminAddToParentGroup
+			}), mock.MatchedBy(func(msg string) bool {
+				return strings.HasPrefix(msg, "Added group")
+			}), groupEmail, parentGroupEmail).
+			Return().
+			Once()
+
+		err := google_workspace_admin_reconciler.
+			New(database, auditLog, domain, service, true, log).
+			Reconcile(ctx, input)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_Plan(t *testing.T) {
+	const (
+		domain           = "example.com"
+		gkeSecurityGroup = "gke-security-groups@example.com"
+	)
+	ctx := context.Background()
+	teamSlug := slug.Slug("my-team")
+	groupEmail := "nais-team-my-team@example.com"
+	auditLogger := auditlogger.NewMockAuditLogger(t)
+
+	t.Run("group does not exist", func(t *testing.T) {
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithComponent", types.ComponentNameGoogleWorkspaceAdmin).
+			Return(log).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, teamSlug, mock.Anything).
+			Return(nil).
+			Once()
+
+		googleAdminService, close := getAdminDirectoryServiceAndClient(t, ctx, []http.HandlerFunc{})
+		defer close()
+
+		changes, err := google_workspace_admin_reconciler.
+			New(database, auditLogger, domain, googleAdminService, false, log).
+			Plan(ctx, reconcilers.Input{
+				Team: db.Team{Team: &sqlc.Team{Slug: teamSlug}},
+				TeamMembers: []*db.User{
+					teamsBackendUserWithEmail("user2@example.com"),
+					teamsBackendUserWithEmail("user1@example.com"),
+				},
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionCreate, Resource: groupEmail, Details: `Create Google Directory group "nais-team-my-team@example.com"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: groupEmail, Details: `Add member "user1@example.com" to Google Directory group "nais-team-my-team@example.com"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: groupEmail, Details: `Add member "user2@example.com" to Google Directory group "nais-team-my-team@example.com"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: gkeSecurityGroup, Details: `Add group "nais-team-my-team@example.com" to GKE security group "gke-security-groups@example.com"`},
+		}, changes)
+	})
+
+	t.Run("existing group is out of date", func(t *testing.T) {
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithComponent", types.ComponentNameGoogleWorkspaceAdmin).
+			Return(log).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, teamSlug, mock.Anything).
+			Run(func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.GoogleWorkspaceState)
+				state.GroupEmail = &groupEmail
+			}).
+			Return(nil).
+			Once()
+
+		googleAdminService, close := getAdminDirectoryServiceAndClient(t, ctx, []http.HandlerFunc{
+			// get group
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, "/groups/"+groupEmail)
+				assert.NoError(t, json.NewEncoder(w).Encode(&admin_directory_v1.Group{
+					Id:          "group-id",
+					Email:       groupEmail,
+					Description: "old purpose",
+				}))
+			},
+
+			// list existing members
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, "/groups/group-id/members")
+				assert.NoError(t, json.NewEncoder(w).Encode(&admin_directory_v1.Members{
+					Members: []*admin_directory_v1.Member{
+						{Id: "user1", Email: "user1@example.com"},
+						{Id: "remove-me", Email: "remove-me@example.com"},
+					},
+				}))
+			},
+
+			// check membership in GKE security group
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.URL.Path, "/groups/"+gkeSecurityGroup+"/hasMember/"+groupEmail)
+				assert.NoError(t, json.NewEncoder(w).Encode(&admin_directory_v1.MembersHasMember{IsMember: true}))
+			},
+		})
+		defer close()
+
+		changes, err := google_workspace_admin_reconciler.
+			New(database, auditLogger, domain, googleAdminService, false, log).
+			Plan(ctx, reconcilers.Input{
+				Team: db.Team{Team: &sqlc.Team{Slug: teamSlug, Purpose: "new purpose"}},
+				TeamMembers: []*db.User{
+					teamsBackendUserWithEmail("user1@example.com"),
+					teamsBackendUserWithEmail("add-me@example.com"),
+				},
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcilers.PlannedChange{
+			{Action: reconcilers.PlannedChangeActionUpdate, Resource: groupEmail, Details: `Update description of Google Directory group "nais-team-my-team@example.com"`},
+			{Action: reconcilers.PlannedChangeActionRemoveMember, Resource: groupEmail, Details: `Remove member "remove-me@example.com" from Google Directory group "nais-team-my-team@example.com"`},
+			{Action: reconcilers.PlannedChangeActionAddMember, Resource: groupEmail, Details: `Add member "add-me@example.com" to Google Directory group "nais-team-my-team@example.com"`},
+		}, changes)
+	})
+
+	t.Run("unable to get group", func(t *testing.T) {
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithComponent", types.ComponentNameGoogleWorkspaceAdmin).
+			Return(log).
+			Once()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("LoadReconcilerStateForTeam", ctx, google_workspace_admin_reconciler.Name, teamSlug, mock.Anything).
+			Run(func(args mock.Arguments) {
+				state := args.Get(3).(*reconcilers.GoogleWorkspaceState)
+				state.GroupEmail = &groupEmail
+			}).
+			Return(nil).
+			Once()
+
+		googleAdminService, close := getAdminDirectoryServiceAndClient(t, ctx, []http.HandlerFunc{
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+		})
+		defer close()
+
+		changes, err := google_workspace_admin_reconciler.
+			New(database, auditLogger, domain, googleAdminService, false, log).
+			Plan(ctx, reconcilers.Input{
+				Team: db.Team{Team: &sqlc.Team{Slug: teamSlug}},
+			})
+		assert.Error(t, err)
+		assert.Nil(t, changes)
+	})
 }
 
 func Test_Delete(t *testing.T) {
@@ -270,7 +541,7 @@ func Test_Delete(t *testing.T) {
 			Once()
 
 		err := google_workspace_admin_reconciler.
-			New(database, auditLogger, domain, googleAdminService, log).
+			New(database, auditLogger, domain, googleAdminService, false, log).
 			Delete(ctx, teamSlug, correlationID)
 		assert.ErrorContains(t, err, "load reconciler state for team")
 	})
@@ -302,7 +573,7 @@ func Test_Delete(t *testing.T) {
 			Once()
 
 		err := google_workspace_admin_reconciler.
-			New(database, auditLogger, domain, googleAdminService, log).
+			New(database, auditLogger, domain, googleAdminService, false, log).
 			Delete(ctx, teamSlug, correlationID)
 		assert.NoError(t, err)
 	})
@@ -334,7 +605,7 @@ func Test_Delete(t *testing.T) {
 		defer close()
 
 		err := google_workspace_admin_reconciler.
-			New(database, auditLogger, domain, googleAdminService, log).
+			New(database, auditLogger, domain, googleAdminService, false, log).
 			Delete(ctx, teamSlug, correlationID)
 		assert.ErrorContains(t, err, "delete Google directory group")
 	})
@@ -386,7 +657,7 @@ func Test_Delete(t *testing.T) {
 			Once()
 
 		err := google_workspace_admin_reconciler.
-			New(database, auditLogger, domain, googleAdminService, log).
+			New(database, auditLogger, domain, googleAdminService, false, log).
 			Delete(ctx, teamSlug, correlationID)
 		assert.Nil(t, err)
 	})
@@ -15,16 +15,20 @@ type Input struct {
 	TeamMembers   []*db.User
 }
 
-// CreateReconcilerInput Helper function to create input for reconcilers
+// CreateReconcilerInput Helper function to create input for reconcilers. Teams with suspended resources will get an
+// empty list of members, which makes the reconcilers remove all members from the external resources.
 func CreateReconcilerInput(ctx context.Context, database db.Database, team db.Team, reconcilerName sqlc.ReconcilerName) (Input, error) {
 	correlationID, err := uuid.NewUUID()
 	if err != nil {
 		return Input{}, err
 	}
 
-	members, err := database.GetTeamMembersForReconciler(ctx, team.Slug, reconcilerName)
-	if err != nil {
-		return Input{}, err
+	members := make([]*db.User, 0)
+	if !team.ResourcesSuspended {
+		members, err = database.GetTeamMembersForReconciler(ctx, team.Slug, reconcilerName)
+		if err != nil {
+			return Input{}, err
+		}
 	}
 
 	return Input{
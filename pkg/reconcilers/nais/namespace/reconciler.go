@@ -100,6 +100,17 @@ func (r *naisNamespaceReconciler) Name() sqlc.ReconcilerName {
 	return Name
 }
 
+func (r *naisNamespaceReconciler) Prerequisites() []sqlc.ReconcilerName {
+	prerequisites := []sqlc.ReconcilerName{
+		google_workspace_admin_reconciler.Name,
+		google_gcp_reconciler.Name,
+	}
+	if r.azureEnabled {
+		prerequisites = append(prerequisites, azure_group_reconciler.Name)
+	}
+	return prerequisites
+}
+
 func (r *naisNamespaceReconciler) Reconcile(ctx context.Context, input reconcilers.Input) error {
 	namespaceState := &reconcilers.NaisNamespaceState{
 		Namespaces: make(map[string]slug.Slug),
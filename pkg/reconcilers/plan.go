@@ -0,0 +1,34 @@
+package reconcilers
+
+import (
+	"context"
+)
+
+type PlannedChangeAction string
+
+const (
+	PlannedChangeActionCreate       PlannedChangeAction = "CREATE"
+	PlannedChangeActionUpdate       PlannedChangeAction = "UPDATE"
+	PlannedChangeActionDelete       PlannedChangeAction = "DELETE"
+	PlannedChangeActionAddMember    PlannedChangeAction = "ADD_MEMBER"
+	PlannedChangeActionRemoveMember PlannedChangeAction = "REMOVE_MEMBER"
+	PlannedChangeActionSetBilling   PlannedChangeAction = "SET_BILLING"
+)
+
+// PlannedChange A change that a reconciler would make in an external system
+type PlannedChange struct {
+	Action PlannedChangeAction
+
+	// Resource Identifier of the affected resource in the external system, for instance the name of a group
+	Resource string
+
+	// Details Human-readable description of the change
+	Details string
+}
+
+// ReconcilerWithPlan Reconcilers that are able to report the changes a reconcile would make, without mutating anything
+// in the external system
+type ReconcilerWithPlan interface {
+	Reconciler
+	Plan(ctx context.Context, input Input) ([]PlannedChange, error)
+}
@@ -27,5 +27,13 @@ type Reconciler interface {
 	Name() sqlc.ReconcilerName
 }
 
+// ReconcilerWithPrerequisites Reconcilers that rely on the state of other reconcilers. The prerequisites always run
+// first, and the reconciler is skipped if one of them fails. The prerequisites will be reconciled as well when only a
+// subset of the reconcilers is requested for a team.
+type ReconcilerWithPrerequisites interface {
+	Reconciler
+	Prerequisites() []sqlc.ReconcilerName
+}
+
 // ReconcilerFactory The constructor function for all reconcilers
 type ReconcilerFactory func(context.Context, db.Database, *config.Config, logger.Logger) (Reconciler, error)
@@ -1,9 +1,21 @@
 package roles
 
-import (
-	"fmt"
+// RoleName The name of a role. Roles and their authorizations are stored in the database, and the constants below are
+// the built-in roles that are created by the database migrations.
+type RoleName string
 
-	"github.com/nais/teams-backend/pkg/sqlc"
+const (
+	RoleNameAdmin                 RoleName = "Admin"
+	RoleNameDeploykeyviewer       RoleName = "Deploy key viewer"
+	RoleNameServiceaccountcreator RoleName = "Service account creator"
+	RoleNameServiceaccountowner   RoleName = "Service account owner"
+	RoleNameSynchronizer          RoleName = "Synchronizer"
+	RoleNameTeamcreator           RoleName = "Team creator"
+	RoleNameTeammember            RoleName = "Team member"
+	RoleNameTeamowner             RoleName = "Team owner"
+	RoleNameTeamviewer            RoleName = "Team viewer"
+	RoleNameUseradmin             RoleName = "User admin"
+	RoleNameUserviewer            RoleName = "User viewer"
 )
 
 type Authorization string
@@ -30,79 +42,40 @@ const (
 	AuthorizationDeployKeyView         Authorization = "deploy_key:view"
 )
 
-var roles = map[sqlc.RoleName][]Authorization{
-	sqlc.RoleNameAdmin: {
-		AuthorizationAuditLogsRead,
-		AuthorizationServiceAccountsCreate,
-		AuthorizationServiceAccountsDelete,
-		AuthorizationServiceAccountsList,
-		AuthorizationServiceAccountsRead,
-		AuthorizationServiceAccountsUpdate,
-		AuthorizationSystemStatesDelete,
-		AuthorizationSystemStatesRead,
-		AuthorizationSystemStatesUpdate,
-		AuthorizationTeamsCreate,
-		AuthorizationTeamsDelete,
-		AuthorizationTeamsList,
-		AuthorizationTeamsRead,
-		AuthorizationTeamsUpdate,
-		AuthorizationUsersList,
-		AuthorizationUsersUpdate,
-		AuthorizationTeamsSynchronize,
-		AuthorizationUsersyncSynchronize,
-		AuthorizationDeployKeyView,
-	},
-	sqlc.RoleNameServiceaccountcreator: {
-		AuthorizationServiceAccountsCreate,
-	},
-	sqlc.RoleNameServiceaccountowner: {
-		AuthorizationServiceAccountsDelete,
-		AuthorizationServiceAccountsRead,
-		AuthorizationServiceAccountsUpdate,
-	},
-	sqlc.RoleNameTeamcreator: {
-		AuthorizationTeamsCreate,
-	},
-	sqlc.RoleNameTeammember: {
-		AuthorizationAuditLogsRead,
-		AuthorizationTeamsRead,
-		AuthorizationDeployKeyView,
-		AuthorizationTeamsSynchronize,
-	},
-	sqlc.RoleNameTeamowner: {
-		AuthorizationAuditLogsRead,
-		AuthorizationTeamsDelete,
-		AuthorizationTeamsRead,
-		AuthorizationTeamsUpdate,
-		AuthorizationTeamsSynchronize,
-		AuthorizationDeployKeyView,
-	},
-	sqlc.RoleNameTeamviewer: {
-		AuthorizationAuditLogsRead,
-		AuthorizationTeamsList,
-		AuthorizationTeamsRead,
-	},
-	sqlc.RoleNameUseradmin: {
-		AuthorizationUsersList,
-		AuthorizationUsersUpdate,
-	},
-	sqlc.RoleNameUserviewer: {
-		AuthorizationUsersList,
-	},
-	sqlc.RoleNameSynchronizer: {
-		AuthorizationTeamsSynchronize,
-		AuthorizationUsersyncSynchronize,
-	},
-	sqlc.RoleNameDeploykeyviewer: {
-		AuthorizationDeployKeyView,
-	},
+var allAuthorizations = []Authorization{
+	AuthorizationAuditLogsRead,
+	AuthorizationServiceAccountsCreate,
+	AuthorizationServiceAccountsDelete,
+	AuthorizationServiceAccountsList,
+	AuthorizationServiceAccountsRead,
+	AuthorizationServiceAccountsUpdate,
+	AuthorizationSystemStatesDelete,
+	AuthorizationSystemStatesRead,
+	AuthorizationSystemStatesUpdate,
+	AuthorizationTeamsCreate,
+	AuthorizationTeamsDelete,
+	AuthorizationTeamsList,
+	AuthorizationTeamsRead,
+	AuthorizationTeamsUpdate,
+	AuthorizationUsersList,
+	AuthorizationUsersUpdate,
+	AuthorizationTeamsSynchronize,
+	AuthorizationUsersyncSynchronize,
+	AuthorizationDeployKeyView,
+}
+
+// AllAuthorizations Get all authorizations known to the application, in the order they are declared
+func AllAuthorizations() []Authorization {
+	return allAuthorizations
 }
 
-func Authorizations(roleName sqlc.RoleName) ([]Authorization, error) {
-	authorizations, exists := roles[roleName]
-	if !exists {
-		return nil, fmt.Errorf("unknown role: %q", roleName)
+// Valid Check if the authorization is known to the application
+func (a Authorization) Valid() bool {
+	for _, authorization := range allAuthorizations {
+		if a == authorization {
+			return true
+		}
 	}
 
-	return authorizations, nil
+	return false
 }
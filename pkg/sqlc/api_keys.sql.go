@@ -7,23 +7,135 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-const createAPIKey = `-- name: CreateAPIKey :exec
-INSERT INTO api_keys (api_key, service_account_id)
-VALUES ($1, $2)
+const createAPIKey = `-- name: CreateAPIKey :one
+INSERT INTO api_keys (service_account_id, prefix, salt, hash, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING service_account_id, id, prefix, salt, hash, created_at, expires_at, last_used_at
 `
 
 type CreateAPIKeyParams struct {
-	ApiKey           string
 	ServiceAccountID uuid.UUID
+	Prefix           string
+	Salt             []byte
+	Hash             []byte
+	ExpiresAt        *time.Time
 }
 
-func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error {
-	_, err := q.db.Exec(ctx, createAPIKey, arg.ApiKey, arg.ServiceAccountID)
-	return err
+func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (*ApiKey, error) {
+	row := q.db.QueryRow(ctx, createAPIKey,
+		arg.ServiceAccountID,
+		arg.Prefix,
+		arg.Salt,
+		arg.Hash,
+		arg.ExpiresAt,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ServiceAccountID,
+		&i.ID,
+		&i.Prefix,
+		&i.Salt,
+		&i.Hash,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+	)
+	return &i, err
+}
+
+const getAPIKeyByID = `-- name: GetAPIKeyByID :one
+SELECT service_account_id, id, prefix, salt, hash, created_at, expires_at, last_used_at FROM api_keys
+WHERE id = $1
+`
+
+func (q *Queries) GetAPIKeyByID(ctx context.Context, id uuid.UUID) (*ApiKey, error) {
+	row := q.db.QueryRow(ctx, getAPIKeyByID, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ServiceAccountID,
+		&i.ID,
+		&i.Prefix,
+		&i.Salt,
+		&i.Hash,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+	)
+	return &i, err
+}
+
+const getActiveAPIKeysByPrefix = `-- name: GetActiveAPIKeysByPrefix :many
+SELECT service_account_id, id, prefix, salt, hash, created_at, expires_at, last_used_at FROM api_keys
+WHERE prefix = $1 AND (expires_at IS NULL OR expires_at > NOW())
+`
+
+func (q *Queries) GetActiveAPIKeysByPrefix(ctx context.Context, prefix string) ([]*ApiKey, error) {
+	rows, err := q.db.Query(ctx, getActiveAPIKeysByPrefix, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ServiceAccountID,
+			&i.ID,
+			&i.Prefix,
+			&i.Salt,
+			&i.Hash,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getServiceAccountAPIKeys = `-- name: GetServiceAccountAPIKeys :many
+SELECT service_account_id, id, prefix, salt, hash, created_at, expires_at, last_used_at FROM api_keys
+WHERE service_account_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetServiceAccountAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]*ApiKey, error) {
+	rows, err := q.db.Query(ctx, getServiceAccountAPIKeys, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ServiceAccountID,
+			&i.ID,
+			&i.Prefix,
+			&i.Salt,
+			&i.Hash,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const removeApiKeysFromServiceAccount = `-- name: RemoveApiKeysFromServiceAccount :exec
@@ -35,3 +147,24 @@ func (q *Queries) RemoveApiKeysFromServiceAccount(ctx context.Context, serviceAc
 	_, err := q.db.Exec(ctx, removeApiKeysFromServiceAccount, serviceAccountID)
 	return err
 }
+
+const revokeAPIKey = `-- name: RevokeAPIKey :exec
+DELETE FROM api_keys
+WHERE id = $1
+`
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, revokeAPIKey, id)
+	return err
+}
+
+const setAPIKeyLastUsed = `-- name: SetAPIKeyLastUsed :exec
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+`
+
+func (q *Queries) SetAPIKeyLastUsed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, setAPIKeyLastUsed, id)
+	return err
+}
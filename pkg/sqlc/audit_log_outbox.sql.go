@@ -0,0 +1,146 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.20.0
+// source: audit_log_outbox.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const claimAuditLogOutboxEntries = `-- name: ClaimAuditLogOutboxEntries :many
+UPDATE audit_log_outbox
+SET locked_by = $1::TEXT, locked_at = NOW(), attempts = attempts + 1
+WHERE id IN (
+    SELECT o.id FROM audit_log_outbox AS o
+    WHERE o.locked_by IS NULL AND o.next_attempt_at <= NOW()
+    ORDER BY o.id ASC
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, audit_log_id, delivered_sinks, attempts, last_error, created_at, next_attempt_at, locked_by, locked_at
+`
+
+type ClaimAuditLogOutboxEntriesParams struct {
+	LockedBy  string
+	BatchSize int32
+}
+
+func (q *Queries) ClaimAuditLogOutboxEntries(ctx context.Context, arg ClaimAuditLogOutboxEntriesParams) ([]*AuditLogOutbox, error) {
+	rows, err := q.db.Query(ctx, claimAuditLogOutboxEntries, arg.LockedBy, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AuditLogOutbox
+	for rows.Next() {
+		var i AuditLogOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuditLogID,
+			&i.DeliveredSinks,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.NextAttemptAt,
+			&i.LockedBy,
+			&i.LockedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createAuditLogOutboxEntry = `-- name: CreateAuditLogOutboxEntry :exec
+INSERT INTO audit_log_outbox (audit_log_id)
+VALUES ($1)
+`
+
+func (q *Queries) CreateAuditLogOutboxEntry(ctx context.Context, auditLogID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, createAuditLogOutboxEntry, auditLogID)
+	return err
+}
+
+const deleteAuditLogOutboxEntries = `-- name: DeleteAuditLogOutboxEntries :execrows
+DELETE FROM audit_log_outbox
+`
+
+func (q *Queries) DeleteAuditLogOutboxEntries(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuditLogOutboxEntries)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteAuditLogOutboxEntry = `-- name: DeleteAuditLogOutboxEntry :exec
+DELETE FROM audit_log_outbox
+WHERE id = $1
+`
+
+func (q *Queries) DeleteAuditLogOutboxEntry(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteAuditLogOutboxEntry, id)
+	return err
+}
+
+const getPendingAuditLogOutboxCount = `-- name: GetPendingAuditLogOutboxCount :one
+SELECT COUNT(*) FROM audit_log_outbox
+`
+
+func (q *Queries) GetPendingAuditLogOutboxCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getPendingAuditLogOutboxCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const releaseStaleAuditLogOutboxEntries = `-- name: ReleaseStaleAuditLogOutboxEntries :execrows
+UPDATE audit_log_outbox
+SET locked_by = NULL, locked_at = NULL
+WHERE locked_by IS NOT NULL AND locked_at < $1::TIMESTAMPTZ
+`
+
+func (q *Queries) ReleaseStaleAuditLogOutboxEntries(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseStaleAuditLogOutboxEntries, lockedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const retryAuditLogOutboxEntry = `-- name: RetryAuditLogOutboxEntry :exec
+UPDATE audit_log_outbox
+SET
+    locked_by = NULL,
+    locked_at = NULL,
+    delivered_sinks = $1::TEXT[],
+    last_error = $2::TEXT,
+    next_attempt_at = $3::TIMESTAMPTZ
+WHERE id = $4
+`
+
+type RetryAuditLogOutboxEntryParams struct {
+	DeliveredSinks []string
+	LastError      string
+	NextAttemptAt  time.Time
+	ID             int64
+}
+
+func (q *Queries) RetryAuditLogOutboxEntry(ctx context.Context, arg RetryAuditLogOutboxEntryParams) error {
+	_, err := q.db.Exec(ctx, retryAuditLogOutboxEntry,
+		arg.DeliveredSinks,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
+	return err
+}
@@ -7,16 +7,19 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 const createAuditLog = `-- name: CreateAuditLog :exec
-INSERT INTO audit_logs (correlation_id, actor, component_name, target_type, target_identifier, action, message)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO audit_logs (id, created_at, correlation_id, actor, component_name, target_type, target_identifier, action, message, changes, chain_position, previous_hash, hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 `
 
 type CreateAuditLogParams struct {
+	ID               uuid.UUID
+	CreatedAt        time.Time
 	CorrelationID    uuid.UUID
 	Actor            *string
 	ComponentName    string
@@ -24,10 +27,16 @@ type CreateAuditLogParams struct {
 	TargetIdentifier string
 	Action           string
 	Message          string
+	Changes          []byte
+	ChainPosition    *int64
+	PreviousHash     *string
+	Hash             *string
 }
 
 func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error {
 	_, err := q.db.Exec(ctx, createAuditLog,
+		arg.ID,
+		arg.CreatedAt,
 		arg.CorrelationID,
 		arg.Actor,
 		arg.ComponentName,
@@ -35,12 +44,258 @@ func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams)
 		arg.TargetIdentifier,
 		arg.Action,
 		arg.Message,
+		arg.Changes,
+		arg.ChainPosition,
+		arg.PreviousHash,
+		arg.Hash,
 	)
 	return err
 }
 
+const deleteAuditLogsByIDs = `-- name: DeleteAuditLogsByIDs :execrows
+WITH pruned AS (
+    INSERT INTO audit_log_pruned_links (chain_position, previous_hash, hash)
+    SELECT chain_position, previous_hash, hash FROM audit_logs
+    WHERE id = ANY($1::UUID[]) AND chain_position IS NOT NULL
+)
+DELETE FROM audit_logs
+WHERE id = ANY($1::UUID[])
+`
+
+func (q *Queries) DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuditLogsByIDs, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAuditLogChain = `-- name: GetAuditLogChain :many
+SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier, chain_position, previous_hash, hash, changes FROM audit_logs
+WHERE chain_position > $1::BIGINT
+ORDER BY chain_position ASC
+LIMIT $2
+`
+
+type GetAuditLogChainParams struct {
+	AfterPosition int64
+	LimitRows     int32
+}
+
+func (q *Queries) GetAuditLogChain(ctx context.Context, arg GetAuditLogChainParams) ([]*AuditLog, error) {
+	rows, err := q.db.Query(ctx, getAuditLogChain, arg.AfterPosition, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.CorrelationID,
+			&i.ComponentName,
+			&i.Actor,
+			&i.Action,
+			&i.Message,
+			&i.TargetType,
+			&i.TargetIdentifier,
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.Changes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditLogChainTail = `-- name: GetAuditLogChainTail :one
+SELECT links.chain_position::BIGINT AS chain_position, links.hash::TEXT AS hash FROM (
+    (SELECT chain_position, hash FROM audit_logs WHERE chain_position IS NOT NULL ORDER BY chain_position DESC LIMIT 1)
+    UNION ALL
+    (SELECT chain_position, hash FROM audit_log_pruned_links ORDER BY chain_position DESC LIMIT 1)
+) AS links
+ORDER BY links.chain_position DESC
+LIMIT 1
+`
+
+type GetAuditLogChainTailRow struct {
+	ChainPosition int64
+	Hash          string
+}
+
+func (q *Queries) GetAuditLogChainTail(ctx context.Context) (*GetAuditLogChainTailRow, error) {
+	row := q.db.QueryRow(ctx, getAuditLogChainTail)
+	var i GetAuditLogChainTailRow
+	err := row.Scan(&i.ChainPosition, &i.Hash)
+	return &i, err
+}
+
+const getAuditLogPrunedLinks = `-- name: GetAuditLogPrunedLinks :many
+SELECT chain_position, previous_hash, hash, pruned_at FROM audit_log_pruned_links
+WHERE chain_position > $1::BIGINT
+ORDER BY chain_position ASC
+LIMIT $2
+`
+
+type GetAuditLogPrunedLinksParams struct {
+	AfterPosition int64
+	LimitRows     int32
+}
+
+func (q *Queries) GetAuditLogPrunedLinks(ctx context.Context, arg GetAuditLogPrunedLinksParams) ([]*AuditLogPrunedLink, error) {
+	rows, err := q.db.Query(ctx, getAuditLogPrunedLinks, arg.AfterPosition, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AuditLogPrunedLink
+	for rows.Next() {
+		var i AuditLogPrunedLink
+		if err := rows.Scan(
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.PrunedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditLogs = `-- name: GetAuditLogs :many
+SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier, chain_position, previous_hash, hash, changes FROM audit_logs
+WHERE
+    ($1::TEXT IS NULL OR action = $1::TEXT)
+    AND ($2::TEXT IS NULL OR component_name = $2::TEXT)
+    AND ($3::TEXT IS NULL OR actor = $3::TEXT)
+    AND ($4::TEXT IS NULL OR target_type = $4::TEXT)
+    AND ($5::TEXT IS NULL OR target_identifier = $5::TEXT)
+    AND ($6::UUID IS NULL OR correlation_id = $6::UUID)
+    AND ($7::TIMESTAMPTZ IS NULL OR created_at >= $7::TIMESTAMPTZ)
+    AND ($8::TIMESTAMPTZ IS NULL OR created_at < $8::TIMESTAMPTZ)
+    AND (
+        $9::TIMESTAMPTZ IS NULL
+        OR (created_at, id) < ($9::TIMESTAMPTZ, $10::UUID)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT $11
+`
+
+type GetAuditLogsParams struct {
+	Action           *string
+	ComponentName    *string
+	Actor            *string
+	TargetType       *string
+	TargetIdentifier *string
+	CorrelationID    *uuid.UUID
+	CreatedAfter     *time.Time
+	CreatedBefore    *time.Time
+	CursorCreatedAt  *time.Time
+	CursorID         *uuid.UUID
+	LimitRows        int32
+}
+
+func (q *Queries) GetAuditLogs(ctx context.Context, arg GetAuditLogsParams) ([]*AuditLog, error) {
+	rows, err := q.db.Query(ctx, getAuditLogs,
+		arg.Action,
+		arg.ComponentName,
+		arg.Actor,
+		arg.TargetType,
+		arg.TargetIdentifier,
+		arg.CorrelationID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.CorrelationID,
+			&i.ComponentName,
+			&i.Actor,
+			&i.Action,
+			&i.Message,
+			&i.TargetType,
+			&i.TargetIdentifier,
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.Changes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditLogsByIDs = `-- name: GetAuditLogsByIDs :many
+SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier, chain_position, previous_hash, hash, changes FROM audit_logs
+WHERE id = ANY($1::UUID[])
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) GetAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) ([]*AuditLog, error) {
+	rows, err := q.db.Query(ctx, getAuditLogsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.CorrelationID,
+			&i.ComponentName,
+			&i.Actor,
+			&i.Action,
+			&i.Message,
+			&i.TargetType,
+			&i.TargetIdentifier,
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.Changes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAuditLogsForCorrelationID = `-- name: GetAuditLogsForCorrelationID :many
-SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier FROM audit_logs
+SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier, chain_position, previous_hash, hash, changes FROM audit_logs
 WHERE correlation_id = $1
 ORDER BY created_at DESC
 `
@@ -64,6 +319,10 @@ func (q *Queries) GetAuditLogsForCorrelationID(ctx context.Context, correlationI
 			&i.Message,
 			&i.TargetType,
 			&i.TargetIdentifier,
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.Changes,
 		); err != nil {
 			return nil, err
 		}
@@ -76,7 +335,7 @@ func (q *Queries) GetAuditLogsForCorrelationID(ctx context.Context, correlationI
 }
 
 const getAuditLogsForReconciler = `-- name: GetAuditLogsForReconciler :many
-SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier FROM audit_logs
+SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier, chain_position, previous_hash, hash, changes FROM audit_logs
 WHERE target_type = 'reconciler' AND target_identifier = $1
 ORDER BY created_at DESC
 LIMIT 100
@@ -101,6 +360,10 @@ func (q *Queries) GetAuditLogsForReconciler(ctx context.Context, targetIdentifie
 			&i.Message,
 			&i.TargetType,
 			&i.TargetIdentifier,
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.Changes,
 		); err != nil {
 			return nil, err
 		}
@@ -113,7 +376,7 @@ func (q *Queries) GetAuditLogsForReconciler(ctx context.Context, targetIdentifie
 }
 
 const getAuditLogsForTeam = `-- name: GetAuditLogsForTeam :many
-SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier FROM audit_logs
+SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier, chain_position, previous_hash, hash, changes FROM audit_logs
 WHERE target_type = 'team' AND target_identifier = $1
 ORDER BY created_at DESC
 LIMIT 100
@@ -138,6 +401,76 @@ func (q *Queries) GetAuditLogsForTeam(ctx context.Context, targetIdentifier stri
 			&i.Message,
 			&i.TargetType,
 			&i.TargetIdentifier,
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.Changes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredAuditLogs = `-- name: GetExpiredAuditLogs :many
+SELECT id, created_at, correlation_id, component_name, actor, action, message, target_type, target_identifier, chain_position, previous_hash, hash, changes FROM audit_logs
+WHERE
+    created_at < $1::TIMESTAMPTZ
+    AND ($2::TEXT IS NULL OR action = $2::TEXT)
+    AND ($3::TEXT IS NULL OR component_name = $3::TEXT)
+    AND NOT (action = ANY($4::TEXT[]))
+    AND NOT (component_name = ANY($5::TEXT[]))
+    AND NOT EXISTS (
+        SELECT o.id FROM audit_log_outbox AS o
+        WHERE o.audit_log_id = audit_logs.id
+    )
+ORDER BY created_at ASC, id ASC
+LIMIT $6
+`
+
+type GetExpiredAuditLogsParams struct {
+	CreatedBefore      time.Time
+	Action             *string
+	ComponentName      *string
+	ExcludedActions    []string
+	ExcludedComponents []string
+	LimitRows          int32
+}
+
+func (q *Queries) GetExpiredAuditLogs(ctx context.Context, arg GetExpiredAuditLogsParams) ([]*AuditLog, error) {
+	rows, err := q.db.Query(ctx, getExpiredAuditLogs,
+		arg.CreatedBefore,
+		arg.Action,
+		arg.ComponentName,
+		arg.ExcludedActions,
+		arg.ExcludedComponents,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.CorrelationID,
+			&i.ComponentName,
+			&i.Actor,
+			&i.Action,
+			&i.Message,
+			&i.TargetType,
+			&i.TargetIdentifier,
+			&i.ChainPosition,
+			&i.PreviousHash,
+			&i.Hash,
+			&i.Changes,
 		); err != nil {
 			return nil, err
 		}
@@ -148,3 +481,12 @@ func (q *Queries) GetAuditLogsForTeam(ctx context.Context, targetIdentifier stri
 	}
 	return items, nil
 }
+
+const lockAuditLogChain = `-- name: LockAuditLogChain :exec
+SELECT pg_advisory_xact_lock($1::BIGINT)
+`
+
+func (q *Queries) LockAuditLogChain(ctx context.Context, lockID int64) error {
+	_, err := q.db.Exec(ctx, lockAuditLogChain, lockID)
+	return err
+}
@@ -0,0 +1,47 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.20.0
+// source: leader_leases.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const acquireLeaderLease = `-- name: AcquireLeaderLease :one
+INSERT INTO leader_leases (name, holder, expires_at)
+VALUES ($1, $2, NOW() + $3::INT * INTERVAL '1 second')
+ON CONFLICT (name) DO
+    UPDATE SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+    WHERE leader_leases.holder = EXCLUDED.holder OR leader_leases.expires_at < NOW()
+RETURNING name, holder, expires_at
+`
+
+type AcquireLeaderLeaseParams struct {
+	Name         string
+	Holder       string
+	LeaseSeconds int32
+}
+
+func (q *Queries) AcquireLeaderLease(ctx context.Context, arg AcquireLeaderLeaseParams) (*LeaderLease, error) {
+	row := q.db.QueryRow(ctx, acquireLeaderLease, arg.Name, arg.Holder, arg.LeaseSeconds)
+	var i LeaderLease
+	err := row.Scan(&i.Name, &i.Holder, &i.ExpiresAt)
+	return &i, err
+}
+
+const releaseLeaderLease = `-- name: ReleaseLeaderLease :exec
+DELETE FROM leader_leases
+WHERE name = $1 AND holder = $2
+`
+
+type ReleaseLeaderLeaseParams struct {
+	Name   string
+	Holder string
+}
+
+func (q *Queries) ReleaseLeaderLease(ctx context.Context, arg ReleaseLeaderLeaseParams) error {
+	_, err := q.db.Exec(ctx, releaseLeaderLease, arg.Name, arg.Holder)
+	return err
+}
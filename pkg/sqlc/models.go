@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 )
 
@@ -75,6 +76,64 @@ func AllReconcilerConfigKeyValues() []ReconcilerConfigKey {
 	}
 }
 
+type ReconcilerErrorKind string
+
+const (
+	ReconcilerErrorKindError   ReconcilerErrorKind = "error"
+	ReconcilerErrorKindTimeout ReconcilerErrorKind = "timeout"
+)
+
+func (e *ReconcilerErrorKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReconcilerErrorKind(s)
+	case string:
+		*e = ReconcilerErrorKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReconcilerErrorKind: %T", src)
+	}
+	return nil
+}
+
+type NullReconcilerErrorKind struct {
+	ReconcilerErrorKind ReconcilerErrorKind
+	Valid               bool // Valid is true if ReconcilerErrorKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReconcilerErrorKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReconcilerErrorKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReconcilerErrorKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReconcilerErrorKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReconcilerErrorKind), nil
+}
+
+func (e ReconcilerErrorKind) Valid() bool {
+	switch e {
+	case ReconcilerErrorKindError,
+		ReconcilerErrorKindTimeout:
+		return true
+	}
+	return false
+}
+
+func AllReconcilerErrorKindValues() []ReconcilerErrorKind {
+	return []ReconcilerErrorKind{
+		ReconcilerErrorKindError,
+		ReconcilerErrorKindTimeout,
+	}
+}
+
 type ReconcilerName string
 
 const (
@@ -206,94 +265,460 @@ func AllRepositoryAuthorizationEnumValues() []RepositoryAuthorizationEnum {
 	}
 }
 
-type RoleName string
+type TeamDeletionReconcilerStatus string
+
+const (
+	TeamDeletionReconcilerStatusSuccess TeamDeletionReconcilerStatus = "success"
+	TeamDeletionReconcilerStatusFailure TeamDeletionReconcilerStatus = "failure"
+)
+
+func (e *TeamDeletionReconcilerStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TeamDeletionReconcilerStatus(s)
+	case string:
+		*e = TeamDeletionReconcilerStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TeamDeletionReconcilerStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTeamDeletionReconcilerStatus struct {
+	TeamDeletionReconcilerStatus TeamDeletionReconcilerStatus
+	Valid                        bool // Valid is true if TeamDeletionReconcilerStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTeamDeletionReconcilerStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TeamDeletionReconcilerStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TeamDeletionReconcilerStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTeamDeletionReconcilerStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TeamDeletionReconcilerStatus), nil
+}
+
+func (e TeamDeletionReconcilerStatus) Valid() bool {
+	switch e {
+	case TeamDeletionReconcilerStatusSuccess,
+		TeamDeletionReconcilerStatusFailure:
+		return true
+	}
+	return false
+}
+
+func AllTeamDeletionReconcilerStatusValues() []TeamDeletionReconcilerStatus {
+	return []TeamDeletionReconcilerStatus{
+		TeamDeletionReconcilerStatusSuccess,
+		TeamDeletionReconcilerStatusFailure,
+	}
+}
+
+type TeamSyncReconcilerStatus string
 
 const (
-	RoleNameAdmin                 RoleName = "Admin"
-	RoleNameDeploykeyviewer       RoleName = "Deploy key viewer"
-	RoleNameServiceaccountcreator RoleName = "Service account creator"
-	RoleNameServiceaccountowner   RoleName = "Service account owner"
-	RoleNameSynchronizer          RoleName = "Synchronizer"
-	RoleNameTeamcreator           RoleName = "Team creator"
-	RoleNameTeammember            RoleName = "Team member"
-	RoleNameTeamowner             RoleName = "Team owner"
-	RoleNameTeamviewer            RoleName = "Team viewer"
-	RoleNameUseradmin             RoleName = "User admin"
-	RoleNameUserviewer            RoleName = "User viewer"
+	TeamSyncReconcilerStatusSuccess  TeamSyncReconcilerStatus = "success"
+	TeamSyncReconcilerStatusFailure  TeamSyncReconcilerStatus = "failure"
+	TeamSyncReconcilerStatusTimeout  TeamSyncReconcilerStatus = "timeout"
+	TeamSyncReconcilerStatusSkipped  TeamSyncReconcilerStatus = "skipped"
+	TeamSyncReconcilerStatusCanceled TeamSyncReconcilerStatus = "canceled"
 )
 
-func (e *RoleName) Scan(src interface{}) error {
+func (e *TeamSyncReconcilerStatus) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
-		*e = RoleName(s)
+		*e = TeamSyncReconcilerStatus(s)
 	case string:
-		*e = RoleName(s)
+		*e = TeamSyncReconcilerStatus(s)
 	default:
-		return fmt.Errorf("unsupported scan type for RoleName: %T", src)
+		return fmt.Errorf("unsupported scan type for TeamSyncReconcilerStatus: %T", src)
 	}
 	return nil
 }
 
-type NullRoleName struct {
-	RoleName RoleName
-	Valid    bool // Valid is true if RoleName is not NULL
+type NullTeamSyncReconcilerStatus struct {
+	TeamSyncReconcilerStatus TeamSyncReconcilerStatus
+	Valid                    bool // Valid is true if TeamSyncReconcilerStatus is not NULL
 }
 
 // Scan implements the Scanner interface.
-func (ns *NullRoleName) Scan(value interface{}) error {
+func (ns *NullTeamSyncReconcilerStatus) Scan(value interface{}) error {
 	if value == nil {
-		ns.RoleName, ns.Valid = "", false
+		ns.TeamSyncReconcilerStatus, ns.Valid = "", false
 		return nil
 	}
 	ns.Valid = true
-	return ns.RoleName.Scan(value)
+	return ns.TeamSyncReconcilerStatus.Scan(value)
 }
 
 // Value implements the driver Valuer interface.
-func (ns NullRoleName) Value() (driver.Value, error) {
+func (ns NullTeamSyncReconcilerStatus) Value() (driver.Value, error) {
 	if !ns.Valid {
 		return nil, nil
 	}
-	return string(ns.RoleName), nil
+	return string(ns.TeamSyncReconcilerStatus), nil
 }
 
-func (e RoleName) Valid() bool {
+func (e TeamSyncReconcilerStatus) Valid() bool {
 	switch e {
-	case RoleNameAdmin,
-		RoleNameDeploykeyviewer,
-		RoleNameServiceaccountcreator,
-		RoleNameServiceaccountowner,
-		RoleNameSynchronizer,
-		RoleNameTeamcreator,
-		RoleNameTeammember,
-		RoleNameTeamowner,
-		RoleNameTeamviewer,
-		RoleNameUseradmin,
-		RoleNameUserviewer:
+	case TeamSyncReconcilerStatusSuccess,
+		TeamSyncReconcilerStatusFailure,
+		TeamSyncReconcilerStatusTimeout,
+		TeamSyncReconcilerStatusSkipped,
+		TeamSyncReconcilerStatusCanceled:
 		return true
 	}
 	return false
 }
 
-func AllRoleNameValues() []RoleName {
-	return []RoleName{
-		RoleNameAdmin,
-		RoleNameDeploykeyviewer,
-		RoleNameServiceaccountcreator,
-		RoleNameServiceaccountowner,
-		RoleNameSynchronizer,
-		RoleNameTeamcreator,
-		RoleNameTeammember,
-		RoleNameTeamowner,
-		RoleNameTeamviewer,
-		RoleNameUseradmin,
-		RoleNameUserviewer,
+func AllTeamSyncReconcilerStatusValues() []TeamSyncReconcilerStatus {
+	return []TeamSyncReconcilerStatus{
+		TeamSyncReconcilerStatusSuccess,
+		TeamSyncReconcilerStatusFailure,
+		TeamSyncReconcilerStatusTimeout,
+		TeamSyncReconcilerStatusSkipped,
+		TeamSyncReconcilerStatusCanceled,
+	}
+}
+
+type TeamSyncRunStatus string
+
+const (
+	TeamSyncRunStatusInProgress TeamSyncRunStatus = "in_progress"
+	TeamSyncRunStatusSuccess    TeamSyncRunStatus = "success"
+	TeamSyncRunStatusFailure    TeamSyncRunStatus = "failure"
+	TeamSyncRunStatusCanceled   TeamSyncRunStatus = "canceled"
+)
+
+func (e *TeamSyncRunStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TeamSyncRunStatus(s)
+	case string:
+		*e = TeamSyncRunStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TeamSyncRunStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTeamSyncRunStatus struct {
+	TeamSyncRunStatus TeamSyncRunStatus
+	Valid             bool // Valid is true if TeamSyncRunStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTeamSyncRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TeamSyncRunStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TeamSyncRunStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTeamSyncRunStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TeamSyncRunStatus), nil
+}
+
+func (e TeamSyncRunStatus) Valid() bool {
+	switch e {
+	case TeamSyncRunStatusInProgress,
+		TeamSyncRunStatusSuccess,
+		TeamSyncRunStatusFailure,
+		TeamSyncRunStatusCanceled:
+		return true
+	}
+	return false
+}
+
+func AllTeamSyncRunStatusValues() []TeamSyncRunStatus {
+	return []TeamSyncRunStatus{
+		TeamSyncRunStatusInProgress,
+		TeamSyncRunStatusSuccess,
+		TeamSyncRunStatusFailure,
+		TeamSyncRunStatusCanceled,
+	}
+}
+
+type TeamSyncTrigger string
+
+const (
+	TeamSyncTriggerChange   TeamSyncTrigger = "change"
+	TeamSyncTriggerManual   TeamSyncTrigger = "manual"
+	TeamSyncTriggerSchedule TeamSyncTrigger = "schedule"
+	TeamSyncTriggerRetry    TeamSyncTrigger = "retry"
+)
+
+func (e *TeamSyncTrigger) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TeamSyncTrigger(s)
+	case string:
+		*e = TeamSyncTrigger(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TeamSyncTrigger: %T", src)
+	}
+	return nil
+}
+
+type NullTeamSyncTrigger struct {
+	TeamSyncTrigger TeamSyncTrigger
+	Valid           bool // Valid is true if TeamSyncTrigger is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTeamSyncTrigger) Scan(value interface{}) error {
+	if value == nil {
+		ns.TeamSyncTrigger, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TeamSyncTrigger.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTeamSyncTrigger) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TeamSyncTrigger), nil
+}
+
+func (e TeamSyncTrigger) Valid() bool {
+	switch e {
+	case TeamSyncTriggerChange,
+		TeamSyncTriggerManual,
+		TeamSyncTriggerSchedule,
+		TeamSyncTriggerRetry:
+		return true
+	}
+	return false
+}
+
+func AllTeamSyncTriggerValues() []TeamSyncTrigger {
+	return []TeamSyncTrigger{
+		TeamSyncTriggerChange,
+		TeamSyncTriggerManual,
+		TeamSyncTriggerSchedule,
+		TeamSyncTriggerRetry,
+	}
+}
+
+type UserSyncRunStatus string
+
+const (
+	UserSyncRunStatusInProgress UserSyncRunStatus = "in_progress"
+	UserSyncRunStatusSuccess    UserSyncRunStatus = "success"
+	UserSyncRunStatusFailure    UserSyncRunStatus = "failure"
+)
+
+func (e *UserSyncRunStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserSyncRunStatus(s)
+	case string:
+		*e = UserSyncRunStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserSyncRunStatus: %T", src)
+	}
+	return nil
+}
+
+type NullUserSyncRunStatus struct {
+	UserSyncRunStatus UserSyncRunStatus
+	Valid             bool // Valid is true if UserSyncRunStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserSyncRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserSyncRunStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserSyncRunStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserSyncRunStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserSyncRunStatus), nil
+}
+
+func (e UserSyncRunStatus) Valid() bool {
+	switch e {
+	case UserSyncRunStatusInProgress,
+		UserSyncRunStatusSuccess,
+		UserSyncRunStatusFailure:
+		return true
+	}
+	return false
+}
+
+func AllUserSyncRunStatusValues() []UserSyncRunStatus {
+	return []UserSyncRunStatus{
+		UserSyncRunStatusInProgress,
+		UserSyncRunStatusSuccess,
+		UserSyncRunStatusFailure,
+	}
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus
+	Valid                 bool // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
+func (e WebhookDeliveryStatus) Valid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusDelivered,
+		WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func AllWebhookDeliveryStatusValues() []WebhookDeliveryStatus {
+	return []WebhookDeliveryStatus{
+		WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusDelivered,
+		WebhookDeliveryStatusFailed,
+	}
+}
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeTeamcreated   WebhookEventType = "team.created"
+	WebhookEventTypeTeamupdated   WebhookEventType = "team.updated"
+	WebhookEventTypeTeamdeleted   WebhookEventType = "team.deleted"
+	WebhookEventTypeMemberadded   WebhookEventType = "member.added"
+	WebhookEventTypeMemberremoved WebhookEventType = "member.removed"
+	WebhookEventTypeSyncfailed    WebhookEventType = "sync.failed"
+)
+
+func (e *WebhookEventType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookEventType(s)
+	case string:
+		*e = WebhookEventType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookEventType: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookEventType struct {
+	WebhookEventType WebhookEventType
+	Valid            bool // Valid is true if WebhookEventType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookEventType) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookEventType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookEventType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookEventType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookEventType), nil
+}
+
+func (e WebhookEventType) Valid() bool {
+	switch e {
+	case WebhookEventTypeTeamcreated,
+		WebhookEventTypeTeamupdated,
+		WebhookEventTypeTeamdeleted,
+		WebhookEventTypeMemberadded,
+		WebhookEventTypeMemberremoved,
+		WebhookEventTypeSyncfailed:
+		return true
+	}
+	return false
+}
+
+func AllWebhookEventTypeValues() []WebhookEventType {
+	return []WebhookEventType{
+		WebhookEventTypeTeamcreated,
+		WebhookEventTypeTeamupdated,
+		WebhookEventTypeTeamdeleted,
+		WebhookEventTypeMemberadded,
+		WebhookEventTypeMemberremoved,
+		WebhookEventTypeSyncfailed,
 	}
 }
 
 type ApiKey struct {
-	ApiKey           string
 	ServiceAccountID uuid.UUID
+	ID               uuid.UUID
+	Prefix           string
+	Salt             []byte
+	Hash             []byte
+	CreatedAt        time.Time
+	ExpiresAt        *time.Time
+	LastUsedAt       *time.Time
 }
 
 type AuditLog struct {
@@ -306,18 +731,48 @@ type AuditLog struct {
 	Message          string
 	TargetType       string
 	TargetIdentifier string
+	ChainPosition    *int64
+	PreviousHash     *string
+	Hash             *string
+	Changes          []byte
+}
+
+type AuditLogOutbox struct {
+	ID             int64
+	AuditLogID     uuid.UUID
+	DeliveredSinks []string
+	Attempts       int32
+	LastError      *string
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	LockedBy       *string
+	LockedAt       *time.Time
+}
+
+type AuditLogPrunedLink struct {
+	ChainPosition int64
+	PreviousHash  *string
+	Hash          string
+	PrunedAt      time.Time
 }
 
 type FirstRun struct {
 	FirstRun bool
 }
 
+type LeaderLease struct {
+	Name      string
+	Holder    string
+	ExpiresAt time.Time
+}
+
 type Reconciler struct {
-	Name        ReconcilerName
-	DisplayName string
-	Description string
-	Enabled     bool
-	RunOrder    int32
+	Name           ReconcilerName
+	DisplayName    string
+	Description    string
+	Enabled        bool
+	RunOrder       int32
+	TimeoutSeconds *int32
 }
 
 type ReconcilerConfig struct {
@@ -336,6 +791,9 @@ type ReconcilerError struct {
 	CreatedAt     time.Time
 	ErrorMessage  string
 	TeamSlug      slug.Slug
+	Attempts      int32
+	NextRetryAt   *time.Time
+	Kind          ReconcilerErrorKind
 }
 
 type ReconcilerOptOut struct {
@@ -356,17 +814,32 @@ type RepositoryAuthorization struct {
 	RepositoryAuthorization RepositoryAuthorizationEnum
 }
 
+type Role struct {
+	Name        roles.RoleName
+	Description string
+	BuiltIn     bool
+	CreatedAt   time.Time
+}
+
+type RoleAuthorization struct {
+	RoleName          roles.RoleName
+	AuthorizationName roles.Authorization
+}
+
 type ServiceAccount struct {
-	ID   uuid.UUID
-	Name string
+	ID       uuid.UUID
+	Name     string
+	TeamSlug *slug.Slug
 }
 
 type ServiceAccountRole struct {
 	ID                     int32
-	RoleName               RoleName
+	RoleName               roles.RoleName
 	ServiceAccountID       uuid.UUID
 	TargetTeamSlug         *slug.Slug
 	TargetServiceAccountID *uuid.UUID
+	GrantedBy              *string
+	GrantedAt              *time.Time
 }
 
 type Session struct {
@@ -386,6 +859,9 @@ type Team struct {
 	Purpose            string
 	LastSuccessfulSync *time.Time
 	SlackChannel       string
+	ParentTeamSlug     *slug.Slug
+	Enabled            bool
+	ResourcesSuspended bool
 }
 
 type TeamDeleteKey struct {
@@ -396,6 +872,56 @@ type TeamDeleteKey struct {
 	ConfirmedAt *time.Time
 }
 
+type TeamDeletion struct {
+	TeamSlug      slug.Slug
+	CorrelationID uuid.UUID
+	CreatedAt     time.Time
+	DeleteAt      time.Time
+	StartedAt     *time.Time
+}
+
+type TeamDeletionReconciler struct {
+	TeamSlug     slug.Slug
+	Reconciler   ReconcilerName
+	Status       TeamDeletionReconcilerStatus
+	FinishedAt   time.Time
+	ErrorMessage *string
+}
+
+type TeamSyncQueue struct {
+	ID            int64
+	TeamSlug      slug.Slug
+	CorrelationID uuid.UUID
+	Attempts      int32
+	CreatedAt     time.Time
+	NextRunAt     time.Time
+	LockedBy      *string
+	LockedAt      *time.Time
+	Reconcilers   []string
+	TriggeredBy   TeamSyncTrigger
+}
+
+type TeamSyncRun struct {
+	ID            int64
+	TeamSlug      slug.Slug
+	CorrelationID uuid.UUID
+	TriggeredBy   TeamSyncTrigger
+	Reconcilers   []string
+	StartedAt     time.Time
+	FinishedAt    *time.Time
+	Status        TeamSyncRunStatus
+	ErrorMessage  *string
+}
+
+type TeamSyncRunReconciler struct {
+	RunID        int64
+	Reconciler   ReconcilerName
+	Status       TeamSyncReconcilerStatus
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	ErrorMessage *string
+}
+
 type User struct {
 	ID         uuid.UUID
 	Email      string
@@ -405,8 +931,48 @@ type User struct {
 
 type UserRole struct {
 	ID                     int32
-	RoleName               RoleName
+	RoleName               roles.RoleName
 	UserID                 uuid.UUID
 	TargetTeamSlug         *slug.Slug
 	TargetServiceAccountID *uuid.UUID
+	GrantedBy              *string
+	GrantedAt              *time.Time
+}
+
+type UserSyncRun struct {
+	ID            int64
+	CorrelationID uuid.UUID
+	StartedAt     time.Time
+	FinishedAt    *time.Time
+	Status        UserSyncRunStatus
+	ErrorMessage  *string
+	CreatedUsers  int32
+	UpdatedUsers  int32
+	DeletedUsers  int32
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      WebhookEventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int32
+	LastStatusCode *int32
+	LastError      *string
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	LockedBy       *string
+	LockedAt       *time.Time
+}
+
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	Url        string
+	Secret     string
+	EventTypes []string
+	Enabled    bool
+	CreatedAt  time.Time
 }
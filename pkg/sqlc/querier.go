@@ -6,88 +6,177 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgtype"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 )
 
 type Querier interface {
+	AcquireLeaderLease(ctx context.Context, arg AcquireLeaderLeaseParams) (*LeaderLease, error)
 	AddReconcilerOptOut(ctx context.Context, arg AddReconcilerOptOutParams) error
+	AddRoleAuthorization(ctx context.Context, arg AddRoleAuthorizationParams) error
 	AssignGlobalRoleToServiceAccount(ctx context.Context, arg AssignGlobalRoleToServiceAccountParams) error
 	AssignGlobalRoleToUser(ctx context.Context, arg AssignGlobalRoleToUserParams) error
+	AssignServiceAccountRoleToUser(ctx context.Context, arg AssignServiceAccountRoleToUserParams) error
 	AssignTeamRoleToServiceAccount(ctx context.Context, arg AssignTeamRoleToServiceAccountParams) error
 	AssignTeamRoleToUser(ctx context.Context, arg AssignTeamRoleToUserParams) error
+	ClaimAuditLogOutboxEntries(ctx context.Context, arg ClaimAuditLogOutboxEntriesParams) ([]*AuditLogOutbox, error)
+	ClaimTeamSync(ctx context.Context, lockedBy string) (*TeamSyncQueue, error)
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]*WebhookDelivery, error)
 	ClearReconcilerErrorsForTeam(ctx context.Context, arg ClearReconcilerErrorsForTeamParams) error
 	ConfigureReconciler(ctx context.Context, arg ConfigureReconcilerParams) error
 	ConfirmTeamDeleteKey(ctx context.Context, key uuid.UUID) error
-	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
+	CountGlobalAdmins(ctx context.Context) (int32, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (*ApiKey, error)
 	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
+	CreateAuditLogOutboxEntry(ctx context.Context, auditLogID uuid.UUID) error
 	CreateRepositoryAuthorization(ctx context.Context, arg CreateRepositoryAuthorizationParams) error
-	CreateServiceAccount(ctx context.Context, name string) (*ServiceAccount, error)
+	CreateRole(ctx context.Context, arg CreateRoleParams) (*Role, error)
+	CreateServiceAccount(ctx context.Context, arg CreateServiceAccountParams) (*ServiceAccount, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (*Session, error)
 	CreateTeam(ctx context.Context, arg CreateTeamParams) (*Team, error)
 	CreateTeamDeleteKey(ctx context.Context, arg CreateTeamDeleteKeyParams) (*TeamDeleteKey, error)
+	CreateTeamDeletion(ctx context.Context, arg CreateTeamDeletionParams) (*TeamDeletion, error)
+	CreateTeamSyncRun(ctx context.Context, arg CreateTeamSyncRunParams) (*TeamSyncRun, error)
+	CreateTeamSyncRunReconciler(ctx context.Context, arg CreateTeamSyncRunReconcilerParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (*User, error)
+	CreateUserSyncRun(ctx context.Context, correlationID uuid.UUID) (*UserSyncRun, error)
+	CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (*WebhookSubscription, error)
 	DangerousGetReconcilerConfigValues(ctx context.Context, reconciler ReconcilerName) ([]*DangerousGetReconcilerConfigValuesRow, error)
+	DeleteAuditLogOutboxEntries(ctx context.Context) (int64, error)
+	DeleteAuditLogOutboxEntry(ctx context.Context, id int64) error
+	DeleteAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) (int64, error)
+	DeleteOldTeamSyncRuns(ctx context.Context, arg DeleteOldTeamSyncRunsParams) (int64, error)
+	DeleteOldUserSyncRuns(ctx context.Context, runsToKeep int32) (int64, error)
+	DeleteRole(ctx context.Context, name roles.RoleName) error
 	DeleteServiceAccount(ctx context.Context, id uuid.UUID) error
 	DeleteSession(ctx context.Context, id uuid.UUID) error
 	DeleteTeam(ctx context.Context, argSlug slug.Slug) error
+	DeleteTeamDeleteKeys(ctx context.Context, teamSlug slug.Slug) error
+	DeleteTeamDeletion(ctx context.Context, teamSlug slug.Slug) (int64, error)
+	DeleteTeamSync(ctx context.Context, id int64) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteWebhookDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error)
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
 	DisableReconciler(ctx context.Context, name ReconcilerName) (*Reconciler, error)
+	DisableTeam(ctx context.Context, arg DisableTeamParams) (*Team, error)
 	EnableReconciler(ctx context.Context, name ReconcilerName) (*Reconciler, error)
+	EnableTeam(ctx context.Context, argSlug slug.Slug) (*Team, error)
+	EnqueueTeamSync(ctx context.Context, arg EnqueueTeamSyncParams) error
+	FinishTeamSyncRun(ctx context.Context, arg FinishTeamSyncRunParams) (*TeamSyncRun, error)
+	FinishUserSyncRun(ctx context.Context, arg FinishUserSyncRunParams) (*UserSyncRun, error)
 	FirstRunComplete(ctx context.Context) error
+	GetAPIKeyByID(ctx context.Context, id uuid.UUID) (*ApiKey, error)
+	GetActiveAPIKeysByPrefix(ctx context.Context, prefix string) ([]*ApiKey, error)
 	GetActiveTeamBySlug(ctx context.Context, argSlug slug.Slug) (*Team, error)
 	GetActiveTeams(ctx context.Context) ([]*Team, error)
 	GetAllUserRoles(ctx context.Context) ([]*UserRole, error)
+	GetAuditLogChain(ctx context.Context, arg GetAuditLogChainParams) ([]*AuditLog, error)
+	GetAuditLogChainTail(ctx context.Context) (*GetAuditLogChainTailRow, error)
+	GetAuditLogPrunedLinks(ctx context.Context, arg GetAuditLogPrunedLinksParams) ([]*AuditLogPrunedLink, error)
+	GetAuditLogs(ctx context.Context, arg GetAuditLogsParams) ([]*AuditLog, error)
+	GetAuditLogsByIDs(ctx context.Context, ids []uuid.UUID) ([]*AuditLog, error)
 	GetAuditLogsForCorrelationID(ctx context.Context, correlationID uuid.UUID) ([]*AuditLog, error)
 	GetAuditLogsForReconciler(ctx context.Context, targetIdentifier string) ([]*AuditLog, error)
 	GetAuditLogsForTeam(ctx context.Context, targetIdentifier string) ([]*AuditLog, error)
+	GetAuthorizationNames(ctx context.Context) ([]roles.Authorization, error)
+	GetAuthorizationsForRoles(ctx context.Context, roleNames []string) ([]*RoleAuthorization, error)
+	GetDescendantSlugsForTeams(ctx context.Context, slugs []string) ([]*GetDescendantSlugsForTeamsRow, error)
 	GetEnabledReconcilers(ctx context.Context) ([]*Reconciler, error)
+	GetExpiredAuditLogs(ctx context.Context, arg GetExpiredAuditLogsParams) ([]*AuditLog, error)
+	GetPendingAuditLogOutboxCount(ctx context.Context) (int64, error)
+	GetPendingTeamSyncCount(ctx context.Context) (int64, error)
+	GetPendingWebhookDeliveryCount(ctx context.Context) (int64, error)
 	GetReconciler(ctx context.Context, name ReconcilerName) (*Reconciler, error)
 	GetReconcilerConfig(ctx context.Context, reconciler ReconcilerName) ([]*GetReconcilerConfigRow, error)
 	GetReconcilerStateForTeam(ctx context.Context, arg GetReconcilerStateForTeamParams) (*ReconcilerState, error)
 	GetReconcilers(ctx context.Context) ([]*Reconciler, error)
 	GetRepositoryAuthorizations(ctx context.Context, arg GetRepositoryAuthorizationsParams) ([]RepositoryAuthorizationEnum, error)
-	GetServiceAccountByApiKey(ctx context.Context, apiKey string) (*ServiceAccount, error)
+	GetRoleAuthorizations(ctx context.Context, roleName roles.RoleName) ([]roles.Authorization, error)
+	GetRoleByName(ctx context.Context, name roles.RoleName) (*Role, error)
+	GetRoles(ctx context.Context) ([]*Role, error)
+	GetServiceAccountAPIKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]*ApiKey, error)
+	GetServiceAccountByID(ctx context.Context, id uuid.UUID) (*ServiceAccount, error)
 	GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error)
 	GetServiceAccountRoles(ctx context.Context, serviceAccountID uuid.UUID) ([]*ServiceAccountRole, error)
 	GetServiceAccounts(ctx context.Context) ([]*ServiceAccount, error)
 	GetSessionByID(ctx context.Context, id uuid.UUID) (*Session, error)
 	GetSlackAlertsChannels(ctx context.Context, teamSlug slug.Slug) ([]*SlackAlertsChannel, error)
 	GetTeamBySlug(ctx context.Context, argSlug slug.Slug) (*Team, error)
+	GetTeamChildren(ctx context.Context, parentTeamSlug *slug.Slug) ([]*Team, error)
 	GetTeamDeleteKey(ctx context.Context, key uuid.UUID) (*TeamDeleteKey, error)
+	GetTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*TeamDeletion, error)
+	GetTeamDeletionReconcilers(ctx context.Context, teamSlug slug.Slug) ([]*TeamDeletionReconciler, error)
+	GetTeamDescendantSlugs(ctx context.Context, argSlug string) ([]string, error)
 	GetTeamMember(ctx context.Context, arg GetTeamMemberParams) (*User, error)
 	GetTeamMemberOptOuts(ctx context.Context, arg GetTeamMemberOptOutsParams) ([]*GetTeamMemberOptOutsRow, error)
 	GetTeamMembers(ctx context.Context, targetTeamSlug *slug.Slug) ([]*User, error)
 	GetTeamMembersForReconciler(ctx context.Context, arg GetTeamMembersForReconcilerParams) ([]*User, error)
 	GetTeamReconcilerErrors(ctx context.Context, teamSlug slug.Slug) ([]*ReconcilerError, error)
+	GetTeamServiceAccounts(ctx context.Context, teamSlug *slug.Slug) ([]*ServiceAccount, error)
+	GetTeamSyncRunReconcilers(ctx context.Context, runID int64) ([]*TeamSyncRunReconciler, error)
+	GetTeamSyncRuns(ctx context.Context, arg GetTeamSyncRunsParams) ([]*TeamSyncRun, error)
 	GetTeams(ctx context.Context) ([]*Team, error)
 	GetTeamsWithPermissionInGitHubRepo(ctx context.Context, state pgtype.JSONB) ([]*Team, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByExternalID(ctx context.Context, externalID string) (*User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*UserRole, error)
+	GetUserSyncRuns(ctx context.Context, arg GetUserSyncRunsParams) ([]*UserSyncRun, error)
 	GetUserTeams(ctx context.Context, userID uuid.UUID) ([]*Team, error)
 	GetUsers(ctx context.Context) ([]*User, error)
-	GetUsersWithGloballyAssignedRole(ctx context.Context, roleName RoleName) ([]*User, error)
+	GetUsersWithGloballyAssignedRole(ctx context.Context, roleName roles.RoleName) ([]*User, error)
+	GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]*WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error)
+	GetWebhookSubscriptions(ctx context.Context) ([]*WebhookSubscription, error)
+	GetWebhookSubscriptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]*WebhookSubscription, error)
 	IsFirstRun(ctx context.Context) (bool, error)
+	LockAuditLogChain(ctx context.Context, lockID int64) error
+	LockGlobalAdmins(ctx context.Context, lockID int64) error
+	LockTeamAndParentAncestors(ctx context.Context, arg LockTeamAndParentAncestorsParams) error
+	Notify(ctx context.Context, arg NotifyParams) error
+	ReleaseLeaderLease(ctx context.Context, arg ReleaseLeaderLeaseParams) error
+	ReleaseStaleAuditLogOutboxEntries(ctx context.Context, lockedBefore time.Time) (int64, error)
+	ReleaseStaleTeamDeletions(ctx context.Context, startedBefore time.Time) (int64, error)
+	ReleaseStaleTeamSyncs(ctx context.Context, lockedBefore time.Time) (int64, error)
+	ReleaseStaleWebhookDeliveries(ctx context.Context, lockedBefore time.Time) (int64, error)
+	ReleaseTeamSync(ctx context.Context, id int64) error
 	RemoveAllServiceAccountRoles(ctx context.Context, serviceAccountID uuid.UUID) error
 	RemoveApiKeysFromServiceAccount(ctx context.Context, serviceAccountID uuid.UUID) error
 	RemoveReconcilerOptOut(ctx context.Context, arg RemoveReconcilerOptOutParams) error
 	RemoveReconcilerStateForTeam(ctx context.Context, arg RemoveReconcilerStateForTeamParams) error
 	RemoveRepositoryAuthorization(ctx context.Context, arg RemoveRepositoryAuthorizationParams) error
+	RemoveRoleAuthorizations(ctx context.Context, roleName roles.RoleName) error
 	RemoveSlackAlertsChannel(ctx context.Context, arg RemoveSlackAlertsChannelParams) error
 	RemoveUserFromTeam(ctx context.Context, arg RemoveUserFromTeamParams) error
 	ResetReconcilerConfig(ctx context.Context, reconciler ReconcilerName) error
+	ResetTeamDeletion(ctx context.Context, arg ResetTeamDeletionParams) error
+	RetryAuditLogOutboxEntry(ctx context.Context, arg RetryAuditLogOutboxEntryParams) error
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	RevokeGlobalServiceAccountRole(ctx context.Context, arg RevokeGlobalServiceAccountRoleParams) error
 	RevokeGlobalUserRole(ctx context.Context, arg RevokeGlobalUserRoleParams) error
+	SetAPIKeyLastUsed(ctx context.Context, id uuid.UUID) error
 	SetLastSuccessfulSyncForTeam(ctx context.Context, argSlug slug.Slug) error
-	SetReconcilerErrorForTeam(ctx context.Context, arg SetReconcilerErrorForTeamParams) error
+	SetReconcilerErrorForTeam(ctx context.Context, arg SetReconcilerErrorForTeamParams) (*ReconcilerError, error)
+	SetReconcilerErrorNextRetry(ctx context.Context, arg SetReconcilerErrorNextRetryParams) error
+	SetReconcilerSkippedForTeam(ctx context.Context, arg SetReconcilerSkippedForTeamParams) error
 	SetReconcilerStateForTeam(ctx context.Context, arg SetReconcilerStateForTeamParams) error
+	SetReconcilerTimeout(ctx context.Context, arg SetReconcilerTimeoutParams) (*Reconciler, error)
 	SetSessionExpires(ctx context.Context, arg SetSessionExpiresParams) (*Session, error)
 	SetSlackAlertsChannel(ctx context.Context, arg SetSlackAlertsChannelParams) error
+	SetTeamDeletionReconcilerResult(ctx context.Context, arg SetTeamDeletionReconcilerResultParams) error
+	SetTeamParent(ctx context.Context, arg SetTeamParentParams) (*Team, error)
+	SetWebhookDeliveryResult(ctx context.Context, arg SetWebhookDeliveryResultParams) error
+	StartDueTeamDeletions(ctx context.Context) ([]*TeamDeletion, error)
+	UpdateRole(ctx context.Context, arg UpdateRoleParams) (*Role, error)
+	UpdateServiceAccount(ctx context.Context, arg UpdateServiceAccountParams) (*ServiceAccount, error)
 	UpdateTeam(ctx context.Context, arg UpdateTeamParams) (*Team, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (*User, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (*WebhookSubscription, error)
 }
 
 var _ Querier = (*Queries)(nil)
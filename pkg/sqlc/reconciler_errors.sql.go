@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nais/teams-backend/pkg/slug"
@@ -28,7 +29,7 @@ func (q *Queries) ClearReconcilerErrorsForTeam(ctx context.Context, arg ClearRec
 }
 
 const getTeamReconcilerErrors = `-- name: GetTeamReconcilerErrors :many
-SELECT id, correlation_id, reconciler, created_at, error_message, team_slug FROM reconciler_errors
+SELECT id, correlation_id, reconciler, created_at, error_message, team_slug, attempts, next_retry_at, kind FROM reconciler_errors
 WHERE team_slug = $1
 ORDER BY created_at DESC
 `
@@ -49,6 +50,9 @@ func (q *Queries) GetTeamReconcilerErrors(ctx context.Context, teamSlug slug.Slu
 			&i.CreatedAt,
 			&i.ErrorMessage,
 			&i.TeamSlug,
+			&i.Attempts,
+			&i.NextRetryAt,
+			&i.Kind,
 		); err != nil {
 			return nil, err
 		}
@@ -60,11 +64,12 @@ func (q *Queries) GetTeamReconcilerErrors(ctx context.Context, teamSlug slug.Slu
 	return items, nil
 }
 
-const setReconcilerErrorForTeam = `-- name: SetReconcilerErrorForTeam :exec
-INSERT INTO reconciler_errors (correlation_id, team_slug, reconciler, error_message)
-VALUES ($1, $2, $3, $4)
+const setReconcilerErrorForTeam = `-- name: SetReconcilerErrorForTeam :one
+INSERT INTO reconciler_errors (correlation_id, team_slug, reconciler, error_message, kind)
+VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT(team_slug, reconciler) DO
-    UPDATE SET correlation_id = $1, created_at = NOW(), error_message = $4
+    UPDATE SET correlation_id = $1, created_at = NOW(), error_message = $4, kind = $5, attempts = reconciler_errors.attempts + 1, next_retry_at = NULL
+RETURNING id, correlation_id, reconciler, created_at, error_message, team_slug, attempts, next_retry_at, kind
 `
 
 type SetReconcilerErrorForTeamParams struct {
@@ -72,10 +77,65 @@ type SetReconcilerErrorForTeamParams struct {
 	TeamSlug      slug.Slug
 	Reconciler    ReconcilerName
 	ErrorMessage  string
+	Kind          ReconcilerErrorKind
+}
+
+func (q *Queries) SetReconcilerErrorForTeam(ctx context.Context, arg SetReconcilerErrorForTeamParams) (*ReconcilerError, error) {
+	row := q.db.QueryRow(ctx, setReconcilerErrorForTeam,
+		arg.CorrelationID,
+		arg.TeamSlug,
+		arg.Reconciler,
+		arg.ErrorMessage,
+		arg.Kind,
+	)
+	var i ReconcilerError
+	err := row.Scan(
+		&i.ID,
+		&i.CorrelationID,
+		&i.Reconciler,
+		&i.CreatedAt,
+		&i.ErrorMessage,
+		&i.TeamSlug,
+		&i.Attempts,
+		&i.NextRetryAt,
+		&i.Kind,
+	)
+	return &i, err
+}
+
+const setReconcilerErrorNextRetry = `-- name: SetReconcilerErrorNextRetry :exec
+UPDATE reconciler_errors
+SET next_retry_at = $3::TIMESTAMPTZ
+WHERE team_slug = $1 AND reconciler = $2
+`
+
+type SetReconcilerErrorNextRetryParams struct {
+	TeamSlug    slug.Slug
+	Reconciler  ReconcilerName
+	NextRetryAt time.Time
+}
+
+func (q *Queries) SetReconcilerErrorNextRetry(ctx context.Context, arg SetReconcilerErrorNextRetryParams) error {
+	_, err := q.db.Exec(ctx, setReconcilerErrorNextRetry, arg.TeamSlug, arg.Reconciler, arg.NextRetryAt)
+	return err
+}
+
+const setReconcilerSkippedForTeam = `-- name: SetReconcilerSkippedForTeam :exec
+INSERT INTO reconciler_errors (correlation_id, team_slug, reconciler, error_message, kind, attempts)
+VALUES ($1, $2, $3, $4, 'error', 0)
+ON CONFLICT(team_slug, reconciler) DO
+    UPDATE SET correlation_id = $1, created_at = NOW(), error_message = $4
+`
+
+type SetReconcilerSkippedForTeamParams struct {
+	CorrelationID uuid.UUID
+	TeamSlug      slug.Slug
+	Reconciler    ReconcilerName
+	ErrorMessage  string
 }
 
-func (q *Queries) SetReconcilerErrorForTeam(ctx context.Context, arg SetReconcilerErrorForTeamParams) error {
-	_, err := q.db.Exec(ctx, setReconcilerErrorForTeam,
+func (q *Queries) SetReconcilerSkippedForTeam(ctx context.Context, arg SetReconcilerSkippedForTeamParams) error {
+	_, err := q.db.Exec(ctx, setReconcilerSkippedForTeam,
 		arg.CorrelationID,
 		arg.TeamSlug,
 		arg.Reconciler,
@@ -30,7 +30,7 @@ func (q *Queries) GetReconcilerStateForTeam(ctx context.Context, arg GetReconcil
 }
 
 const getTeamsWithPermissionInGitHubRepo = `-- name: GetTeamsWithPermissionInGitHubRepo :many
-SELECT t.slug, t.purpose, t.last_successful_sync, t.slack_channel FROM teams t
+SELECT t.slug, t.purpose, t.last_successful_sync, t.slack_channel, t.parent_team_slug, t.enabled, t.resources_suspended FROM teams t
 JOIN reconciler_states rs ON rs.team_slug = t.slug
 WHERE
     rs.reconciler = 'github:team'
@@ -52,6 +52,9 @@ func (q *Queries) GetTeamsWithPermissionInGitHubRepo(ctx context.Context, state
 			&i.Purpose,
 			&i.LastSuccessfulSync,
 			&i.SlackChannel,
+			&i.ParentTeamSlug,
+			&i.Enabled,
+			&i.ResourcesSuspended,
 		); err != nil {
 			return nil, err
 		}
@@ -80,7 +80,7 @@ const disableReconciler = `-- name: DisableReconciler :one
 UPDATE reconcilers
 SET enabled = false
 WHERE name = $1
-RETURNING name, display_name, description, enabled, run_order
+RETURNING name, display_name, description, enabled, run_order, timeout_seconds
 `
 
 func (q *Queries) DisableReconciler(ctx context.Context, name ReconcilerName) (*Reconciler, error) {
@@ -92,6 +92,7 @@ func (q *Queries) DisableReconciler(ctx context.Context, name ReconcilerName) (*
 		&i.Description,
 		&i.Enabled,
 		&i.RunOrder,
+		&i.TimeoutSeconds,
 	)
 	return &i, err
 }
@@ -100,7 +101,7 @@ const enableReconciler = `-- name: EnableReconciler :one
 UPDATE reconcilers
 SET enabled = true
 WHERE name = $1
-RETURNING name, display_name, description, enabled, run_order
+RETURNING name, display_name, description, enabled, run_order, timeout_seconds
 `
 
 func (q *Queries) EnableReconciler(ctx context.Context, name ReconcilerName) (*Reconciler, error) {
@@ -112,12 +113,13 @@ func (q *Queries) EnableReconciler(ctx context.Context, name ReconcilerName) (*R
 		&i.Description,
 		&i.Enabled,
 		&i.RunOrder,
+		&i.TimeoutSeconds,
 	)
 	return &i, err
 }
 
 const getEnabledReconcilers = `-- name: GetEnabledReconcilers :many
-SELECT name, display_name, description, enabled, run_order FROM reconcilers
+SELECT name, display_name, description, enabled, run_order, timeout_seconds FROM reconcilers
 WHERE enabled = true
 ORDER BY run_order ASC
 `
@@ -137,6 +139,7 @@ func (q *Queries) GetEnabledReconcilers(ctx context.Context) ([]*Reconciler, err
 			&i.Description,
 			&i.Enabled,
 			&i.RunOrder,
+			&i.TimeoutSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -149,7 +152,7 @@ func (q *Queries) GetEnabledReconcilers(ctx context.Context) ([]*Reconciler, err
 }
 
 const getReconciler = `-- name: GetReconciler :one
-SELECT name, display_name, description, enabled, run_order FROM reconcilers
+SELECT name, display_name, description, enabled, run_order, timeout_seconds FROM reconcilers
 WHERE name = $1
 `
 
@@ -162,6 +165,7 @@ func (q *Queries) GetReconciler(ctx context.Context, name ReconcilerName) (*Reco
 		&i.Description,
 		&i.Enabled,
 		&i.RunOrder,
+		&i.TimeoutSeconds,
 	)
 	return &i, err
 }
@@ -220,7 +224,7 @@ func (q *Queries) GetReconcilerConfig(ctx context.Context, reconciler Reconciler
 }
 
 const getReconcilers = `-- name: GetReconcilers :many
-SELECT name, display_name, description, enabled, run_order FROM reconcilers
+SELECT name, display_name, description, enabled, run_order, timeout_seconds FROM reconcilers
 ORDER BY run_order ASC
 `
 
@@ -239,6 +243,7 @@ func (q *Queries) GetReconcilers(ctx context.Context) ([]*Reconciler, error) {
 			&i.Description,
 			&i.Enabled,
 			&i.RunOrder,
+			&i.TimeoutSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -276,3 +281,29 @@ func (q *Queries) ResetReconcilerConfig(ctx context.Context, reconciler Reconcil
 	_, err := q.db.Exec(ctx, resetReconcilerConfig, reconciler)
 	return err
 }
+
+const setReconcilerTimeout = `-- name: SetReconcilerTimeout :one
+UPDATE reconcilers
+SET timeout_seconds = $2
+WHERE name = $1
+RETURNING name, display_name, description, enabled, run_order, timeout_seconds
+`
+
+type SetReconcilerTimeoutParams struct {
+	Name           ReconcilerName
+	TimeoutSeconds *int32
+}
+
+func (q *Queries) SetReconcilerTimeout(ctx context.Context, arg SetReconcilerTimeoutParams) (*Reconciler, error) {
+	row := q.db.QueryRow(ctx, setReconcilerTimeout, arg.Name, arg.TimeoutSeconds)
+	var i Reconciler
+	err := row.Scan(
+		&i.Name,
+		&i.DisplayName,
+		&i.Description,
+		&i.Enabled,
+		&i.RunOrder,
+		&i.TimeoutSeconds,
+	)
+	return &i, err
+}
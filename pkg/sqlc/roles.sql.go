@@ -9,36 +9,70 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/slug"
 )
 
-const assignGlobalRoleToServiceAccount = `-- name: AssignGlobalRoleToServiceAccount :exec
-INSERT INTO service_account_roles (service_account_id, role_name)
+const addRoleAuthorization = `-- name: AddRoleAuthorization :exec
+INSERT INTO role_authorizations (role_name, authorization_name)
 VALUES ($1, $2) ON CONFLICT DO NOTHING
 `
 
+type AddRoleAuthorizationParams struct {
+	RoleName          roles.RoleName
+	AuthorizationName roles.Authorization
+}
+
+func (q *Queries) AddRoleAuthorization(ctx context.Context, arg AddRoleAuthorizationParams) error {
+	_, err := q.db.Exec(ctx, addRoleAuthorization, arg.RoleName, arg.AuthorizationName)
+	return err
+}
+
+const assignGlobalRoleToServiceAccount = `-- name: AssignGlobalRoleToServiceAccount :exec
+INSERT INTO service_account_roles (service_account_id, role_name, granted_by)
+VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
+`
+
 type AssignGlobalRoleToServiceAccountParams struct {
 	ServiceAccountID uuid.UUID
-	RoleName         RoleName
+	RoleName         roles.RoleName
+	GrantedBy        *string
 }
 
 func (q *Queries) AssignGlobalRoleToServiceAccount(ctx context.Context, arg AssignGlobalRoleToServiceAccountParams) error {
-	_, err := q.db.Exec(ctx, assignGlobalRoleToServiceAccount, arg.ServiceAccountID, arg.RoleName)
+	_, err := q.db.Exec(ctx, assignGlobalRoleToServiceAccount, arg.ServiceAccountID, arg.RoleName, arg.GrantedBy)
 	return err
 }
 
 const assignGlobalRoleToUser = `-- name: AssignGlobalRoleToUser :exec
-INSERT INTO user_roles (user_id, role_name)
-VALUES ($1, $2) ON CONFLICT DO NOTHING
+INSERT INTO user_roles (user_id, role_name, granted_by)
+VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
 `
 
 type AssignGlobalRoleToUserParams struct {
-	UserID   uuid.UUID
-	RoleName RoleName
+	UserID    uuid.UUID
+	RoleName  roles.RoleName
+	GrantedBy *string
 }
 
 func (q *Queries) AssignGlobalRoleToUser(ctx context.Context, arg AssignGlobalRoleToUserParams) error {
-	_, err := q.db.Exec(ctx, assignGlobalRoleToUser, arg.UserID, arg.RoleName)
+	_, err := q.db.Exec(ctx, assignGlobalRoleToUser, arg.UserID, arg.RoleName, arg.GrantedBy)
+	return err
+}
+
+const assignServiceAccountRoleToUser = `-- name: AssignServiceAccountRoleToUser :exec
+INSERT INTO user_roles (user_id, role_name, target_service_account_id)
+VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
+`
+
+type AssignServiceAccountRoleToUserParams struct {
+	UserID                 uuid.UUID
+	RoleName               roles.RoleName
+	TargetServiceAccountID *uuid.UUID
+}
+
+func (q *Queries) AssignServiceAccountRoleToUser(ctx context.Context, arg AssignServiceAccountRoleToUserParams) error {
+	_, err := q.db.Exec(ctx, assignServiceAccountRoleToUser, arg.UserID, arg.RoleName, arg.TargetServiceAccountID)
 	return err
 }
 
@@ -49,7 +83,7 @@ VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
 
 type AssignTeamRoleToServiceAccountParams struct {
 	ServiceAccountID uuid.UUID
-	RoleName         RoleName
+	RoleName         roles.RoleName
 	TargetTeamSlug   *slug.Slug
 }
 
@@ -65,7 +99,7 @@ VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
 
 type AssignTeamRoleToUserParams struct {
 	UserID         uuid.UUID
-	RoleName       RoleName
+	RoleName       roles.RoleName
 	TargetTeamSlug *slug.Slug
 }
 
@@ -74,8 +108,63 @@ func (q *Queries) AssignTeamRoleToUser(ctx context.Context, arg AssignTeamRoleTo
 	return err
 }
 
+const countGlobalAdmins = `-- name: CountGlobalAdmins :one
+SELECT ((
+    SELECT COUNT(*) FROM user_roles
+    WHERE user_roles.role_name = 'Admin'
+    AND user_roles.target_team_slug IS NULL
+    AND user_roles.target_service_account_id IS NULL
+) + (
+    SELECT COUNT(*) FROM service_account_roles
+    WHERE service_account_roles.role_name = 'Admin'
+    AND service_account_roles.target_team_slug IS NULL
+    AND service_account_roles.target_service_account_id IS NULL
+))::INT AS count
+`
+
+func (q *Queries) CountGlobalAdmins(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, countGlobalAdmins)
+	var count int32
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createRole = `-- name: CreateRole :one
+INSERT INTO roles (name, description)
+VALUES ($1, $2)
+RETURNING name, description, built_in, created_at
+`
+
+type CreateRoleParams struct {
+	Name        roles.RoleName
+	Description string
+}
+
+func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (*Role, error) {
+	row := q.db.QueryRow(ctx, createRole, arg.Name, arg.Description)
+	var i Role
+	err := row.Scan(
+		&i.Name,
+		&i.Description,
+		&i.BuiltIn,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const deleteRole = `-- name: DeleteRole :exec
+DELETE FROM roles
+WHERE name = $1
+AND NOT built_in
+`
+
+func (q *Queries) DeleteRole(ctx context.Context, name roles.RoleName) error {
+	_, err := q.db.Exec(ctx, deleteRole, name)
+	return err
+}
+
 const getAllUserRoles = `-- name: GetAllUserRoles :many
-SELECT id, role_name, user_id, target_team_slug, target_service_account_id FROM user_roles
+SELECT id, role_name, user_id, target_team_slug, target_service_account_id, granted_by, granted_at FROM user_roles
 `
 
 func (q *Queries) GetAllUserRoles(ctx context.Context) ([]*UserRole, error) {
@@ -93,6 +182,132 @@ func (q *Queries) GetAllUserRoles(ctx context.Context) ([]*UserRole, error) {
 			&i.UserID,
 			&i.TargetTeamSlug,
 			&i.TargetServiceAccountID,
+			&i.GrantedBy,
+			&i.GrantedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuthorizationNames = `-- name: GetAuthorizationNames :many
+SELECT DISTINCT authorization_name FROM role_authorizations
+ORDER BY authorization_name ASC
+`
+
+func (q *Queries) GetAuthorizationNames(ctx context.Context) ([]roles.Authorization, error) {
+	rows, err := q.db.Query(ctx, getAuthorizationNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []roles.Authorization
+	for rows.Next() {
+		var authorization_name roles.Authorization
+		if err := rows.Scan(&authorization_name); err != nil {
+			return nil, err
+		}
+		items = append(items, authorization_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuthorizationsForRoles = `-- name: GetAuthorizationsForRoles :many
+SELECT role_name, authorization_name FROM role_authorizations
+WHERE role_name = ANY($1::TEXT[])
+ORDER BY role_name ASC, authorization_name ASC
+`
+
+func (q *Queries) GetAuthorizationsForRoles(ctx context.Context, roleNames []string) ([]*RoleAuthorization, error) {
+	rows, err := q.db.Query(ctx, getAuthorizationsForRoles, roleNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*RoleAuthorization
+	for rows.Next() {
+		var i RoleAuthorization
+		if err := rows.Scan(&i.RoleName, &i.AuthorizationName); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoleAuthorizations = `-- name: GetRoleAuthorizations :many
+SELECT authorization_name FROM role_authorizations
+WHERE role_name = $1
+ORDER BY authorization_name ASC
+`
+
+func (q *Queries) GetRoleAuthorizations(ctx context.Context, roleName roles.RoleName) ([]roles.Authorization, error) {
+	rows, err := q.db.Query(ctx, getRoleAuthorizations, roleName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []roles.Authorization
+	for rows.Next() {
+		var authorization_name roles.Authorization
+		if err := rows.Scan(&authorization_name); err != nil {
+			return nil, err
+		}
+		items = append(items, authorization_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoleByName = `-- name: GetRoleByName :one
+SELECT name, description, built_in, created_at FROM roles
+WHERE name = $1
+`
+
+func (q *Queries) GetRoleByName(ctx context.Context, name roles.RoleName) (*Role, error) {
+	row := q.db.QueryRow(ctx, getRoleByName, name)
+	var i Role
+	err := row.Scan(
+		&i.Name,
+		&i.Description,
+		&i.BuiltIn,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const getRoles = `-- name: GetRoles :many
+SELECT name, description, built_in, created_at FROM roles
+ORDER BY name ASC
+`
+
+func (q *Queries) GetRoles(ctx context.Context) ([]*Role, error) {
+	rows, err := q.db.Query(ctx, getRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Role
+	for rows.Next() {
+		var i Role
+		if err := rows.Scan(
+			&i.Name,
+			&i.Description,
+			&i.BuiltIn,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -105,7 +320,7 @@ func (q *Queries) GetAllUserRoles(ctx context.Context) ([]*UserRole, error) {
 }
 
 const getUserRoles = `-- name: GetUserRoles :many
-SELECT id, role_name, user_id, target_team_slug, target_service_account_id FROM user_roles
+SELECT id, role_name, user_id, target_team_slug, target_service_account_id, granted_by, granted_at FROM user_roles
 WHERE user_id = $1
 `
 
@@ -124,6 +339,8 @@ func (q *Queries) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*UserRo
 			&i.UserID,
 			&i.TargetTeamSlug,
 			&i.TargetServiceAccountID,
+			&i.GrantedBy,
+			&i.GrantedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -143,7 +360,7 @@ AND user_roles.target_service_account_id IS NULL
 AND user_roles.role_name = $1
 `
 
-func (q *Queries) GetUsersWithGloballyAssignedRole(ctx context.Context, roleName RoleName) ([]*User, error) {
+func (q *Queries) GetUsersWithGloballyAssignedRole(ctx context.Context, roleName roles.RoleName) ([]*User, error) {
 	rows, err := q.db.Query(ctx, getUsersWithGloballyAssignedRole, roleName)
 	if err != nil {
 		return nil, err
@@ -168,6 +385,15 @@ func (q *Queries) GetUsersWithGloballyAssignedRole(ctx context.Context, roleName
 	return items, nil
 }
 
+const lockGlobalAdmins = `-- name: LockGlobalAdmins :exec
+SELECT pg_advisory_xact_lock($1::BIGINT)
+`
+
+func (q *Queries) LockGlobalAdmins(ctx context.Context, lockID int64) error {
+	_, err := q.db.Exec(ctx, lockGlobalAdmins, lockID)
+	return err
+}
+
 const removeAllServiceAccountRoles = `-- name: RemoveAllServiceAccountRoles :exec
 DELETE FROM service_account_roles
 WHERE service_account_id = $1
@@ -178,6 +404,34 @@ func (q *Queries) RemoveAllServiceAccountRoles(ctx context.Context, serviceAccou
 	return err
 }
 
+const removeRoleAuthorizations = `-- name: RemoveRoleAuthorizations :exec
+DELETE FROM role_authorizations
+WHERE role_name = $1
+`
+
+func (q *Queries) RemoveRoleAuthorizations(ctx context.Context, roleName roles.RoleName) error {
+	_, err := q.db.Exec(ctx, removeRoleAuthorizations, roleName)
+	return err
+}
+
+const revokeGlobalServiceAccountRole = `-- name: RevokeGlobalServiceAccountRole :exec
+DELETE FROM service_account_roles
+WHERE service_account_id = $1
+AND target_team_slug IS NULL
+AND target_service_account_id IS NULL
+AND role_name = $2
+`
+
+type RevokeGlobalServiceAccountRoleParams struct {
+	ServiceAccountID uuid.UUID
+	RoleName         roles.RoleName
+}
+
+func (q *Queries) RevokeGlobalServiceAccountRole(ctx context.Context, arg RevokeGlobalServiceAccountRoleParams) error {
+	_, err := q.db.Exec(ctx, revokeGlobalServiceAccountRole, arg.ServiceAccountID, arg.RoleName)
+	return err
+}
+
 const revokeGlobalUserRole = `-- name: RevokeGlobalUserRole :exec
 DELETE FROM user_roles
 WHERE user_id = $1
@@ -188,10 +442,35 @@ AND role_name = $2
 
 type RevokeGlobalUserRoleParams struct {
 	UserID   uuid.UUID
-	RoleName RoleName
+	RoleName roles.RoleName
 }
 
 func (q *Queries) RevokeGlobalUserRole(ctx context.Context, arg RevokeGlobalUserRoleParams) error {
 	_, err := q.db.Exec(ctx, revokeGlobalUserRole, arg.UserID, arg.RoleName)
 	return err
 }
+
+const updateRole = `-- name: UpdateRole :one
+UPDATE roles
+SET description = $2
+WHERE name = $1
+AND NOT built_in
+RETURNING name, description, built_in, created_at
+`
+
+type UpdateRoleParams struct {
+	Name        roles.RoleName
+	Description string
+}
+
+func (q *Queries) UpdateRole(ctx context.Context, arg UpdateRoleParams) (*Role, error) {
+	row := q.db.QueryRow(ctx, updateRole, arg.Name, arg.Description)
+	var i Role
+	err := row.Scan(
+		&i.Name,
+		&i.Description,
+		&i.BuiltIn,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
@@ -9,18 +9,24 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/slug"
 )
 
 const createServiceAccount = `-- name: CreateServiceAccount :one
-INSERT INTO service_accounts (name)
-VALUES ($1)
-RETURNING id, name
+INSERT INTO service_accounts (name, team_slug)
+VALUES ($1, $2)
+RETURNING id, name, team_slug
 `
 
-func (q *Queries) CreateServiceAccount(ctx context.Context, name string) (*ServiceAccount, error) {
-	row := q.db.QueryRow(ctx, createServiceAccount, name)
+type CreateServiceAccountParams struct {
+	Name     string
+	TeamSlug *slug.Slug
+}
+
+func (q *Queries) CreateServiceAccount(ctx context.Context, arg CreateServiceAccountParams) (*ServiceAccount, error) {
+	row := q.db.QueryRow(ctx, createServiceAccount, arg.Name, arg.TeamSlug)
 	var i ServiceAccount
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.TeamSlug)
 	return &i, err
 }
 
@@ -34,33 +40,32 @@ func (q *Queries) DeleteServiceAccount(ctx context.Context, id uuid.UUID) error
 	return err
 }
 
-const getServiceAccountByApiKey = `-- name: GetServiceAccountByApiKey :one
-SELECT service_accounts.id, service_accounts.name FROM api_keys
-JOIN service_accounts ON service_accounts.id = api_keys.service_account_id
-WHERE api_keys.api_key = $1
+const getServiceAccountByID = `-- name: GetServiceAccountByID :one
+SELECT id, name, team_slug FROM service_accounts
+WHERE id = $1
 `
 
-func (q *Queries) GetServiceAccountByApiKey(ctx context.Context, apiKey string) (*ServiceAccount, error) {
-	row := q.db.QueryRow(ctx, getServiceAccountByApiKey, apiKey)
+func (q *Queries) GetServiceAccountByID(ctx context.Context, id uuid.UUID) (*ServiceAccount, error) {
+	row := q.db.QueryRow(ctx, getServiceAccountByID, id)
 	var i ServiceAccount
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.TeamSlug)
 	return &i, err
 }
 
 const getServiceAccountByName = `-- name: GetServiceAccountByName :one
-SELECT id, name FROM service_accounts
+SELECT id, name, team_slug FROM service_accounts
 WHERE name = $1
 `
 
 func (q *Queries) GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error) {
 	row := q.db.QueryRow(ctx, getServiceAccountByName, name)
 	var i ServiceAccount
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.TeamSlug)
 	return &i, err
 }
 
 const getServiceAccountRoles = `-- name: GetServiceAccountRoles :many
-SELECT id, role_name, service_account_id, target_team_slug, target_service_account_id FROM service_account_roles
+SELECT id, role_name, service_account_id, target_team_slug, target_service_account_id, granted_by, granted_at FROM service_account_roles
 WHERE service_account_id = $1
 `
 
@@ -79,6 +84,8 @@ func (q *Queries) GetServiceAccountRoles(ctx context.Context, serviceAccountID u
 			&i.ServiceAccountID,
 			&i.TargetTeamSlug,
 			&i.TargetServiceAccountID,
+			&i.GrantedBy,
+			&i.GrantedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -91,7 +98,7 @@ func (q *Queries) GetServiceAccountRoles(ctx context.Context, serviceAccountID u
 }
 
 const getServiceAccounts = `-- name: GetServiceAccounts :many
-SELECT id, name FROM service_accounts
+SELECT id, name, team_slug FROM service_accounts
 ORDER BY name ASC
 `
 
@@ -104,7 +111,33 @@ func (q *Queries) GetServiceAccounts(ctx context.Context) ([]*ServiceAccount, er
 	var items []*ServiceAccount
 	for rows.Next() {
 		var i ServiceAccount
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.TeamSlug); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTeamServiceAccounts = `-- name: GetTeamServiceAccounts :many
+SELECT id, name, team_slug FROM service_accounts
+WHERE team_slug = $1
+ORDER BY name ASC
+`
+
+func (q *Queries) GetTeamServiceAccounts(ctx context.Context, teamSlug *slug.Slug) ([]*ServiceAccount, error) {
+	rows, err := q.db.Query(ctx, getTeamServiceAccounts, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ServiceAccount
+	for rows.Next() {
+		var i ServiceAccount
+		if err := rows.Scan(&i.ID, &i.Name, &i.TeamSlug); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -114,3 +147,22 @@ func (q *Queries) GetServiceAccounts(ctx context.Context) ([]*ServiceAccount, er
 	}
 	return items, nil
 }
+
+const updateServiceAccount = `-- name: UpdateServiceAccount :one
+UPDATE service_accounts
+SET name = $2
+WHERE id = $1
+RETURNING id, name, team_slug
+`
+
+type UpdateServiceAccountParams struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) UpdateServiceAccount(ctx context.Context, arg UpdateServiceAccountParams) (*ServiceAccount, error) {
+	row := q.db.QueryRow(ctx, updateServiceAccount, arg.ID, arg.Name)
+	var i ServiceAccount
+	err := row.Scan(&i.ID, &i.Name, &i.TeamSlug)
+	return &i, err
+}
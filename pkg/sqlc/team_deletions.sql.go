@@ -0,0 +1,189 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.20.0
+// source: team_deletions.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/slug"
+)
+
+const createTeamDeletion = `-- name: CreateTeamDeletion :one
+INSERT INTO team_deletions (team_slug, correlation_id, delete_at)
+VALUES ($1, $2, $3)
+RETURNING team_slug, correlation_id, created_at, delete_at, started_at
+`
+
+type CreateTeamDeletionParams struct {
+	TeamSlug      slug.Slug
+	CorrelationID uuid.UUID
+	DeleteAt      time.Time
+}
+
+func (q *Queries) CreateTeamDeletion(ctx context.Context, arg CreateTeamDeletionParams) (*TeamDeletion, error) {
+	row := q.db.QueryRow(ctx, createTeamDeletion, arg.TeamSlug, arg.CorrelationID, arg.DeleteAt)
+	var i TeamDeletion
+	err := row.Scan(
+		&i.TeamSlug,
+		&i.CorrelationID,
+		&i.CreatedAt,
+		&i.DeleteAt,
+		&i.StartedAt,
+	)
+	return &i, err
+}
+
+const deleteTeamDeletion = `-- name: DeleteTeamDeletion :execrows
+DELETE FROM team_deletions
+WHERE team_slug = $1 AND started_at IS NULL
+`
+
+func (q *Queries) DeleteTeamDeletion(ctx context.Context, teamSlug slug.Slug) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTeamDeletion, teamSlug)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTeamDeletion = `-- name: GetTeamDeletion :one
+SELECT team_slug, correlation_id, created_at, delete_at, started_at FROM team_deletions
+WHERE team_slug = $1
+`
+
+func (q *Queries) GetTeamDeletion(ctx context.Context, teamSlug slug.Slug) (*TeamDeletion, error) {
+	row := q.db.QueryRow(ctx, getTeamDeletion, teamSlug)
+	var i TeamDeletion
+	err := row.Scan(
+		&i.TeamSlug,
+		&i.CorrelationID,
+		&i.CreatedAt,
+		&i.DeleteAt,
+		&i.StartedAt,
+	)
+	return &i, err
+}
+
+const getTeamDeletionReconcilers = `-- name: GetTeamDeletionReconcilers :many
+SELECT team_slug, reconciler, status, finished_at, error_message FROM team_deletion_reconcilers
+WHERE team_slug = $1
+ORDER BY finished_at ASC, reconciler ASC
+`
+
+func (q *Queries) GetTeamDeletionReconcilers(ctx context.Context, teamSlug slug.Slug) ([]*TeamDeletionReconciler, error) {
+	rows, err := q.db.Query(ctx, getTeamDeletionReconcilers, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*TeamDeletionReconciler
+	for rows.Next() {
+		var i TeamDeletionReconciler
+		if err := rows.Scan(
+			&i.TeamSlug,
+			&i.Reconciler,
+			&i.Status,
+			&i.FinishedAt,
+			&i.ErrorMessage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseStaleTeamDeletions = `-- name: ReleaseStaleTeamDeletions :execrows
+UPDATE team_deletions
+SET started_at = NULL
+WHERE started_at IS NOT NULL AND started_at < $1::TIMESTAMPTZ
+`
+
+func (q *Queries) ReleaseStaleTeamDeletions(ctx context.Context, startedBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseStaleTeamDeletions, startedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetTeamDeletion = `-- name: ResetTeamDeletion :exec
+UPDATE team_deletions
+SET started_at = NULL, delete_at = $2
+WHERE team_slug = $1
+`
+
+type ResetTeamDeletionParams struct {
+	TeamSlug slug.Slug
+	DeleteAt time.Time
+}
+
+func (q *Queries) ResetTeamDeletion(ctx context.Context, arg ResetTeamDeletionParams) error {
+	_, err := q.db.Exec(ctx, resetTeamDeletion, arg.TeamSlug, arg.DeleteAt)
+	return err
+}
+
+const setTeamDeletionReconcilerResult = `-- name: SetTeamDeletionReconcilerResult :exec
+INSERT INTO team_deletion_reconcilers (team_slug, reconciler, status, error_message)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (team_slug, reconciler) DO UPDATE
+SET status = EXCLUDED.status, error_message = EXCLUDED.error_message, finished_at = NOW()
+`
+
+type SetTeamDeletionReconcilerResultParams struct {
+	TeamSlug     slug.Slug
+	Reconciler   ReconcilerName
+	Status       TeamDeletionReconcilerStatus
+	ErrorMessage *string
+}
+
+func (q *Queries) SetTeamDeletionReconcilerResult(ctx context.Context, arg SetTeamDeletionReconcilerResultParams) error {
+	_, err := q.db.Exec(ctx, setTeamDeletionReconcilerResult,
+		arg.TeamSlug,
+		arg.Reconciler,
+		arg.Status,
+		arg.ErrorMessage,
+	)
+	return err
+}
+
+const startDueTeamDeletions = `-- name: StartDueTeamDeletions :many
+UPDATE team_deletions
+SET started_at = NOW()
+WHERE delete_at <= NOW() AND started_at IS NULL
+RETURNING team_slug, correlation_id, created_at, delete_at, started_at
+`
+
+func (q *Queries) StartDueTeamDeletions(ctx context.Context) ([]*TeamDeletion, error) {
+	rows, err := q.db.Query(ctx, startDueTeamDeletions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*TeamDeletion
+	for rows.Next() {
+		var i TeamDeletion
+		if err := rows.Scan(
+			&i.TeamSlug,
+			&i.CorrelationID,
+			&i.CreatedAt,
+			&i.DeleteAt,
+			&i.StartedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,136 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.20.0
+// source: team_sync_queue.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/slug"
+)
+
+const claimTeamSync = `-- name: ClaimTeamSync :one
+UPDATE team_sync_queue
+SET locked_by = $1::TEXT, locked_at = NOW(), attempts = attempts + 1
+WHERE id = (
+    SELECT q.id FROM team_sync_queue AS q
+    WHERE
+        q.locked_by IS NULL
+        AND q.next_run_at <= NOW()
+        AND NOT EXISTS (
+            SELECT l.id FROM team_sync_queue AS l
+            WHERE l.team_slug = q.team_slug AND l.locked_by IS NOT NULL
+        )
+    ORDER BY q.next_run_at ASC, q.id ASC
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, team_slug, correlation_id, attempts, created_at, next_run_at, locked_by, locked_at, reconcilers, triggered_by
+`
+
+func (q *Queries) ClaimTeamSync(ctx context.Context, lockedBy string) (*TeamSyncQueue, error) {
+	row := q.db.QueryRow(ctx, claimTeamSync, lockedBy)
+	var i TeamSyncQueue
+	err := row.Scan(
+		&i.ID,
+		&i.TeamSlug,
+		&i.CorrelationID,
+		&i.Attempts,
+		&i.CreatedAt,
+		&i.NextRunAt,
+		&i.LockedBy,
+		&i.LockedAt,
+		&i.Reconcilers,
+		&i.TriggeredBy,
+	)
+	return &i, err
+}
+
+const deleteTeamSync = `-- name: DeleteTeamSync :exec
+DELETE FROM team_sync_queue
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTeamSync(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteTeamSync, id)
+	return err
+}
+
+const enqueueTeamSync = `-- name: EnqueueTeamSync :exec
+INSERT INTO team_sync_queue (team_slug, correlation_id, triggered_by, reconcilers, next_run_at)
+VALUES ($1, $2, $3, $4::TEXT[], $5::TIMESTAMPTZ)
+`
+
+type EnqueueTeamSyncParams struct {
+	TeamSlug      slug.Slug
+	CorrelationID uuid.UUID
+	TriggeredBy   TeamSyncTrigger
+	Reconcilers   []string
+	NextRunAt     time.Time
+}
+
+func (q *Queries) EnqueueTeamSync(ctx context.Context, arg EnqueueTeamSyncParams) error {
+	_, err := q.db.Exec(ctx, enqueueTeamSync,
+		arg.TeamSlug,
+		arg.CorrelationID,
+		arg.TriggeredBy,
+		arg.Reconcilers,
+		arg.NextRunAt,
+	)
+	return err
+}
+
+const getPendingTeamSyncCount = `-- name: GetPendingTeamSyncCount :one
+SELECT COUNT(*) FROM team_sync_queue
+WHERE locked_by IS NULL
+`
+
+func (q *Queries) GetPendingTeamSyncCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getPendingTeamSyncCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const notify = `-- name: Notify :exec
+SELECT pg_notify($1::TEXT, $2::TEXT)
+`
+
+type NotifyParams struct {
+	Channel string
+	Payload string
+}
+
+func (q *Queries) Notify(ctx context.Context, arg NotifyParams) error {
+	_, err := q.db.Exec(ctx, notify, arg.Channel, arg.Payload)
+	return err
+}
+
+const releaseStaleTeamSyncs = `-- name: ReleaseStaleTeamSyncs :execrows
+UPDATE team_sync_queue
+SET locked_by = NULL, locked_at = NULL
+WHERE locked_by IS NOT NULL AND locked_at < $1::TIMESTAMPTZ
+`
+
+func (q *Queries) ReleaseStaleTeamSyncs(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseStaleTeamSyncs, lockedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const releaseTeamSync = `-- name: ReleaseTeamSync :exec
+UPDATE team_sync_queue
+SET locked_by = NULL, locked_at = NULL
+WHERE id = $1
+`
+
+func (q *Queries) ReleaseTeamSync(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, releaseTeamSync, id)
+	return err
+}
@@ -0,0 +1,218 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.20.0
+// source: team_sync_runs.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/slug"
+)
+
+const createTeamSyncRun = `-- name: CreateTeamSyncRun :one
+INSERT INTO team_sync_runs (team_slug, correlation_id, triggered_by, reconcilers)
+VALUES ($1, $2, $3, $4::TEXT[])
+RETURNING id, team_slug, correlation_id, triggered_by, reconcilers, started_at, finished_at, status, error_message
+`
+
+type CreateTeamSyncRunParams struct {
+	TeamSlug      slug.Slug
+	CorrelationID uuid.UUID
+	TriggeredBy   TeamSyncTrigger
+	Reconcilers   []string
+}
+
+func (q *Queries) CreateTeamSyncRun(ctx context.Context, arg CreateTeamSyncRunParams) (*TeamSyncRun, error) {
+	row := q.db.QueryRow(ctx, createTeamSyncRun,
+		arg.TeamSlug,
+		arg.CorrelationID,
+		arg.TriggeredBy,
+		arg.Reconcilers,
+	)
+	var i TeamSyncRun
+	err := row.Scan(
+		&i.ID,
+		&i.TeamSlug,
+		&i.CorrelationID,
+		&i.TriggeredBy,
+		&i.Reconcilers,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Status,
+		&i.ErrorMessage,
+	)
+	return &i, err
+}
+
+const createTeamSyncRunReconciler = `-- name: CreateTeamSyncRunReconciler :exec
+INSERT INTO team_sync_run_reconcilers (run_id, reconciler, status, started_at, finished_at, error_message)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateTeamSyncRunReconcilerParams struct {
+	RunID        int64
+	Reconciler   ReconcilerName
+	Status       TeamSyncReconcilerStatus
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	ErrorMessage *string
+}
+
+func (q *Queries) CreateTeamSyncRunReconciler(ctx context.Context, arg CreateTeamSyncRunReconcilerParams) error {
+	_, err := q.db.Exec(ctx, createTeamSyncRunReconciler,
+		arg.RunID,
+		arg.Reconciler,
+		arg.Status,
+		arg.StartedAt,
+		arg.FinishedAt,
+		arg.ErrorMessage,
+	)
+	return err
+}
+
+const deleteOldTeamSyncRuns = `-- name: DeleteOldTeamSyncRuns :execrows
+DELETE FROM team_sync_runs AS r
+WHERE r.team_slug = $1 AND r.id NOT IN (
+    SELECT k.id FROM team_sync_runs AS k
+    WHERE k.team_slug = $1
+    ORDER BY k.started_at DESC, k.id DESC
+    LIMIT $2
+)
+`
+
+type DeleteOldTeamSyncRunsParams struct {
+	TeamSlug   slug.Slug
+	RunsToKeep int32
+}
+
+func (q *Queries) DeleteOldTeamSyncRuns(ctx context.Context, arg DeleteOldTeamSyncRunsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOldTeamSyncRuns, arg.TeamSlug, arg.RunsToKeep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const finishTeamSyncRun = `-- name: FinishTeamSyncRun :one
+UPDATE team_sync_runs
+SET
+    finished_at = NOW(),
+    status = $2,
+    error_message = $3
+WHERE id = $1
+RETURNING id, team_slug, correlation_id, triggered_by, reconcilers, started_at, finished_at, status, error_message
+`
+
+type FinishTeamSyncRunParams struct {
+	ID           int64
+	Status       TeamSyncRunStatus
+	ErrorMessage *string
+}
+
+func (q *Queries) FinishTeamSyncRun(ctx context.Context, arg FinishTeamSyncRunParams) (*TeamSyncRun, error) {
+	row := q.db.QueryRow(ctx, finishTeamSyncRun, arg.ID, arg.Status, arg.ErrorMessage)
+	var i TeamSyncRun
+	err := row.Scan(
+		&i.ID,
+		&i.TeamSlug,
+		&i.CorrelationID,
+		&i.TriggeredBy,
+		&i.Reconcilers,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Status,
+		&i.ErrorMessage,
+	)
+	return &i, err
+}
+
+const getTeamSyncRunReconcilers = `-- name: GetTeamSyncRunReconcilers :many
+SELECT run_id, reconciler, status, started_at, finished_at, error_message FROM team_sync_run_reconcilers
+WHERE run_id = $1
+ORDER BY started_at ASC, reconciler ASC
+`
+
+func (q *Queries) GetTeamSyncRunReconcilers(ctx context.Context, runID int64) ([]*TeamSyncRunReconciler, error) {
+	rows, err := q.db.Query(ctx, getTeamSyncRunReconcilers, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*TeamSyncRunReconciler
+	for rows.Next() {
+		var i TeamSyncRunReconciler
+		if err := rows.Scan(
+			&i.RunID,
+			&i.Reconciler,
+			&i.Status,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.ErrorMessage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTeamSyncRuns = `-- name: GetTeamSyncRuns :many
+SELECT id, team_slug, correlation_id, triggered_by, reconcilers, started_at, finished_at, status, error_message FROM team_sync_runs
+WHERE
+    team_slug = $1
+    AND (
+        $2::TIMESTAMPTZ IS NULL
+        OR (started_at, id) < ($2::TIMESTAMPTZ, $3::BIGINT)
+    )
+ORDER BY started_at DESC, id DESC
+LIMIT $4
+`
+
+type GetTeamSyncRunsParams struct {
+	TeamSlug        slug.Slug
+	CursorStartedAt *time.Time
+	CursorID        *int64
+	LimitRows       int32
+}
+
+func (q *Queries) GetTeamSyncRuns(ctx context.Context, arg GetTeamSyncRunsParams) ([]*TeamSyncRun, error) {
+	rows, err := q.db.Query(ctx, getTeamSyncRuns,
+		arg.TeamSlug,
+		arg.CursorStartedAt,
+		arg.CursorID,
+		arg.LimitRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*TeamSyncRun
+	for rows.Next() {
+		var i TeamSyncRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamSlug,
+			&i.CorrelationID,
+			&i.TriggeredBy,
+			&i.Reconcilers,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.Status,
+			&i.ErrorMessage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -26,7 +26,7 @@ func (q *Queries) ConfirmTeamDeleteKey(ctx context.Context, key uuid.UUID) error
 const createTeam = `-- name: CreateTeam :one
 INSERT INTO teams (slug, purpose, slack_channel)
 VALUES ($1, $2, $3)
-RETURNING slug, purpose, last_successful_sync, slack_channel
+RETURNING slug, purpose, last_successful_sync, slack_channel, parent_team_slug, enabled, resources_suspended
 `
 
 type CreateTeamParams struct {
@@ -43,6 +43,9 @@ func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (*Team,
 		&i.Purpose,
 		&i.LastSuccessfulSync,
 		&i.SlackChannel,
+		&i.ParentTeamSlug,
+		&i.Enabled,
+		&i.ResourcesSuspended,
 	)
 	return &i, err
 }
@@ -81,8 +84,67 @@ func (q *Queries) DeleteTeam(ctx context.Context, argSlug slug.Slug) error {
 	return err
 }
 
+const deleteTeamDeleteKeys = `-- name: DeleteTeamDeleteKeys :exec
+DELETE FROM team_delete_keys
+WHERE team_slug = $1
+`
+
+func (q *Queries) DeleteTeamDeleteKeys(ctx context.Context, teamSlug slug.Slug) error {
+	_, err := q.db.Exec(ctx, deleteTeamDeleteKeys, teamSlug)
+	return err
+}
+
+const disableTeam = `-- name: DisableTeam :one
+UPDATE teams
+SET enabled = false, resources_suspended = $1
+WHERE slug = $2
+RETURNING slug, purpose, last_successful_sync, slack_channel, parent_team_slug, enabled, resources_suspended
+`
+
+type DisableTeamParams struct {
+	ResourcesSuspended bool
+	Slug               slug.Slug
+}
+
+func (q *Queries) DisableTeam(ctx context.Context, arg DisableTeamParams) (*Team, error) {
+	row := q.db.QueryRow(ctx, disableTeam, arg.ResourcesSuspended, arg.Slug)
+	var i Team
+	err := row.Scan(
+		&i.Slug,
+		&i.Purpose,
+		&i.LastSuccessfulSync,
+		&i.SlackChannel,
+		&i.ParentTeamSlug,
+		&i.Enabled,
+		&i.ResourcesSuspended,
+	)
+	return &i, err
+}
+
+const enableTeam = `-- name: EnableTeam :one
+UPDATE teams
+SET enabled = true, resources_suspended = false
+WHERE slug = $1
+RETURNING slug, purpose, last_successful_sync, slack_channel, parent_team_slug, enabled, resources_suspended
+`
+
+func (q *Queries) EnableTeam(ctx context.Context, argSlug slug.Slug) (*Team, error) {
+	row := q.db.QueryRow(ctx, enableTeam, argSlug)
+	var i Team
+	err := row.Scan(
+		&i.Slug,
+		&i.Purpose,
+		&i.LastSuccessfulSync,
+		&i.SlackChannel,
+		&i.ParentTeamSlug,
+		&i.Enabled,
+		&i.ResourcesSuspended,
+	)
+	return &i, err
+}
+
 const getActiveTeamBySlug = `-- name: GetActiveTeamBySlug :one
-SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel FROM teams
+SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel, teams.parent_team_slug, teams.enabled, teams.resources_suspended FROM teams
 WHERE
     teams.slug = $1
     AND NOT EXISTS (
@@ -102,12 +164,15 @@ func (q *Queries) GetActiveTeamBySlug(ctx context.Context, argSlug slug.Slug) (*
 		&i.Purpose,
 		&i.LastSuccessfulSync,
 		&i.SlackChannel,
+		&i.ParentTeamSlug,
+		&i.Enabled,
+		&i.ResourcesSuspended,
 	)
 	return &i, err
 }
 
 const getActiveTeams = `-- name: GetActiveTeams :many
-SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel FROM teams
+SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel, teams.parent_team_slug, teams.enabled, teams.resources_suspended FROM teams
 WHERE NOT EXISTS (
     SELECT team_delete_keys.team_slug
     FROM team_delete_keys
@@ -132,6 +197,9 @@ func (q *Queries) GetActiveTeams(ctx context.Context) ([]*Team, error) {
 			&i.Purpose,
 			&i.LastSuccessfulSync,
 			&i.SlackChannel,
+			&i.ParentTeamSlug,
+			&i.Enabled,
+			&i.ResourcesSuspended,
 		); err != nil {
 			return nil, err
 		}
@@ -143,6 +211,44 @@ func (q *Queries) GetActiveTeams(ctx context.Context) ([]*Team, error) {
 	return items, nil
 }
 
+const getDescendantSlugsForTeams = `-- name: GetDescendantSlugsForTeams :many
+WITH RECURSIVE descendants AS (
+    SELECT teams.slug AS ancestor_slug, teams.slug FROM teams
+    WHERE teams.slug = ANY($1::TEXT[])
+    UNION
+    SELECT descendants.ancestor_slug, teams.slug FROM teams
+    JOIN descendants ON teams.parent_team_slug = descendants.slug
+)
+SELECT descendants.ancestor_slug::TEXT AS ancestor_slug, descendants.slug::TEXT AS slug FROM descendants
+WHERE descendants.slug <> descendants.ancestor_slug
+ORDER BY descendants.ancestor_slug ASC, descendants.slug ASC
+`
+
+type GetDescendantSlugsForTeamsRow struct {
+	AncestorSlug string
+	Slug         string
+}
+
+func (q *Queries) GetDescendantSlugsForTeams(ctx context.Context, slugs []string) ([]*GetDescendantSlugsForTeamsRow, error) {
+	rows, err := q.db.Query(ctx, getDescendantSlugsForTeams, slugs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*GetDescendantSlugsForTeamsRow
+	for rows.Next() {
+		var i GetDescendantSlugsForTeamsRow
+		if err := rows.Scan(&i.AncestorSlug, &i.Slug); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSlackAlertsChannels = `-- name: GetSlackAlertsChannels :many
 SELECT team_slug, environment, channel_name FROM slack_alerts_channels
 WHERE team_slug = $1
@@ -170,7 +276,7 @@ func (q *Queries) GetSlackAlertsChannels(ctx context.Context, teamSlug slug.Slug
 }
 
 const getTeamBySlug = `-- name: GetTeamBySlug :one
-SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel FROM teams
+SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel, teams.parent_team_slug, teams.enabled, teams.resources_suspended FROM teams
 WHERE teams.slug = $1
 `
 
@@ -182,10 +288,47 @@ func (q *Queries) GetTeamBySlug(ctx context.Context, argSlug slug.Slug) (*Team,
 		&i.Purpose,
 		&i.LastSuccessfulSync,
 		&i.SlackChannel,
+		&i.ParentTeamSlug,
+		&i.Enabled,
+		&i.ResourcesSuspended,
 	)
 	return &i, err
 }
 
+const getTeamChildren = `-- name: GetTeamChildren :many
+SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel, teams.parent_team_slug, teams.enabled, teams.resources_suspended FROM teams
+WHERE teams.parent_team_slug = $1
+ORDER BY teams.slug ASC
+`
+
+func (q *Queries) GetTeamChildren(ctx context.Context, parentTeamSlug *slug.Slug) ([]*Team, error) {
+	rows, err := q.db.Query(ctx, getTeamChildren, parentTeamSlug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Team
+	for rows.Next() {
+		var i Team
+		if err := rows.Scan(
+			&i.Slug,
+			&i.Purpose,
+			&i.LastSuccessfulSync,
+			&i.SlackChannel,
+			&i.ParentTeamSlug,
+			&i.Enabled,
+			&i.ResourcesSuspended,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeamDeleteKey = `-- name: GetTeamDeleteKey :one
 SELECT key, team_slug, created_at, created_by, confirmed_at FROM team_delete_keys
 WHERE key = $1
@@ -204,6 +347,39 @@ func (q *Queries) GetTeamDeleteKey(ctx context.Context, key uuid.UUID) (*TeamDel
 	return &i, err
 }
 
+const getTeamDescendantSlugs = `-- name: GetTeamDescendantSlugs :many
+WITH RECURSIVE descendants AS (
+    SELECT teams.slug FROM teams
+    WHERE teams.slug = $1
+    UNION
+    SELECT teams.slug FROM teams
+    JOIN descendants ON teams.parent_team_slug = descendants.slug
+)
+SELECT descendants.slug::TEXT FROM descendants
+WHERE descendants.slug::TEXT <> $1::TEXT
+ORDER BY descendants.slug ASC
+`
+
+func (q *Queries) GetTeamDescendantSlugs(ctx context.Context, argSlug string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getTeamDescendantSlugs, argSlug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var descendants_slug string
+		if err := rows.Scan(&descendants_slug); err != nil {
+			return nil, err
+		}
+		items = append(items, descendants_slug)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeamMember = `-- name: GetTeamMember :one
 SELECT users.id, users.email, users.name, users.external_id FROM user_roles
 JOIN teams ON teams.slug = user_roles.target_team_slug
@@ -352,7 +528,7 @@ func (q *Queries) GetTeamMembersForReconciler(ctx context.Context, arg GetTeamMe
 }
 
 const getTeams = `-- name: GetTeams :many
-SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel FROM teams
+SELECT teams.slug, teams.purpose, teams.last_successful_sync, teams.slack_channel, teams.parent_team_slug, teams.enabled, teams.resources_suspended FROM teams
 ORDER BY teams.slug ASC
 `
 
@@ -370,6 +546,9 @@ func (q *Queries) GetTeams(ctx context.Context) ([]*Team, error) {
 			&i.Purpose,
 			&i.LastSuccessfulSync,
 			&i.SlackChannel,
+			&i.ParentTeamSlug,
+			&i.Enabled,
+			&i.ResourcesSuspended,
 		); err != nil {
 			return nil, err
 		}
@@ -381,6 +560,30 @@ func (q *Queries) GetTeams(ctx context.Context) ([]*Team, error) {
 	return items, nil
 }
 
+const lockTeamAndParentAncestors = `-- name: LockTeamAndParentAncestors :exec
+WITH RECURSIVE ancestors AS (
+    SELECT teams.slug, teams.parent_team_slug FROM teams
+    WHERE teams.slug = $2
+    UNION
+    SELECT teams.slug, teams.parent_team_slug FROM teams
+    JOIN ancestors ON teams.slug = ancestors.parent_team_slug
+)
+SELECT teams.slug FROM teams
+WHERE teams.slug = $1 OR teams.slug IN (SELECT ancestors.slug FROM ancestors)
+ORDER BY teams.slug ASC
+FOR UPDATE
+`
+
+type LockTeamAndParentAncestorsParams struct {
+	Slug       slug.Slug
+	ParentSlug slug.Slug
+}
+
+func (q *Queries) LockTeamAndParentAncestors(ctx context.Context, arg LockTeamAndParentAncestorsParams) error {
+	_, err := q.db.Exec(ctx, lockTeamAndParentAncestors, arg.Slug, arg.ParentSlug)
+	return err
+}
+
 const removeSlackAlertsChannel = `-- name: RemoveSlackAlertsChannel :exec
 DELETE FROM slack_alerts_channels
 WHERE team_slug = $1 AND environment = $2
@@ -439,12 +642,39 @@ func (q *Queries) SetSlackAlertsChannel(ctx context.Context, arg SetSlackAlertsC
 	return err
 }
 
+const setTeamParent = `-- name: SetTeamParent :one
+UPDATE teams
+SET parent_team_slug = $1
+WHERE slug = $2
+RETURNING slug, purpose, last_successful_sync, slack_channel, parent_team_slug, enabled, resources_suspended
+`
+
+type SetTeamParentParams struct {
+	ParentTeamSlug *slug.Slug
+	Slug           slug.Slug
+}
+
+func (q *Queries) SetTeamParent(ctx context.Context, arg SetTeamParentParams) (*Team, error) {
+	row := q.db.QueryRow(ctx, setTeamParent, arg.ParentTeamSlug, arg.Slug)
+	var i Team
+	err := row.Scan(
+		&i.Slug,
+		&i.Purpose,
+		&i.LastSuccessfulSync,
+		&i.SlackChannel,
+		&i.ParentTeamSlug,
+		&i.Enabled,
+		&i.ResourcesSuspended,
+	)
+	return &i, err
+}
+
 const updateTeam = `-- name: UpdateTeam :one
 UPDATE teams
 SET purpose = COALESCE($1, purpose),
     slack_channel = COALESCE($2, slack_channel)
 WHERE slug = $3
-RETURNING slug, purpose, last_successful_sync, slack_channel
+RETURNING slug, purpose, last_successful_sync, slack_channel, parent_team_slug, enabled, resources_suspended
 `
 
 type UpdateTeamParams struct {
@@ -461,6 +691,9 @@ func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) (*Team,
 		&i.Purpose,
 		&i.LastSuccessfulSync,
 		&i.SlackChannel,
+		&i.ParentTeamSlug,
+		&i.Enabled,
+		&i.ResourcesSuspended,
 	)
 	return &i, err
 }
@@ -0,0 +1,139 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.20.0
+// source: user_sync_runs.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createUserSyncRun = `-- name: CreateUserSyncRun :one
+INSERT INTO user_sync_runs (correlation_id)
+VALUES ($1)
+RETURNING id, correlation_id, started_at, finished_at, status, error_message, created_users, updated_users, deleted_users
+`
+
+func (q *Queries) CreateUserSyncRun(ctx context.Context, correlationID uuid.UUID) (*UserSyncRun, error) {
+	row := q.db.QueryRow(ctx, createUserSyncRun, correlationID)
+	var i UserSyncRun
+	err := row.Scan(
+		&i.ID,
+		&i.CorrelationID,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.CreatedUsers,
+		&i.UpdatedUsers,
+		&i.DeletedUsers,
+	)
+	return &i, err
+}
+
+const deleteOldUserSyncRuns = `-- name: DeleteOldUserSyncRuns :execrows
+DELETE FROM user_sync_runs
+WHERE id NOT IN (
+    SELECT id FROM user_sync_runs
+    ORDER BY started_at DESC, id DESC
+    LIMIT $1
+)
+`
+
+func (q *Queries) DeleteOldUserSyncRuns(ctx context.Context, runsToKeep int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOldUserSyncRuns, runsToKeep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const finishUserSyncRun = `-- name: FinishUserSyncRun :one
+UPDATE user_sync_runs
+SET
+    finished_at = NOW(),
+    status = $2,
+    error_message = $3,
+    created_users = $4,
+    updated_users = $5,
+    deleted_users = $6
+WHERE id = $1
+RETURNING id, correlation_id, started_at, finished_at, status, error_message, created_users, updated_users, deleted_users
+`
+
+type FinishUserSyncRunParams struct {
+	ID           int64
+	Status       UserSyncRunStatus
+	ErrorMessage *string
+	CreatedUsers int32
+	UpdatedUsers int32
+	DeletedUsers int32
+}
+
+func (q *Queries) FinishUserSyncRun(ctx context.Context, arg FinishUserSyncRunParams) (*UserSyncRun, error) {
+	row := q.db.QueryRow(ctx, finishUserSyncRun,
+		arg.ID,
+		arg.Status,
+		arg.ErrorMessage,
+		arg.CreatedUsers,
+		arg.UpdatedUsers,
+		arg.DeletedUsers,
+	)
+	var i UserSyncRun
+	err := row.Scan(
+		&i.ID,
+		&i.CorrelationID,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.CreatedUsers,
+		&i.UpdatedUsers,
+		&i.DeletedUsers,
+	)
+	return &i, err
+}
+
+const getUserSyncRuns = `-- name: GetUserSyncRuns :many
+SELECT id, correlation_id, started_at, finished_at, status, error_message, created_users, updated_users, deleted_users FROM user_sync_runs
+ORDER BY started_at DESC, id DESC
+LIMIT $2 OFFSET $1
+`
+
+type GetUserSyncRunsParams struct {
+	OffsetRows int32
+	LimitRows  int32
+}
+
+func (q *Queries) GetUserSyncRuns(ctx context.Context, arg GetUserSyncRunsParams) ([]*UserSyncRun, error) {
+	rows, err := q.db.Query(ctx, getUserSyncRuns, arg.OffsetRows, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*UserSyncRun
+	for rows.Next() {
+		var i UserSyncRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.CorrelationID,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.Status,
+			&i.ErrorMessage,
+			&i.CreatedUsers,
+			&i.UpdatedUsers,
+			&i.DeletedUsers,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
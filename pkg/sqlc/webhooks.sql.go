@@ -0,0 +1,361 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.20.0
+// source: webhooks.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const claimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET locked_by = $1::TEXT, locked_at = NOW(), attempts = attempts + 1
+WHERE id IN (
+    SELECT d.id FROM webhook_deliveries AS d
+    WHERE d.status = 'pending' AND d.locked_by IS NULL AND d.next_attempt_at <= NOW()
+    ORDER BY d.next_attempt_at ASC
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, created_at, next_attempt_at, delivered_at, locked_by, locked_at
+`
+
+type ClaimWebhookDeliveriesParams struct {
+	LockedBy  string
+	BatchSize int32
+}
+
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]*WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, claimWebhookDeliveries, arg.LockedBy, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.LockedBy,
+			&i.LockedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhookDeliveries = `-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT id, $1, $2, $3
+FROM webhook_subscriptions
+WHERE enabled = true AND $2::TEXT = ANY(event_types)
+`
+
+type CreateWebhookDeliveriesParams struct {
+	EventID   uuid.UUID
+	EventType WebhookEventType
+	Payload   []byte
+}
+
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createWebhookDeliveries, arg.EventID, arg.EventType, arg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, event_types)
+VALUES ($1, $2, $3::TEXT[])
+RETURNING id, url, secret, event_types, enabled, created_at
+`
+
+type CreateWebhookSubscriptionParams struct {
+	Url        string
+	Secret     string
+	EventTypes []string
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (*WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, createWebhookSubscription, arg.Url, arg.Secret, arg.EventTypes)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Enabled,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const deleteWebhookDeliveriesBefore = `-- name: DeleteWebhookDeliveriesBefore :execrows
+DELETE FROM webhook_deliveries
+WHERE status <> 'pending' AND created_at < $1::TIMESTAMPTZ
+`
+
+func (q *Queries) DeleteWebhookDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhookDeliveriesBefore, createdBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :exec
+DELETE FROM webhook_subscriptions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteWebhookSubscription, id)
+	return err
+}
+
+const getPendingWebhookDeliveryCount = `-- name: GetPendingWebhookDeliveryCount :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE status = 'pending'
+`
+
+func (q *Queries) GetPendingWebhookDeliveryCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getPendingWebhookDeliveryCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getWebhookDeliveries = `-- name: GetWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, created_at, next_attempt_at, delivered_at, locked_by, locked_at FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $3 OFFSET $2
+`
+
+type GetWebhookDeliveriesParams struct {
+	SubscriptionID uuid.UUID
+	OffsetRows     int32
+	LimitRows      int32
+}
+
+func (q *Queries) GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]*WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, getWebhookDeliveries, arg.SubscriptionID, arg.OffsetRows, arg.LimitRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.LockedBy,
+			&i.LockedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+SELECT id, url, secret, event_types, enabled, created_at FROM webhook_subscriptions
+WHERE id = $1
+`
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, getWebhookSubscription, id)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Enabled,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const getWebhookSubscriptions = `-- name: GetWebhookSubscriptions :many
+SELECT id, url, secret, event_types, enabled, created_at FROM webhook_subscriptions
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetWebhookSubscriptions(ctx context.Context) ([]*WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, getWebhookSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookSubscription
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.Enabled,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhookSubscriptionsByIDs = `-- name: GetWebhookSubscriptionsByIDs :many
+SELECT id, url, secret, event_types, enabled, created_at FROM webhook_subscriptions
+WHERE id = ANY($1::UUID[])
+`
+
+func (q *Queries) GetWebhookSubscriptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]*WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, getWebhookSubscriptionsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookSubscription
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.Enabled,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseStaleWebhookDeliveries = `-- name: ReleaseStaleWebhookDeliveries :execrows
+UPDATE webhook_deliveries
+SET locked_by = NULL, locked_at = NULL
+WHERE locked_by IS NOT NULL AND locked_at < $1::TIMESTAMPTZ
+`
+
+func (q *Queries) ReleaseStaleWebhookDeliveries(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseStaleWebhookDeliveries, lockedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setWebhookDeliveryResult = `-- name: SetWebhookDeliveryResult :exec
+UPDATE webhook_deliveries
+SET
+    locked_by = NULL,
+    locked_at = NULL,
+    status = $1,
+    last_status_code = $2,
+    last_error = $3,
+    next_attempt_at = $4::TIMESTAMPTZ,
+    delivered_at = CASE WHEN $1 = 'delivered' THEN NOW() END
+WHERE id = $5
+`
+
+type SetWebhookDeliveryResultParams struct {
+	Status         WebhookDeliveryStatus
+	LastStatusCode *int32
+	LastError      *string
+	NextAttemptAt  time.Time
+	ID             uuid.UUID
+}
+
+func (q *Queries) SetWebhookDeliveryResult(ctx context.Context, arg SetWebhookDeliveryResultParams) error {
+	_, err := q.db.Exec(ctx, setWebhookDeliveryResult,
+		arg.Status,
+		arg.LastStatusCode,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
+	return err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET
+    url = COALESCE($1, url),
+    event_types = COALESCE($2::TEXT[], event_types),
+    enabled = COALESCE($3, enabled)
+WHERE id = $4
+RETURNING id, url, secret, event_types, enabled, created_at
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	Url        *string
+	EventTypes []string
+	Enabled    *bool
+	ID         uuid.UUID
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (*WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, updateWebhookSubscription,
+		arg.Url,
+		arg.EventTypes,
+		arg.Enabled,
+		arg.ID,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Enabled,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
@@ -0,0 +1,104 @@
+package teamsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+)
+
+const (
+	// teamDeletionRetryDelay Time to wait before a failed team deletion is attempted again
+	teamDeletionRetryDelay = time.Minute * 15
+
+	// teamDeletionStaleTimeout Started team deletions older than this are considered abandoned, for instance because
+	// the replica running the deletion was killed before it could finish or reset the deletion
+	teamDeletionStaleTimeout = time.Hour
+)
+
+// ScheduleTeamDeletion Confirm a team delete key, disable the team and schedule it for deletion when the configured
+// grace period has passed
+func (h *handler) ScheduleTeamDeletion(ctx context.Context, deleteKey *db.TeamDeleteKey, correlationID uuid.UUID) (*db.TeamDeletion, error) {
+	return h.database.ScheduleTeamDeletion(ctx, deleteKey, correlationID, time.Now().Add(h.cfg.TeamDeletion.GracePeriod))
+}
+
+// DeleteDueTeams Delete all teams with a scheduled deletion that is due. Failed deletions are attempted again later,
+// and deletions interrupted by shutdown are handed back unchanged. Returns the number of deleted teams.
+func (h *handler) DeleteDueTeams(ctx context.Context) (int, error) {
+	released, err := h.database.ReleaseStaleTeamDeletions(ctx, time.Now().Add(-teamDeletionStaleTimeout))
+	if err != nil {
+		h.log.WithError(err).Error("release stale team deletions")
+	} else if released > 0 {
+		h.log.Warnf("released %d stale team deletion(s)", released)
+	}
+
+	deletions, err := h.database.StartDueTeamDeletions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for i, deletion := range deletions {
+		log := h.log.WithTeamSlug(string(deletion.TeamSlug))
+
+		err = h.DeleteTeam(deletion.TeamSlug, deletion.CorrelationID)
+		if err == nil {
+			deleted++
+			continue
+		}
+
+		if ctx.Err() != nil || h.mainContext.Err() != nil {
+			// the deletion was interrupted, hand it and the remaining deletions back so that they are picked up again
+			h.releaseTeamDeletions(deletions[i:])
+			return deleted, nil
+		}
+
+		log.WithError(err).Error("delete team")
+		if err := h.database.ResetTeamDeletion(ctx, deletion.TeamSlug, time.Now().Add(teamDeletionRetryDelay)); err != nil {
+			log.WithError(err).Error("reschedule team deletion")
+		}
+	}
+
+	return deleted, nil
+}
+
+// releaseTeamDeletions Mark started team deletions as not started, keeping their original deletion time
+func (h *handler) releaseTeamDeletions(deletions []*db.TeamDeletion) {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	for _, deletion := range deletions {
+		if err := h.database.ResetTeamDeletion(ctx, deletion.TeamSlug, deletion.DeleteAt); err != nil {
+			h.log.WithTeamSlug(string(deletion.TeamSlug)).WithError(err).Error("release interrupted team deletion")
+		}
+	}
+}
+
+// RunTeamDeletions Delete teams with a scheduled deletion that is due every interval until the context is done. Teams
+// are only deleted while isLeader returns true, so that a single instance does the work.
+func (h *handler) RunTeamDeletions(ctx context.Context, interval time.Duration, isLeader func() bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !isLeader() {
+			continue
+		}
+
+		deleted, err := h.DeleteDueTeams(ctx)
+		if err != nil {
+			h.log.WithError(err).Error("delete teams with a due deletion")
+		}
+
+		if deleted > 0 {
+			h.log.Infof("deleted %d teams", deleted)
+		}
+	}
+}
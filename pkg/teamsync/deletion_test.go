@@ -0,0 +1,248 @@
+package teamsync_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/config"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/reconcilers"
+	github_team_reconciler "github.com/nais/teams-backend/pkg/reconcilers/github/team"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/teamsync"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/nais/teams-backend/pkg/webhooks"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ScheduleTeamDeletion(t *testing.T) {
+	const teamSlug = slug.Slug("my-team")
+
+	ctx := context.Background()
+	correlationID := uuid.New()
+	cfg, _ := config.New()
+	cfg.TeamDeletion.GracePeriod = time.Hour * 24
+
+	deletion := &db.TeamDeletion{
+		TeamDeletion: &sqlc.TeamDeletion{
+			TeamSlug:      teamSlug,
+			CorrelationID: correlationID,
+		},
+	}
+
+	deleteKey := &db.TeamDeleteKey{
+		TeamDeleteKey: &sqlc.TeamDeleteKey{
+			Key:      uuid.New(),
+			TeamSlug: teamSlug,
+		},
+	}
+
+	database := db.NewMockDatabase(t)
+	database.
+		On("ScheduleTeamDeletion", ctx, deleteKey, correlationID, mock.MatchedBy(func(deleteAt time.Time) bool {
+			return deleteAt.After(time.Now().Add(time.Hour*23)) && deleteAt.Before(time.Now().Add(time.Hour*25))
+		})).
+		Return(deletion, nil).
+		Once()
+
+	handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), logger.NewMockLogger(t))
+	scheduled, err := handler.ScheduleTeamDeletion(ctx, deleteKey, correlationID)
+	assert.NoError(t, err)
+	assert.Equal(t, deletion, scheduled)
+}
+
+func TestHandler_DeleteDueTeams(t *testing.T) {
+	const teamSlug = slug.Slug("my-team")
+
+	ctx := context.Background()
+	correlationID := uuid.New()
+	cfg, _ := config.New()
+
+	deletion := &db.TeamDeletion{
+		TeamDeletion: &sqlc.TeamDeletion{
+			TeamSlug:      teamSlug,
+			CorrelationID: correlationID,
+		},
+	}
+
+	t.Run("unable to get due deletions", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamDeletions", ctx, mock.AnythingOfType("time.Time")).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("StartDueTeamDeletions", ctx).
+			Return(nil, fmt.Errorf("some error")).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), logger.NewMockLogger(t))
+		deleted, err := handler.DeleteDueTeams(ctx)
+		assert.Equal(t, 0, deleted)
+		assert.EqualError(t, err, "some error")
+	})
+
+	t.Run("team is deleted", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamDeletions", ctx, mock.AnythingOfType("time.Time")).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("StartDueTeamDeletions", ctx).
+			Return([]*db.TeamDeletion{deletion}, nil).
+			Once()
+		database.
+			On("DeleteTeam", ctx, teamSlug).
+			Return(nil).
+			Once()
+
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithTeamSlug", string(teamSlug)).
+			Return(log)
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		deleted, err := handler.DeleteDueTeams(ctx)
+		assert.Equal(t, 1, deleted)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed deletion is rescheduled", func(t *testing.T) {
+		testLogger, logs := test.NewNullLogger()
+		deleteErr := fmt.Errorf("some error")
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamDeletions", ctx, mock.AnythingOfType("time.Time")).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("StartDueTeamDeletions", ctx).
+			Return([]*db.TeamDeletion{deletion}, nil).
+			Once()
+		database.
+			On("SetTeamDeletionReconcilerResult", ctx, teamSlug, github_team_reconciler.Name, sqlc.TeamDeletionReconcilerStatusFailure, mock.Anything).
+			Return(nil).
+			Once()
+		database.
+			On("ResetTeamDeletion", ctx, teamSlug, mock.MatchedBy(func(deleteAt time.Time) bool {
+				return deleteAt.After(time.Now())
+			})).
+			Return(nil).
+			Once()
+
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithTeamSlug", string(teamSlug)).
+			Return(log)
+		log.
+			On("WithComponent", types.ComponentNameGithubTeam).
+			Return(log).
+			Once()
+		log.
+			On("WithError", mock.Anything).
+			Return(&logrus.Entry{Logger: testLogger}).
+			Twice()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.
+					On("Name").
+					Return(github_team_reconciler.Name).
+					Once()
+				reconciler.
+					On("Delete", ctx, teamSlug, correlationID).
+					Return(deleteErr).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name, RunOrder: 1}}))
+
+		deleted, err := handler.DeleteDueTeams(ctx)
+		assert.Equal(t, 0, deleted)
+		assert.NoError(t, err)
+		assert.Len(t, logs.Entries, 2)
+	})
+	t.Run("stale deletions are released", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamDeletions", ctx, mock.MatchedBy(func(startedBefore time.Time) bool {
+				return startedBefore.Before(time.Now())
+			})).
+			Return(int64(2), nil).
+			Once()
+		database.
+			On("StartDueTeamDeletions", ctx).
+			Return([]*db.TeamDeletion{}, nil).
+			Once()
+
+		log := logger.NewMockLogger(t)
+		log.
+			On("Warnf", "released %d stale team deletion(s)", int64(2)).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		deleted, err := handler.DeleteDueTeams(ctx)
+		assert.Equal(t, 0, deleted)
+		assert.NoError(t, err)
+	})
+
+	t.Run("interrupted deletions are released", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		deleteAt := time.Now().Add(-time.Minute)
+		otherSlug := slug.Slug("other-team")
+		deletions := []*db.TeamDeletion{
+			{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: teamSlug, CorrelationID: correlationID, DeleteAt: deleteAt}},
+			{TeamDeletion: &sqlc.TeamDeletion{TeamSlug: otherSlug, CorrelationID: correlationID, DeleteAt: deleteAt}},
+		}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamDeletions", ctx, mock.AnythingOfType("time.Time")).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("StartDueTeamDeletions", ctx).
+			Return(deletions, nil).
+			Once()
+		database.
+			On("ResetTeamDeletion", mock.Anything, teamSlug, deleteAt).
+			Return(nil).
+			Once()
+		database.
+			On("ResetTeamDeletion", mock.Anything, otherSlug, deleteAt).
+			Return(nil).
+			Once()
+
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithTeamSlug", string(teamSlug)).
+			Return(log)
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				return reconcilers.NewMockReconciler(t), nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name, RunOrder: 1}}))
+
+		deleted, err := handler.DeleteDueTeams(ctx)
+		assert.Equal(t, 0, deleted)
+		assert.NoError(t, err)
+	})
+}
@@ -2,6 +2,7 @@ package teamsync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nais/teams-backend/pkg/config"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
 	"github.com/nais/teams-backend/pkg/logger"
 	"github.com/nais/teams-backend/pkg/metrics"
 	"github.com/nais/teams-backend/pkg/reconcilers"
@@ -24,18 +26,24 @@ import (
 	"github.com/nais/teams-backend/pkg/slug"
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/nais/teams-backend/pkg/types"
+	"github.com/nais/teams-backend/pkg/webhooks"
 )
 
 type Handler interface {
 	SetReconcilerFactories(factories ReconcilerFactories)
-	Schedule(input Input) error
-	ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID) ([]*db.Team, error)
+	Schedule(ctx context.Context, input Input) error
+	ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger) ([]*db.Team, error)
 	InitReconcilers(ctx context.Context) error
 	UseReconciler(reconciler db.Reconciler) error
 	RemoveReconciler(reconcilerName sqlc.ReconcilerName)
 	SyncTeams(ctx context.Context)
+	PlanTeam(ctx context.Context, teamSlug slug.Slug) ([]*ReconcilerPlan, error)
 	UpdateMetrics(ctx context.Context)
 	DeleteTeam(teamSlug slug.Slug, correlationID uuid.UUID) error
+	ScheduleTeamDeletion(ctx context.Context, deleteKey *db.TeamDeleteKey, correlationID uuid.UUID) (*db.TeamDeletion, error)
+	DeleteDueTeams(ctx context.Context) (int, error)
+	RunTeamDeletions(ctx context.Context, interval time.Duration, isLeader func() bool)
+	SubscribeProgress(ctx context.Context, correlationID uuid.UUID) <-chan *ProgressEvent
 	Close()
 }
 
@@ -43,20 +51,37 @@ type handler struct {
 	activeReconcilers map[sqlc.ReconcilerName]ReconcilerWithRunOrder
 	database          db.Database
 	syncQueue         Queue
-	syncQueueChan     <-chan Input
 	log               logger.Logger
 	lock              sync.Mutex
 	cfg               *config.Config
 	factories         ReconcilerFactories
 	mainContext       context.Context
-
-	teamsInFlight     map[slug.Slug]struct{}
-	teamsInFlightLock sync.Mutex
+	webhookPublisher  webhooks.Publisher
+	progress          Progress
 }
 
 type ReconcilerWithRunOrder struct {
-	runOrder   int32
-	reconciler reconcilers.Reconciler
+	name          sqlc.ReconcilerName
+	runOrder      int32
+	prerequisites []sqlc.ReconcilerName
+	timeout       time.Duration
+	reconciler    reconcilers.Reconciler
+}
+
+type reconcileResult struct {
+	name       sqlc.ReconcilerName
+	status     sqlc.TeamSyncReconcilerStatus
+	err        error
+	attempts   int32
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+// ReconcilerPlan The changes a reconciler would make for a team. Error is set if the reconciler was unable to plan.
+type ReconcilerPlan struct {
+	Reconciler sqlc.ReconcilerName
+	Changes    []reconcilers.PlannedChange
+	Error      error
 }
 
 type ReconcilerFactories map[sqlc.ReconcilerName]reconcilers.ReconcilerFactory
@@ -72,23 +97,36 @@ var factories = ReconcilerFactories{
 	google_gar.Name:                        google_gar.NewFromConfig,
 }
 
-const reconcilerTimeout = time.Minute * 15
+const (
+	// teamSyncTimeoutMargin Time allowed for a team sync on top of the timeouts of the reconcilers, for storing the
+	// results and scheduling retries
+	teamSyncTimeoutMargin = time.Minute
+
+	// releaseTimeout Time allowed for handing an interrupted team sync back to the queue during shutdown
+	releaseTimeout = time.Second * 5
 
-func NewHandler(ctx context.Context, database db.Database, cfg *config.Config, log logger.Logger) Handler {
-	queue, channel := NewQueue()
-	return &handler{
+	// finishRunTimeout Time allowed for storing the result of a team sync run, which must also be stored when the sync
+	// is interrupted
+	finishRunTimeout = time.Second * 5
+)
+
+func NewHandler(ctx context.Context, database db.Database, cfg *config.Config, webhookPublisher webhooks.Publisher, progress Progress, log logger.Logger) Handler {
+	h := &handler{
 		activeReconcilers: make(map[sqlc.ReconcilerName]ReconcilerWithRunOrder),
 		database:          database,
-		syncQueue:         queue,
-		syncQueueChan:     channel,
-		teamsInFlight:     make(map[slug.Slug]struct{}),
 		cfg:               cfg,
 		log:               log,
 		factories:         factories,
 		mainContext:       ctx,
+		webhookPublisher:  webhookPublisher,
+		progress:          progress,
 	}
+	h.syncQueue = NewQueue(database, helpers.WorkerID(), h.teamSyncTimeout, log)
+	return h
 }
 
+// DeleteTeam Delete the resources of a team in all active reconcilers, and then the team itself. The result of each
+// reconciler is stored as the progress of the team deletion.
 func (h *handler) DeleteTeam(teamSlug slug.Slug, correlationID uuid.UUID) error {
 	log := h.log.WithTeamSlug(string(teamSlug))
 	errors := 0
@@ -105,11 +143,18 @@ func (h *handler) DeleteTeam(teamSlug slug.Slug, correlationID uuid.UUID) error
 		name := reconcilerImpl.Name()
 		log := log.WithComponent(types.ComponentName(name))
 
+		status := sqlc.TeamDeletionReconcilerStatusSuccess
+		var errorMessage *string
 		err := reconcilerImpl.Delete(h.mainContext, teamSlug, correlationID)
 		if err != nil {
 			log.WithError(err).Error("delete team")
+			status = sqlc.TeamDeletionReconcilerStatusFailure
+			errorMessage = helpers.Strp(err.Error())
 			errors++
-			continue
+		}
+
+		if err := h.database.SetTeamDeletionReconcilerResult(h.mainContext, teamSlug, name, status, errorMessage); err != nil {
+			log.WithError(err).Error("store team deletion progress")
 		}
 	}
 
@@ -120,11 +165,25 @@ func (h *handler) DeleteTeam(teamSlug slug.Slug, correlationID uuid.UUID) error
 	err := h.database.DeleteTeam(h.mainContext, teamSlug)
 	if err != nil {
 		log.WithError(err).Error("delete team from database")
+		return nil
 	}
 
+	h.webhookPublisher.Publish(h.mainContext, webhooks.Event{
+		Type:          sqlc.WebhookEventTypeTeamdeleted,
+		CorrelationID: correlationID,
+		Team:          teamSlug,
+		Message:       "Team deleted",
+	})
+
 	return nil
 }
 
+// SubscribeProgress Get the progress of the team syncs with a correlation ID, as reported by the reconcilers on all
+// replicas. The channel is closed when the context is done.
+func (h *handler) SubscribeProgress(ctx context.Context, correlationID uuid.UUID) <-chan *ProgressEvent {
+	return h.progress.Subscribe(ctx, correlationID)
+}
+
 func (h *handler) Close() {
 	h.syncQueue.Close()
 }
@@ -137,8 +196,8 @@ func (h *handler) SetReconcilerFactories(factories ReconcilerFactories) {
 }
 
 // Schedule a team for sync
-func (h *handler) Schedule(input Input) error {
-	return h.syncQueue.Add(input)
+func (h *handler) Schedule(ctx context.Context, input Input) error {
+	return h.syncQueue.Add(ctx, input)
 }
 
 // InitReconcilers initializes the currently enabled reconcilers during startup of teams-backend
@@ -174,35 +233,73 @@ func (h *handler) UseReconciler(reconciler db.Reconciler) error {
 		return err
 	}
 
+	var prerequisites []sqlc.ReconcilerName
+	if r, ok := reconcilerImplementation.(reconcilers.ReconcilerWithPrerequisites); ok {
+		prerequisites = r.Prerequisites()
+	}
+
+	timeout := h.cfg.ReconcilerTimeout
+	if reconciler.TimeoutSeconds != nil {
+		timeout = time.Duration(*reconciler.TimeoutSeconds) * time.Second
+	}
+
 	h.activeReconcilers[reconciler.Name] = ReconcilerWithRunOrder{
-		runOrder:   reconciler.RunOrder,
-		reconciler: reconcilerImplementation,
+		name:          reconciler.Name,
+		runOrder:      reconciler.RunOrder,
+		prerequisites: prerequisites,
+		timeout:       timeout,
+		reconciler:    reconcilerImplementation,
 	}
 	return nil
 }
 
+// SyncTeams Claim team syncs from the queue and reconcile the teams until the queue is closed or the context is done
 func (h *handler) SyncTeams(ctx context.Context) {
-	for input := range h.syncQueueChan {
-		log := h.log.WithTeamSlug(string(input.TeamSlug))
-
-		if !h.setTeamInFlight(input.TeamSlug) {
-			log.Info("already in flight - adding to back of queue")
-			time.Sleep(100 * time.Millisecond)
-			if err := h.syncQueue.Add(input); err != nil {
-				log.WithError(err).Error("failed while re-queueing team that is in flight")
+	for {
+		item, err := h.syncQueue.Next(ctx)
+		if err != nil {
+			if !errors.Is(err, ErrQueueClosed) && ctx.Err() == nil {
+				h.log.WithError(err).Error("get next team sync from queue")
 			}
-			continue
+			return
 		}
 
-		ctx, cancel := context.WithTimeout(ctx, reconcilerTimeout)
-		err := h.reconcileTeam(ctx, input)
+		log := h.log.WithTeamSlug(string(item.Input.TeamSlug))
+
+		reconcileCtx, cancel := context.WithTimeout(ctx, h.teamSyncTimeout())
+		err = h.reconcileTeam(reconcileCtx, item.Input)
+		cancel()
 		if err != nil {
 			log.WithError(err).Error("reconcile team")
 		}
 
-		cancel()
-		h.unsetTeamInFlight(input.TeamSlug)
+		if ctx.Err() != nil {
+			// the sync was interrupted, hand it back to the queue so that another replica can pick it up
+			releaseCtx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+			if err := h.syncQueue.Release(releaseCtx, item); err != nil {
+				log.WithError(err).Error("release interrupted team sync")
+			}
+			cancel()
+			return
+		}
+
+		if err := h.syncQueue.Done(ctx, item); err != nil {
+			log.WithError(err).Error("remove team sync from queue")
+		}
+	}
+}
+
+// teamSyncTimeout Get the time allowed for a full team sync. As reconcilers with prerequisites run one after another,
+// each of the active reconcilers must be able to use its entire timeout.
+func (h *handler) teamSyncTimeout() time.Duration {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	timeout := teamSyncTimeoutMargin
+	for _, reconciler := range h.activeReconcilers {
+		timeout += reconciler.timeout
 	}
+	return timeout
 }
 
 // RemoveReconciler will remove a reconciler from the list of currently active reconcilers. During the removal of the
@@ -214,7 +311,7 @@ func (h *handler) RemoveReconciler(reconcilerName sqlc.ReconcilerName) {
 	delete(h.activeReconcilers, reconcilerName)
 }
 
-func (h *handler) ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID) ([]*db.Team, error) {
+func (h *handler) ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger) ([]*db.Team, error) {
 	teams, err := h.database.GetActiveTeams(ctx)
 	if err != nil {
 		return nil, err
@@ -224,9 +321,10 @@ func (h *handler) ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID)
 		input := Input{
 			TeamSlug:      team.Slug,
 			CorrelationID: correlationID,
+			Trigger:       trigger,
 		}
 
-		err = h.Schedule(input)
+		err = h.Schedule(ctx, input)
 		if err != nil {
 			return nil, err
 		}
@@ -235,7 +333,9 @@ func (h *handler) ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID)
 	return teams, nil
 }
 
-func (h *handler) reconcileTeam(ctx context.Context, input Input) error {
+// reconcileTeam Run the reconcilers for a team. The run is stored along with the result of each reconciler, also when
+// the sync is interrupted.
+func (h *handler) reconcileTeam(ctx context.Context, input Input) (err error) {
 	log := h.log.WithTeamSlug(string(input.TeamSlug))
 
 	team, err := h.database.GetActiveTeamBySlug(ctx, input.TeamSlug)
@@ -243,66 +343,356 @@ func (h *handler) reconcileTeam(ctx context.Context, input Input) error {
 		return err
 	}
 
+	// disabled teams are only reconciled when the members of the team should be removed from the external resources
+	if !team.Enabled && !team.ResourcesSuspended {
+		log.Infof("team is disabled, skipping reconcile")
+		return nil
+	}
+
+	runResults := make([]*db.TeamSyncReconcilerResult, 0)
+	run, createRunErr := h.database.CreateTeamSyncRun(ctx, team.Slug, input.CorrelationID, input.trigger(), input.Reconcilers)
+	if createRunErr != nil {
+		log.WithError(createRunErr).Error("create team sync run")
+	} else {
+		defer func() {
+			h.finishRun(ctx, log, run, runResults, err)
+		}()
+	}
+
 	log.Infof("reconcile team")
 	errors := 0
 	teamReconcilerTimer := metrics.MeasureReconcileTeamDuration()
 
 	h.lock.Lock()
-	orderedReconcilers := getOrderedReconcilers(h.activeReconcilers)
+	orderedReconcilers := selectReconcilers(getOrderedReconcilers(h.activeReconcilers), input.Reconcilers)
 	h.lock.Unlock()
 
-	for _, reconcilerWithRunOrder := range orderedReconcilers {
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
-		reconcilerImpl := reconcilerWithRunOrder.reconciler
-		name := reconcilerImpl.Name()
-		log := log.WithComponent(types.ComponentName(name))
+	parallelism := h.cfg.ReconcilerParallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
 
-		reconcilerInput, err := reconcilers.CreateReconcilerInput(ctx, h.database, *team, name)
-		if err != nil {
-			log.WithError(err).Errorf("get team members for reconciler")
-			continue
-		}
+	position := make(map[sqlc.ReconcilerName]int)
+	for i, r := range orderedReconcilers {
+		position[r.name] = i
+	}
 
-		metrics.IncReconcilerCounter(name, metrics.ReconcilerStateStarted)
+	pending := append([]ReconcilerWithRunOrder{}, orderedReconcilers...)
+	finished := make(map[sqlc.ReconcilerName]struct{})
+	failed := make(map[sqlc.ReconcilerName]struct{})
+	reconcilerErrors := make([]*webhooks.ReconcilerError, 0)
+	results := make(chan reconcileResult)
+	running := 0
+
+	for len(pending) > 0 || running > 0 {
+		for i := 0; i < len(pending) && running < parallelism && ctx.Err() == nil; {
+			reconcilerWithRunOrder := pending[i]
+			if !prerequisitesFinished(reconcilerWithRunOrder, position, finished) {
+				i++
+				continue
+			}
+			pending = append(pending[:i], pending[i+1:]...)
+
+			reconcilerImpl := reconcilerWithRunOrder.reconciler
+			timeout := reconcilerWithRunOrder.timeout
+			name := reconcilerImpl.Name()
+
+			if prerequisite, ok := failedPrerequisite(reconcilerWithRunOrder, failed); ok {
+				skippedAt := time.Now()
+				skipErr := h.skipReconciler(ctx, log, input.CorrelationID, team.Slug, name, prerequisite)
+				runResults = append(runResults, &db.TeamSyncReconcilerResult{
+					Reconciler:   name,
+					Status:       sqlc.TeamSyncReconcilerStatusSkipped,
+					StartedAt:    skippedAt,
+					FinishedAt:   skippedAt,
+					ErrorMessage: helpers.Strp(skipErr.Error()),
+				})
+				finished[name] = struct{}{}
+				failed[name] = struct{}{}
+				reconcilerErrors = append(reconcilerErrors, &webhooks.ReconcilerError{
+					Reconciler: name,
+					Error:      skipErr.Error(),
+				})
+				errors++
+				continue
+			}
 
-		reconcileTimer := metrics.MeasureReconcilerDuration(name)
-		err = reconcilerImpl.Reconcile(ctx, reconcilerInput)
-		if err != nil {
-			metrics.IncReconcilerCounter(name, metrics.ReconcilerStateFailed)
-			log.WithError(err).Error("reconcile")
-			errors++
-			err = h.database.SetReconcilerErrorForTeam(ctx, input.CorrelationID, team.Slug, name, err)
-			if err != nil {
-				log.WithError(err).Error("add reconcile error to database")
+			running++
+			go func() {
+				startedAt := time.Now()
+				status, attempts, err := h.runReconciler(ctx, log, input.CorrelationID, *team, name, reconcilerImpl, timeout)
+				results <- reconcileResult{
+					name:       name,
+					status:     status,
+					err:        err,
+					attempts:   attempts,
+					startedAt:  startedAt,
+					finishedAt: time.Now(),
+				}
+			}()
+		}
+
+		if running == 0 {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
 			continue
 		}
-		duration := reconcileTimer.ObserveDuration()
-		log.Debugf("successful reconcile duration: %s", duration)
 
-		err = h.database.ClearReconcilerErrorsForTeam(ctx, team.Slug, name)
-		if err != nil {
-			log.WithError(err).Error("purge reconcile errors")
+		result := <-results
+		running--
+		finished[result.name] = struct{}{}
+		runResult := &db.TeamSyncReconcilerResult{
+			Reconciler: result.name,
+			Status:     result.status,
+			StartedAt:  result.startedAt,
+			FinishedAt: result.finishedAt,
 		}
+		runResults = append(runResults, runResult)
+		if result.err != nil {
+			runResult.ErrorMessage = helpers.Strp(result.err.Error())
+			failed[result.name] = struct{}{}
+			reconcilerErrors = append(reconcilerErrors, &webhooks.ReconcilerError{
+				Reconciler:       result.name,
+				Error:            result.err.Error(),
+				Attempts:         result.attempts,
+				RetriesExhausted: result.attempts > 0 && result.attempts >= int32(h.cfg.ReconcilerRetry.MaxAttempts),
+			})
+			errors++
+		}
+	}
 
-		metrics.IncReconcilerCounter(name, metrics.ReconcilerStateSuccessful)
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
 	if errors > 0 {
+		h.webhookPublisher.Publish(ctx, webhooks.Event{
+			Type:          sqlc.WebhookEventTypeSyncfailed,
+			CorrelationID: input.CorrelationID,
+			Team:          team.Slug,
+			Message:       fmt.Sprintf("%d reconciler(s) failed", errors),
+			Errors:        reconcilerErrors,
+		})
 		return fmt.Errorf("%d error(s) occurred during reconcile", errors)
 	}
 
 	duration := teamReconcilerTimer.ObserveDuration()
 	log.Debugf("successful reconcile duration: %s", duration)
 
+	if len(input.Reconcilers) > 0 {
+		// only a subset of the reconcilers ran, so the team as a whole has not been synced
+		return nil
+	}
+
 	if err := h.database.SetLastSuccessfulSyncForTeam(ctx, team.Slug); err != nil {
 		log.WithError(err).Error("update last successful sync timestamp")
 	}
 	return nil
 }
 
+// runReconciler Run a single reconciler for a team with the given timeout. Failures and timeouts are stored, and a
+// retry will be scheduled when needed. Nothing is stored if the team sync itself is canceled. Returns the outcome of the
+// reconciler, along with the number of consecutive failures when a failure was stored.
+func (h *handler) runReconciler(ctx context.Context, log logger.Logger, correlationID uuid.UUID, team db.Team, name sqlc.ReconcilerName, reconcilerImpl reconcilers.Reconciler, timeout time.Duration) (sqlc.TeamSyncReconcilerStatus, int32, error) {
+	log = log.WithComponent(types.ComponentName(name))
+
+	h.progress.Publish(ctx, ProgressEvent{
+		CorrelationID: correlationID,
+		TeamSlug:      team.Slug,
+		Reconciler:    name,
+		State:         ProgressStateStarted,
+	})
+
+	reconcilerInput, err := reconcilers.CreateReconcilerInput(ctx, h.database, team, name)
+	if err != nil {
+		log.WithError(err).Errorf("get team members for reconciler")
+		h.publishProgress(ctx, correlationID, team.Slug, name, err)
+		return sqlc.TeamSyncReconcilerStatusFailure, 0, err
+	}
+
+	metrics.IncReconcilerCounter(name, metrics.ReconcilerStateStarted)
+
+	reconcileTimer := metrics.MeasureReconcilerDuration(name)
+	reconcileCtx, cancel := context.WithTimeout(ctx, timeout)
+	err = reconcilerImpl.Reconcile(reconcileCtx, reconcilerInput)
+	timedOut := errors.Is(reconcileCtx.Err(), context.DeadlineExceeded)
+	cancel()
+
+	switch {
+	case err == nil:
+	case timedOut:
+		err = fmt.Errorf("reconciler timed out after %s: %w", timeout, err)
+		metrics.IncReconcilerCounter(name, metrics.ReconcilerStateTimeout)
+		log.WithError(err).Error("reconcile")
+		h.publishProgress(ctx, correlationID, team.Slug, name, err)
+		attempts := h.scheduleRetry(ctx, correlationID, team.Slug, name, err, sqlc.ReconcilerErrorKindTimeout)
+		return sqlc.TeamSyncReconcilerStatusTimeout, attempts, err
+	case ctx.Err() != nil:
+		metrics.IncReconcilerCounter(name, metrics.ReconcilerStateCanceled)
+		log.WithError(err).Warn("reconcile canceled")
+		return sqlc.TeamSyncReconcilerStatusCanceled, 0, err
+	default:
+		metrics.IncReconcilerCounter(name, metrics.ReconcilerStateFailed)
+		log.WithError(err).Error("reconcile")
+		h.publishProgress(ctx, correlationID, team.Slug, name, err)
+		attempts := h.scheduleRetry(ctx, correlationID, team.Slug, name, err, sqlc.ReconcilerErrorKindError)
+		return sqlc.TeamSyncReconcilerStatusFailure, attempts, err
+	}
+	duration := reconcileTimer.ObserveDuration()
+	log.Debugf("successful reconcile duration: %s", duration)
+
+	err = h.database.ClearReconcilerErrorsForTeam(ctx, team.Slug, name)
+	if err != nil {
+		log.WithError(err).Error("purge reconcile errors")
+	}
+
+	metrics.IncReconcilerCounter(name, metrics.ReconcilerStateSuccessful)
+	h.publishProgress(ctx, correlationID, team.Slug, name, nil)
+	return sqlc.TeamSyncReconcilerStatusSuccess, 0, nil
+}
+
+// skipReconciler Store an error for a reconciler that can not run because one of its prerequisites failed. The skip is
+// not counted as an attempt, and no retry is scheduled, as the reconciler will run once its prerequisites succeed.
+// Returns the stored error.
+func (h *handler) skipReconciler(ctx context.Context, log logger.Logger, correlationID uuid.UUID, teamSlug slug.Slug, name, prerequisite sqlc.ReconcilerName) error {
+	err := fmt.Errorf("skipped because %q failed", prerequisite)
+	metrics.IncReconcilerCounter(name, metrics.ReconcilerStateSkipped)
+	log.WithComponent(types.ComponentName(name)).Warnf("skip reconcile, prerequisite %q failed", prerequisite)
+	h.publishProgress(ctx, correlationID, teamSlug, name, err)
+	if dbErr := h.database.SetReconcilerSkippedForTeam(ctx, correlationID, teamSlug, name, err); dbErr != nil {
+		log.WithError(dbErr).Error("add skipped reconciler to database")
+	}
+	return err
+}
+
+// finishRun Store the result of a team sync run, and remove the oldest runs of the team when there are more than the
+// number of runs to store. The run is canceled when the sync was interrupted before all reconcilers had finished.
+func (h *handler) finishRun(ctx context.Context, log logger.Logger, run *db.TeamSyncRun, results []*db.TeamSyncReconcilerResult, syncErr error) {
+	status := sqlc.TeamSyncRunStatusSuccess
+	var errorMessage *string
+	if syncErr != nil {
+		status = sqlc.TeamSyncRunStatusFailure
+		if ctx.Err() != nil {
+			status = sqlc.TeamSyncRunStatusCanceled
+		}
+		errorMessage = helpers.Strp(syncErr.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), finishRunTimeout)
+	defer cancel()
+
+	if _, err := h.database.FinishTeamSyncRun(ctx, run.ID, status, errorMessage, results); err != nil {
+		log.WithError(err).Error("finish team sync run")
+	}
+
+	if h.cfg.TeamSyncRunsToStore < 1 {
+		return
+	}
+
+	if _, err := h.database.DeleteOldTeamSyncRuns(ctx, run.TeamSlug, h.cfg.TeamSyncRunsToStore); err != nil {
+		log.WithError(err).Error("delete old team sync runs")
+	}
+}
+
+// publishProgress Report that a reconciler has finished for a team. The reconciler failed when err is set.
+func (h *handler) publishProgress(ctx context.Context, correlationID uuid.UUID, teamSlug slug.Slug, name sqlc.ReconcilerName, err error) {
+	event := ProgressEvent{
+		CorrelationID: correlationID,
+		TeamSlug:      teamSlug,
+		Reconciler:    name,
+		State:         ProgressStateSucceeded,
+	}
+
+	if err != nil {
+		event.State = ProgressStateFailed
+		event.Error = helpers.Strp(err.Error())
+	}
+
+	h.progress.Publish(ctx, event)
+}
+
+// scheduleRetry Store the error from a failed reconciler, and schedule a retry of that reconciler for the team unless
+// the maximum number of attempts has been reached. Returns the number of consecutive failures of the reconciler, or 0 if
+// the error could not be stored.
+func (h *handler) scheduleRetry(ctx context.Context, correlationID uuid.UUID, teamSlug slug.Slug, reconcilerName sqlc.ReconcilerName, reconcileErr error, kind sqlc.ReconcilerErrorKind) int32 {
+	log := h.log.WithTeamSlug(string(teamSlug)).WithComponent(types.ComponentName(reconcilerName))
+
+	reconcilerError, err := h.database.SetReconcilerErrorForTeam(ctx, correlationID, teamSlug, reconcilerName, reconcileErr, kind)
+	if err != nil {
+		log.WithError(err).Error("add reconcile error to database")
+		return 0
+	}
+
+	maxAttempts := int32(h.cfg.ReconcilerRetry.MaxAttempts)
+	if reconcilerError.Attempts >= maxAttempts {
+		if reconcilerError.Attempts == maxAttempts {
+			metrics.IncReconcilerMaxAttemptsExhaustion()
+			log.Warnf("reconciler has failed %d time(s) in a row, no more retries will be scheduled", reconcilerError.Attempts)
+		}
+		return reconcilerError.Attempts
+	}
+
+	nextRetryAt := time.Now().Add(helpers.Backoff(reconcilerError.Attempts, h.cfg.ReconcilerRetry.InitialBackoff, h.cfg.ReconcilerRetry.MaxBackoff))
+	retry := Input{
+		CorrelationID: correlationID,
+		TeamSlug:      teamSlug,
+		Trigger:       sqlc.TeamSyncTriggerRetry,
+		Reconcilers:   []sqlc.ReconcilerName{reconcilerName},
+	}
+	if err := h.syncQueue.AddAt(ctx, retry, nextRetryAt); err != nil {
+		if !errors.Is(err, ErrQueueClosed) {
+			log.WithError(err).Error("schedule reconciler retry")
+		}
+		return reconcilerError.Attempts
+	}
+
+	if err := h.database.SetReconcilerErrorNextRetry(ctx, teamSlug, reconcilerName, nextRetryAt); err != nil {
+		log.WithError(err).Error("set next retry for reconciler error")
+	}
+	return reconcilerError.Attempts
+}
+
+// PlanTeam Get the changes the active reconcilers would make for a team, without changing anything. Reconcilers that
+// do not support planning are not included.
+func (h *handler) PlanTeam(ctx context.Context, teamSlug slug.Slug) ([]*ReconcilerPlan, error) {
+	team, err := h.database.GetActiveTeamBySlug(ctx, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	orderedReconcilers := getOrderedReconcilers(h.activeReconcilers)
+	h.lock.Unlock()
+
+	plans := make([]*ReconcilerPlan, 0)
+	for _, reconcilerWithRunOrder := range orderedReconcilers {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		reconcilerImpl, ok := reconcilerWithRunOrder.reconciler.(reconcilers.ReconcilerWithPlan)
+		if !ok {
+			continue
+		}
+
+		plan := &ReconcilerPlan{
+			Reconciler: reconcilerWithRunOrder.name,
+		}
+		plans = append(plans, plan)
+
+		reconcilerInput, err := reconcilers.CreateReconcilerInput(ctx, h.database, *team, reconcilerWithRunOrder.name)
+		if err != nil {
+			plan.Error = fmt.Errorf("get team members for reconciler: %w", err)
+			continue
+		}
+
+		plan.Changes, plan.Error = reconcilerImpl.Plan(ctx, reconcilerInput)
+	}
+
+	return plans, nil
+}
+
 func (h *handler) getReconcilerFactory(reconcilerName sqlc.ReconcilerName) (reconcilers.ReconcilerFactory, error) {
 	factory, exists := h.factories[reconcilerName]
 	if !exists {
@@ -317,36 +707,126 @@ func (h *handler) UpdateMetrics(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-time.After(10 * time.Second):
-			metrics.SetPendingTeamCount(len(h.syncQueueChan))
+			pending, err := h.database.GetPendingTeamSyncCount(ctx)
+			if err != nil {
+				h.log.WithError(err).Error("get pending team sync count")
+				continue
+			}
+			metrics.SetPendingTeamCount(int(pending))
 		}
 	}
 }
 
+// getOrderedReconcilers Sort the reconcilers so that all reconcilers come after their prerequisites. The run order is
+// only used to sort reconcilers that do not depend on each other. Prerequisites that are not active are ignored, and
+// reconcilers that are part of a dependency cycle are placed last.
 func getOrderedReconcilers(reconcilers map[sqlc.ReconcilerName]ReconcilerWithRunOrder) []ReconcilerWithRunOrder {
-	var orderedReconcilers []ReconcilerWithRunOrder
+	pending := make([]ReconcilerWithRunOrder, 0, len(reconcilers))
 	for _, reconcilerWithOrder := range reconcilers {
-		orderedReconcilers = append(orderedReconcilers, reconcilerWithOrder)
+		pending = append(pending, reconcilerWithOrder)
 	}
-	sort.Slice(orderedReconcilers, func(i, j int) bool {
-		return orderedReconcilers[i].runOrder < orderedReconcilers[j].runOrder
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].runOrder < pending[j].runOrder
 	})
+
+	orderedReconcilers := make([]ReconcilerWithRunOrder, 0, len(pending))
+	ordered := make(map[sqlc.ReconcilerName]struct{})
+	for len(pending) > 0 {
+		next := -1
+		for i, r := range pending {
+			if prerequisitesOrdered(r, reconcilers, ordered) {
+				next = i
+				break
+			}
+		}
+
+		if next < 0 {
+			return append(orderedReconcilers, pending...)
+		}
+
+		orderedReconcilers = append(orderedReconcilers, pending[next])
+		ordered[pending[next].name] = struct{}{}
+		pending = append(pending[:next], pending[next+1:]...)
+	}
 	return orderedReconcilers
 }
 
-func (h *handler) setTeamInFlight(slug slug.Slug) bool {
-	h.teamsInFlightLock.Lock()
-	defer h.teamsInFlightLock.Unlock()
+// prerequisitesOrdered Check if all active prerequisites of a reconciler have been ordered
+func prerequisitesOrdered(reconciler ReconcilerWithRunOrder, active map[sqlc.ReconcilerName]ReconcilerWithRunOrder, ordered map[sqlc.ReconcilerName]struct{}) bool {
+	for _, prerequisite := range reconciler.prerequisites {
+		if _, isActive := active[prerequisite]; !isActive {
+			continue
+		}
+		if _, isOrdered := ordered[prerequisite]; !isOrdered {
+			return false
+		}
+	}
+	return true
+}
+
+// prerequisitesFinished Check if all prerequisites of a reconciler that come before it in the run order have finished.
+// Prerequisites that are not part of the run, or come after the reconciler because of a dependency cycle, are ignored.
+func prerequisitesFinished(reconciler ReconcilerWithRunOrder, position map[sqlc.ReconcilerName]int, finished map[sqlc.ReconcilerName]struct{}) bool {
+	for _, prerequisite := range reconciler.prerequisites {
+		if pos, ok := position[prerequisite]; !ok || pos > position[reconciler.name] {
+			continue
+		}
+		if _, ok := finished[prerequisite]; !ok {
+			return false
+		}
+	}
+	return true
+}
 
-	if _, inFlight := h.teamsInFlight[slug]; !inFlight {
-		h.teamsInFlight[slug] = struct{}{}
-		return true
+// failedPrerequisite Get the first prerequisite of a reconciler that has failed, if any
+func failedPrerequisite(reconciler ReconcilerWithRunOrder, failed map[sqlc.ReconcilerName]struct{}) (sqlc.ReconcilerName, bool) {
+	for _, prerequisite := range reconciler.prerequisites {
+		if _, ok := failed[prerequisite]; ok {
+			return prerequisite, true
+		}
 	}
-	return false
+	return "", false
 }
 
-func (h *handler) unsetTeamInFlight(slug slug.Slug) {
-	h.teamsInFlightLock.Lock()
-	defer h.teamsInFlightLock.Unlock()
+// selectReconcilers Get the reconcilers that have been requested, along with their prerequisites, while keeping the run
+// order. All reconcilers are returned if none are requested. Requested reconcilers that are not active are ignored.
+func selectReconcilers(orderedReconcilers []ReconcilerWithRunOrder, requested []sqlc.ReconcilerName) []ReconcilerWithRunOrder {
+	if len(requested) == 0 {
+		return orderedReconcilers
+	}
+
+	byName := make(map[sqlc.ReconcilerName]ReconcilerWithRunOrder)
+	for _, r := range orderedReconcilers {
+		byName[r.name] = r
+	}
+
+	selected := make(map[sqlc.ReconcilerName]struct{})
+	var include func(name sqlc.ReconcilerName)
+	include = func(name sqlc.ReconcilerName) {
+		if _, seen := selected[name]; seen {
+			return
+		}
+
+		reconciler, active := byName[name]
+		if !active {
+			return
+		}
 
-	delete(h.teamsInFlight, slug)
+		selected[name] = struct{}{}
+		for _, prerequisite := range reconciler.prerequisites {
+			include(prerequisite)
+		}
+	}
+
+	for _, name := range requested {
+		include(name)
+	}
+
+	result := make([]ReconcilerWithRunOrder, 0, len(selected))
+	for _, r := range orderedReconcilers {
+		if _, ok := selected[r.name]; ok {
+			result = append(result, r)
+		}
+	}
+	return result
 }
@@ -5,11 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/nais/teams-backend/pkg/config"
 	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
 	"github.com/nais/teams-backend/pkg/logger"
 	"github.com/nais/teams-backend/pkg/reconcilers"
 	azure_group_reconciler "github.com/nais/teams-backend/pkg/reconcilers/azure/group"
@@ -19,6 +24,7 @@ import (
 	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/nais/teams-backend/pkg/teamsync"
 	"github.com/nais/teams-backend/pkg/types"
+	"github.com/nais/teams-backend/pkg/webhooks"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
@@ -55,18 +61,60 @@ func TestHandler_ReconcileTeam(t *testing.T) {
 			Team: &sqlc.Team{
 				Slug:    teamSlug,
 				Purpose: "some purpose",
+				Enabled: true,
 			},
 		}
 		database.On("GetActiveTeamBySlug", mock.Anything, teamSlug).Return(team, nil).Once()
 
-		handler := teamsync.NewHandler(ctx, database, cfg, log)
-		handler.Schedule(input)
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		mockTeamSyncQueue(database, input)
+		handler.Schedule(ctx, input)
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+
+	t.Run("disabled team is not reconciled", func(t *testing.T) {
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithTeamSlug", string(teamSlug)).
+			Return(log)
+		log.
+			On("Infof", "team is disabled, skipping reconcile").
+			Once()
+
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+		team := &db.Team{
+			Team: &sqlc.Team{
+				Slug:    teamSlug,
+				Purpose: "some purpose",
+			},
+		}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			sqlc.ReconcilerNameGithubTeam: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				return reconcilers.NewMockReconciler(t), nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGithubTeam, RunOrder: 1}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
 		handler.Close()
 		handler.SyncTeams(ctx)
 	})
 
 	t.Run("use reconciler with missing factory", func(t *testing.T) {
-		handler := teamsync.NewHandler(ctx, database, cfg, log)
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
 		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{})
 		reconciler := db.Reconciler{Reconciler: &sqlc.Reconciler{Name: nais_deploy_reconciler.Name}}
 		assert.ErrorContains(t, handler.UseReconciler(reconciler), "missing reconciler factory")
@@ -74,7 +122,7 @@ func TestHandler_ReconcileTeam(t *testing.T) {
 
 	t.Run("use reconciler with failing factory", func(t *testing.T) {
 		err := errors.New("some error")
-		handler := teamsync.NewHandler(ctx, database, cfg, log)
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
 		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
 			nais_deploy_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
 				return nil, err
@@ -152,6 +200,7 @@ func TestHandler_ReconcileTeam(t *testing.T) {
 			Team: &sqlc.Team{
 				Slug:    teamSlug,
 				Purpose: "some purpose",
+				Enabled: true,
 			},
 		}
 		input := teamsync.Input{
@@ -232,7 +281,11 @@ func TestHandler_ReconcileTeam(t *testing.T) {
 			return rec, nil
 		}
 
-		handler := teamsync.NewHandler(ctx, database, cfg, log)
+		// run one reconciler at a time to verify the run order of reconcilers that do not depend on each other
+		sequentialCfg := *cfg
+		sequentialCfg.ReconcilerParallelism = 1
+
+		handler := teamsync.NewHandler(ctx, database, &sequentialCfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
 		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
 			azure_group_reconciler.Name: createAzureReconciler,
 			github_team_reconciler.Name: createGitHubReconciler,
@@ -242,7 +295,8 @@ func TestHandler_ReconcileTeam(t *testing.T) {
 		assert.Nil(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: nais_deploy_reconciler.Name, RunOrder: 3}}))
 		assert.Nil(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: azure_group_reconciler.Name, RunOrder: 1}}))
 		assert.Nil(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name, RunOrder: 2}}))
-		handler.Schedule(input)
+		mockTeamSyncQueue(database, input)
+		handler.Schedule(ctx, input)
 		handler.Close()
 		handler.SyncTeams(ctx)
 	})
@@ -261,6 +315,7 @@ func TestHandler_ReconcileTeam(t *testing.T) {
 			Team: &sqlc.Team{
 				Slug:    teamSlug,
 				Purpose: "some purpose",
+				Enabled: true,
 			},
 		}
 		database.
@@ -272,11 +327,983 @@ func TestHandler_ReconcileTeam(t *testing.T) {
 			Return(nil).
 			Twice()
 
-		handler := teamsync.NewHandler(ctx, database, cfg, log)
-		handler.Schedule(input)
-		handler.Schedule(input)
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		mockTeamSyncQueue(database, input, input)
+		handler.Schedule(ctx, input)
+		handler.Schedule(ctx, input)
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+}
+
+func TestHandler_ReconcilerRetries(t *testing.T) {
+	const teamSlug = slug.Slug("my team")
+
+	ctx := context.Background()
+	cfg, _ := config.New()
+	reconcileErr := errors.New("some error")
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Purpose: "some purpose",
+			Enabled: true,
+		},
+	}
+
+	failingReconciler := func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+		reconciler := reconcilers.NewMockReconciler(t)
+		reconciler.
+			On("Name").
+			Return(github_team_reconciler.Name).
+			Once()
+		reconciler.
+			On("Reconcile", mock.Anything, mock.Anything).
+			Return(reconcileErr).
+			Once()
+		return reconciler, nil
+	}
+
+	newLogger := func(t *testing.T) *logger.MockLogger {
+		testLogger, _ := test.NewNullLogger()
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGithubTeam).Return(log)
+		log.On("Infof", "reconcile team").Return(nil)
+		log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger})
+		return log
+	}
+
+	// mockSingleSync Hand out a single team sync from the queue, and close the handler when the queue is empty so that
+	// items enqueued while syncing are accepted
+	mockSingleSync := func(database *db.MockDatabase, handler teamsync.Handler, input teamsync.Input) {
+		database.
+			On("ReleaseStaleTeamSyncs", mock.Anything, mock.Anything).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("ClaimTeamSync", mock.Anything, mock.Anything).
+			Return(&db.TeamSyncQueueItem{
+				TeamSyncQueue: &sqlc.TeamSyncQueue{
+					ID:            1,
+					TeamSlug:      input.TeamSlug,
+					CorrelationID: input.CorrelationID,
+					TriggeredBy:   sqlc.TeamSyncTriggerChange,
+					Attempts:      1,
+				},
+			}, nil).
+			Once()
+		database.
+			On("DeleteTeamSync", mock.Anything, int64(1)).
+			Return(nil).
+			Once()
+		mockTeamSyncRun(database, 1, input)
+		database.
+			On("ClaimTeamSync", mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) {
+				handler.Close()
+			}).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return([]*db.User{}, nil).
+			Once()
+	}
+
+	t.Run("failed reconciler is scheduled for retry", func(t *testing.T) {
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		database := db.NewMockDatabase(t)
+		webhookPublisher := webhooks.NewPublisherForTesting()
+		handler := teamsync.NewHandler(ctx, database, cfg, webhookPublisher, teamsync.NewProgressForTesting(), newLogger(t))
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: failingReconciler,
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name}}))
+
+		run := &db.TeamSyncRun{TeamSyncRun: &sqlc.TeamSyncRun{ID: 1, TeamSlug: teamSlug}}
+		database.
+			On("CreateTeamSyncRun", mock.Anything, teamSlug, input.CorrelationID, sqlc.TeamSyncTriggerChange, []sqlc.ReconcilerName(nil)).
+			Return(run, nil).
+			Once()
+		database.
+			On("FinishTeamSyncRun", mock.Anything, run.ID, sqlc.TeamSyncRunStatusFailure, mock.Anything, mock.MatchedBy(func(results []*db.TeamSyncReconcilerResult) bool {
+				return len(results) == 1 &&
+					results[0].Reconciler == github_team_reconciler.Name &&
+					results[0].Status == sqlc.TeamSyncReconcilerStatusFailure &&
+					*results[0].ErrorMessage == reconcileErr.Error()
+			})).
+			Return(run, nil).
+			Once()
+		database.
+			On("DeleteOldTeamSyncRuns", mock.Anything, teamSlug, cfg.TeamSyncRunsToStore).
+			Return(int64(0), nil).
+			Once()
+
+		mockSingleSync(database, handler, input)
+		database.
+			On("SetReconcilerErrorForTeam", mock.Anything, input.CorrelationID, teamSlug, github_team_reconciler.Name, reconcileErr, sqlc.ReconcilerErrorKindError).
+			Return(&db.ReconcilerError{ReconcilerError: &sqlc.ReconcilerError{Attempts: 3}}, nil).
+			Once()
+
+		// third attempt, so the delay is 4 times the initial backoff, of which half is random
+		isExpectedRetryTime := mock.MatchedBy(func(runAt time.Time) bool {
+			delay := time.Until(runAt)
+			return delay > cfg.ReconcilerRetry.InitialBackoff && delay <= 4*cfg.ReconcilerRetry.InitialBackoff
+		})
+		database.
+			On("EnqueueTeamSync", mock.Anything, teamSlug, input.CorrelationID, sqlc.TeamSyncTriggerRetry, []sqlc.ReconcilerName{github_team_reconciler.Name}, isExpectedRetryTime).
+			Return(nil).
+			Once()
+		database.
+			On("SetReconcilerErrorNextRetry", mock.Anything, teamSlug, github_team_reconciler.Name, isExpectedRetryTime).
+			Return(nil).
+			Once()
+
+		handler.SyncTeams(ctx)
+
+		events := webhookPublisher.Events()
+		assert.Len(t, events, 1)
+		assert.Equal(t, []*webhooks.ReconcilerError{
+			{Reconciler: github_team_reconciler.Name, Error: reconcileErr.Error(), Attempts: 3, RetriesExhausted: false},
+		}, events[0].Errors)
+	})
+
+	t.Run("no retry when max attempts has been reached", func(t *testing.T) {
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		log := newLogger(t)
+		log.
+			On("Warnf", "reconciler has failed %d time(s) in a row, no more retries will be scheduled", int32(cfg.ReconcilerRetry.MaxAttempts)).
+			Return().
+			Once()
+
+		database := db.NewMockDatabase(t)
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: failingReconciler,
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name}}))
+
+		mockSingleSync(database, handler, input)
+		database.
+			On("SetReconcilerErrorForTeam", mock.Anything, input.CorrelationID, teamSlug, github_team_reconciler.Name, reconcileErr, sqlc.ReconcilerErrorKindError).
+			Return(&db.ReconcilerError{ReconcilerError: &sqlc.ReconcilerError{Attempts: int32(cfg.ReconcilerRetry.MaxAttempts)}}, nil).
+			Once()
+
+		handler.SyncTeams(ctx)
+	})
+
+	t.Run("retry only runs the selected reconcilers", func(t *testing.T) {
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+			Reconcilers:   []sqlc.ReconcilerName{github_team_reconciler.Name},
+		}
+
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGithubTeam).Return(log)
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("Debugf", mock.Anything, mock.Anything).Return(nil)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("ClearReconcilerErrorsForTeam", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return(nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(github_team_reconciler.Name).Once()
+				reconciler.On("Reconcile", mock.Anything, mock.Anything).Return(nil).Once()
+				return reconciler, nil
+			},
+			nais_deploy_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				return reconcilers.NewMockReconciler(t), nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name, RunOrder: 1}}))
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: nais_deploy_reconciler.Name, RunOrder: 2}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+}
+
+func TestHandler_ReconcilerTimeout(t *testing.T) {
+	const teamSlug = slug.Slug("my team")
+
+	ctx := context.Background()
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Purpose: "some purpose",
+			Enabled: true,
+		},
+	}
+
+	t.Run("reconciler that times out is stored as a timeout", func(t *testing.T) {
+		cfg, _ := config.New()
+		cfg.ReconcilerTimeout = 10 * time.Millisecond
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		testLogger, _ := test.NewNullLogger()
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGithubTeam).Return(log)
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger})
+		log.On("Warnf", mock.Anything, mock.Anything).Return()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("SetReconcilerErrorForTeam", mock.Anything, input.CorrelationID, teamSlug, github_team_reconciler.Name, mock.MatchedBy(func(err error) bool {
+				return errors.Is(err, context.DeadlineExceeded) && strings.HasPrefix(err.Error(), "reconciler timed out after 10ms")
+			}), sqlc.ReconcilerErrorKindTimeout).
+			Return(&db.ReconcilerError{ReconcilerError: &sqlc.ReconcilerError{Attempts: int32(cfg.ReconcilerRetry.MaxAttempts)}}, nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(github_team_reconciler.Name).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, _ reconcilers.Input) error {
+						<-ctx.Done()
+						return ctx.Err()
+					}).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+
+	t.Run("timeout of the reconciler is used instead of the default timeout", func(t *testing.T) {
+		cfg, _ := config.New()
+		timeoutSeconds := int32(120)
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGithubTeam).Return(log)
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("Debugf", mock.Anything, mock.Anything).Return(nil)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("ClearReconcilerErrorsForTeam", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return(nil).
+			Once()
+		database.
+			On("SetLastSuccessfulSyncForTeam", mock.Anything, teamSlug).
+			Return(nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(github_team_reconciler.Name).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						deadline, ok := args.Get(0).(context.Context).Deadline()
+						assert.True(t, ok)
+						assert.WithinDuration(t, time.Now().Add(120*time.Second), deadline, 10*time.Second)
+					}).
+					Return(nil).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name, TimeoutSeconds: &timeoutSeconds}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+
+	t.Run("timeout of the reconciler is not limited by the default timeout", func(t *testing.T) {
+		cfg, _ := config.New()
+		timeoutSeconds := int32(2 * cfg.ReconcilerTimeout / time.Second)
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGithubTeam).Return(log)
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("Debugf", mock.Anything, mock.Anything).Return(nil)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("ClearReconcilerErrorsForTeam", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return(nil).
+			Once()
+		database.
+			On("SetLastSuccessfulSyncForTeam", mock.Anything, teamSlug).
+			Return(nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(github_team_reconciler.Name).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						deadline, ok := args.Get(0).(context.Context).Deadline()
+						assert.True(t, ok)
+						assert.WithinDuration(t, time.Now().Add(2*cfg.ReconcilerTimeout), deadline, 10*time.Second)
+					}).
+					Return(nil).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name, TimeoutSeconds: &timeoutSeconds}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+
+	t.Run("reconciler that exceeds its own timeout with the default config is stored as a timeout", func(t *testing.T) {
+		cfg, _ := config.New()
+		timeoutSeconds := int32(1)
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		testLogger, _ := test.NewNullLogger()
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGithubTeam).Return(log)
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger})
+		log.On("Warnf", mock.Anything, mock.Anything).Return()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, github_team_reconciler.Name).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("SetReconcilerErrorForTeam", mock.Anything, input.CorrelationID, teamSlug, github_team_reconciler.Name, mock.MatchedBy(func(err error) bool {
+				return errors.Is(err, context.DeadlineExceeded) && strings.HasPrefix(err.Error(), "reconciler timed out after 1s")
+			}), sqlc.ReconcilerErrorKindTimeout).
+			Return(&db.ReconcilerError{ReconcilerError: &sqlc.ReconcilerError{Attempts: int32(cfg.ReconcilerRetry.MaxAttempts)}}, nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			github_team_reconciler.Name: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(github_team_reconciler.Name).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, _ reconcilers.Input) error {
+						<-ctx.Done()
+						return ctx.Err()
+					}).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: github_team_reconciler.Name, TimeoutSeconds: &timeoutSeconds}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+}
+
+func TestHandler_ReconcilerPrerequisites(t *testing.T) {
+	const teamSlug = slug.Slug("my team")
+
+	ctx := context.Background()
+	cfg, _ := config.New()
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Purpose: "some purpose",
+			Enabled: true,
+		},
+	}
+
+	t.Run("prerequisites run first regardless of run order", func(t *testing.T) {
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		runOrder := 1
+
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGoogleGcpProject).Return(log).Once()
+		log.On("WithComponent", types.ComponentNameNaisNamespace).Return(log).Once()
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("Debugf", mock.Anything, mock.Anything).Return(nil)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		for _, name := range []sqlc.ReconcilerName{sqlc.ReconcilerNameGoogleGcpProject, sqlc.ReconcilerNameNaisNamespace} {
+			database.
+				On("GetTeamMembersForReconciler", mock.Anything, teamSlug, name).
+				Return([]*db.User{}, nil).
+				Once()
+			database.
+				On("ClearReconcilerErrorsForTeam", mock.Anything, teamSlug, name).
+				Return(nil).
+				Once()
+		}
+		database.
+			On("SetLastSuccessfulSyncForTeam", mock.Anything, teamSlug).
+			Return(nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			sqlc.ReconcilerNameNaisNamespace: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconcilerWithPrerequisites(t)
+				reconciler.On("Name").Return(sqlc.ReconcilerNameNaisNamespace).Once()
+				reconciler.On("Prerequisites").Return([]sqlc.ReconcilerName{sqlc.ReconcilerNameGoogleGcpProject, sqlc.ReconcilerNameAzureGroup}).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.Equal(t, 2, runOrder)
+					}).
+					Return(nil).
+					Once()
+				return reconciler, nil
+			},
+			sqlc.ReconcilerNameGoogleGcpProject: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(sqlc.ReconcilerNameGoogleGcpProject).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.Equal(t, 1, runOrder)
+						runOrder++
+					}).
+					Return(nil).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameNaisNamespace, RunOrder: 1}}))
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGoogleGcpProject, RunOrder: 2}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+
+	t.Run("reconciler is skipped when a prerequisite fails", func(t *testing.T) {
+		reconcileErr := errors.New("some error")
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+		}
+
+		testLogger, _ := test.NewNullLogger()
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGoogleGcpProject).Return(log)
+		log.On("WithComponent", types.ComponentNameNaisNamespace).Return(log)
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger})
+		log.On("Warnf", "skip reconcile, prerequisite %q failed", sqlc.ReconcilerNameGoogleGcpProject).Return().Once()
+		log.On("Warnf", mock.Anything, mock.Anything).Return()
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, sqlc.ReconcilerNameGoogleGcpProject).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("SetReconcilerErrorForTeam", mock.Anything, input.CorrelationID, teamSlug, sqlc.ReconcilerNameGoogleGcpProject, reconcileErr, sqlc.ReconcilerErrorKindError).
+			Return(&db.ReconcilerError{ReconcilerError: &sqlc.ReconcilerError{Attempts: int32(cfg.ReconcilerRetry.MaxAttempts)}}, nil).
+			Once()
+		database.
+			On("SetReconcilerSkippedForTeam", mock.Anything, input.CorrelationID, teamSlug, sqlc.ReconcilerNameNaisNamespace, mock.MatchedBy(func(err error) bool {
+				return err.Error() == `skipped because "google:gcp:project" failed`
+			})).
+			Return(nil).
+			Once()
+
+		webhookPublisher := webhooks.NewPublisherForTesting()
+		progress := teamsync.NewProgressForTesting()
+		handler := teamsync.NewHandler(ctx, database, cfg, webhookPublisher, progress, log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			sqlc.ReconcilerNameGoogleGcpProject: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(sqlc.ReconcilerNameGoogleGcpProject).Once()
+				reconciler.On("Reconcile", mock.Anything, mock.Anything).Return(reconcileErr).Once()
+				return reconciler, nil
+			},
+			sqlc.ReconcilerNameNaisNamespace: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconcilerWithPrerequisites(t)
+				reconciler.On("Name").Return(sqlc.ReconcilerNameNaisNamespace).Once()
+				reconciler.On("Prerequisites").Return([]sqlc.ReconcilerName{sqlc.ReconcilerNameGoogleGcpProject}).Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGoogleGcpProject, RunOrder: 1}}))
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameNaisNamespace, RunOrder: 2}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
 		handler.Close()
 		handler.SyncTeams(ctx)
+
+		assert.Equal(t, []webhooks.Event{
+			{
+				Type:          sqlc.WebhookEventTypeSyncfailed,
+				CorrelationID: input.CorrelationID,
+				Team:          teamSlug,
+				Message:       "2 reconciler(s) failed",
+				Errors: []*webhooks.ReconcilerError{
+					{Reconciler: sqlc.ReconcilerNameGoogleGcpProject, Error: "some error", Attempts: int32(cfg.ReconcilerRetry.MaxAttempts), RetriesExhausted: true},
+					{Reconciler: sqlc.ReconcilerNameNaisNamespace, Error: `skipped because "google:gcp:project" failed`},
+				},
+			},
+		}, webhookPublisher.Events())
+
+		assert.Equal(t, []teamsync.ProgressEvent{
+			{
+				CorrelationID: input.CorrelationID,
+				TeamSlug:      teamSlug,
+				Reconciler:    sqlc.ReconcilerNameGoogleGcpProject,
+				State:         teamsync.ProgressStateStarted,
+			},
+			{
+				CorrelationID: input.CorrelationID,
+				TeamSlug:      teamSlug,
+				Reconciler:    sqlc.ReconcilerNameGoogleGcpProject,
+				State:         teamsync.ProgressStateFailed,
+				Error:         helpers.Strp("some error"),
+			},
+			{
+				CorrelationID: input.CorrelationID,
+				TeamSlug:      teamSlug,
+				Reconciler:    sqlc.ReconcilerNameNaisNamespace,
+				State:         teamsync.ProgressStateFailed,
+				Error:         helpers.Strp(`skipped because "google:gcp:project" failed`),
+			},
+		}, progress.Events())
+	})
+}
+
+func TestHandler_ParallelReconcilers(t *testing.T) {
+	const teamSlug = slug.Slug("my team")
+
+	ctx := context.Background()
+	cfg, _ := config.New()
+	cfg.ReconcilerParallelism = 2
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Purpose: "some purpose",
+			Enabled: true,
+		},
+	}
+	input := teamsync.Input{
+		CorrelationID: uuid.New(),
+		TeamSlug:      teamSlug,
+	}
+	names := []sqlc.ReconcilerName{azure_group_reconciler.Name, github_team_reconciler.Name, nais_deploy_reconciler.Name}
+
+	log := logger.NewMockLogger(t)
+	log.On("WithTeamSlug", string(teamSlug)).Return(log)
+	log.On("WithComponent", mock.Anything).Return(log)
+	log.On("Infof", "reconcile team").Return(nil).Once()
+	log.On("Debugf", mock.Anything, mock.Anything).Return(nil)
+
+	database := db.NewMockDatabase(t)
+	database.
+		On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+		Return(team, nil).
+		Once()
+	database.
+		On("SetLastSuccessfulSyncForTeam", mock.Anything, teamSlug).
+		Return(nil).
+		Once()
+
+	var running atomic.Int32
+	var startedOnce sync.Once
+	started := make(chan struct{})
+	factories := teamsync.ReconcilerFactories{}
+	for _, name := range names {
+		database.
+			On("GetTeamMembersForReconciler", mock.Anything, teamSlug, name).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("ClearReconcilerErrorsForTeam", mock.Anything, teamSlug, name).
+			Return(nil).
+			Once()
+
+		name := name
+		factories[name] = func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+			reconciler := reconcilers.NewMockReconciler(t)
+			reconciler.On("Name").Return(name).Once()
+			reconciler.
+				On("Reconcile", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					defer running.Add(-1)
+					if running.Add(1) > 2 {
+						t.Errorf("more than 2 reconcilers running at the same time")
+					}
+					if running.Load() == 2 {
+						startedOnce.Do(func() { close(started) })
+					}
+
+					select {
+					case <-started:
+					case <-time.After(5 * time.Second):
+						t.Errorf("reconcilers did not run in parallel")
+					}
+				}).
+				Return(nil).
+				Once()
+			return reconciler, nil
+		}
+	}
+
+	handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+	handler.SetReconcilerFactories(factories)
+	for i, name := range names {
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: name, RunOrder: int32(i + 1)}}))
+	}
+
+	mockTeamSyncQueue(database, input)
+	assert.NoError(t, handler.Schedule(ctx, input))
+	handler.Close()
+	handler.SyncTeams(ctx)
+}
+
+func TestHandler_SelectedReconcilers(t *testing.T) {
+	const teamSlug = slug.Slug("my team")
+
+	ctx := context.Background()
+	cfg, _ := config.New()
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Purpose: "some purpose",
+			Enabled: true,
+		},
+	}
+
+	t.Run("prerequisites of selected reconcilers are included", func(t *testing.T) {
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+			Reconcilers:   []sqlc.ReconcilerName{sqlc.ReconcilerNameNaisNamespace},
+		}
+
+		runOrder := 1
+
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("WithComponent", types.ComponentNameGoogleGcpProject).Return(log).Once()
+		log.On("WithComponent", types.ComponentNameNaisNamespace).Return(log).Once()
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("Debugf", mock.Anything, mock.Anything).Return(nil)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+		for _, name := range []sqlc.ReconcilerName{sqlc.ReconcilerNameGoogleGcpProject, sqlc.ReconcilerNameNaisNamespace} {
+			database.
+				On("GetTeamMembersForReconciler", mock.Anything, teamSlug, name).
+				Return([]*db.User{}, nil).
+				Once()
+			database.
+				On("ClearReconcilerErrorsForTeam", mock.Anything, teamSlug, name).
+				Return(nil).
+				Once()
+		}
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			sqlc.ReconcilerNameGithubTeam: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				return reconcilers.NewMockReconciler(t), nil
+			},
+			sqlc.ReconcilerNameGoogleGcpProject: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconciler(t)
+				reconciler.On("Name").Return(sqlc.ReconcilerNameGoogleGcpProject).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.Equal(t, 1, runOrder)
+						runOrder++
+					}).
+					Return(nil).
+					Once()
+				return reconciler, nil
+			},
+			sqlc.ReconcilerNameNaisNamespace: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconcilerWithPrerequisites(t)
+				reconciler.On("Name").Return(sqlc.ReconcilerNameNaisNamespace).Once()
+				reconciler.On("Prerequisites").Return([]sqlc.ReconcilerName{sqlc.ReconcilerNameGoogleGcpProject}).Once()
+				reconciler.
+					On("Reconcile", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.Equal(t, 2, runOrder)
+					}).
+					Return(nil).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGithubTeam, RunOrder: 1}}))
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGoogleGcpProject, RunOrder: 2}}))
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameNaisNamespace, RunOrder: 3}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+
+	t.Run("selected reconcilers that are not active are ignored", func(t *testing.T) {
+		input := teamsync.Input{
+			CorrelationID: uuid.New(),
+			TeamSlug:      teamSlug,
+			Reconcilers:   []sqlc.ReconcilerName{sqlc.ReconcilerNameAzureGroup},
+		}
+
+		log := logger.NewMockLogger(t)
+		log.On("WithTeamSlug", string(teamSlug)).Return(log)
+		log.On("Infof", "reconcile team").Return(nil).Once()
+		log.On("Debugf", mock.Anything, mock.Anything).Return(nil)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", mock.Anything, teamSlug).
+			Return(team, nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), log)
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			sqlc.ReconcilerNameGithubTeam: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				return reconcilers.NewMockReconciler(t), nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGithubTeam, RunOrder: 1}}))
+
+		mockTeamSyncQueue(database, input)
+		assert.NoError(t, handler.Schedule(ctx, input))
+		handler.Close()
+		handler.SyncTeams(ctx)
+	})
+}
+
+func TestHandler_PlanTeam(t *testing.T) {
+	const teamSlug = slug.Slug("my team")
+
+	ctx := context.Background()
+	cfg, _ := config.New()
+	team := &db.Team{
+		Team: &sqlc.Team{
+			Slug:    teamSlug,
+			Purpose: "some purpose",
+			Enabled: true,
+		},
+	}
+
+	t.Run("unable to get team", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", ctx, teamSlug).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), logger.NewMockLogger(t))
+		plans, err := handler.PlanTeam(ctx, teamSlug)
+		assert.Nil(t, plans)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("only reconcilers supporting plan mode are included", func(t *testing.T) {
+		planErr := errors.New("some error")
+		changes := []reconcilers.PlannedChange{
+			{
+				Action:   reconcilers.PlannedChangeActionCreate,
+				Resource: "my-team",
+				Details:  `Create GitHub team "my-team"`,
+			},
+		}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", ctx, teamSlug).
+			Return(team, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", ctx, teamSlug, sqlc.ReconcilerNameGithubTeam).
+			Return([]*db.User{}, nil).
+			Once()
+		database.
+			On("GetTeamMembersForReconciler", ctx, teamSlug, sqlc.ReconcilerNameAzureGroup).
+			Return([]*db.User{}, nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), logger.NewMockLogger(t))
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			sqlc.ReconcilerNameGithubTeam: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconcilerWithPlan(t)
+				reconciler.
+					On("Plan", ctx, mock.Anything).
+					Return(changes, nil).
+					Once()
+				return reconciler, nil
+			},
+			sqlc.ReconcilerNameNaisDeploy: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				return reconcilers.NewMockReconciler(t), nil
+			},
+			sqlc.ReconcilerNameAzureGroup: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconcilerWithPlan(t)
+				reconciler.
+					On("Plan", ctx, mock.Anything).
+					Return(nil, planErr).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGithubTeam, RunOrder: 1}}))
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameNaisDeploy, RunOrder: 2}}))
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameAzureGroup, RunOrder: 3}}))
+
+		plans, err := handler.PlanTeam(ctx, teamSlug)
+		assert.NoError(t, err)
+		assert.Len(t, plans, 2)
+		assert.Equal(t, sqlc.ReconcilerNameGithubTeam, plans[0].Reconciler)
+		assert.Equal(t, changes, plans[0].Changes)
+		assert.NoError(t, plans[0].Error)
+		assert.Equal(t, sqlc.ReconcilerNameAzureGroup, plans[1].Reconciler)
+		assert.Empty(t, plans[1].Changes)
+		assert.ErrorIs(t, plans[1].Error, planErr)
+	})
+	t.Run("team with suspended resources is planned without members", func(t *testing.T) {
+		suspendedTeam := &db.Team{
+			Team: &sqlc.Team{
+				Slug:               teamSlug,
+				Purpose:            "some purpose",
+				ResourcesSuspended: true,
+			},
+		}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("GetActiveTeamBySlug", ctx, teamSlug).
+			Return(suspendedTeam, nil).
+			Once()
+
+		handler := teamsync.NewHandler(ctx, database, cfg, webhooks.NewPublisherForTesting(), teamsync.NewProgressForTesting(), logger.NewMockLogger(t))
+		handler.SetReconcilerFactories(teamsync.ReconcilerFactories{
+			sqlc.ReconcilerNameGithubTeam: func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
+				reconciler := reconcilers.NewMockReconcilerWithPlan(t)
+				reconciler.
+					On("Plan", ctx, mock.MatchedBy(func(input reconcilers.Input) bool {
+						return input.Team.Slug == teamSlug && len(input.TeamMembers) == 0
+					})).
+					Return([]reconcilers.PlannedChange{}, nil).
+					Once()
+				return reconciler, nil
+			},
+		})
+		assert.NoError(t, handler.UseReconciler(db.Reconciler{Reconciler: &sqlc.Reconciler{Name: sqlc.ReconcilerNameGithubTeam, RunOrder: 1}}))
+
+		plans, err := handler.PlanTeam(ctx, teamSlug)
+		assert.NoError(t, err)
+		assert.Len(t, plans, 1)
+		assert.NoError(t, plans[0].Error)
 	})
 }
 
@@ -311,7 +1338,17 @@ func TestHandler_DeleteTeam(t *testing.T) {
 			Return(&logrus.Entry{Logger: testLogger}).
 			Once()
 
-		handler := teamsync.NewHandler(ctx, database, cfg, log)
+		database.
+			On("SetTeamDeletionReconcilerResult", ctx, teamSlug, azure_group_reconciler.Name, sqlc.TeamDeletionReconcilerStatusSuccess, (*string)(nil)).
+			Return(nil).
+			Once()
+		database.
+			On("SetTeamDeletionReconcilerResult", ctx, teamSlug, github_team_reconciler.Name, sqlc.TeamDeletionReconcilerStatusFailure, helpers.Strp("some error")).
+			Return(nil).
+			Once()
+
+		webhookPublisher := webhooks.NewPublisherForTesting()
+		handler := teamsync.NewHandler(ctx, database, cfg, webhookPublisher, teamsync.NewProgressForTesting(), log)
 
 		reconciler1 := func(context.Context, db.Database, *config.Config, logger.Logger) (reconcilers.Reconciler, error) {
 			reconciler := reconcilers.NewMockReconciler(t)
@@ -350,6 +1387,7 @@ func TestHandler_DeleteTeam(t *testing.T) {
 		err := handler.DeleteTeam(teamSlug, correlationID)
 		assert.EqualError(t, err, "1 error(s) occurred during delete")
 		assert.Len(t, logs.Entries, 1)
+		assert.Empty(t, webhookPublisher.Events())
 	})
 
 	t.Run("no errors", func(t *testing.T) {
@@ -364,7 +1402,123 @@ func TestHandler_DeleteTeam(t *testing.T) {
 			On("WithTeamSlug", string(teamSlug)).
 			Return(log)
 
-		handler := teamsync.NewHandler(ctx, database, cfg, log)
+		webhookPublisher := webhooks.NewPublisherForTesting()
+		handler := teamsync.NewHandler(ctx, database, cfg, webhookPublisher, teamsync.NewProgressForTesting(), log)
+		assert.NoError(t, handler.DeleteTeam(teamSlug, correlationID))
+		assert.Equal(t, []webhooks.Event{
+			{
+				Type:          sqlc.WebhookEventTypeTeamdeleted,
+				CorrelationID: correlationID,
+				Team:          teamSlug,
+				Message:       "Team deleted",
+			},
+		}, webhookPublisher.Events())
+	})
+
+	t.Run("team deletion in database fails", func(t *testing.T) {
+		testLogger, logs := test.NewNullLogger()
+		dbErr := fmt.Errorf("some error")
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("DeleteTeam", ctx, teamSlug).
+			Return(dbErr).
+			Once()
+
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithTeamSlug", string(teamSlug)).
+			Return(log)
+		log.
+			On("WithError", dbErr).
+			Return(&logrus.Entry{Logger: testLogger}).
+			Once()
+
+		webhookPublisher := webhooks.NewPublisherForTesting()
+		handler := teamsync.NewHandler(ctx, database, cfg, webhookPublisher, teamsync.NewProgressForTesting(), log)
 		assert.NoError(t, handler.DeleteTeam(teamSlug, correlationID))
+		assert.Len(t, logs.Entries, 1)
+		assert.Empty(t, webhookPublisher.Events())
 	})
 }
+
+// mockTeamSyncQueue Set up the database mock to accept the given inputs, and hand them out from the team sync queue in
+// the same order
+func mockTeamSyncQueue(database *db.MockDatabase, inputs ...teamsync.Input) {
+	database.
+		On("ReleaseStaleTeamSyncs", mock.Anything, mock.Anything).
+		Return(int64(0), nil).
+		Once()
+
+	for idx, input := range inputs {
+		id := int64(idx + 1)
+		database.
+			On("EnqueueTeamSync", mock.Anything, input.TeamSlug, input.CorrelationID, trigger(input), input.Reconcilers, mock.Anything).
+			Return(nil).
+			Once()
+		database.
+			On("ClaimTeamSync", mock.Anything, mock.Anything).
+			Return(&db.TeamSyncQueueItem{
+				TeamSyncQueue: &sqlc.TeamSyncQueue{
+					ID:            id,
+					TeamSlug:      input.TeamSlug,
+					CorrelationID: input.CorrelationID,
+					TriggeredBy:   trigger(input),
+					Attempts:      1,
+					Reconcilers:   reconcilerNames(input.Reconcilers),
+				},
+			}, nil).
+			Once()
+		database.
+			On("DeleteTeamSync", mock.Anything, id).
+			Return(nil).
+			Once()
+		mockTeamSyncRun(database, id, input)
+	}
+
+	database.
+		On("ClaimTeamSync", mock.Anything, mock.Anything).
+		Return(nil, pgx.ErrNoRows).
+		Once()
+}
+
+// mockTeamSyncRun Set up the database mock to accept storing a run for the given input. The run is optional, as it is
+// not created when the team can not be synced.
+func mockTeamSyncRun(database *db.MockDatabase, id int64, input teamsync.Input) {
+	run := &db.TeamSyncRun{
+		TeamSyncRun: &sqlc.TeamSyncRun{
+			ID:            id,
+			TeamSlug:      input.TeamSlug,
+			CorrelationID: input.CorrelationID,
+			TriggeredBy:   trigger(input),
+		},
+	}
+	database.
+		On("CreateTeamSyncRun", mock.Anything, input.TeamSlug, input.CorrelationID, trigger(input), input.Reconcilers).
+		Return(run, nil).
+		Maybe()
+	database.
+		On("FinishTeamSyncRun", mock.Anything, id, mock.Anything, mock.Anything, mock.Anything).
+		Return(run, nil).
+		Maybe()
+	database.
+		On("DeleteOldTeamSyncRuns", mock.Anything, input.TeamSlug, mock.Anything).
+		Return(int64(0), nil).
+		Maybe()
+}
+
+// trigger Get the trigger that is stored for the given input
+func trigger(input teamsync.Input) sqlc.TeamSyncTrigger {
+	if input.Trigger == "" {
+		return sqlc.TeamSyncTriggerChange
+	}
+	return input.Trigger
+}
+
+func reconcilerNames(reconcilers []sqlc.ReconcilerName) []string {
+	names := make([]string, 0, len(reconcilers))
+	for _, reconciler := range reconcilers {
+		names = append(names, string(reconciler))
+	}
+	return names
+}
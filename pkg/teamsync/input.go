@@ -3,9 +3,25 @@ package teamsync
 import (
 	"github.com/google/uuid"
 	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
 )
 
 type Input struct {
 	CorrelationID uuid.UUID
 	TeamSlug      slug.Slug
+
+	// Trigger What caused the sync. Defaults to a change of the team when empty.
+	Trigger sqlc.TeamSyncTrigger
+
+	// Reconcilers Limit the sync to these reconcilers and their prerequisites. All active reconcilers will run when
+	// empty.
+	Reconcilers []sqlc.ReconcilerName
+}
+
+// trigger Get what caused the sync
+func (i Input) trigger() sqlc.TeamSyncTrigger {
+	if i.Trigger == "" {
+		return sqlc.TeamSyncTriggerChange
+	}
+	return i.Trigger
 }
@@ -12,6 +12,8 @@ import (
 
 	sqlc "github.com/nais/teams-backend/pkg/sqlc"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -60,6 +62,58 @@ func (_c *MockHandler_Close_Call) RunAndReturn(run func()) *MockHandler_Close_Ca
 	return _c
 }
 
+// DeleteDueTeams provides a mock function with given fields: ctx
+func (_m *MockHandler) DeleteDueTeams(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHandler_DeleteDueTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDueTeams'
+type MockHandler_DeleteDueTeams_Call struct {
+	*mock.Call
+}
+
+// DeleteDueTeams is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHandler_Expecter) DeleteDueTeams(ctx interface{}) *MockHandler_DeleteDueTeams_Call {
+	return &MockHandler_DeleteDueTeams_Call{Call: _e.mock.On("DeleteDueTeams", ctx)}
+}
+
+func (_c *MockHandler_DeleteDueTeams_Call) Run(run func(ctx context.Context)) *MockHandler_DeleteDueTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockHandler_DeleteDueTeams_Call) Return(_a0 int, _a1 error) *MockHandler_DeleteDueTeams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHandler_DeleteDueTeams_Call) RunAndReturn(run func(context.Context) (int, error)) *MockHandler_DeleteDueTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteTeam provides a mock function with given fields: teamSlug, correlationID
 func (_m *MockHandler) DeleteTeam(teamSlug slug.Slug, correlationID uuid.UUID) error {
 	ret := _m.Called(teamSlug, correlationID)
@@ -145,6 +199,61 @@ func (_c *MockHandler_InitReconcilers_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// PlanTeam provides a mock function with given fields: ctx, teamSlug
+func (_m *MockHandler) PlanTeam(ctx context.Context, teamSlug slug.Slug) ([]*ReconcilerPlan, error) {
+	ret := _m.Called(ctx, teamSlug)
+
+	var r0 []*ReconcilerPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) ([]*ReconcilerPlan, error)); ok {
+		return rf(ctx, teamSlug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slug.Slug) []*ReconcilerPlan); ok {
+		r0 = rf(ctx, teamSlug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ReconcilerPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slug.Slug) error); ok {
+		r1 = rf(ctx, teamSlug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHandler_PlanTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanTeam'
+type MockHandler_PlanTeam_Call struct {
+	*mock.Call
+}
+
+// PlanTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamSlug slug.Slug
+func (_e *MockHandler_Expecter) PlanTeam(ctx interface{}, teamSlug interface{}) *MockHandler_PlanTeam_Call {
+	return &MockHandler_PlanTeam_Call{Call: _e.mock.On("PlanTeam", ctx, teamSlug)}
+}
+
+func (_c *MockHandler_PlanTeam_Call) Run(run func(ctx context.Context, teamSlug slug.Slug)) *MockHandler_PlanTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slug.Slug))
+	})
+	return _c
+}
+
+func (_c *MockHandler_PlanTeam_Call) Return(_a0 []*ReconcilerPlan, _a1 error) *MockHandler_PlanTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHandler_PlanTeam_Call) RunAndReturn(run func(context.Context, slug.Slug) ([]*ReconcilerPlan, error)) *MockHandler_PlanTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveReconciler provides a mock function with given fields: reconcilerName
 func (_m *MockHandler) RemoveReconciler(reconcilerName sqlc.ReconcilerName) {
 	_m.Called(reconcilerName)
@@ -178,13 +287,48 @@ func (_c *MockHandler_RemoveReconciler_Call) RunAndReturn(run func(sqlc.Reconcil
 	return _c
 }
 
-// Schedule provides a mock function with given fields: input
-func (_m *MockHandler) Schedule(input Input) error {
-	ret := _m.Called(input)
+// RunTeamDeletions provides a mock function with given fields: ctx, interval, isLeader
+func (_m *MockHandler) RunTeamDeletions(ctx context.Context, interval time.Duration, isLeader func() bool) {
+	_m.Called(ctx, interval, isLeader)
+}
+
+// MockHandler_RunTeamDeletions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunTeamDeletions'
+type MockHandler_RunTeamDeletions_Call struct {
+	*mock.Call
+}
+
+// RunTeamDeletions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - interval time.Duration
+//   - isLeader func() bool
+func (_e *MockHandler_Expecter) RunTeamDeletions(ctx interface{}, interval interface{}, isLeader interface{}) *MockHandler_RunTeamDeletions_Call {
+	return &MockHandler_RunTeamDeletions_Call{Call: _e.mock.On("RunTeamDeletions", ctx, interval, isLeader)}
+}
+
+func (_c *MockHandler_RunTeamDeletions_Call) Run(run func(ctx context.Context, interval time.Duration, isLeader func() bool)) *MockHandler_RunTeamDeletions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration), args[2].(func() bool))
+	})
+	return _c
+}
+
+func (_c *MockHandler_RunTeamDeletions_Call) Return() *MockHandler_RunTeamDeletions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHandler_RunTeamDeletions_Call) RunAndReturn(run func(context.Context, time.Duration, func() bool)) *MockHandler_RunTeamDeletions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Schedule provides a mock function with given fields: ctx, input
+func (_m *MockHandler) Schedule(ctx context.Context, input Input) error {
+	ret := _m.Called(ctx, input)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(Input) error); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, Input) error); ok {
+		r0 = rf(ctx, input)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -198,14 +342,15 @@ type MockHandler_Schedule_Call struct {
 }
 
 // Schedule is a helper method to define mock.On call
+//   - ctx context.Context
 //   - input Input
-func (_e *MockHandler_Expecter) Schedule(input interface{}) *MockHandler_Schedule_Call {
-	return &MockHandler_Schedule_Call{Call: _e.mock.On("Schedule", input)}
+func (_e *MockHandler_Expecter) Schedule(ctx interface{}, input interface{}) *MockHandler_Schedule_Call {
+	return &MockHandler_Schedule_Call{Call: _e.mock.On("Schedule", ctx, input)}
 }
 
-func (_c *MockHandler_Schedule_Call) Run(run func(input Input)) *MockHandler_Schedule_Call {
+func (_c *MockHandler_Schedule_Call) Run(run func(ctx context.Context, input Input)) *MockHandler_Schedule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(Input))
+		run(args[0].(context.Context), args[1].(Input))
 	})
 	return _c
 }
@@ -215,30 +360,30 @@ func (_c *MockHandler_Schedule_Call) Return(_a0 error) *MockHandler_Schedule_Cal
 	return _c
 }
 
-func (_c *MockHandler_Schedule_Call) RunAndReturn(run func(Input) error) *MockHandler_Schedule_Call {
+func (_c *MockHandler_Schedule_Call) RunAndReturn(run func(context.Context, Input) error) *MockHandler_Schedule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ScheduleAllTeams provides a mock function with given fields: ctx, correlationID
-func (_m *MockHandler) ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID) ([]*db.Team, error) {
-	ret := _m.Called(ctx, correlationID)
+// ScheduleAllTeams provides a mock function with given fields: ctx, correlationID, trigger
+func (_m *MockHandler) ScheduleAllTeams(ctx context.Context, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger) ([]*db.Team, error) {
+	ret := _m.Called(ctx, correlationID, trigger)
 
 	var r0 []*db.Team
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*db.Team, error)); ok {
-		return rf(ctx, correlationID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.TeamSyncTrigger) ([]*db.Team, error)); ok {
+		return rf(ctx, correlationID, trigger)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*db.Team); ok {
-		r0 = rf(ctx, correlationID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, sqlc.TeamSyncTrigger) []*db.Team); ok {
+		r0 = rf(ctx, correlationID, trigger)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*db.Team)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, correlationID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, sqlc.TeamSyncTrigger) error); ok {
+		r1 = rf(ctx, correlationID, trigger)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -254,13 +399,14 @@ type MockHandler_ScheduleAllTeams_Call struct {
 // ScheduleAllTeams is a helper method to define mock.On call
 //   - ctx context.Context
 //   - correlationID uuid.UUID
-func (_e *MockHandler_Expecter) ScheduleAllTeams(ctx interface{}, correlationID interface{}) *MockHandler_ScheduleAllTeams_Call {
-	return &MockHandler_ScheduleAllTeams_Call{Call: _e.mock.On("ScheduleAllTeams", ctx, correlationID)}
+//   - trigger sqlc.TeamSyncTrigger
+func (_e *MockHandler_Expecter) ScheduleAllTeams(ctx interface{}, correlationID interface{}, trigger interface{}) *MockHandler_ScheduleAllTeams_Call {
+	return &MockHandler_ScheduleAllTeams_Call{Call: _e.mock.On("ScheduleAllTeams", ctx, correlationID, trigger)}
 }
 
-func (_c *MockHandler_ScheduleAllTeams_Call) Run(run func(ctx context.Context, correlationID uuid.UUID)) *MockHandler_ScheduleAllTeams_Call {
+func (_c *MockHandler_ScheduleAllTeams_Call) Run(run func(ctx context.Context, correlationID uuid.UUID, trigger sqlc.TeamSyncTrigger)) *MockHandler_ScheduleAllTeams_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(sqlc.TeamSyncTrigger))
 	})
 	return _c
 }
@@ -270,7 +416,63 @@ func (_c *MockHandler_ScheduleAllTeams_Call) Return(_a0 []*db.Team, _a1 error) *
 	return _c
 }
 
-func (_c *MockHandler_ScheduleAllTeams_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*db.Team, error)) *MockHandler_ScheduleAllTeams_Call {
+func (_c *MockHandler_ScheduleAllTeams_Call) RunAndReturn(run func(context.Context, uuid.UUID, sqlc.TeamSyncTrigger) ([]*db.Team, error)) *MockHandler_ScheduleAllTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleTeamDeletion provides a mock function with given fields: ctx, deleteKey, correlationID
+func (_m *MockHandler) ScheduleTeamDeletion(ctx context.Context, deleteKey *db.TeamDeleteKey, correlationID uuid.UUID) (*db.TeamDeletion, error) {
+	ret := _m.Called(ctx, deleteKey, correlationID)
+
+	var r0 *db.TeamDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.TeamDeleteKey, uuid.UUID) (*db.TeamDeletion, error)); ok {
+		return rf(ctx, deleteKey, correlationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.TeamDeleteKey, uuid.UUID) *db.TeamDeletion); ok {
+		r0 = rf(ctx, deleteKey, correlationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.TeamDeletion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *db.TeamDeleteKey, uuid.UUID) error); ok {
+		r1 = rf(ctx, deleteKey, correlationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHandler_ScheduleTeamDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleTeamDeletion'
+type MockHandler_ScheduleTeamDeletion_Call struct {
+	*mock.Call
+}
+
+// ScheduleTeamDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deleteKey *db.TeamDeleteKey
+//   - correlationID uuid.UUID
+func (_e *MockHandler_Expecter) ScheduleTeamDeletion(ctx interface{}, deleteKey interface{}, correlationID interface{}) *MockHandler_ScheduleTeamDeletion_Call {
+	return &MockHandler_ScheduleTeamDeletion_Call{Call: _e.mock.On("ScheduleTeamDeletion", ctx, deleteKey, correlationID)}
+}
+
+func (_c *MockHandler_ScheduleTeamDeletion_Call) Run(run func(ctx context.Context, deleteKey *db.TeamDeleteKey, correlationID uuid.UUID)) *MockHandler_ScheduleTeamDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.TeamDeleteKey), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHandler_ScheduleTeamDeletion_Call) Return(_a0 *db.TeamDeletion, _a1 error) *MockHandler_ScheduleTeamDeletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHandler_ScheduleTeamDeletion_Call) RunAndReturn(run func(context.Context, *db.TeamDeleteKey, uuid.UUID) (*db.TeamDeletion, error)) *MockHandler_ScheduleTeamDeletion_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -308,6 +510,51 @@ func (_c *MockHandler_SetReconcilerFactories_Call) RunAndReturn(run func(Reconci
 	return _c
 }
 
+// SubscribeProgress provides a mock function with given fields: ctx, correlationID
+func (_m *MockHandler) SubscribeProgress(ctx context.Context, correlationID uuid.UUID) <-chan *ProgressEvent {
+	ret := _m.Called(ctx, correlationID)
+
+	var r0 <-chan *ProgressEvent
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) <-chan *ProgressEvent); ok {
+		r0 = rf(ctx, correlationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *ProgressEvent)
+		}
+	}
+
+	return r0
+}
+
+// MockHandler_SubscribeProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeProgress'
+type MockHandler_SubscribeProgress_Call struct {
+	*mock.Call
+}
+
+// SubscribeProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - correlationID uuid.UUID
+func (_e *MockHandler_Expecter) SubscribeProgress(ctx interface{}, correlationID interface{}) *MockHandler_SubscribeProgress_Call {
+	return &MockHandler_SubscribeProgress_Call{Call: _e.mock.On("SubscribeProgress", ctx, correlationID)}
+}
+
+func (_c *MockHandler_SubscribeProgress_Call) Run(run func(ctx context.Context, correlationID uuid.UUID)) *MockHandler_SubscribeProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHandler_SubscribeProgress_Call) Return(_a0 <-chan *ProgressEvent) *MockHandler_SubscribeProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHandler_SubscribeProgress_Call) RunAndReturn(run func(context.Context, uuid.UUID) <-chan *ProgressEvent) *MockHandler_SubscribeProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SyncTeams provides a mock function with given fields: ctx
 func (_m *MockHandler) SyncTeams(ctx context.Context) {
 	_m.Called(ctx)
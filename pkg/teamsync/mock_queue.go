@@ -2,7 +2,12 @@
 
 package teamsync
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockQueue is an autogenerated mock type for the Queue type
 type MockQueue struct {
@@ -17,13 +22,13 @@ func (_m *MockQueue) EXPECT() *MockQueue_Expecter {
 	return &MockQueue_Expecter{mock: &_m.Mock}
 }
 
-// Add provides a mock function with given fields: _a0
-func (_m *MockQueue) Add(_a0 Input) error {
-	ret := _m.Called(_a0)
+// Add provides a mock function with given fields: ctx, input
+func (_m *MockQueue) Add(ctx context.Context, input Input) error {
+	ret := _m.Called(ctx, input)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(Input) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, Input) error); ok {
+		r0 = rf(ctx, input)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -37,14 +42,15 @@ type MockQueue_Add_Call struct {
 }
 
 // Add is a helper method to define mock.On call
-//   - _a0 Input
-func (_e *MockQueue_Expecter) Add(_a0 interface{}) *MockQueue_Add_Call {
-	return &MockQueue_Add_Call{Call: _e.mock.On("Add", _a0)}
+//   - ctx context.Context
+//   - input Input
+func (_e *MockQueue_Expecter) Add(ctx interface{}, input interface{}) *MockQueue_Add_Call {
+	return &MockQueue_Add_Call{Call: _e.mock.On("Add", ctx, input)}
 }
 
-func (_c *MockQueue_Add_Call) Run(run func(_a0 Input)) *MockQueue_Add_Call {
+func (_c *MockQueue_Add_Call) Run(run func(ctx context.Context, input Input)) *MockQueue_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(Input))
+		run(args[0].(context.Context), args[1].(Input))
 	})
 	return _c
 }
@@ -54,7 +60,51 @@ func (_c *MockQueue_Add_Call) Return(_a0 error) *MockQueue_Add_Call {
 	return _c
 }
 
-func (_c *MockQueue_Add_Call) RunAndReturn(run func(Input) error) *MockQueue_Add_Call {
+func (_c *MockQueue_Add_Call) RunAndReturn(run func(context.Context, Input) error) *MockQueue_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddAt provides a mock function with given fields: ctx, input, runAt
+func (_m *MockQueue) AddAt(ctx context.Context, input Input, runAt time.Time) error {
+	ret := _m.Called(ctx, input, runAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Input, time.Time) error); ok {
+		r0 = rf(ctx, input, runAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQueue_AddAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAt'
+type MockQueue_AddAt_Call struct {
+	*mock.Call
+}
+
+// AddAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input Input
+//   - runAt time.Time
+func (_e *MockQueue_Expecter) AddAt(ctx interface{}, input interface{}, runAt interface{}) *MockQueue_AddAt_Call {
+	return &MockQueue_AddAt_Call{Call: _e.mock.On("AddAt", ctx, input, runAt)}
+}
+
+func (_c *MockQueue_AddAt_Call) Run(run func(ctx context.Context, input Input, runAt time.Time)) *MockQueue_AddAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Input), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockQueue_AddAt_Call) Return(_a0 error) *MockQueue_AddAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQueue_AddAt_Call) RunAndReturn(run func(context.Context, Input, time.Time) error) *MockQueue_AddAt_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -91,6 +141,146 @@ func (_c *MockQueue_Close_Call) RunAndReturn(run func()) *MockQueue_Close_Call {
 	return _c
 }
 
+// Done provides a mock function with given fields: ctx, item
+func (_m *MockQueue) Done(ctx context.Context, item *QueueItem) error {
+	ret := _m.Called(ctx, item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *QueueItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQueue_Done_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Done'
+type MockQueue_Done_Call struct {
+	*mock.Call
+}
+
+// Done is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *QueueItem
+func (_e *MockQueue_Expecter) Done(ctx interface{}, item interface{}) *MockQueue_Done_Call {
+	return &MockQueue_Done_Call{Call: _e.mock.On("Done", ctx, item)}
+}
+
+func (_c *MockQueue_Done_Call) Run(run func(ctx context.Context, item *QueueItem)) *MockQueue_Done_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*QueueItem))
+	})
+	return _c
+}
+
+func (_c *MockQueue_Done_Call) Return(_a0 error) *MockQueue_Done_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQueue_Done_Call) RunAndReturn(run func(context.Context, *QueueItem) error) *MockQueue_Done_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Next provides a mock function with given fields: ctx
+func (_m *MockQueue) Next(ctx context.Context) (*QueueItem, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *QueueItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*QueueItem, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *QueueItem); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*QueueItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueue_Next_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Next'
+type MockQueue_Next_Call struct {
+	*mock.Call
+}
+
+// Next is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQueue_Expecter) Next(ctx interface{}) *MockQueue_Next_Call {
+	return &MockQueue_Next_Call{Call: _e.mock.On("Next", ctx)}
+}
+
+func (_c *MockQueue_Next_Call) Run(run func(ctx context.Context)) *MockQueue_Next_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQueue_Next_Call) Return(_a0 *QueueItem, _a1 error) *MockQueue_Next_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueue_Next_Call) RunAndReturn(run func(context.Context) (*QueueItem, error)) *MockQueue_Next_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, item
+func (_m *MockQueue) Release(ctx context.Context, item *QueueItem) error {
+	ret := _m.Called(ctx, item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *QueueItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQueue_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockQueue_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *QueueItem
+func (_e *MockQueue_Expecter) Release(ctx interface{}, item interface{}) *MockQueue_Release_Call {
+	return &MockQueue_Release_Call{Call: _e.mock.On("Release", ctx, item)}
+}
+
+func (_c *MockQueue_Release_Call) Run(run func(ctx context.Context, item *QueueItem)) *MockQueue_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*QueueItem))
+	})
+	return _c
+}
+
+func (_c *MockQueue_Release_Call) Return(_a0 error) *MockQueue_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQueue_Release_Call) RunAndReturn(run func(context.Context, *QueueItem) error) *MockQueue_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockQueue creates a new instance of MockQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockQueue(t interface {
@@ -0,0 +1,189 @@
+package teamsync
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+type ProgressState string
+
+const (
+	ProgressStateStarted   ProgressState = "STARTED"
+	ProgressStateSucceeded ProgressState = "SUCCEEDED"
+	ProgressStateFailed    ProgressState = "FAILED"
+)
+
+const (
+	// progressSubscriberBuffer The number of events buffered for each subscriber. Events are dropped for subscribers
+	// that do not keep up.
+	progressSubscriberBuffer = 32
+
+	// progressListenRetryInterval How long to wait before listening again when the connection to the database fails
+	progressListenRetryInterval = 5 * time.Second
+
+	// progressMaxErrorLength Errors are truncated to keep events within the size limit of database notifications
+	progressMaxErrorLength = 1000
+)
+
+// ProgressEvent A reconciler has started, succeeded or failed for a team during a team sync
+type ProgressEvent struct {
+	CorrelationID uuid.UUID           `json:"correlationID"`
+	TeamSlug      slug.Slug           `json:"teamSlug"`
+	Reconciler    sqlc.ReconcilerName `json:"reconciler"`
+	State         ProgressState       `json:"state"`
+	Error         *string             `json:"error,omitempty"`
+	CreatedAt     time.Time           `json:"createdAt"`
+}
+
+type Progress interface {
+	Publish(ctx context.Context, event ProgressEvent)
+	Subscribe(ctx context.Context, correlationID uuid.UUID) <-chan *ProgressEvent
+	Run(ctx context.Context)
+}
+
+type progress struct {
+	database    db.Database
+	log         logger.Logger
+	lock        sync.Mutex
+	subscribers map[uuid.UUID]map[chan *ProgressEvent]struct{}
+}
+
+type progressForTesting struct {
+	lock   sync.Mutex
+	events []ProgressEvent
+}
+
+// NewProgress Create a broker for team sync progress events. Events are broadcast to all replicas through the
+// database, since a team sync can be processed by any replica, and the subscriber can be connected to any replica.
+func NewProgress(database db.Database, log logger.Logger) Progress {
+	return &progress{
+		database:    database,
+		log:         log,
+		subscribers: make(map[uuid.UUID]map[chan *ProgressEvent]struct{}),
+	}
+}
+
+func NewProgressForTesting() *progressForTesting {
+	return &progressForTesting{
+		events: make([]ProgressEvent, 0),
+	}
+}
+
+func (p *progressForTesting) Publish(_ context.Context, event ProgressEvent) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *progressForTesting) Subscribe(ctx context.Context, _ uuid.UUID) <-chan *ProgressEvent {
+	ch := make(chan *ProgressEvent)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch
+}
+
+func (p *progressForTesting) Run(ctx context.Context) {
+	<-ctx.Done()
+}
+
+func (p *progressForTesting) Events() []ProgressEvent {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.events
+}
+
+// Publish Broadcast an event to the subscribers on all replicas. Failures are logged and not returned, as progress
+// events must never fail a team sync.
+func (p *progress) Publish(ctx context.Context, event ProgressEvent) {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	if event.Error != nil {
+		event.Error = helpers.Strp(helpers.Truncate(*event.Error, progressMaxErrorLength))
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		p.log.WithError(err).Error("encode team sync progress event")
+		return
+	}
+
+	if err := p.database.NotifyTeamSyncProgress(ctx, payload); err != nil {
+		p.log.WithTeamSlug(string(event.TeamSlug)).WithError(err).Error("publish team sync progress event")
+	}
+}
+
+// Subscribe Get the events of the team syncs with a correlation ID. The channel is closed when the context is done.
+func (p *progress) Subscribe(ctx context.Context, correlationID uuid.UUID) <-chan *ProgressEvent {
+	ch := make(chan *ProgressEvent, progressSubscriberBuffer)
+
+	p.lock.Lock()
+	if _, exists := p.subscribers[correlationID]; !exists {
+		p.subscribers[correlationID] = make(map[chan *ProgressEvent]struct{})
+	}
+	p.subscribers[correlationID][ch] = struct{}{}
+	p.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		delete(p.subscribers[correlationID], ch)
+		if len(p.subscribers[correlationID]) == 0 {
+			delete(p.subscribers, correlationID)
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Run Receive events from all replicas and pass them on to the local subscribers until the context is done
+func (p *progress) Run(ctx context.Context) {
+	for {
+		err := p.database.ListenTeamSyncProgress(ctx, p.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+		p.log.WithError(err).Warn("listen for team sync progress events")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(progressListenRetryInterval):
+		}
+	}
+}
+
+// dispatch Pass an event on to the local subscribers of its correlation ID
+func (p *progress) dispatch(payload []byte) {
+	event := &ProgressEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		p.log.WithError(err).Error("decode team sync progress event")
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for ch := range p.subscribers[event.CorrelationID] {
+		select {
+		case ch <- event:
+		default:
+			p.log.WithTeamSlug(string(event.TeamSlug)).Warnf("drop team sync progress event for slow subscriber")
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package teamsync_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/teamsync"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProgress_Publish(t *testing.T) {
+	ctx := context.Background()
+	correlationID := uuid.New()
+
+	var payload []byte
+	database := db.NewMockDatabase(t)
+	database.
+		On("NotifyTeamSyncProgress", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			payload = args.Get(1).([]byte)
+		}).
+		Return(nil).
+		Once()
+
+	teamsync.NewProgress(database, logger.NewMockLogger(t)).Publish(ctx, teamsync.ProgressEvent{
+		CorrelationID: correlationID,
+		TeamSlug:      "my-team",
+		Reconciler:    sqlc.ReconcilerNameGithubTeam,
+		State:         teamsync.ProgressStateFailed,
+		Error:         helpers.Strp(strings.Repeat("x", 2000)),
+	})
+
+	event := teamsync.ProgressEvent{}
+	assert.NoError(t, json.Unmarshal(payload, &event))
+	assert.Equal(t, correlationID, event.CorrelationID)
+	assert.Equal(t, slug.Slug("my-team"), event.TeamSlug)
+	assert.Equal(t, teamsync.ProgressStateFailed, event.State)
+	assert.False(t, event.CreatedAt.IsZero())
+	assert.Len(t, *event.Error, 1000)
+}
+
+func TestProgress_Subscribe(t *testing.T) {
+	correlationID := uuid.New()
+	otherCorrelationID := uuid.New()
+
+	encode := func(event teamsync.ProgressEvent) []byte {
+		payload, _ := json.Marshal(event)
+		return payload
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testLogger, _ := test.NewNullLogger()
+	log := logger.NewMockLogger(t)
+	log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger}).Once()
+
+	listening := make(chan func(payload []byte))
+	database := db.NewMockDatabase(t)
+	database.
+		On("ListenTeamSyncProgress", ctx, mock.Anything).
+		Run(func(args mock.Arguments) {
+			listening <- args.Get(1).(func(payload []byte))
+			<-ctx.Done()
+		}).
+		Return(context.Canceled).
+		Once()
+
+	progress := teamsync.NewProgress(database, log)
+	subscriberCtx, unsubscribe := context.WithCancel(ctx)
+	events := progress.Subscribe(subscriberCtx, correlationID)
+
+	done := make(chan struct{})
+	go func() {
+		progress.Run(ctx)
+		close(done)
+	}()
+	dispatch := <-listening
+
+	dispatch(encode(teamsync.ProgressEvent{CorrelationID: otherCorrelationID, TeamSlug: "other-team", Reconciler: sqlc.ReconcilerNameGithubTeam, State: teamsync.ProgressStateStarted}))
+	dispatch([]byte("invalid"))
+	dispatch(encode(teamsync.ProgressEvent{CorrelationID: correlationID, TeamSlug: "my-team", Reconciler: sqlc.ReconcilerNameGithubTeam, State: teamsync.ProgressStateSucceeded}))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, correlationID, event.CorrelationID)
+		assert.Equal(t, slug.Slug("my-team"), event.TeamSlug)
+		assert.Equal(t, teamsync.ProgressStateSucceeded, event.State)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	unsubscribe()
+	for range events {
+		t.Fatal("unexpected event after unsubscribe")
+	}
+
+	cancel()
+	<-done
+}
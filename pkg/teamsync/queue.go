@@ -1,46 +1,193 @@
 package teamsync
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"sync"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+const (
+	// queuePollInterval How long a worker waits before checking the queue again when there is no work available
+	queuePollInterval = time.Second
+
+	// queueStaleLockMargin Locks held this long past the team sync timeout are considered abandoned, for instance
+	// because the replica that claimed the item was killed in the middle of a sync
+	queueStaleLockMargin = 5 * time.Minute
+
+	// queueStaleLockCheckInterval How often to look for abandoned locks
+	queueStaleLockCheckInterval = time.Minute
+
+	// pgUniqueViolation Postgres error code returned when two workers race to claim the same team
+	pgUniqueViolation = "23505"
 )
 
-const reconcilerQueueSize = 4096
+var ErrQueueClosed = errors.New("team sync queue is closed")
 
+// Queue A persistent queue of team syncs, shared by all replicas of teams-backend
 type Queue interface {
-	Add(Input) error
+	// Add Persist a team sync to the queue
+	Add(ctx context.Context, input Input) error
+
+	// AddAt Persist a team sync to the queue that will not be handed out before runAt
+	AddAt(ctx context.Context, input Input, runAt time.Time) error
+
+	// Next Claim the next team sync that is due. Blocks until an item is available, the context is done, or the queue
+	// is closed and empty.
+	Next(ctx context.Context) (*QueueItem, error)
+
+	// Done Remove a claimed item from the queue
+	Done(ctx context.Context, item *QueueItem) error
+
+	// Release Give up a claimed item so that it can be picked up again by any worker
+	Release(ctx context.Context, item *QueueItem) error
+
+	// Close Stop handing out items once the queue is empty, and refuse new items
 	Close()
 }
 
+// QueueItem A team sync claimed from the queue
+type QueueItem struct {
+	ID       int64
+	Attempts int32
+	Input    Input
+}
+
 type queue struct {
-	queue  chan Input
-	closed bool
-	lock   sync.Mutex
+	database       db.Database
+	workerID       string
+	syncTimeout    func() time.Duration
+	log            logger.Logger
+	closed         chan struct{}
+	closeOnce      sync.Once
+	lock           sync.Mutex
+	lastStaleCheck time.Time
 }
 
-func NewQueue() (Queue, <-chan Input) {
-	ch := make(chan Input, reconcilerQueueSize)
+// NewQueue Create a team sync queue. syncTimeout returns the time allowed for a team sync, which is used to detect
+// abandoned items.
+func NewQueue(database db.Database, workerID string, syncTimeout func() time.Duration, log logger.Logger) Queue {
 	return &queue{
-		queue:  ch,
-		closed: false,
-	}, ch
+		database:    database,
+		workerID:    workerID,
+		syncTimeout: syncTimeout,
+		log:         log,
+		closed:      make(chan struct{}),
+	}
 }
 
-func (q *queue) Add(input Input) error {
-	q.lock.Lock()
-	defer q.lock.Unlock()
+func (q *queue) Add(ctx context.Context, input Input) error {
+	return q.AddAt(ctx, input, time.Now())
+}
+
+func (q *queue) AddAt(ctx context.Context, input Input, runAt time.Time) error {
+	if q.isClosed() {
+		return ErrQueueClosed
+	}
+
+	return q.database.EnqueueTeamSync(ctx, input.TeamSlug, input.CorrelationID, input.trigger(), input.Reconcilers, runAt)
+}
+
+func (q *queue) Next(ctx context.Context) (*QueueItem, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		q.releaseStaleLocks(ctx)
 
-	if q.closed {
-		return fmt.Errorf("team reconciler channel is closed")
+		item, err := q.database.ClaimTeamSync(ctx, q.workerID)
+		if err == nil {
+			return &QueueItem{
+				ID:       item.ID,
+				Attempts: item.Attempts,
+				Input: Input{
+					CorrelationID: item.CorrelationID,
+					TeamSlug:      item.TeamSlug,
+					Trigger:       item.TriggeredBy,
+					Reconcilers:   reconcilerNames(item.Reconcilers),
+				},
+			}, nil
+		}
+
+		if !errors.Is(err, pgx.ErrNoRows) && !isUniqueViolation(err) {
+			q.log.WithError(err).Error("claim team sync from queue")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.closed:
+			return nil, ErrQueueClosed
+		case <-time.After(queuePollInterval):
+		}
 	}
+}
 
-	q.queue <- input
-	return nil
+func (q *queue) Done(ctx context.Context, item *QueueItem) error {
+	return q.database.DeleteTeamSync(ctx, item.ID)
+}
+
+func (q *queue) Release(ctx context.Context, item *QueueItem) error {
+	return q.database.ReleaseTeamSync(ctx, item.ID)
 }
 
 func (q *queue) Close() {
+	q.closeOnce.Do(func() {
+		close(q.closed)
+	})
+}
+
+func (q *queue) isClosed() bool {
+	select {
+	case <-q.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseStaleLocks Make items claimed by workers that are no longer around available again. Only runs once every
+// queueStaleLockCheckInterval, regardless of the number of workers in this process.
+func (q *queue) releaseStaleLocks(ctx context.Context) {
 	q.lock.Lock()
-	defer q.lock.Unlock()
-	q.closed = true
-	close(q.queue)
+	if time.Since(q.lastStaleCheck) < queueStaleLockCheckInterval {
+		q.lock.Unlock()
+		return
+	}
+	q.lastStaleCheck = time.Now()
+	q.lock.Unlock()
+
+	released, err := q.database.ReleaseStaleTeamSyncs(ctx, time.Now().Add(-q.syncTimeout()-queueStaleLockMargin))
+	if err != nil {
+		q.log.WithError(err).Error("release stale team syncs")
+		return
+	}
+
+	if released > 0 {
+		q.log.Warnf("released %d stale team sync(s) from the queue", released)
+	}
+}
+
+func reconcilerNames(names []string) []sqlc.ReconcilerName {
+	if len(names) == 0 {
+		return nil
+	}
+
+	reconcilers := make([]sqlc.ReconcilerName, 0, len(names))
+	for _, name := range names {
+		reconcilers = append(reconcilers, sqlc.ReconcilerName(name))
+	}
+	return reconcilers
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
 }
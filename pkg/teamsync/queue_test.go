@@ -1,44 +1,214 @@
 package teamsync_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
 	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
 	"github.com/nais/teams-backend/pkg/teamsync"
-
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_Queue(t *testing.T) {
+	const workerID = "worker-1"
+	ctx := context.Background()
+	syncTimeout := func() time.Duration { return 20 * time.Minute }
 	input := teamsync.Input{
 		TeamSlug:      slug.Slug("slug"),
 		CorrelationID: uuid.New(),
 	}
 
 	t.Run("add to queue", func(t *testing.T) {
-		q, ch := teamsync.NewQueue()
-		assert.Nil(t, q.Add(input))
-		assert.Len(t, ch, 1)
-		assert.Equal(t, input, <-ch)
-		assert.Len(t, ch, 0)
-	})
-
-	t.Run("race test", func(t *testing.T) {
-		q, _ := teamsync.NewQueue()
-		go func(q teamsync.Queue) {
-			for i := 0; i < 100; i++ {
-				q.Add(input)
-				time.Sleep(time.Millisecond)
-			}
-		}(q)
+		database := db.NewMockDatabase(t)
+		database.
+			On("EnqueueTeamSync", ctx, input.TeamSlug, input.CorrelationID, sqlc.TeamSyncTriggerChange, input.Reconcilers, mock.AnythingOfType("time.Time")).
+			Return(nil).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, logger.NewMockLogger(t))
+		assert.NoError(t, q.Add(ctx, input))
+	})
+
+	t.Run("add to queue with a later run time", func(t *testing.T) {
+		runAt := time.Now().Add(time.Hour)
+		input := teamsync.Input{
+			TeamSlug:      slug.Slug("slug"),
+			CorrelationID: uuid.New(),
+			Reconcilers:   []sqlc.ReconcilerName{sqlc.ReconcilerNameGithubTeam},
+		}
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("EnqueueTeamSync", ctx, input.TeamSlug, input.CorrelationID, sqlc.TeamSyncTriggerChange, input.Reconcilers, runAt).
+			Return(nil).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, logger.NewMockLogger(t))
+		assert.NoError(t, q.AddAt(ctx, input, runAt))
+	})
+
+	t.Run("add to closed queue", func(t *testing.T) {
+		q := teamsync.NewQueue(db.NewMockDatabase(t), workerID, syncTimeout, logger.NewMockLogger(t))
+		q.Close()
+		assert.ErrorIs(t, q.Add(ctx, input), teamsync.ErrQueueClosed)
+	})
+
+	t.Run("claim next item", func(t *testing.T) {
+		// locks are released when held 5 minutes past the team sync timeout
+		isStaleCutoff := mock.MatchedBy(func(lockedBefore time.Time) bool {
+			age := time.Since(lockedBefore)
+			return age >= 25*time.Minute && age < 26*time.Minute
+		})
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamSyncs", ctx, isStaleCutoff).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("ClaimTeamSync", ctx, workerID).
+			Return(&db.TeamSyncQueueItem{
+				TeamSyncQueue: &sqlc.TeamSyncQueue{
+					ID:            123,
+					TeamSlug:      input.TeamSlug,
+					CorrelationID: input.CorrelationID,
+					Attempts:      1,
+				},
+			}, nil).
+			Once()
+		database.
+			On("DeleteTeamSync", ctx, int64(123)).
+			Return(nil).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, logger.NewMockLogger(t))
+		item, err := q.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(123), item.ID)
+		assert.Equal(t, int32(1), item.Attempts)
+		assert.Equal(t, input, item.Input)
+		assert.NoError(t, q.Done(ctx, item))
+	})
+
+	t.Run("claim item for selected reconcilers", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamSyncs", ctx, mock.Anything).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("ClaimTeamSync", ctx, workerID).
+			Return(&db.TeamSyncQueueItem{
+				TeamSyncQueue: &sqlc.TeamSyncQueue{
+					ID:            123,
+					TeamSlug:      input.TeamSlug,
+					CorrelationID: input.CorrelationID,
+					Attempts:      1,
+					Reconcilers:   []string{string(sqlc.ReconcilerNameGithubTeam)},
+				},
+			}, nil).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, logger.NewMockLogger(t))
+		item, err := q.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []sqlc.ReconcilerName{sqlc.ReconcilerNameGithubTeam}, item.Input.Reconcilers)
+	})
+
+	t.Run("release item", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseTeamSync", ctx, int64(123)).
+			Return(nil).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, logger.NewMockLogger(t))
+		assert.NoError(t, q.Release(ctx, &teamsync.QueueItem{ID: 123, Input: input}))
+	})
+
+	t.Run("closed and empty queue", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamSyncs", ctx, mock.Anything).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("ClaimTeamSync", ctx, workerID).
+			Return(nil, pgx.ErrNoRows).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, logger.NewMockLogger(t))
 		q.Close()
+		item, err := q.Next(ctx)
+		assert.Nil(t, item)
+		assert.ErrorIs(t, err, teamsync.ErrQueueClosed)
 	})
 
-	t.Run("close channel", func(t *testing.T) {
-		q, _ := teamsync.NewQueue()
+	t.Run("lost race for a team is not an error", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamSyncs", ctx, mock.Anything).
+			Return(int64(0), nil).
+			Once()
+		database.
+			On("ClaimTeamSync", ctx, workerID).
+			Return(nil, &pgconn.PgError{Code: "23505"}).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, logger.NewMockLogger(t))
 		q.Close()
-		assert.EqualError(t, q.Add(input), "team reconciler channel is closed")
+		_, err := q.Next(ctx)
+		assert.ErrorIs(t, err, teamsync.ErrQueueClosed)
+	})
+
+	t.Run("database errors are logged", func(t *testing.T) {
+		claimErr := fmt.Errorf("some error")
+		database := db.NewMockDatabase(t)
+		database.
+			On("ReleaseStaleTeamSyncs", ctx, mock.Anything).
+			Return(int64(2), nil).
+			Once()
+		database.
+			On("ClaimTeamSync", ctx, workerID).
+			Return(nil, claimErr).
+			Once()
+
+		testLogger, logs := test.NewNullLogger()
+		log := logger.NewMockLogger(t)
+		log.
+			On("Warnf", "released %d stale team sync(s) from the queue", int64(2)).
+			Return().
+			Once()
+		log.
+			On("WithError", claimErr).
+			Return(&logrus.Entry{Logger: testLogger}).
+			Once()
+
+		q := teamsync.NewQueue(database, workerID, syncTimeout, log)
+		q.Close()
+		_, err := q.Next(ctx)
+		assert.ErrorIs(t, err, teamsync.ErrQueueClosed)
+		assert.Len(t, logs.Entries, 1)
+		assert.Equal(t, "claim team sync from queue", logs.LastEntry().Message)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		q := teamsync.NewQueue(db.NewMockDatabase(t), workerID, syncTimeout, logger.NewMockLogger(t))
+		item, err := q.Next(ctx)
+		assert.Nil(t, item)
+		assert.ErrorIs(t, err, context.Canceled)
 	})
 }
@@ -28,6 +28,7 @@ const (
 	AuditActionGoogleWorkspaceAdminAddMember             AuditAction = "google:workspace-admin:add-member"
 	AuditActionGoogleWorkspaceAdminAddMembers            AuditAction = "google:workspace-admin:add-members"
 	AuditActionGoogleWorkspaceAdminAddToGkeSecurityGroup AuditAction = "google:workspace-admin:add-to-gke-security-group"
+	AuditActionGoogleWorkspaceAdminAddToParentGroup      AuditAction = "google:workspace-admin:add-to-parent-group"
 	AuditActionGoogleWorkspaceAdminCreate                AuditAction = "google:workspace-admin:create"
 	AuditActionGoogleWorkspaceAdminDelete                AuditAction = "google:workspace-admin:delete"
 	AuditActionGoogleWorkspaceAdminDeleteMember          AuditAction = "google:workspace-admin:delete-member"
@@ -37,24 +38,35 @@ const (
 	AuditActionGraphqlApiReconcilersDisable              AuditAction = "graphql-api:reconcilers:disable"
 	AuditActionGraphqlApiReconcilersEnable               AuditAction = "graphql-api:reconcilers:enable"
 	AuditActionGraphqlApiReconcilersReset                AuditAction = "graphql-api:reconcilers:reset"
+	AuditActionGraphqlApiReconcilersSetTimeout           AuditAction = "graphql-api:reconcilers:set-timeout"
 	AuditActionGraphqlApiReconcilersUpdateTeamState      AuditAction = "graphql-api:reconcilers:update-team-state"
 	AuditActionGraphqlApiRolesAssignGlobalRole           AuditAction = "graphql-api:roles:assign-global-role"
+	AuditActionGraphqlApiRolesCreate                     AuditAction = "graphql-api:roles:create"
+	AuditActionGraphqlApiRolesDelete                     AuditAction = "graphql-api:roles:delete"
 	AuditActionGraphqlApiRolesRevokeGlobalRole           AuditAction = "graphql-api:roles:revoke-global-role"
+	AuditActionGraphqlApiRolesUpdate                     AuditAction = "graphql-api:roles:update"
 	AuditActionGraphqlApiServiceAccountCreate            AuditAction = "graphql-api:service-account:create"
+	AuditActionGraphqlApiServiceAccountCreateApiKey      AuditAction = "graphql-api:service-account:create-api-key"
 	AuditActionGraphqlApiServiceAccountDelete            AuditAction = "graphql-api:service-account:delete"
+	AuditActionGraphqlApiServiceAccountRevokeApiKey      AuditAction = "graphql-api:service-account:revoke-api-key"
 	AuditActionGraphqlApiServiceAccountUpdate            AuditAction = "graphql-api:service-account:update"
 	AuditActionGraphqlApiTeamAddMember                   AuditAction = "graphql-api:team:add-member"
 	AuditActionGraphqlApiTeamAddOwner                    AuditAction = "graphql-api:team:add-owner"
+	AuditActionGraphqlApiTeamCancelDeletion              AuditAction = "graphql-api:team:cancel-deletion"
 	AuditActionGraphqlApiTeamCreate                      AuditAction = "graphql-api:team:create"
 	AuditActionGraphqlApiTeamDisable                     AuditAction = "graphql-api:team:disable"
 	AuditActionGraphqlApiTeamEnable                      AuditAction = "graphql-api:team:enable"
 	AuditActionGraphqlApiTeamRemoveMember                AuditAction = "graphql-api:team:remove-member"
 	AuditActionGraphqlApiTeamSetMemberRole               AuditAction = "graphql-api:team:set-member-role"
+	AuditActionGraphqlApiTeamSetParent                   AuditAction = "graphql-api:team:set-parent"
 	AuditActionGraphqlApiTeamSync                        AuditAction = "graphql-api:team:sync"
 	AuditActionGraphqlApiTeamUpdate                      AuditAction = "graphql-api:team:update"
 	AuditActionGraphqlApiTeamsDelete                     AuditAction = "graphql-api:teams:delete"
 	AuditActionGraphqlApiTeamsRequestDelete              AuditAction = "graphql-api:teams:request-delete"
 	AuditActionGraphqlApiUsersSync                       AuditAction = "graphql-api:users:sync"
+	AuditActionGraphqlApiWebhooksCreate                  AuditAction = "graphql-api:webhooks:create"
+	AuditActionGraphqlApiWebhooksDelete                  AuditAction = "graphql-api:webhooks:delete"
+	AuditActionGraphqlApiWebhooksUpdate                  AuditAction = "graphql-api:webhooks:update"
 	AuditActionLegacyImporterTeamAddMember               AuditAction = "legacy-importer:team:add-member"
 	AuditActionLegacyImporterTeamAddOwner                AuditAction = "legacy-importer:team:add-owner"
 	AuditActionLegacyImporterTeamCreate                  AuditAction = "legacy-importer:team:create"
@@ -5,10 +5,14 @@ import "github.com/nais/teams-backend/pkg/sqlc"
 type ComponentName string
 
 const (
-	ComponentNameAuthn      ComponentName = "authn"
-	ComponentNameConsole    ComponentName = "console"
-	ComponentNameGraphqlApi ComponentName = "graphql-api"
-	ComponentNameUsersync   ComponentName = "usersync"
+	ComponentNameAuditRetention ComponentName = "audit-retention"
+	ComponentNameAuditSink      ComponentName = "audit-sink"
+	ComponentNameAuthn          ComponentName = "authn"
+	ComponentNameConsole        ComponentName = "console"
+	ComponentNameGraphqlApi     ComponentName = "graphql-api"
+	ComponentNameLeaderElection ComponentName = "leader-election"
+	ComponentNameUsersync       ComponentName = "usersync"
+	ComponentNameWebhooks       ComponentName = "webhooks"
 
 	ComponentNameAzureGroup           = ComponentName(sqlc.ReconcilerNameAzureGroup)
 	ComponentNameGithubTeam           = ComponentName(sqlc.ReconcilerNameGithubTeam)
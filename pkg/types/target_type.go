@@ -4,6 +4,7 @@ type AuditLogsTargetType string
 
 const (
 	AuditLogsTargetTypeReconciler     AuditLogsTargetType = "reconciler"
+	AuditLogsTargetTypeRole           AuditLogsTargetType = "role"
 	AuditLogsTargetTypeServiceAccount AuditLogsTargetType = "service_account"
 	AuditLogsTargetTypeSystem         AuditLogsTargetType = "system"
 	AuditLogsTargetTypeTeam           AuditLogsTargetType = "team"
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/types"
 
 	"github.com/google/uuid"
@@ -27,7 +29,14 @@ type (
 		tenantDomain     string
 		service          *admin_directory_v1.Service
 		log              logger.Logger
-		syncRuns         *RunsHandler
+		runsToStore      int
+	}
+
+	// syncCounts The number of local users changed by a sync run
+	syncCounts struct {
+		created int
+		updated int
+		deleted int
 	}
 
 	auditLogEntry struct {
@@ -46,17 +55,21 @@ type (
 	}
 
 	userByIDMap  map[uuid.UUID]*db.User
-	userRolesMap map[*db.User]map[sqlc.RoleName]struct{}
+	userRolesMap map[*db.User]map[roles.RoleName]*db.UserRole
 )
 
-var DefaultRoleNames = []sqlc.RoleName{
-	sqlc.RoleNameTeamcreator,
-	sqlc.RoleNameTeamviewer,
-	sqlc.RoleNameUserviewer,
-	sqlc.RoleNameServiceaccountcreator,
+// finishRunTimeout Time allowed for storing the result of a sync run, which might happen after the sync itself has
+// timed out
+const finishRunTimeout = time.Second * 5
+
+var DefaultRoleNames = []roles.RoleName{
+	roles.RoleNameTeamcreator,
+	roles.RoleNameTeamviewer,
+	roles.RoleNameUserviewer,
+	roles.RoleNameServiceaccountcreator,
 }
 
-func New(database db.Database, auditLogger auditlogger.AuditLogger, adminGroupPrefix, tenantDomain string, service *admin_directory_v1.Service, log logger.Logger, syncRuns *RunsHandler) *UserSynchronizer {
+func New(database db.Database, auditLogger auditlogger.AuditLogger, adminGroupPrefix, tenantDomain string, service *admin_directory_v1.Service, log logger.Logger, runsToStore int) *UserSynchronizer {
 	return &UserSynchronizer{
 		database:         database,
 		auditLogger:      auditLogger,
@@ -64,11 +77,11 @@ func New(database db.Database, auditLogger auditlogger.AuditLogger, adminGroupPr
 		tenantDomain:     tenantDomain,
 		service:          service,
 		log:              log,
-		syncRuns:         syncRuns,
+		runsToStore:      runsToStore,
 	}
 }
 
-func NewFromConfig(cfg *config.Config, database db.Database, log logger.Logger, syncRuns *RunsHandler) (*UserSynchronizer, error) {
+func NewFromConfig(cfg *config.Config, database db.Database, log logger.Logger) (*UserSynchronizer, error) {
 	log = log.WithComponent(types.ComponentNameUsersync)
 	ctx := context.Background()
 
@@ -87,22 +100,31 @@ func NewFromConfig(cfg *config.Config, database db.Database, log logger.Logger,
 		return nil, fmt.Errorf("retrieve directory client: %w", err)
 	}
 
-	return New(database, auditlogger.New(database, types.ComponentNameUsersync, log), cfg.UserSync.AdminGroupPrefix, cfg.TenantDomain, srv, log, syncRuns), nil
+	return New(database, auditlogger.New(database, types.ComponentNameUsersync, log), cfg.UserSync.AdminGroupPrefix, cfg.TenantDomain, srv, log, cfg.UserSync.RunsToStore), nil
 }
 
 // Sync Fetch all users from the tenant and add them as local users in teams-backend. If a user already exists in
 // teams-backend the local user will get the name potentially updated. After all users have been upserted, local users
-// that matches the tenant domain that does not exist in the Google Directory will be removed.
+// that matches the tenant domain that does not exist in the Google Directory will be removed. The result of the sync is
+// stored as a sync run.
 func (s *UserSynchronizer) Sync(ctx context.Context, correlationID uuid.UUID) error {
 	log := s.log.WithCorrelationID(correlationID)
-	syncRun := s.syncRuns.StartNewRun(correlationID)
-	defer syncRun.Finish()
 
+	run, err := s.database.CreateUserSyncRun(ctx, correlationID)
+	if err != nil {
+		return fmt.Errorf("create user sync run: %w", err)
+	}
+
+	counts, err := s.sync(ctx, correlationID, log)
+	s.finishRun(run, counts, err, log)
+	return err
+}
+
+func (s *UserSynchronizer) sync(ctx context.Context, correlationID uuid.UUID, log logger.Logger) (syncCounts, error) {
 	remoteUserMapping := make(remoteUsersMap)
 	remoteUsers, err := getAllPaginatedUsers(ctx, s.service.Users, s.tenantDomain)
 	if err != nil {
-		syncRun.FinishWithError(err)
-		return fmt.Errorf("get remote users: %w", err)
+		return syncCounts{}, fmt.Errorf("get remote users: %w", err)
 	}
 
 	auditLogEntries := make([]auditLogEntry, 0)
@@ -133,9 +155,11 @@ func (s *UserSynchronizer) Sync(ctx context.Context, correlationID uuid.UUID) er
 		for _, row := range allUserRolesRows {
 			user := usersByID[row.UserID]
 			if _, exists := userRoles[user]; !exists {
-				userRoles[user] = make(map[sqlc.RoleName]struct{})
+				userRoles[user] = make(map[roles.RoleName]*db.UserRole)
+			}
+			if _, exists := userRoles[user][row.RoleName]; !exists || isGlobalRole(row) {
+				userRoles[user][row.RoleName] = row
 			}
-			userRoles[user][row.RoleName] = struct{}{}
 		}
 
 		for _, remoteUser := range remoteUsers {
@@ -172,7 +196,7 @@ func (s *UserSynchronizer) Sync(ctx context.Context, correlationID uuid.UUID) er
 						continue
 					}
 				}
-				err = dbtx.AssignGlobalRoleToUser(ctx, localUser.ID, roleName)
+				err = dbtx.AssignGlobalRoleToUser(ctx, localUser.ID, roleName, nil)
 				if err != nil {
 					return fmt.Errorf("attach default role %q to user %q: %w", roleName, email, err)
 				}
@@ -200,11 +224,20 @@ func (s *UserSynchronizer) Sync(ctx context.Context, correlationID uuid.UUID) er
 	})
 
 	if err != nil {
-		syncRun.FinishWithError(err)
-		return err
+		return syncCounts{}, err
 	}
 
+	counts := syncCounts{}
 	for _, entry := range auditLogEntries {
+		switch entry.action {
+		case types.AuditActionUsersyncCreate:
+			counts.created++
+		case types.AuditActionUsersyncUpdate:
+			counts.updated++
+		case types.AuditActionUsersyncDelete:
+			counts.deleted++
+		}
+
 		targets := []auditlogger.Target{
 			auditlogger.UserTarget(entry.userEmail),
 		}
@@ -215,7 +248,30 @@ func (s *UserSynchronizer) Sync(ctx context.Context, correlationID uuid.UUID) er
 		s.auditLogger.Logf(ctx, targets, fields, entry.message)
 	}
 
-	return nil
+	return counts, nil
+}
+
+// finishRun Store the result of a sync run, and remove the oldest runs when there are more than the number of runs to
+// store
+func (s *UserSynchronizer) finishRun(run *db.UserSyncRun, counts syncCounts, syncErr error, log logger.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), finishRunTimeout)
+	defer cancel()
+
+	status := sqlc.UserSyncRunStatusSuccess
+	var errorMessage *string
+	if syncErr != nil {
+		status = sqlc.UserSyncRunStatusFailure
+		msg := syncErr.Error()
+		errorMessage = &msg
+	}
+
+	if _, err := s.database.FinishUserSyncRun(ctx, run.ID, status, errorMessage, counts.created, counts.updated, counts.deleted); err != nil {
+		log.WithError(err).Error("finish user sync run")
+	}
+
+	if _, err := s.database.DeleteOldUserSyncRuns(ctx, s.runsToStore); err != nil {
+		log.WithError(err).Error("delete old user sync runs")
+	}
 }
 
 // deleteUnknownUsers Delete users from the teams-backend database that does not exist in the Google Workspace
@@ -238,7 +294,8 @@ func deleteUnknownUsers(ctx context.Context, dbtx db.Database, unknownUsers user
 }
 
 // assignTeamsBackendAdmins Assign the global admin role to users based on the admin group. Existing admins that is not
-// present in the list of admins will get the admin role revoked.
+// present in the list of admins will get the admin role revoked, unless the role was granted by someone else than the
+// user sync, or the user is the last admin.
 func assignTeamsBackendAdmins(ctx context.Context, dbtx db.Database, membersService *admin_directory_v1.MembersService, adminGroupPrefix, tenantDomain string, remoteUserMapping map[string]*db.User, userRoles userRolesMap, auditLogEntries *[]auditLogEntry, log logger.Logger) error {
 	admins, err := getAdminUsers(ctx, membersService, adminGroupPrefix, tenantDomain, remoteUserMapping, log)
 	if err != nil {
@@ -246,24 +303,9 @@ func assignTeamsBackendAdmins(ctx context.Context, dbtx db.Database, membersServ
 	}
 
 	existingAdmins := getExistingTeamsBackendAdmins(userRoles)
-	for _, existingAdmin := range existingAdmins {
-		if _, shouldBeAdmin := admins[existingAdmin.ID]; !shouldBeAdmin {
-			err = dbtx.RevokeGlobalUserRole(ctx, existingAdmin.ID, sqlc.RoleNameAdmin)
-			if err != nil {
-				return err
-			}
-
-			*auditLogEntries = append(*auditLogEntries, auditLogEntry{
-				action:    types.AuditActionUsersyncRevokeAdminRole,
-				message:   fmt.Sprintf("Revoke global admin role from user: %q", existingAdmin.Email),
-				userEmail: existingAdmin.Email,
-			})
-		}
-	}
-
 	for _, admin := range admins {
 		if _, isAlreadyAdmin := existingAdmins[admin.ID]; !isAlreadyAdmin {
-			err = dbtx.AssignGlobalRoleToUser(ctx, admin.ID, sqlc.RoleNameAdmin)
+			err = dbtx.AssignGlobalRoleToUser(ctx, admin.ID, roles.RoleNameAdmin, nil)
 			if err != nil {
 				return err
 			}
@@ -276,22 +318,71 @@ func assignTeamsBackendAdmins(ctx context.Context, dbtx db.Database, membersServ
 		}
 	}
 
+	revokeAdmins := make([]*db.User, 0)
+	for _, existingAdmin := range existingAdmins {
+		if _, shouldBeAdmin := admins[existingAdmin.ID]; shouldBeAdmin {
+			continue
+		}
+
+		if userRoles[existingAdmin][roles.RoleNameAdmin].GrantedBy != nil {
+			// granted through the API, and not managed by the admin group
+			continue
+		}
+
+		revokeAdmins = append(revokeAdmins, existingAdmin)
+	}
+
+	if len(revokeAdmins) == 0 {
+		return nil
+	}
+
+	if err := dbtx.LockGlobalAdmins(ctx); err != nil {
+		return err
+	}
+
+	numAdmins, err := dbtx.CountGlobalAdmins(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, existingAdmin := range revokeAdmins {
+		if numAdmins <= 1 {
+			log.Warnf("not revoking the global admin role from the last admin: %q", existingAdmin.Email)
+			continue
+		}
+
+		err = dbtx.RevokeGlobalUserRole(ctx, existingAdmin.ID, roles.RoleNameAdmin)
+		if err != nil {
+			return err
+		}
+		numAdmins--
+
+		*auditLogEntries = append(*auditLogEntries, auditLogEntry{
+			action:    types.AuditActionUsersyncRevokeAdminRole,
+			message:   fmt.Sprintf("Revoke global admin role from user: %q", existingAdmin.Email),
+			userEmail: existingAdmin.Email,
+		})
+	}
+
 	return nil
 }
 
 // getExistingTeamsBackendAdmins Get all users with a globally assigned admin role
 func getExistingTeamsBackendAdmins(userWithRoles userRolesMap) map[uuid.UUID]*db.User {
 	admins := make(map[uuid.UUID]*db.User)
-	for user, roles := range userWithRoles {
-		for roleName := range roles {
-			if roleName == sqlc.RoleNameAdmin {
-				admins[user.ID] = user
-			}
+	for user, userRoles := range userWithRoles {
+		if role, hasAdminRole := userRoles[roles.RoleNameAdmin]; hasAdminRole && isGlobalRole(role) {
+			admins[user.ID] = user
 		}
 	}
 	return admins
 }
 
+// isGlobalRole Check if a role binding of a user is not limited to a team or a service account
+func isGlobalRole(role *db.UserRole) bool {
+	return role.TargetTeamSlug == nil && role.TargetServiceAccountID == nil
+}
+
 // getAdminUsers Get a list of admin users based on the teams-backend admins group in the Google Workspace
 func getAdminUsers(ctx context.Context, membersService *admin_directory_v1.MembersService, adminGroupPrefix, tenantDomain string, remoteUserMapping map[string]*db.User, log logger.Logger) (map[uuid.UUID]*db.User, error) {
 	adminGroupKey := adminGroupPrefix + "@" + tenantDomain
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/nais/teams-backend/pkg/roles"
 	"github.com/nais/teams-backend/pkg/types"
 
 	"github.com/google/uuid"
@@ -28,7 +29,6 @@ func TestSync(t *testing.T) {
 	)
 
 	correlationID := uuid.New()
-	syncRuns := usersync.NewRunsHandler(numRunsToStore)
 
 	t.Run("No local users, no remote users", func(t *testing.T) {
 		ctx := context.Background()
@@ -52,8 +52,10 @@ func TestSync(t *testing.T) {
 		svc, err := admin_directory_v1.NewService(ctx, option.WithHTTPClient(httpClient))
 		assert.NoError(t, err)
 
+		expectSyncRun(database, correlationID, 0, 0, 0, numRunsToStore)
+
 		err = usersync.
-			New(database, auditLogger, adminGroupPrefix, domain, svc, log, syncRuns).
+			New(database, auditLogger, adminGroupPrefix, domain, svc, log, numRunsToStore).
 			Sync(ctx, correlationID)
 		assert.NoError(t, err)
 	})
@@ -91,8 +93,8 @@ func TestSync(t *testing.T) {
 		dbtx.
 			On("GetAllUserRoles", txCtx).
 			Return([]*db.UserRole{
-				{UserRole: &sqlc.UserRole{UserID: user1.ID, RoleName: sqlc.RoleNameTeamcreator}},
-				{UserRole: &sqlc.UserRole{UserID: user2.ID, RoleName: sqlc.RoleNameAdmin}},
+				{UserRole: &sqlc.UserRole{UserID: user1.ID, RoleName: roles.RoleNameTeamcreator}},
+				{UserRole: &sqlc.UserRole{UserID: user2.ID, RoleName: roles.RoleNameAdmin}},
 			}, nil).
 			Once()
 		dbtx.
@@ -126,8 +128,10 @@ func TestSync(t *testing.T) {
 		svc, err := admin_directory_v1.NewService(ctx, option.WithHTTPClient(httpClient))
 		assert.NoError(t, err)
 
+		expectSyncRun(database, correlationID, 0, 0, 2, numRunsToStore)
+
 		err = usersync.
-			New(database, auditLogger, adminGroupPrefix, domain, svc, log, syncRuns).
+			New(database, auditLogger, adminGroupPrefix, domain, svc, log, numRunsToStore).
 			Sync(ctx, correlationID)
 		assert.NoError(t, err)
 	})
@@ -198,9 +202,9 @@ func TestSync(t *testing.T) {
 		dbtx.
 			On("GetAllUserRoles", txCtx).
 			Return([]*db.UserRole{
-				{UserRole: &sqlc.UserRole{UserID: localUserID1, RoleName: sqlc.RoleNameTeamcreator}},
-				{UserRole: &sqlc.UserRole{UserID: localUserID1, RoleName: sqlc.RoleNameAdmin}},
-				{UserRole: &sqlc.UserRole{UserID: localUserID2, RoleName: sqlc.RoleNameTeamviewer}},
+				{UserRole: &sqlc.UserRole{UserID: localUserID1, RoleName: roles.RoleNameTeamcreator}},
+				{UserRole: &sqlc.UserRole{UserID: localUserID1, RoleName: roles.RoleNameAdmin}},
+				{UserRole: &sqlc.UserRole{UserID: localUserID2, RoleName: roles.RoleNameTeamviewer}},
 			}, nil).
 			Once()
 
@@ -219,9 +223,9 @@ func TestSync(t *testing.T) {
 			Return(localUserWithCorrectName, nil).
 			Once()
 		dbtx.
-			On("AssignGlobalRoleToUser", txCtx, localUserWithCorrectName.ID, mock.MatchedBy(func(roleName sqlc.RoleName) bool {
-				return roleName != sqlc.RoleNameTeamcreator
-			})).
+			On("AssignGlobalRoleToUser", txCtx, localUserWithCorrectName.ID, mock.MatchedBy(func(roleName roles.RoleName) bool {
+				return roleName != roles.RoleNameTeamcreator
+			}), (*string)(nil)).
 			Return(nil).
 			Times(numDefaultRoleNames - 1)
 
@@ -231,7 +235,7 @@ func TestSync(t *testing.T) {
 			Return(createdLocalUser, nil).
 			Once()
 		dbtx.
-			On("AssignGlobalRoleToUser", txCtx, createdLocalUser.ID, mock.AnythingOfType("sqlc.RoleName")).
+			On("AssignGlobalRoleToUser", txCtx, createdLocalUser.ID, mock.AnythingOfType("roles.RoleName"), (*string)(nil)).
 			Return(nil).
 			Times(numDefaultRoleNames)
 
@@ -241,9 +245,9 @@ func TestSync(t *testing.T) {
 			Return(localUserWithCorrectEmail, nil).
 			Once()
 		dbtx.
-			On("AssignGlobalRoleToUser", txCtx, localUserWithCorrectEmail.ID, mock.MatchedBy(func(roleName sqlc.RoleName) bool {
-				return roleName != sqlc.RoleNameTeamviewer
-			})).
+			On("AssignGlobalRoleToUser", txCtx, localUserWithCorrectEmail.ID, mock.MatchedBy(func(roleName roles.RoleName) bool {
+				return roleName != roles.RoleNameTeamviewer
+			}), (*string)(nil)).
 			Return(nil).
 			Times(numDefaultRoleNames - 1)
 
@@ -253,12 +257,20 @@ func TestSync(t *testing.T) {
 			Once()
 
 		dbtx.
-			On("AssignGlobalRoleToUser", txCtx, createdLocalUser.ID, sqlc.RoleNameAdmin).
+			On("AssignGlobalRoleToUser", txCtx, createdLocalUser.ID, roles.RoleNameAdmin, (*string)(nil)).
 			Return(nil).
 			Once()
 
 		dbtx.
-			On("RevokeGlobalUserRole", txCtx, localUserID1, sqlc.RoleNameAdmin).
+			On("LockGlobalAdmins", txCtx).
+			Return(nil).
+			Once()
+		dbtx.
+			On("CountGlobalAdmins", txCtx).
+			Return(2, nil).
+			Once()
+		dbtx.
+			On("RevokeGlobalUserRole", txCtx, localUserID1, roles.RoleNameAdmin).
 			Return(nil).
 			Once()
 
@@ -287,13 +299,160 @@ func TestSync(t *testing.T) {
 			Return().
 			Once()
 
+		expectSyncRun(database, correlationID, 1, 2, 1, numRunsToStore)
+
+		err = usersync.
+			New(database, auditLogger, adminGroupPrefix, domain, svc, log, numRunsToStore).
+			Sync(ctx, correlationID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Admin role granted through the API is not revoked", func(t *testing.T) {
+		ctx := context.Background()
+		txCtx := context.Background()
+		grantedBy := "admin@example.com"
+
+		auditLogger := auditlogger.NewMockAuditLogger(t)
+		database := db.NewMockDatabase(t)
+		dbtx := db.NewMockDatabase(t)
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithCorrelationID", correlationID).
+			Return(log).
+			Once()
+
+		user := &db.User{User: &sqlc.User{ID: serialUuid(1), Email: "user1@example.com", ExternalID: "123", Name: "User 1"}}
+
+		httpClient := test.NewTestHttpClient(
+			// org users
+			func(req *http.Request) *http.Response {
+				return test.Response("200 OK", `{"users":[{"id": "123", "primaryEmail":"user1@example.com","name":{"fullName":"User 1"}}]}`)
+			},
+			// admin group members
+			func(req *http.Request) *http.Response {
+				return test.Response("200 OK", `{"members":[]}`)
+			},
+		)
+		svc, err := admin_directory_v1.NewService(ctx, option.WithHTTPClient(httpClient))
+		assert.NoError(t, err)
+
+		database.
+			On("Transaction", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				_ = fn(txCtx, dbtx)
+			}).
+			Return(nil).
+			Once()
+
+		userRoles := []*db.UserRole{{UserRole: &sqlc.UserRole{UserID: user.ID, RoleName: roles.RoleNameAdmin, GrantedBy: &grantedBy}}}
+		for _, roleName := range usersync.DefaultRoleNames {
+			userRoles = append(userRoles, &db.UserRole{UserRole: &sqlc.UserRole{UserID: user.ID, RoleName: roleName}})
+		}
+		dbtx.
+			On("GetUsers", txCtx).
+			Return([]*db.User{user}, nil).
+			Once()
+		dbtx.
+			On("GetAllUserRoles", txCtx).
+			Return(userRoles, nil).
+			Once()
+
+		expectSyncRun(database, correlationID, 0, 0, 0, numRunsToStore)
+
+		err = usersync.
+			New(database, auditLogger, adminGroupPrefix, domain, svc, log, numRunsToStore).
+			Sync(ctx, correlationID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Admin role is not revoked from the last admin", func(t *testing.T) {
+		ctx := context.Background()
+		txCtx := context.Background()
+
+		auditLogger := auditlogger.NewMockAuditLogger(t)
+		database := db.NewMockDatabase(t)
+		dbtx := db.NewMockDatabase(t)
+		log := logger.NewMockLogger(t)
+		log.
+			On("WithCorrelationID", correlationID).
+			Return(log).
+			Once()
+		log.
+			On("Warnf", "not revoking the global admin role from the last admin: %q", "user1@example.com").
+			Return().
+			Once()
+
+		user := &db.User{User: &sqlc.User{ID: serialUuid(1), Email: "user1@example.com", ExternalID: "123", Name: "User 1"}}
+
+		httpClient := test.NewTestHttpClient(
+			// org users
+			func(req *http.Request) *http.Response {
+				return test.Response("200 OK", `{"users":[{"id": "123", "primaryEmail":"user1@example.com","name":{"fullName":"User 1"}}]}`)
+			},
+			// admin group members
+			func(req *http.Request) *http.Response {
+				return test.Response("200 OK", `{"members":[]}`)
+			},
+		)
+		svc, err := admin_directory_v1.NewService(ctx, option.WithHTTPClient(httpClient))
+		assert.NoError(t, err)
+
+		database.
+			On("Transaction", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(db.DatabaseTransactionFunc)
+				_ = fn(txCtx, dbtx)
+			}).
+			Return(nil).
+			Once()
+
+		userRoles := []*db.UserRole{{UserRole: &sqlc.UserRole{UserID: user.ID, RoleName: roles.RoleNameAdmin}}}
+		for _, roleName := range usersync.DefaultRoleNames {
+			userRoles = append(userRoles, &db.UserRole{UserRole: &sqlc.UserRole{UserID: user.ID, RoleName: roleName}})
+		}
+		dbtx.
+			On("GetUsers", txCtx).
+			Return([]*db.User{user}, nil).
+			Once()
+		dbtx.
+			On("GetAllUserRoles", txCtx).
+			Return(userRoles, nil).
+			Once()
+		dbtx.
+			On("LockGlobalAdmins", txCtx).
+			Return(nil).
+			Once()
+		dbtx.
+			On("CountGlobalAdmins", txCtx).
+			Return(1, nil).
+			Once()
+
+		expectSyncRun(database, correlationID, 0, 0, 0, numRunsToStore)
+
 		err = usersync.
-			New(database, auditLogger, adminGroupPrefix, domain, svc, log, syncRuns).
+			New(database, auditLogger, adminGroupPrefix, domain, svc, log, numRunsToStore).
 			Sync(ctx, correlationID)
 		assert.NoError(t, err)
 	})
 }
 
+func expectSyncRun(database *db.MockDatabase, correlationID uuid.UUID, created, updated, deleted, runsToStore int) {
+	const runID = int64(1)
+	database.
+		On("CreateUserSyncRun", mock.Anything, correlationID).
+		Return(&db.UserSyncRun{UserSyncRun: &sqlc.UserSyncRun{ID: runID, CorrelationID: correlationID}}, nil).
+		Once()
+	database.
+		On("FinishUserSyncRun", mock.Anything, runID, sqlc.UserSyncRunStatusSuccess, (*string)(nil), created, updated, deleted).
+		Return(&db.UserSyncRun{UserSyncRun: &sqlc.UserSyncRun{ID: runID, CorrelationID: correlationID}}, nil).
+		Once()
+	database.
+		On("DeleteOldUserSyncRuns", mock.Anything, runsToStore).
+		Return(int64(0), nil).
+		Once()
+}
+
 func targetIdentifier(identifier string) interface{} {
 	return mock.MatchedBy(func(t []auditlogger.Target) bool {
 		return t[0].Identifier == identifier
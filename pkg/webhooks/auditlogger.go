@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+)
+
+// eventTypes The audit log actions that are published as webhook events. Changes to the role of a member are published
+// as team updates along with the user. Team deletions are published by the team sync handler once the team has actually
+// been deleted, while scheduling and cancelling a deletion disables and enables the team, and are published as team
+// updates.
+var eventTypes = map[types.AuditAction]sqlc.WebhookEventType{
+	types.AuditActionGraphqlApiTeamCreate:         sqlc.WebhookEventTypeTeamcreated,
+	types.AuditActionGraphqlApiTeamUpdate:         sqlc.WebhookEventTypeTeamupdated,
+	types.AuditActionGraphqlApiTeamDisable:        sqlc.WebhookEventTypeTeamupdated,
+	types.AuditActionGraphqlApiTeamEnable:         sqlc.WebhookEventTypeTeamupdated,
+	types.AuditActionGraphqlApiTeamSetParent:      sqlc.WebhookEventTypeTeamupdated,
+	types.AuditActionGraphqlApiTeamSetMemberRole:  sqlc.WebhookEventTypeTeamupdated,
+	types.AuditActionGraphqlApiTeamsDelete:        sqlc.WebhookEventTypeTeamupdated,
+	types.AuditActionGraphqlApiTeamCancelDeletion: sqlc.WebhookEventTypeTeamupdated,
+	types.AuditActionGraphqlApiTeamAddMember:      sqlc.WebhookEventTypeMemberadded,
+	types.AuditActionGraphqlApiTeamAddOwner:       sqlc.WebhookEventTypeMemberadded,
+	types.AuditActionGraphqlApiTeamRemoveMember:   sqlc.WebhookEventTypeMemberremoved,
+}
+
+type publishingAuditLogger struct {
+	auditlogger.AuditLogger
+	publisher Publisher
+}
+
+// NewAuditLogger Wrap an audit logger so that team lifecycle actions are also published as webhook events. This way
+// the events are published from the same places as the audit log entries are created.
+func NewAuditLogger(auditLogger auditlogger.AuditLogger, publisher Publisher) auditlogger.AuditLogger {
+	return &publishingAuditLogger{
+		AuditLogger: auditLogger,
+		publisher:   publisher,
+	}
+}
+
+func (l *publishingAuditLogger) Logf(ctx context.Context, targets []auditlogger.Target, fields auditlogger.Fields, message string, messageArgs ...interface{}) {
+	l.AuditLogger.Logf(ctx, targets, fields, message, messageArgs...)
+
+	eventType, exists := eventTypes[fields.Action]
+	if !exists {
+		return
+	}
+
+	event := Event{
+		Type:          eventType,
+		CorrelationID: fields.CorrelationID,
+		Message:       fmt.Sprintf(message, messageArgs...),
+		Changes:       fields.Changes,
+	}
+
+	if fields.Actor != nil {
+		event.Actor = helpers.Strp(fields.Actor.User.Identity())
+	}
+
+	for _, target := range targets {
+		switch target.Type {
+		case types.AuditLogsTargetTypeTeam:
+			event.Team = slug.Slug(target.Identifier)
+		case types.AuditLogsTargetTypeUser:
+			event.User = helpers.Strp(target.Identifier)
+		}
+	}
+
+	if event.Team == "" {
+		return
+	}
+
+	l.publisher.Publish(ctx, event)
+}
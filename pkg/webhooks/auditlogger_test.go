@@ -0,0 +1,184 @@
+package webhooks_test
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditlogger"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/nais/teams-backend/pkg/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogger_Logf(t *testing.T) {
+	ctx := context.Background()
+	correlationID := uuid.New()
+	teamSlug := slug.Slug("my-team")
+
+	t.Run("team action is published", func(t *testing.T) {
+		inner := auditlogger.NewAuditLoggerForTesting()
+		publisher := webhooks.NewPublisherForTesting()
+		changes := auditlogger.Changes{}.Add("purpose", "old", "new")
+
+		targets := []auditlogger.Target{
+			auditlogger.TeamTarget(teamSlug),
+		}
+		fields := auditlogger.Fields{
+			Action:        types.AuditActionGraphqlApiTeamUpdate,
+			CorrelationID: correlationID,
+			Changes:       changes,
+		}
+		webhooks.NewAuditLogger(inner, publisher).Logf(ctx, targets, fields, "Updated team %q", teamSlug)
+
+		assert.Len(t, inner.Entries(), 1)
+		assert.Equal(t, []webhooks.Event{
+			{
+				Type:          sqlc.WebhookEventTypeTeamupdated,
+				CorrelationID: correlationID,
+				Team:          teamSlug,
+				Message:       `Updated team "my-team"`,
+				Changes:       []*db.AuditLogChange(changes),
+			},
+		}, publisher.Events())
+	})
+
+	t.Run("member action is published with the user", func(t *testing.T) {
+		inner := auditlogger.NewAuditLoggerForTesting()
+		publisher := webhooks.NewPublisherForTesting()
+
+		targets := []auditlogger.Target{
+			auditlogger.TeamTarget(teamSlug),
+			auditlogger.UserTarget("user@example.com"),
+		}
+		fields := auditlogger.Fields{
+			Action:        types.AuditActionGraphqlApiTeamAddOwner,
+			CorrelationID: correlationID,
+		}
+		webhooks.NewAuditLogger(inner, publisher).Logf(ctx, targets, fields, "Add team owner")
+
+		assert.Len(t, inner.Entries(), 1)
+		assert.Equal(t, []webhooks.Event{
+			{
+				Type:          sqlc.WebhookEventTypeMemberadded,
+				CorrelationID: correlationID,
+				Team:          teamSlug,
+				User:          helpers.Strp("user@example.com"),
+				Message:       "Add team owner",
+			},
+		}, publisher.Events())
+	})
+
+	t.Run("other actions are not published", func(t *testing.T) {
+		inner := auditlogger.NewAuditLoggerForTesting()
+		publisher := webhooks.NewPublisherForTesting()
+
+		targets := []auditlogger.Target{
+			auditlogger.TeamTarget(teamSlug),
+		}
+		fields := auditlogger.Fields{
+			Action:        types.AuditActionGraphqlApiTeamSync,
+			CorrelationID: correlationID,
+		}
+		webhooks.NewAuditLogger(inner, publisher).Logf(ctx, targets, fields, "Manually scheduled for synchronization")
+
+		assert.Len(t, inner.Entries(), 1)
+		assert.Empty(t, publisher.Events())
+	})
+}
+
+func TestAuditLogger_TeamActions(t *testing.T) {
+	ctx := context.Background()
+	teamSlug := slug.Slug("my-team")
+
+	// every action that changes a team or its members must be published, new actions must be added here
+	tests := map[types.AuditAction]*sqlc.WebhookEventType{
+		types.AuditActionGraphqlApiTeamCreate:         eventType(sqlc.WebhookEventTypeTeamcreated),
+		types.AuditActionGraphqlApiTeamUpdate:         eventType(sqlc.WebhookEventTypeTeamupdated),
+		types.AuditActionGraphqlApiTeamDisable:        eventType(sqlc.WebhookEventTypeTeamupdated),
+		types.AuditActionGraphqlApiTeamEnable:         eventType(sqlc.WebhookEventTypeTeamupdated),
+		types.AuditActionGraphqlApiTeamSetParent:      eventType(sqlc.WebhookEventTypeTeamupdated),
+		types.AuditActionGraphqlApiTeamSetMemberRole:  eventType(sqlc.WebhookEventTypeTeamupdated),
+		types.AuditActionGraphqlApiTeamsDelete:        eventType(sqlc.WebhookEventTypeTeamupdated),
+		types.AuditActionGraphqlApiTeamCancelDeletion: eventType(sqlc.WebhookEventTypeTeamupdated),
+		types.AuditActionGraphqlApiTeamAddMember:      eventType(sqlc.WebhookEventTypeMemberadded),
+		types.AuditActionGraphqlApiTeamAddOwner:       eventType(sqlc.WebhookEventTypeMemberadded),
+		types.AuditActionGraphqlApiTeamRemoveMember:   eventType(sqlc.WebhookEventTypeMemberremoved),
+
+		// does not change the team
+		types.AuditActionGraphqlApiTeamSync:           nil,
+		types.AuditActionGraphqlApiTeamsRequestDelete: nil,
+	}
+
+	for _, action := range teamActions(t) {
+		t.Run(string(action), func(t *testing.T) {
+			expected, exists := tests[action]
+			if !exists {
+				t.Fatalf("no expected webhook event for team action %q", action)
+			}
+
+			publisher := webhooks.NewPublisherForTesting()
+			targets := []auditlogger.Target{
+				auditlogger.TeamTarget(teamSlug),
+			}
+			fields := auditlogger.Fields{
+				Action:        action,
+				CorrelationID: uuid.New(),
+			}
+			webhooks.NewAuditLogger(auditlogger.NewAuditLoggerForTesting(), publisher).Logf(ctx, targets, fields, "some message")
+
+			if expected == nil {
+				assert.Empty(t, publisher.Events())
+				return
+			}
+
+			events := publisher.Events()
+			assert.Len(t, events, 1)
+			assert.Equal(t, *expected, events[0].Type)
+		})
+	}
+}
+
+// teamActions Get the audit log actions of the GraphQL API that target teams, as declared in the types package
+func teamActions(t *testing.T) []types.AuditAction {
+	file, err := parser.ParseFile(token.NewFileSet(), "../types/actions.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse audit log actions: %v", err)
+	}
+
+	actions := make([]types.AuditAction, 0)
+	ast.Inspect(file, func(node ast.Node) bool {
+		literal, ok := node.(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+
+		value, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			t.Fatalf("unquote audit log action: %v", err)
+		}
+
+		if strings.HasPrefix(value, "graphql-api:team:") || strings.HasPrefix(value, "graphql-api:teams:") {
+			actions = append(actions, types.AuditAction(value))
+		}
+		return true
+	})
+
+	if len(actions) == 0 {
+		t.Fatal("no team actions found")
+	}
+	return actions
+}
+
+func eventType(eventType sqlc.WebhookEventType) *sqlc.WebhookEventType {
+	return &eventType
+}
@@ -0,0 +1,241 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditsink"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/metrics"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+)
+
+const (
+	// EventTypeHeader The header containing the type of the delivered event
+	EventTypeHeader = "X-Teams-Backend-Event"
+
+	// DeliveryIDHeader The header containing the ID of the delivery. Retries of a delivery use the same ID.
+	DeliveryIDHeader = "X-Teams-Backend-Delivery"
+
+	// dispatchPollInterval How long the dispatcher waits before looking for pending deliveries again when there are none
+	dispatchPollInterval = 5 * time.Second
+
+	// dispatchBatchSize The maximum number of deliveries claimed at once
+	dispatchBatchSize = 20
+
+	// deliveryTimeout The time allowed for a subscriber to handle a single delivery
+	deliveryTimeout = 10 * time.Second
+
+	// staleLockTimeout Locks older than this are considered abandoned, for instance because the replica that claimed
+	// the deliveries was killed. It must be longer than the time spent sending a whole batch.
+	staleLockTimeout = 10 * time.Minute
+
+	// maintenanceInterval How often to look for abandoned locks, remove old deliveries and update metrics
+	maintenanceInterval = time.Minute
+
+	// maxAttempts The number of attempts before a delivery is marked as failed
+	maxAttempts = 10
+
+	// retryInitialBackoff The delay before the first retry of a failed delivery
+	retryInitialBackoff = 10 * time.Second
+
+	// retryMaxBackoff The upper limit for the delay between retries of a failed delivery
+	retryMaxBackoff = time.Hour
+
+	// deliveryLogRetention How long finished deliveries are kept in the delivery log
+	deliveryLogRetention = 30 * 24 * time.Hour
+)
+
+// Dispatcher Sends pending webhook deliveries to the subscribers. Failed deliveries are retried with a backoff until
+// maxAttempts is reached. Every attempt is recorded on the delivery, which serves as the delivery log of a
+// subscription. All replicas can run a dispatcher at the same time.
+type Dispatcher struct {
+	database          db.Database
+	httpClient        *http.Client
+	workerID          string
+	log               logger.Logger
+	lastMaintenanceAt time.Time
+}
+
+func NewDispatcher(database db.Database, httpClient *http.Client, log logger.Logger) *Dispatcher {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: deliveryTimeout}
+	}
+
+	return &Dispatcher{
+		database:   database,
+		httpClient: httpClient,
+		workerID:   helpers.WorkerID(),
+		log:        log.WithComponent(types.ComponentNameWebhooks),
+	}
+}
+
+// Run Send pending deliveries until the context is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		d.maintenance(ctx)
+
+		claimed, err := d.DeliverBatch(ctx)
+		if err != nil {
+			d.log.WithError(err).Error("deliver webhooks")
+		}
+
+		// keep going while there are more pending deliveries
+		if err == nil && claimed == dispatchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dispatchPollInterval):
+		}
+	}
+}
+
+// DeliverBatch Claim a batch of pending deliveries and send them to the subscribers. Returns the number of claimed
+// deliveries.
+func (d *Dispatcher) DeliverBatch(ctx context.Context) (int, error) {
+	deliveries, err := d.database.ClaimWebhookDeliveries(ctx, d.workerID, dispatchBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.SubscriptionID)
+	}
+
+	subscriptions, err := d.database.GetWebhookSubscriptionsByIDs(ctx, ids)
+	if err != nil {
+		err = fmt.Errorf("get webhook subscriptions: %w", err)
+		for _, delivery := range deliveries {
+			d.retry(ctx, delivery, nil, err)
+		}
+		return len(deliveries), err
+	}
+
+	subscriptionsByID := make(map[uuid.UUID]*db.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionsByID[subscription.ID] = subscription
+	}
+
+	for _, delivery := range deliveries {
+		subscription, exists := subscriptionsByID[delivery.SubscriptionID]
+		if !exists || !subscription.Enabled {
+			d.fail(ctx, delivery, nil, fmt.Errorf("the subscription is disabled"))
+			continue
+		}
+
+		statusCode, err := d.send(ctx, subscription, delivery)
+		if err != nil {
+			d.log.WithError(err).Warnf("send webhook delivery %q to %q", delivery.ID, subscription.Url)
+			d.retry(ctx, delivery, statusCode, err)
+			continue
+		}
+
+		metrics.IncWebhookDeliveries(string(delivery.EventType), metrics.WebhookDeliveryStateDelivered)
+		if err := d.database.SetWebhookDeliveryResult(ctx, delivery.ID, sqlc.WebhookDeliveryStatusDelivered, statusCode, nil, time.Now()); err != nil {
+			d.log.WithError(err).Errorf("mark webhook delivery %q as delivered", delivery.ID)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// send Send a single delivery to the subscriber. The status code is returned when the subscriber responded.
+func (d *Dispatcher) send(ctx context.Context, subscription *db.WebhookSubscription, delivery *db.WebhookDelivery) (*int32, error) {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(delivery.EventType))
+	req.Header.Set(DeliveryIDHeader, delivery.ID.String())
+	req.Header.Set(auditsink.WebhookTimestampHeader, timestamp)
+	req.Header.Set(auditsink.WebhookSignatureHeader, auditsink.WebhookSignature([]byte(subscription.Secret), timestamp, delivery.Payload))
+
+	resp, err := d.httpClient.Do(req)
+	metrics.IncExternalHTTPCalls("webhooks", resp, err)
+	if err != nil {
+		return nil, fmt.Errorf("send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	statusCode := int32(resp.StatusCode)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusCode, fmt.Errorf("unexpected status code from webhook: %d", resp.StatusCode)
+	}
+
+	return &statusCode, nil
+}
+
+// retry Schedule a new attempt of a delivery after a backoff, or mark it as failed when there are no attempts left
+func (d *Dispatcher) retry(ctx context.Context, delivery *db.WebhookDelivery, statusCode *int32, deliveryErr error) {
+	if delivery.Attempts >= maxAttempts {
+		d.fail(ctx, delivery, statusCode, deliveryErr)
+		return
+	}
+
+	metrics.IncWebhookDeliveries(string(delivery.EventType), metrics.WebhookDeliveryStateRetry)
+	nextAttemptAt := time.Now().Add(helpers.Backoff(delivery.Attempts, retryInitialBackoff, retryMaxBackoff))
+	err := d.database.SetWebhookDeliveryResult(ctx, delivery.ID, sqlc.WebhookDeliveryStatusPending, statusCode, helpers.Strp(deliveryErr.Error()), nextAttemptAt)
+	if err != nil {
+		d.log.WithError(err).Errorf("schedule retry of webhook delivery %q", delivery.ID)
+	}
+}
+
+// fail Mark a delivery as failed. It will not be attempted again.
+func (d *Dispatcher) fail(ctx context.Context, delivery *db.WebhookDelivery, statusCode *int32, deliveryErr error) {
+	metrics.IncWebhookDeliveries(string(delivery.EventType), metrics.WebhookDeliveryStateFailed)
+	err := d.database.SetWebhookDeliveryResult(ctx, delivery.ID, sqlc.WebhookDeliveryStatusFailed, statusCode, helpers.Strp(deliveryErr.Error()), time.Now())
+	if err != nil {
+		d.log.WithError(err).Errorf("mark webhook delivery %q as failed", delivery.ID)
+	}
+}
+
+// maintenance Make deliveries claimed by replicas that are no longer around available again, remove old deliveries
+// from the delivery log, and update the number of pending deliveries. Only runs once every maintenanceInterval.
+func (d *Dispatcher) maintenance(ctx context.Context) {
+	if time.Since(d.lastMaintenanceAt) < maintenanceInterval {
+		return
+	}
+	d.lastMaintenanceAt = time.Now()
+
+	released, err := d.database.ReleaseStaleWebhookDeliveries(ctx, time.Now().Add(-staleLockTimeout))
+	if err != nil {
+		d.log.WithError(err).Error("release stale webhook deliveries")
+	} else if released > 0 {
+		d.log.Warnf("released %d stale webhook deliveries", released)
+	}
+
+	if _, err := d.database.DeleteWebhookDeliveriesBefore(ctx, time.Now().Add(-deliveryLogRetention)); err != nil {
+		d.log.WithError(err).Error("delete old webhook deliveries")
+	}
+
+	pending, err := d.database.GetPendingWebhookDeliveryCount(ctx)
+	if err != nil {
+		d.log.WithError(err).Error("get number of pending webhook deliveries")
+		return
+	}
+	metrics.SetPendingWebhookDeliveries(pending)
+}
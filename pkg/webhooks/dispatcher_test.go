@@ -0,0 +1,204 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/auditsink"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/helpers"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/sqlc"
+	"github.com/nais/teams-backend/pkg/types"
+	"github.com/nais/teams-backend/pkg/webhooks"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatcher_DeliverBatch(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte(`{"type":"team.created"}`)
+	statusCode := func(code int32) *int32 {
+		return &code
+	}
+
+	newLogger := func(t *testing.T) *logger.MockLogger {
+		testLogger, _ := test.NewNullLogger()
+		log := logger.NewMockLogger(t)
+		log.On("WithComponent", types.ComponentNameWebhooks).Return(log).Once()
+		log.On("WithError", mock.Anything).Return(&logrus.Entry{Logger: testLogger}).Maybe()
+		return log
+	}
+
+	newDelivery := func(subscriptionID uuid.UUID, attempts int32) *db.WebhookDelivery {
+		return &db.WebhookDelivery{WebhookDelivery: &sqlc.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscriptionID,
+			EventID:        uuid.New(),
+			EventType:      sqlc.WebhookEventTypeTeamcreated,
+			Payload:        payload,
+			Status:         sqlc.WebhookDeliveryStatusPending,
+			Attempts:       attempts,
+		}}
+	}
+
+	t.Run("no pending deliveries", func(t *testing.T) {
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimWebhookDeliveries", ctx, mock.AnythingOfType("string"), 20).
+			Return([]*db.WebhookDelivery{}, nil).
+			Once()
+
+		claimed, err := webhooks.NewDispatcher(database, nil, newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, claimed)
+	})
+
+	t.Run("signed delivery is accepted", func(t *testing.T) {
+		var request *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		subscription := &db.WebhookSubscription{WebhookSubscription: &sqlc.WebhookSubscription{
+			ID:      uuid.New(),
+			Url:     server.URL,
+			Secret:  "secret",
+			Enabled: true,
+		}}
+		delivery := newDelivery(subscription.ID, 1)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimWebhookDeliveries", ctx, mock.AnythingOfType("string"), 20).
+			Return([]*db.WebhookDelivery{delivery}, nil).
+			Once()
+		database.
+			On("GetWebhookSubscriptionsByIDs", ctx, []uuid.UUID{subscription.ID}).
+			Return([]*db.WebhookSubscription{subscription}, nil).
+			Once()
+		database.
+			On("SetWebhookDeliveryResult", ctx, delivery.ID, sqlc.WebhookDeliveryStatusDelivered, statusCode(http.StatusNoContent), (*string)(nil), mock.Anything).
+			Return(nil).
+			Once()
+
+		claimed, err := webhooks.NewDispatcher(database, server.Client(), newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+
+		assert.Equal(t, payload, body)
+		assert.Equal(t, "team.created", request.Header.Get(webhooks.EventTypeHeader))
+		assert.Equal(t, delivery.ID.String(), request.Header.Get(webhooks.DeliveryIDHeader))
+		timestamp := request.Header.Get(auditsink.WebhookTimestampHeader)
+		assert.Equal(t, auditsink.WebhookSignature([]byte("secret"), timestamp, payload), request.Header.Get(auditsink.WebhookSignatureHeader))
+	})
+
+	t.Run("rejected delivery is retried", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		subscription := &db.WebhookSubscription{WebhookSubscription: &sqlc.WebhookSubscription{
+			ID:      uuid.New(),
+			Url:     server.URL,
+			Secret:  "secret",
+			Enabled: true,
+		}}
+		delivery := newDelivery(subscription.ID, 1)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimWebhookDeliveries", ctx, mock.AnythingOfType("string"), 20).
+			Return([]*db.WebhookDelivery{delivery}, nil).
+			Once()
+		database.
+			On("GetWebhookSubscriptionsByIDs", ctx, []uuid.UUID{subscription.ID}).
+			Return([]*db.WebhookSubscription{subscription}, nil).
+			Once()
+		database.
+			On("SetWebhookDeliveryResult", ctx, delivery.ID, sqlc.WebhookDeliveryStatusPending, statusCode(http.StatusServiceUnavailable), helpers.Strp("unexpected status code from webhook: 503"), mock.Anything).
+			Return(nil).
+			Once()
+
+		claimed, err := webhooks.NewDispatcher(database, server.Client(), newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+	})
+
+	t.Run("delivery fails when there are no attempts left", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		subscription := &db.WebhookSubscription{WebhookSubscription: &sqlc.WebhookSubscription{
+			ID:      uuid.New(),
+			Url:     server.URL,
+			Secret:  "secret",
+			Enabled: true,
+		}}
+		delivery := newDelivery(subscription.ID, 10)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimWebhookDeliveries", ctx, mock.AnythingOfType("string"), 20).
+			Return([]*db.WebhookDelivery{delivery}, nil).
+			Once()
+		database.
+			On("GetWebhookSubscriptionsByIDs", ctx, []uuid.UUID{subscription.ID}).
+			Return([]*db.WebhookSubscription{subscription}, nil).
+			Once()
+		database.
+			On("SetWebhookDeliveryResult", ctx, delivery.ID, sqlc.WebhookDeliveryStatusFailed, statusCode(http.StatusInternalServerError), helpers.Strp("unexpected status code from webhook: 500"), mock.Anything).
+			Return(nil).
+			Once()
+
+		claimed, err := webhooks.NewDispatcher(database, server.Client(), newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+	})
+
+	t.Run("delivery to disabled subscription fails", func(t *testing.T) {
+		subscription := &db.WebhookSubscription{WebhookSubscription: &sqlc.WebhookSubscription{
+			ID:      uuid.New(),
+			Url:     "http://localhost",
+			Secret:  "secret",
+			Enabled: false,
+		}}
+		delivery := newDelivery(subscription.ID, 1)
+		deleted := newDelivery(uuid.New(), 1)
+
+		database := db.NewMockDatabase(t)
+		database.
+			On("ClaimWebhookDeliveries", ctx, mock.AnythingOfType("string"), 20).
+			Return([]*db.WebhookDelivery{delivery, deleted}, nil).
+			Once()
+		database.
+			On("GetWebhookSubscriptionsByIDs", ctx, []uuid.UUID{subscription.ID, deleted.SubscriptionID}).
+			Return([]*db.WebhookSubscription{subscription}, nil).
+			Once()
+		database.
+			On("SetWebhookDeliveryResult", ctx, delivery.ID, sqlc.WebhookDeliveryStatusFailed, (*int32)(nil), helpers.Strp("the subscription is disabled"), mock.Anything).
+			Return(nil).
+			Once()
+		database.
+			On("SetWebhookDeliveryResult", ctx, deleted.ID, sqlc.WebhookDeliveryStatusFailed, (*int32)(nil), helpers.Strp("the subscription is disabled"), mock.Anything).
+			Return(nil).
+			Once()
+
+		claimed, err := webhooks.NewDispatcher(database, nil, newLogger(t)).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, claimed)
+	})
+}
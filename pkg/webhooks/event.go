@@ -0,0 +1,35 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/slug"
+	"github.com/nais/teams-backend/pkg/sqlc"
+)
+
+// Event The JSON payload delivered to webhook subscribers. All deliveries of the same event share the same ID, which
+// receivers can use to discard duplicates.
+type Event struct {
+	ID            uuid.UUID             `json:"id"`
+	Type          sqlc.WebhookEventType `json:"type"`
+	CreatedAt     time.Time             `json:"createdAt"`
+	CorrelationID uuid.UUID             `json:"correlationID"`
+	Actor         *string               `json:"actor,omitempty"`
+	Team          slug.Slug             `json:"team"`
+	User          *string               `json:"user,omitempty"`
+	Message       string                `json:"message,omitempty"`
+	Changes       []*db.AuditLogChange  `json:"changes,omitempty"`
+	Errors        []*ReconcilerError    `json:"errors,omitempty"`
+}
+
+// ReconcilerError A reconciler that failed during a team sync, included in sync.failed events. Attempts is the number
+// of consecutive failures of the reconciler for the team, and is 0 for skipped reconcilers. RetriesExhausted is set when
+// no more retries will be scheduled.
+type ReconcilerError struct {
+	Reconciler       sqlc.ReconcilerName `json:"reconciler"`
+	Error            string              `json:"error"`
+	Attempts         int32               `json:"attempts"`
+	RetriesExhausted bool                `json:"retriesExhausted"`
+}
@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nais/teams-backend/pkg/db"
+	"github.com/nais/teams-backend/pkg/logger"
+	"github.com/nais/teams-backend/pkg/types"
+)
+
+// secretLength The number of random bytes in a generated subscription secret
+const secretLength = 32
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+type publisher struct {
+	database db.Database
+	log      logger.Logger
+}
+
+type publisherForTesting struct {
+	lock   sync.Mutex
+	events []Event
+}
+
+func NewPublisher(database db.Database, log logger.Logger) Publisher {
+	return &publisher{
+		database: database,
+		log:      log.WithComponent(types.ComponentNameWebhooks),
+	}
+}
+
+func NewPublisherForTesting() *publisherForTesting {
+	return &publisherForTesting{
+		events: make([]Event, 0),
+	}
+}
+
+func (p *publisherForTesting) Publish(_ context.Context, event Event) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *publisherForTesting) Events() []Event {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.events
+}
+
+// Publish Queue a delivery of the event to all enabled subscriptions of the event type. The deliveries are sent by the
+// Dispatcher. Failures are logged and not returned, as a webhook event must never fail the action that caused it. Do
+// not call this function inside of a database transaction, as the event would be published even if the transaction is
+// rolled back.
+func (p *publisher) Publish(ctx context.Context, event Event) {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	log := p.log.WithTeamSlug(string(event.Team))
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.WithError(err).Errorf("encode webhook event %q", event.Type)
+		return
+	}
+
+	deliveries, err := p.database.CreateWebhookDeliveries(ctx, event.ID, event.Type, payload)
+	if err != nil {
+		log.WithError(err).Errorf("create deliveries of webhook event %q", event.Type)
+		return
+	}
+
+	if deliveries > 0 {
+		log.Debugf("queued %d deliveries of webhook event %q", deliveries, event.Type)
+	}
+}
+
+// GenerateSecret Create a random secret used to sign the deliveries to a subscription
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
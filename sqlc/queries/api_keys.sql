@@ -1,7 +1,30 @@
--- name: CreateAPIKey :exec
-INSERT INTO api_keys (api_key, service_account_id)
-VALUES ($1, $2);
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (service_account_id, prefix, salt, hash, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetAPIKeyByID :one
+SELECT * FROM api_keys
+WHERE id = $1;
+
+-- name: GetActiveAPIKeysByPrefix :many
+SELECT * FROM api_keys
+WHERE prefix = $1 AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: GetServiceAccountAPIKeys :many
+SELECT * FROM api_keys
+WHERE service_account_id = $1
+ORDER BY created_at DESC;
+
+-- name: SetAPIKeyLastUsed :exec
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
+
+-- name: RevokeAPIKey :exec
+DELETE FROM api_keys
+WHERE id = $1;
 
 -- name: RemoveApiKeysFromServiceAccount :exec
 DELETE FROM api_keys
-WHERE service_account_id = $1;
\ No newline at end of file
+WHERE service_account_id = $1;
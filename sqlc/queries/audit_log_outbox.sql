@@ -0,0 +1,40 @@
+-- name: CreateAuditLogOutboxEntry :exec
+INSERT INTO audit_log_outbox (audit_log_id)
+VALUES ($1);
+
+-- name: ClaimAuditLogOutboxEntries :many
+UPDATE audit_log_outbox
+SET locked_by = sqlc.arg(locked_by)::TEXT, locked_at = NOW(), attempts = attempts + 1
+WHERE id IN (
+    SELECT o.id FROM audit_log_outbox AS o
+    WHERE o.locked_by IS NULL AND o.next_attempt_at <= NOW()
+    ORDER BY o.id ASC
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: DeleteAuditLogOutboxEntry :exec
+DELETE FROM audit_log_outbox
+WHERE id = $1;
+
+-- name: RetryAuditLogOutboxEntry :exec
+UPDATE audit_log_outbox
+SET
+    locked_by = NULL,
+    locked_at = NULL,
+    delivered_sinks = sqlc.arg(delivered_sinks)::TEXT[],
+    last_error = sqlc.arg(last_error)::TEXT,
+    next_attempt_at = sqlc.arg(next_attempt_at)::TIMESTAMPTZ
+WHERE id = sqlc.arg(id);
+
+-- name: ReleaseStaleAuditLogOutboxEntries :execrows
+UPDATE audit_log_outbox
+SET locked_by = NULL, locked_at = NULL
+WHERE locked_by IS NOT NULL AND locked_at < sqlc.arg(locked_before)::TIMESTAMPTZ;
+
+-- name: GetPendingAuditLogOutboxCount :one
+SELECT COUNT(*) FROM audit_log_outbox;
+
+-- name: DeleteAuditLogOutboxEntries :execrows
+DELETE FROM audit_log_outbox;
@@ -1,6 +1,6 @@
 -- name: CreateAuditLog :exec
-INSERT INTO audit_logs (correlation_id, actor, component_name, target_type, target_identifier, action, message)
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+INSERT INTO audit_logs (id, created_at, correlation_id, actor, component_name, target_type, target_identifier, action, message, changes, chain_position, previous_hash, hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
 
 -- name: GetAuditLogsForTeam :many
 SELECT * FROM audit_logs
@@ -17,4 +17,76 @@ ORDER BY created_at DESC;
 SELECT * FROM audit_logs
 WHERE target_type = 'reconciler' AND target_identifier = $1
 ORDER BY created_at DESC
-LIMIT 100;
\ No newline at end of file
+LIMIT 100;
+
+-- name: GetAuditLogs :many
+SELECT * FROM audit_logs
+WHERE
+    (sqlc.narg(action)::TEXT IS NULL OR action = sqlc.narg(action)::TEXT)
+    AND (sqlc.narg(component_name)::TEXT IS NULL OR component_name = sqlc.narg(component_name)::TEXT)
+    AND (sqlc.narg(actor)::TEXT IS NULL OR actor = sqlc.narg(actor)::TEXT)
+    AND (sqlc.narg(target_type)::TEXT IS NULL OR target_type = sqlc.narg(target_type)::TEXT)
+    AND (sqlc.narg(target_identifier)::TEXT IS NULL OR target_identifier = sqlc.narg(target_identifier)::TEXT)
+    AND (sqlc.narg(correlation_id)::UUID IS NULL OR correlation_id = sqlc.narg(correlation_id)::UUID)
+    AND (sqlc.narg(created_after)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(created_after)::TIMESTAMPTZ)
+    AND (sqlc.narg(created_before)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(created_before)::TIMESTAMPTZ)
+    AND (
+        sqlc.narg(cursor_created_at)::TIMESTAMPTZ IS NULL
+        OR (created_at, id) < (sqlc.narg(cursor_created_at)::TIMESTAMPTZ, sqlc.narg(cursor_id)::UUID)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows);
+
+
+-- name: GetAuditLogsByIDs :many
+SELECT * FROM audit_logs
+WHERE id = ANY(sqlc.arg(ids)::UUID[])
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetExpiredAuditLogs :many
+SELECT * FROM audit_logs
+WHERE
+    created_at < sqlc.arg(created_before)::TIMESTAMPTZ
+    AND (sqlc.narg(action)::TEXT IS NULL OR action = sqlc.narg(action)::TEXT)
+    AND (sqlc.narg(component_name)::TEXT IS NULL OR component_name = sqlc.narg(component_name)::TEXT)
+    AND NOT (action = ANY(sqlc.arg(excluded_actions)::TEXT[]))
+    AND NOT (component_name = ANY(sqlc.arg(excluded_components)::TEXT[]))
+    AND NOT EXISTS (
+        SELECT o.id FROM audit_log_outbox AS o
+        WHERE o.audit_log_id = audit_logs.id
+    )
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg(limit_rows);
+
+-- name: DeleteAuditLogsByIDs :execrows
+WITH pruned AS (
+    INSERT INTO audit_log_pruned_links (chain_position, previous_hash, hash)
+    SELECT chain_position, previous_hash, hash FROM audit_logs
+    WHERE id = ANY(sqlc.arg(ids)::UUID[]) AND chain_position IS NOT NULL
+)
+DELETE FROM audit_logs
+WHERE id = ANY(sqlc.arg(ids)::UUID[]);
+
+-- name: LockAuditLogChain :exec
+SELECT pg_advisory_xact_lock(sqlc.arg(lock_id)::BIGINT);
+
+-- name: GetAuditLogChainTail :one
+SELECT links.chain_position::BIGINT AS chain_position, links.hash::TEXT AS hash FROM (
+    (SELECT chain_position, hash FROM audit_logs WHERE chain_position IS NOT NULL ORDER BY chain_position DESC LIMIT 1)
+    UNION ALL
+    (SELECT chain_position, hash FROM audit_log_pruned_links ORDER BY chain_position DESC LIMIT 1)
+) AS links
+ORDER BY links.chain_position DESC
+LIMIT 1;
+
+-- name: GetAuditLogChain :many
+SELECT * FROM audit_logs
+WHERE chain_position > sqlc.arg(after_position)::BIGINT
+ORDER BY chain_position ASC
+LIMIT sqlc.arg(limit_rows);
+
+-- name: GetAuditLogPrunedLinks :many
+SELECT * FROM audit_log_pruned_links
+WHERE chain_position > sqlc.arg(after_position)::BIGINT
+ORDER BY chain_position ASC
+LIMIT sqlc.arg(limit_rows);
@@ -0,0 +1,11 @@
+-- name: AcquireLeaderLease :one
+INSERT INTO leader_leases (name, holder, expires_at)
+VALUES ($1, $2, NOW() + sqlc.arg(lease_seconds)::INT * INTERVAL '1 second')
+ON CONFLICT (name) DO
+    UPDATE SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+    WHERE leader_leases.holder = EXCLUDED.holder OR leader_leases.expires_at < NOW()
+RETURNING *;
+
+-- name: ReleaseLeaderLease :exec
+DELETE FROM leader_leases
+WHERE name = $1 AND holder = $2;
@@ -2,13 +2,25 @@
 DELETE FROM reconciler_errors
 WHERE team_slug = $1 AND reconciler = $2;
 
--- name: SetReconcilerErrorForTeam :exec
-INSERT INTO reconciler_errors (correlation_id, team_slug, reconciler, error_message)
-VALUES ($1, $2, $3, $4)
+-- name: SetReconcilerErrorForTeam :one
+INSERT INTO reconciler_errors (correlation_id, team_slug, reconciler, error_message, kind)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT(team_slug, reconciler) DO
+    UPDATE SET correlation_id = $1, created_at = NOW(), error_message = $4, kind = $5, attempts = reconciler_errors.attempts + 1, next_retry_at = NULL
+RETURNING *;
+
+-- name: SetReconcilerSkippedForTeam :exec
+INSERT INTO reconciler_errors (correlation_id, team_slug, reconciler, error_message, kind, attempts)
+VALUES ($1, $2, $3, $4, 'error', 0)
 ON CONFLICT(team_slug, reconciler) DO
     UPDATE SET correlation_id = $1, created_at = NOW(), error_message = $4;
 
+-- name: SetReconcilerErrorNextRetry :exec
+UPDATE reconciler_errors
+SET next_retry_at = sqlc.arg(next_retry_at)::TIMESTAMPTZ
+WHERE team_slug = $1 AND reconciler = $2;
+
 -- name: GetTeamReconcilerErrors :many
 SELECT * FROM reconciler_errors
 WHERE team_slug = $1
-ORDER BY created_at DESC;
\ No newline at end of file
+ORDER BY created_at DESC;
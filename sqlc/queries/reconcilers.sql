@@ -23,6 +23,12 @@ SET enabled = false
 WHERE name = $1
 RETURNING *;
 
+-- name: SetReconcilerTimeout :one
+UPDATE reconcilers
+SET timeout_seconds = sqlc.narg(timeout_seconds)
+WHERE name = $1
+RETURNING *;
+
 -- name: ResetReconcilerConfig :exec
 UPDATE reconciler_config
 SET value = NULL
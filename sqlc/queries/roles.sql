@@ -1,3 +1,50 @@
+-- name: GetRoles :many
+SELECT * FROM roles
+ORDER BY name ASC;
+
+-- name: GetRoleByName :one
+SELECT * FROM roles
+WHERE name = $1;
+
+-- name: GetRoleAuthorizations :many
+SELECT authorization_name FROM role_authorizations
+WHERE role_name = $1
+ORDER BY authorization_name ASC;
+
+-- name: GetAuthorizationsForRoles :many
+SELECT role_name, authorization_name FROM role_authorizations
+WHERE role_name = ANY(sqlc.arg(role_names)::TEXT[])
+ORDER BY role_name ASC, authorization_name ASC;
+
+-- name: GetAuthorizationNames :many
+SELECT DISTINCT authorization_name FROM role_authorizations
+ORDER BY authorization_name ASC;
+
+-- name: CreateRole :one
+INSERT INTO roles (name, description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET description = $2
+WHERE name = $1
+AND NOT built_in
+RETURNING *;
+
+-- name: DeleteRole :exec
+DELETE FROM roles
+WHERE name = $1
+AND NOT built_in;
+
+-- name: AddRoleAuthorization :exec
+INSERT INTO role_authorizations (role_name, authorization_name)
+VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: RemoveRoleAuthorizations :exec
+DELETE FROM role_authorizations
+WHERE role_name = $1;
+
 -- name: GetUserRoles :many
 SELECT * FROM user_roles
 WHERE user_id = $1;
@@ -6,12 +53,12 @@ WHERE user_id = $1;
 SELECT * FROM user_roles;
 
 -- name: AssignGlobalRoleToUser :exec
-INSERT INTO user_roles (user_id, role_name)
-VALUES ($1, $2) ON CONFLICT DO NOTHING;
+INSERT INTO user_roles (user_id, role_name, granted_by)
+VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;
 
 -- name: AssignGlobalRoleToServiceAccount :exec
-INSERT INTO service_account_roles (service_account_id, role_name)
-VALUES ($1, $2) ON CONFLICT DO NOTHING;
+INSERT INTO service_account_roles (service_account_id, role_name, granted_by)
+VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;
 
 -- name: AssignTeamRoleToUser :exec
 INSERT INTO user_roles (user_id, role_name, target_team_slug)
@@ -21,6 +68,10 @@ VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;
 INSERT INTO service_account_roles (service_account_id, role_name, target_team_slug)
 VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;
 
+-- name: AssignServiceAccountRoleToUser :exec
+INSERT INTO user_roles (user_id, role_name, target_service_account_id)
+VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;
+
 -- name: RevokeGlobalUserRole :exec
 DELETE FROM user_roles
 WHERE user_id = $1
@@ -28,6 +79,29 @@ AND target_team_slug IS NULL
 AND target_service_account_id IS NULL
 AND role_name = $2;
 
+-- name: RevokeGlobalServiceAccountRole :exec
+DELETE FROM service_account_roles
+WHERE service_account_id = $1
+AND target_team_slug IS NULL
+AND target_service_account_id IS NULL
+AND role_name = $2;
+
+-- name: LockGlobalAdmins :exec
+SELECT pg_advisory_xact_lock(sqlc.arg(lock_id)::BIGINT);
+
+-- name: CountGlobalAdmins :one
+SELECT ((
+    SELECT COUNT(*) FROM user_roles
+    WHERE user_roles.role_name = 'Admin'
+    AND user_roles.target_team_slug IS NULL
+    AND user_roles.target_service_account_id IS NULL
+) + (
+    SELECT COUNT(*) FROM service_account_roles
+    WHERE service_account_roles.role_name = 'Admin'
+    AND service_account_roles.target_team_slug IS NULL
+    AND service_account_roles.target_service_account_id IS NULL
+))::INT AS count;
+
 -- name: RemoveAllServiceAccountRoles :exec
 DELETE FROM service_account_roles
 WHERE service_account_id = $1;
@@ -1,21 +1,31 @@
 -- name: CreateServiceAccount :one
-INSERT INTO service_accounts (name)
-VALUES ($1)
+INSERT INTO service_accounts (name, team_slug)
+VALUES ($1, $2)
 RETURNING *;
 
 -- name: GetServiceAccounts :many
 SELECT * FROM service_accounts
 ORDER BY name ASC;
 
+-- name: GetTeamServiceAccounts :many
+SELECT * FROM service_accounts
+WHERE team_slug = $1
+ORDER BY name ASC;
+
+-- name: GetServiceAccountByID :one
+SELECT * FROM service_accounts
+WHERE id = $1;
+
+-- name: UpdateServiceAccount :one
+UPDATE service_accounts
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: GetServiceAccountByName :one
 SELECT * FROM service_accounts
 WHERE name = $1;
 
--- name: GetServiceAccountByApiKey :one
-SELECT service_accounts.* FROM api_keys
-JOIN service_accounts ON service_accounts.id = api_keys.service_account_id
-WHERE api_keys.api_key = $1;
-
 -- name: DeleteServiceAccount :exec
 DELETE FROM service_accounts
 WHERE id = $1;
@@ -0,0 +1,39 @@
+-- name: CreateTeamDeletion :one
+INSERT INTO team_deletions (team_slug, correlation_id, delete_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetTeamDeletion :one
+SELECT * FROM team_deletions
+WHERE team_slug = $1;
+
+-- name: DeleteTeamDeletion :execrows
+DELETE FROM team_deletions
+WHERE team_slug = $1 AND started_at IS NULL;
+
+-- name: StartDueTeamDeletions :many
+UPDATE team_deletions
+SET started_at = NOW()
+WHERE delete_at <= NOW() AND started_at IS NULL
+RETURNING *;
+
+-- name: ResetTeamDeletion :exec
+UPDATE team_deletions
+SET started_at = NULL, delete_at = $2
+WHERE team_slug = $1;
+
+-- name: ReleaseStaleTeamDeletions :execrows
+UPDATE team_deletions
+SET started_at = NULL
+WHERE started_at IS NOT NULL AND started_at < sqlc.arg(started_before)::TIMESTAMPTZ;
+
+-- name: SetTeamDeletionReconcilerResult :exec
+INSERT INTO team_deletion_reconcilers (team_slug, reconciler, status, error_message)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (team_slug, reconciler) DO UPDATE
+SET status = EXCLUDED.status, error_message = EXCLUDED.error_message, finished_at = NOW();
+
+-- name: GetTeamDeletionReconcilers :many
+SELECT * FROM team_deletion_reconcilers
+WHERE team_slug = $1
+ORDER BY finished_at ASC, reconciler ASC;
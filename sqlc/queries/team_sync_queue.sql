@@ -0,0 +1,42 @@
+-- name: EnqueueTeamSync :exec
+INSERT INTO team_sync_queue (team_slug, correlation_id, triggered_by, reconcilers, next_run_at)
+VALUES ($1, $2, $3, sqlc.arg(reconcilers)::TEXT[], sqlc.arg(next_run_at)::TIMESTAMPTZ);
+
+-- name: ClaimTeamSync :one
+UPDATE team_sync_queue
+SET locked_by = sqlc.arg(locked_by)::TEXT, locked_at = NOW(), attempts = attempts + 1
+WHERE id = (
+    SELECT q.id FROM team_sync_queue AS q
+    WHERE
+        q.locked_by IS NULL
+        AND q.next_run_at <= NOW()
+        AND NOT EXISTS (
+            SELECT l.id FROM team_sync_queue AS l
+            WHERE l.team_slug = q.team_slug AND l.locked_by IS NOT NULL
+        )
+    ORDER BY q.next_run_at ASC, q.id ASC
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: DeleteTeamSync :exec
+DELETE FROM team_sync_queue
+WHERE id = $1;
+
+-- name: ReleaseTeamSync :exec
+UPDATE team_sync_queue
+SET locked_by = NULL, locked_at = NULL
+WHERE id = $1;
+
+-- name: ReleaseStaleTeamSyncs :execrows
+UPDATE team_sync_queue
+SET locked_by = NULL, locked_at = NULL
+WHERE locked_by IS NOT NULL AND locked_at < sqlc.arg(locked_before)::TIMESTAMPTZ;
+
+-- name: GetPendingTeamSyncCount :one
+SELECT COUNT(*) FROM team_sync_queue
+WHERE locked_by IS NULL;
+
+-- name: Notify :exec
+SELECT pg_notify(sqlc.arg(channel)::TEXT, sqlc.arg(payload)::TEXT);
@@ -0,0 +1,42 @@
+-- name: CreateTeamSyncRun :one
+INSERT INTO team_sync_runs (team_slug, correlation_id, triggered_by, reconcilers)
+VALUES ($1, $2, $3, sqlc.arg(reconcilers)::TEXT[])
+RETURNING *;
+
+-- name: FinishTeamSyncRun :one
+UPDATE team_sync_runs
+SET
+    finished_at = NOW(),
+    status = $2,
+    error_message = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateTeamSyncRunReconciler :exec
+INSERT INTO team_sync_run_reconcilers (run_id, reconciler, status, started_at, finished_at, error_message)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetTeamSyncRuns :many
+SELECT * FROM team_sync_runs
+WHERE
+    team_slug = sqlc.arg(team_slug)
+    AND (
+        sqlc.narg(cursor_started_at)::TIMESTAMPTZ IS NULL
+        OR (started_at, id) < (sqlc.narg(cursor_started_at)::TIMESTAMPTZ, sqlc.narg(cursor_id)::BIGINT)
+    )
+ORDER BY started_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows);
+
+-- name: GetTeamSyncRunReconcilers :many
+SELECT * FROM team_sync_run_reconcilers
+WHERE run_id = $1
+ORDER BY started_at ASC, reconciler ASC;
+
+-- name: DeleteOldTeamSyncRuns :execrows
+DELETE FROM team_sync_runs AS r
+WHERE r.team_slug = sqlc.arg(team_slug) AND r.id NOT IN (
+    SELECT k.id FROM team_sync_runs AS k
+    WHERE k.team_slug = sqlc.arg(team_slug)
+    ORDER BY k.started_at DESC, k.id DESC
+    LIMIT sqlc.arg(runs_to_keep)
+);
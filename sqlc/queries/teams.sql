@@ -108,6 +108,10 @@ UPDATE team_delete_keys
 SET confirmed_at = NOW()
 WHERE key = $1;
 
+-- name: DeleteTeamDeleteKeys :exec
+DELETE FROM team_delete_keys
+WHERE team_slug = $1;
+
 -- name: DeleteTeam :exec
 DELETE FROM teams
 WHERE slug = $1;
@@ -121,4 +125,64 @@ SELECT
     ) AS enabled
 FROM reconcilers
 WHERE reconcilers.enabled = true
-ORDER BY reconcilers.name ASC;
\ No newline at end of file
+ORDER BY reconcilers.name ASC;
+
+-- name: LockTeamAndParentAncestors :exec
+WITH RECURSIVE ancestors AS (
+    SELECT teams.slug, teams.parent_team_slug FROM teams
+    WHERE teams.slug = sqlc.arg(parent_slug)
+    UNION
+    SELECT teams.slug, teams.parent_team_slug FROM teams
+    JOIN ancestors ON teams.slug = ancestors.parent_team_slug
+)
+SELECT teams.slug FROM teams
+WHERE teams.slug = sqlc.arg(slug) OR teams.slug IN (SELECT ancestors.slug FROM ancestors)
+ORDER BY teams.slug ASC
+FOR UPDATE;
+
+-- name: SetTeamParent :one
+UPDATE teams
+SET parent_team_slug = sqlc.narg(parent_team_slug)
+WHERE slug = sqlc.arg(slug)
+RETURNING *;
+
+-- name: GetTeamChildren :many
+SELECT teams.* FROM teams
+WHERE teams.parent_team_slug = $1
+ORDER BY teams.slug ASC;
+
+-- name: GetTeamDescendantSlugs :many
+WITH RECURSIVE descendants AS (
+    SELECT teams.slug FROM teams
+    WHERE teams.slug = sqlc.arg(slug)
+    UNION
+    SELECT teams.slug FROM teams
+    JOIN descendants ON teams.parent_team_slug = descendants.slug
+)
+SELECT descendants.slug::TEXT FROM descendants
+WHERE descendants.slug::TEXT <> sqlc.arg(slug)::TEXT
+ORDER BY descendants.slug ASC;
+
+-- name: GetDescendantSlugsForTeams :many
+WITH RECURSIVE descendants AS (
+    SELECT teams.slug AS ancestor_slug, teams.slug FROM teams
+    WHERE teams.slug = ANY(sqlc.arg(slugs)::TEXT[])
+    UNION
+    SELECT descendants.ancestor_slug, teams.slug FROM teams
+    JOIN descendants ON teams.parent_team_slug = descendants.slug
+)
+SELECT descendants.ancestor_slug::TEXT AS ancestor_slug, descendants.slug::TEXT AS slug FROM descendants
+WHERE descendants.slug <> descendants.ancestor_slug
+ORDER BY descendants.ancestor_slug ASC, descendants.slug ASC;
+
+-- name: DisableTeam :one
+UPDATE teams
+SET enabled = false, resources_suspended = sqlc.arg(resources_suspended)
+WHERE slug = sqlc.arg(slug)
+RETURNING *;
+
+-- name: EnableTeam :one
+UPDATE teams
+SET enabled = true, resources_suspended = false
+WHERE slug = $1
+RETURNING *;
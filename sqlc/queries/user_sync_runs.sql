@@ -0,0 +1,29 @@
+-- name: CreateUserSyncRun :one
+INSERT INTO user_sync_runs (correlation_id)
+VALUES ($1)
+RETURNING *;
+
+-- name: FinishUserSyncRun :one
+UPDATE user_sync_runs
+SET
+    finished_at = NOW(),
+    status = $2,
+    error_message = $3,
+    created_users = $4,
+    updated_users = $5,
+    deleted_users = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: GetUserSyncRuns :many
+SELECT * FROM user_sync_runs
+ORDER BY started_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
+
+-- name: DeleteOldUserSyncRuns :execrows
+DELETE FROM user_sync_runs
+WHERE id NOT IN (
+    SELECT id FROM user_sync_runs
+    ORDER BY started_at DESC, id DESC
+    LIMIT sqlc.arg(runs_to_keep)
+);
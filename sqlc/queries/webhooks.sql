@@ -0,0 +1,78 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, event_types)
+VALUES (sqlc.arg(url), sqlc.arg(secret), sqlc.arg(event_types)::TEXT[])
+RETURNING *;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET
+    url = COALESCE(sqlc.narg(url), url),
+    event_types = COALESCE(sqlc.narg(event_types)::TEXT[], event_types),
+    enabled = COALESCE(sqlc.narg(enabled), enabled)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :exec
+DELETE FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: GetWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+ORDER BY created_at ASC;
+
+-- name: GetWebhookSubscriptionsByIDs :many
+SELECT * FROM webhook_subscriptions
+WHERE id = ANY(sqlc.arg(ids)::UUID[]);
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT id, sqlc.arg(event_id), sqlc.arg(event_type), sqlc.arg(payload)
+FROM webhook_subscriptions
+WHERE enabled = true AND sqlc.arg(event_type)::TEXT = ANY(event_types);
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET locked_by = sqlc.arg(locked_by)::TEXT, locked_at = NOW(), attempts = attempts + 1
+WHERE id IN (
+    SELECT d.id FROM webhook_deliveries AS d
+    WHERE d.status = 'pending' AND d.locked_by IS NULL AND d.next_attempt_at <= NOW()
+    ORDER BY d.next_attempt_at ASC
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SetWebhookDeliveryResult :exec
+UPDATE webhook_deliveries
+SET
+    locked_by = NULL,
+    locked_at = NULL,
+    status = sqlc.arg(status),
+    last_status_code = sqlc.narg(last_status_code),
+    last_error = sqlc.narg(last_error),
+    next_attempt_at = sqlc.arg(next_attempt_at)::TIMESTAMPTZ,
+    delivered_at = CASE WHEN sqlc.arg(status) = 'delivered' THEN NOW() END
+WHERE id = sqlc.arg(id);
+
+-- name: ReleaseStaleWebhookDeliveries :execrows
+UPDATE webhook_deliveries
+SET locked_by = NULL, locked_at = NULL
+WHERE locked_by IS NOT NULL AND locked_at < sqlc.arg(locked_before)::TIMESTAMPTZ;
+
+-- name: DeleteWebhookDeliveriesBefore :execrows
+DELETE FROM webhook_deliveries
+WHERE status <> 'pending' AND created_at < sqlc.arg(created_before)::TIMESTAMPTZ;
+
+-- name: GetWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = sqlc.arg(subscription_id)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
+
+-- name: GetPendingWebhookDeliveryCount :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE status = 'pending';
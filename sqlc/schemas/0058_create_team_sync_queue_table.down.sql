@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE team_sync_queue;
+
+COMMIT;
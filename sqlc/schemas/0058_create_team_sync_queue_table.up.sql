@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE team_sync_queue (
+    id BIGSERIAL,
+    team_slug text NOT NULL,
+    correlation_id uuid NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    next_run_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    locked_by text,
+    locked_at timestamp with time zone,
+    PRIMARY KEY(id),
+    CHECK (((locked_by IS NULL) = (locked_at IS NULL)))
+);
+
+CREATE INDEX ON team_sync_queue USING btree (next_run_at, id) WHERE (locked_by IS NULL);
+CREATE INDEX ON team_sync_queue USING btree (locked_at) WHERE (locked_by IS NOT NULL);
+
+-- a team can only be synchronized by a single worker at a time
+CREATE UNIQUE INDEX ON team_sync_queue USING btree (team_slug) WHERE (locked_by IS NOT NULL);
+
+ALTER TABLE team_sync_queue
+ADD FOREIGN KEY (team_slug) REFERENCES teams(slug) ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE team_sync_queue
+DROP COLUMN reconcilers;
+
+ALTER TABLE reconciler_errors
+DROP COLUMN next_retry_at,
+DROP COLUMN attempts;
+
+COMMIT;
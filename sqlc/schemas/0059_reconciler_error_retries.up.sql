@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE reconciler_errors
+ADD COLUMN attempts integer DEFAULT 1 NOT NULL,
+ADD COLUMN next_retry_at timestamp with time zone;
+
+ALTER TABLE team_sync_queue
+ADD COLUMN reconcilers text[] NOT NULL DEFAULT '{}';
+
+COMMIT;
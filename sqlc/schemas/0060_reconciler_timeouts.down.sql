@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE reconcilers
+DROP COLUMN timeout_seconds;
+
+ALTER TABLE reconciler_errors
+DROP COLUMN kind;
+
+DROP TYPE reconciler_error_kind;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TYPE reconciler_error_kind AS ENUM (
+    'error',
+    'timeout'
+);
+
+ALTER TABLE reconciler_errors
+ADD COLUMN kind reconciler_error_kind DEFAULT 'error' NOT NULL;
+
+ALTER TABLE reconcilers
+ADD COLUMN timeout_seconds integer,
+ADD CHECK (timeout_seconds > 0);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TABLE leader_leases (
+    name text NOT NULL,
+    holder text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    PRIMARY KEY(name)
+);
+
+COMMIT;
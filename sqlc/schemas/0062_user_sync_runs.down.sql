@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE user_sync_runs;
+DROP TYPE user_sync_run_status;
+
+COMMIT;
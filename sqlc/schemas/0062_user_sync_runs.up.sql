@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TYPE user_sync_run_status AS ENUM (
+    'in_progress',
+    'success',
+    'failure'
+);
+
+CREATE TABLE user_sync_runs (
+    id BIGSERIAL,
+    correlation_id uuid NOT NULL,
+    started_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    finished_at timestamp with time zone,
+    status user_sync_run_status DEFAULT 'in_progress' NOT NULL,
+    error_message text,
+    created_users integer DEFAULT 0 NOT NULL,
+    updated_users integer DEFAULT 0 NOT NULL,
+    deleted_users integer DEFAULT 0 NOT NULL,
+    PRIMARY KEY(id)
+);
+
+CREATE INDEX ON user_sync_runs USING btree (started_at DESC, id DESC);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX audit_logs_actor_idx;
+DROP INDEX audit_logs_component_name_idx;
+DROP INDEX audit_logs_action_idx;
+DROP INDEX audit_logs_target_idx;
+DROP INDEX audit_logs_correlation_id_idx;
+DROP INDEX audit_logs_created_at_id_idx;
+
+CREATE INDEX ON audit_logs USING btree (created_at DESC);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX audit_logs_created_at_idx;
+
+CREATE INDEX audit_logs_created_at_id_idx ON audit_logs USING btree (created_at DESC, id DESC);
+CREATE INDEX audit_logs_correlation_id_idx ON audit_logs USING btree (correlation_id);
+CREATE INDEX audit_logs_target_idx ON audit_logs USING btree (target_type, target_identifier, created_at DESC, id DESC);
+CREATE INDEX audit_logs_action_idx ON audit_logs USING btree (action, created_at DESC, id DESC);
+CREATE INDEX audit_logs_component_name_idx ON audit_logs USING btree (component_name, created_at DESC, id DESC);
+CREATE INDEX audit_logs_actor_idx ON audit_logs USING btree (actor, created_at DESC, id DESC);
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE audit_log_outbox (
+    id BIGSERIAL,
+    audit_log_id uuid NOT NULL,
+    delivered_sinks text[] DEFAULT '{}' NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_error text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    next_attempt_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    locked_by text,
+    locked_at timestamp with time zone,
+    PRIMARY KEY(id),
+    UNIQUE(audit_log_id),
+    CHECK (((locked_by IS NULL) = (locked_at IS NULL)))
+);
+
+CREATE INDEX ON audit_log_outbox USING btree (next_attempt_at, id) WHERE (locked_by IS NULL);
+CREATE INDEX ON audit_log_outbox USING btree (locked_at) WHERE (locked_by IS NOT NULL);
+
+ALTER TABLE audit_log_outbox
+ADD FOREIGN KEY (audit_log_id) REFERENCES audit_logs(id) ON DELETE CASCADE;
+
+COMMIT;
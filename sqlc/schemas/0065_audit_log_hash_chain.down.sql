@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE audit_log_pruned_links;
+
+ALTER TABLE audit_logs
+DROP COLUMN hash,
+DROP COLUMN previous_hash,
+DROP COLUMN chain_position;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- entries created before the hash chain was introduced are not part of it
+ALTER TABLE audit_logs
+ADD COLUMN chain_position bigint,
+ADD COLUMN previous_hash text,
+ADD COLUMN hash text,
+ADD UNIQUE (chain_position),
+ADD CHECK (((chain_position IS NULL) = (hash IS NULL)));
+
+-- links of entries removed by the retention policy, so that the rest of the chain can still be verified
+CREATE TABLE audit_log_pruned_links (
+    chain_position bigint NOT NULL,
+    previous_hash text,
+    hash text NOT NULL,
+    pruned_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(chain_position)
+);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE audit_logs DROP COLUMN changes;
+
+COMMIT;
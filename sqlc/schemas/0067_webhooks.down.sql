@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
+DROP TYPE webhook_delivery_status;
+DROP TYPE webhook_event_type;
+
+COMMIT;
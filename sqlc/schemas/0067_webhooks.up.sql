@@ -0,0 +1,56 @@
+BEGIN;
+
+CREATE TYPE webhook_event_type AS ENUM (
+    'team.created',
+    'team.updated',
+    'team.deleted',
+    'member.added',
+    'member.removed',
+    'sync.failed'
+);
+
+CREATE TYPE webhook_delivery_status AS ENUM (
+    'pending',
+    'delivered',
+    'failed'
+);
+
+CREATE TABLE webhook_subscriptions (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    event_types text[] NOT NULL,
+    enabled boolean DEFAULT true NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(id),
+    CHECK ((cardinality(event_types) > 0))
+);
+
+CREATE TABLE webhook_deliveries (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    subscription_id uuid NOT NULL,
+    event_id uuid NOT NULL,
+    event_type webhook_event_type NOT NULL,
+    payload jsonb NOT NULL,
+    status webhook_delivery_status DEFAULT 'pending' NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_status_code integer,
+    last_error text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    next_attempt_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    delivered_at timestamp with time zone,
+    locked_by text,
+    locked_at timestamp with time zone,
+    PRIMARY KEY(id),
+    UNIQUE(subscription_id, event_id),
+    CHECK (((locked_by IS NULL) = (locked_at IS NULL)))
+);
+
+CREATE INDEX ON webhook_deliveries USING btree (next_attempt_at) WHERE (status = 'pending' AND locked_by IS NULL);
+CREATE INDEX ON webhook_deliveries USING btree (locked_at) WHERE (locked_by IS NOT NULL);
+CREATE INDEX ON webhook_deliveries USING btree (subscription_id, created_at DESC);
+
+ALTER TABLE webhook_deliveries
+ADD FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TABLE team_sync_run_reconcilers;
+DROP TABLE team_sync_runs;
+DROP TYPE team_sync_reconciler_status;
+DROP TYPE team_sync_run_status;
+
+ALTER TABLE team_sync_queue
+DROP COLUMN triggered_by;
+
+DROP TYPE team_sync_trigger;
+
+COMMIT;
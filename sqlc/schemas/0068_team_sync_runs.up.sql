@@ -0,0 +1,59 @@
+BEGIN;
+
+CREATE TYPE team_sync_trigger AS ENUM (
+    'change',
+    'manual',
+    'schedule',
+    'retry'
+);
+
+ALTER TABLE team_sync_queue
+ADD COLUMN triggered_by team_sync_trigger DEFAULT 'change' NOT NULL;
+
+CREATE TYPE team_sync_run_status AS ENUM (
+    'in_progress',
+    'success',
+    'failure',
+    'canceled'
+);
+
+CREATE TYPE team_sync_reconciler_status AS ENUM (
+    'success',
+    'failure',
+    'timeout',
+    'skipped',
+    'canceled'
+);
+
+CREATE TABLE team_sync_runs (
+    id BIGSERIAL,
+    team_slug text NOT NULL,
+    correlation_id uuid NOT NULL,
+    triggered_by team_sync_trigger NOT NULL,
+    reconcilers text[] NOT NULL DEFAULT '{}',
+    started_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    finished_at timestamp with time zone,
+    status team_sync_run_status DEFAULT 'in_progress' NOT NULL,
+    error_message text,
+    PRIMARY KEY(id)
+);
+
+CREATE INDEX ON team_sync_runs USING btree (team_slug, started_at DESC, id DESC);
+
+ALTER TABLE team_sync_runs
+ADD FOREIGN KEY (team_slug) REFERENCES teams(slug) ON DELETE CASCADE;
+
+CREATE TABLE team_sync_run_reconcilers (
+    run_id bigint NOT NULL,
+    reconciler reconciler_name NOT NULL,
+    status team_sync_reconciler_status NOT NULL,
+    started_at timestamp with time zone NOT NULL,
+    finished_at timestamp with time zone NOT NULL,
+    error_message text,
+    PRIMARY KEY(run_id, reconciler)
+);
+
+ALTER TABLE team_sync_run_reconcilers
+ADD FOREIGN KEY (run_id) REFERENCES team_sync_runs(id) ON DELETE CASCADE;
+
+COMMIT;
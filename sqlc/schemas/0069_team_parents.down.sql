@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE teams
+DROP COLUMN parent_team_slug;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE teams
+ADD COLUMN parent_team_slug text,
+ADD CONSTRAINT teams_parent_team_slug_check CHECK (parent_team_slug <> slug);
+
+ALTER TABLE teams
+ADD FOREIGN KEY (parent_team_slug) REFERENCES teams(slug) ON DELETE SET NULL;
+
+CREATE INDEX ON teams USING btree (parent_team_slug);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE teams
+DROP COLUMN resources_suspended,
+DROP COLUMN enabled;
+
+COMMIT;
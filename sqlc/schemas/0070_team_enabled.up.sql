@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE teams
+ADD COLUMN enabled boolean DEFAULT true NOT NULL,
+ADD COLUMN resources_suspended boolean DEFAULT false NOT NULL;
+
+COMMIT;
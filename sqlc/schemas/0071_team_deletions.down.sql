@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE team_deletion_reconcilers;
+DROP TABLE team_deletions;
+DROP TYPE team_deletion_reconciler_status;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+CREATE TYPE team_deletion_reconciler_status AS ENUM (
+    'success',
+    'failure'
+);
+
+CREATE TABLE team_deletions (
+    team_slug text NOT NULL,
+    correlation_id uuid NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    delete_at timestamp with time zone NOT NULL,
+    started_at timestamp with time zone,
+    PRIMARY KEY(team_slug)
+);
+
+CREATE INDEX ON team_deletions USING btree (delete_at);
+
+ALTER TABLE team_deletions
+ADD FOREIGN KEY (team_slug) REFERENCES teams(slug) ON DELETE CASCADE;
+
+CREATE TABLE team_deletion_reconcilers (
+    team_slug text NOT NULL,
+    reconciler reconciler_name NOT NULL,
+    status team_deletion_reconciler_status NOT NULL,
+    finished_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    error_message text,
+    PRIMARY KEY(team_slug, reconciler)
+);
+
+ALTER TABLE team_deletion_reconcilers
+ADD FOREIGN KEY (team_slug) REFERENCES team_deletions(team_slug) ON DELETE CASCADE;
+
+COMMIT;
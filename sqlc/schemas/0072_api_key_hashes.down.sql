@@ -0,0 +1,18 @@
+BEGIN;
+
+-- the plaintext keys can not be restored from the hashes
+DELETE FROM api_keys;
+
+ALTER TABLE api_keys
+DROP CONSTRAINT api_keys_pkey,
+DROP COLUMN id,
+DROP COLUMN prefix,
+DROP COLUMN salt,
+DROP COLUMN hash,
+DROP COLUMN created_at,
+DROP COLUMN expires_at,
+DROP COLUMN last_used_at,
+ADD COLUMN api_key text NOT NULL,
+ADD PRIMARY KEY(api_key);
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+ALTER TABLE api_keys
+ADD COLUMN id uuid DEFAULT gen_random_uuid() NOT NULL,
+ADD COLUMN prefix text,
+ADD COLUMN salt bytea,
+ADD COLUMN hash bytea,
+ADD COLUMN created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+ADD COLUMN expires_at timestamp with time zone,
+ADD COLUMN last_used_at timestamp with time zone;
+
+-- hash the existing keys, see db.APIKeyPrefix and db.APIKeyHash
+UPDATE api_keys
+SET prefix = CASE WHEN length(api_key) < 16 THEN '' ELSE left(api_key, 8) END,
+    salt = uuid_send(gen_random_uuid());
+
+UPDATE api_keys
+SET hash = sha256(salt || convert_to(api_key, 'UTF8'));
+
+ALTER TABLE api_keys
+DROP CONSTRAINT api_keys_pkey,
+DROP COLUMN api_key,
+ALTER COLUMN prefix SET NOT NULL,
+ALTER COLUMN salt SET NOT NULL,
+ALTER COLUMN hash SET NOT NULL,
+ADD PRIMARY KEY(id);
+
+CREATE INDEX ON api_keys USING btree (prefix);
+CREATE INDEX ON api_keys USING btree (service_account_id);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DELETE FROM service_accounts
+WHERE team_slug IS NOT NULL;
+
+ALTER TABLE service_accounts
+DROP COLUMN team_slug;
+
+COMMIT;
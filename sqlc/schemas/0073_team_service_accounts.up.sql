@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE service_accounts
+ADD COLUMN team_slug text;
+
+ALTER TABLE service_accounts
+ADD FOREIGN KEY (team_slug) REFERENCES teams(slug) ON DELETE CASCADE;
+
+CREATE INDEX ON service_accounts USING btree (team_slug);
+
+COMMIT;
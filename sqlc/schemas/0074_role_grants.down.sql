@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE service_account_roles
+DROP COLUMN granted_at,
+DROP COLUMN granted_by;
+
+ALTER TABLE user_roles
+DROP COLUMN granted_at,
+DROP COLUMN granted_by;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+-- the grant of existing roles is unknown, so the columns are only set for new roles
+ALTER TABLE user_roles
+ADD COLUMN granted_by text,
+ADD COLUMN granted_at timestamp with time zone;
+
+ALTER TABLE user_roles
+ALTER COLUMN granted_at SET DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE service_account_roles
+ADD COLUMN granted_by text,
+ADD COLUMN granted_at timestamp with time zone;
+
+ALTER TABLE service_account_roles
+ALTER COLUMN granted_at SET DEFAULT CURRENT_TIMESTAMP;
+
+COMMIT;
@@ -0,0 +1,30 @@
+-- custom roles, and all assignments of them, are lost when converting back
+BEGIN;
+
+CREATE TYPE role_name AS ENUM (
+    'Admin',
+    'Deploy key viewer',
+    'Service account creator',
+    'Service account owner',
+    'Synchronizer',
+    'Team creator',
+    'Team member',
+    'Team owner',
+    'Team viewer',
+    'User admin',
+    'User viewer'
+);
+
+DELETE FROM user_roles WHERE role_name IN (SELECT name FROM roles WHERE NOT built_in);
+DELETE FROM service_account_roles WHERE role_name IN (SELECT name FROM roles WHERE NOT built_in);
+
+ALTER TABLE user_roles DROP CONSTRAINT user_roles_role_name_fkey;
+ALTER TABLE service_account_roles DROP CONSTRAINT service_account_roles_role_name_fkey;
+
+ALTER TABLE user_roles ALTER COLUMN role_name TYPE role_name USING role_name::role_name;
+ALTER TABLE service_account_roles ALTER COLUMN role_name TYPE role_name USING role_name::role_name;
+
+DROP TABLE role_authorizations;
+DROP TABLE roles;
+
+COMMIT;
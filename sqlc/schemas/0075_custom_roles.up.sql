@@ -0,0 +1,91 @@
+BEGIN;
+
+CREATE TABLE roles (
+    name text PRIMARY KEY,
+    description text NOT NULL DEFAULT '',
+    built_in boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE role_authorizations (
+    role_name text NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    authorization_name text NOT NULL,
+    PRIMARY KEY (role_name, authorization_name)
+);
+
+-- built-in roles can not be changed or deleted through the API
+INSERT INTO roles (name, built_in) VALUES
+    ('Admin', true),
+    ('Deploy key viewer', true),
+    ('Service account creator', true),
+    ('Service account owner', true),
+    ('Synchronizer', true),
+    ('Team creator', true),
+    ('Team member', true),
+    ('Team owner', true),
+    ('Team viewer', true),
+    ('User admin', true),
+    ('User viewer', true);
+
+INSERT INTO role_authorizations (role_name, authorization_name) VALUES
+    ('Admin', 'audit_logs:read'),
+    ('Admin', 'service_accounts:create'),
+    ('Admin', 'service_accounts:delete'),
+    ('Admin', 'service_accounts:list'),
+    ('Admin', 'service_accounts:read'),
+    ('Admin', 'service_accounts:update'),
+    ('Admin', 'system_states:delete'),
+    ('Admin', 'system_states:read'),
+    ('Admin', 'system_states:update'),
+    ('Admin', 'teams:create'),
+    ('Admin', 'teams:delete'),
+    ('Admin', 'teams:list'),
+    ('Admin', 'teams:read'),
+    ('Admin', 'teams:update'),
+    ('Admin', 'users:list'),
+    ('Admin', 'users:update'),
+    ('Admin', 'teams:synchronize'),
+    ('Admin', 'usersync:synchronize'),
+    ('Admin', 'deploy_key:view'),
+    ('Service account creator', 'service_accounts:create'),
+    ('Service account owner', 'service_accounts:delete'),
+    ('Service account owner', 'service_accounts:read'),
+    ('Service account owner', 'service_accounts:update'),
+    ('Team creator', 'teams:create'),
+    ('Team member', 'audit_logs:read'),
+    ('Team member', 'service_accounts:read'),
+    ('Team member', 'teams:read'),
+    ('Team member', 'deploy_key:view'),
+    ('Team member', 'teams:synchronize'),
+    ('Team owner', 'audit_logs:read'),
+    ('Team owner', 'service_accounts:create'),
+    ('Team owner', 'service_accounts:delete'),
+    ('Team owner', 'service_accounts:read'),
+    ('Team owner', 'service_accounts:update'),
+    ('Team owner', 'teams:delete'),
+    ('Team owner', 'teams:read'),
+    ('Team owner', 'teams:update'),
+    ('Team owner', 'teams:synchronize'),
+    ('Team owner', 'deploy_key:view'),
+    ('Team viewer', 'audit_logs:read'),
+    ('Team viewer', 'teams:list'),
+    ('Team viewer', 'teams:read'),
+    ('User admin', 'users:list'),
+    ('User admin', 'users:update'),
+    ('User viewer', 'users:list'),
+    ('Synchronizer', 'teams:synchronize'),
+    ('Synchronizer', 'usersync:synchronize'),
+    ('Deploy key viewer', 'deploy_key:view');
+
+ALTER TABLE user_roles ALTER COLUMN role_name TYPE text;
+ALTER TABLE service_account_roles ALTER COLUMN role_name TYPE text;
+
+ALTER TABLE user_roles
+ADD FOREIGN KEY (role_name) REFERENCES roles(name) ON DELETE CASCADE;
+
+ALTER TABLE service_account_roles
+ADD FOREIGN KEY (role_name) REFERENCES roles(name) ON DELETE CASCADE;
+
+DROP TYPE role_name;
+
+COMMIT;